@@ -34,11 +34,43 @@ type GetRunImagesRequest []struct {
 // GetRunImagesBatchRequest is a request object for `POST /runs/images/get-batch` endpoint.
 type GetRunImagesBatchRequest []string
 
+// GetRunArtifactsRequest is a request object for `POST /runs/:id/{texts,audios,distributions,figures}/get-batch`
+// endpoints. Traces are provided in the request body, ranges and densities in the query.
+type GetRunArtifactsRequest struct {
+	Traces        []GetRunArtifactsTraceRequest
+	RecordRange   string `query:"record_range"`
+	IndexRange    string `query:"index_range"`
+	RecordDensity int    `query:"record_density"`
+	IndexDensity  int    `query:"index_density"`
+}
+
+// GetRunArtifactsTraceRequest is a partial request object for GetRunArtifactsRequest.
+type GetRunArtifactsTraceRequest struct {
+	Name    string            `json:"name"`
+	Context map[string]string `json:"context"`
+}
+
 // GetRunsActiveRequest is a request object for `GET /runs/active` endpoint.
 type GetRunsActiveRequest struct {
 	BaseSearchRequest
 }
 
+// GetRunsLiveRequest is a request object for `GET /runs/live` endpoint.
+type GetRunsLiveRequest struct {
+	Runs string `query:"runs"`
+}
+
+// RunIDs returns comma separated list of the runs as a slice. Empty slice means all the runs.
+func (r GetRunsLiveRequest) RunIDs() []string {
+	var runIDs []string
+	for _, runID := range strings.Split(r.Runs, ",") {
+		if runID = strings.TrimSpace(runID); runID != "" {
+			runIDs = append(runIDs, runID)
+		}
+	}
+	return runIDs
+}
+
 // UpdateRunRequest is a request struct for `PUT /runs/:id` endpoint.
 type UpdateRunRequest struct {
 	ID          string  `params:"id"`
@@ -52,8 +84,41 @@ type UpdateRunRequest struct {
 }
 
 // GetRunLogsRequest is a request struct for `GET /runs/:id/logs` endpoint.
+// Since is a unix timestamp (seconds), AfterID continues reading after the last log of the previous page,
+// Tail returns only last lines and Follow keeps the stream open and sends new lines while the run is active.
 type GetRunLogsRequest struct {
-	ID string `params:"id"`
+	ID      string `params:"id"`
+	Since   int64  `query:"since"`
+	AfterID uint   `query:"after_id"`
+	Limit   int    `query:"limit"`
+	Tail    int    `query:"tail"`
+	Follow  bool   `query:"follow"`
+}
+
+// SearchLogsRequest is a request struct for `GET /runs/logs/search` endpoint.
+type SearchLogsRequest struct {
+	Query        string `query:"q"`
+	Regex        bool   `query:"regex"`
+	RunID        string `query:"run_id"`
+	ExperimentID *int32 `query:"experiment_id"`
+	Since        int64  `query:"since"`
+	Limit        int    `query:"limit"`
+}
+
+// CompareRunsRequest is a request struct for `POST /runs/compare` endpoint.
+type CompareRunsRequest struct {
+	RunIDs    []string `json:"run_ids"`
+	Metrics   []string `json:"metrics"`
+	History   bool     `json:"history"`
+	MaxPoints int      `json:"max_points"`
+	Sampling  string   `json:"sampling"`
+}
+
+// GroupRunsRequest is a request struct for `POST /runs/group` endpoint.
+type GroupRunsRequest struct {
+	GroupBy       string   `json:"group_by"`
+	ExperimentIDs []int32  `json:"experiment_ids"`
+	Metrics       []string `json:"metrics"`
 }
 
 // SearchRunsRequest is a request object for `GET /runs/search/run` endpoint.
@@ -83,6 +148,8 @@ type SearchMetricsRequest struct {
 	Steps      int           `json:"steps"`
 	XAxis      string        `json:"x_axis"`
 	SkipSystem bool          `json:"skip_system"`
+	MaxPoints  int           `json:"max_points"`
+	Sampling   string        `json:"sampling"`
 }
 
 // SearchAlignedMetricsRequest is a request struct for `GET /runs/search/metric/align` endpoint.
@@ -153,6 +220,42 @@ func (req SearchArtifactsRequest) IndexRangeMax(dflt int) int {
 	return rangeMax(req.IndexRange, dflt)
 }
 
+// RecordRangeMin returns the low end of the record range.
+func (req GetRunArtifactsRequest) RecordRangeMin() int {
+	return rangeMin(req.RecordRange)
+}
+
+// RecordRangeMax returns the high end of the record range.
+func (req GetRunArtifactsRequest) RecordRangeMax(dflt int) int {
+	return rangeMax(req.RecordRange, dflt)
+}
+
+// IndexRangeMin returns the low end of the index range.
+func (req GetRunArtifactsRequest) IndexRangeMin() int {
+	return rangeMin(req.IndexRange)
+}
+
+// IndexRangeMax returns the high end of the index range.
+func (req GetRunArtifactsRequest) IndexRangeMax(dflt int) int {
+	return rangeMax(req.IndexRange, dflt)
+}
+
+// StepCount returns the RecordDensity requested or -1 if not limited.
+func (req GetRunArtifactsRequest) StepCount() int {
+	if req.RecordDensity < 1 {
+		return -1
+	}
+	return req.RecordDensity
+}
+
+// ItemsPerStep returns the IndexDensity requested or -1 if not limited.
+func (req GetRunArtifactsRequest) ItemsPerStep() int {
+	if req.IndexDensity < 1 {
+		return -1
+	}
+	return req.IndexDensity
+}
+
 // StepCount returns the RecordDensity requested or -1 if not limited.
 func (req SearchArtifactsRequest) StepCount() int {
 	switch v := req.RecordDensity.(type) {
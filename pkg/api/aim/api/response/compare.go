@@ -0,0 +1,212 @@
+package response
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/aim/dao/models"
+)
+
+// CompareRunsRunPartial is a partial response object for CompareRunsResponse.
+type CompareRunsRunPartial struct {
+	ID         string                      `json:"id"`
+	Name       string                      `json:"name"`
+	Experiment GetRunInfoExperimentPartial `json:"experiment"`
+}
+
+// CompareRunsValuesPartial represents values of a param or a tag aligned with the runs.
+// Values of the runs which don't have the key are null.
+type CompareRunsValuesPartial struct {
+	Key       string `json:"key"`
+	Values    []any  `json:"values"`
+	Different bool   `json:"different"`
+}
+
+// CompareRunsMetricHistoryPartial represents metric values of the runs aligned on step.
+// Values[i][j] is the value of i-th run at Steps[j] or null, if the run has no value at the step.
+type CompareRunsMetricHistoryPartial struct {
+	Steps  []int64      `json:"steps"`
+	Values [][]*float64 `json:"values"`
+}
+
+// CompareRunsMetricPartial represents summary of a metric shared by all the compared runs.
+// NaN and infinite values are returned as null.
+type CompareRunsMetricPartial struct {
+	Name      string                           `json:"name"`
+	Context   json.RawMessage                  `json:"context"`
+	Latest    []*float64                       `json:"latest"`
+	Min       []*float64                       `json:"min"`
+	Max       []*float64                       `json:"max"`
+	Different bool                             `json:"different"`
+	History   *CompareRunsMetricHistoryPartial `json:"history,omitempty"`
+}
+
+// CompareRunsResponse represents the response object for `POST /runs/compare` endpoint.
+type CompareRunsResponse struct {
+	Runs    []CompareRunsRunPartial    `json:"runs"`
+	Params  []CompareRunsValuesPartial `json:"params"`
+	Tags    []CompareRunsValuesPartial `json:"tags"`
+	Metrics []CompareRunsMetricPartial `json:"metrics"`
+}
+
+// NewCompareRunsResponse creates new response object for `POST /runs/compare` endpoint.
+func NewCompareRunsResponse(
+	req *request.CompareRunsRequest,
+	runs []models.Run,
+	summaries []models.MetricSummary,
+	histories []models.Metric,
+) *CompareRunsResponse {
+	resp := CompareRunsResponse{
+		Runs:    make([]CompareRunsRunPartial, len(runs)),
+		Params:  []CompareRunsValuesPartial{},
+		Tags:    []CompareRunsValuesPartial{},
+		Metrics: []CompareRunsMetricPartial{},
+	}
+
+	// 1. align params and tags by their keys.
+	params := map[string][]any{}
+	tags := map[string][]any{}
+	for i, run := range runs {
+		resp.Runs[i] = CompareRunsRunPartial{
+			ID:   run.ID,
+			Name: run.Name,
+			Experiment: GetRunInfoExperimentPartial{
+				ID:   fmt.Sprintf("%d", *run.Experiment.ID),
+				Name: run.Experiment.Name,
+			},
+		}
+		for _, param := range run.Params {
+			if _, ok := params[param.Key]; !ok {
+				params[param.Key] = make([]any, len(runs))
+			}
+			params[param.Key][i] = param.ValueAny()
+		}
+		for _, tag := range run.Tags {
+			if _, ok := tags[tag.Key]; !ok {
+				tags[tag.Key] = make([]any, len(runs))
+			}
+			tags[tag.Key][i] = tag.Value
+		}
+	}
+	resp.Params = newCompareRunsValuesPartials(params)
+	resp.Tags = newCompareRunsValuesPartials(tags)
+
+	// 2. summarize metrics shared by all the runs.
+	type metricKey struct {
+		runID     string
+		key       string
+		contextID uint
+	}
+	summariesMap := make(map[metricKey]models.MetricSummary, len(summaries))
+	for _, summary := range summaries {
+		summariesMap[metricKey{summary.RunID, summary.Key, summary.ContextID}] = summary
+	}
+	for _, shared := range models.GetSharedLatestMetrics(runs) {
+		if len(req.Metrics) > 0 && !slices.Contains(req.Metrics, shared.Key) {
+			continue
+		}
+		metric := CompareRunsMetricPartial{
+			Name:    shared.Key,
+			Context: json.RawMessage(shared.Context.Json),
+			Latest:  make([]*float64, len(runs)),
+			Min:     make([]*float64, len(runs)),
+			Max:     make([]*float64, len(runs)),
+		}
+		for i, run := range runs {
+			for _, latest := range run.LatestMetrics {
+				if latest.Key == shared.Key && latest.ContextID == shared.ContextID {
+					metric.Latest[i] = newCompareRunsValue(latest.Value, latest.IsNan)
+				}
+			}
+			if summary, ok := summariesMap[metricKey{run.ID, shared.Key, shared.ContextID}]; ok {
+				metric.Min[i] = newCompareRunsValue(summary.Min, false)
+				metric.Max[i] = newCompareRunsValue(summary.Max, false)
+			}
+			if i > 0 && !isCompareRunsValueEqual(metric.Latest[0], metric.Latest[i]) {
+				metric.Different = true
+			}
+		}
+		if req.History {
+			metric.History = newCompareRunsMetricHistoryPartial(runs, shared, histories)
+		}
+		resp.Metrics = append(resp.Metrics, metric)
+	}
+
+	return &resp
+}
+
+// newCompareRunsValuesPartials converts aligned values into partials sorted by key.
+func newCompareRunsValuesPartials(values map[string][]any) []CompareRunsValuesPartial {
+	partials := make([]CompareRunsValuesPartial, 0, len(values))
+	for key, keyValues := range values {
+		partial := CompareRunsValuesPartial{Key: key, Values: keyValues}
+		for _, value := range keyValues[1:] {
+			if value != keyValues[0] {
+				partial.Different = true
+			}
+		}
+		partials = append(partials, partial)
+	}
+	slices.SortFunc(partials, func(a, b CompareRunsValuesPartial) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+	return partials
+}
+
+// newCompareRunsMetricHistoryPartial aligns the histories of the metric on step.
+// When a run logged several values at the same step, the last one is used.
+func newCompareRunsMetricHistoryPartial(
+	runs []models.Run, shared models.LatestMetric, histories []models.Metric,
+) *CompareRunsMetricHistoryPartial {
+	runIndexes := make(map[string]int, len(runs))
+	for i, run := range runs {
+		runIndexes[run.ID] = i
+	}
+
+	// histories are ordered by step, so steps are collected in ascending order.
+	history := CompareRunsMetricHistoryPartial{
+		Steps:  []int64{},
+		Values: make([][]*float64, len(runs)),
+	}
+	for _, metric := range histories {
+		if metric.Key != shared.Key || metric.ContextID != shared.ContextID {
+			continue
+		}
+		if len(history.Steps) == 0 || history.Steps[len(history.Steps)-1] != metric.Step {
+			history.Steps = append(history.Steps, metric.Step)
+			for i := range history.Values {
+				history.Values[i] = append(history.Values[i], nil)
+			}
+		}
+		history.Values[runIndexes[metric.RunID]][len(history.Steps)-1] = newCompareRunsValue(
+			metric.Value, metric.IsNan,
+		)
+	}
+	for i := range history.Values {
+		if history.Values[i] == nil {
+			history.Values[i] = []*float64{}
+		}
+	}
+	return &history
+}
+
+// newCompareRunsValue returns pointer to the metric value, or nil for NaN and infinite values,
+// which can't be represented in JSON.
+func newCompareRunsValue(value float64, isNan bool) *float64 {
+	if isNan || math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil
+	}
+	return &value
+}
+
+// isCompareRunsValueEqual checks that both metric values are equal or are both missing.
+func isCompareRunsValueEqual(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,100 @@
+package response
+
+import (
+	"cmp"
+	"encoding/json"
+	"slices"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/aim/dao/models"
+)
+
+// GroupRunsMetricPartial represents statistics of the metric logged by the runs of the group.
+// Min, Max and Mean are calculated over all the values of the metric, LatestMean is the mean
+// of the latest values. NaN and infinite values are returned as null.
+type GroupRunsMetricPartial struct {
+	Name       string          `json:"name"`
+	Context    json.RawMessage `json:"context"`
+	Min        *float64        `json:"min"`
+	Max        *float64        `json:"max"`
+	Mean       *float64        `json:"mean"`
+	LatestMean *float64        `json:"latest_mean"`
+	RunCount   int64           `json:"run_count"`
+}
+
+// GroupRunsGroupPartial represents runs with the same value of the param or tag.
+// Value is null for the runs, which don't have the param or tag.
+type GroupRunsGroupPartial struct {
+	Value    any                      `json:"value"`
+	RunCount int64                    `json:"run_count"`
+	Metrics  []GroupRunsMetricPartial `json:"metrics"`
+}
+
+// GroupRunsResponse represents the response object for `POST /runs/group` endpoint.
+type GroupRunsResponse struct {
+	GroupBy string                  `json:"group_by"`
+	Groups  []GroupRunsGroupPartial `json:"groups"`
+}
+
+// NewGroupRunsResponse creates new response object for `POST /runs/group` endpoint.
+// Groups are sorted by number of runs in descending order.
+func NewGroupRunsResponse(
+	req *request.GroupRunsRequest, groups []models.RunGroup, metrics []models.RunGroupMetric,
+) *GroupRunsResponse {
+	groups = slices.Clone(groups)
+	slices.SortFunc(groups, func(a, b models.RunGroup) int {
+		if a.RunCount != b.RunCount {
+			return cmp.Compare(b.RunCount, a.RunCount)
+		}
+		return cmp.Compare(a.UniqueKey(), b.UniqueKey())
+	})
+	resp := GroupRunsResponse{
+		GroupBy: req.GroupBy,
+		Groups:  make([]GroupRunsGroupPartial, len(groups)),
+	}
+
+	groupIndexes := make(map[string]int, len(groups))
+	for i, group := range groups {
+		groupIndexes[group.UniqueKey()] = i
+		resp.Groups[i] = GroupRunsGroupPartial{
+			Value:    group.ValueAny(),
+			RunCount: group.RunCount,
+			Metrics:  []GroupRunsMetricPartial{},
+		}
+	}
+
+	for _, metric := range metrics {
+		i, ok := groupIndexes[metric.UniqueKey()]
+		if !ok {
+			continue
+		}
+		partial := GroupRunsMetricPartial{
+			Name:     metric.Key,
+			Context:  json.RawMessage(metric.Context),
+			RunCount: metric.RunCount,
+		}
+		if metric.Min != nil {
+			partial.Min = newCompareRunsValue(*metric.Min, false)
+		}
+		if metric.Max != nil {
+			partial.Max = newCompareRunsValue(*metric.Max, false)
+		}
+		if metric.Count > 0 {
+			partial.Mean = newCompareRunsValue(metric.Sum/float64(metric.Count), false)
+		}
+		if metric.LatestMean != nil {
+			partial.LatestMean = newCompareRunsValue(*metric.LatestMean, false)
+		}
+		resp.Groups[i].Metrics = append(resp.Groups[i].Metrics, partial)
+	}
+
+	for _, group := range resp.Groups {
+		slices.SortFunc(group.Metrics, func(a, b GroupRunsMetricPartial) int {
+			if a.Name != b.Name {
+				return cmp.Compare(a.Name, b.Name)
+			}
+			return cmp.Compare(string(a.Context), string(b.Context))
+		})
+	}
+	return &resp
+}
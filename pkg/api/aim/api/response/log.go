@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"database/sql"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -15,8 +16,14 @@ import (
 )
 
 // NewGetRunLogsResponse creates a new response object for `GET /runs/:id/logs` endpoint.
+// Logs are keyed by their line number in the run, lineOffset is the number of run logs preceding
+// the first streamed one. When follow is provided, stream stays open and new logs are sent as they come.
 func NewGetRunLogsResponse(
-	ctx *fiber.Ctx, rows *sql.Rows, next func(*sql.Rows) (*models.Log, error),
+	ctx *fiber.Ctx,
+	rows *sql.Rows,
+	next func(*sql.Rows) (*models.Log, error),
+	lineOffset int64,
+	follow func(afterID uint, callback func([]models.Log) error) error,
 ) {
 	ctx.Set("Content-Type", "application/octet-stream")
 	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
@@ -36,7 +43,7 @@ func NewGetRunLogsResponse(
 		start := time.Now()
 		if err := func() error {
 			data := fiber.Map{}
-			count, batchSize := 1, 500
+			count, batchSize, lastID := lineOffset+1, int64(500), uint(0)
 			for rows.Next() {
 				runLog, err := next(rows)
 				if err != nil {
@@ -49,16 +56,56 @@ func NewGetRunLogsResponse(
 					}
 					data = fiber.Map{}
 				}
+				lastID = runLog.ID
 				count++
 			}
 
 			if err := flush(w, data); err != nil {
 				return err
 			}
-			return nil
+			//nolint:errcheck,gosec
+			rows.Close()
+
+			if follow == nil {
+				return nil
+			}
+			return follow(lastID, func(logs []models.Log) error {
+				data := make(fiber.Map, len(logs))
+				for _, runLog := range logs {
+					data[strconv.FormatInt(count, 10)] = runLog.Value
+					count++
+				}
+				return flush(w, data)
+			})
 		}(); err != nil {
 			log.Errorf("error encountered in %s %s: error streaming run logs: %s", ctx.Method(), ctx.Path(), err)
 		}
 		log.Infof("body - %s %s %s", time.Since(start), ctx.Method(), ctx.Path())
 	})
 }
+
+// SearchLogsResponse represents the response object for `GET /runs/logs/search` endpoint.
+type SearchLogsResponse struct {
+	RunID          string `json:"run_id"`
+	RunName        string `json:"run_name"`
+	ExperimentID   string `json:"experiment_id"`
+	ExperimentName string `json:"experiment_name"`
+	Timestamp      int64  `json:"timestamp"`
+	Value          string `json:"value"`
+}
+
+// NewSearchLogsResponse creates new response object for `GET /runs/logs/search` endpoint.
+func NewSearchLogsResponse(logs []models.LogSearchResult) []SearchLogsResponse {
+	resp := make([]SearchLogsResponse, len(logs))
+	for i, runLog := range logs {
+		resp[i] = SearchLogsResponse{
+			RunID:          runLog.RunID,
+			RunName:        runLog.RunName,
+			ExperimentID:   strconv.Itoa(int(runLog.ExperimentID)),
+			ExperimentName: runLog.ExperimentName,
+			Timestamp:      runLog.Timestamp,
+			Value:          runLog.Value,
+		}
+	}
+	return resp
+}
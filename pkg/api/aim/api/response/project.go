@@ -107,6 +107,23 @@ func NewProjectParamsResponse(projectParams *models.ProjectParams,
 		images[imageName] = []fiber.Map{}
 	}
 
+	// process other artifact sequences
+	artifactSequences := make(map[models.ArtifactType]fiber.Map, len(projectParams.Sequences))
+	for artifactType, names := range projectParams.Sequences {
+		sequence := make(fiber.Map, len(names))
+		for _, name := range names {
+			sequence[name] = []fiber.Map{}
+		}
+		artifactSequences[artifactType] = sequence
+	}
+	getSequence := func(artifactType models.ArtifactType) *fiber.Map {
+		sequence, ok := artifactSequences[artifactType]
+		if !ok {
+			sequence = fiber.Map{}
+		}
+		return &sequence
+	}
+
 	rsp := ProjectParamsResponse{}
 	if !excludeParams {
 		rsp.Params = &params
@@ -126,13 +143,13 @@ func NewProjectParamsResponse(projectParams *models.ProjectParams,
 		case "images":
 			rsp.Images = &images
 		case "texts":
-			rsp.Texts = &fiber.Map{}
+			rsp.Texts = getSequence(models.ArtifactTypeTexts)
 		case "figures":
-			rsp.Figures = &fiber.Map{}
+			rsp.Figures = getSequence(models.ArtifactTypeFigures)
 		case "distributions":
-			rsp.Distributions = &fiber.Map{}
+			rsp.Distributions = getSequence(models.ArtifactTypeDistributions)
 		case "audios":
-			rsp.Audios = &fiber.Map{}
+			rsp.Audios = getSequence(models.ArtifactTypeAudios)
 		case "metric":
 			rsp.Metric = &metrics
 		}
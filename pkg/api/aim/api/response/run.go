@@ -3,6 +3,7 @@ package response
 import (
 	"bufio"
 	"database/sql"
+	"encoding/binary"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
@@ -23,7 +24,10 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/aim/encoding"
 	mlflowCommon "github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
 	"github.com/G-Research/fasttrackml/pkg/common"
+	"github.com/G-Research/fasttrackml/pkg/common/events"
+	"github.com/G-Research/fasttrackml/pkg/common/sampling"
 	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/common/services/live"
 	"github.com/G-Research/fasttrackml/pkg/database"
 )
 
@@ -399,6 +403,18 @@ func NewStreamMetricsResponse(ctx *fiber.Ctx, rows *sql.Rows, totalRuns int64,
 			}
 			addMetrics := func() {
 				if key != "" {
+					// LTTB algorithm can't be expressed in the query and min/max buckets of the query are
+					// approximate, so the final downsampling is applied to already pre-sampled points.
+					if req.MaxPoints > 0 && len(values) > req.MaxPoints {
+						indices := sampling.Downsample(sampling.GetMode(req.Sampling), iters, values, req.MaxPoints)
+						values = sampling.Pick(values, indices)
+						iters = sampling.Pick(iters, indices)
+						epochs = sampling.Pick(epochs, indices)
+						timestamps = sampling.Pick(timestamps, indices)
+						if xAxis {
+							xAxisValues = sampling.Pick(xAxisValues, indices)
+						}
+					}
 					metric := fiber.Map{
 						"name":          key,
 						"context":       context,
@@ -518,7 +534,7 @@ func NewStreamMetricsResponse(ctx *fiber.Ctx, rows *sql.Rows, totalRuns int64,
 //
 //nolint:gocyclo
 func NewStreamArtifactsResponse(ctx *fiber.Ctx, rows *sql.Rows, runs map[string]models.Run,
-	summary repositories.ArtifactSearchSummary, req request.SearchArtifactsRequest,
+	summary repositories.ArtifactSearchSummary, artifactType models.ArtifactType, req request.SearchArtifactsRequest,
 ) {
 	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
 		//nolint:errcheck
@@ -546,7 +562,7 @@ func NewStreamArtifactsResponse(ctx *fiber.Ctx, rows *sql.Rows, runs map[string]
 				cur++
 				return w.Flush()
 			}
-			addImage := func(img models.Artifact, run models.Run) {
+			addImage := func(img models.Artifact, run models.Run) error {
 				maxIndex := summary.MaxIndex(img.RunID, img.Name)
 				maxStep := summary.MaxStep(img.RunID, img.Name)
 				if runData == nil {
@@ -558,7 +574,7 @@ func NewStreamArtifactsResponse(ctx *fiber.Ctx, rows *sql.Rows, runs map[string]
 							"index_range_used":   []int{req.IndexRangeMin(), req.IndexRangeMax(maxIndex)},
 						},
 						"params": fiber.Map{
-							"images_per_step": maxIndex,
+							fmt.Sprintf("%s_per_step", artifactType): maxIndex,
 						},
 						"props": renderProps(run),
 					}
@@ -583,15 +599,9 @@ func NewStreamArtifactsResponse(ctx *fiber.Ctx, rows *sql.Rows, runs map[string]
 				if !ok {
 					iters = make([]int64, maxStep+1)
 				}
-				value := fiber.Map{
-					"blob_uri": img.BlobURI,
-					"caption":  img.Caption,
-					"height":   img.Height,
-					"width":    img.Width,
-					"format":   img.Format,
-					"iter":     img.Iter,
-					"index":    img.Index,
-					"step":     img.Step,
+				value, err := renderArtifactValue(img)
+				if err != nil {
+					return err
 				}
 
 				stepImages := traceValues[img.Step]
@@ -604,6 +614,7 @@ func NewStreamArtifactsResponse(ctx *fiber.Ctx, rows *sql.Rows, runs map[string]
 				trace["values"] = traceValues
 				trace["iters"] = iters
 				tracesMap[img.Name] = trace
+				return nil
 			}
 			selectTraces := func() {
 				// collect the traces for this run, limiting to RecordDensity and IndexDensity.
@@ -619,29 +630,7 @@ func NewStreamArtifactsResponse(ctx *fiber.Ctx, rows *sql.Rows, runs map[string]
 					if !ok {
 						return trace
 					}
-					filteredSteps := [][]fiber.Map{}
-					filteredIters := []int64{}
-					stepInterval := len(steps) / stepCount
-					for stepIndex := 0; stepIndex < len(steps); stepIndex++ {
-						if stepCount == -1 ||
-							len(steps) <= stepCount ||
-							stepIndex%stepInterval == 0 {
-							step := steps[stepIndex]
-							newStep := []fiber.Map{}
-							imgInterval := len(step) / imgCount
-							for imgIndex := 0; imgIndex < len(step); imgIndex++ {
-								if imgCount == -1 ||
-									len(step) <= imgCount ||
-									imgIndex%imgInterval == 0 {
-									newStep = append(newStep, step[imgIndex])
-								}
-							}
-							filteredSteps = append(filteredSteps, newStep)
-							filteredIters = append(filteredIters, iters[stepIndex])
-						}
-					}
-					trace["values"] = filteredSteps
-					trace["iters"] = filteredIters
+					trace["values"], trace["iters"] = selectArtifactValues(steps, iters, stepCount, imgCount)
 					return trace
 				}
 
@@ -683,7 +672,9 @@ func NewStreamArtifactsResponse(ctx *fiber.Ctx, rows *sql.Rows, runs map[string]
 					runID = image.RunID
 					runData = nil
 				}
-				addImage(image, runs[image.RunID])
+				if err := addImage(image, runs[image.RunID]); err != nil {
+					return err
+				}
 				hasRows = true
 			}
 
@@ -698,13 +689,155 @@ func NewStreamArtifactsResponse(ctx *fiber.Ctx, rows *sql.Rows, runs map[string]
 
 			return nil
 		}(); err != nil {
-			log.Errorf("Error encountered in %s %s: error streaming images: %s", ctx.Method(), ctx.Path(), err)
+			log.Errorf(
+				"Error encountered in %s %s: error streaming %s: %s", ctx.Method(), ctx.Path(), artifactType, err,
+			)
 		}
 
 		log.Infof("body - %s %s %s", time.Since(start), ctx.Method(), ctx.Path())
 	})
 }
 
+// NewRunArtifactsStreamResponse streams the provided run artifacts grouped by requested traces to the fiber context.
+func NewRunArtifactsStreamResponse(
+	ctx *fiber.Ctx, artifacts []models.Artifact, req *request.GetRunArtifactsRequest,
+) error {
+	// group artifact values by name, step and index.
+	type trace struct {
+		steps    map[int64][]fiber.Map
+		maxStep  int64
+		maxIndex int64
+	}
+	traces := make(map[string]*trace, len(req.Traces))
+	for _, artifact := range artifacts {
+		value, err := renderArtifactValue(artifact)
+		if err != nil {
+			return eris.Wrap(err, "error rendering artifact value")
+		}
+		t, ok := traces[artifact.Name]
+		if !ok {
+			t = &trace{steps: map[int64][]fiber.Map{}}
+			traces[artifact.Name] = t
+		}
+		t.steps[artifact.Step] = append(t.steps[artifact.Step], value)
+		t.maxStep = max(t.maxStep, artifact.Step)
+		t.maxIndex = max(t.maxIndex, artifact.Index)
+	}
+
+	ctx.Set("Content-Type", "application/octet-stream")
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		start := time.Now()
+		if err := func() error {
+			for _, requested := range req.Traces {
+				t, ok := traces[requested.Name]
+				if !ok {
+					continue
+				}
+				steps, iters := [][]fiber.Map{}, []int64{}
+				for step := int64(0); step <= t.maxStep; step++ {
+					if values, ok := t.steps[step]; ok {
+						steps, iters = append(steps, values), append(iters, step)
+					}
+				}
+				steps, iters = selectArtifactValues(steps, iters, req.StepCount(), req.ItemsPerStep())
+				if err := encoding.EncodeTree(w, fiber.Map{
+					"name":         requested.Name,
+					"context":      requested.Context,
+					"values":       steps,
+					"iters":        iters,
+					"record_range": []int64{0, t.maxStep + 1},
+					"index_range":  []int64{0, t.maxIndex + 1},
+				}); err != nil {
+					return err
+				}
+			}
+			return w.Flush()
+		}(); err != nil {
+			log.Errorf("Error encountered in %s %s: error streaming run artifacts: %s", ctx.Method(), ctx.Path(), err)
+		}
+
+		log.Infof("body - %s %s %s", time.Since(start), ctx.Method(), ctx.Path())
+	})
+	return nil
+}
+
+// selectArtifactValues limits the steps to stepCount and the values of every step to itemCount.
+// Negative limits mean that all the steps or values are selected.
+func selectArtifactValues(
+	steps [][]fiber.Map, iters []int64, stepCount, itemCount int,
+) ([][]fiber.Map, []int64) {
+	filteredSteps := [][]fiber.Map{}
+	filteredIters := []int64{}
+	stepInterval := 1
+	if stepCount > 0 && len(steps) > stepCount {
+		stepInterval = len(steps) / stepCount
+	}
+	for stepIndex := 0; stepIndex < len(steps); stepIndex++ {
+		if stepCount == -1 ||
+			len(steps) <= stepCount ||
+			stepIndex%stepInterval == 0 {
+			step := steps[stepIndex]
+			newStep := []fiber.Map{}
+			itemInterval := 1
+			if itemCount > 0 && len(step) > itemCount {
+				itemInterval = len(step) / itemCount
+			}
+			for itemIndex := 0; itemIndex < len(step); itemIndex++ {
+				if itemCount == -1 ||
+					len(step) <= itemCount ||
+					itemIndex%itemInterval == 0 {
+					newStep = append(newStep, step[itemIndex])
+				}
+			}
+			filteredSteps = append(filteredSteps, newStep)
+			filteredIters = append(filteredIters, iters[stepIndex])
+		}
+	}
+	return filteredSteps, filteredIters
+}
+
+// renderArtifactValue renders the artifact as a sequence value expected by the Aim UI for its type.
+func renderArtifactValue(artifact models.Artifact) (fiber.Map, error) {
+	value := fiber.Map{
+		"iter":  artifact.Iter,
+		"index": artifact.Index,
+		"step":  artifact.Step,
+	}
+	switch artifact.Type {
+	case models.ArtifactTypeTexts:
+		value["data"] = artifact.Text
+	case models.ArtifactTypeDistributions:
+		var distribution models.Distribution
+		if err := json.Unmarshal(artifact.Data, &distribution); err != nil {
+			return nil, eris.Wrap(err, "error unmarshaling distribution")
+		}
+		// weights are transferred as numpy array of float64 values.
+		blob := make([]byte, 0, len(distribution.Weights)*8)
+		for _, weight := range distribution.Weights {
+			blob = binary.LittleEndian.AppendUint64(blob, math.Float64bits(weight))
+		}
+		value["data"] = fiber.Map{
+			"type":  "numpy",
+			"shape": len(distribution.Weights),
+			"dtype": "float64",
+			"blob":  blob,
+		}
+		value["bin_count"] = distribution.BinCount
+		value["range"] = distribution.Range
+	case models.ArtifactTypeAudios, models.ArtifactTypeFigures:
+		value["blob_uri"] = artifact.BlobURI
+		value["caption"] = artifact.Caption
+		value["format"] = artifact.Format
+	default:
+		value["blob_uri"] = artifact.BlobURI
+		value["caption"] = artifact.Caption
+		value["height"] = artifact.Height
+		value["width"] = artifact.Width
+		value["format"] = artifact.Format
+	}
+	return value, nil
+}
+
 // NewRunsSearchCSVResponse formats and sends Runs search response as a CSV file.
 //
 //nolint:gocyclo
@@ -1112,3 +1245,56 @@ func NewRunImagesBatchStreamResponse(ctx *fiber.Ctx, imagesMap map[string]any) e
 	})
 	return nil
 }
+
+// NewRunsLiveStreamResponse streams live updates of the runs as server-sent events. Stream is finished,
+// when client disconnects or subscription is closed, so the client has to reconnect and refresh the data.
+func NewRunsLiveStreamResponse(
+	ctx *fiber.Ctx, subscription *live.Subscription, unsubscribe func(), heartbeatInterval time.Duration,
+) {
+	ctx.Set("Content-Type", "text/event-stream")
+	ctx.Set("Cache-Control", "no-cache")
+	ctx.Set("Connection", "keep-alive")
+	ctx.Set("X-Accel-Buffering", "no")
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+		heartbeat := time.NewTicker(heartbeatInterval)
+		defer heartbeat.Stop()
+
+		// write a comment right away, so the client knows that the stream is open.
+		if err := writeServerSentEvent(w, ": connected\n\n"); err != nil {
+			return
+		}
+		for {
+			select {
+			case event, ok := <-subscription.Events():
+				if !ok {
+					return
+				}
+				eventType := string(event.Type)
+				if event.Action == events.EventActionReset {
+					eventType = events.EventActionReset
+				}
+				data, err := json.Marshal(event)
+				if err != nil {
+					log.Errorf("error serializing live update: %+v", err)
+					return
+				}
+				if err := writeServerSentEvent(w, fmt.Sprintf("event: %s\ndata: %s\n\n", eventType, data)); err != nil {
+					return
+				}
+			case <-heartbeat.C:
+				if err := writeServerSentEvent(w, ": ping\n\n"); err != nil {
+					return
+				}
+			}
+		}
+	})
+}
+
+// writeServerSentEvent writes and flushes single server-sent event.
+func writeServerSentEvent(w *bufio.Writer, event string) error {
+	if _, err := w.WriteString(event); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/aim/services/run"
 	"github.com/G-Research/fasttrackml/pkg/api/aim/services/tag"
 	"github.com/G-Research/fasttrackml/pkg/common/services/artifact"
+	"github.com/G-Research/fasttrackml/pkg/common/services/live"
 )
 
 // Controller handles all the input HTTP requests.
@@ -19,6 +20,7 @@ type Controller struct {
 	projectService    *project.Service
 	dashboardService  *dashboard.Service
 	experimentService *experiment.Service
+	liveService       *live.Service
 }
 
 // NewController creates new Controller instance.
@@ -30,6 +32,7 @@ func NewController(
 	projectService *project.Service,
 	dashboardService *dashboard.Service,
 	experimentService *experiment.Service,
+	liveService *live.Service,
 ) *Controller {
 	return &Controller{
 		tagService:        tagService,
@@ -39,5 +42,6 @@ func NewController(
 		projectService:    projectService,
 		dashboardService:  dashboardService,
 		experimentService: experimentService,
+		liveService:       liveService,
 	}
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/G-Research/fasttrackml/pkg/api/aim/api/request"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/telemetry"
 )
 
 // convertError converts api.ErrorResponse to fiber error.
@@ -29,7 +30,8 @@ func convertImagesToMap(
 
 	for i, image := range images {
 		var buffer bytes.Buffer
-		_, err := io.CopyBuffer(&buffer, image, make([]byte, 4096))
+		bytesWritten, err := io.CopyBuffer(&buffer, image, make([]byte, 4096))
+		telemetry.ArtifactBytesServedTotal.Add(float64(bytesWritten))
 		if err != nil {
 			return nil, eris.Wrap(err, "error copying artifact Reader to output stream")
 		}
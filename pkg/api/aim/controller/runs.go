@@ -1,19 +1,25 @@
 package controller
 
 import (
+	"context"
 	"strconv"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	log "github.com/sirupsen/logrus"
 
 	"github.com/G-Research/fasttrackml/pkg/api/aim/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/aim/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/aim/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/aim/services/run"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 	commonRequest "github.com/G-Research/fasttrackml/pkg/common/api/request"
 	"github.com/G-Research/fasttrackml/pkg/common/middleware"
 )
 
+// liveHeartbeatInterval is an interval of comments sent to keep idle live updates stream open.
+const liveHeartbeatInterval = 15 * time.Second
+
 // GetRunInfo handles `GET /runs/:id/info` endpoint.
 func (c Controller) GetRunInfo(ctx *fiber.Ctx) error {
 	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
@@ -118,6 +124,50 @@ func (c Controller) GetRunImagesBatch(ctx *fiber.Ctx) error {
 	return response.NewRunImagesBatchStreamResponse(ctx, imagesMap)
 }
 
+// GetRunTexts handles `POST /runs/:id/texts/get-batch` endpoint.
+func (c Controller) GetRunTexts(ctx *fiber.Ctx) error {
+	return c.getRunArtifacts(ctx, models.ArtifactTypeTexts)
+}
+
+// GetRunAudios handles `POST /runs/:id/audios/get-batch` endpoint.
+func (c Controller) GetRunAudios(ctx *fiber.Ctx) error {
+	return c.getRunArtifacts(ctx, models.ArtifactTypeAudios)
+}
+
+// GetRunDistributions handles `POST /runs/:id/distributions/get-batch` endpoint.
+func (c Controller) GetRunDistributions(ctx *fiber.Ctx) error {
+	return c.getRunArtifacts(ctx, models.ArtifactTypeDistributions)
+}
+
+// GetRunFigures handles `POST /runs/:id/figures/get-batch` endpoint.
+func (c Controller) GetRunFigures(ctx *fiber.Ctx) error {
+	return c.getRunArtifacts(ctx, models.ArtifactTypeFigures)
+}
+
+// getRunArtifacts handles `POST /runs/:id/{texts,audios,distributions,figures}/get-batch` endpoints.
+func (c Controller) getRunArtifacts(ctx *fiber.Ctx, artifactType models.ArtifactType) error {
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("getRun %s namespace: %s", artifactType, ns.Code)
+
+	req := request.GetRunArtifactsRequest{}
+	if err := ctx.QueryParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+	}
+	if err := ctx.BodyParser(&req.Traces); err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+	}
+
+	artifacts, err := c.runService.GetRunArtifacts(ctx.Context(), ns.ID, ctx.Params("id"), artifactType, &req)
+	if err != nil {
+		return convertError(err)
+	}
+
+	return response.NewRunArtifactsStreamResponse(ctx, artifacts, &req)
+}
+
 // GetRunsActive handles `GET /runs/active` endpoint.
 func (c Controller) GetRunsActive(ctx *fiber.Ctx) error {
 	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
@@ -143,6 +193,30 @@ func (c Controller) GetRunsActive(ctx *fiber.Ctx) error {
 	return response.NewActiveRunsStreamResponse(ctx, runs, req.ReportProgress)
 }
 
+// GetRunsLive handles `GET /runs/live` endpoint.
+func (c Controller) GetRunsLive(ctx *fiber.Ctx) error {
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("getRunsLive namespace: %s", ns.Code)
+
+	if c.liveService == nil {
+		return fiber.NewError(fiber.StatusNotFound, "live updates are disabled")
+	}
+
+	req := request.GetRunsLiveRequest{}
+	if err := ctx.QueryParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+	}
+
+	subscription := c.liveService.Subscribe(ns.ID, req.RunIDs())
+	response.NewRunsLiveStreamResponse(ctx, subscription, func() {
+		c.liveService.Unsubscribe(subscription)
+	}, liveHeartbeatInterval)
+	return nil
+}
+
 // SearchRuns handles `GET /runs/search` endpoint.
 func (c Controller) SearchRuns(ctx *fiber.Ctx) error {
 	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
@@ -211,7 +285,7 @@ func (c Controller) SearchMetrics(ctx *fiber.Ctx) error {
 	//nolint:rowserrcheck
 	rows, totalRuns, result, err := c.runService.SearchMetrics(ctx.Context(), ns.ID, tzOffset, req)
 	if err != nil {
-		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		return err
 	}
 
 	response.NewStreamMetricsResponse(ctx, rows, totalRuns, result, req)
@@ -241,13 +315,38 @@ func (c Controller) SearchAlignedMetrics(ctx *fiber.Ctx) error {
 	return nil
 }
 
-// SearchMetrics handles `POST /runs/search/image` endpoint.
+// SearchImages handles `POST /runs/search/images` endpoint.
 func (c Controller) SearchImages(ctx *fiber.Ctx) error {
+	return c.searchArtifacts(ctx, models.ArtifactTypeImages)
+}
+
+// SearchTexts handles `POST /runs/search/texts` endpoint.
+func (c Controller) SearchTexts(ctx *fiber.Ctx) error {
+	return c.searchArtifacts(ctx, models.ArtifactTypeTexts)
+}
+
+// SearchAudios handles `POST /runs/search/audios` endpoint.
+func (c Controller) SearchAudios(ctx *fiber.Ctx) error {
+	return c.searchArtifacts(ctx, models.ArtifactTypeAudios)
+}
+
+// SearchDistributions handles `POST /runs/search/distributions` endpoint.
+func (c Controller) SearchDistributions(ctx *fiber.Ctx) error {
+	return c.searchArtifacts(ctx, models.ArtifactTypeDistributions)
+}
+
+// SearchFigures handles `POST /runs/search/figures` endpoint.
+func (c Controller) SearchFigures(ctx *fiber.Ctx) error {
+	return c.searchArtifacts(ctx, models.ArtifactTypeFigures)
+}
+
+// searchArtifacts handles `POST /runs/search/{images,texts,audios,distributions,figures}` endpoints.
+func (c Controller) searchArtifacts(ctx *fiber.Ctx, artifactType models.ArtifactType) error {
 	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
 	if err != nil {
 		return api.NewInternalError("error getting namespace from context")
 	}
-	log.Debugf("searchMetrics namespace: %s", ns.Code)
+	log.Debugf("search %s namespace: %s", artifactType, ns.Code)
 
 	req := request.SearchArtifactsRequest{}
 	if err = ctx.BodyParser(&req); err != nil {
@@ -263,12 +362,12 @@ func (c Controller) SearchImages(ctx *fiber.Ctx) error {
 	}
 
 	//nolint:rowserrcheck
-	rows, runs, result, err := c.runService.SearchArtifacts(ctx.Context(), ns.ID, tzOffset, req)
+	rows, runs, result, err := c.runService.SearchArtifacts(ctx.Context(), ns.ID, tzOffset, artifactType, req)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
-	response.NewStreamArtifactsResponse(ctx, rows, runs, result, req)
+	response.NewStreamArtifactsResponse(ctx, rows, runs, result, artifactType, req)
 	return nil
 }
 
@@ -328,17 +427,98 @@ func (c Controller) GetRunLogs(ctx *fiber.Ctx) error {
 	if err = ctx.ParamsParser(&req); err != nil {
 		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
 	}
+	if err = ctx.QueryParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+	}
 
 	//nolint:rowserrcheck
-	rows, next, err := c.runService.GetRunLogs(ctx.Context(), ns.ID, &req)
+	rows, next, lineOffset, lastID, err := c.runService.GetRunLogs(ctx.Context(), ns.ID, &req)
 	if err != nil {
 		return err
 	}
+	// the page is full, so the next one could be requested after its last log.
+	if lastID > 0 {
+		ctx.Set("X-Last-Log-ID", strconv.FormatUint(uint64(lastID), 10))
+	}
 
-	response.NewGetRunLogsResponse(ctx, rows, next)
+	var follow func(uint, func([]models.Log) error) error
+	if req.Follow {
+		// logs are streamed after the handler returns, so following is limited by its own timeout.
+		follow = func(afterID uint, callback func([]models.Log) error) error {
+			return c.runService.FollowRunLogs(context.Background(), ns.ID, &req, afterID, callback)
+		}
+	}
+	response.NewGetRunLogsResponse(ctx, rows, next, lineOffset, follow)
 	return nil
 }
 
+// SearchLogs handles `GET /runs/logs/search` endpoint.
+func (c Controller) SearchLogs(ctx *fiber.Ctx) error {
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("searchLogs namespace: %s", ns.Code)
+
+	req := request.SearchLogsRequest{}
+	if err = ctx.QueryParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+	}
+
+	logs, err := c.runService.SearchLogs(ctx.Context(), ns.ID, &req)
+	if err != nil {
+		return err
+	}
+
+	return ctx.JSON(response.NewSearchLogsResponse(logs))
+}
+
+// CompareRuns handles `POST /runs/compare` endpoint.
+func (c Controller) CompareRuns(ctx *fiber.Ctx) error {
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("compareRuns namespace: %s", ns.Code)
+
+	req := request.CompareRunsRequest{}
+	if err := ctx.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+	}
+
+	runs, summaries, histories, err := c.runService.CompareRuns(ctx.Context(), ns.ID, &req)
+	if err != nil {
+		return err
+	}
+
+	resp := response.NewCompareRunsResponse(&req, runs, summaries, histories)
+	log.Debugf("compareRuns response: %#v", resp)
+	return ctx.JSON(resp)
+}
+
+// GroupRuns handles `POST /runs/group` endpoint.
+func (c Controller) GroupRuns(ctx *fiber.Ctx) error {
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("groupRuns namespace: %s", ns.Code)
+
+	req := request.GroupRunsRequest{}
+	if err := ctx.BodyParser(&req); err != nil {
+		return fiber.NewError(fiber.StatusUnprocessableEntity, err.Error())
+	}
+
+	groups, metrics, err := c.runService.GroupRuns(ctx.Context(), ns.ID, &req)
+	if err != nil {
+		return err
+	}
+
+	resp := response.NewGroupRunsResponse(&req, groups, metrics)
+	log.Debugf("groupRuns response: %#v", resp)
+	return ctx.JSON(resp)
+}
+
 // ArchiveBatch handles `POST /runs/archive-batch` endpoint.
 func (c Controller) ArchiveBatch(ctx *fiber.Ctx) error {
 	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
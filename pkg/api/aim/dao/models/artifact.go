@@ -4,6 +4,20 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+)
+
+// ArtifactType represents the kind of Aim sequence the artifact belongs to.
+type ArtifactType string
+
+// Supported list of ArtifactType.
+const (
+	ArtifactTypeImages        ArtifactType = "images"
+	ArtifactTypeTexts         ArtifactType = "texts"
+	ArtifactTypeAudios        ArtifactType = "audios"
+	ArtifactTypeDistributions ArtifactType = "distributions"
+	ArtifactTypeFigures       ArtifactType = "figures"
 )
 
 // Artifact represents the artifact model.
@@ -21,6 +35,16 @@ type Artifact struct {
 	Format    string
 	Caption   string
 	BlobURI   string
+	Type      ArtifactType `gorm:"type:varchar(16);not null;default:images;index"`
+	Text      string
+	Data      types.JSONB
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
+
+// Distribution represents the data of `distributions` artifact.
+type Distribution struct {
+	Weights  []float64 `json:"weights"`
+	BinCount int64     `json:"bin_count"`
+	Range    []float64 `json:"range"`
+}
@@ -6,3 +6,14 @@ type Log struct {
 	RunID     string `gorm:"column:run_uuid;not null;index"`
 	Timestamp int64  `gorm:"not null;index"`
 }
+
+// LogSearchResult represents log line found by logs search together with its run and experiment.
+type LogSearchResult struct {
+	ID             uint
+	RunID          string `gorm:"column:run_uuid"`
+	RunName        string
+	ExperimentID   int32
+	ExperimentName string
+	Timestamp      int64
+	Value          string
+}
@@ -49,6 +49,11 @@ type LatestMetric struct {
 	LastIter  int64
 	ContextID uint `gorm:"not null;primaryKey"`
 	Context   Context
+	// summary of all the non NaN values of the metric, maintained when the metric is logged.
+	MinValue   *float64 `gorm:"type:double precision"`
+	MaxValue   *float64 `gorm:"type:double precision"`
+	SumValue   float64  `gorm:"type:double precision;not null;default:0"`
+	ValueCount int64    `gorm:"not null;default:0"`
 }
 
 // UniqueKey is a compound unique key for this metric series.
@@ -56,6 +61,15 @@ func (m LatestMetric) UniqueKey() string {
 	return fmt.Sprintf("%v-%v-%v", m.RunID, m.Key, m.ContextID)
 }
 
+// MetricSummary represents min and max values of the run metric.
+type MetricSummary struct {
+	RunID     string `gorm:"column:run_uuid"`
+	Key       string
+	ContextID uint
+	Min       float64
+	Max       float64
+}
+
 // Context represents model to work with `contexts` table.
 type Context struct {
 	ID   uint        `gorm:"primaryKey;autoIncrement"`
@@ -15,4 +15,6 @@ type ProjectParams struct {
 	TagKeys   []string
 	ParamKeys []string
 	Images    []string
+	// Sequences holds artifact names of every requested artifact type except images.
+	Sequences map[ArtifactType][]string
 }
@@ -1,9 +1,12 @@
 package models
 
 import (
+	"cmp"
 	"context"
 	"database/sql"
+	"slices"
 
+	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -48,6 +51,30 @@ type Run struct {
 	LatestMetrics  []LatestMetric `gorm:"constraint:OnDelete:CASCADE"`
 }
 
+// GetSharedLatestMetrics returns latest metrics of the first run, which keys and contexts are logged by all the runs.
+func GetSharedLatestMetrics(runs []Run) []LatestMetric {
+	if len(runs) == 0 {
+		return nil
+	}
+	var shared []LatestMetric
+	for _, metric := range runs[0].LatestMetrics {
+		if !slices.ContainsFunc(runs[1:], func(run Run) bool {
+			return !slices.ContainsFunc(run.LatestMetrics, func(m LatestMetric) bool {
+				return m.Key == metric.Key && m.ContextID == metric.ContextID
+			})
+		}) {
+			shared = append(shared, metric)
+		}
+	}
+	slices.SortFunc(shared, func(a, b LatestMetric) int {
+		if a.Key != b.Key {
+			return cmp.Compare(a.Key, b.Key)
+		}
+		return cmp.Compare(a.ContextID, b.ContextID)
+	})
+	return shared
+}
+
 // RowNum represents custom data type.
 type RowNum int64
 
@@ -69,6 +96,15 @@ func (rn RowNum) GormDataType() string {
 // GormValue implements Gorm interface for custom data types.
 func (rn RowNum) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
 	if rn == 0 {
+		switch db.Dialector.Name() {
+		case mysql.Dialector{}.Name():
+			// MySQL doesn't allow to select from the table being inserted into,
+			// unless the subquery result is materialized into a derived table.
+			return clause.Expr{
+				SQL: "(SELECT next_row_num FROM " +
+					"(SELECT COALESCE(MAX(row_num), -1) + 1 AS next_row_num FROM runs) AS max_row_num)",
+			}
+		}
 		return clause.Expr{
 			SQL: "(SELECT COALESCE(MAX(row_num), -1) FROM runs) + 1",
 		}
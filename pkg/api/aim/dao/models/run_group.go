@@ -0,0 +1,54 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+)
+
+// Supported list of entities runs could be grouped by.
+const (
+	RunGroupByParams = "params"
+	RunGroupByTags   = "tags"
+)
+
+// RunGroupValue represents value of the param or tag, which runs are grouped by.
+// All the fields are nil for the group of runs, which don't have the param or tag.
+type RunGroupValue struct {
+	ValueStr   *string
+	ValueInt   *int64
+	ValueFloat *float64
+}
+
+// ValueAny returns the group value as any with underlying type.
+func (v RunGroupValue) ValueAny() any {
+	return Param{ValueStr: v.ValueStr, ValueInt: v.ValueInt, ValueFloat: v.ValueFloat}.ValueAny()
+}
+
+// UniqueKey is a unique key of the group value.
+func (v RunGroupValue) UniqueKey() string {
+	value := v.ValueAny()
+	return fmt.Sprintf("%T:%v", value, value)
+}
+
+// RunGroup represents number of runs with the same group value.
+type RunGroup struct {
+	RunGroupValue
+	RunCount int64
+}
+
+// RunGroupMetric represents metric statistics of the runs with the same group value.
+// Min, Max, Sum and Count are aggregated from the metric summaries of the runs, LatestMean is
+// the mean of the latest metric values.
+type RunGroupMetric struct {
+	RunGroupValue
+	Key        string
+	ContextID  uint
+	Context    types.JSONB
+	Min        *float64
+	Max        *float64
+	Sum        float64
+	Count      int64
+	LatestMean *float64
+	RunCount   int64
+}
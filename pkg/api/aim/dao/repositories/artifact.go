@@ -62,16 +62,26 @@ func (r ArtifactSearchSummary) StepImageCount(runID, name string, step int) int
 // ArtifactRepositoryProvider provides an interface to work with `artifact` entity.
 type ArtifactRepositoryProvider interface {
 	repositories.BaseRepositoryProvider
-	// Search will find artifacts based on the request.
+	// Search will find artifacts of the provided type based on the request.
 	Search(
 		ctx context.Context,
 		namespaceID uint,
 		timeZoneOffset int,
+		artifactType models.ArtifactType,
 		req request.SearchArtifactsRequest,
 	) (*sql.Rows, map[string]models.Run, ArtifactSearchSummary, error)
+	// GetArtifactNamesByExperiments will find names of the artifacts of the provided type in the selected experiments.
 	GetArtifactNamesByExperiments(
-		ctx context.Context, namespaceID uint, experiments []int,
+		ctx context.Context, namespaceID uint, experiments []int, artifactType models.ArtifactType,
 	) ([]string, error)
+	// GetByRunIDAndNames will find artifacts of the provided type and names belonging to the run.
+	GetByRunIDAndNames(
+		ctx context.Context,
+		runID string,
+		artifactType models.ArtifactType,
+		names []string,
+		req *request.GetRunArtifactsRequest,
+	) ([]models.Artifact, error)
 }
 
 // ArtifactRepository repository to work with `artifact` entity.
@@ -86,11 +96,12 @@ func NewArtifactRepository(db *gorm.DB) *ArtifactRepository {
 	}
 }
 
-// Search will find artifacts based on the request.
+// Search will find artifacts of the provided type based on the request.
 func (r ArtifactRepository) Search(
 	ctx context.Context,
 	namespaceID uint,
 	timeZoneOffset int,
+	artifactType models.ArtifactType,
 	req request.SearchArtifactsRequest,
 ) (*sql.Rows, map[string]models.Run, ArtifactSearchSummary, error) {
 	qp := query.QueryParser{
@@ -106,7 +117,7 @@ func (r ArtifactRepository) Search(
 		TzOffset:  timeZoneOffset,
 		Dialector: r.GetDB().Dialector.Name(),
 	}
-	pq, err := qp.Parse(req.Query)
+	pq, err := qp.ParseWithContext(ctx, req.Query)
 	if err != nil {
 		return nil, nil, nil, err
 	}
@@ -139,14 +150,15 @@ func (r ArtifactRepository) Search(
 		Raw(`SELECT run_uuid, name, step, count(id) as img_count, max("index") as max_index
 			FROM artifacts
 			WHERE run_uuid IN (?)
+			AND type = ?
 			GROUP BY run_uuid, name, step;`,
-			runIDs).
+			runIDs, artifactType).
 		Find(&stepInfo); tx.Error != nil {
 		return nil, nil, nil, eris.Wrap(err, "error find result summary for artifact search")
 	}
 
-	imageNames := []string{}
-	imageNameQueryTemplate := `images.name == "%s"`
+	artifactNames := []string{}
+	artifactNameQueryTemplate := `%s.name == "%s"`
 	resultSummary := make(ArtifactSearchSummary, len(runIDs))
 	for _, rslt := range stepInfo {
 		traceMap, ok := resultSummary[rslt.RunUUID]
@@ -155,25 +167,26 @@ func (r ArtifactRepository) Search(
 		}
 		traceMap[rslt.Name] = append(traceMap[rslt.Name], rslt)
 		resultSummary[rslt.RunUUID] = traceMap
-		qImage := fmt.Sprintf(imageNameQueryTemplate, rslt.Name)
-		if strings.Contains(req.Query, qImage) {
-			imageNames = append(imageNames, rslt.Name)
+		qArtifact := fmt.Sprintf(artifactNameQueryTemplate, artifactType, rslt.Name)
+		if strings.Contains(req.Query, qArtifact) {
+			artifactNames = append(artifactNames, rslt.Name)
 		}
 	}
 
 	// get a cursor for the artifacts
 	tx := r.GetDB().WithContext(ctx).
 		Raw(`
-                    SELECT artifacts.*, rows.row_num
+                    SELECT artifacts.*, numbered_artifacts.row_num
                     FROM artifacts
                     JOIN (
                        SELECT id, ROW_NUMBER() OVER() row_num
                        FROM artifacts
-                    ) rows USING (id)
+                    ) numbered_artifacts USING (id)
                     WHERE run_uuid IN ?
                     AND step BETWEEN ? AND ?
                     AND "index" BETWEEN ? AND ?
                     AND name IN ?
+                    AND type = ?
                     ORDER BY run_uuid, name, step
                 `,
 			runIDs,
@@ -181,7 +194,8 @@ func (r ArtifactRepository) Search(
 			req.RecordRangeMax(math.MaxInt16),
 			req.IndexRangeMin(),
 			req.IndexRangeMax(math.MaxInt16),
-			imageNames)
+			artifactNames,
+			artifactType)
 
 	rows, err := tx.Rows()
 	if err != nil {
@@ -194,9 +208,9 @@ func (r ArtifactRepository) Search(
 	return rows, runMap, resultSummary, nil
 }
 
-// GetArtifactNamesByExperiments will find image names in the selected experiments.
+// GetArtifactNamesByExperiments will find names of the artifacts of the provided type in the selected experiments.
 func (r ArtifactRepository) GetArtifactNamesByExperiments(
-	ctx context.Context, namespaceID uint, experiments []int,
+	ctx context.Context, namespaceID uint, experiments []int, artifactType models.ArtifactType,
 ) ([]string, error) {
 	runIDs := []string{}
 	if err := r.GetDB().WithContext(ctx).
@@ -212,13 +226,38 @@ func (r ArtifactRepository) GetArtifactNamesByExperiments(
 		return nil, eris.Wrap(err, "error finding runs for artifacts")
 	}
 
-	imageNames := []string{}
+	artifactNames := []string{}
 	if err := r.GetDB().WithContext(ctx).
 		Distinct("name").
 		Table("artifacts").
 		Where("run_uuid IN ?", runIDs).
-		Find(&imageNames).Error; err != nil {
+		Where("type = ?", artifactType).
+		Find(&artifactNames).Error; err != nil {
 		return nil, eris.Wrap(err, "error finding runs for artifact search")
 	}
-	return imageNames, nil
+	return artifactNames, nil
+}
+
+// GetByRunIDAndNames will find artifacts of the provided type and names belonging to the run.
+func (r ArtifactRepository) GetByRunIDAndNames(
+	ctx context.Context,
+	runID string,
+	artifactType models.ArtifactType,
+	names []string,
+	req *request.GetRunArtifactsRequest,
+) ([]models.Artifact, error) {
+	var artifacts []models.Artifact
+	if err := r.GetDB().WithContext(ctx).
+		Where("run_uuid = ?", runID).
+		Where("type = ?", artifactType).
+		Where("name IN ?", names).
+		Where("step BETWEEN ? AND ?", req.RecordRangeMin(), req.RecordRangeMax(math.MaxInt32)).
+		Where(`"index" BETWEEN ? AND ?`, req.IndexRangeMin(), req.IndexRangeMax(math.MaxInt32)).
+		Order("name").
+		Order("step").
+		Order(`"index"`).
+		Find(&artifacts).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting artifacts by run id: %s", runID)
+	}
+	return artifacts, nil
 }
@@ -80,7 +80,7 @@ func (d DashboardRepository) GetByNamespaceIDAndDashboardID(ctx context.Context,
 	if err := d.db.WithContext(ctx).
 		InnerJoins(
 			"App",
-			database.DB.Select(
+			d.db.Select(
 				"ID", "Type",
 			).Where(
 				&database.App{
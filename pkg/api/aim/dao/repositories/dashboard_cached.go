@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao"
+	"github.com/G-Research/fasttrackml/pkg/common/events"
+)
+
+// cachedDashboard represents cached dashboard together with its namespace,
+// as namespace of the dashboard is known only from the request.
+type cachedDashboard struct {
+	namespaceID uint
+	dashboard   models.Dashboard
+}
+
+// DashboardCachedRepository cached repository to work with `dashboard` entity.
+// Cache is invalidated by the dashboard events, which are published for every change of
+// dashboards, so changes made by other instances are picked up as well.
+type DashboardCachedRepository struct {
+	cache               *lru.Cache[string, cachedDashboard]
+	dashboardRepository DashboardRepositoryProvider
+}
+
+// NewDashboardCachedRepository creates new instance of cached repository to work with `dashboard` entity.
+func NewDashboardCachedRepository(
+	ctx context.Context,
+	dashboardRepository DashboardRepositoryProvider,
+	eventListener dao.EventListenerProvider,
+) (*DashboardCachedRepository, error) {
+	cache, err := lru.New[string, cachedDashboard](1000)
+	if err != nil {
+		return nil, eris.Wrap(err, "error creating lru cache for dashboard entities")
+	}
+
+	repository := DashboardCachedRepository{
+		cache:               cache,
+		dashboardRepository: dashboardRepository,
+	}
+
+	ch := make(chan string)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case data := <-ch:
+				if err := repository.processEvent(data); err != nil {
+					log.Errorf(`error processing incoming event: %s, error: %+v`, data, err)
+				}
+			}
+		}
+	}()
+
+	// subscribe to incoming events.
+	eventListener.Subscribe(events.DashboardChannel, ch)
+
+	return &repository, nil
+}
+
+// Update updates existing models.Dashboard object.
+func (r DashboardCachedRepository) Update(ctx context.Context, dashboard *models.Dashboard) error {
+	if err := r.dashboardRepository.Update(ctx, dashboard); err != nil {
+		return eris.Wrap(err, "error updating cached dashboard entity")
+	}
+
+	// event is delivered asynchronously, so local cache is updated right away.
+	r.cache.Remove(dashboard.ID.String())
+	return nil
+}
+
+// Create creates new models.Dashboard object.
+func (r DashboardCachedRepository) Create(ctx context.Context, dashboard *models.Dashboard) error {
+	return r.dashboardRepository.Create(ctx, dashboard)
+}
+
+// Delete deletes existing models.Dashboard object.
+func (r DashboardCachedRepository) Delete(ctx context.Context, dashboard *models.Dashboard) error {
+	if err := r.dashboardRepository.Delete(ctx, dashboard); err != nil {
+		return eris.Wrap(err, "error deleting cached dashboard entity")
+	}
+
+	// event is delivered asynchronously, so local cache is updated right away.
+	r.cache.Remove(dashboard.ID.String())
+	return nil
+}
+
+// GetDashboardsByNamespace returns the list of active models.Dashboard by provided Namespace ID.
+func (r DashboardCachedRepository) GetDashboardsByNamespace(
+	ctx context.Context, namespaceID uint,
+) ([]models.Dashboard, error) {
+	return r.dashboardRepository.GetDashboardsByNamespace(ctx, namespaceID)
+}
+
+// GetByNamespaceIDAndDashboardID returns models.Dashboard by Dashboard ID.
+func (r DashboardCachedRepository) GetByNamespaceIDAndDashboardID(
+	ctx context.Context, namespaceID uint, dashboardID string,
+) (*models.Dashboard, error) {
+	if cached, ok := r.cache.Get(dashboardID); ok && cached.namespaceID == namespaceID {
+		return copyDashboard(cached.dashboard), nil
+	}
+
+	dashboard, err := r.dashboardRepository.GetByNamespaceIDAndDashboardID(ctx, namespaceID, dashboardID)
+	if err != nil || dashboard == nil {
+		return nil, err
+	}
+	r.cache.Add(dashboardID, cachedDashboard{namespaceID: namespaceID, dashboard: *copyDashboard(*dashboard)})
+	return dashboard, nil
+}
+
+// processEvent process incoming event from database.
+func (r DashboardCachedRepository) processEvent(data string) error {
+	log.Debugf("got incoming dashboard event: %s", data)
+	event := events.EntityEvent{}
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		return eris.Wrap(err, "error unmarshaling incoming database event")
+	}
+	// changed dashboards are unknown, so drop all of them.
+	if event.Action == events.EventActionReset || len(event.IDs) == 0 {
+		r.cache.Purge()
+		return nil
+	}
+	for _, id := range event.IDs {
+		r.cache.Remove(id)
+	}
+	return nil
+}
+
+// copyDashboard returns a copy of dashboard, so callers can't modify cached entity.
+func copyDashboard(dashboard models.Dashboard) *models.Dashboard {
+	if dashboard.AppID != nil {
+		appID := *dashboard.AppID
+		dashboard.AppID = &appID
+	}
+	return &dashboard
+}
@@ -112,21 +112,12 @@ func (r ExperimentRepository) Delete(ctx context.Context, experiment *models.Exp
 				return eris.New("attempting to renumber with less than 0 row number value")
 			}
 
-			if tx.Dialector.Name() == database.PostgresDialectorName {
-				if err := tx.Exec("LOCK TABLE runs").Error; err != nil {
-					return eris.Wrap(err, "unable to lock table")
-				}
+			if err := database.LockRuns(tx); err != nil {
+				return eris.Wrap(err, "unable to lock table")
 			}
 
 			if err := tx.Exec(
-				`UPDATE runs
-				 SET row_num = rows.new_row_num
-					 FROM (
-					   SELECT run_uuid, ROW_NUMBER() OVER (ORDER BY start_time) + ? - 1 as new_row_num
-					   FROM runs
-					   WHERE runs.row_num >= ?
-					 ) as rows
-				 WHERE runs.run_uuid = rows.run_uuid`,
+				database.RenumberRunsQuery(tx),
 				minRowNum.Int64,
 				minRowNum.Int64,
 			).Error; err != nil {
@@ -6,17 +6,36 @@ import (
 
 	"github.com/rotisserie/eris"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/G-Research/fasttrackml/pkg/api/aim/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/aim/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/aim/query"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/database"
 )
 
+// LogsFilter represents filter of run logs. Logs are ordered by the order of insertion,
+// AfterID allows to continue reading logs after already received ones.
+type LogsFilter struct {
+	Since   int64
+	AfterID uint
+	Offset  int
+	Limit   int
+}
+
 // LogRepositoryProvider provides an interface to work with models.Log entity.
 type LogRepositoryProvider interface {
 	// GetLogsByNamespaceIDAndRunID returns logs by Run ID.
 	GetLogsByNamespaceIDAndRunID(
-		ctx context.Context, namespaceID uint, runID string,
+		ctx context.Context, namespaceID uint, runID string, filter LogsFilter,
 	) (*sql.Rows, func(rows *sql.Rows) (*models.Log, error), error)
+	// CountLogs returns number of run logs matching the filter and number of run logs preceding them.
+	CountLogs(ctx context.Context, runID string, filter LogsFilter) (int64, int64, error)
+	// GetPageInfo returns number of run logs in the page selected by the filter and ID of the last one.
+	GetPageInfo(ctx context.Context, runID string, filter LogsFilter) (int64, uint, error)
+	// Search searches logs of the run or the experiment by substring or regular expression.
+	Search(ctx context.Context, namespaceID uint, req *request.SearchLogsRequest) ([]models.LogSearchResult, error)
 }
 
 // LogRepository repository to work with models.Log entity.
@@ -33,17 +52,36 @@ func NewLogRepository(db *gorm.DB) *LogRepository {
 
 // GetLogsByNamespaceIDAndRunID returns logs by Namespace ID and Run ID.
 func (r LogRepository) GetLogsByNamespaceIDAndRunID(
-	ctx context.Context, namespaceID uint, runID string,
+	ctx context.Context, namespaceID uint, runID string, filter LogsFilter,
 ) (*sql.Rows, func(rows *sql.Rows) (*models.Log, error), error) {
-	rows, err := r.GetDB().WithContext(ctx).Model(
+	tx := r.GetDB().WithContext(ctx).Model(
 		&models.Log{},
+	).Select(
+		"logs.*",
 	).Joins(
 		"LEFT JOIN runs ON runs.run_uuid = logs.run_uuid",
 	).Joins(
 		"LEFT JOIN experiments ON experiments.experiment_id = runs.experiment_id",
 	).Where(
 		"experiments.namespace_id = ?", namespaceID,
-	).Rows()
+	).Where(
+		"logs.run_uuid = ?", runID,
+	).Order(
+		"logs.id",
+	)
+	if filter.Since > 0 {
+		tx = tx.Where("logs.timestamp >= ?", filter.Since)
+	}
+	if filter.AfterID > 0 {
+		tx = tx.Where("logs.id > ?", filter.AfterID)
+	}
+	if filter.Offset > 0 {
+		tx = tx.Offset(filter.Offset)
+	}
+	if filter.Limit > 0 {
+		tx = tx.Limit(filter.Limit)
+	}
+	rows, err := tx.Rows()
 	if err != nil {
 		return nil, nil, eris.Wrap(err, "error getting run logs")
 	}
@@ -59,3 +97,111 @@ func (r LogRepository) GetLogsByNamespaceIDAndRunID(
 		return &runLog, nil
 	}, nil
 }
+
+// CountLogs returns number of run logs matching the filter and number of run logs preceding them.
+func (r LogRepository) CountLogs(ctx context.Context, runID string, filter LogsFilter) (int64, int64, error) {
+	var counts struct {
+		Matched   int64
+		Preceding int64
+	}
+	if err := r.GetDB().WithContext(ctx).Model(
+		&models.Log{},
+	).Select(
+		`COUNT(CASE WHEN timestamp >= ? AND id > ? THEN 1 END) AS matched,
+		 COUNT(CASE WHEN timestamp < ? OR id <= ? THEN 1 END) AS preceding`,
+		filter.Since, filter.AfterID, filter.Since, filter.AfterID,
+	).Where(
+		"run_uuid = ?", runID,
+	).Scan(&counts).Error; err != nil {
+		return 0, 0, eris.Wrapf(err, "error counting logs by run id: %s", runID)
+	}
+	return counts.Matched, counts.Preceding, nil
+}
+
+// GetPageInfo returns number of run logs in the page selected by the filter and ID of the last one.
+func (r LogRepository) GetPageInfo(ctx context.Context, runID string, filter LogsFilter) (int64, uint, error) {
+	page := r.GetDB().WithContext(ctx).Model(
+		&models.Log{},
+	).Select(
+		"id",
+	).Where(
+		"run_uuid = ?", runID,
+	).Where(
+		"timestamp >= ?", filter.Since,
+	).Where(
+		"id > ?", filter.AfterID,
+	).Order(
+		"id",
+	)
+	if filter.Offset > 0 {
+		page = page.Offset(filter.Offset)
+	}
+	if filter.Limit > 0 {
+		page = page.Limit(filter.Limit)
+	}
+
+	var info struct {
+		Count  int64
+		LastID sql.NullInt64
+	}
+	if err := r.GetDB().WithContext(ctx).Table(
+		"(?) AS page", page,
+	).Select(
+		"COUNT(*) AS count, MAX(id) AS last_id",
+	).Scan(&info).Error; err != nil {
+		return 0, 0, eris.Wrapf(err, "error getting logs page by run id: %s", runID)
+	}
+	return info.Count, uint(info.LastID.Int64), nil
+}
+
+// Search searches logs of the run or the experiment by substring or regular expression.
+func (r LogRepository) Search(
+	ctx context.Context, namespaceID uint, req *request.SearchLogsRequest,
+) ([]models.LogSearchResult, error) {
+	tx := r.GetDB().WithContext(ctx).Model(
+		&models.Log{},
+	).Select(
+		`logs.id, logs.run_uuid, logs.timestamp, logs.value, runs.name AS run_name,
+		 experiments.experiment_id, experiments.name AS experiment_name`,
+	).Joins(
+		"INNER JOIN runs ON runs.run_uuid = logs.run_uuid",
+	).Joins(
+		"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id",
+	).Where(
+		"experiments.namespace_id = ?", namespaceID,
+	).Where(
+		"runs.lifecycle_stage <> ?", models.LifecycleStageDeleted,
+	).Order(
+		"logs.id",
+	)
+	if req.RunID != "" {
+		tx = tx.Where("logs.run_uuid = ?", req.RunID)
+	}
+	if req.ExperimentID != nil {
+		tx = tx.Where("runs.experiment_id = ?", *req.ExperimentID)
+	}
+	if req.Since > 0 {
+		tx = tx.Where("logs.timestamp >= ?", req.Since)
+	}
+	if req.Limit > 0 {
+		tx = tx.Limit(req.Limit)
+	}
+
+	switch {
+	case req.Regex:
+		tx = tx.Where(query.Regexp{
+			Eq:        clause.Eq{Column: clause.Column{Table: "logs", Name: "value"}, Value: req.Query},
+			Dialector: r.GetDB().Dialector.Name(),
+		})
+	case r.GetDB().Dialector.Name() == database.PostgresDialectorName:
+		tx = tx.Where("STRPOS(logs.value, ?) > 0", req.Query)
+	default:
+		tx = tx.Where("INSTR(logs.value, ?) > 0", req.Query)
+	}
+
+	var results []models.LogSearchResult
+	if err := tx.Scan(&results).Error; err != nil {
+		return nil, eris.Wrap(err, "error searching logs")
+	}
+	return results, nil
+}
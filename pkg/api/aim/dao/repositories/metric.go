@@ -17,6 +17,7 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/aim/query"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+	"github.com/G-Research/fasttrackml/pkg/common/sampling"
 )
 
 // SearchResult is a helper for reporting result progress.
@@ -102,7 +103,7 @@ func (r MetricRepository) SearchMetrics(
 		TzOffset:  timeZoneOffset,
 		Dialector: r.GetDB().Dialector.Name(),
 	}
-	pq, err := qp.Parse(req.Query)
+	pq, err := qp.ParseWithContext(ctx, req.Query)
 	if err != nil {
 		return nil, 0, nil, err
 	}
@@ -185,6 +186,13 @@ func (r MetricRepository) SearchMetrics(
 	}
 	metricKeyContextCondition := strings.Join(metricKeyContextConditionSlice, " OR ")
 
+	// by default every N-th point of the series is selected, where N = (number of points) / (requested steps).
+	// when max_points is requested, series is split into buckets and points with the minimal and
+	// the maximal values are selected from each bucket.
+	buckets := req.Steps
+	if req.MaxPoints > 0 {
+		buckets = sampling.BucketCount(sampling.GetMode(req.Sampling), req.MaxPoints)
+	}
 	subQuery := r.GetDB().WithContext(ctx).
 		Select(
 			"runs.run_uuid",
@@ -192,7 +200,7 @@ func (r MetricRepository) SearchMetrics(
 			"latest_metrics.key",
 			"latest_metrics.context_id",
 			"contexts.json AS context_json",
-			fmt.Sprintf("(latest_metrics.last_iter + 1)/ %f AS interval", float32(req.Steps)),
+			fmt.Sprintf(`(latest_metrics.last_iter + 1)/ %f AS "interval"`, float32(buckets)),
 		).
 		Table("runs").
 		Joins(
@@ -203,25 +211,50 @@ func (r MetricRepository) SearchMetrics(
 		Joins("LEFT JOIN contexts ON latest_metrics.context_id = contexts.id").
 		Where(metricKeyContextCondition)
 
-	tx := r.GetDB().WithContext(ctx).
-		Select(`
-        metrics.*,
-        runmetrics.context_json`,
-		).
-		Table("metrics").
-		Joins(
-			"INNER JOIN (?) runmetrics USING(run_uuid, key, context_id)",
-			pq.Filter(subQuery),
-		).
-		Where("MOD(metrics.iter + 1 + runmetrics.interval / 2, runmetrics.interval) < 1").
-		Order("runmetrics.row_num DESC").
+	var tx *gorm.DB
+	columns := []string{"metrics.*", "runmetrics.context_json"}
+	if req.MaxPoints > 0 {
+		// sampled subquery already contains `context_json` column.
+		columns = []string{"metrics.*"}
+		sampled := r.GetDB().WithContext(ctx).
+			Select(append(
+				[]string{"metrics.*", "runmetrics.row_num", "runmetrics.context_json"},
+				sampling.MinMaxColumns(
+					[]string{"metrics.run_uuid", "metrics.key", "metrics.context_id"},
+					"FLOOR(metrics.iter / runmetrics.interval)",
+					"metrics.value",
+					"metrics.iter",
+				)...,
+			)).
+			Table("metrics").
+			Joins(
+				`INNER JOIN (?) runmetrics USING(run_uuid, "key", context_id)`,
+				pq.Filter(subQuery),
+			)
+		tx = r.GetDB().WithContext(ctx).
+			Select(columns).
+			Table("(?) metrics", sampled).
+			Where(sampling.MinMaxCondition).
+			Order("metrics.row_num DESC")
+	} else {
+		tx = r.GetDB().WithContext(ctx).
+			Select(columns).
+			Table("metrics").
+			Joins(
+				`INNER JOIN (?) runmetrics USING(run_uuid, "key", context_id)`,
+				pq.Filter(subQuery),
+			).
+			Where("MOD(metrics.iter + 1 + runmetrics.interval / 2, runmetrics.interval) < 1").
+			Order("runmetrics.row_num DESC")
+	}
+	tx.
 		Order("metrics.key").
 		Order("metrics.context_id").
 		Order("metrics.iter")
 
 	if req.XAxis != "" {
 		tx.
-			Select("metrics.*", "runmetrics.context_json", "x_axis.value as x_axis_value", "x_axis.is_nan as x_axis_is_nan").
+			Select(append(columns, "x_axis.value as x_axis_value", "x_axis.is_nan as x_axis_is_nan")).
 			Joins(
 				"LEFT JOIN metrics x_axis ON metrics.run_uuid = x_axis.run_uuid AND "+
 					"metrics.iter = x_axis.iter AND x_axis.context_id = metrics.context_id AND x_axis.key = ?",
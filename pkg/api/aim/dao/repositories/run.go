@@ -11,13 +11,13 @@ import (
 	"github.com/rotisserie/eris"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 
 	"github.com/G-Research/fasttrackml/pkg/api/aim/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/aim/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/aim/query"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+	"github.com/G-Research/fasttrackml/pkg/common/sampling"
 	"github.com/G-Research/fasttrackml/pkg/database"
 )
 
@@ -34,6 +34,20 @@ type RunRepositoryProvider interface {
 	) (*sql.Rows, func(*sql.Rows) (*models.AlignedMetric, error), error)
 	// GetRunByNamespaceIDAndRunID returns experiment by Namespace ID and Run ID.
 	GetRunByNamespaceIDAndRunID(ctx context.Context, namespaceID uint, runID string) (*models.Run, error)
+	// GetByNamespaceIDAndRunIDs returns runs with params, tags and latest metrics by Namespace ID and Run IDs.
+	GetByNamespaceIDAndRunIDs(ctx context.Context, namespaceID uint, runIDs []string) ([]models.Run, error)
+	// GetMetricSummaries returns min and max values of the metrics of provided runs.
+	GetMetricSummaries(ctx context.Context, runIDs []string) ([]models.MetricSummary, error)
+	// GetMetricHistories returns values of the metrics of provided runs and keys ordered by step,
+	// each series is downsampled to about maxPoints points.
+	GetMetricHistories(
+		ctx context.Context, runIDs []string, keys []string, maxPoints int, mode sampling.Mode,
+	) ([]models.Metric, error)
+	// GetRunGroups returns active runs of the namespace grouped by the value of the param or tag
+	// together with statistics of their metrics.
+	GetRunGroups(
+		ctx context.Context, namespaceID uint, entity, key string, experimentIDs []int32, metricKeys []string,
+	) ([]models.RunGroup, []models.RunGroupMetric, error)
 	// GetByNamespaceID returns list of models.Run by requested namespace ID.
 	GetByNamespaceID(ctx context.Context, namespaceID uint) ([]models.Run, error)
 	// GetByNamespaceIDAndStatus returns []models.Run by Namespace ID and status.
@@ -109,7 +123,7 @@ func (r RunRepository) GetRunMetrics(
 ) ([]models.Metric, error) {
 	subQuery := r.GetDB().WithContext(ctx)
 	for metricKey := range metricKeysMap {
-		subQuery = subQuery.Or("key = ? AND json = ?", metricKey.Name, types.JSONB(metricKey.Context))
+		subQuery = subQuery.Or(`"key" = ? AND json = ?`, metricKey.Name, types.JSONB(metricKey.Context))
 	}
 
 	// fetch run metrics based on provided criteria.
@@ -132,17 +146,22 @@ func (r RunRepository) GetRunMetrics(
 func (r RunRepository) GetAlignedMetrics(
 	ctx context.Context, namespaceID uint, values []any, alignBy string,
 ) (*sql.Rows, func(rows *sql.Rows) (*models.AlignedMetric, error), error) {
+	row := "(?, ?, CAST(? AS numeric), CAST(? AS numeric))"
+	if r.GetDB().Dialector.Name() == database.MySQLDialectorName {
+		// MySQL requires ROW constructors inside VALUES and doesn't support CAST to numeric.
+		row = "ROW(?, ?, CAST(? AS DECIMAL), CAST(? AS DECIMAL))"
+	}
 	var valuesStmt strings.Builder
 	length := len(values) / 4
 	for i := 0; i < length; i++ {
-		valuesStmt.WriteString("(?, ?, CAST(? AS numeric), CAST(? AS numeric))")
+		valuesStmt.WriteString(row)
 		if i < length-1 {
 			valuesStmt.WriteString(",")
 		}
 	}
 	values = append(values, namespaceID, alignBy)
 	rows, err := r.GetDB().Raw(
-		fmt.Sprintf("WITH params(run_uuid, key, context_id, steps) AS (VALUES %s)", &valuesStmt)+
+		fmt.Sprintf(`WITH params(run_uuid, "key", context_id, steps) AS (VALUES %s)`, &valuesStmt)+
 			"        SELECT m.run_uuid, "+
 			"				rm.key, "+
 			"				m.iter, "+
@@ -156,10 +175,10 @@ func (r RunRepository) GetAlignedMetrics(
 			"				  p.key, "+
 			"				  p.context_id, "+
 			"				  lm.last_iter AS max, "+
-			"				  (lm.last_iter + 1) / p.steps AS interval, "+
+			`				  (lm.last_iter + 1) / p.steps AS "interval", `+
 			"				  contexts.json AS context_json"+
 			"          FROM params AS p"+
-			"          LEFT JOIN latest_metrics AS lm USING(run_uuid, key, context_id)"+
+			`          LEFT JOIN latest_metrics AS lm USING(run_uuid, "key", context_id)`+
 			"          INNER JOIN contexts ON contexts.id = lm.context_id"+
 			"        ) rm USING(run_uuid, context_id)"+
 			"		 INNER JOIN runs AS r ON m.run_uuid = r.run_uuid"+
@@ -191,7 +210,7 @@ func (r RunRepository) GetRunByNamespaceIDAndRunID(
 ) (*models.Run, error) {
 	var run models.Run
 	if err := r.GetDB().WithContext(ctx).Select(
-		"ID", "ArtifactURI",
+		"ID", "ArtifactURI", "Status",
 	).InnerJoins(
 		"Experiment",
 		database.DB.Select(
@@ -210,6 +229,165 @@ func (r RunRepository) GetRunByNamespaceIDAndRunID(
 	return &run, nil
 }
 
+// GetByNamespaceIDAndRunIDs returns runs with params, tags and latest metrics by Namespace ID and Run IDs.
+func (r RunRepository) GetByNamespaceIDAndRunIDs(
+	ctx context.Context, namespaceID uint, runIDs []string,
+) ([]models.Run, error) {
+	var runs []models.Run
+	if err := r.GetDB().WithContext(ctx).Where(
+		"run_uuid IN ?", runIDs,
+	).InnerJoins(
+		"Experiment",
+		database.DB.Select(
+			"ID", "Name",
+		).Where(
+			&models.Experiment{NamespaceID: namespaceID},
+		),
+	).Preload(
+		"Params",
+	).Preload(
+		"Tags",
+	).Preload(
+		"LatestMetrics.Context",
+	).Find(&runs).Error; err != nil {
+		return nil, eris.Wrap(err, "error getting runs by ids")
+	}
+	return runs, nil
+}
+
+// GetMetricSummaries returns min and max values of the metrics of provided runs. NaN values are skipped.
+func (r RunRepository) GetMetricSummaries(ctx context.Context, runIDs []string) ([]models.MetricSummary, error) {
+	var summaries []models.MetricSummary
+	if err := r.GetDB().WithContext(ctx).Model(
+		&models.LatestMetric{},
+	).Select(
+		`run_uuid, "key", context_id, min_value AS min, max_value AS max`,
+	).Where(
+		"run_uuid IN ?", runIDs,
+	).Where(
+		"value_count > 0",
+	).Scan(&summaries).Error; err != nil {
+		return nil, eris.Wrap(err, "error getting metric summaries")
+	}
+	return summaries, nil
+}
+
+// GetMetricHistories returns values of the metrics of provided runs and keys ordered by step.
+// Each series is split into buckets and points with the minimal and the maximal values are selected
+// from each bucket, so at most maxPoints points (or candidate points for LTTB) are returned per series.
+func (r RunRepository) GetMetricHistories(
+	ctx context.Context, runIDs []string, keys []string, maxPoints int, mode sampling.Mode,
+) ([]models.Metric, error) {
+	runMetrics := r.GetDB().WithContext(ctx).Select(
+		"run_uuid",
+		`"key"`,
+		"context_id",
+		fmt.Sprintf(`(last_iter + 1) / %f AS "interval"`, float32(sampling.BucketCount(mode, maxPoints))),
+	).Table(
+		"latest_metrics",
+	).Where(
+		"run_uuid IN ?", runIDs,
+	).Where(
+		`"key" IN ?`, keys,
+	)
+	sampled := r.GetDB().WithContext(ctx).Select(append(
+		[]string{"metrics.*"},
+		sampling.MinMaxColumns(
+			[]string{"metrics.run_uuid", "metrics.key", "metrics.context_id"},
+			"FLOOR(metrics.iter / runmetrics.interval)",
+			"metrics.value",
+			"metrics.iter",
+		)...,
+	)).Table(
+		"metrics",
+	).Joins(
+		`INNER JOIN (?) runmetrics USING(run_uuid, "key", context_id)`, runMetrics,
+	)
+
+	var metrics []models.Metric
+	if err := r.GetDB().WithContext(ctx).Table(
+		"(?) metrics", sampled,
+	).Where(
+		sampling.MinMaxCondition,
+	).Order(
+		"step",
+	).Order(
+		"iter",
+	).Find(&metrics).Error; err != nil {
+		return nil, eris.Wrap(err, "error getting metric histories")
+	}
+	return metrics, nil
+}
+
+// GetRunGroups returns active runs of the namespace grouped by the value of the param or tag
+// together with statistics of their metrics. The statistics are aggregated from the metric summaries
+// maintained in `latest_metrics` table, so the metric histories are not read.
+func (r RunRepository) GetRunGroups(
+	ctx context.Context, namespaceID uint, entity, key string, experimentIDs []int32, metricKeys []string,
+) ([]models.RunGroup, []models.RunGroupMetric, error) {
+	var table, columns, group string
+	switch entity {
+	case models.RunGroupByParams:
+		table = "params"
+		columns = "grouped.value_str, grouped.value_int, grouped.value_float"
+		group = columns
+	case models.RunGroupByTags:
+		table = "tags"
+		columns = "grouped.value AS value_str, NULL AS value_int, NULL AS value_float"
+		group = "grouped.value"
+	default:
+		return nil, nil, eris.Errorf("unsupported entity to group runs by: %s", entity)
+	}
+
+	newQuery := func() *gorm.DB {
+		query := r.GetDB().WithContext(ctx).Table(
+			"runs",
+		).Joins(
+			"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND "+
+				"experiments.namespace_id = ?", namespaceID,
+		).Joins(
+			fmt.Sprintf("LEFT JOIN %s grouped ON grouped.run_uuid = runs.run_uuid AND grouped.key = ?", table), key,
+		).Where(
+			"runs.lifecycle_stage = ?", models.LifecycleStageActive,
+		)
+		if len(experimentIDs) > 0 {
+			query = query.Where("runs.experiment_id IN ?", experimentIDs)
+		}
+		return query
+	}
+
+	var groups []models.RunGroup
+	if err := newQuery().Select(
+		columns + ", COUNT(*) AS run_count",
+	).Group(
+		group,
+	).Scan(&groups).Error; err != nil {
+		return nil, nil, eris.Wrap(err, "error getting run groups")
+	}
+
+	query := newQuery().Joins(
+		"INNER JOIN latest_metrics ON latest_metrics.run_uuid = runs.run_uuid",
+	).Joins(
+		"INNER JOIN contexts ON contexts.id = latest_metrics.context_id",
+	)
+	if len(metricKeys) > 0 {
+		query = query.Where("latest_metrics.key IN ?", metricKeys)
+	}
+	var metrics []models.RunGroupMetric
+	if err := query.Select(
+		columns + ", latest_metrics.key, latest_metrics.context_id, contexts.json AS context, " +
+			"MIN(latest_metrics.min_value) AS min, MAX(latest_metrics.max_value) AS max, " +
+			"SUM(latest_metrics.sum_value) AS sum, SUM(latest_metrics.value_count) AS count, " +
+			"AVG(CASE WHEN latest_metrics.is_nan THEN NULL ELSE latest_metrics.value END) AS latest_mean, " +
+			"COUNT(*) AS run_count",
+	).Group(
+		group + ", latest_metrics.key, latest_metrics.context_id, contexts.json",
+	).Scan(&metrics).Error; err != nil {
+		return nil, nil, eris.Wrap(err, "error getting run group metrics")
+	}
+	return groups, metrics, nil
+}
+
 // GetByNamespaceID returns list of models.Run by requested namespace ID.
 func (r RunRepository) GetByNamespaceID(ctx context.Context, namespaceID uint) ([]models.Run, error) {
 	var runs []models.Run
@@ -263,16 +441,15 @@ func (r RunRepository) ArchiveBatch(ctx context.Context, namespaceID uint, ids [
 	).Model(
 		models.Run{},
 	).Where(
-		"run_uuid IN (?)",
+		"run_uuid IN (?)", ids,
+	).Where(
+		"experiment_id IN (?)",
 		r.GetDB().Model(
-			models.Run{},
+			models.Experiment{},
 		).Select(
-			"run_uuid",
-		).Joins(
-			"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
-			namespaceID,
+			"experiment_id",
 		).Where(
-			"run_uuid IN (?)", ids,
+			"namespace_id = ?", namespaceID,
 		),
 	).Updates(models.Run{
 		DeletedTime: sql.NullInt64{
@@ -295,33 +472,32 @@ func (r RunRepository) Delete(ctx context.Context, namespaceID uint, run *models
 // DeleteBatch removes existing models.Run from the db.
 func (r RunRepository) DeleteBatch(ctx context.Context, namespaceID uint, ids []string) error {
 	if err := r.GetDB().Transaction(func(tx *gorm.DB) error {
+		// runs are selected before deletion, because MySQL doesn't support RETURNING clause.
 		runs := make([]models.Run, 0, len(ids))
-		if err := tx.Clauses(
-			clause.Returning{Columns: []clause.Column{{Name: "row_num"}}},
+		if err := tx.Model(
+			models.Run{},
+		).Select(
+			"runs.run_uuid", "runs.row_num",
+		).Joins(
+			"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
+			namespaceID,
 		).Where(
-			"run_uuid IN (?)",
-			r.GetDB().Model(
-				models.Run{},
-			).Select(
-				"run_uuid",
-			).Joins(
-				"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
-				namespaceID,
-			).Where(
-				"run_uuid IN (?)", ids,
-			),
-		).Delete(
+			"runs.run_uuid IN (?)", ids,
+		).Find(
 			&runs,
 		).Error; err != nil {
-			return eris.Wrapf(err, "error deleting existing runs with ids: %s", ids)
+			return eris.Wrapf(err, "error getting existing runs with ids: %s", ids)
 		}
 
 		// verify deletion
-		// NOTE: tx.RowsAffected does not provide correct number of deleted, using the returning slice instead
 		if len(runs) != len(ids) {
 			return eris.New("count of deleted runs does not match length of ids input (invalid run ID?)")
 		}
 
+		if err := tx.Where("run_uuid IN (?)", ids).Delete(&models.Run{}).Error; err != nil {
+			return eris.Wrapf(err, "error deleting existing runs with ids: %s", ids)
+		}
+
 		// renumber the remainder
 		if err := r.renumberRows(tx, getMinRowNum(runs)); err != nil {
 			return eris.Wrapf(err, "error renumbering runs.row_num")
@@ -339,16 +515,15 @@ func (r RunRepository) RestoreBatch(ctx context.Context, namespaceID uint, ids [
 	if err := r.GetDB().WithContext(
 		ctx,
 	).Where(
-		"run_uuid IN (?)",
+		"run_uuid IN (?)", ids,
+	).Where(
+		"experiment_id IN (?)",
 		r.GetDB().Model(
-			models.Run{},
+			models.Experiment{},
 		).Select(
-			"run_uuid",
-		).Joins(
-			"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
-			namespaceID,
+			"experiment_id",
 		).Where(
-			"run_uuid IN (?)", ids,
+			"namespace_id = ?", namespaceID,
 		),
 	).Updates(models.Run{
 		DeletedTime:    sql.NullInt64{},
@@ -384,7 +559,7 @@ func (r RunRepository) SearchRuns(
 		TzOffset:  timeZoneOffset,
 		Dialector: r.GetDB().Dialector.Name(),
 	}
-	pq, err := qp.Parse(req.Query)
+	pq, err := qp.ParseWithContext(ctx, req.Query)
 	if err != nil {
 		return nil, 0, eris.Wrap(err, "problem parsing query")
 	}
@@ -463,21 +638,12 @@ func (r RunRepository) renumberRows(tx *gorm.DB, startWith models.RowNum) error
 		return eris.New("attempting to renumber with less than 0 row number value")
 	}
 
-	if tx.Dialector.Name() == "postgres" {
-		if err := tx.Exec("LOCK TABLE runs").Error; err != nil {
-			return eris.Wrap(err, "unable to lock table")
-		}
+	if err := database.LockRuns(tx); err != nil {
+		return eris.Wrap(err, "unable to lock table")
 	}
 
 	if err := tx.Exec(
-		`UPDATE runs
-	         SET row_num = rows.new_row_num
-                 FROM (
-                   SELECT run_uuid, ROW_NUMBER() OVER (ORDER BY start_time) + ? - 1 as new_row_num
-                   FROM runs
-                   WHERE runs.row_num >= ?
-                 ) as rows
-	         WHERE runs.run_uuid = rows.run_uuid`,
+		database.RenumberRunsQuery(tx),
 		int64(startWith),
 		int64(startWith)).Error; err != nil {
 		return eris.Wrap(err, "error updating runs.row_num")
@@ -6,6 +6,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
@@ -69,3 +70,32 @@ func Test_renumberRows(t *testing.T) {
 		})
 	}
 }
+
+func Test_renumberRowsMySQL(t *testing.T) {
+	mockDb, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	//nolint:errcheck
+	defer mockDb.Close()
+
+	dialector := mysql.New(mysql.Config{
+		Conn:                      mockDb,
+		SkipInitializeWithVersion: true,
+	})
+	db, err := gorm.Open(dialector, &gorm.Config{})
+	require.Nil(t, err)
+
+	repo := NewRunRepository(db)
+
+	mock.ExpectExec(
+		`SELECT MAX\(row_num\) FROM runs FOR UPDATE`,
+	).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE runs\s+JOIN`).WithArgs(
+		models.RowNum(1), models.RowNum(1),
+	).WillReturnResult(
+		sqlmock.NewResult(0, 1),
+	)
+	assert.NoError(t, repo.renumberRows(db, models.RowNum(1)))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/G-Research/fasttrackml/pkg/api/aim/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/database"
 )
 
 // SharedTagRepositoryProvider provides an interface to work with models.SharedTag entity.
@@ -128,8 +129,12 @@ func (r SharedTagRepository) Delete(ctx context.Context, tag *models.SharedTag)
 
 // AddAssociation will add the association between SharedTag and Run.
 func (r SharedTagRepository) AddAssociation(ctx context.Context, tag *models.SharedTag, run *models.Run) error {
+	sql := "INSERT INTO run_shared_tags VALUES(?, ?) ON CONFLICT DO NOTHING"
+	if r.GetDB().Dialector.Name() == database.MySQLDialectorName {
+		sql = "INSERT IGNORE INTO run_shared_tags VALUES(?, ?)"
+	}
 	if err := r.GetDB().WithContext(ctx).
-		Exec("INSERT INTO run_shared_tags VALUES(?, ?) ON CONFLICT DO NOTHING", tag.ID, run.ID).
+		Exec(sql, tag.ID, run.ID).
 		Error; err != nil {
 		return eris.Wrap(err, "error adding tag/run association")
 	}
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/rotisserie/eris"
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm/clause"
@@ -68,20 +69,28 @@ type Json struct {
 
 // Build builds positive statement.
 func (json Json) Build(builder clause.Builder) {
-	json.writeColumn(builder)
-	switch json.Dialector {
-	case postgres.Dialector{}.Name():
-		//nolint:errcheck,gosec
-		builder.WriteString("#>>")
-	default:
-		//nolint:errcheck,gosec
-		builder.WriteString("->>")
-	}
-	builder.AddVar(builder, json.jsonPathForDialect())
+	json.build(builder)
 }
 
 // NegationBuild builds negative statement.
 func (json Json) NegationBuild(builder clause.Builder) {
+	json.build(builder)
+}
+
+func (json Json) build(builder clause.Builder) {
+	switch json.Dialector {
+	case mysql.Dialector{}.Name():
+		// MySQL stores json as a string, so the value has to be extracted explicitly.
+		//nolint:errcheck,gosec
+		builder.WriteString("JSON_UNQUOTE(JSON_EXTRACT(")
+		json.writeColumn(builder)
+		//nolint:errcheck,gosec
+		builder.WriteString(", ")
+		builder.AddVar(builder, json.jsonPathForDialect())
+		//nolint:errcheck,gosec
+		builder.WriteString("))")
+		return
+	}
 	json.writeColumn(builder)
 	switch json.Dialector {
 	case postgres.Dialector{}.Name():
@@ -275,7 +284,7 @@ func renderArrayValue(builder clause.Builder, dialector string, rv reflect.Value
 	tmpl := strings.Repeat("%v,", rv.Len()-1) + "%v"
 
 	switch dialector {
-	case postgres.Dialector{}.Name():
+	case postgres.Dialector{}.Name(), mysql.Dialector{}.Name():
 		tmpl = strings.ReplaceAll(tmpl, ",", ", ")
 	}
 
@@ -296,7 +305,7 @@ func renderDictValue(builder clause.Builder, dialector string, rv reflect.Value)
 	tmpl := strings.Repeat(`"%v":"%v",`, rv.Len()-1) + `"%v":"%v"`
 
 	switch dialector {
-	case postgres.Dialector{}.Name():
+	case postgres.Dialector{}.Name(), mysql.Dialector{}.Name():
 		tmpl = strings.ReplaceAll(tmpl, ":", ": ")
 		tmpl = strings.ReplaceAll(tmpl, ",", ", ")
 	}
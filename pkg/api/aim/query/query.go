@@ -1,6 +1,7 @@
 package query
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -11,10 +12,14 @@ import (
 	"github.com/go-python/gpython/py"
 	"github.com/gofiber/fiber/v2"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
 	"github.com/G-Research/fasttrackml/pkg/api/aim/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/tracing"
 )
 
 const (
@@ -120,6 +125,20 @@ func wrapError(e error, q string) error {
 	return e
 }
 
+// ParseWithContext parses the query recording the parsing as a span of the trace from the context.
+func (qp *QueryParser) ParseWithContext(ctx context.Context, q string) (ParsedQuery, error) {
+	_, span := tracing.StartSpan(
+		ctx, "query.QueryParser.Parse", trace.WithAttributes(attribute.String("aim.query", q)),
+	)
+	defer span.End()
+	pq, err := qp.Parse(q)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return pq, err
+}
+
 func (qp *QueryParser) Parse(q string) (ParsedQuery, error) {
 	pq := &parsedQuery{
 		qp:    qp,
@@ -706,24 +725,24 @@ func (pq *parsedQuery) parseName(node *ast.Name) (any, error) {
 					).UnixMilli(), nil
 				},
 			), nil
-		case "images":
+		case "images", "texts", "audios", "distributions", "figures":
 			table, ok := pq.qp.Tables["runs"]
 			if !ok {
 				return nil, errors.New("unsupported name identifier 'runs'")
 			}
 			return attributeGetter(
 				func(attr string) (any, error) {
-					joinKey := fmt.Sprintf("artifacts:%s", attr)
+					joinKey := fmt.Sprintf("artifacts:%s:%s", node.Id, attr)
 					j, ok := pq.joins[joinKey]
 					alias := fmt.Sprintf("artifacts_%d", len(pq.joins))
 					if !ok {
 						j = join{
 							alias: alias,
 							query: fmt.Sprintf(
-								"INNER JOIN artifacts %s ON %s.run_uuid = %s.run_uuid",
-								alias, table, alias,
+								"INNER JOIN artifacts %s ON %s.run_uuid = %s.run_uuid AND %s.type = ?",
+								alias, table, alias, alias,
 							),
-							args: []any{attr},
+							args: []any{string(node.Id)},
 						}
 						pq.AddJoin(joinKey, j)
 					}
@@ -885,6 +904,42 @@ func metricAttributeGetter(table string) (any, error) {
 			name = "last_iter"
 		case "first_step":
 			return 0, nil
+		case "min":
+			name = "min_value"
+		case "max":
+			name = "max_value"
+		case "mean":
+			return clause.Column{
+				Name: fmt.Sprintf("(%s.sum_value / NULLIF(%s.value_count, 0))", table, table),
+				Raw:  true,
+			}, nil
+		case "at_step":
+			return callable(func(args []ast.Expr) (any, error) {
+				if len(args) != 1 {
+					return nil, errors.New("`at_step` function support exactly one argument")
+				}
+				arg, ok := args[0].(*ast.Num)
+				if !ok {
+					return nil, errors.New("unsupported argument type. has to be `int` only")
+				}
+				value, ok := arg.N.(py.Int)
+				if !ok {
+					return nil, errors.New("unsupported argument type. has to be `int` only")
+				}
+				step, err := value.GoInt()
+				if err != nil {
+					return nil, err
+				}
+				return clause.Column{
+					Name: fmt.Sprintf(
+						"(SELECT MAX(value) FROM metrics WHERE metrics.run_uuid = %s.run_uuid AND "+
+							"metrics.key = %s.key AND metrics.context_id = %s.context_id AND "+
+							"metrics.step = %d AND metrics.is_nan = false)",
+						table, table, table, step,
+					),
+					Raw: true,
+				}, nil
+			}), nil
 		default:
 			return nil, fmt.Errorf("unsupported metrics attribute %q", attr)
 		}
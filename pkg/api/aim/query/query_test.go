@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -122,6 +123,33 @@ func (s *QueryTestSuite) TestPostgresDialector_Ok() {
 				`WHERE "metrics_0"."value" < $2 AND "runs"."lifecycle_stage" <> $3`,
 			expectedVars: []interface{}{"my_metric", -1.0, models.LifecycleStageDeleted},
 		},
+		{
+			name:  "TestMetricMin",
+			query: `run.metrics['my_metric'].min < 0.5`,
+			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`LEFT JOIN latest_metrics metrics_0 ON runs.run_uuid = metrics_0.run_uuid AND metrics_0.key = $1 ` +
+				`WHERE "metrics_0"."min_value" < $2 AND "runs"."lifecycle_stage" <> $3`,
+			expectedVars: []interface{}{"my_metric", 0.5, models.LifecycleStageDeleted},
+		},
+		{
+			name:  "TestMetricMean",
+			query: `run.metrics['my_metric'].mean > 0.5`,
+			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`LEFT JOIN latest_metrics metrics_0 ON runs.run_uuid = metrics_0.run_uuid AND metrics_0.key = $1 ` +
+				`WHERE (metrics_0.sum_value / NULLIF(metrics_0.value_count, 0)) > $2 ` +
+				`AND "runs"."lifecycle_stage" <> $3`,
+			expectedVars: []interface{}{"my_metric", 0.5, models.LifecycleStageDeleted},
+		},
+		{
+			name:  "TestMetricAtStep",
+			query: `run.metrics['my_metric'].at_step(10) < 0.5`,
+			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`LEFT JOIN latest_metrics metrics_0 ON runs.run_uuid = metrics_0.run_uuid AND metrics_0.key = $1 ` +
+				`WHERE (SELECT MAX(value) FROM metrics WHERE metrics.run_uuid = metrics_0.run_uuid AND ` +
+				`metrics.key = metrics_0.key AND metrics.context_id = metrics_0.context_id AND ` +
+				`metrics.step = 10 AND metrics.is_nan = false) < $2 AND "runs"."lifecycle_stage" <> $3`,
+			expectedVars: []interface{}{"my_metric", 0.5, models.LifecycleStageDeleted},
+		},
 		{
 			name:  "TestMetricContextSliceTuple",
 			query: `run.metrics["my_metric", {"key1": "value1"}].last < -1`,
@@ -378,9 +406,17 @@ func (s *QueryTestSuite) TestSqliteDialector_Ok() {
 			name:  "TestImagesName",
 			query: `(images.name == 'my-image')`,
 			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
-				`INNER JOIN artifacts artifacts_0 ON runs.run_uuid = artifacts_0.run_uuid ` +
-				`WHERE "artifacts_0"."name" = $1 AND "runs"."lifecycle_stage" <> $2`,
-			expectedVars: []interface{}{"my-image", models.LifecycleStageDeleted},
+				`INNER JOIN artifacts artifacts_0 ON runs.run_uuid = artifacts_0.run_uuid AND artifacts_0.type = $1 ` +
+				`WHERE "artifacts_0"."name" = $2 AND "runs"."lifecycle_stage" <> $3`,
+			expectedVars: []interface{}{"images", "my-image", models.LifecycleStageDeleted},
+		},
+		{
+			name:  "TestTextsName",
+			query: `(texts.name == 'my-text')`,
+			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`INNER JOIN artifacts artifacts_0 ON runs.run_uuid = artifacts_0.run_uuid AND artifacts_0.type = $1 ` +
+				`WHERE "artifacts_0"."name" = $2 AND "runs"."lifecycle_stage" <> $3`,
+			expectedVars: []interface{}{"texts", "my-text", models.LifecycleStageDeleted},
 		},
 	}
 
@@ -419,6 +455,92 @@ func (s *QueryTestSuite) TestSqliteDialector_Ok() {
 	}
 }
 
+func (s *QueryTestSuite) TestMysqlDialector_Ok() {
+	tests := []struct {
+		name          string
+		query         string
+		selectMetrics bool
+		expectedSQL   string
+		expectedVars  []interface{}
+	}{
+		{
+			name:  "TestRunNameWithoutFunction",
+			query: `(run.name == 'run')`,
+			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`WHERE "runs"."name" = $1 AND "runs"."lifecycle_stage" <> $2`,
+			expectedVars: []interface{}{"run", models.LifecycleStageDeleted},
+		},
+		{
+			name:  "TestRunNameWithRegexpMatchFunction",
+			query: `(re.match('run', run.name))`,
+			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`WHERE "runs"."name" REGEXP $1 AND "runs"."lifecycle_stage" <> $2`,
+			expectedVars: []interface{}{"^run", models.LifecycleStageDeleted},
+		},
+		{
+			name:  "TestRunNameWithNegatedRegexpSearchFunction",
+			query: `not (re.search('run', run.name))`,
+			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`WHERE "runs"."name" NOT REGEXP $1 AND "runs"."lifecycle_stage" <> $2`,
+			expectedVars: []interface{}{"run", models.LifecycleStageDeleted},
+		},
+		{
+			name:  "TestMetricContextSliceTuple",
+			query: `run.metrics["my_metric", {"key1": "value1"}].last < -1`,
+			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`LEFT JOIN latest_metrics metrics_0 ON runs.run_uuid = metrics_0.run_uuid AND metrics_0.key = $1 ` +
+				`LEFT JOIN contexts contexts_1 ON metrics_0.context_id = contexts_1.id ` +
+				`WHERE JSON_UNQUOTE(JSON_EXTRACT("contexts_1"."json", $2)) = $3 ` +
+				`AND ("metrics_0"."value" < $4 AND "runs"."lifecycle_stage" <> $5)`,
+			expectedVars: []interface{}{"my_metric", "$.key1", "value1", -1, models.LifecycleStageDeleted},
+		},
+		{
+			name:  "TestMetricContextSliceTupleWithPrefix",
+			query: `run.metrics["my_metric", {"$.key1": "value1"}].last < -1`,
+			expectedSQL: `SELECT "run_uuid" FROM "runs" ` +
+				`LEFT JOIN latest_metrics metrics_0 ON runs.run_uuid = metrics_0.run_uuid AND metrics_0.key = $1 ` +
+				`LEFT JOIN contexts contexts_1 ON metrics_0.context_id = contexts_1.id ` +
+				`WHERE JSON_UNQUOTE(JSON_EXTRACT("contexts_1"."json", $2)) = $3 ` +
+				`AND ("metrics_0"."value" < $4 AND "runs"."lifecycle_stage" <> $5)`,
+			expectedVars: []interface{}{"my_metric", "$.key1", "value1", -1, models.LifecycleStageDeleted},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			pq := QueryParser{
+				Default: DefaultExpression{
+					Contains:   "run.archived",
+					Expression: "not run.archived",
+				},
+				Tables: map[string]string{
+					"runs":        "runs",
+					"experiments": "Experiment",
+					"metrics":     "metrics",
+					"images":      "images",
+				},
+				Dialector: mysql.Dialector{}.Name(),
+			}
+			parsedQuery, err := pq.Parse(tt.query)
+			require.Nil(s.T(), err)
+			var tx *gorm.DB
+			if tt.selectMetrics {
+				tx = parsedQuery.Filter(
+					s.db.Session(&gorm.Session{DryRun: true}).Model(models.Metric{}),
+				).Select("ID").Find(models.Metric{})
+			} else {
+				tx = parsedQuery.Filter(
+					s.db.Session(&gorm.Session{DryRun: true}).Model(models.Run{}),
+				).Select("ID").Find(&models.Run{})
+			}
+
+			require.Nil(s.T(), tx.Error)
+			assert.Equal(s.T(), tt.expectedSQL, tx.Statement.SQL.String())
+			assert.Equal(s.T(), tt.expectedVars, tx.Statement.Vars)
+		})
+	}
+}
+
 func (s *QueryTestSuite) Test_Error() {
 	tests := []struct {
 		name          string
@@ -61,18 +61,32 @@ func (r *Router) Init(server fiber.Router) {
 
 	runs := mainGroup.Group("/runs")
 	runs.Get("/active/", r.controller.GetRunsActive)
+	runs.Get("/live/", r.controller.GetRunsLive)
 	runs.Get("/search/run/", r.controller.SearchRuns)
 	runs.Post("/search/metric/", r.controller.SearchMetrics)
 	runs.Post("/search/metric/align/", r.controller.SearchAlignedMetrics)
 	runs.Post("/search/images/", r.controller.SearchImages)
+	runs.Post("/search/texts/", r.controller.SearchTexts)
+	runs.Post("/search/audios/", r.controller.SearchAudios)
+	runs.Post("/search/distributions/", r.controller.SearchDistributions)
+	runs.Post("/search/figures/", r.controller.SearchFigures)
+	runs.Post("/compare/", r.controller.CompareRuns)
+	runs.Post("/group/", r.controller.GroupRuns)
 	runs.Get("/:id/info/", r.controller.GetRunInfo)
 	runs.Post("/:id/tags/new", r.controller.AddRunTag)
 	runs.Delete("/:id/tags/:tagID", r.controller.DeleteRunTag)
 	runs.Post("/:id/metric/get-batch/", r.controller.GetRunMetrics)
 	runs.Post("/:id/images/get-batch/", r.controller.GetRunImages)
 	runs.Post("/images/get-batch/", r.controller.GetRunImagesBatch)
+	runs.Post("/:id/texts/get-batch/", r.controller.GetRunTexts)
+	runs.Post("/:id/audios/get-batch/", r.controller.GetRunAudios)
+	runs.Post("/:id/distributions/get-batch/", r.controller.GetRunDistributions)
+	runs.Post("/:id/figures/get-batch/", r.controller.GetRunFigures)
+	runs.Post("/audios/get-batch/", r.controller.GetRunImagesBatch)
+	runs.Post("/figures/get-batch/", r.controller.GetRunImagesBatch)
 	runs.Put("/:id/", r.controller.UpdateRun)
 	runs.Get("/:id/logs", r.controller.GetRunLogs)
+	runs.Get("/logs/search/", r.controller.SearchLogs)
 	runs.Delete("/:id/", r.controller.DeleteRun)
 	runs.Post("/delete-batch/", r.controller.DeleteBatch)
 	runs.Post("/archive-batch/", r.controller.ArchiveBatch)
@@ -119,12 +119,32 @@ func (s Service) GetProjectParams(
 	if slices.Contains(req.Sequences, "images") {
 		// fetch images available for requested Experiments.
 		images, err := s.artifactRepository.GetArtifactNamesByExperiments(
-			ctx, namespaceID, req.Experiments,
+			ctx, namespaceID, req.Experiments, models.ArtifactTypeImages,
 		)
 		if err != nil {
 			return nil, api.NewInternalError("error getting images: %s", err)
 		}
 		projectParams.Images = images
 	}
+	for _, artifactType := range []models.ArtifactType{
+		models.ArtifactTypeTexts,
+		models.ArtifactTypeAudios,
+		models.ArtifactTypeDistributions,
+		models.ArtifactTypeFigures,
+	} {
+		if !slices.Contains(req.Sequences, string(artifactType)) {
+			continue
+		}
+		names, err := s.artifactRepository.GetArtifactNamesByExperiments(
+			ctx, namespaceID, req.Experiments, artifactType,
+		)
+		if err != nil {
+			return nil, api.NewInternalError("error getting %s: %s", artifactType, err)
+		}
+		if projectParams.Sequences == nil {
+			projectParams.Sequences = map[models.ArtifactType][]string{}
+		}
+		projectParams.Sequences[artifactType] = names
+	}
 	return &projectParams, nil
 }
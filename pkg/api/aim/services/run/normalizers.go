@@ -11,3 +11,11 @@ func NormaliseGetRunInfoRequest(req *request.GetRunInfoRequest) *request.GetRunI
 	}
 	return req
 }
+
+// NormaliseSearchLogsRequest normalizes request object for `GET /runs/logs/search` endpoint.
+func NormaliseSearchLogsRequest(req *request.SearchLogsRequest) *request.SearchLogsRequest {
+	if req.Limit == 0 {
+		req.Limit = DefaultSearchLogsLimit
+	}
+	return req
+}
@@ -1,6 +1,7 @@
 package run
 
 import (
+	"cmp"
 	"context"
 	"database/sql"
 	"encoding/json"
@@ -9,6 +10,9 @@ import (
 	"io"
 	"io/fs"
 	"net/url"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/rotisserie/eris"
 
@@ -18,7 +22,9 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/aim/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+	"github.com/G-Research/fasttrackml/pkg/common/sampling"
 	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/common/tracing"
 )
 
 // allowed batch actions.
@@ -28,6 +34,27 @@ const (
 	BatchActionRestore = "restore"
 )
 
+// logs search limits.
+const (
+	DefaultSearchLogsLimit = 100
+	MaxSearchLogsLimit     = 1000
+)
+
+// run comparison limits.
+const (
+	// MaxCompareRuns is the maximum number of runs compared at once.
+	MaxCompareRuns = 100
+	// MaxCompareHistoryPoints is the maximum number of points returned for each compared metric history.
+	MaxCompareHistoryPoints = 1000
+)
+
+const (
+	// followLogsInterval is an interval of polling new logs of the followed run.
+	followLogsInterval = time.Second
+	// followLogsTimeout is a maximum duration of logs following, then client has to reconnect.
+	followLogsTimeout = 10 * time.Minute
+)
+
 // Service provides service layer to work with `run` business logic.
 type Service struct {
 	runRepository          repositories.RunRepositoryProvider
@@ -80,16 +107,257 @@ func (s Service) GetRunInfo(
 	return runInfo, nil
 }
 
-// GetRunLogs return run logs.
+// GetRunLogs return run logs together with the number of run logs preceding the first returned one.
+// When logs are read page by page and the page is full, ID of its last log is returned as well,
+// so the next page can be requested after it.
 func (s Service) GetRunLogs(
 	ctx context.Context, namespaceID uint, req *request.GetRunLogsRequest,
-) (*sql.Rows, func(*sql.Rows) (*models.Log, error), error) {
-	rows, next, err := s.logRepository.GetLogsByNamespaceIDAndRunID(ctx, namespaceID, req.ID)
+) (*sql.Rows, func(*sql.Rows) (*models.Log, error), int64, uint, error) {
+	if err := ValidateGetRunLogsRequest(req); err != nil {
+		return nil, nil, 0, 0, err
+	}
+
+	run, err := s.runRepository.GetRunByNamespaceIDAndRunID(ctx, namespaceID, req.ID)
 	if err != nil {
-		return nil, nil, api.NewInternalError("error getting run logs: %s", err)
+		return nil, nil, 0, 0, api.NewInternalError("unable to find run by id %s: %s", req.ID, err)
+	}
+	if run == nil {
+		return nil, nil, 0, 0, api.NewResourceDoesNotExistError("run '%s' not found", req.ID)
 	}
 
-	return rows, next, nil
+	filter := repositories.LogsFilter{
+		Since:   req.Since,
+		AfterID: req.AfterID,
+		Limit:   req.Limit,
+	}
+	matched, preceding, err := s.logRepository.CountLogs(ctx, req.ID, filter)
+	if err != nil {
+		return nil, nil, 0, 0, api.NewInternalError("error counting run logs: %s", err)
+	}
+	if req.Tail > 0 && matched > int64(req.Tail) {
+		filter.Offset = int(matched) - req.Tail
+	}
+
+	var lastID uint
+	if req.Limit > 0 {
+		count, pageLastID, err := s.logRepository.GetPageInfo(ctx, req.ID, filter)
+		if err != nil {
+			return nil, nil, 0, 0, api.NewInternalError("error getting run logs page: %s", err)
+		}
+		if count == int64(req.Limit) {
+			lastID = pageLastID
+		}
+	}
+
+	rows, next, err := s.logRepository.GetLogsByNamespaceIDAndRunID(ctx, namespaceID, req.ID, filter)
+	if err != nil {
+		return nil, nil, 0, 0, api.NewInternalError("error getting run logs: %s", err)
+	}
+
+	return rows, next, preceding + int64(filter.Offset), lastID, nil
+}
+
+// FollowRunLogs polls logs of the run logged after the log with provided ID and passes them to the callback.
+// Following is finished when the run is not active anymore, the callback fails or the timeout is reached.
+func (s Service) FollowRunLogs(
+	ctx context.Context, namespaceID uint, req *request.GetRunLogsRequest, afterID uint,
+	callback func([]models.Log) error,
+) error {
+	ctx, cancel := context.WithTimeout(ctx, followLogsTimeout)
+	defer cancel()
+
+	for {
+		// get the run status before the logs, so the logs logged right before the run was finished are not lost.
+		run, err := s.runRepository.GetRunByNamespaceIDAndRunID(ctx, namespaceID, req.ID)
+		if err != nil {
+			return eris.Wrapf(err, "error getting run by id %s", req.ID)
+		}
+		if run == nil {
+			return nil
+		}
+
+		logs, err := s.getRunLogs(ctx, namespaceID, req.ID, repositories.LogsFilter{
+			Since:   req.Since,
+			AfterID: max(req.AfterID, afterID),
+		})
+		if err != nil {
+			return err
+		}
+		if len(logs) > 0 {
+			if err := callback(logs); err != nil {
+				return err
+			}
+			afterID = logs[len(logs)-1].ID
+		}
+
+		if run.Status != models.StatusRunning {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(followLogsInterval):
+		}
+	}
+}
+
+// getRunLogs returns run logs selected by the filter.
+func (s Service) getRunLogs(
+	ctx context.Context, namespaceID uint, runID string, filter repositories.LogsFilter,
+) ([]models.Log, error) {
+	rows, next, err := s.logRepository.GetLogsByNamespaceIDAndRunID(ctx, namespaceID, runID, filter)
+	if err != nil {
+		return nil, eris.Wrap(err, "error getting run logs")
+	}
+	//nolint:errcheck
+	defer rows.Close()
+
+	var logs []models.Log
+	for rows.Next() {
+		runLog, err := next(rows)
+		if err != nil {
+			return nil, err
+		}
+		logs = append(logs, *runLog)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, eris.Wrap(err, "error reading run logs")
+	}
+	return logs, nil
+}
+
+// SearchLogs searches logs of the run or the experiment.
+func (s Service) SearchLogs(
+	ctx context.Context, namespaceID uint, req *request.SearchLogsRequest,
+) ([]models.LogSearchResult, error) {
+	req = NormaliseSearchLogsRequest(req)
+	if err := ValidateSearchLogsRequest(req); err != nil {
+		return nil, err
+	}
+
+	logs, err := s.logRepository.Search(ctx, namespaceID, req)
+	if err != nil {
+		return nil, api.NewInternalError("error searching logs: %s", err)
+	}
+	return logs, nil
+}
+
+// CompareRuns returns the compared runs in the requested order together with summaries of their
+// metrics and, when requested, histories of the metrics shared by all the runs.
+func (s Service) CompareRuns(
+	ctx context.Context, namespaceID uint, req *request.CompareRunsRequest,
+) ([]models.Run, []models.MetricSummary, []models.Metric, error) {
+	if err := ValidateCompareRunsRequest(req); err != nil {
+		return nil, nil, nil, err
+	}
+
+	runs, err := s.runRepository.GetByNamespaceIDAndRunIDs(ctx, namespaceID, req.RunIDs)
+	if err != nil {
+		return nil, nil, nil, api.NewInternalError("error getting runs: %s", err)
+	}
+	for _, runID := range req.RunIDs {
+		if !slices.ContainsFunc(runs, func(run models.Run) bool { return run.ID == runID }) {
+			return nil, nil, nil, api.NewResourceDoesNotExistError("run '%s' not found", runID)
+		}
+	}
+	slices.SortFunc(runs, func(a, b models.Run) int {
+		return cmp.Compare(slices.Index(req.RunIDs, a.ID), slices.Index(req.RunIDs, b.ID))
+	})
+
+	summaries, err := s.runRepository.GetMetricSummaries(ctx, req.RunIDs)
+	if err != nil {
+		return nil, nil, nil, api.NewInternalError("error getting metric summaries: %s", err)
+	}
+
+	var histories []models.Metric
+	if req.History {
+		var keys []string
+		for _, metric := range models.GetSharedLatestMetrics(runs) {
+			if len(req.Metrics) > 0 && !slices.Contains(req.Metrics, metric.Key) {
+				continue
+			}
+			if !slices.Contains(keys, metric.Key) {
+				keys = append(keys, metric.Key)
+			}
+		}
+		if len(keys) > 0 {
+			maxPoints, mode := req.MaxPoints, sampling.GetMode(req.Sampling)
+			if maxPoints == 0 {
+				maxPoints = MaxCompareHistoryPoints
+			}
+			histories, err = s.runRepository.GetMetricHistories(ctx, req.RunIDs, keys, maxPoints, mode)
+			if err != nil {
+				return nil, nil, nil, api.NewInternalError("error getting metric histories: %s", err)
+			}
+			histories = sampleMetricHistories(histories, maxPoints, mode)
+		}
+	}
+
+	return runs, summaries, histories, nil
+}
+
+// sampleMetricHistories downsamples each series of the metric histories with the algorithm of the provided
+// mode, keeping the histories ordered by step, run, key and context.
+func sampleMetricHistories(histories []models.Metric, maxPoints int, mode sampling.Mode) []models.Metric {
+	type seriesKey struct {
+		runID     string
+		key       string
+		contextID uint
+	}
+	series := map[seriesKey][]models.Metric{}
+	for _, metric := range histories {
+		key := seriesKey{metric.RunID, metric.Key, metric.ContextID}
+		series[key] = append(series[key], metric)
+	}
+
+	sampled := make([]models.Metric, 0, len(histories))
+	for _, metrics := range series {
+		iters, values := make([]float64, len(metrics)), make([]float64, len(metrics))
+		for i, metric := range metrics {
+			iters[i], values[i] = float64(metric.Iter), metric.Value
+		}
+		sampled = append(sampled, sampling.Pick(metrics, sampling.Downsample(mode, iters, values, maxPoints))...)
+	}
+	// series are collected in a map, so points of different series with the same step
+	// are ordered by the series, otherwise their order would change from one request to another.
+	slices.SortFunc(sampled, func(a, b models.Metric) int {
+		if a.Step != b.Step {
+			return cmp.Compare(a.Step, b.Step)
+		}
+		if a.Iter != b.Iter {
+			return cmp.Compare(a.Iter, b.Iter)
+		}
+		if a.RunID != b.RunID {
+			return cmp.Compare(a.RunID, b.RunID)
+		}
+		if a.Key != b.Key {
+			return cmp.Compare(a.Key, b.Key)
+		}
+		return cmp.Compare(a.ContextID, b.ContextID)
+	})
+	return sampled
+}
+
+// GroupRuns returns active runs grouped by the value of the param or tag together with statistics
+// of their metrics.
+func (s Service) GroupRuns(
+	ctx context.Context, namespaceID uint, req *request.GroupRunsRequest,
+) ([]models.RunGroup, []models.RunGroupMetric, error) {
+	ctx, span := tracing.StartSpan(ctx, "aim.RunService.GroupRuns")
+	defer span.End()
+
+	if err := ValidateGroupRunsRequest(req); err != nil {
+		return nil, nil, err
+	}
+
+	entity, key, _ := strings.Cut(req.GroupBy, ".")
+	groups, metrics, err := s.runRepository.GetRunGroups(
+		ctx, namespaceID, entity, key, req.ExperimentIDs, req.Metrics,
+	)
+	if err != nil {
+		return nil, nil, api.NewInternalError("error grouping runs: %s", err)
+	}
+	return groups, metrics, nil
 }
 
 // GetRunMetrics returns run metrics.
@@ -197,6 +465,33 @@ func (s Service) GetRunImagesBatch(
 	return readers, nil
 }
 
+// GetRunArtifacts returns the requested artifacts of the provided type belonging to the run.
+func (s Service) GetRunArtifacts(
+	ctx context.Context,
+	namespaceID uint,
+	runID string,
+	artifactType models.ArtifactType,
+	req *request.GetRunArtifactsRequest,
+) ([]models.Artifact, error) {
+	run, err := s.runRepository.GetRunByNamespaceIDAndRunID(ctx, namespaceID, runID)
+	if err != nil {
+		return nil, api.NewInternalError("error getting run by id %s: %s", runID, err)
+	}
+	if run == nil {
+		return nil, api.NewResourceDoesNotExistError("run '%s' not found", runID)
+	}
+
+	names := make([]string, len(req.Traces))
+	for i, trace := range req.Traces {
+		names[i] = trace.Name
+	}
+	artifacts, err := s.artifactRepository.GetByRunIDAndNames(ctx, run.ID, artifactType, names, req)
+	if err != nil {
+		return nil, api.NewInternalError("error getting run %s: %s", artifactType, err)
+	}
+	return artifacts, nil
+}
+
 // GetRunsActive returns the active runs.
 func (s Service) GetRunsActive(
 	ctx context.Context, namespaceID uint, req *request.GetRunsActiveRequest,
@@ -212,6 +507,9 @@ func (s Service) GetRunsActive(
 func (s Service) SearchRuns(
 	ctx context.Context, namespaceID uint, tzOffset int, req request.SearchRunsRequest,
 ) ([]models.Run, int64, error) {
+	ctx, span := tracing.StartSpan(ctx, "aim.RunService.SearchRuns")
+	defer span.End()
+
 	runs, total, err := s.runRepository.SearchRuns(ctx, namespaceID, tzOffset, req)
 	if err != nil {
 		return nil, 0, api.NewInternalError("error searching runs: %s", err)
@@ -223,6 +521,12 @@ func (s Service) SearchRuns(
 func (s Service) SearchMetrics(
 	ctx context.Context, namespaceID uint, timeZoneOffset int, req request.SearchMetricsRequest,
 ) (*sql.Rows, int64, repositories.SearchResultMap, error) {
+	ctx, span := tracing.StartSpan(ctx, "aim.RunService.SearchMetrics")
+	defer span.End()
+
+	if err := ValidateSearchMetricsRequest(&req); err != nil {
+		return nil, 0, nil, err
+	}
 	rows, total, searchResult, err := s.metricRepository.SearchMetrics(ctx, namespaceID, timeZoneOffset, req)
 	if err != nil {
 		return nil, 0, nil, api.NewInternalError("error searching runs: %s", err)
@@ -230,11 +534,18 @@ func (s Service) SearchMetrics(
 	return rows, total, searchResult, nil
 }
 
-// SearchArtifacts returns the list of artifacts (images) by provided search criteria.
+// SearchArtifacts returns the list of artifacts of the provided type by provided search criteria.
 func (s Service) SearchArtifacts(
-	ctx context.Context, namespaceID uint, timeZoneOffset int, req request.SearchArtifactsRequest,
+	ctx context.Context,
+	namespaceID uint,
+	timeZoneOffset int,
+	artifactType models.ArtifactType,
+	req request.SearchArtifactsRequest,
 ) (*sql.Rows, map[string]models.Run, repositories.ArtifactSearchSummary, error) {
-	rows, runs, result, err := s.artifactRepository.Search(ctx, namespaceID, timeZoneOffset, req)
+	ctx, span := tracing.StartSpan(ctx, "aim.RunService.SearchArtifacts")
+	defer span.End()
+
+	rows, runs, result, err := s.artifactRepository.Search(ctx, namespaceID, timeZoneOffset, artifactType, req)
 	if err != nil {
 		return nil, nil, nil, api.NewInternalError("error searching artifacts: %s", err)
 	}
@@ -245,6 +556,9 @@ func (s Service) SearchArtifacts(
 func (s Service) SearchAlignedMetrics(
 	ctx context.Context, namespaceID uint, req *request.SearchAlignedMetricsRequest,
 ) (*sql.Rows, func(*sql.Rows) (*models.AlignedMetric, error), int, error) {
+	ctx, span := tracing.StartSpan(ctx, "aim.RunService.SearchAlignedMetrics")
+	defer span.End()
+
 	// collect map of unique contexts, collect values.
 	values, capacity, contextsMap := []any{}, 0, map[string]types.JSONB{}
 	for _, r := range req.Runs {
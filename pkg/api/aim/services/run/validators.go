@@ -1,10 +1,14 @@
 package run
 
 import (
+	"regexp"
 	"slices"
+	"strings"
 
 	"github.com/G-Research/fasttrackml/pkg/api/aim/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/aim/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/sampling"
 )
 
 // SupportedSequences list of supported Sequences for `GET /runs/:id/info` request.
@@ -28,3 +32,95 @@ func ValidateGetRunInfoRequest(req *request.GetRunInfoRequest) error {
 	}
 	return nil
 }
+
+// ValidateSearchMetricsRequest validates `POST /runs/search/metric` request.
+func ValidateSearchMetricsRequest(req *request.SearchMetricsRequest) error {
+	if req.MaxPoints < 0 {
+		return api.NewInvalidParameterValueError("Invalid value for parameter 'max_points' supplied.")
+	}
+	if !sampling.Mode(req.Sampling).IsValid() {
+		return api.NewInvalidParameterValueError(
+			"Invalid value '%s' for parameter 'sampling'. Valid values are ['lttb', 'minmax']", req.Sampling,
+		)
+	}
+	return nil
+}
+
+// ValidateGetRunLogsRequest validates `GET /runs/:id/logs` request.
+func ValidateGetRunLogsRequest(req *request.GetRunLogsRequest) error {
+	if req.Since < 0 {
+		return api.NewInvalidParameterValueError("Invalid value for parameter 'since' supplied.")
+	}
+	if req.Limit < 0 {
+		return api.NewInvalidParameterValueError("Invalid value for parameter 'limit' supplied.")
+	}
+	if req.Tail < 0 {
+		return api.NewInvalidParameterValueError("Invalid value for parameter 'tail' supplied.")
+	}
+	if req.Limit > 0 && req.Tail > 0 {
+		return api.NewInvalidParameterValueError("Only one of parameters 'limit' and 'tail' can be supplied.")
+	}
+	return nil
+}
+
+// ValidateSearchLogsRequest validates `GET /runs/logs/search` request.
+func ValidateSearchLogsRequest(req *request.SearchLogsRequest) error {
+	if req.Query == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'q'.")
+	}
+	if req.RunID == "" && req.ExperimentID == nil {
+		return api.NewInvalidParameterValueError("One of parameters 'run_id' and 'experiment_id' has to be supplied.")
+	}
+	if req.Regex {
+		if _, err := regexp.Compile(req.Query); err != nil {
+			return api.NewInvalidParameterValueError("Invalid regular expression supplied in parameter 'q': %s", err)
+		}
+	}
+	if req.Since < 0 {
+		return api.NewInvalidParameterValueError("Invalid value for parameter 'since' supplied.")
+	}
+	if req.Limit < 0 || req.Limit > MaxSearchLogsLimit {
+		return api.NewInvalidParameterValueError(
+			"Invalid value for parameter 'limit' supplied. Value has to be between 1 and %d.", MaxSearchLogsLimit,
+		)
+	}
+	return nil
+}
+
+// ValidateCompareRunsRequest validates `POST /runs/compare` request.
+func ValidateCompareRunsRequest(req *request.CompareRunsRequest) error {
+	if len(req.RunIDs) < 2 || len(req.RunIDs) > MaxCompareRuns {
+		return api.NewInvalidParameterValueError(
+			"Invalid value for parameter 'run_ids' supplied. Between 2 and %d runs can be compared.", MaxCompareRuns,
+		)
+	}
+	for i, runID := range req.RunIDs {
+		if runID == "" || slices.Contains(req.RunIDs[:i], runID) {
+			return api.NewInvalidParameterValueError(
+				"Invalid value for parameter 'run_ids' supplied. Run ids have to be unique and non-empty.",
+			)
+		}
+	}
+	if req.MaxPoints < 0 || req.MaxPoints > MaxCompareHistoryPoints {
+		return api.NewInvalidParameterValueError(
+			"Invalid value for parameter 'max_points' supplied. It has to be between 0 and %d.", MaxCompareHistoryPoints,
+		)
+	}
+	if !sampling.Mode(req.Sampling).IsValid() {
+		return api.NewInvalidParameterValueError(
+			"Invalid value '%s' for parameter 'sampling'. Valid values are ['lttb', 'minmax']", req.Sampling,
+		)
+	}
+	return nil
+}
+
+// ValidateGroupRunsRequest validates `POST /runs/group` request.
+func ValidateGroupRunsRequest(req *request.GroupRunsRequest) error {
+	entity, key, _ := strings.Cut(req.GroupBy, ".")
+	if (entity != models.RunGroupByParams && entity != models.RunGroupByTags) || key == "" {
+		return api.NewInvalidParameterValueError(
+			"Invalid value for parameter 'group_by' supplied. It has to be 'params.<key>' or 'tags.<key>'.",
+		)
+	}
+	return nil
+}
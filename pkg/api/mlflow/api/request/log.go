@@ -86,4 +86,43 @@ type LogArtifactRequest struct {
 	Height  int64  `json:"height"`
 	Format  string `json:"format"`
 	BlobURI string `json:"blob_uri"`
+	// Type is a sequence type of the artifact, `images` when not provided.
+	Type         string                      `json:"type"`
+	Text         string                      `json:"text"`
+	Distribution *DistributionPartialRequest `json:"distribution"`
+}
+
+// DistributionPartialRequest is a partial request object for `distributions` artifacts.
+type DistributionPartialRequest struct {
+	Weights  []float64 `json:"weights"`
+	BinCount int64     `json:"bin_count"`
+	Range    []float64 `json:"range"`
+}
+
+// InputTagPartialRequest is a partial request object for different requests.
+type InputTagPartialRequest struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// DatasetPartialRequest is a partial request object for different requests.
+type DatasetPartialRequest struct {
+	Name       string `json:"name"`
+	Digest     string `json:"digest"`
+	SourceType string `json:"source_type"`
+	Source     string `json:"source"`
+	Schema     string `json:"schema"`
+	Profile    string `json:"profile"`
+}
+
+// DatasetInputPartialRequest is a partial request object for different requests.
+type DatasetInputPartialRequest struct {
+	Tags    []InputTagPartialRequest `json:"tags,omitempty"`
+	Dataset DatasetPartialRequest    `json:"dataset"`
+}
+
+// LogInputsRequest is a request object for `POST mlflow/runs/log-inputs` endpoint.
+type LogInputsRequest struct {
+	RunID    string                       `json:"run_id"`
+	Datasets []DatasetInputPartialRequest `json:"datasets,omitempty"`
 }
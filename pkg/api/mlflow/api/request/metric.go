@@ -30,4 +30,23 @@ type GetMetricHistoriesRequest struct {
 	ViewType      ViewType          `json:"run_view_type"`
 	MaxResults    int32             `json:"max_results"`
 	Context       map[string]string `json:"context"`
+	MaxPoints     int32             `json:"max_points"`
+	Sampling      string            `json:"sampling"`
+}
+
+// LogMetricBulkRequest is a request object for `POST /mlflow/metrics/log-bulk` endpoint.
+// It isn't decoded from JSON, but from Apache Arrow IPC stream with a row per metric.
+type LogMetricBulkRequest struct {
+	Metrics []LogMetricBulkPartialRequest
+}
+
+// LogMetricBulkPartialRequest is a partial request object for LogMetricBulkRequest.
+// Value can be NaN or infinite, as Arrow supports them natively.
+type LogMetricBulkPartialRequest struct {
+	RunID     string
+	Key       string
+	Value     float64
+	Timestamp int64
+	Step      int64
+	Context   map[string]any
 }
@@ -0,0 +1,73 @@
+package request
+
+// ModelVersionTagPartialRequest is a partial request object for different requests.
+type ModelVersionTagPartialRequest struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// CreateModelVersionRequest is a request object for `POST /mlflow/model-versions/create` endpoint.
+type CreateModelVersionRequest struct {
+	Name        string                          `json:"name"`
+	Source      string                          `json:"source"`
+	RunID       string                          `json:"run_id"`
+	Tags        []ModelVersionTagPartialRequest `json:"tags"`
+	RunLink     string                          `json:"run_link"`
+	Description string                          `json:"description"`
+}
+
+// UpdateModelVersionRequest is a request object for `PATCH /mlflow/model-versions/update` endpoint.
+type UpdateModelVersionRequest struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Description string `json:"description"`
+}
+
+// DeleteModelVersionRequest is a request object for `DELETE /mlflow/model-versions/delete` endpoint.
+type DeleteModelVersionRequest struct {
+	Name    string `json:"name"    query:"name"`
+	Version string `json:"version" query:"version"`
+}
+
+// GetModelVersionRequest is a request object for `GET /mlflow/model-versions/get` endpoint.
+type GetModelVersionRequest struct {
+	Name    string `query:"name"`
+	Version string `query:"version"`
+}
+
+// SearchModelVersionsRequest is a request object for `GET /mlflow/model-versions/search` endpoint.
+type SearchModelVersionsRequest struct {
+	Filter     string   `json:"filter"      query:"filter"`
+	MaxResults int64    `json:"max_results" query:"max_results"`
+	OrderBy    []string `json:"order_by"    query:"order_by"`
+	PageToken  string   `json:"page_token"  query:"page_token"`
+}
+
+// GetModelVersionDownloadURIRequest is a request object for `GET /mlflow/model-versions/get-download-uri` endpoint.
+type GetModelVersionDownloadURIRequest struct {
+	Name    string `query:"name"`
+	Version string `query:"version"`
+}
+
+// TransitionModelVersionStageRequest is a request object for `POST /mlflow/model-versions/transition-stage`.
+type TransitionModelVersionStageRequest struct {
+	Name                    string `json:"name"`
+	Version                 string `json:"version"`
+	Stage                   string `json:"stage"`
+	ArchiveExistingVersions bool   `json:"archive_existing_versions"`
+}
+
+// SetModelVersionTagRequest is a request object for `POST /mlflow/model-versions/set-tag` endpoint.
+type SetModelVersionTagRequest struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+}
+
+// DeleteModelVersionTagRequest is a request object for `DELETE /mlflow/model-versions/delete-tag` endpoint.
+type DeleteModelVersionTagRequest struct {
+	Name    string `json:"name"    query:"name"`
+	Version string `json:"version" query:"version"`
+	Key     string `json:"key"     query:"key"`
+}
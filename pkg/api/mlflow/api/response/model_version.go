@@ -0,0 +1,120 @@
+package response
+
+import (
+	"fmt"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+)
+
+// ModelVersionTagPartialResponse is a partial response object for different responses.
+type ModelVersionTagPartialResponse struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// ModelVersionPartialResponse is a partial response object for different responses.
+type ModelVersionPartialResponse struct {
+	Name                 string                           `json:"name"`
+	Version              string                           `json:"version"`
+	CreationTimestamp    int64                            `json:"creation_timestamp"`
+	LastUpdatedTimestamp int64                            `json:"last_updated_timestamp"`
+	UserID               string                           `json:"user_id,omitempty"`
+	CurrentStage         string                           `json:"current_stage"`
+	Description          string                           `json:"description,omitempty"`
+	Source               string                           `json:"source,omitempty"`
+	RunID                string                           `json:"run_id,omitempty"`
+	Status               string                           `json:"status"`
+	StatusMessage        string                           `json:"status_message,omitempty"`
+	Tags                 []ModelVersionTagPartialResponse `json:"tags,omitempty"`
+	RunLink              string                           `json:"run_link,omitempty"`
+	Aliases              []string                         `json:"aliases,omitempty"`
+}
+
+// ModelVersionResponse is a response object for `POST /mlflow/model-versions/create`,
+// `PATCH /mlflow/model-versions/update`, `GET /mlflow/model-versions/get`,
+// `POST /mlflow/model-versions/transition-stage` and `GET /mlflow/registered-models/alias` endpoints.
+type ModelVersionResponse struct {
+	ModelVersion *ModelVersionPartialResponse `json:"model_version"`
+}
+
+// NewModelVersionResponse creates new ModelVersionResponse object.
+func NewModelVersionResponse(version *models.ModelVersion) *ModelVersionResponse {
+	return &ModelVersionResponse{
+		ModelVersion: NewModelVersionPartialResponse(version),
+	}
+}
+
+// SearchModelVersionsResponse is a response object for `GET /mlflow/model-versions/search` endpoint.
+type SearchModelVersionsResponse struct {
+	ModelVersions []*ModelVersionPartialResponse `json:"model_versions"`
+	NextPageToken string                         `json:"next_page_token,omitempty"`
+}
+
+// NewSearchModelVersionsResponse creates new SearchModelVersionsResponse object.
+func NewSearchModelVersionsResponse(
+	versions []models.ModelVersion, limit, offset int,
+) (*SearchModelVersionsResponse, error) {
+	token, err := newNextPageToken(len(versions), limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) > limit {
+		versions = versions[:limit]
+	}
+
+	resp := SearchModelVersionsResponse{
+		ModelVersions: make([]*ModelVersionPartialResponse, 0, len(versions)),
+		NextPageToken: token,
+	}
+	for _, version := range versions {
+		//nolint:gosec
+		resp.ModelVersions = append(resp.ModelVersions, NewModelVersionPartialResponse(&version))
+	}
+	return &resp, nil
+}
+
+// GetModelVersionDownloadURIResponse is a response object for `GET /mlflow/model-versions/get-download-uri`.
+type GetModelVersionDownloadURIResponse struct {
+	ArtifactURI string `json:"artifact_uri"`
+}
+
+// NewGetModelVersionDownloadURIResponse creates new GetModelVersionDownloadURIResponse object.
+func NewGetModelVersionDownloadURIResponse(version *models.ModelVersion) *GetModelVersionDownloadURIResponse {
+	return &GetModelVersionDownloadURIResponse{
+		ArtifactURI: version.Source,
+	}
+}
+
+// NewModelVersionPartialResponse is a helper function for the model version responses.
+// Provided models.ModelVersion has to have its models.RegisteredModel relation loaded.
+func NewModelVersionPartialResponse(version *models.ModelVersion) *ModelVersionPartialResponse {
+	return newModelVersionPartialResponse(&version.RegisteredModel, version)
+}
+
+// newModelVersionPartialResponse converts models.ModelVersion belonging to models.RegisteredModel.
+func newModelVersionPartialResponse(
+	model *models.RegisteredModel, version *models.ModelVersion,
+) *ModelVersionPartialResponse {
+	resp := ModelVersionPartialResponse{
+		Name:                 model.Name,
+		Version:              fmt.Sprint(version.Version),
+		CreationTimestamp:    version.CreationTime,
+		LastUpdatedTimestamp: version.LastUpdateTime,
+		UserID:               version.UserID,
+		CurrentStage:         string(version.CurrentStage),
+		Description:          version.Description,
+		Source:               version.Source,
+		RunID:                version.RunID,
+		Status:               string(version.Status),
+		StatusMessage:        version.StatusMessage,
+		RunLink:              version.RunLink,
+		Aliases:              model.GetAliasesByVersion(version.Version),
+	}
+	for _, tag := range version.Tags {
+		resp.Tags = append(resp.Tags, ModelVersionTagPartialResponse{
+			Key:   tag.Key,
+			Value: tag.Value,
+		})
+	}
+	return &resp
+}
@@ -0,0 +1,141 @@
+package response
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+)
+
+// RegisteredModelTagPartialResponse is a partial response object for different responses.
+type RegisteredModelTagPartialResponse struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// RegisteredModelAliasPartialResponse is a partial response object for different responses.
+type RegisteredModelAliasPartialResponse struct {
+	Alias   string `json:"alias"`
+	Version string `json:"version"`
+}
+
+// RegisteredModelPartialResponse is a partial response object for different responses.
+type RegisteredModelPartialResponse struct {
+	Name                 string                                `json:"name"`
+	CreationTimestamp    int64                                 `json:"creation_timestamp"`
+	LastUpdatedTimestamp int64                                 `json:"last_updated_timestamp"`
+	Description          string                                `json:"description,omitempty"`
+	LatestVersions       []*ModelVersionPartialResponse        `json:"latest_versions,omitempty"`
+	Tags                 []RegisteredModelTagPartialResponse   `json:"tags,omitempty"`
+	Aliases              []RegisteredModelAliasPartialResponse `json:"aliases,omitempty"`
+}
+
+// RegisteredModelResponse is a response object for `POST /mlflow/registered-models/create`,
+// `POST /mlflow/registered-models/rename`, `PATCH /mlflow/registered-models/update`
+// and `GET /mlflow/registered-models/get` endpoints.
+type RegisteredModelResponse struct {
+	RegisteredModel *RegisteredModelPartialResponse `json:"registered_model"`
+}
+
+// NewRegisteredModelResponse creates new RegisteredModelResponse object.
+func NewRegisteredModelResponse(model *models.RegisteredModel) *RegisteredModelResponse {
+	return &RegisteredModelResponse{
+		RegisteredModel: NewRegisteredModelPartialResponse(model),
+	}
+}
+
+// SearchRegisteredModelsResponse is a response object for `GET /mlflow/registered-models/search` endpoint.
+type SearchRegisteredModelsResponse struct {
+	RegisteredModels []*RegisteredModelPartialResponse `json:"registered_models"`
+	NextPageToken    string                            `json:"next_page_token,omitempty"`
+}
+
+// NewSearchRegisteredModelsResponse creates new SearchRegisteredModelsResponse object.
+func NewSearchRegisteredModelsResponse(
+	registeredModels []models.RegisteredModel, limit, offset int,
+) (*SearchRegisteredModelsResponse, error) {
+	token, err := newNextPageToken(len(registeredModels), limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	if len(registeredModels) > limit {
+		registeredModels = registeredModels[:limit]
+	}
+
+	resp := SearchRegisteredModelsResponse{
+		RegisteredModels: make([]*RegisteredModelPartialResponse, 0, len(registeredModels)),
+		NextPageToken:    token,
+	}
+	for _, model := range registeredModels {
+		//nolint:gosec
+		resp.RegisteredModels = append(resp.RegisteredModels, NewRegisteredModelPartialResponse(&model))
+	}
+	return &resp, nil
+}
+
+// GetLatestVersionsResponse is a response object for `POST /mlflow/registered-models/get-latest-versions` endpoint.
+type GetLatestVersionsResponse struct {
+	ModelVersions []*ModelVersionPartialResponse `json:"model_versions"`
+}
+
+// NewGetLatestVersionsResponse creates new GetLatestVersionsResponse object.
+func NewGetLatestVersionsResponse(
+	registeredModel *models.RegisteredModel, versions []models.ModelVersion,
+) *GetLatestVersionsResponse {
+	resp := GetLatestVersionsResponse{
+		ModelVersions: make([]*ModelVersionPartialResponse, 0, len(versions)),
+	}
+	for _, version := range versions {
+		//nolint:gosec
+		resp.ModelVersions = append(resp.ModelVersions, newModelVersionPartialResponse(registeredModel, &version))
+	}
+	return &resp
+}
+
+// NewRegisteredModelPartialResponse is a helper function for the registered model responses,
+// because they use almost the same response structure.
+func NewRegisteredModelPartialResponse(model *models.RegisteredModel) *RegisteredModelPartialResponse {
+	resp := RegisteredModelPartialResponse{
+		Name:                 model.Name,
+		CreationTimestamp:    model.CreationTime,
+		LastUpdatedTimestamp: model.LastUpdateTime,
+		Description:          model.Description,
+	}
+	for _, tag := range model.Tags {
+		resp.Tags = append(resp.Tags, RegisteredModelTagPartialResponse{
+			Key:   tag.Key,
+			Value: tag.Value,
+		})
+	}
+	for _, alias := range model.Aliases {
+		resp.Aliases = append(resp.Aliases, RegisteredModelAliasPartialResponse{
+			Alias:   alias.Alias,
+			Version: fmt.Sprint(alias.Version),
+		})
+	}
+	for _, version := range model.GetLatestVersions() {
+		//nolint:gosec
+		resp.LatestVersions = append(resp.LatestVersions, newModelVersionPartialResponse(model, &version))
+	}
+	return &resp
+}
+
+// newNextPageToken encodes `next_page_token` value when there are more results than the requested limit.
+func newNextPageToken(count, limit, offset int) (string, error) {
+	var token strings.Builder
+	if count > limit {
+		if err := json.NewEncoder(
+			base64.NewEncoder(base64.StdEncoding, &token),
+		).Encode(request.PageToken{
+			Offset: int32(offset + limit),
+		}); err != nil {
+			return "", eris.Wrap(err, "error encoding 'nextPageToken' value")
+		}
+	}
+	return token.String(), nil
+}
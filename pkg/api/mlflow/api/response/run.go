@@ -54,10 +54,38 @@ type RunInfoPartialResponse struct {
 	LifecycleStage string `json:"lifecycle_stage"`
 }
 
+// InputTagPartialResponse is a partial response object for different responses.
+type InputTagPartialResponse struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// DatasetPartialResponse is a partial response object for different responses.
+type DatasetPartialResponse struct {
+	Name       string `json:"name"`
+	Digest     string `json:"digest"`
+	SourceType string `json:"source_type"`
+	Source     string `json:"source"`
+	Schema     string `json:"schema,omitempty"`
+	Profile    string `json:"profile,omitempty"`
+}
+
+// DatasetInputPartialResponse is a partial response object for different responses.
+type DatasetInputPartialResponse struct {
+	Tags    []InputTagPartialResponse `json:"tags,omitempty"`
+	Dataset DatasetPartialResponse    `json:"dataset"`
+}
+
+// RunInputsPartialResponse is a partial response object for different responses.
+type RunInputsPartialResponse struct {
+	DatasetInputs []DatasetInputPartialResponse `json:"dataset_inputs,omitempty"`
+}
+
 // RunPartialResponse is a partial response object for different responses.
 type RunPartialResponse struct {
-	Info RunInfoPartialResponse `json:"info"`
-	Data RunDataPartialResponse `json:"data"`
+	Info   RunInfoPartialResponse   `json:"info"`
+	Data   RunDataPartialResponse   `json:"data"`
+	Inputs RunInputsPartialResponse `json:"inputs"`
 }
 
 // CreateRunResponse is a response object for `POST mlflow/runs/create` endpoint.
@@ -202,6 +230,27 @@ func NewRunPartialResponse(run *models.Run) *RunPartialResponse {
 		}
 	}
 
+	var datasetInputs []DatasetInputPartialResponse
+	for _, input := range run.Inputs {
+		datasetInput := DatasetInputPartialResponse{
+			Dataset: DatasetPartialResponse{
+				Name:       input.Dataset.Name,
+				Digest:     input.Dataset.Digest,
+				SourceType: input.Dataset.SourceType,
+				Source:     input.Dataset.Source,
+				Schema:     input.Dataset.Schema,
+				Profile:    input.Dataset.Profile,
+			},
+		}
+		for _, tag := range input.Tags {
+			datasetInput.Tags = append(datasetInput.Tags, InputTagPartialResponse{
+				Key:   tag.Key,
+				Value: tag.Value,
+			})
+		}
+		datasetInputs = append(datasetInputs, datasetInput)
+	}
+
 	return &RunPartialResponse{
 		Info: RunInfoPartialResponse{
 			ID:             run.ID,
@@ -220,5 +269,8 @@ func NewRunPartialResponse(run *models.Run) *RunPartialResponse {
 			Params:  params,
 			Tags:    tags,
 		},
+		Inputs: RunInputsPartialResponse{
+			DatasetInputs: datasetInputs,
+		},
 	}
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/common/api/request"
 	"github.com/G-Research/fasttrackml/pkg/common/api/response"
 	"github.com/G-Research/fasttrackml/pkg/common/middleware"
+	"github.com/G-Research/fasttrackml/pkg/common/telemetry"
 )
 
 // ListArtifacts handles `GET /artifacts/list` endpoint.
@@ -56,6 +57,15 @@ func (c Controller) GetArtifact(ctx *fiber.Ctx) error {
 	}
 	log.Debugf("getArtifact namespace: %s", ns.Code)
 
+	// let the client download the artifact directly from the storage, when it is possible.
+	url, err := c.artifactService.GetArtifactPresignedURL(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+	if url != "" {
+		return ctx.Redirect(url, fiber.StatusTemporaryRedirect)
+	}
+
 	artifact, err := c.artifactService.GetArtifact(ctx.Context(), ns, &req)
 	if err != nil {
 		return err
@@ -72,6 +82,7 @@ func (c Controller) GetArtifact(ctx *fiber.Ctx) error {
 		start := time.Now()
 		if err := func() error {
 			bytesWritten, err := io.CopyBuffer(w, artifact, make([]byte, 4096))
+			telemetry.ArtifactBytesServedTotal.Add(float64(bytesWritten))
 			if err != nil {
 				return eris.Wrap(err, "error copying artifact Reader to output stream")
 			}
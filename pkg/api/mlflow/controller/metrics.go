@@ -2,7 +2,10 @@ package controller
 
 import (
 	"bufio"
+	"encoding/json"
 	"fmt"
+	"io"
+	"math"
 	"time"
 
 	"github.com/apache/arrow/go/v14/arrow"
@@ -17,6 +20,7 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/pkg/common/middleware"
+	"github.com/G-Research/fasttrackml/pkg/common/sampling"
 	"github.com/G-Research/fasttrackml/pkg/database"
 )
 
@@ -119,11 +123,8 @@ func (c Controller) GetMetricHistories(ctx *fiber.Ctx) error {
 			b := array.NewRecordBuilder(pool, schema)
 			defer b.Release()
 
-			for i := 0; rows.Next(); i++ {
-				var m database.Metric
-				if err := iterator(rows, &m); err != nil {
-					return eris.Wrap(err, "error reading metric from iterator")
-				}
+			written := 0
+			writeMetric := func(m *database.Metric) error {
 				b.Field(0).(*array.StringBuilder).Append(m.RunID)
 				b.Field(1).(*array.StringBuilder).Append(m.Key)
 				b.Field(2).(*array.Int64Builder).Append(m.Step)
@@ -134,11 +135,62 @@ func (c Controller) GetMetricHistories(ctx *fiber.Ctx) error {
 					b.Field(4).(*array.Float64Builder).Append(m.Value)
 				}
 				b.Field(5).(*array.StringBuilder).Append(string(m.Context.Json))
-				if (i+1)%100000 == 0 {
+				written++
+				if written%100000 == 0 {
 					if err := WriteStreamingRecord(writer, b.NewRecord()); err != nil {
 						return fmt.Errorf("unable to write Arrow record batch: %w", err)
 					}
 				}
+				return nil
+			}
+
+			// series are pre-sampled by the query, but the final downsampling needs the whole series,
+			// so points are buffered until the next series begins.
+			downsample := req.MaxPoints > 0
+			var series []database.Metric
+			writeSeries := func() error {
+				if len(series) > int(req.MaxPoints) {
+					steps, values := make([]float64, len(series)), make([]float64, len(series))
+					for i, m := range series {
+						steps[i], values[i] = float64(m.Step), m.Value
+						if m.IsNan {
+							values[i] = math.NaN()
+						}
+					}
+					series = sampling.Pick(
+						series, sampling.Downsample(sampling.GetMode(req.Sampling), steps, values, int(req.MaxPoints)),
+					)
+				}
+				for i := range series {
+					if err := writeMetric(&series[i]); err != nil {
+						return err
+					}
+				}
+				series = nil
+				return nil
+			}
+
+			for rows.Next() {
+				var m database.Metric
+				if err := iterator(rows, &m); err != nil {
+					return eris.Wrap(err, "error reading metric from iterator")
+				}
+				if !downsample {
+					if err := writeMetric(&m); err != nil {
+						return err
+					}
+					continue
+				}
+				if len(series) > 0 &&
+					(series[0].RunID != m.RunID || series[0].Key != m.Key || series[0].ContextID != m.ContextID) {
+					if err := writeSeries(); err != nil {
+						return err
+					}
+				}
+				series = append(series, m)
+			}
+			if err := writeSeries(); err != nil {
+				return err
 			}
 			if b.Field(0).Len() > 0 {
 				if err := WriteStreamingRecord(writer, b.NewRecord()); err != nil {
@@ -154,3 +206,137 @@ func (c Controller) GetMetricHistories(ctx *fiber.Ctx) error {
 	})
 	return nil
 }
+
+const (
+	// logMetricBulkMaxMetrics is the maximum number of metrics in `POST /metrics/log-bulk` request.
+	logMetricBulkMaxMetrics = 1000000
+	// logMetricBulkMaxBytes is the maximum size of `POST /metrics/log-bulk` request body.
+	logMetricBulkMaxBytes = 256 * 1024 * 1024
+)
+
+// LogMetricBulk handles `POST /metrics/log-bulk` endpoint. Request body is Apache Arrow IPC stream
+// with `run_id`, `key`, `value`, `timestamp` and optional `step` and `context` (JSON) columns.
+// The stream is decoded straight from the connection, so it isn't bound by the request body limit,
+// but by its own limits of the number of metrics and of the body size.
+func (c Controller) LogMetricBulk(ctx *fiber.Ctx) error {
+	req, err := decodeLogMetricBulkRequest(
+		middleware.GetRequestBodyReader(ctx), logMetricBulkMaxMetrics, logMetricBulkMaxBytes,
+	)
+	if err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("logMetricBulk request: %d metrics", len(req.Metrics))
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("logMetricBulk namespace: %s", ns.Code)
+
+	if err := c.runService.LogMetricBulk(ctx.Context(), ns, req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
+
+// decodeLogMetricBulkRequest decodes request.LogMetricBulkRequest from Arrow IPC stream. Decoding fails
+// as soon as the stream has more than maxMetrics rows or more than maxBytes bytes.
+// Null values are treated as NaN, null steps as 0 and null contexts as the default context.
+func decodeLogMetricBulkRequest(
+	r io.Reader, maxMetrics int, maxBytes int64,
+) (*request.LogMetricBulkRequest, error) {
+	reader, err := ipc.NewReader(
+		&maxBytesReader{reader: r, remaining: maxBytes, limit: maxBytes},
+		ipc.WithAllocator(memory.NewGoAllocator()),
+	)
+	if err != nil {
+		return nil, eris.Wrap(err, "error creating Arrow reader")
+	}
+	defer reader.Release()
+
+	columns := map[string]arrow.DataType{
+		"run_id":    arrow.BinaryTypes.String,
+		"key":       arrow.BinaryTypes.String,
+		"value":     arrow.PrimitiveTypes.Float64,
+		"timestamp": arrow.PrimitiveTypes.Int64,
+		"step":      arrow.PrimitiveTypes.Int64,
+		"context":   arrow.BinaryTypes.String,
+	}
+	indexes := map[string]int{}
+	for name, dataType := range columns {
+		fieldIndexes := reader.Schema().FieldIndices(name)
+		if len(fieldIndexes) == 0 {
+			if name == "step" || name == "context" {
+				continue
+			}
+			return nil, eris.Errorf("column '%s' is missing", name)
+		}
+		if field := reader.Schema().Field(fieldIndexes[0]); !arrow.TypeEqual(field.Type, dataType) {
+			return nil, eris.Errorf("column '%s' has type %s, but %s is expected", name, field.Type, dataType)
+		}
+		indexes[name] = fieldIndexes[0]
+	}
+
+	req := request.LogMetricBulkRequest{}
+	for reader.Next() {
+		record := reader.Record()
+		if len(req.Metrics)+int(record.NumRows()) > maxMetrics {
+			return nil, eris.Errorf("stream has more than %d metrics", maxMetrics)
+		}
+		runIDs := record.Column(indexes["run_id"]).(*array.String)
+		keys := record.Column(indexes["key"]).(*array.String)
+		values := record.Column(indexes["value"]).(*array.Float64)
+		timestamps := record.Column(indexes["timestamp"]).(*array.Int64)
+		for i := 0; i < int(record.NumRows()); i++ {
+			metric := request.LogMetricBulkPartialRequest{
+				RunID:     runIDs.Value(i),
+				Key:       keys.Value(i),
+				Value:     math.NaN(),
+				Timestamp: timestamps.Value(i),
+			}
+			if values.IsValid(i) {
+				metric.Value = values.Value(i)
+			}
+			if index, ok := indexes["step"]; ok {
+				metric.Step = record.Column(index).(*array.Int64).Value(i)
+			}
+			if index, ok := indexes["context"]; ok {
+				if contexts := record.Column(index).(*array.String); contexts.IsValid(i) && contexts.Value(i) != "" {
+					if err := json.Unmarshal([]byte(contexts.Value(i)), &metric.Context); err != nil {
+						return nil, eris.Wrapf(err, "error decoding context of row %d", len(req.Metrics))
+					}
+				}
+			}
+			req.Metrics = append(req.Metrics, metric)
+		}
+	}
+	if err := reader.Err(); err != nil {
+		return nil, eris.Wrap(err, "error reading Arrow stream")
+	}
+	return &req, nil
+}
+
+// maxBytesReader reads from the underlying reader and fails, when more than limit bytes have been read.
+type maxBytesReader struct {
+	reader    io.Reader
+	remaining int64
+	limit     int64
+}
+
+// Read implements io.Reader interface.
+func (r *maxBytesReader) Read(p []byte) (int, error) {
+	if r.remaining < 0 {
+		return 0, eris.Errorf("stream is larger than %d bytes", r.limit)
+	}
+	// one byte more than remaining is requested to find out, whether the stream exceeds the limit.
+	if int64(len(p)) > r.remaining+1 {
+		p = p[:r.remaining+1]
+	}
+	n, err := r.reader.Read(p)
+	r.remaining -= int64(n)
+	if r.remaining < 0 {
+		return n, eris.Errorf("stream is larger than %d bytes", r.limit)
+	}
+	return n, err
+}
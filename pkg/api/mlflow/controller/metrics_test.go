@@ -0,0 +1,84 @@
+package controller
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/ipc"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeLogMetricBulkRequest_Ok(t *testing.T) {
+	body := encodeTestMetrics(t, 3)
+
+	req, err := decodeLogMetricBulkRequest(bytes.NewReader(body), 3, int64(len(body)))
+	require.Nil(t, err)
+	assert.Len(t, req.Metrics, 3)
+	assert.Equal(t, "run", req.Metrics[0].RunID)
+	assert.Equal(t, "loss", req.Metrics[0].Key)
+	assert.Equal(t, 2.0, req.Metrics[2].Value)
+}
+
+func TestDecodeLogMetricBulkRequest_Error(t *testing.T) {
+	body := encodeTestMetrics(t, 3)
+
+	tests := []struct {
+		name       string
+		maxMetrics int
+		maxBytes   int64
+		error      string
+	}{
+		{
+			name:       "TooManyMetrics",
+			maxMetrics: 2,
+			maxBytes:   int64(len(body)),
+			error:      "stream has more than 2 metrics",
+		},
+		{
+			name:       "TooManyBytes",
+			maxMetrics: 3,
+			maxBytes:   int64(len(body) / 2),
+			error:      "larger than",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeLogMetricBulkRequest(bytes.NewReader(body), tt.maxMetrics, tt.maxBytes)
+			assert.ErrorContains(t, err, tt.error)
+		})
+	}
+}
+
+// encodeTestMetrics encodes count metrics of the same run and key into Arrow IPC stream.
+func encodeTestMetrics(t *testing.T, count int) []byte {
+	pool := memory.NewGoAllocator()
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "run_id", Type: arrow.BinaryTypes.String},
+			{Name: "key", Type: arrow.BinaryTypes.String},
+			{Name: "value", Type: arrow.PrimitiveTypes.Float64},
+			{Name: "timestamp", Type: arrow.PrimitiveTypes.Int64},
+		},
+		nil,
+	)
+	builder := array.NewRecordBuilder(pool, schema)
+	defer builder.Release()
+	for i := 0; i < count; i++ {
+		builder.Field(0).(*array.StringBuilder).Append("run")
+		builder.Field(1).(*array.StringBuilder).Append("loss")
+		builder.Field(2).(*array.Float64Builder).Append(float64(i))
+		builder.Field(3).(*array.Int64Builder).Append(int64(i))
+	}
+	record := builder.NewRecord()
+	defer record.Release()
+
+	var buf bytes.Buffer
+	writer := ipc.NewWriter(&buf, ipc.WithAllocator(pool), ipc.WithSchema(schema))
+	require.Nil(t, writer.Write(record))
+	require.Nil(t, writer.Close())
+	return buf.Bytes()
+}
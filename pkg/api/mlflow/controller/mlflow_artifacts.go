@@ -0,0 +1,277 @@
+package controller
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/api/request"
+	"github.com/G-Research/fasttrackml/pkg/common/api/response"
+	"github.com/G-Research/fasttrackml/pkg/common/middleware"
+	"github.com/G-Research/fasttrackml/pkg/common/telemetry"
+)
+
+// ListProxiedArtifacts handles `GET /mlflow-artifacts/artifacts` endpoint.
+func (c Controller) ListProxiedArtifacts(ctx *fiber.Ctx) error {
+	req := request.ListProxiedArtifactsRequest{}
+	if err := ctx.QueryParser(&req); err != nil {
+		return api.NewBadRequestError(err.Error())
+	}
+	log.Debugf("listProxiedArtifacts request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("listProxiedArtifacts namespace: %s", ns.Code)
+
+	artifacts, err := c.artifactService.ListProxiedArtifacts(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	resp := response.NewListProxiedArtifactsResponse(artifacts)
+	log.Debugf("listProxiedArtifacts response: %#v", resp)
+	return ctx.JSON(resp)
+}
+
+// DownloadArtifact handles `GET /mlflow-artifacts/artifacts/:path` endpoint.
+func (c Controller) DownloadArtifact(ctx *fiber.Ctx) error {
+	path, err := getArtifactPathFromParams(ctx)
+	if err != nil {
+		return err
+	}
+	req := request.DownloadArtifactRequest{Path: path}
+	log.Debugf("downloadArtifact request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("downloadArtifact namespace: %s", ns.Code)
+
+	// let the client download the artifact directly from the storage, when it is possible.
+	url, err := c.artifactService.GetProxiedArtifactPresignedURL(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+	if url != "" {
+		return ctx.Redirect(url, fiber.StatusTemporaryRedirect)
+	}
+
+	artifact, err := c.artifactService.DownloadArtifact(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	filename := filepath.Base(req.Path)
+	ctx.Set("Content-Type", common.GetContentType(filename))
+	ctx.Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	ctx.Set("X-Content-Type-Options", "nosniff")
+	ctx.Context().Response.SetBodyStreamWriter(func(w *bufio.Writer) {
+		//nolint:errcheck
+		defer artifact.Close()
+
+		start := time.Now()
+		if err := func() error {
+			bytesWritten, err := io.CopyBuffer(w, artifact, make([]byte, 4096))
+			telemetry.ArtifactBytesServedTotal.Add(float64(bytesWritten))
+			if err != nil {
+				return eris.Wrap(err, "error copying artifact Reader to output stream")
+			}
+			if err := w.Flush(); err != nil {
+				return eris.Wrap(err, "error flushing output stream")
+			}
+			log.Debugf("DownloadArtifact wrote bytes to output stream: %d", bytesWritten)
+			return nil
+		}(); err != nil {
+			log.Errorf(
+				"error encountered in %s %s: error streaming artifact: %s",
+				ctx.Method(),
+				ctx.Path(),
+				err,
+			)
+		}
+		log.Infof("body - %s %s %s", time.Since(start), ctx.Method(), ctx.Path())
+	})
+	return nil
+}
+
+// UploadArtifact handles `PUT /mlflow-artifacts/artifacts/:path` endpoint.
+func (c Controller) UploadArtifact(ctx *fiber.Ctx) error {
+	path, err := getArtifactPathFromParams(ctx)
+	if err != nil {
+		return err
+	}
+	req := request.UploadArtifactRequest{Path: path}
+	log.Debugf("uploadArtifact request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("uploadArtifact namespace: %s", ns.Code)
+
+	if err := c.artifactService.UploadArtifact(ctx.Context(), ns, &req, middleware.GetRequestBodyReader(ctx)); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
+
+// DeleteArtifact handles `DELETE /mlflow-artifacts/artifacts/:path` endpoint.
+func (c Controller) DeleteArtifact(ctx *fiber.Ctx) error {
+	path, err := getArtifactPathFromParams(ctx)
+	if err != nil {
+		return err
+	}
+	req := request.DeleteArtifactRequest{Path: path}
+	log.Debugf("deleteArtifact request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("deleteArtifact namespace: %s", ns.Code)
+
+	if err := c.artifactService.DeleteArtifact(ctx.Context(), ns, &req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
+
+// CreateMultipartUpload handles `POST /mlflow-artifacts/mpu/create/:path` endpoint.
+func (c Controller) CreateMultipartUpload(ctx *fiber.Ctx) error {
+	req := request.CreateMultipartUploadRequest{}
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	path, err := getArtifactPathFromParams(ctx)
+	if err != nil {
+		return err
+	}
+	req.ArtifactPath = path
+	log.Debugf("createMultipartUpload request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("createMultipartUpload namespace: %s", ns.Code)
+
+	uploadID, err := c.artifactService.CreateMultipartUpload(ctx.Context(), ns, &req)
+	if err != nil {
+		return err
+	}
+
+	resp, err := response.NewCreateMultipartUploadResponse(
+		uploadID,
+		req.NumParts,
+		ctx.BaseURL()+strings.Replace(ctx.OriginalURL(), "/mpu/create/", "/mpu/upload/", 1),
+	)
+	if err != nil {
+		return api.NewInternalError("error creating multipart upload response: %s", err)
+	}
+	log.Debugf("createMultipartUpload response: %#v", resp)
+	return ctx.JSON(resp)
+}
+
+// UploadMultipartPart handles `PUT /mlflow-artifacts/mpu/upload/:path` endpoint.
+func (c Controller) UploadMultipartPart(ctx *fiber.Ctx) error {
+	req := request.UploadMultipartPartRequest{}
+	if err := ctx.QueryParser(&req); err != nil {
+		return api.NewBadRequestError(err.Error())
+	}
+	path, err := getArtifactPathFromParams(ctx)
+	if err != nil {
+		return err
+	}
+	req.ArtifactPath = path
+	log.Debugf("uploadMultipartPart request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("uploadMultipartPart namespace: %s", ns.Code)
+
+	etag, err := c.artifactService.UploadMultipartPart(ctx.Context(), ns, &req, middleware.GetRequestBodyReader(ctx))
+	if err != nil {
+		return err
+	}
+
+	ctx.Set(fiber.HeaderETag, etag)
+	return ctx.JSON(fiber.Map{})
+}
+
+// CompleteMultipartUpload handles `POST /mlflow-artifacts/mpu/complete/:path` endpoint.
+func (c Controller) CompleteMultipartUpload(ctx *fiber.Ctx) error {
+	req := request.CompleteMultipartUploadRequest{}
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	path, err := getArtifactPathFromParams(ctx)
+	if err != nil {
+		return err
+	}
+	req.ArtifactPath = path
+	log.Debugf("completeMultipartUpload request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("completeMultipartUpload namespace: %s", ns.Code)
+
+	if err := c.artifactService.CompleteMultipartUpload(ctx.Context(), ns, &req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
+
+// AbortMultipartUpload handles `POST /mlflow-artifacts/mpu/abort/:path` endpoint.
+func (c Controller) AbortMultipartUpload(ctx *fiber.Ctx) error {
+	req := request.AbortMultipartUploadRequest{}
+	if err := ctx.BodyParser(&req); err != nil {
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	path, err := getArtifactPathFromParams(ctx)
+	if err != nil {
+		return err
+	}
+	req.ArtifactPath = path
+	log.Debugf("abortMultipartUpload request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("abortMultipartUpload namespace: %s", ns.Code)
+
+	if err := c.artifactService.AbortMultipartUpload(ctx.Context(), ns, &req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
+
+// getArtifactPathFromParams returns unescaped artifact path from the wildcard route parameter.
+func getArtifactPathFromParams(ctx *fiber.Ctx) (string, error) {
+	path, err := url.PathUnescape(ctx.Params("*"))
+	if err != nil {
+		return "", api.NewInvalidParameterValueError("Invalid value for parameter 'path' supplied.")
+	}
+	return path, nil
+}
@@ -288,6 +288,34 @@ func (c Controller) LogBatch(ctx *fiber.Ctx) error {
 	return ctx.JSON(fiber.Map{})
 }
 
+// LogInputs handles `POST /runs/log-inputs` endpoint.
+func (c Controller) LogInputs(ctx *fiber.Ctx) error {
+	var req request.LogInputsRequest
+	if err := ctx.BodyParser(&req); err != nil {
+		if err, ok := err.(*json.UnmarshalTypeError); ok {
+			return api.NewInvalidParameterValueError(
+				"Invalid value for parameter '%s' supplied. Hint: Value was of type '%s'. "+
+					"See the API docs for more information about request parameters.",
+				err.Field, err.Value,
+			)
+		}
+		return api.NewBadRequestError("Unable to decode request body: %s", err)
+	}
+	log.Debugf("logInputs request: %#v", req)
+
+	ns, err := middleware.GetNamespaceFromContext(ctx.Context())
+	if err != nil {
+		return api.NewInternalError("error getting namespace from context")
+	}
+	log.Debugf("logInputs namespace: %s", ns.Code)
+
+	if err := c.runService.LogInputs(ctx.Context(), ns, &req); err != nil {
+		return err
+	}
+
+	return ctx.JSON(fiber.Map{})
+}
+
 // LogOutput handles `POST /runs/log-output` endpoint.
 func (c Controller) LogOutput(ctx *fiber.Ctx) error {
 	var req request.LogOutputRequest
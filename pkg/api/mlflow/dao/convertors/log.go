@@ -98,3 +98,30 @@ func ConvertLogBatchRequestToDBModel(
 	}
 	return metrics, params, tags, nil
 }
+
+// ConvertLogInputsRequestToDBModel converts request.LogInputsRequest into actual []models.Input models.
+func ConvertLogInputsRequestToDBModel(run *models.Run, req *request.LogInputsRequest) []models.Input {
+	inputs := make([]models.Input, len(req.Datasets))
+	for i, input := range req.Datasets {
+		inputs[i] = models.Input{
+			RunID: run.ID,
+			Dataset: models.Dataset{
+				Name:         input.Dataset.Name,
+				Digest:       input.Dataset.Digest,
+				SourceType:   input.Dataset.SourceType,
+				Source:       input.Dataset.Source,
+				Schema:       input.Dataset.Schema,
+				Profile:      input.Dataset.Profile,
+				ExperimentID: run.ExperimentID,
+			},
+			Tags: make([]models.InputTag, len(input.Tags)),
+		}
+		for j, tag := range input.Tags {
+			inputs[i].Tags[j] = models.InputTag{
+				Key:   tag.Key,
+				Value: tag.Value,
+			}
+		}
+	}
+	return inputs
+}
@@ -297,3 +297,40 @@ func TestConvertLogBatchRequestToDBModel_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertLogInputsRequestToDBModel_Ok(t *testing.T) {
+	req := request.LogInputsRequest{
+		RunID: "run_id",
+		Datasets: []request.DatasetInputPartialRequest{{
+			Tags: []request.InputTagPartialRequest{{
+				Key:   "mlflow.data.context",
+				Value: "training",
+			}},
+			Dataset: request.DatasetPartialRequest{
+				Name:       "name",
+				Digest:     "digest",
+				SourceType: "local",
+				Source:     "source",
+				Schema:     "schema",
+				Profile:    "profile",
+			},
+		}},
+	}
+	result := ConvertLogInputsRequestToDBModel(&models.Run{ID: "run_id", ExperimentID: 1}, &req)
+	assert.Equal(t, []models.Input{{
+		RunID: "run_id",
+		Dataset: models.Dataset{
+			Name:         "name",
+			Digest:       "digest",
+			SourceType:   "local",
+			Source:       "source",
+			Schema:       "schema",
+			Profile:      "profile",
+			ExperimentID: 1,
+		},
+		Tags: []models.InputTag{{
+			Key:   "mlflow.data.context",
+			Value: "training",
+		}},
+	}}, result)
+}
@@ -49,3 +49,36 @@ func ConvertLogMetricRequestToDBModel(runID string, req *request.LogMetricReques
 	}
 	return &metric, nil
 }
+
+// ConvertLogMetricBulkPartialRequestToDBModel converts request.LogMetricBulkPartialRequest
+// into actual models.Metric model the same way as metrics logged by `log-batch`.
+func ConvertLogMetricBulkPartialRequestToDBModel(req *request.LogMetricBulkPartialRequest) (*models.Metric, error) {
+	metric := models.Metric{
+		Key:       req.Key,
+		Value:     req.Value,
+		Timestamp: req.Timestamp,
+		Step:      req.Step,
+		RunID:     req.RunID,
+	}
+	switch {
+	case math.IsNaN(req.Value):
+		metric.Value = 0
+		metric.IsNan = true
+	case math.IsInf(req.Value, 1):
+		metric.Value = math.MaxFloat64
+	case math.IsInf(req.Value, -1):
+		metric.Value = -math.MaxFloat64
+	}
+	if len(req.Context) == 0 {
+		metric.Context = models.DefaultContext
+	} else {
+		contextJSON, err := json.Marshal(req.Context)
+		if err != nil {
+			return nil, eris.Wrap(err, "error marshalling context")
+		}
+		metric.Context = models.Context{
+			Json: contextJSON,
+		}
+	}
+	return &metric, nil
+}
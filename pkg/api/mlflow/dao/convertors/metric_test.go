@@ -203,3 +203,63 @@ func TestConvertMetricParamRequestToDBModel_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertLogMetricBulkPartialRequestToDBModel_Ok(t *testing.T) {
+	testData := []struct {
+		name           string
+		request        *request.LogMetricBulkPartialRequest
+		expectedMetric *models.Metric
+	}{
+		{
+			name: "WithMetricNormalValue",
+			request: &request.LogMetricBulkPartialRequest{
+				RunID: "run_id", Key: "key", Value: 1.1, Timestamp: 1234567890, Step: 1,
+			},
+			expectedMetric: &models.Metric{
+				RunID: "run_id", Key: "key", Value: 1.1, Timestamp: 1234567890, Step: 1,
+				Context: models.DefaultContext,
+			},
+		},
+		{
+			name: "WithMetricNaNValue",
+			request: &request.LogMetricBulkPartialRequest{
+				RunID: "run_id", Key: "key", Value: math.NaN(), Timestamp: 1234567890, Step: 1,
+			},
+			expectedMetric: &models.Metric{
+				RunID: "run_id", Key: "key", Value: 0, IsNan: true, Timestamp: 1234567890, Step: 1,
+				Context: models.DefaultContext,
+			},
+		},
+		{
+			name: "WithMetricInfinityValues",
+			request: &request.LogMetricBulkPartialRequest{
+				RunID: "run_id", Key: "key", Value: math.Inf(-1), Timestamp: 1234567890, Step: 1,
+			},
+			expectedMetric: &models.Metric{
+				RunID: "run_id", Key: "key", Value: -math.MaxFloat64, Timestamp: 1234567890, Step: 1,
+				Context: models.DefaultContext,
+			},
+		},
+		{
+			name: "WithMetricContext",
+			request: &request.LogMetricBulkPartialRequest{
+				RunID: "run_id", Key: "key", Value: 1.1, Timestamp: 1234567890, Step: 1,
+				Context: map[string]any{"key2": 2.0, "key1": "value1"},
+			},
+			expectedMetric: &models.Metric{
+				RunID: "run_id", Key: "key", Value: 1.1, Timestamp: 1234567890, Step: 1,
+				Context: models.Context{
+					Json: []byte(`{"key1":"value1","key2":2}`),
+				},
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			metric, err := ConvertLogMetricBulkPartialRequestToDBModel(tt.request)
+			require.Nil(t, err)
+			assert.Equal(t, tt.expectedMetric, metric)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APITokenScope represents access scope of the API token in the namespace.
+type APITokenScope string
+
+// Supported list of API token scopes.
+const (
+	APITokenScopeRead  APITokenScope = "read"
+	APITokenScopeWrite APITokenScope = "write"
+)
+
+// HashAPIToken returns hash of the API token, which is stored in database instead of the token itself.
+func HashAPIToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// APIToken represents model to work with `api_tokens` table.
+type APIToken struct {
+	Base
+	Name        string               `gorm:"type:varchar(256);not null"`
+	Description string               `gorm:"type:varchar(500)"`
+	TokenHash   string               `gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt   *time.Time           `gorm:"index"`
+	Permissions []APITokenPermission `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// IsExpired makes check that APIToken is expired at the given moment.
+func (t APIToken) IsExpired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
+
+// APITokenPermission represents model to work with `api_token_permissions` table.
+type APITokenPermission struct {
+	APITokenID  uuid.UUID     `gorm:"type:uuid;not null;primaryKey"`
+	NamespaceID uint          `gorm:"not null;primaryKey"`
+	Namespace   Namespace     `gorm:"constraint:OnDelete:CASCADE"`
+	Scope       APITokenScope `gorm:"type:varchar(5);not null;check:scope IN ('read', 'write')"`
+}
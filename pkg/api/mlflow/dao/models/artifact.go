@@ -5,7 +5,22 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/rotisserie/eris"
+	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+)
+
+// ArtifactType represents the kind of Aim sequence the artifact belongs to.
+type ArtifactType string
+
+// Supported list of ArtifactType.
+const (
+	ArtifactTypeImages        ArtifactType = "images"
+	ArtifactTypeTexts         ArtifactType = "texts"
+	ArtifactTypeAudios        ArtifactType = "audios"
+	ArtifactTypeDistributions ArtifactType = "distributions"
+	ArtifactTypeFigures       ArtifactType = "figures"
 )
 
 // Artifact represents the artifact model.
@@ -22,14 +37,16 @@ type Artifact struct {
 	Format    string
 	Caption   string
 	BlobURI   string
+	Type      ArtifactType `gorm:"type:varchar(16);not null;default:images;index"`
+	Text      string
+	Data      types.JSONB
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // AfterSave will calculate the iter number for this step sequence based on creation time.
 func (u *Artifact) AfterSave(tx *gorm.DB) error {
-	if err := tx.Exec(
-		`UPDATE artifacts
+	query := `UPDATE artifacts
 	         SET iter = rows.new_iter
                  FROM (
                    SELECT id, ROW_NUMBER() OVER (ORDER BY created_at) as new_iter
@@ -37,9 +54,23 @@ func (u *Artifact) AfterSave(tx *gorm.DB) error {
                    WHERE run_uuid = ?
                    AND name = ?
                    AND step = ?
+                   AND type = ?
                  ) as rows
-	         WHERE artifacts.id = rows.id`,
-		u.RunID, u.Name, u.Step,
+	         WHERE artifacts.id = rows.id`
+	if tx.Dialector.Name() == (mysql.Dialector{}).Name() {
+		query = `UPDATE artifacts
+	         JOIN (
+                   SELECT id, ROW_NUMBER() OVER (ORDER BY created_at) as new_iter
+                   FROM artifacts
+                   WHERE run_uuid = ?
+                   AND name = ?
+                   AND step = ?
+                   AND type = ?
+                 ) as numbered_artifacts ON artifacts.id = numbered_artifacts.id
+	         SET artifacts.iter = numbered_artifacts.new_iter`
+	}
+	if err := tx.Exec(
+		query, u.RunID, u.Name, u.Step, u.Type,
 	).Error; err != nil {
 		return eris.Wrap(err, "error updating artifacts iter")
 	}
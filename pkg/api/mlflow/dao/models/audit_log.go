@@ -0,0 +1,15 @@
+package models
+
+// AuditLog represents a row of the `audit_logs` table. Every record describes single mutating
+// request of Mlflow, Aim or Admin API. Records aren't connected to the namespace with foreign key,
+// so they outlive deleted namespaces.
+type AuditLog struct {
+	ID          uint   `gorm:"primaryKey;autoIncrement"`
+	Timestamp   int64  `gorm:"not null;index"`
+	NamespaceID *uint  `gorm:"index"`
+	Actor       string `gorm:"type:varchar(256);not null;index"`
+	Method      string `gorm:"type:varchar(16);not null"`
+	Path        string `gorm:"type:varchar(1024);not null"`
+	EntityIDs   string `gorm:"column:entity_ids;type:text"`
+	StatusCode  int    `gorm:"not null"`
+}
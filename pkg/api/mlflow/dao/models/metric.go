@@ -39,6 +39,7 @@ type LatestMetric struct {
 	LastIter  int64
 	ContextID uint `gorm:"not null;primaryKey"`
 	Context   Context
+	LatestMetricSummary
 }
 
 // UniqueKey is a compound unique key for this metric series.
@@ -46,6 +47,39 @@ func (m LatestMetric) UniqueKey() string {
 	return fmt.Sprintf("%v-%v-%v", m.RunID, m.Key, m.ContextID)
 }
 
+// LatestMetricSummary represents summary of all the non NaN values of the metric series,
+// which is maintained together with the latest metric, so runs could be searched by it.
+type LatestMetricSummary struct {
+	MinValue   *float64 `gorm:"type:double precision"`
+	MaxValue   *float64 `gorm:"type:double precision"`
+	SumValue   float64  `gorm:"type:double precision;not null;default:0"`
+	ValueCount int64    `gorm:"not null;default:0"`
+}
+
+// Add adds the metric value to the summary. NaN values are skipped.
+func (s *LatestMetricSummary) Add(value float64, isNan bool) {
+	if isNan {
+		return
+	}
+	if s.MinValue == nil || value < *s.MinValue {
+		s.MinValue = &value
+	}
+	if s.MaxValue == nil || value > *s.MaxValue {
+		s.MaxValue = &value
+	}
+	s.SumValue += value
+	s.ValueCount++
+}
+
+// Mean returns the mean of the metric values or nil, if there are no values.
+func (s LatestMetricSummary) Mean() *float64 {
+	if s.ValueCount == 0 {
+		return nil
+	}
+	mean := s.SumValue / float64(s.ValueCount)
+	return &mean
+}
+
 // Context represents model to work with `contexts` table.
 type Context struct {
 	ID   uint        `gorm:"primaryKey;autoIncrement"`
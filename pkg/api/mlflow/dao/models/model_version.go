@@ -0,0 +1,69 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ModelVersionStage represents Model Version stage.
+type ModelVersionStage string
+
+// Supported list of Model Version stages.
+const (
+	ModelVersionStageNone            ModelVersionStage = "None"
+	ModelVersionStageStaging         ModelVersionStage = "Staging"
+	ModelVersionStageProduction      ModelVersionStage = "Production"
+	ModelVersionStageArchived        ModelVersionStage = "Archived"
+	ModelVersionStageDeletedInternal ModelVersionStage = "Deleted_Internal"
+)
+
+// ModelVersionStatus represents Model Version status.
+type ModelVersionStatus string
+
+// Supported list of Model Version statuses.
+const (
+	ModelVersionStatusPendingRegistration ModelVersionStatus = "PENDING_REGISTRATION"
+	ModelVersionStatusFailedRegistration  ModelVersionStatus = "FAILED_REGISTRATION"
+	ModelVersionStatusReady               ModelVersionStatus = "READY"
+)
+
+// ModelVersion represents model to work with `model_versions` table.
+//
+//nolint:lll
+type ModelVersion struct {
+	ID                uuid.UUID          `gorm:"type:uuid;primaryKey"`
+	Version           int64              `gorm:"not null;index:,unique,composite:version"`
+	RegisteredModelID uuid.UUID          `gorm:"type:uuid;not null;index:,unique,composite:version"`
+	RegisteredModel   RegisteredModel    `gorm:"constraint:OnDelete:CASCADE"`
+	CreationTime      int64              `gorm:"type:bigint;not null"`
+	LastUpdateTime    int64              `gorm:"type:bigint;not null"`
+	Description       string             `gorm:"type:varchar(5000)"`
+	UserID            string             `gorm:"type:varchar(256)"`
+	CurrentStage      ModelVersionStage  `gorm:"type:varchar(20);check:current_stage IN ('None', 'Staging', 'Production', 'Archived', 'Deleted_Internal')"`
+	Source            string             `gorm:"type:varchar(500)"`
+	RunID             string             `gorm:"column:run_uuid;type:varchar(32);index"`
+	RunLink           string             `gorm:"type:varchar(500)"`
+	Status            ModelVersionStatus `gorm:"type:varchar(20)"`
+	StatusMessage     string             `gorm:"type:varchar(500)"`
+	Tags              []ModelVersionTag  `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate triggers by GORM before create.
+func (v *ModelVersion) BeforeCreate(tx *gorm.DB) error {
+	if v.ID == uuid.Nil {
+		v.ID = uuid.New()
+	}
+	return nil
+}
+
+// IsDeleted makes check that Model Version has been deleted.
+func (v ModelVersion) IsDeleted() bool {
+	return v.CurrentStage == ModelVersionStageDeletedInternal
+}
+
+// ModelVersionTag represents model to work with `model_version_tags` table.
+type ModelVersionTag struct {
+	Key            string    `gorm:"type:varchar(250);not null;primaryKey"`
+	Value          string    `gorm:"type:varchar(5000)"`
+	ModelVersionID uuid.UUID `gorm:"type:uuid;not null;primaryKey"`
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RegisteredModel represents model to work with `registered_models` table.
+type RegisteredModel struct {
+	ID             uuid.UUID              `gorm:"type:uuid;primaryKey"`
+	Name           string                 `gorm:"type:varchar(256);not null;index:,unique,composite:name"`
+	Description    string                 `gorm:"type:varchar(5000)"`
+	CreationTime   int64                  `gorm:"type:bigint;not null"`
+	LastUpdateTime int64                  `gorm:"type:bigint;not null"`
+	NamespaceID    uint                   `gorm:"not null;index:,unique,composite:name"`
+	Namespace      Namespace              `gorm:"constraint:OnDelete:CASCADE"`
+	Tags           []RegisteredModelTag   `gorm:"constraint:OnDelete:CASCADE"`
+	Aliases        []RegisteredModelAlias `gorm:"constraint:OnDelete:CASCADE"`
+	Versions       []ModelVersion         `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+// BeforeCreate triggers by GORM before create.
+func (m *RegisteredModel) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = uuid.New()
+	}
+	return nil
+}
+
+// GetAliasesByVersion returns the list of aliases pointing to the provided version.
+func (m RegisteredModel) GetAliasesByVersion(version int64) []string {
+	var aliases []string
+	for _, alias := range m.Aliases {
+		if alias.Version == version {
+			aliases = append(aliases, alias.Alias)
+		}
+	}
+	return aliases
+}
+
+// GetLatestVersions returns the latest Model Version for each of the provided stages.
+// If no stages were provided, then the latest Model Version of every stage is returned.
+func (m RegisteredModel) GetLatestVersions(stages ...ModelVersionStage) []ModelVersion {
+	latest := map[ModelVersionStage]ModelVersion{}
+	for _, version := range m.Versions {
+		if version.IsDeleted() {
+			continue
+		}
+		if current, ok := latest[version.CurrentStage]; !ok || version.Version > current.Version {
+			latest[version.CurrentStage] = version
+		}
+	}
+
+	if len(stages) == 0 {
+		stages = []ModelVersionStage{
+			ModelVersionStageNone,
+			ModelVersionStageStaging,
+			ModelVersionStageProduction,
+			ModelVersionStageArchived,
+		}
+	}
+
+	versions := make([]ModelVersion, 0, len(stages))
+	for _, stage := range stages {
+		if version, ok := latest[stage]; ok {
+			versions = append(versions, version)
+		}
+	}
+	return versions
+}
+
+// RegisteredModelTag represents model to work with `registered_model_tags` table.
+type RegisteredModelTag struct {
+	Key               string    `gorm:"type:varchar(250);not null;primaryKey"`
+	Value             string    `gorm:"type:varchar(5000)"`
+	RegisteredModelID uuid.UUID `gorm:"type:uuid;not null;primaryKey"`
+}
+
+// RegisteredModelAlias represents model to work with `registered_model_aliases` table.
+type RegisteredModelAlias struct {
+	Alias             string    `gorm:"type:varchar(256);not null;primaryKey"`
+	Version           int64     `gorm:"not null"`
+	RegisteredModelID uuid.UUID `gorm:"type:uuid;not null;primaryKey"`
+}
@@ -12,4 +12,6 @@ type RoleNamespace struct {
 	RoleID      uuid.UUID `gorm:"not null;index:,unique,composite:relation"`
 	Namespace   Namespace `gorm:"constraint:OnDelete:CASCADE"`
 	NamespaceID uint      `gorm:"not null;index:,unique,composite:relation"`
+	// Level keeps namespace role (viewer, editor or owner), which is granted to the Role.
+	Level string `gorm:"type:varchar(8);not null;default:owner"`
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 
+	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
@@ -45,6 +46,7 @@ type Run struct {
 	Logs           []Log          `gorm:"constraint:OnDelete:CASCADE"`
 	Metrics        []Metric       `gorm:"constraint:OnDelete:CASCADE"`
 	LatestMetrics  []LatestMetric `gorm:"constraint:OnDelete:CASCADE"`
+	Inputs         []Input        `gorm:"constraint:OnDelete:CASCADE"`
 }
 
 // RowNum represents custom data type.
@@ -68,6 +70,15 @@ func (rn RowNum) GormDataType() string {
 // GormValue implements Gorm interface for custom data types.
 func (rn RowNum) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
 	if rn == 0 {
+		switch db.Dialector.Name() {
+		case mysql.Dialector{}.Name():
+			// MySQL doesn't allow to select from the table being inserted into,
+			// unless the subquery result is materialized into a derived table.
+			return clause.Expr{
+				SQL: "(SELECT next_row_num FROM " +
+					"(SELECT COALESCE(MAX(row_num), -1) + 1 AS next_row_num FROM runs) AS max_row_num)",
+			}
+		}
 		return clause.Expr{
 			SQL: "(SELECT COALESCE(MAX(row_num), -1) FROM runs) + 1",
 		}
@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// APITokenRepositoryProvider provides an interface to work with models.APIToken entity.
+type APITokenRepositoryProvider interface {
+	repositories.BaseRepositoryProvider
+	// Create creates new models.APIToken entity together with its permissions.
+	Create(ctx context.Context, token *models.APIToken) error
+	// Delete removes existing models.APIToken entity.
+	Delete(ctx context.Context, token *models.APIToken) error
+	// GetByID returns models.APIToken entity by its ID.
+	GetByID(ctx context.Context, id uuid.UUID) (*models.APIToken, error)
+	// GetByTokenHash returns models.APIToken entity by hash of the token.
+	GetByTokenHash(ctx context.Context, tokenHash string) (*models.APIToken, error)
+	// List returns all models.APIToken entities.
+	List(ctx context.Context) ([]models.APIToken, error)
+}
+
+// APITokenRepository repository to work with models.APIToken entity.
+type APITokenRepository struct {
+	repositories.BaseRepositoryProvider
+}
+
+// NewAPITokenRepository creates repository to work with models.APIToken entity.
+func NewAPITokenRepository(db *gorm.DB) *APITokenRepository {
+	return &APITokenRepository{
+		repositories.NewBaseRepository(db),
+	}
+}
+
+// Create creates new models.APIToken entity together with its permissions.
+func (r APITokenRepository) Create(ctx context.Context, token *models.APIToken) error {
+	if err := r.GetDB().WithContext(ctx).Omit("Permissions.Namespace").Create(token).Error; err != nil {
+		return eris.Wrap(err, "error creating api token entity")
+	}
+	return nil
+}
+
+// Delete removes existing models.APIToken entity.
+func (r APITokenRepository) Delete(ctx context.Context, token *models.APIToken) error {
+	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(
+			"api_token_id = ?", token.ID,
+		).Delete(&models.APITokenPermission{}).Error; err != nil {
+			return eris.Wrap(err, "error deleting api token permissions")
+		}
+		return tx.Delete(token).Error
+	}); err != nil {
+		return eris.Wrapf(err, "error deleting api token entity with id: %s", token.ID)
+	}
+	return nil
+}
+
+// GetByID returns models.APIToken entity by its ID.
+func (r APITokenRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.APIToken, error) {
+	var token models.APIToken
+	if err := r.GetDB().WithContext(ctx).Preload(
+		"Permissions.Namespace",
+	).Where(
+		"id = ?", id,
+	).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, eris.Wrapf(err, "error getting api token by id: %s", id)
+	}
+	return &token, nil
+}
+
+// GetByTokenHash returns models.APIToken entity by hash of the token.
+func (r APITokenRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*models.APIToken, error) {
+	var token models.APIToken
+	if err := r.GetDB().WithContext(ctx).Preload(
+		"Permissions.Namespace",
+	).Where(
+		"token_hash = ?", tokenHash,
+	).First(&token).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, eris.Wrap(err, "error getting api token by hash")
+	}
+	return &token, nil
+}
+
+// List returns all models.APIToken entities.
+func (r APITokenRepository) List(ctx context.Context) ([]models.APIToken, error) {
+	var tokens []models.APIToken
+	if err := r.GetDB().WithContext(ctx).Preload(
+		"Permissions.Namespace",
+	).Order(
+		"created_at",
+	).Find(&tokens).Error; err != nil {
+		return nil, eris.Wrap(err, "error listing api tokens")
+	}
+	return tokens, nil
+}
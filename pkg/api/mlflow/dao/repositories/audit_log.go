@@ -0,0 +1,119 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// AuditLogsFilter represents filter of audit log records. Records are ordered from the newest to the oldest.
+type AuditLogsFilter struct {
+	NamespaceID *uint
+	Actor       string
+	Since       int64
+	Until       int64
+	Limit       int
+}
+
+// AuditLogRepositoryProvider provides an interface to work with models.AuditLog entity.
+type AuditLogRepositoryProvider interface {
+	repositories.BaseRepositoryProvider
+	// Create creates new models.AuditLog entity.
+	Create(ctx context.Context, auditLog *models.AuditLog) error
+	// List returns models.AuditLog entities matching the filter.
+	List(ctx context.Context, filter AuditLogsFilter) ([]models.AuditLog, error)
+	// GetRows returns cursor over models.AuditLog entities matching the filter.
+	GetRows(
+		ctx context.Context, filter AuditLogsFilter,
+	) (*sql.Rows, func(rows *sql.Rows) (*models.AuditLog, error), error)
+	// CleanExpired deletes audit log records older than provided period.
+	CleanExpired(ctx context.Context, period time.Duration) (int64, error)
+}
+
+// AuditLogRepository repository to work with models.AuditLog entity.
+type AuditLogRepository struct {
+	repositories.BaseRepositoryProvider
+}
+
+// NewAuditLogRepository creates repository to work with models.AuditLog entity.
+func NewAuditLogRepository(db *gorm.DB) *AuditLogRepository {
+	return &AuditLogRepository{
+		repositories.NewBaseRepository(db),
+	}
+}
+
+// Create creates new models.AuditLog entity.
+func (r AuditLogRepository) Create(ctx context.Context, auditLog *models.AuditLog) error {
+	if err := r.GetDB().WithContext(ctx).Create(auditLog).Error; err != nil {
+		return eris.Wrap(err, "error creating audit log entity")
+	}
+	return nil
+}
+
+// List returns models.AuditLog entities matching the filter.
+func (r AuditLogRepository) List(ctx context.Context, filter AuditLogsFilter) ([]models.AuditLog, error) {
+	var auditLogs []models.AuditLog
+	if err := r.applyFilter(r.GetDB().WithContext(ctx), filter).Find(&auditLogs).Error; err != nil {
+		return nil, eris.Wrap(err, "error listing audit logs")
+	}
+	return auditLogs, nil
+}
+
+// GetRows returns cursor over models.AuditLog entities matching the filter.
+func (r AuditLogRepository) GetRows(
+	ctx context.Context, filter AuditLogsFilter,
+) (*sql.Rows, func(rows *sql.Rows) (*models.AuditLog, error), error) {
+	rows, err := r.applyFilter(r.GetDB().WithContext(ctx), filter).Model(&models.AuditLog{}).Rows()
+	if err != nil {
+		return nil, nil, eris.Wrap(err, "error getting audit logs")
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, eris.Wrap(err, "error getting query result")
+	}
+
+	return rows, func(rows *sql.Rows) (*models.AuditLog, error) {
+		var auditLog models.AuditLog
+		if err := r.GetDB().ScanRows(rows, &auditLog); err != nil {
+			return nil, eris.Wrap(err, "error scanning audit log")
+		}
+		return &auditLog, nil
+	}, nil
+}
+
+// CleanExpired deletes audit log records older than provided period.
+func (r AuditLogRepository) CleanExpired(ctx context.Context, period time.Duration) (int64, error) {
+	result := r.GetDB().WithContext(ctx).Where(
+		"timestamp < ?", time.Now().Add(-period).UnixMilli(),
+	).Delete(&models.AuditLog{})
+	if err := result.Error; err != nil {
+		return 0, eris.Wrap(err, "error deleting expired audit logs")
+	}
+	return result.RowsAffected, nil
+}
+
+// applyFilter applies filter conditions to the query.
+func (r AuditLogRepository) applyFilter(tx *gorm.DB, filter AuditLogsFilter) *gorm.DB {
+	tx = tx.Order("id DESC")
+	if filter.NamespaceID != nil {
+		tx = tx.Where("namespace_id = ?", *filter.NamespaceID)
+	}
+	if filter.Actor != "" {
+		tx = tx.Where("actor = ?", filter.Actor)
+	}
+	if filter.Since > 0 {
+		tx = tx.Where("timestamp >= ?", filter.Since)
+	}
+	if filter.Until > 0 {
+		tx = tx.Where("timestamp < ?", filter.Until)
+	}
+	if filter.Limit > 0 {
+		tx = tx.Limit(filter.Limit)
+	}
+	return tx
+}
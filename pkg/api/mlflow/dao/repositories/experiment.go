@@ -7,7 +7,6 @@ import (
 
 	"github.com/rotisserie/eris"
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
@@ -29,6 +28,9 @@ type ExperimentRepositoryProvider interface {
 	GetByNamespaceIDAndExperimentID(
 		ctx context.Context, namespaceID uint, experimentID int32,
 	) (*models.Experiment, error)
+	// GetDeletedBefore returns experiments of the namespace, which were deleted before provided time.
+	// Zero time means any deleted ones.
+	GetDeletedBefore(ctx context.Context, namespaceID uint, before int64) ([]models.Experiment, error)
 	// UpdateWithTransaction updates existing models.Experiment entity in scope of transaction.
 	UpdateWithTransaction(ctx context.Context, tx *gorm.DB, experiment *models.Experiment) error
 }
@@ -99,6 +101,26 @@ func (r ExperimentRepository) GetByNamespaceIDAndName(
 	return &experiment, nil
 }
 
+// GetDeletedBefore returns experiments of the namespace, which were deleted before provided time.
+// Zero time means any deleted ones.
+func (r ExperimentRepository) GetDeletedBefore(
+	ctx context.Context, namespaceID uint, before int64,
+) ([]models.Experiment, error) {
+	tx := r.GetDB().WithContext(ctx).Where(
+		"experiments.namespace_id = ?", namespaceID,
+	).Where(
+		"experiments.lifecycle_stage = ?", models.LifecycleStageDeleted,
+	)
+	if before > 0 {
+		tx = tx.Where("experiments.last_update_time < ?", before)
+	}
+	var experiments []models.Experiment
+	if err := tx.Order("experiments.experiment_id").Find(&experiments).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting deleted experiments of namespace with id: %d", namespaceID)
+	}
+	return experiments, nil
+}
+
 // Update updates existing models.Experiment entity.
 func (r ExperimentRepository) Update(ctx context.Context, experiment *models.Experiment) error {
 	if err := r.GetDB().Transaction(func(tx *gorm.DB) error {
@@ -149,20 +171,34 @@ func (r ExperimentRepository) DeleteBatch(ctx context.Context, ids []*int32) err
 			return err
 		}
 
-		experiments := make([]models.Experiment, 0, len(ids))
-		if err := tx.Clauses(
-			clause.Returning{Columns: []clause.Column{{Name: "experiment_id"}}},
+		// logs aren't removed by cascade, so they have to be removed explicitly.
+		if err := tx.Where(
+			"run_uuid IN (?)", tx.Model(&models.Run{}).Select("run_uuid").Where("experiment_id IN ?", ids),
+		).Delete(&models.Log{}).Error; err != nil {
+			return eris.Wrapf(err, "error deleting logs of experiments with ids: %d", ids)
+		}
+
+		// experiments are counted before deletion, because MySQL doesn't support RETURNING clause.
+		var count int64
+		if err := tx.Model(
+			&models.Experiment{},
 		).Where(
 			"experiment_id IN ?", ids,
-		).Delete(&experiments).Error; err != nil {
-			return eris.Wrapf(err, "error deleting existing experiments with ids: %d", ids)
+		).Count(&count).Error; err != nil {
+			return eris.Wrapf(err, "error counting existing experiments with ids: %d", ids)
 		}
 
 		// verify deletion
-		if len(experiments) != len(ids) {
+		if count != int64(len(ids)) {
 			return eris.New("count of deleted experiments does not match length of ids input (invalid experiment ID?)")
 		}
 
+		if err := tx.Where(
+			"experiment_id IN ?", ids,
+		).Delete(&models.Experiment{}).Error; err != nil {
+			return eris.Wrapf(err, "error deleting existing experiments with ids: %d", ids)
+		}
+
 		// renumbering the remainder runs
 		if minRowNum.Valid {
 			runRepo := NewRunRepository(tx)
@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strconv"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao"
+	"github.com/G-Research/fasttrackml/pkg/common/events"
+)
+
+// ExperimentCachedRepository cached repository to work with `experiment` entity.
+// Cache is invalidated by the experiment events, which are published for every change of
+// experiments or their tags, so changes made by other instances are picked up as well.
+type ExperimentCachedRepository struct {
+	cache                *lru.Cache[int32, models.Experiment]
+	experimentRepository ExperimentRepositoryProvider
+}
+
+// NewExperimentCachedRepository creates new instance of cached repository to work with `experiment` entity.
+func NewExperimentCachedRepository(
+	ctx context.Context,
+	experimentRepository ExperimentRepositoryProvider,
+	eventListener dao.EventListenerProvider,
+) (*ExperimentCachedRepository, error) {
+	cache, err := lru.New[int32, models.Experiment](1000)
+	if err != nil {
+		return nil, eris.Wrap(err, "error creating lru cache for experiment entities")
+	}
+
+	repository := ExperimentCachedRepository{
+		cache:                cache,
+		experimentRepository: experimentRepository,
+	}
+
+	ch := make(chan string)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case data := <-ch:
+				if err := repository.processEvent(data); err != nil {
+					log.Errorf(`error processing incoming event: %s, error: %+v`, data, err)
+				}
+			}
+		}
+	}()
+
+	// subscribe to incoming events.
+	eventListener.Subscribe(events.ExperimentChannel, ch)
+
+	return &repository, nil
+}
+
+// Create creates new models.Experiment entity.
+func (r ExperimentCachedRepository) Create(ctx context.Context, experiment *models.Experiment) error {
+	return r.experimentRepository.Create(ctx, experiment)
+}
+
+// Update updates existing models.Experiment entity.
+func (r ExperimentCachedRepository) Update(ctx context.Context, experiment *models.Experiment) error {
+	if err := r.experimentRepository.Update(ctx, experiment); err != nil {
+		return eris.Wrap(err, "error updating cached experiment entity")
+	}
+
+	// event is delivered asynchronously, so local cache is updated right away.
+	r.cache.Remove(*experiment.ID)
+	return nil
+}
+
+// Delete removes the existing models.Experiment from the db.
+func (r ExperimentCachedRepository) Delete(ctx context.Context, experiment *models.Experiment) error {
+	return r.DeleteBatch(ctx, []*int32{experiment.ID})
+}
+
+// DeleteBatch removes existing []models.Experiment in batch from the db.
+func (r ExperimentCachedRepository) DeleteBatch(ctx context.Context, ids []*int32) error {
+	if err := r.experimentRepository.DeleteBatch(ctx, ids); err != nil {
+		return eris.Wrap(err, "error deleting cached experiment entities")
+	}
+	for _, id := range ids {
+		r.cache.Remove(*id)
+	}
+	return nil
+}
+
+// GetByNamespaceIDAndName returns experiment by Namespace ID and Experiment name.
+func (r ExperimentCachedRepository) GetByNamespaceIDAndName(
+	ctx context.Context, namespaceID uint, name string,
+) (*models.Experiment, error) {
+	return r.experimentRepository.GetByNamespaceIDAndName(ctx, namespaceID, name)
+}
+
+// GetByNamespaceIDAndExperimentID returns experiment by Namespace ID and Experiment ID.
+func (r ExperimentCachedRepository) GetByNamespaceIDAndExperimentID(
+	ctx context.Context, namespaceID uint, experimentID int32,
+) (*models.Experiment, error) {
+	if experiment, ok := r.cache.Get(experimentID); ok && experiment.NamespaceID == namespaceID {
+		return copyExperiment(experiment), nil
+	}
+
+	experiment, err := r.experimentRepository.GetByNamespaceIDAndExperimentID(ctx, namespaceID, experimentID)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.Add(experimentID, *copyExperiment(*experiment))
+	return experiment, nil
+}
+
+// GetDeletedBefore returns experiments of the namespace, which were deleted before provided time.
+func (r ExperimentCachedRepository) GetDeletedBefore(
+	ctx context.Context, namespaceID uint, before int64,
+) ([]models.Experiment, error) {
+	return r.experimentRepository.GetDeletedBefore(ctx, namespaceID, before)
+}
+
+// UpdateWithTransaction updates existing models.Experiment entity in scope of transaction.
+// Cache is invalidated by the experiment event, as transaction could be rolled back yet.
+func (r ExperimentCachedRepository) UpdateWithTransaction(
+	ctx context.Context, tx *gorm.DB, experiment *models.Experiment,
+) error {
+	return r.experimentRepository.UpdateWithTransaction(ctx, tx, experiment)
+}
+
+// processEvent process incoming event from database.
+func (r ExperimentCachedRepository) processEvent(data string) error {
+	log.Debugf("got incoming experiment event: %s", data)
+	event := events.EntityEvent{}
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		return eris.Wrap(err, "error unmarshaling incoming database event")
+	}
+	// changed experiments are unknown, for example, when tags are changed, so drop all of them.
+	if event.Action == events.EventActionReset || len(event.IDs) == 0 {
+		r.cache.Purge()
+		return nil
+	}
+	for _, id := range event.IDs {
+		experimentID, err := strconv.ParseInt(id, 10, 32)
+		if err != nil {
+			return eris.Wrapf(err, "error parsing experiment id: %s", id)
+		}
+		r.cache.Remove(int32(experimentID))
+	}
+	return nil
+}
+
+// copyExperiment returns a copy of experiment, so callers can't modify cached entity.
+func copyExperiment(experiment models.Experiment) *models.Experiment {
+	if experiment.ID != nil {
+		id := *experiment.ID
+		experiment.ID = &id
+	}
+	experiment.Tags = slices.Clone(experiment.Tags)
+	return &experiment
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 
@@ -22,9 +23,13 @@ func makeSqlPlaceholders(numberInEachSet, numberOfSets int) string {
 func makeParamConflictPlaceholdersAndValues(params []models.Param, dialector string) (string, []interface{}) {
 	var placeholders string
 	// make place holders of 5 fields for each param
-	if (sqlite.Dialector{}.Name() == dialector) {
+	switch dialector {
+	case sqlite.Dialector{}.Name():
 		placeholders = fmt.Sprintf("VALUES %s", makeSqlPlaceholders(5, len(params)))
-	} else {
+	case mysql.Dialector{}.Name():
+		set := "ROW(?,?,?,?,?)"
+		placeholders = "VALUES " + strings.Repeat(set+",", len(params)-1) + set
+	default:
 		set := "SELECT ?::text, ?::text, ?::int, ?::float, ?::text"
 		placeholders = strings.Repeat(set+"\nUNION ALL\n", len(params)-1) + set
 	}
@@ -68,6 +73,14 @@ func BuildJsonCondition(
 			args[idx+1] = v
 			idx = idx + 2
 		}
+	case mysql.Dialector{}.Name():
+		conditionTemplate = "JSON_UNQUOTE(JSON_EXTRACT(%s, ?)) = ?"
+		idx := 0
+		for k, v := range jsonPathValueMap {
+			args[idx] = fmt.Sprintf("$.%s", k)
+			args[idx+1] = v
+			idx = idx + 2
+		}
 	default:
 		conditionTemplate = "%s->>? = ?"
 		idx := 0
@@ -71,6 +71,15 @@ func Test_makeParamConflictPlaceholdersAndValues(t *testing.T) {
 			expectedPlaceholders: "VALUES (?,?,?,?,?),(?,?,?,?,?)",
 			expectedValues:       []interface{}{"key1", "run1", nil, nil, "value1", "key2", "run2", nil, nil, "value2"},
 		},
+		{
+			params: []models.Param{
+				{Key: "key1", ValueStr: common.GetPointer("value1"), RunID: "run1"},
+				{Key: "key2", ValueInt: common.GetPointer(int64(2)), RunID: "run2"},
+			},
+			dialector:            "mysql",
+			expectedPlaceholders: "VALUES ROW(?,?,?,?,?),ROW(?,?,?,?,?)",
+			expectedValues:       []interface{}{"key1", "run1", nil, nil, "value1", "key2", "run2", int64(2), nil, nil},
+		},
 	}
 
 	for _, tt := range tests {
@@ -111,6 +120,18 @@ func TestBuildJsonCondition(t *testing.T) {
 			expectedSQL:  "contexts.json->>? = ? AND contexts.json->>? = ?",
 			expectedArgs: []interface{}{"$.key1", "value1", "$.key2.nested", "value2"},
 		},
+		{
+			name:           "MySQL",
+			dialector:      database.MySQLDialectorName,
+			jsonColumnName: "contexts.json",
+			jsonPathValueMap: map[string]string{
+				"key1":        "value1",
+				"key2.nested": "value2",
+			},
+			expectedSQL: "JSON_UNQUOTE(JSON_EXTRACT(contexts.json, ?)) = ? AND " +
+				"JSON_UNQUOTE(JSON_EXTRACT(contexts.json, ?)) = ?",
+			expectedArgs: []interface{}{"$.key1", "value1", "$.key2.nested", "value2"},
+		},
 		{
 			name:             "SqliteEmptyMap",
 			dialector:        database.SQLiteDialectorName,
@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// InputRepositoryProvider provides an interface to work with models.Input entity.
+type InputRepositoryProvider interface {
+	repositories.BaseRepositoryProvider
+	// CreateBatch creates []models.Input entities together with their models.Dataset and models.InputTag.
+	CreateBatch(ctx context.Context, inputs []models.Input) error
+}
+
+// InputRepository repository to work with models.Input entity.
+type InputRepository struct {
+	repositories.BaseRepositoryProvider
+}
+
+// NewInputRepository creates repository to work with models.Input entity.
+func NewInputRepository(db *gorm.DB) *InputRepository {
+	return &InputRepository{
+		repositories.NewBaseRepository(db),
+	}
+}
+
+// CreateBatch creates []models.Input entities together with their models.Dataset and models.InputTag.
+// Datasets are unique per experiment by name and digest, inputs are unique per run and dataset,
+// so already existing entities are reused and only input tags are updated.
+func (r InputRepository) CreateBatch(ctx context.Context, inputs []models.Input) error {
+	return r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, input := range inputs {
+			dataset := models.Dataset{}
+			if err := tx.Where(models.Dataset{
+				Name:         input.Dataset.Name,
+				Digest:       input.Dataset.Digest,
+				ExperimentID: input.Dataset.ExperimentID,
+			}).Attrs(models.Dataset{
+				SourceType: input.Dataset.SourceType,
+				Source:     input.Dataset.Source,
+				Schema:     input.Dataset.Schema,
+				Profile:    input.Dataset.Profile,
+			}).FirstOrCreate(&dataset).Error; err != nil {
+				return eris.Wrapf(err, "error creating dataset with name: %s", input.Dataset.Name)
+			}
+
+			entity := models.Input{}
+			if err := tx.Omit(clause.Associations).Where(models.Input{
+				DatasetID: dataset.ID,
+				RunID:     input.RunID,
+			}).FirstOrCreate(&entity).Error; err != nil {
+				return eris.Wrapf(err, "error creating input for run with id: %s", input.RunID)
+			}
+
+			if len(input.Tags) == 0 {
+				continue
+			}
+			tags := make([]models.InputTag, len(input.Tags))
+			for i, tag := range input.Tags {
+				tags[i] = models.InputTag{
+					Key:     tag.Key,
+					Value:   tag.Value,
+					InputID: entity.ID,
+				}
+			}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "input_id"}, {Name: "key"}},
+				DoUpdates: clause.AssignmentColumns([]string{"value"}),
+			}).Create(&tags).Error; err != nil {
+				return eris.Wrapf(err, "error creating input tags for run with id: %s", input.RunID)
+			}
+		}
+		return nil
+	})
+}
@@ -3,20 +3,30 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"fmt"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/rotisserie/eris"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+	"github.com/G-Research/fasttrackml/pkg/common/sampling"
+	"github.com/G-Research/fasttrackml/pkg/common/telemetry"
 	"github.com/G-Research/fasttrackml/pkg/database"
 )
 
 const (
 	MetricHistoriesDefaultLimit   = 10000000
 	MetricHistoryBulkDefaultLimit = 25000
+	// MetricCopyThreshold is a minimal number of metrics which are inserted using COPY on Postgres.
+	MetricCopyThreshold = 1000
 )
 
 // MetricRepositoryProvider provides an interface to work with models.Metric entity.
@@ -31,6 +41,8 @@ type MetricRepositoryProvider interface {
 		experimentIDs []string, runIDs []string, metricKeys []string,
 		viewType request.ViewType,
 		limit int32,
+		maxPoints int32,
+		samplingMode sampling.Mode,
 		jsonPathValueMap map[string]string,
 	) (*sql.Rows, func(*sql.Rows, interface{}) error, error)
 	// GetMetricHistoryBulk returns metrics history bulk.
@@ -107,6 +119,11 @@ func (r MetricRepository) CreateBatch(
 	).CreateInBatches(&uniqueContexts, batchSize).Error; err != nil {
 		return eris.Wrapf(err, "error creating contexts")
 	}
+	if r.GetDB().Dialector.Name() == (mysql.Dialector{}).Name() {
+		if err := r.loadContextIDs(ctx, uniqueContexts); err != nil {
+			return eris.Wrap(err, "error loading context ids")
+		}
+	}
 
 	for n := range metrics {
 		metrics[n].ContextID = allContexts[n].ID
@@ -132,13 +149,23 @@ func (r MetricRepository) CreateBatch(
 		}
 	}
 
-	if err := r.GetDB().WithContext(ctx).Clauses(
-		clause.OnConflict{DoNothing: true},
-	).CreateInBatches(&metrics, batchSize).Error; err != nil {
+	if err := r.insertMetrics(ctx, batchSize, metrics); err != nil {
 		return eris.Wrapf(err, "error creating metrics for run: %s", run.ID)
 	}
 
-	// TODO update latest metrics in the background?
+	// metrics, which already existed, are skipped by the insert, so they must not be added to the summaries.
+	insertedMetrics, err := r.getInsertedMetrics(ctx, run.ID, metricKeys, metrics)
+	if err != nil {
+		return eris.Wrapf(err, "error getting inserted metrics for run: %s", run.ID)
+	}
+	telemetry.MetricPointsIngestedTotal.Add(float64(len(insertedMetrics)))
+	summaries := make(map[string]models.LatestMetricSummary)
+	for _, metric := range insertedMetrics {
+		summary := summaries[metric.UniqueKey()]
+		summary.Add(metric.Value, metric.IsNan)
+		summaries[metric.UniqueKey()] = summary
+	}
+
 	currentLatestMetricsMap := make(map[string]models.LatestMetric, len(latestMetrics))
 	for k, m := range latestMetrics {
 		currentLatestMetricsMap[k] = m
@@ -158,17 +185,186 @@ func (r MetricRepository) CreateBatch(
 		}
 	}
 
+	for n := range updatedLatestMetrics {
+		updatedLatestMetrics[n].LatestMetricSummary = summaries[updatedLatestMetrics[n].UniqueKey()]
+	}
+
 	if len(updatedLatestMetrics) > 0 {
-		if err := r.GetDB().Clauses(clause.OnConflict{
-			Columns:   []clause.Column{{Name: "run_uuid"}, {Name: "key"}, {Name: "context_id"}},
-			UpdateAll: true,
-		}).CreateInBatches(&updatedLatestMetrics, batchSize).Error; err != nil {
+		if err := r.GetDB().WithContext(ctx).Clauses(
+			r.getLatestMetricsConflictClause(),
+		).CreateInBatches(&updatedLatestMetrics, batchSize).Error; err != nil {
 			return eris.Wrapf(err, "error updating latest metrics for run: %s", run.ID)
 		}
 	}
 	return nil
 }
 
+// metricRow identifies the stored metric row together with its iter.
+type metricRow struct {
+	key       string
+	value     float64
+	timestamp int64
+	step      int64
+	isNan     bool
+	contextID uint
+	iter      int64
+}
+
+// newMetricRow creates metricRow from the metric.
+func newMetricRow(metric *models.Metric) metricRow {
+	return metricRow{
+		key:       metric.Key,
+		value:     metric.Value,
+		timestamp: metric.Timestamp,
+		step:      metric.Step,
+		isNan:     metric.IsNan,
+		contextID: metric.ContextID,
+		iter:      metric.Iter,
+	}
+}
+
+// getInsertedMetrics returns the metrics, which have actually been inserted. Metrics, which already
+// existed, are stored with another iter, than the one they have just been assigned, so they aren't returned.
+func (r MetricRepository) getInsertedMetrics(
+	ctx context.Context, runID string, keys []string, metrics []models.Metric,
+) ([]models.Metric, error) {
+	rows := make(map[metricRow]struct{}, len(metrics))
+	minIter, maxIter := metrics[0].Iter, metrics[0].Iter
+	for n := range metrics {
+		rows[newMetricRow(&metrics[n])] = struct{}{}
+		minIter, maxIter = min(minIter, metrics[n].Iter), max(maxIter, metrics[n].Iter)
+	}
+
+	var storedMetrics []models.Metric
+	if err := r.GetDB().WithContext(ctx).Where(
+		"run_uuid = ?", runID,
+	).Where(
+		`"key" IN ?`, keys,
+	).Where(
+		"iter BETWEEN ? AND ?", minIter, maxIter,
+	).Find(&storedMetrics).Error; err != nil {
+		return nil, err
+	}
+	insertedMetrics := make([]models.Metric, 0, len(storedMetrics))
+	for n := range storedMetrics {
+		if _, ok := rows[newMetricRow(&storedMetrics[n])]; ok {
+			insertedMetrics = append(insertedMetrics, storedMetrics[n])
+		}
+	}
+	return insertedMetrics, nil
+}
+
+// getLatestMetricsConflictClause returns clause, which replaces the latest metric and adds summary of
+// the inserted values to the stored one within the same statement, so concurrent writes of the same
+// metric don't lose each other's values.
+func (r MetricRepository) getLatestMetricsConflictClause() clause.OnConflict {
+	stored, inserted := "latest_metrics.%s", "excluded.%s"
+	if r.GetDB().Dialector.Name() == (mysql.Dialector{}).Name() {
+		stored, inserted = "%s", "VALUES(%s)"
+	}
+	expression := func(format, column string) clause.Assignment {
+		return clause.Assignment{
+			Column: clause.Column{Name: column},
+			Value: gorm.Expr(fmt.Sprintf(
+				format, fmt.Sprintf(stored, column), fmt.Sprintf(inserted, column),
+			)),
+		}
+	}
+	return clause.OnConflict{
+		Columns: []clause.Column{{Name: "run_uuid"}, {Name: "key"}, {Name: "context_id"}},
+		DoUpdates: append(
+			clause.AssignmentColumns([]string{"value", "timestamp", "step", "is_nan", "last_iter"}),
+			expression("CASE WHEN %[1]s IS NULL OR %[2]s < %[1]s THEN %[2]s ELSE %[1]s END", "min_value"),
+			expression("CASE WHEN %[1]s IS NULL OR %[2]s > %[1]s THEN %[2]s ELSE %[1]s END", "max_value"),
+			expression("%s + %s", "sum_value"),
+			expression("%s + %s", "value_count"),
+		),
+	}
+}
+
+// loadContextIDs sets ids of already stored contexts. MySQL doesn't return ids of upserted rows,
+// so ids, which were assigned by the batch insert, aren't reliable.
+func (r MetricRepository) loadContextIDs(ctx context.Context, contexts []*models.Context) error {
+	jsons := make([]types.JSONB, len(contexts))
+	for n, c := range contexts {
+		jsons[n] = c.Json
+	}
+	var stored []models.Context
+	if err := r.GetDB().WithContext(ctx).Where("json IN ?", jsons).Find(&stored).Error; err != nil {
+		return err
+	}
+	ids := make(map[string]uint, len(stored))
+	for _, c := range stored {
+		ids[c.GetJsonHash()] = c.ID
+	}
+	for _, c := range contexts {
+		c.ID = ids[c.GetJsonHash()]
+	}
+	return nil
+}
+
+// insertMetrics inserts metrics ignoring already existing ones. Big batches are copied
+// into a temporary table on Postgres, as COPY is much faster than multi-row INSERT.
+func (r MetricRepository) insertMetrics(ctx context.Context, batchSize int, metrics []models.Metric) error {
+	if r.GetDB().Dialector.Name() == (postgres.Dialector{}).Name() && len(metrics) >= MetricCopyThreshold {
+		return r.copyMetrics(ctx, metrics)
+	}
+	return r.GetDB().WithContext(ctx).Clauses(
+		clause.OnConflict{DoNothing: true},
+	).CreateInBatches(&metrics, batchSize).Error
+}
+
+// copyMetrics copies metrics into a temporary table and moves them into `metrics` table,
+// skipping conflicting rows, as COPY itself doesn't support ON CONFLICT.
+func (r MetricRepository) copyMetrics(ctx context.Context, metrics []models.Metric) error {
+	sqlDB, err := r.GetDB().DB()
+	if err != nil {
+		return eris.Wrap(err, "error getting database connection pool")
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return eris.Wrap(err, "error getting database connection")
+	}
+	//nolint:errcheck
+	defer conn.Close()
+
+	columns := []string{"key", "value", "timestamp", "run_uuid", "step", "is_nan", "iter", "context_id"}
+	return conn.Raw(func(driverConn any) error {
+		pgxConn, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			return eris.Errorf("unsupported database driver connection %T", driverConn)
+		}
+		return pgx.BeginFunc(ctx, pgxConn.Conn(), func(tx pgx.Tx) error {
+			if _, err := tx.Exec(
+				ctx, "CREATE TEMPORARY TABLE metrics_copy (LIKE metrics INCLUDING DEFAULTS) ON COMMIT DROP",
+			); err != nil {
+				return eris.Wrap(err, "error creating temporary table")
+			}
+			if _, err := tx.CopyFrom(
+				ctx,
+				pgx.Identifier{"metrics_copy"},
+				columns,
+				pgx.CopyFromSlice(len(metrics), func(i int) ([]any, error) {
+					m := metrics[i]
+					return []any{m.Key, m.Value, m.Timestamp, m.RunID, m.Step, m.IsNan, m.Iter, m.ContextID}, nil
+				}),
+			); err != nil {
+				return eris.Wrap(err, "error copying metrics")
+			}
+			quoted := pgx.Identifier(columns).Sanitize()
+			if _, err := tx.Exec(
+				ctx,
+				fmt.Sprintf(
+					"INSERT INTO metrics (%s) SELECT %s FROM metrics_copy ON CONFLICT DO NOTHING", quoted, quoted,
+				),
+			); err != nil {
+				return eris.Wrap(err, "error moving copied metrics")
+			}
+			return nil
+		})
+	})
+}
+
 // GetMetricHistories returns metric histories by request parameters.
 // TODO think about to use interface instead of underlying type for -> func(*sql.Rows, interface{})
 func (r MetricRepository) GetMetricHistories(
@@ -177,6 +373,8 @@ func (r MetricRepository) GetMetricHistories(
 	experimentIDs []string, runIDs []string, metricKeys []string,
 	viewType request.ViewType,
 	limit int32,
+	maxPoints int32,
+	samplingMode sampling.Mode,
 	jsonPathValueMap map[string]string,
 ) (*sql.Rows, func(*sql.Rows, interface{}) error, error) {
 	// if experimentIDs has been provided then firstly get the runs by provided experimentIDs.
@@ -231,7 +429,45 @@ func (r MetricRepository) GetMetricHistories(
 		"metrics.run_uuid",
 	).Order(
 		"metrics.key",
-	).Order(
+	)
+
+	// when max_points is requested, each series is split into buckets and only points with
+	// the minimal and the maximal values in each bucket are selected.
+	if maxPoints > 0 {
+		sampled := r.GetDB().WithContext(ctx).Select(append(
+			[]string{"metrics.run_uuid", "metrics.key", "metrics.context_id", "metrics.iter"},
+			sampling.MinMaxColumns(
+				[]string{"metrics.run_uuid", "metrics.key", "metrics.context_id"},
+				fmt.Sprintf(
+					"FLOOR(metrics.iter / ((latest_metrics.last_iter + 1) / %f))",
+					float32(sampling.BucketCount(samplingMode, int(maxPoints))),
+				),
+				"metrics.value",
+				"metrics.iter",
+			)...,
+		)).Table(
+			"metrics",
+		).Joins(
+			"INNER JOIN latest_metrics ON latest_metrics.run_uuid = metrics.run_uuid AND "+
+				"latest_metrics.key = metrics.key AND latest_metrics.context_id = metrics.context_id",
+		).Where(
+			"metrics.run_uuid IN ?", runIDs,
+		)
+		if len(metricKeys) > 0 {
+			sampled.Where("metrics.key IN ?", metricKeys)
+		}
+		query.Joins(
+			"INNER JOIN (?) sampled ON sampled.run_uuid = metrics.run_uuid AND sampled.key = metrics.key AND "+
+				"sampled.context_id = metrics.context_id AND sampled.iter = metrics.iter AND "+
+				"(sampled.sampling_min_rank = 1 OR sampled.sampling_max_rank = 1)",
+			sampled,
+		).Order(
+			// points of the same series have to follow each other, so they could be post-processed.
+			"metrics.context_id",
+		)
+	}
+
+	query.Order(
 		"metrics.step",
 	).Order(
 		"metrics.timestamp",
@@ -275,7 +511,7 @@ func (r MetricRepository) getLatestMetricsByRunIDAndKeys(
 	if err := r.GetDB().WithContext(ctx).Where(
 		"run_uuid = ?", runID,
 	).Where(
-		"key IN ?", keys,
+		`"key" IN ?`, keys,
 	).Find(&metrics).Error; err != nil {
 		return nil, eris.Wrapf(err, "error getting latest metrics by run id: %s and keys: %v", runID, keys)
 	}
@@ -294,7 +530,7 @@ func (r MetricRepository) GetMetricHistoryByRunIDAndKey(
 	).Where(
 		"run_uuid = ?", runID,
 	).Where(
-		"key = ?", key,
+		`"key" = ?`, key,
 	).Find(&metrics).Error; err != nil {
 		return nil, eris.Wrapf(err, "error getting metric history by run id: %s and key: %s", runID, key)
 	}
@@ -314,7 +550,7 @@ func (r MetricRepository) GetMetricHistoryBulk(
 		"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
 		namespaceID,
 	).Where(
-		"key = ?", key,
+		`"key" = ?`, key,
 	).Order(
 		"metrics.run_uuid",
 	).Order(
@@ -111,6 +111,32 @@ func (_m *MockExperimentRepositoryProvider) GetByNamespaceIDAndName(ctx context.
 	return r0, r1
 }
 
+// GetDeletedBefore provides a mock function with given fields: ctx, namespaceID, before
+func (_m *MockExperimentRepositoryProvider) GetDeletedBefore(ctx context.Context, namespaceID uint, before int64) ([]models.Experiment, error) {
+	ret := _m.Called(ctx, namespaceID, before)
+
+	var r0 []models.Experiment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int64) ([]models.Experiment, error)); ok {
+		return rf(ctx, namespaceID, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int64) []models.Experiment); ok {
+		r0 = rf(ctx, namespaceID, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Experiment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, int64) error); ok {
+		r1 = rf(ctx, namespaceID, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, experiment
 func (_m *MockExperimentRepositoryProvider) Update(ctx context.Context, experiment *models.Experiment) error {
 	ret := _m.Called(ctx, experiment)
@@ -13,6 +13,8 @@ import (
 
 	request "github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 
+	sampling "github.com/G-Research/fasttrackml/pkg/common/sampling"
+
 	sql "database/sql"
 )
 
@@ -51,34 +53,34 @@ func (_m *MockMetricRepositoryProvider) GetDB() *gorm.DB {
 	return r0
 }
 
-// GetMetricHistories provides a mock function with given fields: ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, jsonPathValueMap
-func (_m *MockMetricRepositoryProvider) GetMetricHistories(ctx context.Context, namespaceID uint, experimentIDs []string, runIDs []string, metricKeys []string, viewType request.ViewType, limit int32, jsonPathValueMap map[string]string) (*sql.Rows, func(*sql.Rows, interface{}) error, error) {
-	ret := _m.Called(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, jsonPathValueMap)
+// GetMetricHistories provides a mock function with given fields: ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, maxPoints, samplingMode, jsonPathValueMap
+func (_m *MockMetricRepositoryProvider) GetMetricHistories(ctx context.Context, namespaceID uint, experimentIDs []string, runIDs []string, metricKeys []string, viewType request.ViewType, limit int32, maxPoints int32, samplingMode sampling.Mode, jsonPathValueMap map[string]string) (*sql.Rows, func(*sql.Rows, interface{}) error, error) {
+	ret := _m.Called(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, maxPoints, samplingMode, jsonPathValueMap)
 
 	var r0 *sql.Rows
 	var r1 func(*sql.Rows, interface{}) error
 	var r2 error
-	if rf, ok := ret.Get(0).(func(context.Context, uint, []string, []string, []string, request.ViewType, int32, map[string]string) (*sql.Rows, func(*sql.Rows, interface{}) error, error)); ok {
-		return rf(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, jsonPathValueMap)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string, []string, []string, request.ViewType, int32, int32, sampling.Mode, map[string]string) (*sql.Rows, func(*sql.Rows, interface{}) error, error)); ok {
+		return rf(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, maxPoints, samplingMode, jsonPathValueMap)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uint, []string, []string, []string, request.ViewType, int32, map[string]string) *sql.Rows); ok {
-		r0 = rf(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, jsonPathValueMap)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, []string, []string, []string, request.ViewType, int32, int32, sampling.Mode, map[string]string) *sql.Rows); ok {
+		r0 = rf(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, maxPoints, samplingMode, jsonPathValueMap)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*sql.Rows)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uint, []string, []string, []string, request.ViewType, int32, map[string]string) func(*sql.Rows, interface{}) error); ok {
-		r1 = rf(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, jsonPathValueMap)
+	if rf, ok := ret.Get(1).(func(context.Context, uint, []string, []string, []string, request.ViewType, int32, int32, sampling.Mode, map[string]string) func(*sql.Rows, interface{}) error); ok {
+		r1 = rf(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, maxPoints, samplingMode, jsonPathValueMap)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(func(*sql.Rows, interface{}) error)
 		}
 	}
 
-	if rf, ok := ret.Get(2).(func(context.Context, uint, []string, []string, []string, request.ViewType, int32, map[string]string) error); ok {
-		r2 = rf(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, jsonPathValueMap)
+	if rf, ok := ret.Get(2).(func(context.Context, uint, []string, []string, []string, request.ViewType, int32, int32, sampling.Mode, map[string]string) error); ok {
+		r2 = rf(ctx, namespaceID, experimentIDs, runIDs, metricKeys, viewType, limit, maxPoints, samplingMode, jsonPathValueMap)
 	} else {
 		r2 = ret.Error(2)
 	}
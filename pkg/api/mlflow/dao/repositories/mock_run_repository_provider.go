@@ -181,6 +181,32 @@ func (_m *MockRunRepositoryProvider) GetDB() *gorm.DB {
 	return r0
 }
 
+// GetDeletedBefore provides a mock function with given fields: ctx, namespaceID, before
+func (_m *MockRunRepositoryProvider) GetDeletedBefore(ctx context.Context, namespaceID uint, before int64) ([]models.Run, error) {
+	ret := _m.Called(ctx, namespaceID, before)
+
+	var r0 []models.Run
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int64) ([]models.Run, error)); ok {
+		return rf(ctx, namespaceID, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int64) []models.Run); ok {
+		r0 = rf(ctx, namespaceID, before)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Run)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, int64) error); ok {
+		r1 = rf(ctx, namespaceID, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Restore provides a mock function with given fields: ctx, run
 func (_m *MockRunRepositoryProvider) Restore(ctx context.Context, run *models.Run) error {
 	ret := _m.Called(ctx, run)
@@ -0,0 +1,208 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// ModelVersionRepositoryProvider provides an interface to work with models.ModelVersion entity.
+type ModelVersionRepositoryProvider interface {
+	repositories.BaseRepositoryProvider
+	// Create creates new models.ModelVersion entity with the next available version number.
+	Create(ctx context.Context, version *models.ModelVersion) error
+	// Update updates existing models.ModelVersion entity.
+	Update(ctx context.Context, version *models.ModelVersion) error
+	// Delete marks existing models.ModelVersion entity as deleted and drops its aliases.
+	Delete(ctx context.Context, version *models.ModelVersion) error
+	// GetByRegisteredModelIDAndVersion returns models.ModelVersion entity by models.RegisteredModel ID and version.
+	GetByRegisteredModelIDAndVersion(
+		ctx context.Context, registeredModelID uuid.UUID, version int64,
+	) (*models.ModelVersion, error)
+	// TransitionStage moves existing models.ModelVersion entity to the provided stage and optionally
+	// archives other versions of the same models.RegisteredModel which are in the same stage.
+	TransitionStage(
+		ctx context.Context, version *models.ModelVersion, stage models.ModelVersionStage, archiveExisting bool,
+	) error
+	// SetTag creates or updates models.ModelVersionTag entity.
+	SetTag(ctx context.Context, tag *models.ModelVersionTag) error
+	// DeleteTag removes models.ModelVersionTag entity by models.ModelVersion ID and Tag key.
+	DeleteTag(ctx context.Context, modelVersionID uuid.UUID, key string) error
+}
+
+// ModelVersionRepository repository to work with models.ModelVersion entity.
+type ModelVersionRepository struct {
+	repositories.BaseRepositoryProvider
+}
+
+// NewModelVersionRepository creates repository to work with models.ModelVersion entity.
+func NewModelVersionRepository(db *gorm.DB) *ModelVersionRepository {
+	return &ModelVersionRepository{
+		repositories.NewBaseRepository(db),
+	}
+}
+
+// Create creates new models.ModelVersion entity with the next available version number.
+func (r ModelVersionRepository) Create(ctx context.Context, version *models.ModelVersion) error {
+	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// the registered model row is updated first, so it stays locked until the end of the transaction
+		// and concurrent creates of the same registered model can't pick the same version number.
+		if err := touchRegisteredModel(tx, version.RegisteredModelID, version.LastUpdateTime); err != nil {
+			return err
+		}
+
+		// deleted versions are taken into account too, so version numbers are never reused.
+		var maxVersion sql.NullInt64
+		if err := tx.Model(
+			&models.ModelVersion{},
+		).Where(
+			"registered_model_id = ?", version.RegisteredModelID,
+		).Pluck("MAX(version)", &maxVersion).Error; err != nil {
+			return eris.Wrap(err, "error getting max model version")
+		}
+		version.Version = maxVersion.Int64 + 1
+
+		if err := tx.Omit("RegisteredModel").Create(version).Error; err != nil {
+			return eris.Wrap(err, "error creating model version")
+		}
+		return nil
+	}); err != nil {
+		return eris.Wrapf(err, "error creating model version for registered model with id: %s", version.RegisteredModelID)
+	}
+	return nil
+}
+
+// Update updates existing models.ModelVersion entity.
+func (r ModelVersionRepository) Update(ctx context.Context, version *models.ModelVersion) error {
+	if err := r.GetDB().WithContext(ctx).Model(
+		version,
+	).Select(
+		"Description", "LastUpdateTime",
+	).Updates(version).Error; err != nil {
+		return eris.Wrapf(err, "error updating model version with id: %s", version.ID)
+	}
+	return nil
+}
+
+// Delete marks existing models.ModelVersion entity as deleted and drops its aliases.
+func (r ModelVersionRepository) Delete(ctx context.Context, version *models.ModelVersion) error {
+	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(
+			"registered_model_id = ? AND version = ?", version.RegisteredModelID, version.Version,
+		).Delete(&models.RegisteredModelAlias{}).Error; err != nil {
+			return eris.Wrap(err, "error deleting model version aliases")
+		}
+		if err := tx.Where(
+			"model_version_id = ?", version.ID,
+		).Delete(&models.ModelVersionTag{}).Error; err != nil {
+			return eris.Wrap(err, "error deleting model version tags")
+		}
+		if err := tx.Model(version).Select(
+			"CurrentStage", "LastUpdateTime", "Description", "Source", "RunID", "RunLink", "UserID", "StatusMessage",
+		).Updates(&models.ModelVersion{
+			CurrentStage:   models.ModelVersionStageDeletedInternal,
+			LastUpdateTime: version.LastUpdateTime,
+		}).Error; err != nil {
+			return eris.Wrap(err, "error marking model version as deleted")
+		}
+		return touchRegisteredModel(tx, version.RegisteredModelID, version.LastUpdateTime)
+	}); err != nil {
+		return eris.Wrapf(err, "error deleting model version with id: %s", version.ID)
+	}
+	return nil
+}
+
+// GetByRegisteredModelIDAndVersion returns models.ModelVersion entity by models.RegisteredModel ID and version.
+func (r ModelVersionRepository) GetByRegisteredModelIDAndVersion(
+	ctx context.Context, registeredModelID uuid.UUID, version int64,
+) (*models.ModelVersion, error) {
+	var modelVersion models.ModelVersion
+	if err := r.GetDB().WithContext(ctx).Preload(
+		"Tags",
+	).Preload(
+		"RegisteredModel.Aliases",
+	).Where(
+		"registered_model_id = ? AND version = ?", registeredModelID, version,
+	).Where(
+		"current_stage != ?", models.ModelVersionStageDeletedInternal,
+	).First(&modelVersion).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, eris.Wrapf(err, "error getting model version: %d", version)
+	}
+	return &modelVersion, nil
+}
+
+// TransitionStage moves existing models.ModelVersion entity to the provided stage and optionally
+// archives other versions of the same models.RegisteredModel which are in the same stage.
+func (r ModelVersionRepository) TransitionStage(
+	ctx context.Context, version *models.ModelVersion, stage models.ModelVersionStage, archiveExisting bool,
+) error {
+	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if archiveExisting && (stage == models.ModelVersionStageStaging || stage == models.ModelVersionStageProduction) {
+			if err := tx.Model(
+				&models.ModelVersion{},
+			).Where(
+				"registered_model_id = ? AND id != ? AND current_stage = ?", version.RegisteredModelID, version.ID, stage,
+			).Updates(map[string]any{
+				"current_stage":    models.ModelVersionStageArchived,
+				"last_update_time": version.LastUpdateTime,
+			}).Error; err != nil {
+				return eris.Wrap(err, "error archiving existing model versions")
+			}
+		}
+		version.CurrentStage = stage
+		if err := tx.Model(version).Select(
+			"CurrentStage", "LastUpdateTime",
+		).Updates(version).Error; err != nil {
+			return eris.Wrap(err, "error updating model version stage")
+		}
+		return touchRegisteredModel(tx, version.RegisteredModelID, version.LastUpdateTime)
+	}); err != nil {
+		return eris.Wrapf(err, "error transitioning model version with id: %s", version.ID)
+	}
+	return nil
+}
+
+// SetTag creates or updates models.ModelVersionTag entity.
+func (r ModelVersionRepository) SetTag(ctx context.Context, tag *models.ModelVersionTag) error {
+	if err := r.GetDB().WithContext(ctx).Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(tag).Error; err != nil {
+		return eris.Wrapf(err, "error setting tag for model version with id: %s", tag.ModelVersionID)
+	}
+	return nil
+}
+
+// DeleteTag removes models.ModelVersionTag entity by models.ModelVersion ID and Tag key.
+func (r ModelVersionRepository) DeleteTag(ctx context.Context, modelVersionID uuid.UUID, key string) error {
+	if err := r.GetDB().WithContext(ctx).Where(
+		`model_version_id = ? AND "key" = ?`, modelVersionID, key,
+	).Delete(&models.ModelVersionTag{}).Error; err != nil {
+		return eris.Wrapf(err, "error deleting tag for model version with id: %s and key: %s", modelVersionID, key)
+	}
+	return nil
+}
+
+// touchRegisteredModel updates `last_update_time` of the models.RegisteredModel in scope of transaction.
+func touchRegisteredModel(tx *gorm.DB, registeredModelID uuid.UUID, lastUpdateTime int64) error {
+	if err := tx.Model(
+		&models.RegisteredModel{},
+	).Where(
+		"id = ?", registeredModelID,
+	).Update(
+		"last_update_time", lastUpdateTime,
+	).Error; err != nil {
+		return eris.Wrap(err, "error updating registered model last_update_time")
+	}
+	return nil
+}
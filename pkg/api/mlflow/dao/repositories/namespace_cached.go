@@ -3,7 +3,6 @@ package repositories
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 
 	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/rotisserie/eris"
@@ -13,21 +12,21 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common/dao"
 	"github.com/G-Research/fasttrackml/pkg/common/events"
-	"github.com/G-Research/fasttrackml/pkg/database"
+	"github.com/G-Research/fasttrackml/pkg/common/telemetry"
 )
 
 // NamespaceCachedRepository cached repository to work with `namespace` entity.
 type NamespaceCachedRepository struct {
-	cache                  *lru.Cache[string, models.Namespace]
-	namespaceRepository    NamespaceRepositoryProvider
-	namespaceEventListener dao.EventListenerProvider
+	cache               *lru.Cache[string, models.Namespace]
+	namespaceRepository NamespaceRepositoryProvider
+	eventListener       dao.EventListenerProvider
 }
 
 // NewNamespaceCachedRepository creates new instance of cached repository to work with `namespace` entity.
 func NewNamespaceCachedRepository(
 	ctx context.Context,
 	namespaceRepository NamespaceRepositoryProvider,
-	namespaceEventListener dao.EventListenerProvider,
+	eventListener dao.EventListenerProvider,
 ) (*NamespaceCachedRepository, error) {
 	cache, err := lru.New[string, models.Namespace](1000)
 	if err != nil {
@@ -35,14 +34,13 @@ func NewNamespaceCachedRepository(
 	}
 
 	repository := NamespaceCachedRepository{
-		cache:                  cache,
-		namespaceRepository:    namespaceRepository,
-		namespaceEventListener: namespaceEventListener,
+		cache:               cache,
+		namespaceRepository: namespaceRepository,
+		eventListener:       eventListener,
 	}
 
 	ch := make(chan string)
 	go func() {
-		defer close(ch)
 		for {
 			select {
 			case <-ctx.Done():
@@ -56,7 +54,7 @@ func NewNamespaceCachedRepository(
 	}()
 
 	// subscribe to incoming events.
-	namespaceEventListener.Subscribe(ch)
+	eventListener.Subscribe(events.NamespaceChannel, ch)
 
 	return &repository, nil
 }
@@ -67,9 +65,10 @@ func (r NamespaceCachedRepository) Create(ctx context.Context, namespace *models
 		return eris.Wrap(err, "error creating cached namespace entity")
 	}
 
-	// trigger database event to notify current instance and
-	// other instances to create record in theirs local cache.
-	if err := r.sendEvent(events.NamespaceEventActionCreated, namespace); err != nil {
+	// update local cache right away and trigger database event
+	// to notify other instances to create record in theirs local cache.
+	r.cache.Add(namespace.Code, *namespace)
+	if err := r.sendEvent(ctx, events.EventActionCreated, namespace); err != nil {
 		return eris.Wrap(err, "error sending database event")
 	}
 	return nil
@@ -81,9 +80,10 @@ func (r NamespaceCachedRepository) Update(ctx context.Context, namespace *models
 		return eris.Wrap(err, "error updating cached namespace entity")
 	}
 
-	// trigger database event to notify current instance and
-	// other instances to update record in theirs local cache.
-	if err := r.sendEvent(events.NamespaceEventActionUpdated, namespace); err != nil {
+	// update local cache right away and trigger database event
+	// to notify other instances to update record in theirs local cache.
+	r.cache.Add(namespace.Code, *namespace)
+	if err := r.sendEvent(ctx, events.EventActionUpdated, namespace); err != nil {
 		return eris.Wrap(err, "error sending database event")
 	}
 	return nil
@@ -95,8 +95,10 @@ func (r NamespaceCachedRepository) GetByCode(
 ) (*models.Namespace, error) {
 	result, ok := r.cache.Get(code)
 	if ok {
+		telemetry.NamespaceCacheRequestsTotal.WithLabelValues(telemetry.CacheResultHit).Inc()
 		return &result, nil
 	}
+	telemetry.NamespaceCacheRequestsTotal.WithLabelValues(telemetry.CacheResultMiss).Inc()
 
 	namespace, err := r.namespaceRepository.GetByCode(ctx, code)
 	if err != nil {
@@ -106,9 +108,10 @@ func (r NamespaceCachedRepository) GetByCode(
 		return nil, nil
 	}
 
-	// trigger database event to notify current instance and
-	// other instances to add record to theirs local cache.
-	if err := r.sendEvent(events.NamespaceEventActionFetched, namespace); err != nil {
+	// update local cache right away and trigger database event
+	// to notify other instances to add record to theirs local cache.
+	r.cache.Add(namespace.Code, *namespace)
+	if err := r.sendEvent(ctx, events.EventActionFetched, namespace); err != nil {
 		return nil, eris.Wrap(err, "error sending database event")
 	}
 	return namespace, nil
@@ -130,9 +133,10 @@ func (r NamespaceCachedRepository) Delete(ctx context.Context, namespace *models
 		return eris.Wrap(err, "error deleting cached namespace entity")
 	}
 
-	// trigger database event to notify current instance and
-	// other instances to remove record from theirs local cache.
-	if err := r.sendEvent(events.NamespaceEventActionDeleted, namespace); err != nil {
+	// update local cache right away and trigger database event
+	// to notify other instances to remove record from theirs local cache.
+	r.cache.Remove(namespace.Code)
+	if err := r.sendEvent(ctx, events.EventActionDeleted, namespace); err != nil {
 		return eris.Wrap(err, "error sending database event")
 	}
 	return nil
@@ -151,14 +155,16 @@ func (r NamespaceCachedRepository) processEvent(data string) error {
 		return eris.Wrap(err, "error unmarshaling incoming database event")
 	}
 	switch event.Action {
-	case events.NamespaceEventActionFetched:
+	case events.EventActionFetched:
 		r.cache.Add(event.Namespace.Code, event.Namespace)
-	case events.NamespaceEventActionCreated:
+	case events.EventActionCreated:
 		r.cache.Add(event.Namespace.Code, event.Namespace)
-	case events.NamespaceEventActionUpdated:
+	case events.EventActionUpdated:
 		r.cache.Add(event.Namespace.Code, event.Namespace)
-	case events.NamespaceEventActionDeleted:
+	case events.EventActionDeleted:
 		r.cache.Remove(event.Namespace.Code)
+	case events.EventActionReset:
+		r.cache.Purge()
 	}
 	log.Debugf("namespace keys in local cache: %+v", r.cache.Keys())
 	return nil
@@ -170,12 +176,9 @@ func (r NamespaceCachedRepository) GetDB() *gorm.DB {
 }
 
 // sendEvent sends database event.
-func (r NamespaceCachedRepository) sendEvent(action events.NamespaceEventAction, namespace *models.Namespace) error {
-	// skip event processing if current database is not a `postgres`.
-	if r.namespaceRepository.GetDB().Dialector.Name() != database.PostgresDialectorName {
-		return nil
-	}
-
+func (r NamespaceCachedRepository) sendEvent(
+	ctx context.Context, action events.EventAction, namespace *models.Namespace,
+) error {
 	data, err := json.Marshal(events.NamespaceEvent{
 		Action:    action,
 		Namespace: *namespace,
@@ -183,10 +186,10 @@ func (r NamespaceCachedRepository) sendEvent(action events.NamespaceEventAction,
 	if err != nil {
 		return eris.Wrap(err, "error serializing NamespaceEvent event")
 	}
-	if err := r.namespaceRepository.GetDB().Exec(
-		fmt.Sprintf(`SELECT pg_notify('%s', '%s')`, r.namespaceEventListener.GetChannelName(), data),
-	).Error; err != nil {
-		return eris.Wrap(err, "error triggering 'pg_notify'")
+	if err := r.eventListener.Publish(
+		r.namespaceRepository.GetDB().WithContext(ctx), events.NamespaceChannel, string(data),
+	); err != nil {
+		return eris.Wrap(err, "error publishing namespace event")
 	}
 	return nil
 }
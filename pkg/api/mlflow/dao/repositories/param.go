@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/rotisserie/eris"
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -88,20 +89,27 @@ func (r ParamRepository) CreateBatch(ctx context.Context, batchSize int, params
 func findConflictingParams(tx *gorm.DB, params []models.Param) ([]paramConflict, error) {
 	var conflicts []paramConflict
 	placeholders, values := makeParamConflictPlaceholdersAndValues(params, tx.Dialector.Name())
-	nullSafeEquality := "IS NOT"
-	if (tx.Dialector.Name() == postgres.Dialector{}.Name()) {
-		nullSafeEquality = "IS DISTINCT FROM"
+	isDistinct := "%[1]s IS NOT %[2]s"
+	switch tx.Dialector.Name() {
+	case postgres.Dialector{}.Name():
+		isDistinct = "%[1]s IS DISTINCT FROM %[2]s"
+	case mysql.Dialector{}.Name():
+		isDistinct = "NOT (%[1]s <=> %[2]s)"
 	}
-	sql := fmt.Sprintf(`WITH new(key, run_uuid, value_int, value_float, value_str) AS (%s)
-		     SELECT current.run_uuid, current.key, CONCAT(current.value_int, 
-			   current.value_float, current.value_str) as old_value, CONCAT(new.value_int,
+	sql := fmt.Sprintf(`WITH new("key", run_uuid, value_int, value_float, value_str) AS (%s)
+		     SELECT current.run_uuid, current.key, CONCAT_WS('', current.value_int,
+			   current.value_float, current.value_str) as old_value, CONCAT_WS('', new.value_int,
 			   new.value_float, new.value_str) as new_value
 		     FROM params AS current
-		     INNER JOIN new USING (run_uuid, key)
-		     WHERE (new.value_int %s current.value_int)
-			 OR (new.value_float %s current.value_float)
-			 OR (new.value_str %s current.value_str)`,
-		placeholders, nullSafeEquality, nullSafeEquality, nullSafeEquality)
+		     INNER JOIN new USING (run_uuid, "key")
+		     WHERE (%s)
+			 OR (%s)
+			 OR (%s)`,
+		placeholders,
+		fmt.Sprintf(isDistinct, "new.value_int", "current.value_int"),
+		fmt.Sprintf(isDistinct, "new.value_float", "current.value_float"),
+		fmt.Sprintf(isDistinct, "new.value_str", "current.value_str"),
+	)
 	if err := tx.Raw(sql, values...).
 		Find(&conflicts).Error; err != nil {
 		return nil, eris.Wrap(err, "error fetching params from db")
@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+)
+
+// RegisteredModelRepositoryProvider provides an interface to work with models.RegisteredModel entity.
+type RegisteredModelRepositoryProvider interface {
+	repositories.BaseRepositoryProvider
+	// Create creates new models.RegisteredModel entity.
+	Create(ctx context.Context, registeredModel *models.RegisteredModel) error
+	// Update updates existing models.RegisteredModel entity.
+	Update(ctx context.Context, registeredModel *models.RegisteredModel) error
+	// Delete removes existing models.RegisteredModel entity together with all its versions.
+	Delete(ctx context.Context, registeredModel *models.RegisteredModel) error
+	// GetByNamespaceIDAndName returns models.RegisteredModel entity by Namespace ID and its name.
+	GetByNamespaceIDAndName(ctx context.Context, namespaceID uint, name string) (*models.RegisteredModel, error)
+	// SetTag creates or updates models.RegisteredModelTag entity.
+	SetTag(ctx context.Context, tag *models.RegisteredModelTag) error
+	// DeleteTag removes models.RegisteredModelTag entity by models.RegisteredModel ID and Tag key.
+	DeleteTag(ctx context.Context, registeredModelID uuid.UUID, key string) error
+	// SetAlias creates or updates models.RegisteredModelAlias entity.
+	SetAlias(ctx context.Context, alias *models.RegisteredModelAlias) error
+	// DeleteAlias removes models.RegisteredModelAlias entity by models.RegisteredModel ID and alias name.
+	DeleteAlias(ctx context.Context, registeredModelID uuid.UUID, alias string) error
+}
+
+// RegisteredModelRepository repository to work with models.RegisteredModel entity.
+type RegisteredModelRepository struct {
+	repositories.BaseRepositoryProvider
+}
+
+// NewRegisteredModelRepository creates repository to work with models.RegisteredModel entity.
+func NewRegisteredModelRepository(db *gorm.DB) *RegisteredModelRepository {
+	return &RegisteredModelRepository{
+		repositories.NewBaseRepository(db),
+	}
+}
+
+// Create creates new models.RegisteredModel entity.
+func (r RegisteredModelRepository) Create(ctx context.Context, registeredModel *models.RegisteredModel) error {
+	if err := r.GetDB().WithContext(ctx).Omit("Namespace").Create(registeredModel).Error; err != nil {
+		return eris.Wrapf(err, "error creating registered model with name: %s", registeredModel.Name)
+	}
+	return nil
+}
+
+// Update updates existing models.RegisteredModel entity.
+func (r RegisteredModelRepository) Update(ctx context.Context, registeredModel *models.RegisteredModel) error {
+	if err := r.GetDB().WithContext(ctx).Model(
+		registeredModel,
+	).Select(
+		"Name", "Description", "LastUpdateTime",
+	).Updates(registeredModel).Error; err != nil {
+		return eris.Wrapf(err, "error updating registered model with id: %s", registeredModel.ID)
+	}
+	return nil
+}
+
+// Delete removes existing models.RegisteredModel entity together with all its versions.
+func (r RegisteredModelRepository) Delete(ctx context.Context, registeredModel *models.RegisteredModel) error {
+	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where(
+			"model_version_id IN (?)",
+			tx.Model(&models.ModelVersion{}).Select("id").Where("registered_model_id = ?", registeredModel.ID),
+		).Delete(&models.ModelVersionTag{}).Error; err != nil {
+			return eris.Wrap(err, "error deleting model version tags")
+		}
+		for _, entity := range []any{
+			&models.ModelVersion{},
+			&models.RegisteredModelTag{},
+			&models.RegisteredModelAlias{},
+		} {
+			if err := tx.Where(
+				"registered_model_id = ?", registeredModel.ID,
+			).Delete(entity).Error; err != nil {
+				return eris.Wrap(err, "error deleting registered model relations")
+			}
+		}
+		return tx.Delete(registeredModel).Error
+	}); err != nil {
+		return eris.Wrapf(err, "error deleting registered model with id: %s", registeredModel.ID)
+	}
+	return nil
+}
+
+// GetByNamespaceIDAndName returns models.RegisteredModel entity by Namespace ID and its name.
+func (r RegisteredModelRepository) GetByNamespaceIDAndName(
+	ctx context.Context, namespaceID uint, name string,
+) (*models.RegisteredModel, error) {
+	var registeredModel models.RegisteredModel
+	if err := r.GetDB().WithContext(ctx).Preload(
+		"Tags",
+	).Preload(
+		"Aliases",
+	).Preload(
+		"Versions", "current_stage != ?", models.ModelVersionStageDeletedInternal,
+	).Preload(
+		"Versions.Tags",
+	).Where(
+		"registered_models.namespace_id = ?", namespaceID,
+	).Where(
+		"registered_models.name = ?", name,
+	).First(&registeredModel).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, eris.Wrapf(err, "error getting registered model by name: %s", name)
+	}
+	return &registeredModel, nil
+}
+
+// SetTag creates or updates models.RegisteredModelTag entity.
+func (r RegisteredModelRepository) SetTag(ctx context.Context, tag *models.RegisteredModelTag) error {
+	if err := r.GetDB().WithContext(ctx).Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(tag).Error; err != nil {
+		return eris.Wrapf(err, "error setting tag for registered model with id: %s", tag.RegisteredModelID)
+	}
+	return nil
+}
+
+// DeleteTag removes models.RegisteredModelTag entity by models.RegisteredModel ID and Tag key.
+func (r RegisteredModelRepository) DeleteTag(ctx context.Context, registeredModelID uuid.UUID, key string) error {
+	if err := r.GetDB().WithContext(ctx).Where(
+		`registered_model_id = ? AND "key" = ?`, registeredModelID, key,
+	).Delete(&models.RegisteredModelTag{}).Error; err != nil {
+		return eris.Wrapf(err, "error deleting tag for registered model with id: %s and key: %s", registeredModelID, key)
+	}
+	return nil
+}
+
+// SetAlias creates or updates models.RegisteredModelAlias entity.
+func (r RegisteredModelRepository) SetAlias(ctx context.Context, alias *models.RegisteredModelAlias) error {
+	if err := r.GetDB().WithContext(ctx).Clauses(clause.OnConflict{
+		UpdateAll: true,
+	}).Create(alias).Error; err != nil {
+		return eris.Wrapf(err, "error setting alias for registered model with id: %s", alias.RegisteredModelID)
+	}
+	return nil
+}
+
+// DeleteAlias removes models.RegisteredModelAlias entity by models.RegisteredModel ID and alias name.
+func (r RegisteredModelRepository) DeleteAlias(ctx context.Context, registeredModelID uuid.UUID, alias string) error {
+	if err := r.GetDB().WithContext(ctx).Where(
+		"registered_model_id = ? AND alias = ?", registeredModelID, alias,
+	).Delete(&models.RegisteredModelAlias{}).Error; err != nil {
+		return eris.Wrapf(
+			err, "error deleting alias for registered model with id: %s and alias: %s", registeredModelID, alias,
+		)
+	}
+	return nil
+}
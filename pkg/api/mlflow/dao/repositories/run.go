@@ -44,6 +44,9 @@ type RunRepositoryProvider interface {
 	DeleteBatch(ctx context.Context, namespaceID uint, ids []string) error
 	// RestoreBatch marks existing models.Run entities as active.
 	RestoreBatch(ctx context.Context, namespaceID uint, ids []string) error
+	// GetDeletedBefore returns models.Run entities of the namespace, which were deleted, or which experiments
+	// were deleted, before provided time. Zero time means any deleted ones.
+	GetDeletedBefore(ctx context.Context, namespaceID uint, before int64) ([]models.Run, error)
 	// SetRunTagsBatch sets Run tags in batch.
 	SetRunTagsBatch(ctx context.Context, run *models.Run, batchSize int, tags []models.Tag) error
 	// UpdateWithTransaction updates existing models.Run entity in scope of transaction.
@@ -73,6 +76,10 @@ func (r RunRepository) GetByID(ctx context.Context, id string) (*models.Run, err
 		"Params",
 	).Preload(
 		"Tags",
+	).Preload(
+		"Inputs.Dataset",
+	).Preload(
+		"Inputs.Tags",
 	).First(&run).Error; err != nil {
 		return nil, eris.Wrapf(err, "error getting 'run' entity by id: %s", id)
 	}
@@ -113,6 +120,10 @@ func (r RunRepository) GetByNamespaceIDAndRunID(
 		"Params",
 	).Preload(
 		"Tags",
+	).Preload(
+		"Inputs.Dataset",
+	).Preload(
+		"Inputs.Tags",
 	).Joins(
 		"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
 		namespaceID,
@@ -129,10 +140,8 @@ func (r RunRepository) GetByNamespaceIDAndRunID(
 func (r RunRepository) Create(ctx context.Context, run *models.Run) error {
 	// Lock need to calculate row_num
 	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		if tx.Dialector.Name() == "postgres" {
-			if err := tx.Exec("LOCK TABLE runs").Error; err != nil {
-				return err
-			}
+		if err := database.LockRuns(tx); err != nil {
+			return err
 		}
 		return tx.Create(&run).Error
 	}); err != nil {
@@ -170,16 +179,15 @@ func (r RunRepository) ArchiveBatch(ctx context.Context, namespaceID uint, ids [
 	).Model(
 		models.Run{},
 	).Where(
-		"run_uuid IN (?)",
+		"run_uuid IN (?)", ids,
+	).Where(
+		"experiment_id IN (?)",
 		r.GetDB().Model(
-			models.Run{},
+			models.Experiment{},
 		).Select(
-			"run_uuid",
-		).Joins(
-			"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
-			namespaceID,
+			"experiment_id",
 		).Where(
-			"run_uuid IN (?)", ids,
+			"namespace_id = ?", namespaceID,
 		),
 	).Updates(models.Run{
 		DeletedTime: sql.NullInt64{
@@ -201,34 +209,38 @@ func (r RunRepository) Delete(ctx context.Context, namespaceID uint, run *models
 
 // DeleteBatch removes existing models.Run from the db.
 func (r RunRepository) DeleteBatch(ctx context.Context, namespaceID uint, ids []string) error {
-	if err := r.GetDB().Transaction(func(tx *gorm.DB) error {
+	if err := r.GetDB().WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// logs aren't removed by cascade, so they have to be removed explicitly.
+		if err := tx.Where("run_uuid IN ?", ids).Delete(&models.Log{}).Error; err != nil {
+			return eris.Wrapf(err, "error deleting logs of runs with ids: %s", ids)
+		}
+
+		// runs are selected before deletion, because MySQL doesn't support RETURNING clause.
 		runs := make([]models.Run, 0, len(ids))
-		if err := tx.Clauses(
-			clause.Returning{Columns: []clause.Column{{Name: "row_num"}}},
+		if err := tx.Model(
+			models.Run{},
+		).Select(
+			"runs.run_uuid", "runs.row_num",
+		).Joins(
+			"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
+			namespaceID,
 		).Where(
-			"run_uuid IN (?)",
-			r.GetDB().Model(
-				models.Run{},
-			).Select(
-				"run_uuid",
-			).Joins(
-				"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
-				namespaceID,
-			).Where(
-				"run_uuid IN (?)", ids,
-			),
-		).Delete(
+			"runs.run_uuid IN (?)", ids,
+		).Find(
 			&runs,
 		).Error; err != nil {
-			return eris.Wrapf(err, "error deleting existing runs with ids: %s", ids)
+			return eris.Wrapf(err, "error getting existing runs with ids: %s", ids)
 		}
 
 		// verify deletion
-		// NOTE: tx.RowsAffected does not provide correct number of deleted, using the returning slice instead
 		if len(runs) != len(ids) {
 			return eris.New("count of deleted runs does not match length of ids input (invalid run ID?)")
 		}
 
+		if err := tx.Where("run_uuid IN (?)", ids).Delete(&models.Run{}).Error; err != nil {
+			return eris.Wrapf(err, "error deleting existing runs with ids: %s", ids)
+		}
+
 		// renumber the remainder
 		if err := r.renumberRows(tx, getMinRowNum(runs)); err != nil {
 			return eris.Wrapf(err, "error renumbering runs.row_num")
@@ -241,6 +253,32 @@ func (r RunRepository) DeleteBatch(ctx context.Context, namespaceID uint, ids []
 	return nil
 }
 
+// GetDeletedBefore returns models.Run entities of the namespace, which were deleted, or which experiments
+// were deleted, before provided time. Zero time means any deleted ones.
+func (r RunRepository) GetDeletedBefore(ctx context.Context, namespaceID uint, before int64) ([]models.Run, error) {
+	runsCondition := r.GetDB().Where("runs.lifecycle_stage = ?", models.LifecycleStageDeleted)
+	experimentsCondition := r.GetDB().Where("experiments.lifecycle_stage = ?", models.LifecycleStageDeleted)
+	if before > 0 {
+		runsCondition = runsCondition.Where("runs.deleted_time < ?", before)
+		experimentsCondition = experimentsCondition.Where("experiments.last_update_time < ?", before)
+	}
+
+	var runs []models.Run
+	if err := r.GetDB().WithContext(ctx).Select(
+		"runs.run_uuid", "runs.experiment_id", "runs.artifact_uri",
+	).Joins(
+		"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
+		namespaceID,
+	).Where(
+		runsCondition.Or(experimentsCondition),
+	).Order(
+		"runs.row_num",
+	).Find(&runs).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting deleted runs of namespace with id: %d", namespaceID)
+	}
+	return runs, nil
+}
+
 // Restore marks existing models.Run entity as active.
 func (r RunRepository) Restore(ctx context.Context, run *models.Run) error {
 	// Use UpdateColumns so we can reset DeletedTime to null
@@ -259,16 +297,15 @@ func (r RunRepository) RestoreBatch(ctx context.Context, namespaceID uint, ids [
 	if err := r.GetDB().WithContext(
 		ctx,
 	).Where(
-		"run_uuid IN (?)",
+		"run_uuid IN (?)", ids,
+	).Where(
+		"experiment_id IN (?)",
 		r.GetDB().Model(
-			models.Run{},
+			models.Experiment{},
 		).Select(
-			"run_uuid",
-		).Joins(
-			"INNER JOIN experiments ON experiments.experiment_id = runs.experiment_id AND experiments.namespace_id = ?",
-			namespaceID,
+			"experiment_id",
 		).Where(
-			"run_uuid IN (?)", ids,
+			"namespace_id = ?", namespaceID,
 		),
 	).Updates(models.Run{
 		DeletedTime:    sql.NullInt64{},
@@ -282,7 +319,7 @@ func (r RunRepository) RestoreBatch(ctx context.Context, namespaceID uint, ids [
 
 // UpdateWithTransaction updates existing models.Run entity in scope of transaction.
 func (r RunRepository) UpdateWithTransaction(ctx context.Context, tx *gorm.DB, run *models.Run) error {
-	if err := tx.WithContext(ctx).Model(&run).Omit("LatestMetrics", "Metrics", "Params").Updates(run).Error; err != nil {
+	if err := tx.WithContext(ctx).Model(&run).Omit("LatestMetrics", "Metrics", "Params", "Inputs").Updates(run).Error; err != nil {
 		return eris.Wrapf(err, "error updating existing run with id: %s", run.ID)
 	}
 	return nil
@@ -336,21 +373,12 @@ func (r RunRepository) renumberRows(tx *gorm.DB, startWith models.RowNum) error
 		return eris.New("attempting to renumber with less than 0 row number value")
 	}
 
-	if tx.Dialector.Name() == "postgres" {
-		if err := tx.Exec("LOCK TABLE runs").Error; err != nil {
-			return eris.Wrap(err, "unable to lock table")
-		}
+	if err := database.LockRuns(tx); err != nil {
+		return eris.Wrap(err, "unable to lock table")
 	}
 
 	if err := tx.Exec(
-		`UPDATE runs
-	         SET row_num = rows.new_row_num
-                 FROM (
-                   SELECT run_uuid, ROW_NUMBER() OVER (ORDER BY start_time) + ? - 1 as new_row_num
-                   FROM runs
-                   WHERE runs.row_num >= ?
-                 ) as rows
-	         WHERE runs.run_uuid = rows.run_uuid`,
+		database.RenumberRunsQuery(tx),
 		int64(startWith),
 		int64(startWith)).Error; err != nil {
 		return eris.Wrap(err, "error updating runs.row_num")
@@ -0,0 +1,160 @@
+package query
+
+import (
+	"strings"
+	"unicode"
+)
+
+// TokenType represents type of the filter token.
+type TokenType int
+
+// Supported list of filter tokens.
+const (
+	TokenEOF TokenType = iota
+	TokenIdentifier
+	TokenString
+	TokenNumber
+	TokenOperator
+	TokenKeyword
+	TokenLeftParen
+	TokenRightParen
+	TokenComma
+	TokenDot
+)
+
+// Supported list of filter keywords.
+const (
+	KeywordAnd   = "AND"
+	KeywordOr    = "OR"
+	KeywordNot   = "NOT"
+	KeywordIn    = "IN"
+	KeywordIs    = "IS"
+	KeywordNull  = "NULL"
+	KeywordLike  = "LIKE"
+	KeywordILike = "ILIKE"
+)
+
+var keywords = map[string]struct{}{
+	KeywordAnd:   {},
+	KeywordOr:    {},
+	KeywordNot:   {},
+	KeywordIn:    {},
+	KeywordIs:    {},
+	KeywordNull:  {},
+	KeywordLike:  {},
+	KeywordILike: {},
+}
+
+// Token represents single filter token.
+// Quoted is set for quoted strings and identifiers quoted with backticks.
+type Token struct {
+	Type     TokenType
+	Value    string
+	Position int
+	Quoted   bool
+}
+
+// String returns human readable representation of the token, which is used in error messages.
+func (t Token) String() string {
+	if t.Type == TokenEOF {
+		return "end of filter"
+	}
+	return t.Value
+}
+
+// lexer splits filter into the tokens.
+type lexer struct {
+	input    string
+	position int
+}
+
+// tokenize returns list of filter tokens. The last token is always TokenEOF.
+func tokenize(input string) ([]Token, error) {
+	l := lexer{input: input}
+	var tokens []Token
+	for {
+		token, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+		if token.Type == TokenEOF {
+			return tokens, nil
+		}
+	}
+}
+
+// next returns next token.
+func (l *lexer) next() (Token, error) {
+	for l.position < len(l.input) && unicode.IsSpace(rune(l.input[l.position])) {
+		l.position++
+	}
+
+	start := l.position
+	if start >= len(l.input) {
+		return Token{Type: TokenEOF, Position: start}, nil
+	}
+
+	switch c := l.input[start]; {
+	case c == '(':
+		l.position++
+		return Token{Type: TokenLeftParen, Value: "(", Position: start}, nil
+	case c == ')':
+		l.position++
+		return Token{Type: TokenRightParen, Value: ")", Position: start}, nil
+	case c == ',':
+		l.position++
+		return Token{Type: TokenComma, Value: ",", Position: start}, nil
+	case c == '.':
+		l.position++
+		return Token{Type: TokenDot, Value: ".", Position: start}, nil
+	case c == '\'' || c == '"' || c == '`':
+		end := strings.IndexByte(l.input[start+1:], c)
+		if end == -1 {
+			return Token{}, newSyntaxError(start, "unterminated quoted string")
+		}
+		l.position = start + end + 2
+		tokenType := TokenString
+		if c == '`' {
+			tokenType = TokenIdentifier
+		}
+		return Token{Type: tokenType, Value: l.input[start+1 : start+end+1], Position: start, Quoted: true}, nil
+	case c == '<' || c == '>' || c == '=' || c == '!':
+		l.position++
+		if l.position < len(l.input) && l.input[l.position] == '=' {
+			l.position++
+		}
+		value := l.input[start:l.position]
+		if value == "!" {
+			return Token{}, newSyntaxError(start, "unexpected character '!'")
+		}
+		return Token{Type: TokenOperator, Value: value, Position: start}, nil
+	case c == '-' || isDigit(c):
+		l.position++
+		for l.position < len(l.input) && (isWordCharacter(l.input[l.position]) || l.input[l.position] == '.') {
+			l.position++
+		}
+		return Token{Type: TokenNumber, Value: l.input[start:l.position], Position: start}, nil
+	case isWordCharacter(c):
+		for l.position < len(l.input) && isWordCharacter(l.input[l.position]) {
+			l.position++
+		}
+		value := l.input[start:l.position]
+		if _, ok := keywords[strings.ToUpper(value)]; ok {
+			return Token{Type: TokenKeyword, Value: strings.ToUpper(value), Position: start}, nil
+		}
+		return Token{Type: TokenIdentifier, Value: value, Position: start}, nil
+	default:
+		return Token{}, newSyntaxError(start, "unexpected character '%c'", c)
+	}
+}
+
+// isDigit checks that character is a digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isWordCharacter checks that character could be a part of identifier.
+func isWordCharacter(c byte) bool {
+	return c == '_' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
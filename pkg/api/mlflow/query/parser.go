@@ -0,0 +1,410 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Supported list of comparison operators.
+const (
+	OperatorIn            = "IN"
+	OperatorNotIn         = "NOT IN"
+	OperatorLike          = "LIKE"
+	OperatorILike         = "ILIKE"
+	OperatorEqual         = "="
+	OperatorNotEqual      = "!="
+	OperatorLess          = "<"
+	OperatorLessOrEqual   = "<="
+	OperatorGrater        = ">"
+	OperatorGraterOrEqual = ">="
+	OperatorIsNull        = "IS NULL"
+	OperatorIsNotNull     = "IS NOT NULL"
+)
+
+// ValueType represents type of the value in the filter condition.
+type ValueType int
+
+// Supported list of value types.
+const (
+	ValueTypeNone ValueType = iota
+	ValueTypeString
+	ValueTypeNumber
+	ValueTypeList
+)
+
+// Node represents node of the parsed filter.
+type Node interface {
+	node()
+}
+
+// AndExpression represents `<left> AND <right>` expression.
+type AndExpression struct {
+	Left  Node
+	Right Node
+}
+
+// OrExpression represents `<left> OR <right>` expression.
+type OrExpression struct {
+	Left  Node
+	Right Node
+}
+
+// NotExpression represents `NOT <expression>` expression.
+type NotExpression struct {
+	Expression Node
+}
+
+// Value represents value of the filter condition.
+// Unquoted values are kept as a raw text, so it is up to the caller how to interpret them.
+type Value struct {
+	Type   ValueType
+	Raw    string
+	Quoted bool
+	List   []string
+}
+
+// Condition represents single `<entity>.<key> <operator> <value>` condition.
+// Function is set, when the key is wrapped into the function call, e.g. `min(metrics.loss)`,
+// and Arguments keep the rest of the function arguments, e.g. `10` of `at_step(metrics.loss, 10)`.
+type Condition struct {
+	Entity    string
+	Key       string
+	Function  string
+	Arguments []string
+	Operator  string
+	Value     Value
+	Position  int
+}
+
+func (AndExpression) node() {}
+func (OrExpression) node()  {}
+func (NotExpression) node() {}
+func (Condition) node()     {}
+
+// SyntaxError represents error in the filter syntax.
+type SyntaxError struct {
+	Statement string `json:"statement"`
+	Offset    int    `json:"offset"`
+	Err       string `json:"error,omitempty"`
+}
+
+// newSyntaxError creates new SyntaxError at the given offset.
+func newSyntaxError(offset int, format string, args ...any) SyntaxError {
+	return SyntaxError{
+		Offset: offset,
+		Err:    fmt.Sprintf(format, args...),
+	}
+}
+
+// Error implements error interface.
+func (s SyntaxError) Error() string {
+	return fmt.Sprintf("syntax error at position %d in filter %q: %s", s.Offset, s.Statement, s.Err)
+}
+
+// Is makes it possible to check error type with errors.Is.
+func (s SyntaxError) Is(target error) bool {
+	_, ok := target.(SyntaxError)
+	return ok
+}
+
+// parser converts list of tokens into the filter tree.
+type parser struct {
+	tokens   []Token
+	position int
+}
+
+// ParseFilter parses MLflow filter string. Grammar of the filter is:
+//
+//	expression := and_expression (OR and_expression)*
+//	and_expression := not_expression (AND not_expression)*
+//	not_expression := NOT not_expression | '(' expression ')' | condition
+//	condition := operand operator value | operand IS [NOT] NULL
+//	operand := identifier | function '(' identifier (',' argument)* ')'
+func ParseFilter(filter string) (Node, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, wrapError(err, filter)
+	}
+
+	p := parser{tokens: tokens}
+	node, err := p.parseExpression()
+	if err != nil {
+		return nil, wrapError(err, filter)
+	}
+	if token := p.peek(); token.Type != TokenEOF {
+		return nil, wrapError(newSyntaxError(token.Position, "unexpected '%s'", token), filter)
+	}
+	return node, nil
+}
+
+// wrapError adds filter statement to the SyntaxError.
+func wrapError(err error, filter string) error {
+	if syntaxError, ok := err.(SyntaxError); ok {
+		syntaxError.Statement = filter
+		return syntaxError
+	}
+	return err
+}
+
+// peek returns current token without moving forward.
+func (p *parser) peek() Token {
+	return p.tokens[p.position]
+}
+
+// peekNext returns token after the current one without moving forward.
+func (p *parser) peekNext() Token {
+	if p.position+1 < len(p.tokens) {
+		return p.tokens[p.position+1]
+	}
+	return p.tokens[len(p.tokens)-1]
+}
+
+// next returns current token and moves forward.
+func (p *parser) next() Token {
+	token := p.tokens[p.position]
+	if token.Type != TokenEOF {
+		p.position++
+	}
+	return token
+}
+
+// isKeyword checks that token is the given keyword.
+func isKeyword(token Token, keyword string) bool {
+	return token.Type == TokenKeyword && token.Value == keyword
+}
+
+// expectKeyword moves forward if current token is the given keyword, otherwise returns error.
+func (p *parser) expectKeyword(keyword string) error {
+	if token := p.next(); !isKeyword(token, keyword) {
+		return newSyntaxError(token.Position, "expected '%s', got '%s'", keyword, token)
+	}
+	return nil
+}
+
+// parseExpression parses `and_expression (OR and_expression)*` rule.
+func (p *parser) parseExpression() (Node, error) {
+	left, err := p.parseAndExpression()
+	if err != nil {
+		return nil, err
+	}
+	for isKeyword(p.peek(), KeywordOr) {
+		p.next()
+		right, err := p.parseAndExpression()
+		if err != nil {
+			return nil, err
+		}
+		left = OrExpression{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseAndExpression parses `not_expression (AND not_expression)*` rule.
+func (p *parser) parseAndExpression() (Node, error) {
+	left, err := p.parseNotExpression()
+	if err != nil {
+		return nil, err
+	}
+	for isKeyword(p.peek(), KeywordAnd) {
+		p.next()
+		right, err := p.parseNotExpression()
+		if err != nil {
+			return nil, err
+		}
+		left = AndExpression{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseNotExpression parses `NOT not_expression | '(' expression ')' | condition` rule.
+func (p *parser) parseNotExpression() (Node, error) {
+	switch token := p.peek(); {
+	case isKeyword(token, KeywordNot):
+		p.next()
+		expression, err := p.parseNotExpression()
+		if err != nil {
+			return nil, err
+		}
+		return NotExpression{Expression: expression}, nil
+	case token.Type == TokenLeftParen:
+		p.next()
+		expression, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if token := p.next(); token.Type != TokenRightParen {
+			return nil, newSyntaxError(token.Position, "expected ')', got '%s'", token)
+		}
+		return expression, nil
+	default:
+		return p.parseCondition()
+	}
+}
+
+// parseCondition parses `operand operator value | operand IS [NOT] NULL` rule.
+func (p *parser) parseCondition() (Node, error) {
+	position := p.peek().Position
+	var function string
+	if token := p.peek(); token.Type == TokenIdentifier && !token.Quoted && p.peekNext().Type == TokenLeftParen {
+		function = p.next().Value
+		p.next()
+	}
+	entity, key, err := p.parseIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	var arguments []string
+	if function != "" {
+		if arguments, err = p.parseArguments(); err != nil {
+			return nil, err
+		}
+	}
+
+	operator, err := p.parseOperator()
+	if err != nil {
+		return nil, err
+	}
+
+	condition := Condition{
+		Entity:    entity,
+		Key:       key,
+		Function:  function,
+		Arguments: arguments,
+		Operator:  operator,
+		Position:  position,
+	}
+	switch operator {
+	case OperatorIsNull, OperatorIsNotNull:
+		return condition, nil
+	case OperatorIn, OperatorNotIn:
+		condition.Value, err = p.parseList()
+	default:
+		condition.Value, err = p.parseValue()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return condition, nil
+}
+
+// parseIdentifier parses `[entity.]key` identifier. Key could contain dots or be quoted.
+func (p *parser) parseIdentifier() (string, string, error) {
+	token := p.next()
+	if token.Type != TokenIdentifier && token.Type != TokenString {
+		return "", "", newSyntaxError(token.Position, "expected identifier, got '%s'", token)
+	}
+
+	parts := []string{token.Value}
+	quoted := token.Type == TokenString
+	for p.peek().Type == TokenDot && !quoted {
+		p.next()
+		switch token := p.next(); token.Type {
+		case TokenIdentifier, TokenNumber:
+			parts = append(parts, token.Value)
+		case TokenString:
+			parts = append(parts, token.Value)
+			quoted = true
+		default:
+			return "", "", newSyntaxError(token.Position, "expected identifier, got '%s'", token)
+		}
+	}
+
+	if len(parts) == 1 {
+		return "", parts[0], nil
+	}
+	return parts[0], strings.Join(parts[1:], "."), nil
+}
+
+// parseArguments parses `(',' argument)* ')'` rest of the function call. Arguments are numbers or strings.
+func (p *parser) parseArguments() ([]string, error) {
+	var arguments []string
+	for {
+		switch token := p.next(); token.Type {
+		case TokenRightParen:
+			return arguments, nil
+		case TokenComma:
+			switch token := p.next(); token.Type {
+			case TokenNumber, TokenString:
+				arguments = append(arguments, token.Value)
+			default:
+				return nil, newSyntaxError(token.Position, "expected argument, got '%s'", token)
+			}
+		default:
+			return nil, newSyntaxError(token.Position, "expected ',' or ')', got '%s'", token)
+		}
+	}
+}
+
+// parseOperator parses comparison operator.
+func (p *parser) parseOperator() (string, error) {
+	token := p.next()
+	switch {
+	case token.Type == TokenOperator:
+		return token.Value, nil
+	case isKeyword(token, KeywordLike), isKeyword(token, KeywordILike), isKeyword(token, KeywordIn):
+		return token.Value, nil
+	case isKeyword(token, KeywordNot):
+		if err := p.expectKeyword(KeywordIn); err != nil {
+			return "", err
+		}
+		return OperatorNotIn, nil
+	case isKeyword(token, KeywordIs):
+		if isKeyword(p.peek(), KeywordNot) {
+			p.next()
+			if err := p.expectKeyword(KeywordNull); err != nil {
+				return "", err
+			}
+			return OperatorIsNotNull, nil
+		}
+		if err := p.expectKeyword(KeywordNull); err != nil {
+			return "", err
+		}
+		return OperatorIsNull, nil
+	default:
+		return "", newSyntaxError(token.Position, "expected comparison operator, got '%s'", token)
+	}
+}
+
+// parseValue parses single string or numeric value.
+func (p *parser) parseValue() (Value, error) {
+	switch token := p.next(); token.Type {
+	case TokenString:
+		return Value{Type: ValueTypeString, Raw: token.Value, Quoted: true}, nil
+	case TokenNumber:
+		return Value{Type: ValueTypeNumber, Raw: token.Value}, nil
+	case TokenIdentifier:
+		// unquoted words are allowed for backward compatibility, e.g. `attributes.status = RUNNING`.
+		raw := token.Value
+		for p.peek().Type == TokenDot && (p.peekNext().Type == TokenIdentifier || p.peekNext().Type == TokenNumber) {
+			p.next()
+			raw = fmt.Sprintf("%s.%s", raw, p.next().Value)
+		}
+		return Value{Type: ValueTypeString, Raw: raw}, nil
+	default:
+		return Value{}, newSyntaxError(token.Position, "expected value, got '%s'", token)
+	}
+}
+
+// parseList parses `('value1', 'value2', ...)` list of string values.
+func (p *parser) parseList() (Value, error) {
+	if token := p.next(); token.Type != TokenLeftParen {
+		return Value{}, newSyntaxError(token.Position, "expected '(', got '%s'", token)
+	}
+
+	value := Value{Type: ValueTypeList}
+	for {
+		token := p.next()
+		if token.Type != TokenString {
+			return Value{}, newSyntaxError(token.Position, "expected quoted string, got '%s'", token)
+		}
+		value.List = append(value.List, token.Value)
+
+		switch token := p.next(); token.Type {
+		case TokenComma:
+			continue
+		case TokenRightParen:
+			return value, nil
+		default:
+			return Value{}, newSyntaxError(token.Position, "expected ',' or ')', got '%s'", token)
+		}
+	}
+}
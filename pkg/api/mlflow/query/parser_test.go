@@ -0,0 +1,225 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter_Ok(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		node   Node
+	}{
+		{
+			name:   "SingleCondition",
+			filter: `metrics.accuracy > 0.9`,
+			node: Condition{
+				Entity:   "metrics",
+				Key:      "accuracy",
+				Operator: OperatorGrater,
+				Value:    Value{Type: ValueTypeNumber, Raw: "0.9"},
+			},
+		},
+		{
+			name:   "ConditionWithoutEntity",
+			filter: `start_time >= -1`,
+			node: Condition{
+				Key:      "start_time",
+				Operator: OperatorGraterOrEqual,
+				Value:    Value{Type: ValueTypeNumber, Raw: "-1"},
+			},
+		},
+		{
+			name:   "KeyWithDotsAndQuotedKey",
+			filter: "tags.mlflow.runName = 'name' AND params.`my param` LIKE \"val%\"",
+			node: AndExpression{
+				Left: Condition{
+					Entity:   "tags",
+					Key:      "mlflow.runName",
+					Operator: OperatorEqual,
+					Value:    Value{Type: ValueTypeString, Raw: "name", Quoted: true},
+				},
+				Right: Condition{
+					Entity:   "params",
+					Key:      "my param",
+					Operator: OperatorLike,
+					Value:    Value{Type: ValueTypeString, Raw: "val%", Quoted: true},
+					Position: 33,
+				},
+			},
+		},
+		{
+			name:   "FunctionCall",
+			filter: "min(metrics.val_loss) < 1 OR at_step(metrics.`val loss`, 10) < 1 OR metrics.val_loss.min < 1",
+			node: OrExpression{
+				Left: OrExpression{
+					Left: Condition{
+						Entity:   "metrics",
+						Key:      "val_loss",
+						Function: "min",
+						Operator: OperatorLess,
+						Value:    Value{Type: ValueTypeNumber, Raw: "1"},
+					},
+					Right: Condition{
+						Entity:    "metrics",
+						Key:       "val loss",
+						Function:  "at_step",
+						Arguments: []string{"10"},
+						Operator:  OperatorLess,
+						Value:     Value{Type: ValueTypeNumber, Raw: "1"},
+						Position:  29,
+					},
+				},
+				Right: Condition{
+					Entity:   "metrics",
+					Key:      "val_loss.min",
+					Operator: OperatorLess,
+					Value:    Value{Type: ValueTypeNumber, Raw: "1"},
+					Position: 68,
+				},
+			},
+		},
+		{
+			name:   "OrHasLowerPrecedenceThanAnd",
+			filter: `a = 1 or b = 2 AND NOT c = 3`,
+			node: OrExpression{
+				Left: Condition{Key: "a", Operator: OperatorEqual, Value: Value{Type: ValueTypeNumber, Raw: "1"}},
+				Right: AndExpression{
+					Left: Condition{
+						Key: "b", Operator: OperatorEqual, Value: Value{Type: ValueTypeNumber, Raw: "2"}, Position: 9,
+					},
+					Right: NotExpression{
+						Expression: Condition{
+							Key: "c", Operator: OperatorEqual, Value: Value{Type: ValueTypeNumber, Raw: "3"}, Position: 23,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "Parentheses",
+			filter: `(a = 1 OR b = 2) AND c = 3`,
+			node: AndExpression{
+				Left: OrExpression{
+					Left: Condition{
+						Key: "a", Operator: OperatorEqual, Value: Value{Type: ValueTypeNumber, Raw: "1"}, Position: 1,
+					},
+					Right: Condition{
+						Key: "b", Operator: OperatorEqual, Value: Value{Type: ValueTypeNumber, Raw: "2"}, Position: 10,
+					},
+				},
+				Right: Condition{
+					Key: "c", Operator: OperatorEqual, Value: Value{Type: ValueTypeNumber, Raw: "3"}, Position: 21,
+				},
+			},
+		},
+		{
+			name:   "IsNullAndIsNotNull",
+			filter: `params.lr IS NULL OR tags.stage is not null`,
+			node: OrExpression{
+				Left: Condition{Entity: "params", Key: "lr", Operator: OperatorIsNull},
+				Right: Condition{
+					Entity: "tags", Key: "stage", Operator: OperatorIsNotNull, Position: 21,
+				},
+			},
+		},
+		{
+			name:   "NotInList",
+			filter: `attributes.run_id NOT IN ('id1','id2')`,
+			node: Condition{
+				Entity:   "attributes",
+				Key:      "run_id",
+				Operator: OperatorNotIn,
+				Value:    Value{Type: ValueTypeList, List: []string{"id1", "id2"}},
+			},
+		},
+		{
+			name:   "UnquotedValue",
+			filter: `attributes.status = RUNNING`,
+			node: Condition{
+				Entity:   "attributes",
+				Key:      "status",
+				Operator: OperatorEqual,
+				Value:    Value{Type: ValueTypeString, Raw: "RUNNING"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, err := ParseFilter(tt.filter)
+			require.Nil(t, err)
+			assert.Equal(t, tt.node, node)
+		})
+	}
+}
+
+func TestParseFilter_Error(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		error  SyntaxError
+	}{
+		{
+			name:   "UnexpectedCharacter",
+			filter: `metrics.accuracy ~ 1`,
+			error:  SyntaxError{Offset: 17, Err: "unexpected character '~'"},
+		},
+		{
+			name:   "MissingOperator",
+			filter: `metrics.accuracy 1`,
+			error:  SyntaxError{Offset: 17, Err: "expected comparison operator, got '1'"},
+		},
+		{
+			name:   "MissingClosingParenthesis",
+			filter: `(a = 1`,
+			error:  SyntaxError{Offset: 6, Err: "expected ')', got 'end of filter'"},
+		},
+		{
+			name:   "UnexpectedClosingParenthesis",
+			filter: `a = 1)`,
+			error:  SyntaxError{Offset: 5, Err: "unexpected ')'"},
+		},
+		{
+			name:   "DanglingOr",
+			filter: `a = 1 OR`,
+			error:  SyntaxError{Offset: 8, Err: "expected identifier, got 'end of filter'"},
+		},
+		{
+			name:   "InWithoutList",
+			filter: `attributes.run_id IN 'id1'`,
+			error:  SyntaxError{Offset: 21, Err: "expected '(', got 'id1'"},
+		},
+		{
+			name:   "IsWithoutNull",
+			filter: `tags.stage IS 'prod'`,
+			error:  SyntaxError{Offset: 14, Err: "expected 'NULL', got 'prod'"},
+		},
+		{
+			name:   "UnclosedFunctionCall",
+			filter: `min(metrics.loss < 1`,
+			error:  SyntaxError{Offset: 17, Err: "expected ',' or ')', got '<'"},
+		},
+		{
+			name:   "InvalidFunctionArgument",
+			filter: `at_step(metrics.loss, step) < 1`,
+			error:  SyntaxError{Offset: 22, Err: "expected argument, got 'step'"},
+		},
+		{
+			name:   "UnterminatedString",
+			filter: `tags.stage = "prod`,
+			error:  SyntaxError{Offset: 13, Err: "unterminated quoted string"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFilter(tt.filter)
+			tt.error.Statement = tt.filter
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
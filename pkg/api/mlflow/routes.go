@@ -9,10 +9,26 @@ import (
 
 // List of route prefixes.
 const (
-	RunsRoutePrefix        = "/runs"
-	MetricsRoutePrefix     = "/metrics"
-	ArtifactsRoutePrefix   = "/artifacts"
-	ExperimentsRoutePrefix = "/experiments"
+	RunsRoutePrefix             = "/runs"
+	MetricsRoutePrefix          = "/metrics"
+	ArtifactsRoutePrefix        = "/artifacts"
+	ExperimentsRoutePrefix      = "/experiments"
+	ModelVersionsRoutePrefix    = "/model-versions"
+	RegisteredModelsRoutePrefix = "/registered-models"
+)
+
+// List of `mlflow-artifacts` route prefixes.
+const (
+	MlflowArtifactsRoutePrefix                = "/artifacts"
+	MlflowArtifactsMultipartUploadRoutePrefix = "/mpu"
+)
+
+// List of `/mlflow-artifacts/mpu/*` routes.
+const (
+	MlflowArtifactsMultipartUploadAbortRoute    = "/abort/*"
+	MlflowArtifactsMultipartUploadCreateRoute   = "/create/*"
+	MlflowArtifactsMultipartUploadUploadRoute   = "/upload/*"
+	MlflowArtifactsMultipartUploadCompleteRoute = "/complete/*"
 )
 
 // List of `/artifact/*` routes.
@@ -39,6 +55,34 @@ const (
 	MetricsGetHistoriesRoute   = "/get-histories"
 	MetricsGetHistoryRoute     = "/get-history"
 	MetricsGetHistoryBulkRoute = "/get-history-bulk"
+	MetricsLogBulkRoute        = "/log-bulk"
+)
+
+// List of `/model-versions/*` routes.
+const (
+	ModelVersionsGetRoute             = "/get"
+	ModelVersionsCreateRoute          = "/create"
+	ModelVersionsDeleteRoute          = "/delete"
+	ModelVersionsSearchRoute          = "/search"
+	ModelVersionsSetTagRoute          = "/set-tag"
+	ModelVersionsUpdateRoute          = "/update"
+	ModelVersionsDeleteTagRoute       = "/delete-tag"
+	ModelVersionsGetDownloadURIRoute  = "/get-download-uri"
+	ModelVersionsTransitionStageRoute = "/transition-stage"
+)
+
+// List of `/registered-models/*` routes.
+const (
+	RegisteredModelsGetRoute               = "/get"
+	RegisteredModelsAliasRoute             = "/alias"
+	RegisteredModelsCreateRoute            = "/create"
+	RegisteredModelsDeleteRoute            = "/delete"
+	RegisteredModelsRenameRoute            = "/rename"
+	RegisteredModelsSearchRoute            = "/search"
+	RegisteredModelsSetTagRoute            = "/set-tag"
+	RegisteredModelsUpdateRoute            = "/update"
+	RegisteredModelsDeleteTagRoute         = "/delete-tag"
+	RegisteredModelsGetLatestVersionsRoute = "/get-latest-versions"
 )
 
 // List of `/runs/*` routes.
@@ -52,6 +96,7 @@ const (
 	RunsRestoreRoute      = "/restore"
 	RunsDeleteTagRoute    = "/delete-tag"
 	RunsLogBatchRoute     = "/log-batch"
+	RunsLogInputsRoute    = "/log-inputs"
 	RunsLogMetricRoute    = "/log-metric"
 	RunsLogParameterRoute = "/log-parameter"
 	RunsLogOutputRoute    = "/log-output"
@@ -60,9 +105,10 @@ const (
 
 // Router represents `mlflow` router.
 type Router struct {
-	prefixList        []string
-	controller        *controller.Controller
-	globalMiddlewares []fiber.Handler
+	prefixList                []string
+	mlflowArtifactsPrefixList []string
+	controller                *controller.Controller
+	globalMiddlewares         []fiber.Handler
 }
 
 // NewRouter creates new instance of `mlflow` router.
@@ -72,6 +118,10 @@ func NewRouter(controller *controller.Controller) *Router {
 			"/api/2.0/mlflow/",
 			"/ajax-api/2.0/mlflow/",
 		},
+		mlflowArtifactsPrefixList: []string{
+			"/api/2.0/mlflow-artifacts/",
+			"/ajax-api/2.0/mlflow-artifacts/",
+		},
 		controller:        controller,
 		globalMiddlewares: make([]fiber.Handler, 0),
 	}
@@ -79,6 +129,33 @@ func NewRouter(controller *controller.Controller) *Router {
 
 // Init makes initialization of all `mlflow` routes.
 func (r *Router) Init(router fiber.Router) {
+	// `mlflow-artifacts` routes have to be registered first,
+	// otherwise they will be caught by `mlflow` not found handler.
+	for _, prefix := range r.mlflowArtifactsPrefixList {
+		mainGroup := router.Group(prefix)
+		// apply global middlewares.
+		for _, globalMiddleware := range r.globalMiddlewares {
+			mainGroup.Use(globalMiddleware)
+		}
+
+		// setup related routes.
+		artifacts := mainGroup.Group(MlflowArtifactsRoutePrefix)
+		artifacts.Get("/", r.controller.ListProxiedArtifacts)
+		artifacts.Get("/*", r.controller.DownloadArtifact)
+		artifacts.Put("/*", r.controller.UploadArtifact)
+		artifacts.Delete("/*", r.controller.DeleteArtifact)
+
+		multipartUpload := mainGroup.Group(MlflowArtifactsMultipartUploadRoutePrefix)
+		multipartUpload.Post(MlflowArtifactsMultipartUploadAbortRoute, r.controller.AbortMultipartUpload)
+		multipartUpload.Post(MlflowArtifactsMultipartUploadCreateRoute, r.controller.CreateMultipartUpload)
+		multipartUpload.Put(MlflowArtifactsMultipartUploadUploadRoute, r.controller.UploadMultipartPart)
+		multipartUpload.Post(MlflowArtifactsMultipartUploadCompleteRoute, r.controller.CompleteMultipartUpload)
+
+		mainGroup.Use(func(c *fiber.Ctx) error {
+			return api.NewEndpointNotFound("Not found")
+		})
+	}
+
 	for _, prefix := range r.prefixList {
 		mainGroup := router.Group(prefix)
 		// apply global middlewares.
@@ -107,6 +184,33 @@ func (r *Router) Init(router fiber.Router) {
 		metrics.Get(MetricsGetHistoryRoute, r.controller.GetMetricHistory)
 		metrics.Get(MetricsGetHistoryBulkRoute, r.controller.GetMetricHistoryBulk)
 		metrics.Post(MetricsGetHistoriesRoute, r.controller.GetMetricHistories)
+		metrics.Post(MetricsLogBulkRoute, r.controller.LogMetricBulk)
+
+		modelVersions := mainGroup.Group(ModelVersionsRoutePrefix)
+		modelVersions.Post(ModelVersionsCreateRoute, r.controller.CreateModelVersion)
+		modelVersions.Delete(ModelVersionsDeleteRoute, r.controller.DeleteModelVersion)
+		modelVersions.Delete(ModelVersionsDeleteTagRoute, r.controller.DeleteModelVersionTag)
+		modelVersions.Get(ModelVersionsGetRoute, r.controller.GetModelVersion)
+		modelVersions.Get(ModelVersionsGetDownloadURIRoute, r.controller.GetModelVersionDownloadURI)
+		modelVersions.Get(ModelVersionsSearchRoute, r.controller.SearchModelVersions)
+		modelVersions.Post(ModelVersionsSetTagRoute, r.controller.SetModelVersionTag)
+		modelVersions.Post(ModelVersionsTransitionStageRoute, r.controller.TransitionModelVersionStage)
+		modelVersions.Patch(ModelVersionsUpdateRoute, r.controller.UpdateModelVersion)
+
+		registeredModels := mainGroup.Group(RegisteredModelsRoutePrefix)
+		registeredModels.Delete(RegisteredModelsAliasRoute, r.controller.DeleteRegisteredModelAlias)
+		registeredModels.Get(RegisteredModelsAliasRoute, r.controller.GetModelVersionByAlias)
+		registeredModels.Post(RegisteredModelsAliasRoute, r.controller.SetRegisteredModelAlias)
+		registeredModels.Post(RegisteredModelsCreateRoute, r.controller.CreateRegisteredModel)
+		registeredModels.Delete(RegisteredModelsDeleteRoute, r.controller.DeleteRegisteredModel)
+		registeredModels.Delete(RegisteredModelsDeleteTagRoute, r.controller.DeleteRegisteredModelTag)
+		registeredModels.Get(RegisteredModelsGetRoute, r.controller.GetRegisteredModel)
+		registeredModels.Get(RegisteredModelsGetLatestVersionsRoute, r.controller.GetLatestVersions)
+		registeredModels.Post(RegisteredModelsGetLatestVersionsRoute, r.controller.GetLatestVersions)
+		registeredModels.Post(RegisteredModelsRenameRoute, r.controller.RenameRegisteredModel)
+		registeredModels.Get(RegisteredModelsSearchRoute, r.controller.SearchRegisteredModels)
+		registeredModels.Post(RegisteredModelsSetTagRoute, r.controller.SetRegisteredModelTag)
+		registeredModels.Patch(RegisteredModelsUpdateRoute, r.controller.UpdateRegisteredModel)
 
 		runs := mainGroup.Group(RunsRoutePrefix)
 		runs.Post(RunsCreateRoute, r.controller.CreateRun)
@@ -114,6 +218,7 @@ func (r *Router) Init(router fiber.Router) {
 		runs.Post(RunsDeleteTagRoute, r.controller.DeleteRunTag)
 		runs.Get(RunsGetRoute, r.controller.GetRun)
 		runs.Post(RunsLogBatchRoute, r.controller.LogBatch)
+		runs.Post(RunsLogInputsRoute, r.controller.LogInputs)
 		runs.Post(RunsLogMetricRoute, r.controller.LogMetric)
 		runs.Post(RunsLogParameterRoute, r.controller.LogParam)
 		runs.Post(RunsRestoreRoute, r.controller.RestoreRun)
@@ -123,9 +228,6 @@ func (r *Router) Init(router fiber.Router) {
 		runs.Post(RunsLogOutputRoute, r.controller.LogOutput)
 		runs.Post(RunsLogArtifactRoute, r.controller.LogArtifact)
 
-		mainGroup.Get("/model-versions/search", r.controller.SearchModelVersions)
-		mainGroup.Get("/registered-models/search", r.controller.SearchRegisteredModels)
-
 		mainGroup.Use(func(c *fiber.Ctx) error {
 			return api.NewEndpointNotFound("Not found")
 		})
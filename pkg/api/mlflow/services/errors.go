@@ -17,7 +17,7 @@ func ErrorHandler(c *fiber.Ctx, err error) error {
 		var f *fiber.Error
 		if errors.As(err, &f) {
 			switch f.Code {
-			case fiber.StatusBadRequest:
+			case fiber.StatusBadRequest, fiber.StatusRequestEntityTooLarge:
 				code = api.ErrorCodeBadRequest
 			case fiber.StatusServiceUnavailable:
 				code = api.ErrorCodeTemporarilyUnavailable
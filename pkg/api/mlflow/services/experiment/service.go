@@ -20,6 +20,7 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
 	"github.com/G-Research/fasttrackml/pkg/database"
 )
 
@@ -46,9 +47,10 @@ const (
 
 // Service provides service layer to work with `metric` business logic.
 type Service struct {
-	config               *config.Config
-	tagRepository        repositories.TagRepositoryProvider
-	experimentRepository repositories.ExperimentRepositoryProvider
+	config                 *config.Config
+	tagRepository          repositories.TagRepositoryProvider
+	experimentRepository   repositories.ExperimentRepositoryProvider
+	artifactStorageFactory storage.ArtifactStorageFactoryProvider
 }
 
 // NewService creates new Service instance.
@@ -56,11 +58,13 @@ func NewService(
 	config *config.Config,
 	tagRepository repositories.TagRepositoryProvider,
 	experimentRepository repositories.ExperimentRepositoryProvider,
+	artifactStorageFactory storage.ArtifactStorageFactoryProvider,
 ) *Service {
 	return &Service{
-		config:               config,
-		tagRepository:        tagRepository,
-		experimentRepository: experimentRepository,
+		config:                 config,
+		tagRepository:          tagRepository,
+		experimentRepository:   experimentRepository,
+		artifactStorageFactory: artifactStorageFactory,
 	}
 }
 
@@ -84,6 +88,11 @@ func (s Service) CreateExperiment(
 	if err != nil {
 		return nil, api.NewInvalidParameterValueError("Invalid value for parameter 'artifact_location': %s", err)
 	}
+	if experiment.ArtifactLocation != "" {
+		if err := s.artifactStorageFactory.ValidateArtifactURI(experiment.ArtifactLocation); err != nil {
+			return nil, api.NewInvalidParameterValueError("Invalid value for parameter 'artifact_location': %s", err)
+		}
+	}
 	experiment.NamespaceID = ns.ID
 
 	if err := s.experimentRepository.Create(ctx, experiment); err != nil {
@@ -358,7 +367,7 @@ func (s Service) SearchExperiments(
 							return nil, 0, 0, api.NewInvalidParameterValueError("invalid string value '%s'", value)
 						}
 						value = strings.Trim(value.(string), `"'`)
-						if database.DB.Dialector.Name() == "sqlite" && strings.ToUpper(comparison) == ILikeExpression {
+						if database.DB.Dialector.Name() != "postgres" && strings.ToUpper(comparison) == ILikeExpression {
 							key = fmt.Sprintf("LOWER(%s)", key)
 							comparison = LikeExpression
 							value = strings.ToLower(value.(string))
@@ -386,7 +395,7 @@ func (s Service) SearchExperiments(
 				}
 				table := fmt.Sprintf("filter_%d", n)
 				where := fmt.Sprintf("value %s ?", comparison)
-				if database.DB.Dialector.Name() == "sqlite" && strings.ToUpper(comparison) == ILikeExpression {
+				if database.DB.Dialector.Name() != "postgres" && strings.ToUpper(comparison) == ILikeExpression {
 					where = "LOWER(value) LIKE ?"
 					value = strings.ToLower(value.(string))
 				}
@@ -394,7 +403,7 @@ func (s Service) SearchExperiments(
 					fmt.Sprintf("JOIN (?) AS %s ON experiments.experiment_id = %s.experiment_id", table, table),
 					database.DB.Select(
 						"experiment_id", "value",
-					).Where("key = ?", key).Where(where, value).Model(&database.ExperimentTag{}),
+					).Where(`"key" = ?`, key).Where(where, value).Model(&database.ExperimentTag{}),
 				)
 			default:
 				return nil, 0, 0, api.NewInvalidParameterValueError(
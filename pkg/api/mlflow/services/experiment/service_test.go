@@ -16,6 +16,7 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
 )
 
 func TestService_CreateExperiment_Ok(t *testing.T) {
@@ -33,12 +34,17 @@ func TestService_CreateExperiment_Ok(t *testing.T) {
 	experimentRepository.On(
 		"Create", context.TODO(), mock.Anything,
 	).Return(nil)
+	artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
+	artifactStorageFactory.On(
+		"ValidateArtifactURI", "/artifact/location",
+	).Return(nil)
 
 	// call service under testing.
 	service := NewService(
 		&config.Config{},
 		&repositories.MockTagRepositoryProvider{},
 		&experimentRepository,
+		&artifactStorageFactory,
 	)
 	experiment, err := service.CreateExperiment(context.TODO(), &ns, &request.CreateExperimentRequest{
 		Name: "name",
@@ -88,6 +94,7 @@ func TestService_CreateExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -110,6 +117,33 @@ func TestService_CreateExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&storage.MockArtifactStorageFactoryProvider{},
+				)
+			},
+		},
+		{
+			name: "UnsupportedArtifactLocation",
+			error: api.NewInvalidParameterValueError(
+				`Invalid value for parameter 'artifact_location': unsupported artifact location`,
+			),
+			request: &request.CreateExperimentRequest{
+				Name:             "name",
+				ArtifactLocation: "https://example.com/artifacts",
+			},
+			service: func() *Service {
+				experimentRepository := repositories.MockExperimentRepositoryProvider{}
+				experimentRepository.On(
+					"GetByNamespaceIDAndName", context.TODO(), ns.ID, "name",
+				).Return(nil, nil)
+				artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
+				artifactStorageFactory.On(
+					"ValidateArtifactURI", "https://example.com/artifacts",
+				).Return(errors.New("unsupported artifact location"))
+				return NewService(
+					&config.Config{},
+					&repositories.MockTagRepositoryProvider{},
+					&experimentRepository,
+					&artifactStorageFactory,
 				)
 			},
 		},
@@ -128,6 +162,7 @@ func TestService_CreateExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -146,6 +181,7 @@ func TestService_CreateExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -167,6 +203,7 @@ func TestService_CreateExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -196,6 +233,7 @@ func TestService_CreateExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -239,6 +277,7 @@ func TestService_DeleteExperiment_Ok(t *testing.T) {
 		&config.Config{},
 		&repositories.MockTagRepositoryProvider{},
 		&experimentRepository,
+		&storage.MockArtifactStorageFactoryProvider{},
 	)
 	err := service.DeleteExperiment(context.TODO(), &ns, &request.DeleteExperimentRequest{
 		ID: "1",
@@ -271,6 +310,7 @@ func TestService_DeleteExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -287,6 +327,7 @@ func TestService_DeleteExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -305,6 +346,7 @@ func TestService_DeleteExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -326,6 +368,7 @@ func TestService_DeleteExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -349,6 +392,7 @@ func TestService_DeleteExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -398,6 +442,7 @@ func TestService_GetExperiment_Ok(t *testing.T) {
 		&config.Config{},
 		&repositories.MockTagRepositoryProvider{},
 		&experimentRepository,
+		&storage.MockArtifactStorageFactoryProvider{},
 	)
 	experiment, err := service.GetExperiment(context.TODO(), &ns, &request.GetExperimentRequest{
 		ID: "1",
@@ -446,6 +491,7 @@ func TestService_GetExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -462,6 +508,7 @@ func TestService_GetExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -480,6 +527,7 @@ func TestService_GetExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -530,6 +578,7 @@ func TestService_GetExperimentByName_Ok(t *testing.T) {
 		&config.Config{},
 		&repositories.MockTagRepositoryProvider{},
 		&experimentRepository,
+		&storage.MockArtifactStorageFactoryProvider{},
 	)
 	experiment, err := service.GetExperimentByName(
 		context.TODO(),
@@ -582,6 +631,7 @@ func TestService_GetExperimentByName_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -600,6 +650,7 @@ func TestService_GetExperimentByName_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -618,6 +669,7 @@ func TestService_GetExperimentByName_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -661,6 +713,7 @@ func TestService_RestoreExperiment_Ok(t *testing.T) {
 		&config.Config{},
 		&repositories.MockTagRepositoryProvider{},
 		&experimentRepository,
+		&storage.MockArtifactStorageFactoryProvider{},
 	)
 	err := service.RestoreExperiment(context.TODO(), &ns, &request.RestoreExperimentRequest{
 		ID: "1",
@@ -692,6 +745,7 @@ func TestService_RestoreExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -708,6 +762,7 @@ func TestService_RestoreExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -726,6 +781,7 @@ func TestService_RestoreExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -749,6 +805,7 @@ func TestService_RestoreExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -793,6 +850,7 @@ func TestService_SetExperimentTag_Ok(t *testing.T) {
 		&config.Config{},
 		&tagsRepository,
 		&experimentRepository,
+		&storage.MockArtifactStorageFactoryProvider{},
 	)
 	err := service.SetExperimentTag(context.TODO(), &ns, &request.SetExperimentTagRequest{
 		ID:    "1",
@@ -826,6 +884,7 @@ func TestService_SetExperimentTag_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -840,6 +899,7 @@ func TestService_SetExperimentTag_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -857,6 +917,7 @@ func TestService_SetExperimentTag_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -876,6 +937,7 @@ func TestService_SetExperimentTag_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -904,6 +966,7 @@ func TestService_SetExperimentTag_Error(t *testing.T) {
 					&config.Config{},
 					&tagRepository,
 					&experimentRepository,
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -946,6 +1009,7 @@ func TestService_UpdateExperiment_Ok(t *testing.T) {
 		&config.Config{},
 		&repositories.MockTagRepositoryProvider{},
 		&experimentRepository,
+		&storage.MockArtifactStorageFactoryProvider{},
 	)
 	err := service.UpdateExperiment(context.TODO(), &ns, &request.UpdateExperimentRequest{
 		ID:   "1",
@@ -978,6 +1042,7 @@ func TestService_UpdateExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -992,6 +1057,7 @@ func TestService_UpdateExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -1009,6 +1075,7 @@ func TestService_UpdateExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&repositories.MockExperimentRepositoryProvider{},
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -1028,6 +1095,7 @@ func TestService_UpdateExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -1052,6 +1120,7 @@ func TestService_UpdateExperiment_Error(t *testing.T) {
 					&config.Config{},
 					&repositories.MockTagRepositoryProvider{},
 					&experimentRepository,
+					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
 		},
@@ -8,6 +8,8 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/sampling"
+	"github.com/G-Research/fasttrackml/pkg/common/tracing"
 )
 
 // Service provides service layer to work with `metric` business logic.
@@ -55,6 +57,9 @@ func (s Service) GetMetricHistory(
 func (s Service) GetMetricHistoryBulk(
 	ctx context.Context, namespace *models.Namespace, req *request.GetMetricHistoryBulkRequest,
 ) ([]models.Metric, error) {
+	ctx, span := tracing.StartSpan(ctx, "mlflow.MetricService.GetMetricHistoryBulk")
+	defer span.End()
+
 	if err := ValidateGetMetricHistoryBulkRequest(req); err != nil {
 		return nil, err
 	}
@@ -76,6 +81,9 @@ func (s Service) GetMetricHistoryBulk(
 func (s Service) GetMetricHistories(
 	ctx context.Context, namespace *models.Namespace, req *request.GetMetricHistoriesRequest,
 ) (*sql.Rows, func(*sql.Rows, interface{}) error, error) {
+	ctx, span := tracing.StartSpan(ctx, "mlflow.MetricService.GetMetricHistories")
+	defer span.End()
+
 	adjustGetMetricHistoriesRequestForNamespace(namespace, req)
 	if err := ValidateGetMetricHistoriesRequest(req); err != nil {
 		return nil, nil, err
@@ -89,6 +97,8 @@ func (s Service) GetMetricHistories(
 		req.MetricKeys,
 		req.ViewType,
 		req.MaxResults,
+		req.MaxPoints,
+		sampling.GetMode(req.Sampling),
 		req.Context,
 	)
 	if err != nil {
@@ -15,6 +15,7 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/sampling"
 )
 
 func TestService_GetMetricHistory_Ok(t *testing.T) {
@@ -335,6 +336,8 @@ func TestNewService_GetMetricHistories_Ok(t *testing.T) {
 				[]string{"key1", "key2"},
 				request.ViewTypeActiveOnly,
 				int32(1),
+				int32(0),
+				sampling.ModeLTTB,
 				map[string]string(nil),
 			).Return(
 				tt.expectedRows,
@@ -403,6 +406,35 @@ func TestNewService_GetMetricHistories_Error(t *testing.T) {
 				return NewService(&runRepository, &metricRepository)
 			},
 		},
+		{
+			name:  "IncorrectMaxPoints",
+			error: api.NewInvalidParameterValueError(`Invalid value for parameter 'max_points' supplied.`),
+			request: &request.GetMetricHistoriesRequest{
+				RunIDs:    []string{"1"},
+				MaxPoints: -1,
+			},
+			service: func() *Service {
+				runRepository := repositories.MockRunRepositoryProvider{}
+				metricRepository := repositories.MockMetricRepositoryProvider{}
+				return NewService(&runRepository, &metricRepository)
+			},
+		},
+		{
+			name: "UnsupportedSampling",
+			error: api.NewInvalidParameterValueError(
+				`Invalid value 'average' for parameter 'sampling'. Valid values are ['lttb', 'minmax']`,
+			),
+			request: &request.GetMetricHistoriesRequest{
+				RunIDs:    []string{"1"},
+				MaxPoints: 10,
+				Sampling:  "average",
+			},
+			service: func() *Service {
+				runRepository := repositories.MockRunRepositoryProvider{}
+				metricRepository := repositories.MockMetricRepositoryProvider{}
+				return NewService(&runRepository, &metricRepository)
+			},
+		},
 		{
 			name:  "GetGetMetricHistoriesDatabaseError",
 			error: api.NewInternalError(`Unable to search runs: database error`),
@@ -424,6 +456,8 @@ func TestNewService_GetMetricHistories_Error(t *testing.T) {
 					[]string{"key1", "key2"},
 					request.ViewTypeAll,
 					int32(1),
+					int32(0),
+					sampling.ModeLTTB,
 					map[string]string(nil),
 				).Return(
 					nil,
@@ -3,6 +3,7 @@ package metric
 import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/sampling"
 )
 
 const (
@@ -66,5 +67,15 @@ func ValidateGetMetricHistoriesRequest(req *request.GetMetricHistoriesRequest) e
 	if req.MaxResults > MaxResultsForMetricHistoriesRequest {
 		return api.NewInvalidParameterValueError("Invalid value for parameter 'max_results' supplied.")
 	}
+
+	if req.MaxPoints < 0 {
+		return api.NewInvalidParameterValueError("Invalid value for parameter 'max_points' supplied.")
+	}
+
+	if !sampling.Mode(req.Sampling).IsValid() {
+		return api.NewInvalidParameterValueError(
+			"Invalid value '%s' for parameter 'sampling'. Valid values are ['lttb', 'minmax']", req.Sampling,
+		)
+	}
 	return nil
 }
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+)
+
+// ParseModelVersionStage converts case-insensitive stage name into models.ModelVersionStage.
+func ParseModelVersionStage(stage string) (models.ModelVersionStage, bool) {
+	for _, s := range []models.ModelVersionStage{
+		models.ModelVersionStageNone,
+		models.ModelVersionStageStaging,
+		models.ModelVersionStageProduction,
+		models.ModelVersionStageArchived,
+	} {
+		if strings.EqualFold(stage, string(s)) {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+// ConvertCreateRegisteredModelRequestToDBModel converts request.CreateRegisteredModelRequest
+// into actual models.RegisteredModel model.
+func ConvertCreateRegisteredModelRequestToDBModel(
+	namespaceID uint, req *request.CreateRegisteredModelRequest,
+) *models.RegisteredModel {
+	ts := time.Now().UTC().UnixMilli()
+	registeredModel := models.RegisteredModel{
+		Name:           req.Name,
+		Description:    req.Description,
+		CreationTime:   ts,
+		LastUpdateTime: ts,
+		NamespaceID:    namespaceID,
+	}
+	for _, tag := range req.Tags {
+		registeredModel.Tags = append(registeredModel.Tags, models.RegisteredModelTag{
+			Key:   tag.Key,
+			Value: tag.Value,
+		})
+	}
+	return &registeredModel
+}
+
+// ConvertCreateModelVersionRequestToDBModel converts request.CreateModelVersionRequest
+// into actual models.ModelVersion model.
+func ConvertCreateModelVersionRequestToDBModel(
+	registeredModel *models.RegisteredModel, req *request.CreateModelVersionRequest,
+) *models.ModelVersion {
+	ts := time.Now().UTC().UnixMilli()
+	version := models.ModelVersion{
+		RegisteredModelID: registeredModel.ID,
+		CreationTime:      ts,
+		LastUpdateTime:    ts,
+		Description:       req.Description,
+		CurrentStage:      models.ModelVersionStageNone,
+		Source:            req.Source,
+		RunID:             req.RunID,
+		RunLink:           req.RunLink,
+		Status:            models.ModelVersionStatusReady,
+	}
+	for _, tag := range req.Tags {
+		version.Tags = append(version.Tags, models.ModelVersionTag{
+			Key:   tag.Key,
+			Value: tag.Value,
+		})
+	}
+	return &version
+}
@@ -2,26 +2,821 @@ package model
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+//nolint:lll
+var (
+	filterAnd     = regexp.MustCompile(`(?i)\s+AND\s+`)
+	filterCond    = regexp.MustCompile(`^(?:(\w+)\.)?("[^"]+"|` + "`[^`]+`" + `|[\w\.]+)\s+(<|<=|>|>=|=|!=|(?i:I?LIKE)|(?i:(?:NOT )?IN))\s+(\((?:'[^']+'(?:,\s*)?)+\)|"[^"]+"|'[^']+'|[\w\.]+)$`)
+	filterInGroup = regexp.MustCompile(`,\s*`)
+	modelOrder    = regexp.MustCompile(`^(?:attr(?:ibutes?)?\.)?(\w+)(?i:\s+(ASC|DESC))?$`)
+)
+
+// supported expression list.
+const (
+	InExpression            = "IN"
+	NotInExpression         = "NOT IN"
+	LikeExpression          = "LIKE"
+	ILikeExpression         = "ILIKE"
+	EqualExpression         = "="
+	NotEqualExpression      = "!="
+	LessExpression          = "<"
+	LessOrEqualExpression   = "<="
+	GraterExpression        = ">"
+	GraterOrEqualExpression = ">="
 )
 
 // Service provides service layer to work with `model` business logic.
-type Service struct{}
+type Service struct {
+	modelVersionRepository    repositories.ModelVersionRepositoryProvider
+	registeredModelRepository repositories.RegisteredModelRepositoryProvider
+}
 
 // NewService creates new Service instance.
-func NewService() *Service {
-	return &Service{}
+func NewService(
+	modelVersionRepository repositories.ModelVersionRepositoryProvider,
+	registeredModelRepository repositories.RegisteredModelRepositoryProvider,
+) *Service {
+	return &Service{
+		modelVersionRepository:    modelVersionRepository,
+		registeredModelRepository: registeredModelRepository,
+	}
+}
+
+// CreateRegisteredModel creates new models.RegisteredModel entity.
+func (s Service) CreateRegisteredModel(
+	ctx context.Context, ns *models.Namespace, req *request.CreateRegisteredModelRequest,
+) (*models.RegisteredModel, error) {
+	if err := ValidateCreateRegisteredModelRequest(req); err != nil {
+		return nil, err
+	}
+
+	registeredModel, err := s.registeredModelRepository.GetByNamespaceIDAndName(ctx, ns.ID, req.Name)
+	if err != nil {
+		return nil, api.NewInternalError("error getting registered model with name: '%s', error: %s", req.Name, err)
+	}
+	if registeredModel != nil {
+		return nil, api.NewResourceAlreadyExistsError("Registered Model (name=%s) already exists", req.Name)
+	}
+
+	registeredModel = ConvertCreateRegisteredModelRequestToDBModel(ns.ID, req)
+	if err := s.registeredModelRepository.Create(ctx, registeredModel); err != nil {
+		return nil, api.NewInternalError("error inserting registered model '%s': %s", req.Name, err)
+	}
+	return registeredModel, nil
+}
+
+// GetRegisteredModel returns existing models.RegisteredModel entity by its name.
+func (s Service) GetRegisteredModel(
+	ctx context.Context, ns *models.Namespace, req *request.GetRegisteredModelRequest,
+) (*models.RegisteredModel, error) {
+	if err := ValidateGetRegisteredModelRequest(req); err != nil {
+		return nil, err
+	}
+	return s.getRegisteredModel(ctx, ns, req.Name)
+}
+
+// RenameRegisteredModel renames existing models.RegisteredModel entity.
+func (s Service) RenameRegisteredModel(
+	ctx context.Context, ns *models.Namespace, req *request.RenameRegisteredModelRequest,
+) (*models.RegisteredModel, error) {
+	if err := ValidateRenameRegisteredModelRequest(req); err != nil {
+		return nil, err
+	}
+
+	registeredModel, err := s.getRegisteredModel(ctx, ns, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.registeredModelRepository.GetByNamespaceIDAndName(ctx, ns.ID, req.NewName)
+	if err != nil {
+		return nil, api.NewInternalError("error getting registered model with name: '%s', error: %s", req.NewName, err)
+	}
+	if existing != nil {
+		return nil, api.NewResourceAlreadyExistsError("Registered Model (name=%s) already exists", req.NewName)
+	}
+
+	registeredModel.Name = req.NewName
+	registeredModel.LastUpdateTime = time.Now().UTC().UnixMilli()
+	if err := s.registeredModelRepository.Update(ctx, registeredModel); err != nil {
+		return nil, api.NewInternalError("unable to rename registered model '%s': %s", req.Name, err)
+	}
+	return registeredModel, nil
+}
+
+// UpdateRegisteredModel updates description of existing models.RegisteredModel entity.
+func (s Service) UpdateRegisteredModel(
+	ctx context.Context, ns *models.Namespace, req *request.UpdateRegisteredModelRequest,
+) (*models.RegisteredModel, error) {
+	if err := ValidateUpdateRegisteredModelRequest(req); err != nil {
+		return nil, err
+	}
+
+	registeredModel, err := s.getRegisteredModel(ctx, ns, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	registeredModel.Description = req.Description
+	registeredModel.LastUpdateTime = time.Now().UTC().UnixMilli()
+	if err := s.registeredModelRepository.Update(ctx, registeredModel); err != nil {
+		return nil, api.NewInternalError("unable to update registered model '%s': %s", req.Name, err)
+	}
+	return registeredModel, nil
+}
+
+// DeleteRegisteredModel deletes existing models.RegisteredModel entity with all its versions.
+func (s Service) DeleteRegisteredModel(
+	ctx context.Context, ns *models.Namespace, req *request.DeleteRegisteredModelRequest,
+) error {
+	if err := ValidateDeleteRegisteredModelRequest(req); err != nil {
+		return err
+	}
+
+	registeredModel, err := s.getRegisteredModel(ctx, ns, req.Name)
+	if err != nil {
+		return err
+	}
+
+	if err := s.registeredModelRepository.Delete(ctx, registeredModel); err != nil {
+		return api.NewInternalError("unable to delete registered model '%s': %s", req.Name, err)
+	}
+	return nil
+}
+
+// GetLatestVersions returns the latest models.ModelVersion entities for each requested stage.
+func (s Service) GetLatestVersions(
+	ctx context.Context, ns *models.Namespace, req *request.GetLatestVersionsRequest,
+) (*models.RegisteredModel, []models.ModelVersion, error) {
+	if err := ValidateGetLatestVersionsRequest(req); err != nil {
+		return nil, nil, err
+	}
+
+	registeredModel, err := s.getRegisteredModel(ctx, ns, req.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stages := make([]models.ModelVersionStage, 0, len(req.Stages))
+	for _, stage := range req.Stages {
+		//nolint:errcheck
+		parsed, _ := ParseModelVersionStage(stage)
+		stages = append(stages, parsed)
+	}
+	return registeredModel, registeredModel.GetLatestVersions(stages...), nil
+}
+
+// SetRegisteredModelTag sets a tag on existing models.RegisteredModel entity.
+func (s Service) SetRegisteredModelTag(
+	ctx context.Context, ns *models.Namespace, req *request.SetRegisteredModelTagRequest,
+) error {
+	if err := ValidateSetRegisteredModelTagRequest(req); err != nil {
+		return err
+	}
+
+	registeredModel, err := s.getRegisteredModel(ctx, ns, req.Name)
+	if err != nil {
+		return err
+	}
+
+	if err := s.registeredModelRepository.SetTag(ctx, &models.RegisteredModelTag{
+		Key:               req.Key,
+		Value:             req.Value,
+		RegisteredModelID: registeredModel.ID,
+	}); err != nil {
+		return api.NewInternalError("unable to set tag for registered model '%s': %s", req.Name, err)
+	}
+	return nil
+}
+
+// DeleteRegisteredModelTag deletes a tag from existing models.RegisteredModel entity.
+func (s Service) DeleteRegisteredModelTag(
+	ctx context.Context, ns *models.Namespace, req *request.DeleteRegisteredModelTagRequest,
+) error {
+	if err := ValidateDeleteRegisteredModelTagRequest(req); err != nil {
+		return err
+	}
+
+	registeredModel, err := s.getRegisteredModel(ctx, ns, req.Name)
+	if err != nil {
+		return err
+	}
+
+	if err := s.registeredModelRepository.DeleteTag(ctx, registeredModel.ID, req.Key); err != nil {
+		return api.NewInternalError("unable to delete tag for registered model '%s': %s", req.Name, err)
+	}
+	return nil
+}
+
+// SetRegisteredModelAlias points an alias of existing models.RegisteredModel entity to the provided version.
+func (s Service) SetRegisteredModelAlias(
+	ctx context.Context, ns *models.Namespace, req *request.SetRegisteredModelAliasRequest,
+) error {
+	if err := ValidateSetRegisteredModelAliasRequest(req); err != nil {
+		return err
+	}
+
+	registeredModel, err := s.getRegisteredModel(ctx, ns, req.Name)
+	if err != nil {
+		return err
+	}
+
+	version, err := s.getModelVersion(ctx, registeredModel, req.Version)
+	if err != nil {
+		return err
+	}
+
+	if err := s.registeredModelRepository.SetAlias(ctx, &models.RegisteredModelAlias{
+		Alias:             req.Alias,
+		Version:           version.Version,
+		RegisteredModelID: registeredModel.ID,
+	}); err != nil {
+		return api.NewInternalError("unable to set alias for registered model '%s': %s", req.Name, err)
+	}
+	return nil
+}
+
+// DeleteRegisteredModelAlias deletes an alias from existing models.RegisteredModel entity.
+func (s Service) DeleteRegisteredModelAlias(
+	ctx context.Context, ns *models.Namespace, req *request.DeleteRegisteredModelAliasRequest,
+) error {
+	if err := ValidateDeleteRegisteredModelAliasRequest(req); err != nil {
+		return err
+	}
+
+	registeredModel, err := s.getRegisteredModel(ctx, ns, req.Name)
+	if err != nil {
+		return err
+	}
+
+	if err := s.registeredModelRepository.DeleteAlias(ctx, registeredModel.ID, req.Alias); err != nil {
+		return api.NewInternalError("unable to delete alias for registered model '%s': %s", req.Name, err)
+	}
+	return nil
+}
+
+// GetModelVersionByAlias returns models.ModelVersion entity the provided alias points to.
+func (s Service) GetModelVersionByAlias(
+	ctx context.Context, ns *models.Namespace, req *request.GetModelVersionByAliasRequest,
+) (*models.ModelVersion, error) {
+	if err := ValidateGetModelVersionByAliasRequest(req); err != nil {
+		return nil, err
+	}
+
+	registeredModel, err := s.getRegisteredModel(ctx, ns, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, alias := range registeredModel.Aliases {
+		if alias.Alias == req.Alias {
+			return s.getModelVersion(ctx, registeredModel, strconv.FormatInt(alias.Version, 10))
+		}
+	}
+	return nil, api.NewInvalidParameterValueError("Registered model alias %s not found.", req.Alias)
+}
+
+// CreateModelVersion creates new models.ModelVersion entity.
+func (s Service) CreateModelVersion(
+	ctx context.Context, ns *models.Namespace, req *request.CreateModelVersionRequest,
+) (*models.ModelVersion, error) {
+	if err := ValidateCreateModelVersionRequest(req); err != nil {
+		return nil, err
+	}
+
+	registeredModel, err := s.getRegisteredModel(ctx, ns, req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	version := ConvertCreateModelVersionRequestToDBModel(registeredModel, req)
+	if err := s.modelVersionRepository.Create(ctx, version); err != nil {
+		return nil, api.NewInternalError("error inserting model version for registered model '%s': %s", req.Name, err)
+	}
+	version.RegisteredModel = *registeredModel
+	return version, nil
+}
+
+// GetModelVersion returns existing models.ModelVersion entity.
+func (s Service) GetModelVersion(
+	ctx context.Context, ns *models.Namespace, req *request.GetModelVersionRequest,
+) (*models.ModelVersion, error) {
+	if err := ValidateGetModelVersionRequest(req); err != nil {
+		return nil, err
+	}
+	return s.getModelVersionByName(ctx, ns, req.Name, req.Version)
+}
+
+// UpdateModelVersion updates description of existing models.ModelVersion entity.
+func (s Service) UpdateModelVersion(
+	ctx context.Context, ns *models.Namespace, req *request.UpdateModelVersionRequest,
+) (*models.ModelVersion, error) {
+	if err := ValidateUpdateModelVersionRequest(req); err != nil {
+		return nil, err
+	}
+
+	version, err := s.getModelVersionByName(ctx, ns, req.Name, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	version.Description = req.Description
+	version.LastUpdateTime = time.Now().UTC().UnixMilli()
+	if err := s.modelVersionRepository.Update(ctx, version); err != nil {
+		return nil, api.NewInternalError(
+			"unable to update model version '%s' of registered model '%s': %s", req.Version, req.Name, err,
+		)
+	}
+	return version, nil
+}
+
+// DeleteModelVersion deletes existing models.ModelVersion entity.
+func (s Service) DeleteModelVersion(
+	ctx context.Context, ns *models.Namespace, req *request.DeleteModelVersionRequest,
+) error {
+	if err := ValidateDeleteModelVersionRequest(req); err != nil {
+		return err
+	}
+
+	version, err := s.getModelVersionByName(ctx, ns, req.Name, req.Version)
+	if err != nil {
+		return err
+	}
+
+	version.LastUpdateTime = time.Now().UTC().UnixMilli()
+	if err := s.modelVersionRepository.Delete(ctx, version); err != nil {
+		return api.NewInternalError(
+			"unable to delete model version '%s' of registered model '%s': %s", req.Version, req.Name, err,
+		)
+	}
+	return nil
+}
+
+// GetModelVersionDownloadURI returns existing models.ModelVersion entity to build its download URI.
+func (s Service) GetModelVersionDownloadURI(
+	ctx context.Context, ns *models.Namespace, req *request.GetModelVersionDownloadURIRequest,
+) (*models.ModelVersion, error) {
+	if err := ValidateGetModelVersionDownloadURIRequest(req); err != nil {
+		return nil, err
+	}
+	return s.getModelVersionByName(ctx, ns, req.Name, req.Version)
+}
+
+// TransitionModelVersionStage moves existing models.ModelVersion entity to the requested stage.
+func (s Service) TransitionModelVersionStage(
+	ctx context.Context, ns *models.Namespace, req *request.TransitionModelVersionStageRequest,
+) (*models.ModelVersion, error) {
+	if err := ValidateTransitionModelVersionStageRequest(req); err != nil {
+		return nil, err
+	}
+
+	version, err := s.getModelVersionByName(ctx, ns, req.Name, req.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint:errcheck
+	stage, _ := ParseModelVersionStage(req.Stage)
+	version.LastUpdateTime = time.Now().UTC().UnixMilli()
+	if err := s.modelVersionRepository.TransitionStage(
+		ctx, version, stage, req.ArchiveExistingVersions,
+	); err != nil {
+		return nil, api.NewInternalError(
+			"unable to transition model version '%s' of registered model '%s': %s", req.Version, req.Name, err,
+		)
+	}
+	return version, nil
+}
+
+// SetModelVersionTag sets a tag on existing models.ModelVersion entity.
+func (s Service) SetModelVersionTag(
+	ctx context.Context, ns *models.Namespace, req *request.SetModelVersionTagRequest,
+) error {
+	if err := ValidateSetModelVersionTagRequest(req); err != nil {
+		return err
+	}
+
+	version, err := s.getModelVersionByName(ctx, ns, req.Name, req.Version)
+	if err != nil {
+		return err
+	}
+
+	if err := s.modelVersionRepository.SetTag(ctx, &models.ModelVersionTag{
+		Key:            req.Key,
+		Value:          req.Value,
+		ModelVersionID: version.ID,
+	}); err != nil {
+		return api.NewInternalError(
+			"unable to set tag for model version '%s' of registered model '%s': %s", req.Version, req.Name, err,
+		)
+	}
+	return nil
+}
+
+// DeleteModelVersionTag deletes a tag from existing models.ModelVersion entity.
+func (s Service) DeleteModelVersionTag(
+	ctx context.Context, ns *models.Namespace, req *request.DeleteModelVersionTagRequest,
+) error {
+	if err := ValidateDeleteModelVersionTagRequest(req); err != nil {
+		return err
+	}
+
+	version, err := s.getModelVersionByName(ctx, ns, req.Name, req.Version)
+	if err != nil {
+		return err
+	}
+
+	if err := s.modelVersionRepository.DeleteTag(ctx, version.ID, req.Key); err != nil {
+		return api.NewInternalError(
+			"unable to delete tag for model version '%s' of registered model '%s': %s", req.Version, req.Name, err,
+		)
+	}
+	return nil
+}
+
+// SearchRegisteredModels searches models.RegisteredModel entities by the provided filter.
+//
+// nolint: gocyclo
+func (s Service) SearchRegisteredModels(
+	ctx context.Context, ns *models.Namespace, req *request.SearchRegisteredModelsRequest,
+) ([]models.RegisteredModel, int, int, error) {
+	if err := ValidateSearchRegisteredModelsRequest(req); err != nil {
+		return nil, 0, 0, err
+	}
+
+	db := s.registeredModelRepository.GetDB().WithContext(ctx)
+	query := db.Where("registered_models.namespace_id = ?", ns.ID)
+
+	// MaxResults
+	limit := int(req.MaxResults)
+	if limit == 0 {
+		limit = DefaultRegisteredModelsResultsLimit
+	}
+	query.Limit(limit + 1)
+
+	// PageToken
+	offset, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	query.Offset(offset)
+
+	// Filter
+	if req.Filter != "" {
+		for n, f := range filterAnd.Split(req.Filter, -1) {
+			entity, key, comparison, value, err := parseFilterCondition(f)
+			if err != nil {
+				return nil, 0, 0, err
+			}
+			switch entity {
+			case "", "attribute", "attributes", "attr":
+				if key != "name" {
+					return nil, 0, 0, api.NewInvalidParameterValueError(
+						"invalid attribute '%s'. Valid values are ['name']", key,
+					)
+				}
+				where, value, err := newStringCondition(db, "registered_models.name", comparison, value)
+				if err != nil {
+					return nil, 0, 0, err
+				}
+				query.Where(where, value)
+			case "tag", "tags":
+				where, value, err := newStringCondition(db, "value", comparison, value)
+				if err != nil {
+					return nil, 0, 0, err
+				}
+				table := fmt.Sprintf("filter_%d", n)
+				query.Joins(
+					fmt.Sprintf("JOIN (?) AS %s ON registered_models.id = %s.registered_model_id", table, table),
+					db.Select(
+						"registered_model_id", "value",
+					).Where(`"key" = ?`, key).Where(where, value).Model(&models.RegisteredModelTag{}),
+				)
+			default:
+				return nil, 0, 0, api.NewInvalidParameterValueError(
+					"invalid entity type '%s'. Valid values are ['tag', 'attribute']", entity,
+				)
+			}
+		}
+	}
+
+	// OrderBy
+	nameOrder := false
+	for _, o := range req.OrderBy {
+		components := modelOrder.FindStringSubmatch(o)
+		if len(components) == 0 {
+			return nil, 0, 0, api.NewInvalidParameterValueError("invalid order_by clause '%s'", o)
+		}
+
+		var column string
+		switch components[1] {
+		case "name":
+			nameOrder = true
+			column = "registered_models.name"
+		case "timestamp", "last_updated_timestamp":
+			column = "registered_models.last_update_time"
+		case "creation_timestamp":
+			column = "registered_models.creation_time"
+		default:
+			return nil, 0, 0, api.NewInvalidParameterValueError(
+				`invalid order_by attribute '%s'. `+
+					`Valid values are ['name', 'timestamp', 'last_updated_timestamp', 'creation_timestamp']`,
+				components[1],
+			)
+		}
+		query.Order(clause.OrderByColumn{
+			Column: clause.Column{Name: column, Raw: true},
+			Desc:   len(components) == 3 && strings.ToUpper(components[2]) == "DESC",
+		})
+	}
+	if !nameOrder {
+		query.Order("registered_models.name ASC")
+	}
+
+	// Actual query
+	var registeredModels []models.RegisteredModel
+	if err := query.Preload(
+		"Tags",
+	).Preload(
+		"Aliases",
+	).Preload(
+		"Versions", "current_stage != ?", models.ModelVersionStageDeletedInternal,
+	).Preload(
+		"Versions.Tags",
+	).Find(&registeredModels).Error; err != nil {
+		return nil, 0, 0, api.NewInternalError("unable to search registered models: %s", err)
+	}
+
+	return registeredModels, limit, offset, nil
+}
+
+// SearchModelVersions searches models.ModelVersion entities by the provided filter.
+//
+// nolint: gocyclo
+func (s Service) SearchModelVersions(
+	ctx context.Context, ns *models.Namespace, req *request.SearchModelVersionsRequest,
+) ([]models.ModelVersion, int, int, error) {
+	if err := ValidateSearchModelVersionsRequest(req); err != nil {
+		return nil, 0, 0, err
+	}
+
+	db := s.modelVersionRepository.GetDB().WithContext(ctx)
+	query := db.Joins(
+		"INNER JOIN registered_models ON registered_models.id = model_versions.registered_model_id",
+	).Where(
+		"registered_models.namespace_id = ?", ns.ID,
+	).Where(
+		"model_versions.current_stage != ?", models.ModelVersionStageDeletedInternal,
+	)
+
+	// MaxResults
+	limit := int(req.MaxResults)
+	if limit == 0 {
+		limit = DefaultModelVersionsResultsLimit
+	}
+	query.Limit(limit + 1)
+
+	// PageToken
+	offset, err := decodePageToken(req.PageToken)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	query.Offset(offset)
+
+	// Filter
+	if req.Filter != "" {
+		for n, f := range filterAnd.Split(req.Filter, -1) {
+			entity, key, comparison, value, err := parseFilterCondition(f)
+			if err != nil {
+				return nil, 0, 0, err
+			}
+			switch entity {
+			case "", "attribute", "attributes", "attr":
+				switch key {
+				case "name", "source_path":
+					column := "registered_models.name"
+					if key == "source_path" {
+						column = "model_versions.source"
+					}
+					where, value, err := newStringCondition(db, column, comparison, value)
+					if err != nil {
+						return nil, 0, 0, err
+					}
+					query.Where(where, value)
+				case "run_id":
+					switch strings.ToUpper(comparison) {
+					case InExpression, NotInExpression:
+						if !strings.HasPrefix(value, "(") {
+							return nil, 0, 0, api.NewInvalidParameterValueError("invalid list definition '%s'", value)
+						}
+						var values []string
+						for _, v := range filterInGroup.Split(value[1:len(value)-1], -1) {
+							values = append(values, strings.Trim(v, "'"))
+						}
+						query.Where(fmt.Sprintf("model_versions.run_uuid %s ?", strings.ToUpper(comparison)), values)
+					case EqualExpression, NotEqualExpression:
+						if strings.HasPrefix(value, "(") {
+							return nil, 0, 0, api.NewInvalidParameterValueError("invalid string value '%s'", value)
+						}
+						query.Where(
+							fmt.Sprintf("model_versions.run_uuid %s ?", comparison), strings.Trim(value, `"'`),
+						)
+					default:
+						return nil, 0, 0, api.NewInvalidParameterValueError(
+							"invalid string attribute comparison operator '%s'", comparison,
+						)
+					}
+				case "version_number":
+					switch comparison {
+					case GraterExpression, GraterOrEqualExpression, NotEqualExpression,
+						EqualExpression, LessExpression, LessOrEqualExpression:
+						v, err := strconv.ParseInt(strings.Trim(value, `"'`), 10, 64)
+						if err != nil {
+							return nil, 0, 0, api.NewInvalidParameterValueError("invalid numeric value '%s'", value)
+						}
+						query.Where(fmt.Sprintf("model_versions.version %s ?", comparison), v)
+					default:
+						return nil, 0, 0, api.NewInvalidParameterValueError(
+							"invalid numeric attribute comparison operator '%s'", comparison,
+						)
+					}
+				default:
+					return nil, 0, 0, api.NewInvalidParameterValueError(
+						"invalid attribute '%s'. Valid values are ['name', 'run_id', 'source_path', 'version_number']",
+						key,
+					)
+				}
+			case "tag", "tags":
+				where, value, err := newStringCondition(db, "value", comparison, value)
+				if err != nil {
+					return nil, 0, 0, err
+				}
+				table := fmt.Sprintf("filter_%d", n)
+				query.Joins(
+					fmt.Sprintf("JOIN (?) AS %s ON model_versions.id = %s.model_version_id", table, table),
+					db.Select(
+						"model_version_id", "value",
+					).Where(`"key" = ?`, key).Where(where, value).Model(&models.ModelVersionTag{}),
+				)
+			default:
+				return nil, 0, 0, api.NewInvalidParameterValueError(
+					"invalid entity type '%s'. Valid values are ['tag', 'attribute']", entity,
+				)
+			}
+		}
+	}
+
+	// OrderBy
+	for _, o := range req.OrderBy {
+		components := modelOrder.FindStringSubmatch(o)
+		if len(components) == 0 {
+			return nil, 0, 0, api.NewInvalidParameterValueError("invalid order_by clause '%s'", o)
+		}
+
+		var column string
+		switch components[1] {
+		case "name":
+			column = "registered_models.name"
+		case "version_number":
+			column = "model_versions.version"
+		case "timestamp", "last_updated_timestamp":
+			column = "model_versions.last_update_time"
+		case "creation_timestamp":
+			column = "model_versions.creation_time"
+		default:
+			return nil, 0, 0, api.NewInvalidParameterValueError(
+				`invalid order_by attribute '%s'. Valid values are `+
+					`['name', 'version_number', 'timestamp', 'last_updated_timestamp', 'creation_timestamp']`,
+				components[1],
+			)
+		}
+		query.Order(clause.OrderByColumn{
+			Column: clause.Column{Name: column, Raw: true},
+			Desc:   len(components) == 3 && strings.ToUpper(components[2]) == "DESC",
+		})
+	}
+	query.Order("registered_models.name ASC").Order("model_versions.version DESC")
+
+	// Actual query
+	var versions []models.ModelVersion
+	if err := query.Preload(
+		"Tags",
+	).Preload(
+		"RegisteredModel.Aliases",
+	).Find(&versions).Error; err != nil {
+		return nil, 0, 0, api.NewInternalError("unable to search model versions: %s", err)
+	}
+
+	return versions, limit, offset, nil
+}
+
+// getRegisteredModel returns models.RegisteredModel entity by its name or `RESOURCE_DOES_NOT_EXIST` error.
+func (s Service) getRegisteredModel(
+	ctx context.Context, ns *models.Namespace, name string,
+) (*models.RegisteredModel, error) {
+	registeredModel, err := s.registeredModelRepository.GetByNamespaceIDAndName(ctx, ns.ID, name)
+	if err != nil {
+		return nil, api.NewInternalError("unable to get registered model by name '%s': %s", name, err)
+	}
+	if registeredModel == nil {
+		return nil, api.NewResourceDoesNotExistError("Registered Model with name=%s not found", name)
+	}
+	return registeredModel, nil
+}
+
+// getModelVersionByName returns models.ModelVersion entity by the name of its models.RegisteredModel and version.
+func (s Service) getModelVersionByName(
+	ctx context.Context, ns *models.Namespace, name, version string,
+) (*models.ModelVersion, error) {
+	registeredModel, err := s.getRegisteredModel(ctx, ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return s.getModelVersion(ctx, registeredModel, version)
+}
+
+// getModelVersion returns models.ModelVersion entity of models.RegisteredModel or `RESOURCE_DOES_NOT_EXIST` error.
+func (s Service) getModelVersion(
+	ctx context.Context, registeredModel *models.RegisteredModel, version string,
+) (*models.ModelVersion, error) {
+	parsedVersion, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		return nil, api.NewInvalidParameterValueError("Model version must be an integer, got '%s'", version)
+	}
+
+	modelVersion, err := s.modelVersionRepository.GetByRegisteredModelIDAndVersion(
+		ctx, registeredModel.ID, parsedVersion,
+	)
+	if err != nil {
+		return nil, api.NewInternalError(
+			"unable to get model version '%d' of registered model '%s': %s", parsedVersion, registeredModel.Name, err,
+		)
+	}
+	if modelVersion == nil {
+		return nil, api.NewResourceDoesNotExistError(
+			"Model Version (name=%s, version=%d) not found", registeredModel.Name, parsedVersion,
+		)
+	}
+	return modelVersion, nil
+}
+
+// decodePageToken decodes `page_token` value into the actual offset.
+func decodePageToken(pageToken string) (int, error) {
+	if pageToken == "" {
+		return 0, nil
+	}
+	var token request.PageToken
+	if err := json.NewDecoder(
+		base64.NewDecoder(
+			base64.StdEncoding,
+			strings.NewReader(pageToken),
+		),
+	).Decode(&token); err != nil {
+		return 0, api.NewInvalidParameterValueError("invalid page_token '%s': %s", pageToken, err)
+	}
+	return int(token.Offset), nil
 }
 
-func (s Service) SearchModelVersions(ctx context.Context) (any, error) {
-	return fiber.Map{
-		"model_versions": []any{},
-	}, nil
+// parseFilterCondition splits a single filter condition into entity, key, comparison and value.
+func parseFilterCondition(condition string) (string, string, string, string, error) {
+	components := filterCond.FindStringSubmatch(condition)
+	if len(components) != 5 {
+		return "", "", "", "", api.NewInvalidParameterValueError("malformed filter '%s'", condition)
+	}
+	return components[1], strings.Trim(components[2], "\"`"), components[3], components[4], nil
 }
 
-func (s Service) SearchRegisteredModels(ctx context.Context) (any, error) {
-	return fiber.Map{
-		"registered_models": []any{},
-	}, nil
+// newStringCondition builds WHERE condition for string column comparison.
+func newStringCondition(db *gorm.DB, column, comparison, value string) (string, string, error) {
+	switch strings.ToUpper(comparison) {
+	case NotEqualExpression, EqualExpression, LikeExpression, ILikeExpression:
+		if strings.HasPrefix(value, "(") {
+			return "", "", api.NewInvalidParameterValueError("invalid string value '%s'", value)
+		}
+		value = strings.Trim(value, `"'`)
+		if db.Dialector.Name() != "postgres" && strings.ToUpper(comparison) == ILikeExpression {
+			return fmt.Sprintf("LOWER(%s) LIKE ?", column), strings.ToLower(value), nil
+		}
+		return fmt.Sprintf("%s %s ?", column, comparison), value, nil
+	default:
+		return "", "", api.NewInvalidParameterValueError(
+			"invalid string attribute comparison operator '%s'", comparison,
+		)
+	}
 }
@@ -0,0 +1,347 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+func TestService_CreateRegisteredModel_Ok(t *testing.T) {
+	// initialise namespace to which registered model under the test belongs to.
+	ns := models.Namespace{
+		ID:   1,
+		Code: "code",
+	}
+
+	// init repository mocks.
+	registeredModelRepository := repositories.MockRegisteredModelRepositoryProvider{}
+	registeredModelRepository.On(
+		"GetByNamespaceIDAndName", context.TODO(), ns.ID, "name",
+	).Return(nil, nil)
+	registeredModelRepository.On(
+		"Create", context.TODO(), mock.AnythingOfType("*models.RegisteredModel"),
+	).Return(nil)
+
+	// call service under testing.
+	service := NewService(
+		&repositories.MockModelVersionRepositoryProvider{},
+		&registeredModelRepository,
+	)
+	registeredModel, err := service.CreateRegisteredModel(context.TODO(), &ns, &request.CreateRegisteredModelRequest{
+		Name:        "name",
+		Description: "description",
+		Tags: []request.RegisteredModelTagPartialRequest{
+			{
+				Key:   "key",
+				Value: "value",
+			},
+		},
+	})
+
+	// compare results.
+	require.Nil(t, err)
+	assert.Equal(t, "name", registeredModel.Name)
+	assert.Equal(t, "description", registeredModel.Description)
+	assert.Equal(t, ns.ID, registeredModel.NamespaceID)
+	assert.Equal(t, []models.RegisteredModelTag{
+		{
+			Key:   "key",
+			Value: "value",
+		},
+	}, registeredModel.Tags)
+	assert.NotEmpty(t, registeredModel.CreationTime)
+	assert.Equal(t, registeredModel.CreationTime, registeredModel.LastUpdateTime)
+}
+
+func TestService_CreateRegisteredModel_Error(t *testing.T) {
+	// initialise namespace to which registered model under the test belongs to.
+	ns := models.Namespace{
+		ID:   1,
+		Code: "code",
+	}
+
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.CreateRegisteredModelRequest
+		service func() *Service
+	}{
+		{
+			name:    "EmptyOrIncorrectName",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'name'"),
+			request: &request.CreateRegisteredModelRequest{},
+			service: func() *Service {
+				return NewService(
+					&repositories.MockModelVersionRepositoryProvider{},
+					&repositories.MockRegisteredModelRepositoryProvider{},
+				)
+			},
+		},
+		{
+			name:    "RegisteredModelAlreadyExists",
+			error:   api.NewResourceAlreadyExistsError("Registered Model (name=name) already exists"),
+			request: &request.CreateRegisteredModelRequest{Name: "name"},
+			service: func() *Service {
+				registeredModelRepository := repositories.MockRegisteredModelRepositoryProvider{}
+				registeredModelRepository.On(
+					"GetByNamespaceIDAndName", context.TODO(), ns.ID, "name",
+				).Return(&models.RegisteredModel{Name: "name"}, nil)
+				return NewService(
+					&repositories.MockModelVersionRepositoryProvider{},
+					&registeredModelRepository,
+				)
+			},
+		},
+		{
+			name:    "CreateRegisteredModelDatabaseError",
+			error:   api.NewInternalError("error inserting registered model 'name': database error"),
+			request: &request.CreateRegisteredModelRequest{Name: "name"},
+			service: func() *Service {
+				registeredModelRepository := repositories.MockRegisteredModelRepositoryProvider{}
+				registeredModelRepository.On(
+					"GetByNamespaceIDAndName", context.TODO(), ns.ID, "name",
+				).Return(nil, nil)
+				registeredModelRepository.On(
+					"Create", context.TODO(), mock.AnythingOfType("*models.RegisteredModel"),
+				).Return(errors.New("database error"))
+				return NewService(
+					&repositories.MockModelVersionRepositoryProvider{},
+					&registeredModelRepository,
+				)
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.service().CreateRegisteredModel(context.TODO(), &ns, tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestService_GetLatestVersions_Ok(t *testing.T) {
+	// initialise namespace to which registered model under the test belongs to.
+	ns := models.Namespace{
+		ID:   1,
+		Code: "code",
+	}
+
+	// init repository mocks.
+	registeredModelRepository := repositories.MockRegisteredModelRepositoryProvider{}
+	registeredModelRepository.On(
+		"GetByNamespaceIDAndName", context.TODO(), ns.ID, "name",
+	).Return(&models.RegisteredModel{
+		Name: "name",
+		Versions: []models.ModelVersion{
+			{Version: 1, CurrentStage: models.ModelVersionStageProduction},
+			{Version: 2, CurrentStage: models.ModelVersionStageProduction},
+			{Version: 3, CurrentStage: models.ModelVersionStageStaging},
+			{Version: 4, CurrentStage: models.ModelVersionStageDeletedInternal},
+		},
+	}, nil)
+
+	// call service under testing.
+	service := NewService(
+		&repositories.MockModelVersionRepositoryProvider{},
+		&registeredModelRepository,
+	)
+	registeredModel, versions, err := service.GetLatestVersions(context.TODO(), &ns, &request.GetLatestVersionsRequest{
+		Name:   "name",
+		Stages: []string{"production"},
+	})
+
+	// compare results.
+	require.Nil(t, err)
+	assert.Equal(t, "name", registeredModel.Name)
+	require.Len(t, versions, 1)
+	assert.Equal(t, int64(2), versions[0].Version)
+}
+
+func TestService_SetRegisteredModelAlias_Ok(t *testing.T) {
+	// initialise namespace to which registered model under the test belongs to.
+	ns := models.Namespace{
+		ID:   1,
+		Code: "code",
+	}
+	registeredModel := models.RegisteredModel{
+		ID:   uuid.New(),
+		Name: "name",
+	}
+
+	// init repository mocks.
+	registeredModelRepository := repositories.MockRegisteredModelRepositoryProvider{}
+	registeredModelRepository.On(
+		"GetByNamespaceIDAndName", context.TODO(), ns.ID, "name",
+	).Return(&registeredModel, nil)
+	registeredModelRepository.On(
+		"SetAlias", context.TODO(), &models.RegisteredModelAlias{
+			Alias:             "champion",
+			Version:           2,
+			RegisteredModelID: registeredModel.ID,
+		},
+	).Return(nil)
+	modelVersionRepository := repositories.MockModelVersionRepositoryProvider{}
+	modelVersionRepository.On(
+		"GetByRegisteredModelIDAndVersion", context.TODO(), registeredModel.ID, int64(2),
+	).Return(&models.ModelVersion{
+		Version:           2,
+		RegisteredModelID: registeredModel.ID,
+	}, nil)
+
+	// call service under testing.
+	service := NewService(&modelVersionRepository, &registeredModelRepository)
+	err := service.SetRegisteredModelAlias(context.TODO(), &ns, &request.SetRegisteredModelAliasRequest{
+		Name:    "name",
+		Alias:   "champion",
+		Version: "2",
+	})
+
+	// compare results.
+	require.Nil(t, err)
+	registeredModelRepository.AssertExpectations(t)
+}
+
+func TestService_GetModelVersion_Error(t *testing.T) {
+	// initialise namespace to which registered model under the test belongs to.
+	ns := models.Namespace{
+		ID:   1,
+		Code: "code",
+	}
+	registeredModel := models.RegisteredModel{
+		ID:   uuid.New(),
+		Name: "name",
+	}
+
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.GetModelVersionRequest
+		service func() *Service
+	}{
+		{
+			name:    "EmptyOrIncorrectVersion",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'version'"),
+			request: &request.GetModelVersionRequest{Name: "name"},
+			service: func() *Service {
+				return NewService(
+					&repositories.MockModelVersionRepositoryProvider{},
+					&repositories.MockRegisteredModelRepositoryProvider{},
+				)
+			},
+		},
+		{
+			name:    "RegisteredModelNotFound",
+			error:   api.NewResourceDoesNotExistError("Registered Model with name=name not found"),
+			request: &request.GetModelVersionRequest{Name: "name", Version: "1"},
+			service: func() *Service {
+				registeredModelRepository := repositories.MockRegisteredModelRepositoryProvider{}
+				registeredModelRepository.On(
+					"GetByNamespaceIDAndName", context.TODO(), ns.ID, "name",
+				).Return(nil, nil)
+				return NewService(
+					&repositories.MockModelVersionRepositoryProvider{},
+					&registeredModelRepository,
+				)
+			},
+		},
+		{
+			name:    "NotIntegerVersion",
+			error:   api.NewInvalidParameterValueError("Model version must be an integer, got 'abc'"),
+			request: &request.GetModelVersionRequest{Name: "name", Version: "abc"},
+			service: func() *Service {
+				registeredModelRepository := repositories.MockRegisteredModelRepositoryProvider{}
+				registeredModelRepository.On(
+					"GetByNamespaceIDAndName", context.TODO(), ns.ID, "name",
+				).Return(&registeredModel, nil)
+				return NewService(
+					&repositories.MockModelVersionRepositoryProvider{},
+					&registeredModelRepository,
+				)
+			},
+		},
+		{
+			name:    "ModelVersionNotFound",
+			error:   api.NewResourceDoesNotExistError("Model Version (name=name, version=1) not found"),
+			request: &request.GetModelVersionRequest{Name: "name", Version: "1"},
+			service: func() *Service {
+				registeredModelRepository := repositories.MockRegisteredModelRepositoryProvider{}
+				registeredModelRepository.On(
+					"GetByNamespaceIDAndName", context.TODO(), ns.ID, "name",
+				).Return(&registeredModel, nil)
+				modelVersionRepository := repositories.MockModelVersionRepositoryProvider{}
+				modelVersionRepository.On(
+					"GetByRegisteredModelIDAndVersion", context.TODO(), registeredModel.ID, int64(1),
+				).Return(nil, nil)
+				return NewService(&modelVersionRepository, &registeredModelRepository)
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.service().GetModelVersion(context.TODO(), &ns, tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestService_TransitionModelVersionStage_Ok(t *testing.T) {
+	// initialise namespace to which registered model under the test belongs to.
+	ns := models.Namespace{
+		ID:   1,
+		Code: "code",
+	}
+	registeredModel := models.RegisteredModel{
+		ID:   uuid.New(),
+		Name: "name",
+	}
+
+	// init repository mocks.
+	registeredModelRepository := repositories.MockRegisteredModelRepositoryProvider{}
+	registeredModelRepository.On(
+		"GetByNamespaceIDAndName", context.TODO(), ns.ID, "name",
+	).Return(&registeredModel, nil)
+	modelVersionRepository := repositories.MockModelVersionRepositoryProvider{}
+	modelVersionRepository.On(
+		"GetByRegisteredModelIDAndVersion", context.TODO(), registeredModel.ID, int64(1),
+	).Return(&models.ModelVersion{
+		Version:           1,
+		CurrentStage:      models.ModelVersionStageNone,
+		RegisteredModelID: registeredModel.ID,
+	}, nil)
+	modelVersionRepository.On(
+		"TransitionStage",
+		context.TODO(),
+		mock.AnythingOfType("*models.ModelVersion"),
+		models.ModelVersionStageProduction,
+		true,
+	).Return(nil)
+
+	// call service under testing.
+	service := NewService(&modelVersionRepository, &registeredModelRepository)
+	version, err := service.TransitionModelVersionStage(
+		context.TODO(), &ns, &request.TransitionModelVersionStageRequest{
+			Name:                    "name",
+			Version:                 "1",
+			Stage:                   "production",
+			ArchiveExistingVersions: true,
+		},
+	)
+
+	// compare results.
+	require.Nil(t, err)
+	assert.Equal(t, int64(1), version.Version)
+	assert.NotEmpty(t, version.LastUpdateTime)
+	modelVersionRepository.AssertExpectations(t)
+}
@@ -0,0 +1,263 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+const (
+	MaxAliasLength                      = 255
+	MaxRegisteredModelsResultsPerPage   = 1000
+	MaxModelVersionsResultsPerPage      = 200000
+	DefaultRegisteredModelsResultsLimit = 100
+	DefaultModelVersionsResultsLimit    = 10000
+)
+
+var (
+	aliasName        = regexp.MustCompile(`^[\w\-]*$`)
+	aliasVersionName = regexp.MustCompile(`^[vV]\d+$`)
+)
+
+// ValidateCreateRegisteredModelRequest validates `POST /mlflow/registered-models/create` request.
+func ValidateCreateRegisteredModelRequest(req *request.CreateRegisteredModelRequest) error {
+	if req.Name == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'name'")
+	}
+	for _, tag := range req.Tags {
+		if tag.Key == "" {
+			return api.NewInvalidParameterValueError("Missing value for required parameter 'key'")
+		}
+	}
+	return nil
+}
+
+// ValidateRenameRegisteredModelRequest validates `POST /mlflow/registered-models/rename` request.
+func ValidateRenameRegisteredModelRequest(req *request.RenameRegisteredModelRequest) error {
+	if req.Name == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'name'")
+	}
+	if req.NewName == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'new_name'")
+	}
+	return nil
+}
+
+// ValidateUpdateRegisteredModelRequest validates `PATCH /mlflow/registered-models/update` request.
+func ValidateUpdateRegisteredModelRequest(req *request.UpdateRegisteredModelRequest) error {
+	if req.Name == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'name'")
+	}
+	return nil
+}
+
+// ValidateDeleteRegisteredModelRequest validates `DELETE /mlflow/registered-models/delete` request.
+func ValidateDeleteRegisteredModelRequest(req *request.DeleteRegisteredModelRequest) error {
+	if req.Name == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'name'")
+	}
+	return nil
+}
+
+// ValidateGetRegisteredModelRequest validates `GET /mlflow/registered-models/get` request.
+func ValidateGetRegisteredModelRequest(req *request.GetRegisteredModelRequest) error {
+	if req.Name == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'name'")
+	}
+	return nil
+}
+
+// ValidateSearchRegisteredModelsRequest validates `GET /mlflow/registered-models/search` request.
+func ValidateSearchRegisteredModelsRequest(req *request.SearchRegisteredModelsRequest) error {
+	if req.MaxResults < 0 || req.MaxResults > MaxRegisteredModelsResultsPerPage {
+		return api.NewInvalidParameterValueError("Invalid value for parameter 'max_results' supplied.")
+	}
+	return nil
+}
+
+// ValidateGetLatestVersionsRequest validates `POST /mlflow/registered-models/get-latest-versions` request.
+func ValidateGetLatestVersionsRequest(req *request.GetLatestVersionsRequest) error {
+	if req.Name == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'name'")
+	}
+	for _, stage := range req.Stages {
+		if _, ok := ParseModelVersionStage(stage); !ok {
+			return api.NewInvalidParameterValueError("Invalid Model Version stage: %s", stage)
+		}
+	}
+	return nil
+}
+
+// ValidateSetRegisteredModelTagRequest validates `POST /mlflow/registered-models/set-tag` request.
+func ValidateSetRegisteredModelTagRequest(req *request.SetRegisteredModelTagRequest) error {
+	if req.Name == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'name'")
+	}
+	if req.Key == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'key'")
+	}
+	return nil
+}
+
+// ValidateDeleteRegisteredModelTagRequest validates `DELETE /mlflow/registered-models/delete-tag` request.
+func ValidateDeleteRegisteredModelTagRequest(req *request.DeleteRegisteredModelTagRequest) error {
+	if req.Name == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'name'")
+	}
+	if req.Key == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'key'")
+	}
+	return nil
+}
+
+// ValidateSetRegisteredModelAliasRequest validates `POST /mlflow/registered-models/alias` request.
+func ValidateSetRegisteredModelAliasRequest(req *request.SetRegisteredModelAliasRequest) error {
+	if req.Name == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'name'")
+	}
+	if err := validateAlias(req.Alias); err != nil {
+		return err
+	}
+	if req.Version == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'version'")
+	}
+	return nil
+}
+
+// ValidateDeleteRegisteredModelAliasRequest validates `DELETE /mlflow/registered-models/alias` request.
+func ValidateDeleteRegisteredModelAliasRequest(req *request.DeleteRegisteredModelAliasRequest) error {
+	if req.Name == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'name'")
+	}
+	if req.Alias == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'alias'")
+	}
+	return nil
+}
+
+// ValidateGetModelVersionByAliasRequest validates `GET /mlflow/registered-models/alias` request.
+func ValidateGetModelVersionByAliasRequest(req *request.GetModelVersionByAliasRequest) error {
+	if req.Name == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'name'")
+	}
+	if req.Alias == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'alias'")
+	}
+	return nil
+}
+
+// ValidateCreateModelVersionRequest validates `POST /mlflow/model-versions/create` request.
+func ValidateCreateModelVersionRequest(req *request.CreateModelVersionRequest) error {
+	if req.Name == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'name'")
+	}
+	if req.Source == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'source'")
+	}
+	for _, tag := range req.Tags {
+		if tag.Key == "" {
+			return api.NewInvalidParameterValueError("Missing value for required parameter 'key'")
+		}
+	}
+	return nil
+}
+
+// ValidateUpdateModelVersionRequest validates `PATCH /mlflow/model-versions/update` request.
+func ValidateUpdateModelVersionRequest(req *request.UpdateModelVersionRequest) error {
+	return validateNameAndVersion(req.Name, req.Version)
+}
+
+// ValidateDeleteModelVersionRequest validates `DELETE /mlflow/model-versions/delete` request.
+func ValidateDeleteModelVersionRequest(req *request.DeleteModelVersionRequest) error {
+	return validateNameAndVersion(req.Name, req.Version)
+}
+
+// ValidateGetModelVersionRequest validates `GET /mlflow/model-versions/get` request.
+func ValidateGetModelVersionRequest(req *request.GetModelVersionRequest) error {
+	return validateNameAndVersion(req.Name, req.Version)
+}
+
+// ValidateSearchModelVersionsRequest validates `GET /mlflow/model-versions/search` request.
+func ValidateSearchModelVersionsRequest(req *request.SearchModelVersionsRequest) error {
+	if req.MaxResults < 0 || req.MaxResults > MaxModelVersionsResultsPerPage {
+		return api.NewInvalidParameterValueError("Invalid value for parameter 'max_results' supplied.")
+	}
+	return nil
+}
+
+// ValidateGetModelVersionDownloadURIRequest validates `GET /mlflow/model-versions/get-download-uri` request.
+func ValidateGetModelVersionDownloadURIRequest(req *request.GetModelVersionDownloadURIRequest) error {
+	return validateNameAndVersion(req.Name, req.Version)
+}
+
+// ValidateTransitionModelVersionStageRequest validates `POST /mlflow/model-versions/transition-stage` request.
+func ValidateTransitionModelVersionStageRequest(req *request.TransitionModelVersionStageRequest) error {
+	if err := validateNameAndVersion(req.Name, req.Version); err != nil {
+		return err
+	}
+	if req.Stage == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'stage'")
+	}
+	if _, ok := ParseModelVersionStage(req.Stage); !ok {
+		return api.NewInvalidParameterValueError("Invalid Model Version stage: %s", req.Stage)
+	}
+	return nil
+}
+
+// ValidateSetModelVersionTagRequest validates `POST /mlflow/model-versions/set-tag` request.
+func ValidateSetModelVersionTagRequest(req *request.SetModelVersionTagRequest) error {
+	if err := validateNameAndVersion(req.Name, req.Version); err != nil {
+		return err
+	}
+	if req.Key == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'key'")
+	}
+	return nil
+}
+
+// ValidateDeleteModelVersionTagRequest validates `DELETE /mlflow/model-versions/delete-tag` request.
+func ValidateDeleteModelVersionTagRequest(req *request.DeleteModelVersionTagRequest) error {
+	if err := validateNameAndVersion(req.Name, req.Version); err != nil {
+		return err
+	}
+	if req.Key == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'key'")
+	}
+	return nil
+}
+
+// validateNameAndVersion validates the pair of parameters identifying Model Version.
+func validateNameAndVersion(name, version string) error {
+	if name == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'name'")
+	}
+	if version == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'version'")
+	}
+	return nil
+}
+
+// validateAlias validates Registered Model alias name.
+func validateAlias(alias string) error {
+	if alias == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'alias'")
+	}
+	if len(alias) > MaxAliasLength {
+		return api.NewInvalidParameterValueError(
+			"Registered model alias '%s' exceeds the maximum length of %d characters", alias, MaxAliasLength,
+		)
+	}
+	if !aliasName.MatchString(alias) {
+		return api.NewInvalidParameterValueError(
+			"Invalid alias name: '%s'. Names may only contain alphanumerics, underscores (_) and dashes (-)", alias,
+		)
+	}
+	if strings.EqualFold(alias, "latest") || aliasVersionName.MatchString(alias) {
+		return api.NewInvalidParameterValueError(
+			"Invalid alias name: '%s'. Aliases cannot be 'latest' or have the format 'v<number>'", alias,
+		)
+	}
+	return nil
+}
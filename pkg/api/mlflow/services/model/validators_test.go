@@ -0,0 +1,336 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+)
+
+func TestValidateCreateRegisteredModelRequest_Ok(t *testing.T) {
+	err := ValidateCreateRegisteredModelRequest(&request.CreateRegisteredModelRequest{
+		Name: "name",
+		Tags: []request.RegisteredModelTagPartialRequest{
+			{
+				Key:   "key",
+				Value: "value",
+			},
+		},
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateCreateRegisteredModelRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.CreateRegisteredModelRequest
+	}{
+		{
+			name:    "EmptyNameProperty",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'name'"),
+			request: &request.CreateRegisteredModelRequest{},
+		},
+		{
+			name:  "EmptyTagKeyProperty",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'key'"),
+			request: &request.CreateRegisteredModelRequest{
+				Name: "name",
+				Tags: []request.RegisteredModelTagPartialRequest{
+					{
+						Value: "value",
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateRegisteredModelRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestValidateRenameRegisteredModelRequest_Ok(t *testing.T) {
+	err := ValidateRenameRegisteredModelRequest(&request.RenameRegisteredModelRequest{
+		Name:    "name",
+		NewName: "new_name",
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateRenameRegisteredModelRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.RenameRegisteredModelRequest
+	}{
+		{
+			name:    "EmptyNameProperty",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'name'"),
+			request: &request.RenameRegisteredModelRequest{},
+		},
+		{
+			name:  "EmptyNewNameProperty",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'new_name'"),
+			request: &request.RenameRegisteredModelRequest{
+				Name: "name",
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRenameRegisteredModelRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestValidateSearchRegisteredModelsRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.SearchRegisteredModelsRequest
+	}{
+		{
+			name:  "NegativeMaxResultsProperty",
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'max_results' supplied."),
+			request: &request.SearchRegisteredModelsRequest{
+				MaxResults: -1,
+			},
+		},
+		{
+			name:  "TooBigMaxResultsProperty",
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'max_results' supplied."),
+			request: &request.SearchRegisteredModelsRequest{
+				MaxResults: MaxRegisteredModelsResultsPerPage + 1,
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSearchRegisteredModelsRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestValidateGetLatestVersionsRequest_Ok(t *testing.T) {
+	err := ValidateGetLatestVersionsRequest(&request.GetLatestVersionsRequest{
+		Name:   "name",
+		Stages: []string{"production", "Staging"},
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateGetLatestVersionsRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.GetLatestVersionsRequest
+	}{
+		{
+			name:    "EmptyNameProperty",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'name'"),
+			request: &request.GetLatestVersionsRequest{},
+		},
+		{
+			name:  "IncorrectStagesProperty",
+			error: api.NewInvalidParameterValueError("Invalid Model Version stage: unknown"),
+			request: &request.GetLatestVersionsRequest{
+				Name:   "name",
+				Stages: []string{"unknown"},
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateGetLatestVersionsRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestValidateSetRegisteredModelAliasRequest_Ok(t *testing.T) {
+	err := ValidateSetRegisteredModelAliasRequest(&request.SetRegisteredModelAliasRequest{
+		Name:    "name",
+		Alias:   "champion",
+		Version: "1",
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateSetRegisteredModelAliasRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.SetRegisteredModelAliasRequest
+	}{
+		{
+			name:    "EmptyNameProperty",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'name'"),
+			request: &request.SetRegisteredModelAliasRequest{},
+		},
+		{
+			name:  "EmptyAliasProperty",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'alias'"),
+			request: &request.SetRegisteredModelAliasRequest{
+				Name: "name",
+			},
+		},
+		{
+			name: "TooLongAliasProperty",
+			error: api.NewInvalidParameterValueError(
+				"Registered model alias '%s' exceeds the maximum length of 255 characters", strings.Repeat("a", 256),
+			),
+			request: &request.SetRegisteredModelAliasRequest{
+				Name:  "name",
+				Alias: strings.Repeat("a", 256),
+			},
+		},
+		{
+			name: "IncorrectAliasProperty",
+			error: api.NewInvalidParameterValueError(
+				"Invalid alias name: 'a.b'. Names may only contain alphanumerics, underscores (_) and dashes (-)",
+			),
+			request: &request.SetRegisteredModelAliasRequest{
+				Name:  "name",
+				Alias: "a.b",
+			},
+		},
+		{
+			name: "ReservedLatestAliasProperty",
+			error: api.NewInvalidParameterValueError(
+				"Invalid alias name: 'latest'. Aliases cannot be 'latest' or have the format 'v<number>'",
+			),
+			request: &request.SetRegisteredModelAliasRequest{
+				Name:  "name",
+				Alias: "latest",
+			},
+		},
+		{
+			name: "ReservedVersionAliasProperty",
+			error: api.NewInvalidParameterValueError(
+				"Invalid alias name: 'v1'. Aliases cannot be 'latest' or have the format 'v<number>'",
+			),
+			request: &request.SetRegisteredModelAliasRequest{
+				Name:  "name",
+				Alias: "v1",
+			},
+		},
+		{
+			name:  "EmptyVersionProperty",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'version'"),
+			request: &request.SetRegisteredModelAliasRequest{
+				Name:  "name",
+				Alias: "champion",
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSetRegisteredModelAliasRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestValidateCreateModelVersionRequest_Ok(t *testing.T) {
+	err := ValidateCreateModelVersionRequest(&request.CreateModelVersionRequest{
+		Name:   "name",
+		Source: "s3://bucket/path",
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateCreateModelVersionRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.CreateModelVersionRequest
+	}{
+		{
+			name:    "EmptyNameProperty",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'name'"),
+			request: &request.CreateModelVersionRequest{},
+		},
+		{
+			name:  "EmptySourceProperty",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'source'"),
+			request: &request.CreateModelVersionRequest{
+				Name: "name",
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateModelVersionRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestValidateTransitionModelVersionStageRequest_Ok(t *testing.T) {
+	err := ValidateTransitionModelVersionStageRequest(&request.TransitionModelVersionStageRequest{
+		Name:    "name",
+		Version: "1",
+		Stage:   "Production",
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateTransitionModelVersionStageRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.TransitionModelVersionStageRequest
+	}{
+		{
+			name:    "EmptyNameProperty",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'name'"),
+			request: &request.TransitionModelVersionStageRequest{},
+		},
+		{
+			name:  "EmptyVersionProperty",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'version'"),
+			request: &request.TransitionModelVersionStageRequest{
+				Name: "name",
+			},
+		},
+		{
+			name:  "EmptyStageProperty",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'stage'"),
+			request: &request.TransitionModelVersionStageRequest{
+				Name:    "name",
+				Version: "1",
+			},
+		},
+		{
+			name:  "IncorrectStageProperty",
+			error: api.NewInvalidParameterValueError("Invalid Model Version stage: unknown"),
+			request: &request.TransitionModelVersionStageRequest{
+				Name:    "name",
+				Version: "1",
+				Stage:   "unknown",
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTransitionModelVersionStageRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
@@ -1,12 +1,15 @@
 package run
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
 )
 
 // adjustSearchRunsRequestForNamespace preprocesses the SearchRunRequest for the given namespace.
@@ -29,7 +32,19 @@ func adjustCreateRunRequestForNamespace(ns *models.Namespace, req *request.Creat
 // `POST /runs/:id/artifact` endpoint to an internal Model object.
 func ConvertCreateRunArtifactRequestToModel(
 	namespaceID uint, req *request.LogArtifactRequest,
-) *models.Artifact {
+) (*models.Artifact, error) {
+	artifactType := models.ArtifactType(req.Type)
+	if artifactType == "" {
+		artifactType = models.ArtifactTypeImages
+	}
+	var data types.JSONB
+	if req.Distribution != nil {
+		distribution, err := json.Marshal(req.Distribution)
+		if err != nil {
+			return nil, eris.Wrap(err, "error marshaling distribution")
+		}
+		data = distribution
+	}
 	return &models.Artifact{
 		ID:      uuid.New(),
 		Name:    req.Name,
@@ -42,5 +57,8 @@ func ConvertCreateRunArtifactRequestToModel(
 		Format:  req.Format,
 		Caption: req.Caption,
 		BlobURI: req.BlobURI,
-	}
+		Type:    artifactType,
+		Text:    req.Text,
+		Data:    data,
+	}, nil
 }
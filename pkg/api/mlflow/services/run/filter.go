@@ -0,0 +1,366 @@
+package run
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/query"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/database"
+)
+
+// datasetContextTagKey is the input tag key, which keeps dataset context, e.g. `training`.
+const datasetContextTagKey = "mlflow.data.context"
+
+// Supported list of metric aggregations.
+const (
+	MetricAggregationLast = "last"
+	MetricAggregationMin  = "min"
+	MetricAggregationMax  = "max"
+	MetricAggregationMean = "mean"
+	MetricAggregationStep = "at_step"
+)
+
+// metricAggregation represents `<aggregation>(metrics.<key>)` reference to the summary of the metric values.
+type metricAggregation struct {
+	key         string
+	aggregation string
+	step        int64
+}
+
+// newMetricAggregation creates metricAggregation from `last`, `min`, `max`, `mean` or `at_step` function
+// applied to the metric key. `at_step` takes the step, which the value has been logged at, as an argument.
+func newMetricAggregation(function, key string, arguments []string) (metricAggregation, error) {
+	aggregation := metricAggregation{key: key, aggregation: strings.ToLower(function)}
+	switch aggregation.aggregation {
+	case MetricAggregationLast, MetricAggregationMin, MetricAggregationMax, MetricAggregationMean:
+		if len(arguments) != 0 {
+			return metricAggregation{}, api.NewInvalidParameterValueError(
+				"function '%s' doesn't take arguments", function,
+			)
+		}
+	case MetricAggregationStep:
+		if len(arguments) != 1 {
+			return metricAggregation{}, api.NewInvalidParameterValueError(
+				"function '%s' takes exactly one step argument", function,
+			)
+		}
+		step, err := strconv.ParseInt(arguments[0], 10, 64)
+		if err != nil {
+			return metricAggregation{}, api.NewInvalidParameterValueError("invalid step '%s'", arguments[0])
+		}
+		aggregation.step = step
+	default:
+		return metricAggregation{}, api.NewInvalidParameterValueError(
+			"invalid metric function '%s'. Valid values are ['last', 'min', 'max', 'mean', 'at_step']", function,
+		)
+	}
+	return aggregation, nil
+}
+
+// subQuery returns `run_uuid, value` query with the aggregated metric value of every run. Values of the metric
+// logged with different contexts are aggregated together and NaN values are skipped. Min, max and mean are taken
+// from the metric summary maintained in `latest_metrics` table, so only the value at step needs to look into
+// the metric history.
+func (a metricAggregation) subQuery(db *gorm.DB) *gorm.DB {
+	var value string
+	model := any(&database.LatestMetric{})
+	switch a.aggregation {
+	case MetricAggregationMin:
+		value = "MIN(min_value)"
+	case MetricAggregationMax:
+		value = "MAX(max_value)"
+	case MetricAggregationMean:
+		value = "SUM(sum_value) / NULLIF(SUM(value_count), 0)"
+	case MetricAggregationStep:
+		value, model = "MAX(value)", &database.Metric{}
+		db = db.Where("step = ? AND is_nan = ?", a.step, false)
+	default:
+		value = "MAX(value)"
+		db = db.Where("is_nan = ?", false)
+	}
+	return db.Model(model).Select(
+		fmt.Sprintf("run_uuid, %s AS value", value),
+	).Where(
+		`"key" = ?`, a.key,
+	).Group(
+		"run_uuid",
+	)
+}
+
+// filterBuilder converts parsed `SearchRuns` filter into the SQL condition.
+type filterBuilder struct {
+	db *gorm.DB
+}
+
+// newFilterBuilder creates new filterBuilder instance.
+func newFilterBuilder(db *gorm.DB) *filterBuilder {
+	return &filterBuilder{
+		db: db,
+	}
+}
+
+// Build parses filter and returns SQL condition with its arguments.
+func (b filterBuilder) Build(filter string) (string, []any, error) {
+	node, err := query.ParseFilter(filter)
+	if err != nil {
+		return "", nil, api.NewInvalidParameterValueError("%s", err)
+	}
+	return b.build(node)
+}
+
+// build recursively converts filter node into the SQL condition.
+func (b filterBuilder) build(node query.Node) (string, []any, error) {
+	switch node := node.(type) {
+	case query.AndExpression:
+		return b.buildBinary("AND", node.Left, node.Right)
+	case query.OrExpression:
+		return b.buildBinary("OR", node.Left, node.Right)
+	case query.NotExpression:
+		sql, args, err := b.build(node.Expression)
+		if err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("NOT (%s)", sql), args, nil
+	case query.Condition:
+		return b.buildCondition(node)
+	default:
+		return "", nil, api.NewInternalError("unsupported filter node %T", node)
+	}
+}
+
+// buildBinary converts `<left> AND|OR <right>` expression into the SQL condition.
+func (b filterBuilder) buildBinary(operator string, left, right query.Node) (string, []any, error) {
+	leftSQL, leftArgs, err := b.build(left)
+	if err != nil {
+		return "", nil, err
+	}
+	rightSQL, rightArgs, err := b.build(right)
+	if err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf("(%s) %s (%s)", leftSQL, operator, rightSQL), append(leftArgs, rightArgs...), nil
+}
+
+// nolint:gocyclo
+// buildCondition converts single filter condition into the SQL condition.
+func (b filterBuilder) buildCondition(condition query.Condition) (string, []any, error) {
+	key, comparison, value := condition.Key, condition.Operator, condition.Value
+	valueCol := "value"
+	if condition.Function != "" && condition.Entity != "metric" && condition.Entity != "metrics" {
+		return "", nil, api.NewInvalidParameterValueError(
+			"function '%s' can be applied only to metrics", condition.Function,
+		)
+	}
+
+	var kind any
+	var arg any
+	switch condition.Entity {
+	case "", "attribute", "attributes", "attr", "run":
+		switch key {
+		case "start_time", "end_time":
+			switch comparison {
+			case GraterExpression, GraterOrEqualExpression, NotEqualExpression,
+				EqualExpression, LessExpression, LessOrEqualExpression:
+				v, err := strconv.Atoi(value.Raw)
+				if err != nil || value.Quoted {
+					return "", nil, api.NewInvalidParameterValueError("invalid numeric value '%s'", formatValue(value))
+				}
+				arg = v
+			default:
+				return "", nil, api.NewInvalidParameterValueError(
+					"invalid numeric attribute comparison operator '%s'", comparison,
+				)
+			}
+		case "run_name":
+			key = "mlflow.runName"
+			kind = &database.Tag{}
+			fallthrough
+		case "status", "user_id", "artifact_uri":
+			switch comparison {
+			case NotEqualExpression, EqualExpression, LikeExpression, ILikeExpression:
+				arg = value.Raw
+			default:
+				return "", nil, api.NewInvalidParameterValueError(
+					"invalid string attribute comparison operator '%s'", comparison,
+				)
+			}
+		case "run_id":
+			key = "run_uuid"
+			switch comparison {
+			case NotEqualExpression, EqualExpression, LikeExpression, ILikeExpression:
+				arg = value.Raw
+			case InExpression, NotInExpression:
+				arg = value.List
+			default:
+				return "", nil, api.NewInvalidParameterValueError(
+					"invalid string attribute comparison operator '%s'", comparison,
+				)
+			}
+		default:
+			return "", nil, api.NewInvalidParameterValueError(
+				`invalid attribute '%s'. `+
+					`Valid values are ['run_name', 'start_time', 'end_time', 'status', 'user_id', 'artifact_uri', 'run_id']`,
+				key,
+			)
+		}
+	case "metric", "metrics":
+		switch comparison {
+		case GraterExpression, GraterOrEqualExpression,
+			NotEqualExpression, EqualExpression, LessExpression, LessOrEqualExpression:
+			v, err := strconv.ParseFloat(value.Raw, 64)
+			if err != nil || value.Quoted {
+				return "", nil, api.NewInvalidParameterValueError("invalid numeric value '%s'", formatValue(value))
+			}
+			arg = v
+		default:
+			return "", nil, api.NewInvalidParameterValueError(
+				"invalid metric comparison operator '%s'", comparison,
+			)
+		}
+		if condition.Function != "" {
+			aggregation, err := newMetricAggregation(condition.Function, key, condition.Arguments)
+			if err != nil {
+				return "", nil, err
+			}
+			subQuery := b.db.Table("(?) AS aggregations", aggregation.subQuery(b.db)).Select(
+				"run_uuid",
+			).Where(
+				fmt.Sprintf("value %s ?", comparison), arg,
+			)
+			return "runs.run_uuid IN (?)", []any{subQuery}, nil
+		}
+		kind = &database.LatestMetric{}
+	case "parameter", "parameters", "param", "params":
+		switch comparison {
+		case NotEqualExpression, EqualExpression, LikeExpression, ILikeExpression:
+			arg = value.Raw
+			valueCol = "value_str"
+		case GraterExpression, GraterOrEqualExpression, LessExpression, LessOrEqualExpression:
+			if value.Type != query.ValueTypeNumber {
+				return "", nil, api.NewInvalidParameterValueError(
+					"invalid value '%s' for comparison operator '%s'", formatValue(value), comparison,
+				)
+			}
+			if v, err := strconv.ParseInt(value.Raw, 10, 64); err == nil {
+				arg, valueCol = v, "value_int"
+			} else if v, err := strconv.ParseFloat(value.Raw, 64); err == nil {
+				arg, valueCol = v, "value_float"
+			} else {
+				return "", nil, api.NewInvalidParameterValueError("invalid numeric value '%s'", formatValue(value))
+			}
+		case IsNullExpression, IsNotNullExpression:
+		default:
+			return "", nil, api.NewInvalidParameterValueError(
+				"invalid param comparison operator '%s'", comparison,
+			)
+		}
+		kind = &database.Param{}
+	case "tag", "tags":
+		switch comparison {
+		case NotEqualExpression, EqualExpression, LikeExpression, ILikeExpression:
+			arg = value.Raw
+		case IsNullExpression, IsNotNullExpression:
+		default:
+			return "", nil, api.NewInvalidParameterValueError(
+				"invalid tag comparison operator '%s'", comparison,
+			)
+		}
+		kind = &database.Tag{}
+	case "dataset", "datasets":
+		switch key {
+		case "name", "digest", "context":
+		default:
+			return "", nil, api.NewInvalidParameterValueError(
+				"invalid dataset attribute key '%s'. Valid values are ['name', 'digest', 'context']", key,
+			)
+		}
+		switch comparison {
+		case NotEqualExpression, EqualExpression, LikeExpression, ILikeExpression:
+			arg = value.Raw
+		case InExpression, NotInExpression:
+			arg = value.List
+		default:
+			return "", nil, api.NewInvalidParameterValueError(
+				"invalid dataset comparison operator '%s'", comparison,
+			)
+		}
+	default:
+		return "", nil, api.NewInvalidParameterValueError(
+			"invalid entity type '%s'. Valid values are ['metric', 'parameter', 'tag', 'attribute', 'dataset']",
+			condition.Entity,
+		)
+	}
+
+	// after validation every list value has to be used with IN operator only and vice versa.
+	switch {
+	case value.Type == query.ValueTypeList && comparison != InExpression && comparison != NotInExpression:
+		return "", nil, api.NewInvalidParameterValueError("invalid string value '%s'", formatValue(value))
+	case value.Type != query.ValueTypeList && (comparison == InExpression || comparison == NotInExpression):
+		return "", nil, api.NewInvalidParameterValueError("invalid list definition '%s'", formatValue(value))
+	}
+
+	if condition.Entity == "dataset" || condition.Entity == "datasets" {
+		return b.buildDatasetCondition(key, comparison, arg)
+	}
+
+	if kind == nil {
+		if b.db.Dialector.Name() != "postgres" && comparison == ILikeExpression {
+			return fmt.Sprintf("LOWER(runs.%s) LIKE ?", key), []any{strings.ToLower(value.Raw)}, nil
+		}
+		return fmt.Sprintf("runs.%s %s ?", key, comparison), []any{arg}, nil
+	}
+
+	// `IS NULL` means that run has no param or tag with the given key at all.
+	subQuery := b.db.Select("run_uuid").Where(`"key" = ?`, key).Model(kind)
+	switch comparison {
+	case IsNullExpression:
+		return "runs.run_uuid NOT IN (?)", []any{subQuery}, nil
+	case IsNotNullExpression:
+		return "runs.run_uuid IN (?)", []any{subQuery}, nil
+	}
+
+	where := fmt.Sprintf("%s %s ?", valueCol, comparison)
+	if b.db.Dialector.Name() != "postgres" && comparison == ILikeExpression {
+		where = fmt.Sprintf("LOWER(%s) LIKE ?", valueCol)
+		arg = strings.ToLower(value.Raw)
+	}
+	return "runs.run_uuid IN (?)", []any{subQuery.Where(where, arg)}, nil
+}
+
+// buildDatasetCondition converts `datasets.<key>` filter condition into the SQL condition.
+// Dataset `context` is stored as `mlflow.data.context` input tag.
+func (b filterBuilder) buildDatasetCondition(key, comparison string, arg any) (string, []any, error) {
+	column := fmt.Sprintf("datasets.%s", key)
+	subQuery := b.db.Table("inputs").Select("inputs.run_uuid").Joins(
+		"INNER JOIN datasets ON datasets.id = inputs.dataset_id",
+	)
+	if key == "context" {
+		column = "input_tags.value"
+		subQuery = subQuery.Joins(
+			"INNER JOIN input_tags ON input_tags.input_id = inputs.id AND input_tags.key = ?", datasetContextTagKey,
+		)
+	}
+
+	where := fmt.Sprintf("%s %s ?", column, comparison)
+	if b.db.Dialector.Name() != "postgres" && comparison == ILikeExpression {
+		where = fmt.Sprintf("LOWER(%s) LIKE ?", column)
+		arg = strings.ToLower(arg.(string))
+	}
+	return "runs.run_uuid IN (?)", []any{subQuery.Where(where, arg)}, nil
+}
+
+// formatValue returns filter value in the form it is used in the error messages.
+func formatValue(value query.Value) string {
+	if value.Type == query.ValueTypeList {
+		items := make([]string, len(value.List))
+		for i, item := range value.List {
+			items[i] = fmt.Sprintf("'%s'", item)
+		}
+		return fmt.Sprintf("(%s)", strings.Join(items, ", "))
+	}
+	return value.Raw
+}
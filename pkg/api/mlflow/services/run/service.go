@@ -20,15 +20,19 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/services/ingest"
+	"github.com/G-Research/fasttrackml/pkg/common/services/live"
+	"github.com/G-Research/fasttrackml/pkg/common/tracing"
 	"github.com/G-Research/fasttrackml/pkg/database"
 )
 
 //nolint:lll
 var (
-	runOrder      = regexp.MustCompile(`^(attribute|metric|param|tag)s?\.("[^"]+"|` + "`[^`]+`" + `|[\w\.]+)(?i:\s+(ASC|DESC))?$`)
-	filterAnd     = regexp.MustCompile(`(?i)\s+AND\s+`)
-	filterCond    = regexp.MustCompile(`^(?:(\w+)\.)?("[^"]+"|` + "`[^`]+`" + `|[\w\.]+)\s+(<|<=|>|>=|=|!=|(?i:I?LIKE)|(?i:(?:NOT )?IN))\s+(\((?:'[^']+'(?:,\s*)?)+\)|"[^"]+"|'[^']+'|[\w\.]+)$`)
-	filterInGroup = regexp.MustCompile(`,\s*`)
+	runOrder = regexp.MustCompile(`^(attribute|metric|param|tag)s?\.("[^"]+"|` + "`[^`]+`" + `|[\w\.]+)(?i:\s+(ASC|DESC))?$`)
+	// runAggregationOrder matches `<function>(metrics.<key>[, <argument>])` order by the metric aggregation.
+	runAggregationOrder = regexp.MustCompile(
+		`^(\w+)\(metrics?\.("[^"]+"|` + "`[^`]+`" + `|[\w\.]+)(?:\s*,\s*(\w+))?\)(?i:\s+(ASC|DESC))?$`,
+	)
 )
 
 // supported expression list.
@@ -43,6 +47,8 @@ const (
 	LessOrEqualExpression   = "<="
 	GraterExpression        = ">"
 	GraterOrEqualExpression = ">="
+	IsNullExpression        = "IS NULL"
+	IsNotNullExpression     = "IS NOT NULL"
 )
 
 // Service provides service layer to work with `run` business logic.
@@ -54,6 +60,9 @@ type Service struct {
 	metricRepository     repositories.MetricRepositoryProvider
 	experimentRepository repositories.ExperimentRepositoryProvider
 	artifactRepository   repositories.ArtifactRepositoryProvider
+	inputRepository      repositories.InputRepositoryProvider
+	liveService          *live.Service
+	ingestService        *ingest.Service
 }
 
 // NewService creates new Service instance.
@@ -65,6 +74,9 @@ func NewService(
 	experimentRepository repositories.ExperimentRepositoryProvider,
 	logRepository repositories.LogRepositoryProvider,
 	artifactRepository repositories.ArtifactRepositoryProvider,
+	inputRepository repositories.InputRepositoryProvider,
+	liveService *live.Service,
+	ingestService *ingest.Service,
 ) *Service {
 	return &Service{
 		logRepository:        logRepository,
@@ -74,6 +86,9 @@ func NewService(
 		metricRepository:     metricRepository,
 		experimentRepository: experimentRepository,
 		artifactRepository:   artifactRepository,
+		inputRepository:      inputRepository,
+		liveService:          liveService,
+		ingestService:        ingestService,
 	}
 }
 
@@ -117,6 +132,7 @@ func (s Service) UpdateRun(
 		return nil, api.NewResourceDoesNotExistError("unable to find run '%s'", req.GetRunID())
 	}
 
+	status := run.Status
 	run = convertors.ConvertUpdateRunRequestToDBModel(run, req)
 	if err := s.runRepository.GetDB().Transaction(func(tx *gorm.DB) error {
 		if err := s.runRepository.UpdateWithTransaction(ctx, tx, run); err != nil {
@@ -135,6 +151,9 @@ func (s Service) UpdateRun(
 	}); err != nil {
 		return nil, api.NewInternalError("unable to update run '%s': %s", run.ID, err)
 	}
+	if run.Status != status {
+		s.liveService.PublishStatus(namespace.ID, run)
+	}
 
 	return run, nil
 }
@@ -164,6 +183,9 @@ func (s Service) GetRun(
 func (s Service) SearchRuns(
 	ctx context.Context, namespace *models.Namespace, req *request.SearchRunsRequest,
 ) ([]models.Run, int, int, error) {
+	ctx, span := tracing.StartSpan(ctx, "mlflow.RunService.SearchRuns")
+	defer span.End()
+
 	if err := ValidateSearchRunsRequest(req); err != nil {
 		return nil, 0, 0, err
 	}
@@ -222,186 +244,11 @@ func (s Service) SearchRuns(
 
 	// Filter
 	if req.Filter != "" {
-		for n, f := range filterAnd.Split(req.Filter, -1) {
-			components := filterCond.FindStringSubmatch(f)
-			if len(components) != 5 {
-				return nil, 0, 0, api.NewInvalidParameterValueError("malformed filter '%s'", f)
-			}
-
-			entity := components[1]
-			key := strings.Trim(components[2], "\"`")
-			comparison := components[3]
-			var value any = components[4]
-			valueCol := "value"
-
-			var kind any
-			switch entity {
-			case "", "attribute", "attributes", "attr", "run":
-				switch key {
-				case "start_time", "end_time":
-					switch comparison {
-					case GraterExpression, GraterOrEqualExpression, NotEqualExpression,
-						EqualExpression, LessExpression, LessOrEqualExpression:
-						v, err := strconv.Atoi(value.(string))
-						if err != nil {
-							return nil, 0, 0, api.NewInvalidParameterValueError("invalid numeric value '%s'", value)
-						}
-						value = v
-					default:
-						return nil, 0, 0, api.NewInvalidParameterValueError(
-							"invalid numeric attribute comparison operator '%s'", comparison,
-						)
-					}
-				case "run_name":
-					key = "mlflow.runName"
-					kind = &database.Tag{}
-					fallthrough
-				case "status", "user_id", "artifact_uri":
-					switch strings.ToUpper(comparison) {
-					case NotEqualExpression, EqualExpression, LikeExpression, ILikeExpression:
-						if strings.HasPrefix(value.(string), "(") {
-							return nil, 0, 0, api.NewInvalidParameterValueError("invalid string value '%s'", value)
-						}
-						value = strings.Trim(value.(string), `"'`)
-					default:
-						return nil, 0, 0, api.NewInvalidParameterValueError(
-							"invalid string attribute comparison operator '%s'", comparison,
-						)
-					}
-				case "run_id":
-					key = "run_uuid"
-					switch strings.ToUpper(comparison) {
-					case NotEqualExpression, EqualExpression, LikeExpression, ILikeExpression:
-						if strings.HasPrefix(value.(string), "(") {
-							return nil, 0, 0, api.NewInvalidParameterValueError("invalid string value '%s'", value)
-						}
-						value = strings.Trim(value.(string), `"'`)
-					case InExpression, NotInExpression:
-						if !strings.HasPrefix(value.(string), "(") {
-							return nil, 0, 0, api.NewInvalidParameterValueError("invalid list definition '%s'", value)
-						}
-						var values []string
-						for _, v := range filterInGroup.Split(value.(string)[1:len(value.(string))-1], -1) {
-							values = append(values, strings.Trim(v, "'"))
-						}
-						value = values
-					default:
-						return nil, 0, 0, api.NewInvalidParameterValueError(
-							"invalid string attribute comparison operator '%s'", comparison,
-						)
-					}
-				default:
-					return nil, 0, 0, api.NewInvalidParameterValueError(
-						`invalid attribute '%s'. `+
-							`Valid values are ['run_name', 'start_time', 'end_time', 'status', 'user_id', 'artifact_uri', 'run_id']`,
-						key,
-					)
-				}
-			case "metric", "metrics":
-				switch comparison {
-				case GraterExpression, GraterOrEqualExpression,
-					NotEqualExpression, EqualExpression, LessExpression, LessOrEqualExpression:
-					v, err := strconv.ParseFloat(value.(string), 64)
-					if err != nil {
-						return nil, 0, 0, api.NewInvalidParameterValueError("invalid numeric value '%s'", value)
-					}
-					value = v
-				default:
-					return nil, 0, 0, api.NewInvalidParameterValueError(
-						"invalid metric comparison operator '%s'", comparison,
-					)
-				}
-				kind = &database.LatestMetric{}
-			case "parameter", "parameters", "param", "params":
-				switch strings.ToUpper(comparison) {
-				case NotEqualExpression, EqualExpression:
-					switch v := value.(type) {
-					case string:
-						if strings.HasPrefix(v, "(") {
-							return nil, 0, 0, api.NewInvalidParameterValueError("invalid string value '%s'", value)
-						}
-						value = strings.Trim(v, `"'`)
-						valueCol = "value_str"
-					case int64, int32, int:
-						valueCol = "value_int"
-					case float64, float32:
-						valueCol = "value_float"
-					default:
-						return nil, 0, 0, api.NewInvalidParameterValueError(
-							"invalid value '%v' for comparison operator '%s'", v, comparison,
-						)
-					}
-				case LikeExpression, ILikeExpression:
-					switch v := value.(type) {
-					case string:
-						if strings.HasPrefix(v, "(") {
-							return nil, 0, 0, api.NewInvalidParameterValueError("invalid string value '%s'", value)
-						}
-						value = strings.Trim(v, `"'`)
-						valueCol = "value_str"
-					default:
-						return nil, 0, 0, api.NewInvalidParameterValueError(
-							"invalid value '%v' for comparison operator '%s'", v, comparison,
-						)
-					}
-				case GraterExpression, GraterOrEqualExpression, LessExpression, LessOrEqualExpression:
-					switch v := value.(type) {
-					case int64, int32, int:
-						valueCol = "value_int"
-					case float64, float32:
-						valueCol = "value_float"
-					default:
-						return nil, 0, 0, api.NewInvalidParameterValueError(
-							"invalid value '%v' for comparison operator '%s'", v, comparison,
-						)
-					}
-				default:
-					return nil, 0, 0, api.NewInvalidParameterValueError(
-						"invalid param comparison operator '%s'", comparison,
-					)
-				}
-				kind = &database.Param{}
-			case "tag", "tags":
-				switch strings.ToUpper(comparison) {
-				case NotEqualExpression, EqualExpression, LikeExpression, ILikeExpression:
-					if strings.HasPrefix(value.(string), "(") {
-						return nil, 0, 0, api.NewInvalidParameterValueError("invalid string value '%s'", value)
-					}
-					value = strings.Trim(value.(string), `"'`)
-				default:
-					return nil, 0, 0, api.NewInvalidParameterValueError(
-						"invalid tag comparison operator '%s'", comparison,
-					)
-				}
-				kind = &database.Tag{}
-			default:
-				return nil, 0, 0, api.NewInvalidParameterValueError(
-					"invalid entity type '%s'. Valid values are ['metric', 'parameter', 'tag', 'attribute']", entity,
-				)
-			}
-
-			if kind == nil {
-				if database.DB.Dialector.Name() == "sqlite" && strings.ToUpper(comparison) == ILikeExpression {
-					key = fmt.Sprintf("LOWER(runs.%s)", key)
-					comparison = LikeExpression
-					value = strings.ToLower(value.(string))
-					tx.Where(fmt.Sprintf("%s %s ?", key, comparison), value)
-				} else {
-					tx.Where(fmt.Sprintf("runs.%s %s ?", key, comparison), value)
-				}
-			} else {
-				table := fmt.Sprintf("filter_%d", n)
-				where := fmt.Sprintf("%s %s ?", valueCol, comparison)
-				if database.DB.Dialector.Name() == "sqlite" && strings.ToUpper(comparison) == ILikeExpression {
-					where = fmt.Sprintf("LOWER(%s) LIKE ?", valueCol)
-					value = strings.ToLower(fmt.Sprintf("%v", value))
-				}
-				tx.Joins(
-					fmt.Sprintf("JOIN (?) AS %s ON runs.run_uuid = %s.run_uuid", table, table),
-					database.DB.Select("run_uuid", valueCol).Where("key = ?", key).Where(where, value).Model(kind),
-				)
-			}
+		where, args, err := newFilterBuilder(database.DB).Build(req.Filter)
+		if err != nil {
+			return nil, 0, 0, err
 		}
+		tx.Where(where, args...)
 	}
 
 	// OrderBy
@@ -409,6 +256,29 @@ func (s Service) SearchRuns(
 	// TODO collation for strings on postgres?
 	startTimeOrder := false
 	for n, o := range req.OrderBy {
+		if components := runAggregationOrder.FindStringSubmatch(o); components != nil {
+			var arguments []string
+			if components[3] != "" {
+				arguments = append(arguments, components[3])
+			}
+			aggregation, err := newMetricAggregation(components[1], strings.Trim(components[2], "`\""), arguments)
+			if err != nil {
+				return nil, 0, 0, err
+			}
+			table := fmt.Sprintf("order_%d", n)
+			tx.Joins(
+				fmt.Sprintf("LEFT OUTER JOIN (?) AS %s ON runs.run_uuid = %s.run_uuid", table, table),
+				aggregation.subQuery(database.DB),
+			)
+			tx.Order(clause.OrderByColumn{
+				Column: clause.Column{
+					Name: fmt.Sprintf("%s.value", table),
+				},
+				Desc: strings.ToUpper(components[4]) == "DESC",
+			})
+			continue
+		}
+
 		components := runOrder.FindStringSubmatch(o)
 		log.Debugf("Components: %#v", components)
 		if len(components) < 3 {
@@ -439,7 +309,7 @@ func (s Service) SearchRuns(
 			table := fmt.Sprintf("order_%d", n)
 			tx.Joins(
 				fmt.Sprintf("LEFT OUTER JOIN (?) AS %s ON runs.run_uuid = %s.run_uuid", table, table),
-				database.DB.Select("run_uuid", "value").Where("key = ?", column).Model(kind),
+				database.DB.Select("run_uuid", "value").Where(`"key" = ?`, column).Model(kind),
 			)
 			column = fmt.Sprintf("%s.value", table)
 		}
@@ -460,6 +330,8 @@ func (s Service) SearchRuns(
 	tx.Preload("LatestMetrics").
 		Preload("Params").
 		Preload("Tags").
+		Preload("Inputs.Dataset").
+		Preload("Inputs.Tags").
 		Find(&runs)
 	if tx.Error != nil {
 		return nil, 0, 0, api.NewInternalError("unable to search runs: %s", tx.Error)
@@ -522,6 +394,9 @@ func (s Service) LogMetric(
 	namespace *models.Namespace,
 	req *request.LogMetricRequest,
 ) error {
+	ctx, span := tracing.StartSpan(ctx, "mlflow.RunService.LogMetric")
+	defer span.End()
+
 	if err := ValidateLogMetricRequest(req); err != nil {
 		return err
 	}
@@ -538,7 +413,11 @@ func (s Service) LogMetric(
 	if err != nil {
 		return api.NewInvalidParameterValueError(err.Error())
 	}
-	if err := s.metricRepository.CreateBatch(ctx, run, 1, []models.Metric{*metric}); err != nil {
+	metrics := []models.Metric{*metric}
+	if err := s.createMetrics(ctx, namespace.ID, run, 1, metrics); err != nil {
+		if errors.Is(err, ingest.ErrQueueFull) {
+			return api.NewTemporarilyUnavailableError("unable to log metric '%s' for run '%s': %s", req.Key, run.ID, err)
+		}
 		return api.NewInternalError("unable to log metric '%s' for run '%s': %s", req.Key, req.GetRunID(), err)
 	}
 
@@ -640,6 +519,9 @@ func (s Service) LogBatch(
 	namespace *models.Namespace,
 	req *request.LogBatchRequest,
 ) error {
+	ctx, span := tracing.StartSpan(ctx, "mlflow.RunService.LogBatch")
+	defer span.End()
+
 	if err := ValidateLogBatchRequest(req); err != nil {
 		return err
 	}
@@ -664,7 +546,10 @@ func (s Service) LogBatch(
 		}
 		return api.NewInternalError("unable to insert params for run '%s': %s", run.ID, err)
 	}
-	if err := s.metricRepository.CreateBatch(ctx, run, 100, metrics); err != nil {
+	if err := s.createMetrics(ctx, namespace.ID, run, 100, metrics); err != nil {
+		if errors.Is(err, ingest.ErrQueueFull) {
+			return api.NewTemporarilyUnavailableError("unable to insert metrics for run '%s': %s", run.ID, err)
+		}
 		return api.NewInternalError("unable to insert metrics for run '%s': %s", run.ID, err)
 	}
 	if err := s.runRepository.SetRunTagsBatch(ctx, run, 100, tags); err != nil {
@@ -674,6 +559,105 @@ func (s Service) LogBatch(
 	return nil
 }
 
+// LogMetricBulk logs metrics of several runs at once. All the runs are checked before anything
+// is written, so a request with an unknown run doesn't log anything. Metrics of each run are written
+// separately, so a write, which fails, keeps metrics of the preceding runs logged.
+func (s Service) LogMetricBulk(
+	ctx context.Context,
+	namespace *models.Namespace,
+	req *request.LogMetricBulkRequest,
+) error {
+	ctx, span := tracing.StartSpan(ctx, "mlflow.RunService.LogMetricBulk")
+	defer span.End()
+
+	if err := ValidateLogMetricBulkRequest(req); err != nil {
+		return err
+	}
+
+	// group metrics by run keeping the logged order, as it defines metric iters.
+	runIDs := []string{}
+	metrics := map[string][]models.Metric{}
+	for i := range req.Metrics {
+		metric, err := convertors.ConvertLogMetricBulkPartialRequestToDBModel(&req.Metrics[i])
+		if err != nil {
+			return api.NewInvalidParameterValueError(err.Error())
+		}
+		if _, ok := metrics[metric.RunID]; !ok {
+			runIDs = append(runIDs, metric.RunID)
+		}
+		metrics[metric.RunID] = append(metrics[metric.RunID], *metric)
+	}
+
+	runs := make([]*models.Run, len(runIDs))
+	for i, runID := range runIDs {
+		run, err := s.runRepository.GetByNamespaceIDRunIDAndLifecycleStage(
+			ctx, namespace.ID, runID, models.LifecycleStageActive,
+		)
+		if err != nil {
+			return api.NewInternalError("Unable to find run '%s': %s", runID, err)
+		}
+		if run == nil {
+			return api.NewResourceDoesNotExistError("Run '%s' not found", runID)
+		}
+		runs[i] = run
+	}
+
+	for _, run := range runs {
+		if err := s.createMetrics(ctx, namespace.ID, run, 1000, metrics[run.ID]); err != nil {
+			if errors.Is(err, ingest.ErrQueueFull) {
+				return api.NewTemporarilyUnavailableError("unable to insert metrics for run '%s': %s", run.ID, err)
+			}
+			return api.NewInternalError("unable to insert metrics for run '%s': %s", run.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// createMetrics writes metrics of the run through the ingestion service, when asynchronous
+// ingestion is enabled, otherwise metrics are written directly. Live updates are published only
+// after metrics were written, so the ingestion service publishes them once they are flushed.
+func (s Service) createMetrics(
+	ctx context.Context, namespaceID uint, run *models.Run, batchSize int, metrics []models.Metric,
+) error {
+	if s.ingestService != nil {
+		return s.ingestService.Enqueue(ctx, namespaceID, run, metrics)
+	}
+	if err := s.metricRepository.CreateBatch(ctx, run, batchSize, metrics); err != nil {
+		return err
+	}
+	s.liveService.PublishMetrics(namespaceID, run.ID, metrics)
+	return nil
+}
+
+// LogInputs logs dataset inputs of the existing Run.
+func (s Service) LogInputs(
+	ctx context.Context,
+	namespace *models.Namespace,
+	req *request.LogInputsRequest,
+) error {
+	if err := ValidateLogInputsRequest(req); err != nil {
+		return err
+	}
+
+	run, err := s.runRepository.GetByNamespaceIDRunIDAndLifecycleStage(
+		ctx, namespace.ID, req.RunID, models.LifecycleStageActive,
+	)
+	if err != nil {
+		return api.NewInternalError("Unable to find run '%s': %s", req.RunID, err)
+	}
+	if run == nil {
+		return api.NewResourceDoesNotExistError("Run '%s' not found", req.RunID)
+	}
+
+	inputs := convertors.ConvertLogInputsRequestToDBModel(run, req)
+	if err := s.inputRepository.CreateBatch(ctx, inputs); err != nil {
+		return api.NewInternalError("unable to insert inputs for run '%s': %s", run.ID, err)
+	}
+
+	return nil
+}
+
 func (s Service) LogOutput(
 	ctx context.Context,
 	namespace *models.Namespace,
@@ -695,6 +679,7 @@ func (s Service) LogOutput(
 	if err := s.logRepository.Create(ctx, log); err != nil {
 		return api.NewInternalError("unable to save log for run '%s'", req.RunID)
 	}
+	s.liveService.PublishLogs(namespace.ID, run.ID, log.Value)
 	return nil
 }
 
@@ -702,7 +687,14 @@ func (s Service) LogOutput(
 func (s Service) LogArtifact(
 	ctx context.Context, namespaceID uint, req *request.LogArtifactRequest,
 ) error {
-	artifact := ConvertCreateRunArtifactRequestToModel(namespaceID, req)
+	if err := ValidateLogArtifactRequest(req); err != nil {
+		return err
+	}
+
+	artifact, err := ConvertCreateRunArtifactRequestToModel(namespaceID, req)
+	if err != nil {
+		return api.NewBadRequestError("unable to convert request: %s", err)
+	}
 	if err := s.artifactRepository.Create(ctx, artifact); err != nil {
 		return api.NewInternalError("error creating run artifact: %s", err)
 	}
@@ -69,6 +69,9 @@ func TestService_CreateRun_Ok(t *testing.T) {
 		&experimentRepository,
 		&repositories.MockLogRepositoryProvider{},
 		&repositories.MockArtifactRepositoryProvider{},
+		&repositories.MockInputRepositoryProvider{},
+		nil,
+		nil,
 	)
 	run, err := service.CreateRun(context.TODO(), &ns, &request.CreateRunRequest{
 		ExperimentID: "0", // default experiment id provided by the client is "0"
@@ -126,6 +129,9 @@ func TestService_CreateRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -151,6 +157,9 @@ func TestService_CreateRun_Error(t *testing.T) {
 					&experimentRepository,
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -205,6 +214,9 @@ func TestService_CreateRun_Error(t *testing.T) {
 					&experimentRepository,
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -243,6 +255,9 @@ func TestService_UpdateRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -268,6 +283,9 @@ func TestService_UpdateRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -310,6 +328,9 @@ func TestService_RestoreRun_Ok(t *testing.T) {
 		&repositories.MockExperimentRepositoryProvider{},
 		&repositories.MockLogRepositoryProvider{},
 		&repositories.MockArtifactRepositoryProvider{},
+		&repositories.MockInputRepositoryProvider{},
+		nil,
+		nil,
 	)
 	err := service.RestoreRun(context.TODO(), &models.Namespace{ID: 1}, &request.RestoreRunRequest{RunID: "1"})
 
@@ -337,6 +358,9 @@ func TestService_RestoreRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -362,6 +386,9 @@ func TestService_RestoreRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -399,6 +426,9 @@ func TestService_RestoreRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -442,6 +472,9 @@ func TestService_SetRunTag_Ok(t *testing.T) {
 		&repositories.MockExperimentRepositoryProvider{},
 		&repositories.MockLogRepositoryProvider{},
 		&repositories.MockArtifactRepositoryProvider{},
+		&repositories.MockInputRepositoryProvider{},
+		nil,
+		nil,
 	)
 	err := service.SetRunTag(context.TODO(), &models.Namespace{
 		ID: 1,
@@ -480,6 +513,9 @@ func TestService_DeleteRun_Ok(t *testing.T) {
 		&repositories.MockExperimentRepositoryProvider{},
 		&repositories.MockLogRepositoryProvider{},
 		&repositories.MockArtifactRepositoryProvider{},
+		&repositories.MockInputRepositoryProvider{},
+		nil,
+		nil,
 	)
 	err := service.DeleteRun(context.TODO(), &models.Namespace{ID: 1}, &request.DeleteRunRequest{RunID: "1"})
 
@@ -507,6 +543,9 @@ func TestService_DeleteRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -532,6 +571,9 @@ func TestService_DeleteRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -557,6 +599,9 @@ func TestService_DeleteRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -592,6 +637,9 @@ func TestService_DeleteRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -629,6 +677,9 @@ func TestService_DeleteRunTag_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -655,6 +706,9 @@ func TestService_DeleteRunTag_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -681,6 +735,9 @@ func TestService_DeleteRunTag_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -718,6 +775,9 @@ func TestService_DeleteRunTag_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -755,6 +815,9 @@ func TestService_DeleteRunTag_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -806,6 +869,9 @@ func TestService_DeleteRunTag_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -874,6 +940,9 @@ func TestService_GetRun_Ok(t *testing.T) {
 		&repositories.MockExperimentRepositoryProvider{},
 		&repositories.MockLogRepositoryProvider{},
 		&repositories.MockArtifactRepositoryProvider{},
+		&repositories.MockInputRepositoryProvider{},
+		nil,
+		nil,
 	)
 	run, err := service.GetRun(context.TODO(), &models.Namespace{
 		ID: 1,
@@ -937,6 +1006,9 @@ func TestService_GetRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -962,6 +1034,9 @@ func TestService_GetRun_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -1040,6 +1115,9 @@ func TestService_LogBatch_Ok(t *testing.T) {
 		&repositories.MockExperimentRepositoryProvider{},
 		&repositories.MockLogRepositoryProvider{},
 		&repositories.MockArtifactRepositoryProvider{},
+		&repositories.MockInputRepositoryProvider{},
+		nil,
+		nil,
 	)
 	err := service.LogBatch(context.TODO(), &models.Namespace{
 		ID: 1,
@@ -1091,6 +1169,9 @@ func TestService_LogBatch_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -1117,6 +1198,9 @@ func TestService_LogBatch_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -1143,6 +1227,9 @@ func TestService_LogBatch_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -1169,6 +1256,9 @@ func TestService_LogBatch_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -1204,6 +1294,9 @@ func TestService_LogBatch_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -1251,6 +1344,9 @@ func TestService_LogBatch_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -1298,6 +1394,9 @@ func TestService_LogBatch_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -1375,6 +1474,9 @@ func TestService_LogBatch_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -1474,6 +1576,9 @@ func TestService_LogBatch_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -1525,6 +1630,9 @@ func TestService_LogMetric_Ok(t *testing.T) {
 		&repositories.MockExperimentRepositoryProvider{},
 		&repositories.MockLogRepositoryProvider{},
 		&repositories.MockArtifactRepositoryProvider{},
+		&repositories.MockInputRepositoryProvider{},
+		nil,
+		nil,
 	)
 	err := service.LogMetric(context.TODO(), &models.Namespace{
 		ID: 1,
@@ -1560,6 +1668,9 @@ func TestService_LogMetric_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -1578,6 +1689,9 @@ func TestService_LogMetric_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -1597,6 +1711,9 @@ func TestService_LogMetric_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -1625,6 +1742,9 @@ func TestService_LogMetric_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -1655,6 +1775,9 @@ func TestService_LogMetric_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -1705,6 +1828,9 @@ func TestService_LogMetric_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -1754,6 +1880,9 @@ func TestService_LogParam_Ok(t *testing.T) {
 		&repositories.MockExperimentRepositoryProvider{},
 		&repositories.MockLogRepositoryProvider{},
 		&repositories.MockArtifactRepositoryProvider{},
+		&repositories.MockInputRepositoryProvider{},
+		nil,
+		nil,
 	)
 	err := service.LogParam(context.TODO(), &models.Namespace{
 		ID: 1,
@@ -1787,6 +1916,9 @@ func TestService_LogParam_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -1805,6 +1937,9 @@ func TestService_LogParam_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -1833,6 +1968,9 @@ func TestService_LogParam_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -1861,6 +1999,9 @@ func TestService_LogParam_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -1905,6 +2046,9 @@ func TestService_LogParam_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -1949,6 +2093,9 @@ func TestService_LogParam_Error(t *testing.T) {
 					&repositories.MockExperimentRepositoryProvider{},
 					&repositories.MockLogRepositoryProvider{},
 					&repositories.MockArtifactRepositoryProvider{},
+					&repositories.MockInputRepositoryProvider{},
+					nil,
+					nil,
 				)
 			},
 		},
@@ -1,7 +1,10 @@
 package run
 
 import (
+	"math"
+
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 )
 
@@ -17,6 +20,14 @@ var (
 		request.ViewTypeActiveOnly:  {},
 		request.ViewTypeDeletedOnly: {},
 	}
+	AllowedArtifactTypeList = map[models.ArtifactType]struct{}{
+		"":                               {},
+		models.ArtifactTypeImages:        {},
+		models.ArtifactTypeTexts:         {},
+		models.ArtifactTypeAudios:        {},
+		models.ArtifactTypeDistributions: {},
+		models.ArtifactTypeFigures:       {},
+	}
 )
 
 // ValidateUpdateRunRequest validates `POST /mlflow/runs/update` request.
@@ -123,6 +134,19 @@ func ValidateLogBatchRequest(req *request.LogBatchRequest) error {
 	return nil
 }
 
+// ValidateLogMetricBulkRequest validates `POST /mlflow/metrics/log-bulk` request.
+func ValidateLogMetricBulkRequest(req *request.LogMetricBulkRequest) error {
+	if len(req.Metrics) == 0 {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'metrics'")
+	}
+	for _, metric := range req.Metrics {
+		if metric.RunID == "" || metric.Key == "" || metric.Timestamp == 0 {
+			return api.NewInvalidParameterValueError("Invalid value for parameter 'metrics' supplied")
+		}
+	}
+	return nil
+}
+
 // ValidateSearchRunsRequest validates `POST /mlflow/runs/search` request.
 func ValidateSearchRunsRequest(req *request.SearchRunsRequest) error {
 	if _, ok := AllowedViewTypeList[req.ViewType]; !ok {
@@ -144,3 +168,54 @@ func ValidateLogOutputRequest(req *request.LogOutputRequest) error {
 	}
 	return nil
 }
+
+// ValidateLogInputsRequest validates `POST /mlflow/runs/log-inputs` request.
+func ValidateLogInputsRequest(req *request.LogInputsRequest) error {
+	if req.RunID == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'")
+	}
+	for _, input := range req.Datasets {
+		switch {
+		case input.Dataset.Name == "":
+			return api.NewInvalidParameterValueError("Missing value for required parameter 'dataset.name'")
+		case input.Dataset.Digest == "":
+			return api.NewInvalidParameterValueError("Missing value for required parameter 'dataset.digest'")
+		case input.Dataset.SourceType == "":
+			return api.NewInvalidParameterValueError("Missing value for required parameter 'dataset.source_type'")
+		case input.Dataset.Source == "":
+			return api.NewInvalidParameterValueError("Missing value for required parameter 'dataset.source'")
+		}
+		for _, tag := range input.Tags {
+			if tag.Key == "" {
+				return api.NewInvalidParameterValueError("Invalid value for parameter 'tags' supplied")
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateLogArtifactRequest validates `POST /mlflow/runs/log-artifact` request.
+func ValidateLogArtifactRequest(req *request.LogArtifactRequest) error {
+	if req.RunID == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'")
+	}
+	if _, ok := AllowedArtifactTypeList[models.ArtifactType(req.Type)]; !ok {
+		return api.NewInvalidParameterValueError("Invalid value for parameter 'type' supplied")
+	}
+	if models.ArtifactType(req.Type) == models.ArtifactTypeDistributions {
+		if req.Distribution == nil || len(req.Distribution.Weights) == 0 {
+			return api.NewInvalidParameterValueError("Missing value for required parameter 'distribution.weights'")
+		}
+		if len(req.Distribution.Range) != 2 {
+			return api.NewInvalidParameterValueError("Invalid value for parameter 'distribution.range' supplied")
+		}
+		for _, values := range [][]float64{req.Distribution.Range, req.Distribution.Weights} {
+			for _, value := range values {
+				if math.IsNaN(value) || math.IsInf(value, 0) {
+					return api.NewInvalidParameterValueError("Invalid value for parameter 'distribution' supplied")
+				}
+			}
+		}
+	}
+	return nil
+}
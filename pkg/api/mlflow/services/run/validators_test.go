@@ -1,6 +1,7 @@
 package run
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -444,3 +445,218 @@ func TestValidateLogOutputRequest_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateLogInputsRequest_Ok(t *testing.T) {
+	err := ValidateLogInputsRequest(&request.LogInputsRequest{
+		RunID: "id",
+		Datasets: []request.DatasetInputPartialRequest{{
+			Tags: []request.InputTagPartialRequest{{Key: "mlflow.data.context", Value: "training"}},
+			Dataset: request.DatasetPartialRequest{
+				Name:       "name",
+				Digest:     "digest",
+				SourceType: "local",
+				Source:     "source",
+			},
+		}},
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateLogInputsRequest_Error(t *testing.T) {
+	dataset := request.DatasetPartialRequest{
+		Name:       "name",
+		Digest:     "digest",
+		SourceType: "local",
+		Source:     "source",
+	}
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.LogInputsRequest
+	}{
+		{
+			name:    "EmptyRunID",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'"),
+			request: &request.LogInputsRequest{},
+		},
+		{
+			name:  "EmptyDatasetDigest",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'dataset.digest'"),
+			request: &request.LogInputsRequest{
+				RunID: "id",
+				Datasets: []request.DatasetInputPartialRequest{{
+					Dataset: request.DatasetPartialRequest{
+						Name:       "name",
+						SourceType: "local",
+						Source:     "source",
+					},
+				}},
+			},
+		},
+		{
+			name:  "EmptyDatasetSourceType",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'dataset.source_type'"),
+			request: &request.LogInputsRequest{
+				RunID: "id",
+				Datasets: []request.DatasetInputPartialRequest{{
+					Dataset: request.DatasetPartialRequest{
+						Name:   "name",
+						Digest: "digest",
+						Source: "source",
+					},
+				}},
+			},
+		},
+		{
+			name:  "EmptyTagKey",
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'tags' supplied"),
+			request: &request.LogInputsRequest{
+				RunID: "id",
+				Datasets: []request.DatasetInputPartialRequest{{
+					Tags:    []request.InputTagPartialRequest{{Value: "training"}},
+					Dataset: dataset,
+				}},
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLogInputsRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestValidateLogArtifactRequest_Ok(t *testing.T) {
+	err := ValidateLogArtifactRequest(&request.LogArtifactRequest{
+		RunID: "id",
+		Name:  "weights",
+		Type:  "distributions",
+		Distribution: &request.DistributionPartialRequest{
+			Weights:  []float64{1, 2, 3},
+			BinCount: 3,
+			Range:    []float64{0, 1},
+		},
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateLogArtifactRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.LogArtifactRequest
+	}{
+		{
+			name:    "EmptyRunID",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'"),
+			request: &request.LogArtifactRequest{},
+		},
+		{
+			name:  "UnsupportedType",
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'type' supplied"),
+			request: &request.LogArtifactRequest{
+				RunID: "id",
+				Type:  "videos",
+			},
+		},
+		{
+			name:  "EmptyDistributionWeights",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'distribution.weights'"),
+			request: &request.LogArtifactRequest{
+				RunID: "id",
+				Type:  "distributions",
+			},
+		},
+		{
+			name:  "IncorrectDistributionRange",
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'distribution.range' supplied"),
+			request: &request.LogArtifactRequest{
+				RunID: "id",
+				Type:  "distributions",
+				Distribution: &request.DistributionPartialRequest{
+					Weights: []float64{1},
+					Range:   []float64{0},
+				},
+			},
+		},
+		{
+			name:  "NotFiniteDistributionWeight",
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'distribution' supplied"),
+			request: &request.LogArtifactRequest{
+				RunID: "id",
+				Type:  "distributions",
+				Distribution: &request.DistributionPartialRequest{
+					Weights: []float64{math.Inf(1)},
+					Range:   []float64{0, 1},
+				},
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLogArtifactRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestValidateLogMetricBulkRequest_Ok(t *testing.T) {
+	err := ValidateLogMetricBulkRequest(&request.LogMetricBulkRequest{
+		Metrics: []request.LogMetricBulkPartialRequest{
+			{RunID: "id", Key: "key1", Timestamp: 123456789},
+		},
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateLogMetricBulkRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.LogMetricBulkRequest
+	}{
+		{
+			name:    "EmptyMetrics",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'metrics'"),
+			request: &request.LogMetricBulkRequest{},
+		},
+		{
+			name:  "EmptyMetricRunID",
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'metrics' supplied"),
+			request: &request.LogMetricBulkRequest{
+				Metrics: []request.LogMetricBulkPartialRequest{
+					{RunID: "id", Key: "key1", Timestamp: 123456789},
+					{Key: "key1", Timestamp: 123456789},
+				},
+			},
+		},
+		{
+			name:  "EmptyMetricKey",
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'metrics' supplied"),
+			request: &request.LogMetricBulkRequest{
+				Metrics: []request.LogMetricBulkPartialRequest{
+					{RunID: "id", Timestamp: 123456789},
+				},
+			},
+		},
+		{
+			name:  "EmptyMetricTimestamp",
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'metrics' supplied"),
+			request: &request.LogMetricBulkRequest{
+				Metrics: []request.LogMetricBulkPartialRequest{
+					{RunID: "id", Key: "key1"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateLogMetricBulkRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
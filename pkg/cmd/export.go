@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/G-Research/fasttrackml/pkg/database"
+)
+
+var ExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Exports a namespace to a portable archive",
+	Long: `The export command will write the experiments, runs, params, tags,
+         metrics, logs, dashboards and apps of the namespace, and optionally
+         the run artifacts, to a self-describing tar.zst archive, which
+         can be loaded with the import command.`,
+	RunE: exportCmd,
+}
+
+func exportCmd(cmd *cobra.Command, args []string) error {
+	db, err := database.NewDBProvider(
+		viper.GetString("database-uri"),
+		time.Second*1,
+		20,
+	)
+	if err != nil {
+		return fmt.Errorf("error connecting to DB: %w", err)
+	}
+	//nolint:errcheck
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	var options []func(*database.Exporter)
+	if viper.GetBool("artifacts") {
+		_, artifactStorageFactory, err := newArtifactStorageFactory()
+		if err != nil {
+			return err
+		}
+		options = append(options, database.WithExportArtifacts(artifactStorageFactory))
+	}
+
+	out, err := os.Create(viper.GetString("out"))
+	if err != nil {
+		return fmt.Errorf("error creating output file: %w", err)
+	}
+	//nolint:errcheck
+	defer out.Close()
+
+	manifest, err := database.NewExporter(
+		db.GormDB().WithContext(ctx), viper.GetString("namespace"), options...,
+	).Export(ctx, out)
+	if err != nil {
+		// don't leave incomplete bundle behind.
+		//nolint:errcheck,gosec
+		out.Close()
+		//nolint:errcheck,gosec
+		os.Remove(viper.GetString("out"))
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("error closing output file: %w", err)
+	}
+	log.Infof(
+		"Exported namespace %s with %d experiments, %d runs and %d artifacts to %s",
+		manifest.Namespace.Code,
+		manifest.Tables["experiments"],
+		manifest.Tables["runs"],
+		len(manifest.Artifacts),
+		viper.GetString("out"),
+	)
+	return nil
+}
+
+// nolint:errcheck,gosec
+func init() {
+	RootCmd.AddCommand(ExportCmd)
+
+	ExportCmd.Flags().StringP("database-uri", "d", "sqlite://fasttrackml.db", "Database URI")
+	ExportCmd.Flags().StringP("namespace", "n", "default", "Namespace to export")
+	ExportCmd.Flags().StringP("out", "o", "", "Output archive (eg., bundle.tar.zst)")
+	ExportCmd.Flags().Bool("artifacts", false, "Include run artifacts")
+	ExportCmd.Flags().StringP("default-artifact-root", "a", "./artifacts", "Default artifact root")
+	ExportCmd.Flags().String(
+		"artifacts-destination", "", "Storage for artifacts served by mlflow-artifacts API (default-artifact-root if empty)",
+	)
+	addArtifactStorageFlags(ExportCmd.Flags())
+	ExportCmd.MarkFlagRequired("out")
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/services/gc"
+	"github.com/G-Research/fasttrackml/pkg/database"
+)
+
+var GCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Permanently purges deleted runs and experiments",
+	Long: `The gc command will permanently delete runs and experiments,
+         which were deleted longer than the provided period ago,
+         together with their metrics, params, tags, logs and artifacts.
+         Staged parts of abandoned multipart artifact uploads are purged too.`,
+	RunE: gcCmd,
+}
+
+func gcCmd(cmd *cobra.Command, args []string) error {
+	db, err := database.NewDBProvider(
+		viper.GetString("database-uri"),
+		time.Second*1,
+		20,
+	)
+	if err != nil {
+		return fmt.Errorf("error connecting to DB: %w", err)
+	}
+	//nolint:errcheck
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(cmd.Context())
+	defer cancel()
+
+	_, artifactStorageFactory, err := newArtifactStorageFactory()
+	if err != nil {
+		return err
+	}
+
+	result, err := gc.NewCollector(
+		repositories.NewNamespaceRepository(db.GormDB()),
+		repositories.NewExperimentRepository(db.GormDB()),
+		repositories.NewRunRepository(db.GormDB()),
+		artifactStorageFactory,
+	).Collect(ctx, gc.Options{
+		OlderThan:        viper.GetDuration("older-than"),
+		Namespaces:       viper.GetStringSlice("namespace"),
+		UploadsOlderThan: viper.GetDuration("uploads-older-than"),
+		DryRun:           viper.GetBool("dry-run"),
+	})
+	if err != nil {
+		return err
+	}
+	if viper.GetBool("dry-run") {
+		log.Infof(
+			"Would purge %d experiments, %d runs and %d multipart uploads", result.Experiments, result.Runs, result.Uploads,
+		)
+		return nil
+	}
+	log.Infof(
+		"Purged %d experiments, %d runs and %d multipart uploads, %d runs were kept due to errors",
+		result.Experiments, result.Runs, result.Uploads, result.Failed,
+	)
+	return nil
+}
+
+// nolint:errcheck,gosec
+func init() {
+	RootCmd.AddCommand(GCCmd)
+
+	GCCmd.Flags().StringP("database-uri", "d", "sqlite://fasttrackml.db", "Database URI")
+	GCCmd.Flags().StringSliceP("namespace", "n", nil, "Namespaces to collect (all namespaces if empty)")
+	GCCmd.Flags().Duration(
+		"older-than", 30*24*time.Hour, "Purge runs and experiments deleted longer than this period ago (0 for all)",
+	)
+	GCCmd.Flags().Duration(
+		"uploads-older-than", gc.DefaultUploadsOlderThan,
+		"Purge staged parts of multipart uploads started longer than this period ago (0 to keep them)",
+	)
+	GCCmd.Flags().Bool("dry-run", false, "Only report runs and experiments, which would be purged")
+	GCCmd.Flags().StringP("default-artifact-root", "a", "./artifacts", "Default artifact root")
+	GCCmd.Flags().String(
+		"artifacts-destination", "", "Storage for artifacts served by mlflow-artifacts API (default-artifact-root if empty)",
+	)
+	addArtifactStorageFlags(GCCmd.Flags())
+}
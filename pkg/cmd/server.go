@@ -66,10 +66,18 @@ func init() {
 	RootCmd.AddCommand(ServerCmd)
 
 	ServerCmd.Flags().StringP("listen-address", "a", "localhost:5000", "Address (host:post) to listen to")
+	ServerCmd.Flags().String(
+		"metrics-listen-address", "", "Address (host:port) to expose Prometheus metrics on (listen-address if empty)",
+	)
 	ServerCmd.Flags().String("default-artifact-root", "./artifacts", "Default artifact root")
-	ServerCmd.Flags().String("s3-endpoint-uri", "", "S3 compatible storage base endpoint url")
-	ServerCmd.Flags().String("gs-endpoint-uri", "", "Google Storage base endpoint url")
-	ServerCmd.Flags().MarkHidden("gs-endpoint-uri")
+	ServerCmd.Flags().String(
+		"artifacts-destination", "", "Storage for artifacts served by mlflow-artifacts API (default-artifact-root if empty)",
+	)
+	addArtifactStorageFlags(ServerCmd.Flags())
+	ServerCmd.Flags().Bool(
+		"presigned-urls-enabled", false, "Redirect artifact downloads to presigned URLs of S3 and GS storages",
+	)
+	ServerCmd.Flags().Duration("presigned-urls-expiry", 15*time.Minute, "Expiry of presigned artifact URLs")
 	ServerCmd.Flags().String("auth-username", "", "BasicAuth username")
 	ServerCmd.Flags().String("auth-password", "", "BasicAuth password")
 	ServerCmd.Flags().String("auth-users-config", "", "Users configuration file")
@@ -90,6 +98,29 @@ func init() {
 	ServerCmd.Flags().MarkHidden("dev-mode")
 	ServerCmd.Flags().Int("log-output-max", 2000, "Maximum log rows per run to retain.")
 	ServerCmd.Flags().Duration("log-output-retention", 7*24*time.Hour, "Run logs retention period")
+	ServerCmd.Flags().Duration(
+		"gc-older-than", 0, "Purge runs and experiments deleted longer than this period ago (0 to keep them forever)",
+	)
+	ServerCmd.Flags().Bool("audit-log-enabled", false, "Record mutating API calls into the audit log")
+	ServerCmd.Flags().Duration("audit-log-retention", 90*24*time.Hour, "Audit log retention period (0 to keep forever)")
+	ServerCmd.Flags().Bool("metric-ingest-async", false, "Buffer logged metrics and write them in background")
+	ServerCmd.Flags().String(
+		"metric-ingest-durability", "flush", "When to acknowledge buffered metrics: after 'flush' or after 'enqueue'",
+	)
+	ServerCmd.Flags().Int("metric-ingest-workers", 4, "Number of workers writing buffered metrics")
+	ServerCmd.Flags().Int("metric-ingest-queue-size", 100000, "Maximum number of buffered metrics")
+	ServerCmd.Flags().Duration(
+		"metric-ingest-queue-timeout", 5*time.Second, "How long to wait for a room in the full metric queue",
+	)
+	ServerCmd.Flags().Int("metric-ingest-batch-size", 10000, "Number of buffered metrics of a run to flush at once")
+	ServerCmd.Flags().Duration("metric-ingest-flush-interval", 100*time.Millisecond, "Buffered metrics flush interval")
+	ServerCmd.Flags().Bool("tracing-enabled", false, "Export OpenTelemetry traces of the requests")
+	ServerCmd.Flags().String(
+		"tracing-otlp-endpoint", "", "OTLP/HTTP endpoint (host:port) to export traces to (OTEL_EXPORTER_OTLP_* if empty)",
+	)
+	ServerCmd.Flags().Bool("tracing-otlp-insecure", false, "Export traces over plain HTTP instead of HTTPS")
+	ServerCmd.Flags().Float64("tracing-sample-ratio", 1, "Ratio of the sampled traces, which don't have a parent")
+	ServerCmd.Flags().String("tracing-service-name", "fasttrackml", "Service name reported in traces")
 	viper.BindEnv("auth-username", "MLFLOW_TRACKING_USERNAME")
 	viper.BindEnv("auth-password", "MLFLOW_TRACKING_PASSWORD")
 }
@@ -33,6 +33,7 @@ const (
 	ErrorCodeEndpointNotFound       = "ENDPOINT_NOT_FOUND"
 	ErrorCodeResourceAlreadyExists  = "RESOURCE_ALREADY_EXISTS"
 	ErrorCodeResourceDoesNotExist   = "RESOURCE_DOES_NOT_EXIST"
+	ErrorCodePermissionDenied       = "PERMISSION_DENIED"
 )
 
 // NewBadRequestError creates new Response object with ErrorCodeBadRequest.
@@ -88,3 +89,21 @@ func NewEndpointNotFound(msg string, args ...any) *ErrorResponse {
 		StatusCode: http.StatusNotFound,
 	}
 }
+
+// NewPermissionDeniedError creates new Response object with ErrorCodePermissionDenied.
+func NewPermissionDeniedError(msg string, args ...any) *ErrorResponse {
+	return &ErrorResponse{
+		Message:    fmt.Sprintf(msg, args...),
+		ErrorCode:  ErrorCodePermissionDenied,
+		StatusCode: http.StatusForbidden,
+	}
+}
+
+// NewTemporarilyUnavailableError creates new Response object with ErrorCodeTemporarilyUnavailable.
+func NewTemporarilyUnavailableError(msg string, args ...any) *ErrorResponse {
+	return &ErrorResponse{
+		Message:    fmt.Sprintf(msg, args...),
+		ErrorCode:  ErrorCodeTemporarilyUnavailable,
+		StatusCode: http.StatusServiceUnavailable,
+	}
+}
@@ -1,5 +1,7 @@
 package request
 
+import "path"
+
 // ListArtifactsRequest is a request object for `GET /mlflow/artifacts/list` endpoint.
 type ListArtifactsRequest struct {
 	Path    string `query:"path"`
@@ -29,3 +31,70 @@ func (r GetArtifactRequest) GetRunID() string {
 	}
 	return r.RunUUID
 }
+
+// ListProxiedArtifactsRequest is a request object for `GET /mlflow-artifacts/artifacts` endpoint.
+type ListProxiedArtifactsRequest struct {
+	Path string `query:"path"`
+}
+
+// DownloadArtifactRequest is a request object for `GET /mlflow-artifacts/artifacts/:path` endpoint.
+type DownloadArtifactRequest struct {
+	Path string
+}
+
+// UploadArtifactRequest is a request object for `PUT /mlflow-artifacts/artifacts/:path` endpoint.
+type UploadArtifactRequest struct {
+	Path string
+}
+
+// DeleteArtifactRequest is a request object for `DELETE /mlflow-artifacts/artifacts/:path` endpoint.
+type DeleteArtifactRequest struct {
+	Path string
+}
+
+// CreateMultipartUploadRequest is a request object for `POST /mlflow-artifacts/mpu/create/:path` endpoint.
+// Path is a local path of the uploaded file, only its base name is appended to ArtifactPath.
+type CreateMultipartUploadRequest struct {
+	ArtifactPath string `json:"-"`
+	Path         string `json:"path"`
+	NumParts     int64  `json:"num_parts"`
+}
+
+// UploadMultipartPartRequest is a request object for `PUT /mlflow-artifacts/mpu/upload/:path` endpoint.
+type UploadMultipartPartRequest struct {
+	ArtifactPath string `query:"-"`
+	UploadID     string `query:"upload_id"`
+	PartNumber   int64  `query:"part_number"`
+}
+
+// MultipartUploadPartPartialRequest is a partial request object for different requests.
+type MultipartUploadPartPartialRequest struct {
+	PartNumber int64  `json:"part_number"`
+	ETag       string `json:"etag"`
+	URL        string `json:"url"`
+}
+
+// CompleteMultipartUploadRequest is a request object for `POST /mlflow-artifacts/mpu/complete/:path` endpoint.
+type CompleteMultipartUploadRequest struct {
+	ArtifactPath string                              `json:"-"`
+	Path         string                              `json:"path"`
+	UploadID     string                              `json:"upload_id"`
+	Parts        []MultipartUploadPartPartialRequest `json:"parts"`
+}
+
+// AbortMultipartUploadRequest is a request object for `POST /mlflow-artifacts/mpu/abort/:path` endpoint.
+type AbortMultipartUploadRequest struct {
+	ArtifactPath string `json:"-"`
+	Path         string `json:"path"`
+	UploadID     string `json:"upload_id"`
+}
+
+// GetDestinationPath returns path of the artifact created by multipart upload.
+func (r CreateMultipartUploadRequest) GetDestinationPath() string {
+	return path.Join(r.ArtifactPath, path.Base(r.Path))
+}
+
+// GetDestinationPath returns path of the artifact created by multipart upload.
+func (r CompleteMultipartUploadRequest) GetDestinationPath() string {
+	return path.Join(r.ArtifactPath, path.Base(r.Path))
+}
@@ -1,6 +1,14 @@
 package response
 
-import "github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+import (
+	"net/url"
+	"path"
+	"strconv"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+)
 
 // FilePartialResponse is a partial response object for different responses.
 type FilePartialResponse struct {
@@ -34,3 +42,68 @@ func NewListArtifactsResponse(
 
 	return &response
 }
+
+// ListProxiedArtifactsResponse is a response object for `GET /mlflow-artifacts/artifacts` endpoint.
+type ListProxiedArtifactsResponse struct {
+	Files []FilePartialResponse `json:"files"`
+}
+
+// NewListProxiedArtifactsResponse creates new instance of ListProxiedArtifactsResponse.
+// Unlike `GET mlflow/artifacts/list` endpoint, paths of the files are relative to the requested path.
+func NewListProxiedArtifactsResponse(artifacts []storage.ArtifactObject) *ListProxiedArtifactsResponse {
+	response := ListProxiedArtifactsResponse{
+		Files: make([]FilePartialResponse, len(artifacts)),
+	}
+
+	for i, artifact := range artifacts {
+		response.Files[i] = FilePartialResponse{
+			Path:     path.Base(artifact.GetPath()),
+			IsDir:    artifact.IsDirectory(),
+			FileSize: artifact.GetSize(),
+		}
+	}
+
+	return &response
+}
+
+// MultipartUploadCredentialPartialResponse is a partial response object for different responses.
+type MultipartUploadCredentialPartialResponse struct {
+	PartNumber int64  `json:"part_number"`
+	URL        string `json:"url"`
+}
+
+// CreateMultipartUploadResponse is a response object for `POST /mlflow-artifacts/mpu/create/:path` endpoint.
+type CreateMultipartUploadResponse struct {
+	UploadID    string                                     `json:"upload_id"`
+	Credentials []MultipartUploadCredentialPartialResponse `json:"credentials"`
+}
+
+// NewCreateMultipartUploadResponse creates new instance of CreateMultipartUploadResponse.
+// Every part has to be uploaded to the `partURL` with `upload_id` and `part_number` query parameters.
+// Parts are uploaded back to the tracking server, so clients authorize them with their own credentials.
+func NewCreateMultipartUploadResponse(
+	uploadID string, numParts int64, partURL string,
+) (*CreateMultipartUploadResponse, error) {
+	u, err := url.Parse(partURL)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error parsing part url: %s", partURL)
+	}
+
+	response := CreateMultipartUploadResponse{
+		UploadID:    uploadID,
+		Credentials: make([]MultipartUploadCredentialPartialResponse, numParts),
+	}
+	for i := range response.Credentials {
+		partNumber := int64(i + 1)
+		u.RawQuery = url.Values{
+			"upload_id":   []string{uploadID},
+			"part_number": []string{strconv.FormatInt(partNumber, 10)},
+		}.Encode()
+		response.Credentials[i] = MultipartUploadCredentialPartialResponse{
+			PartNumber: partNumber,
+			URL:        u.String(),
+		}
+	}
+
+	return &response, nil
+}
@@ -2,6 +2,7 @@ package oidc
 
 // User represents an object to store current user information.
 type User struct {
+	subject string
 	roles   []string
 	isAdmin bool
 }
@@ -15,3 +16,8 @@ func (u User) IsAdmin() bool {
 func (u User) GetRoles() []string {
 	return u.roles
 }
+
+// GetSubject returns current user subject, which identifies the user in OIDC provider.
+func (u User) GetSubject() string {
+	return u.subject
+}
@@ -66,6 +66,11 @@ func parseUserConfigFromYaml(content []byte) (*models.UserPermissions, error) {
 		}
 		roles := map[string]struct{}{}
 		for _, role := range user.Roles {
+			if strings.HasPrefix(role, "ns:") {
+				if _, _, err := models.ParseNamespaceRole(role); err != nil {
+					return nil, eris.Wrapf(err, "error parsing role of user: %s", user.Name)
+				}
+			}
 			roles[role] = struct{}{}
 		}
 
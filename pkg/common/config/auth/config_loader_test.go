@@ -141,6 +141,24 @@ func TestLoad_Error(t *testing.T) {
 
 	_, err = Load(configPath)
 	assert.Equal(t, "unsupported user configuration file type", err.Error())
+
+	data, err := yaml.Marshal(YamlConfig{
+		Users: []YamlUserConfig{
+			{
+				Name:     "user1",
+				Roles:    []string{"ns:namespace1:superuser"},
+				Password: "user1password",
+			},
+		},
+	})
+	assert.Nil(t, err)
+	configPath = fmt.Sprintf("%s/configuration.yaml", t.TempDir())
+	assert.Nil(t, os.WriteFile(configPath, data, 0o600))
+
+	_, err = Load(configPath)
+	assert.ErrorContains(
+		t, err, "invalid namespace role 'superuser'. Valid values are ['viewer', 'editor', 'owner']",
+	)
 }
 
 func TestUserPermissions_HasAccess_Ok(t *testing.T) {
@@ -171,6 +189,68 @@ func TestUserPermissions_HasAccess_Ok(t *testing.T) {
 	}
 }
 
+func TestUserPermissions_HasNamespaceRole_Ok(t *testing.T) {
+	permissions := models.NewUserPermissions(map[string]map[string]struct{}{
+		"token": {
+			"ns:namespace1":        struct{}{},
+			"ns:namespace2:viewer": struct{}{},
+			"ns:namespace3:editor": struct{}{},
+			"ns:namespace3:viewer": struct{}{},
+		},
+	})
+	tests := []struct {
+		name      string
+		namespace string
+		role      models.NamespaceRole
+		expected  bool
+	}{
+		{
+			name:      "RoleWithoutLevelGrantsOwnerRole",
+			namespace: "namespace1",
+			role:      models.NamespaceRoleOwner,
+			expected:  true,
+		},
+		{
+			name:      "ViewerCanRead",
+			namespace: "namespace2",
+			role:      models.NamespaceRoleViewer,
+			expected:  true,
+		},
+		{
+			name:      "ViewerCanNotModify",
+			namespace: "namespace2",
+			role:      models.NamespaceRoleEditor,
+			expected:  false,
+		},
+		{
+			name:      "MostPermissiveRoleIsUsed",
+			namespace: "namespace3",
+			role:      models.NamespaceRoleEditor,
+			expected:  true,
+		},
+		{
+			name:      "EditorCanNotDelete",
+			namespace: "namespace3",
+			role:      models.NamespaceRoleOwner,
+			expected:  false,
+		},
+		{
+			name:      "NoAccessToNamespace",
+			namespace: "namespace4",
+			role:      models.NamespaceRoleViewer,
+			expected:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			authToken := permissions.ValidateAuthToken("token")
+			assert.NotNil(t, authToken)
+			assert.Equal(t, tt.expected, authToken.HasNamespaceRole(tt.namespace, tt.role))
+		})
+	}
+}
+
 func TestUserPermissions_HasAdminAccess_Ok(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -4,7 +4,7 @@ import (
 	"net/url"
 	"path"
 	"path/filepath"
-	"slices"
+	"sync"
 	"time"
 
 	"github.com/rotisserie/eris"
@@ -15,20 +15,80 @@ import (
 
 // Config represents main service configuration.
 type Config struct {
-	Auth                  auth.Config
-	DevMode               bool
-	ListenAddress         string
-	DefaultArtifactRoot   string
-	S3EndpointURI         string
-	GSEndpointURI         string
-	DatabaseURI           string
-	DatabaseReset         bool
-	DatabasePoolMax       int
-	DatabaseMigrate       bool
-	DatabaseSlowThreshold time.Duration
-	LiveUpdatesEnabled    bool
-	RunLogOutputMax       int
-	RunLogOutputRetain    time.Duration
+	Auth                   auth.Config
+	DevMode                bool
+	ListenAddress          string
+	MetricsListenAddress   string
+	DefaultArtifactRoot    string
+	ArtifactsDestination   string
+	ArtifactStorageOptions map[string]string
+	ArtifactStorageConfig  string
+	PresignedURLsEnabled   bool
+	PresignedURLsExpiry    time.Duration
+	DatabaseURI            string
+	DatabaseReset          bool
+	DatabasePoolMax        int
+	DatabaseMigrate        bool
+	DatabaseSlowThreshold  time.Duration
+	LiveUpdatesEnabled     bool
+	RunLogOutputMax        int
+	RunLogOutputRetain     time.Duration
+	GCOlderThan            time.Duration
+	AuditLogEnabled        bool
+	AuditLogRetain         time.Duration
+	MetricIngest           MetricIngestConfig
+	Tracing                TracingConfig
+}
+
+// supported durability modes of asynchronous metric ingestion.
+const (
+	// MetricIngestDurabilityFlush acknowledges logged metrics after they were written into the database.
+	MetricIngestDurabilityFlush = "flush"
+	// MetricIngestDurabilityEnqueue acknowledges logged metrics as soon as they were buffered.
+	MetricIngestDurabilityEnqueue = "enqueue"
+)
+
+// MetricIngestConfig represents configuration of asynchronous metric ingestion.
+type MetricIngestConfig struct {
+	Async         bool
+	Durability    string
+	Workers       int
+	QueueSize     int
+	QueueTimeout  time.Duration
+	BatchSize     int
+	FlushInterval time.Duration
+}
+
+// TracingConfig represents configuration of OpenTelemetry tracing.
+type TracingConfig struct {
+	Enabled     bool
+	Endpoint    string
+	Insecure    bool
+	SampleRatio float64
+	ServiceName string
+}
+
+// artifactStorageOptions holds names of the flags registered by artifact storage backends.
+var artifactStorageOptions sync.Map
+
+// RegisterArtifactStorageOptions registers names of the flags which values
+// have to be passed to artifact storage backends through ArtifactStorageOptions.
+func RegisterArtifactStorageOptions(names ...string) {
+	for _, name := range names {
+		artifactStorageOptions.Store(name, struct{}{})
+	}
+}
+
+// getArtifactStorageOptions reads values of the registered artifact storage flags.
+func getArtifactStorageOptions() map[string]string {
+	options := map[string]string{}
+	artifactStorageOptions.Range(func(name, _ any) bool {
+		if value := viper.GetString(name.(string)); value != "" {
+			options[name.(string)] = value
+		}
+		return true
+	})
+	return options
 }
 
 // NewConfig creates a new instance of Config.
@@ -45,19 +105,42 @@ func NewConfig() *Config {
 			AuthOIDCClientSecret:     viper.GetString("auth-oidc-client-secret"),
 			AuthOIDCProviderEndpoint: viper.GetString("auth-oidc-provider-endpoint"),
 		},
-		DevMode:               viper.GetBool("dev-mode"),
-		ListenAddress:         viper.GetString("listen-address"),
-		DefaultArtifactRoot:   viper.GetString("default-artifact-root"),
-		S3EndpointURI:         viper.GetString("s3-endpoint-uri"),
-		GSEndpointURI:         viper.GetString("gs-endpoint-uri"),
-		DatabaseURI:           viper.GetString("database-uri"),
-		DatabaseReset:         viper.GetBool("database-reset"),
-		DatabasePoolMax:       viper.GetInt("database-pool-max"),
-		DatabaseMigrate:       viper.GetBool("database-migrate"),
-		DatabaseSlowThreshold: viper.GetDuration("database-slow-threshold"),
-		LiveUpdatesEnabled:    viper.GetBool("live-updates-enabled"),
-		RunLogOutputMax:       viper.GetInt("log-output-max"),
-		RunLogOutputRetain:    viper.GetDuration("log-output-retention"),
+		DevMode:                viper.GetBool("dev-mode"),
+		ListenAddress:          viper.GetString("listen-address"),
+		MetricsListenAddress:   viper.GetString("metrics-listen-address"),
+		DefaultArtifactRoot:    viper.GetString("default-artifact-root"),
+		ArtifactsDestination:   viper.GetString("artifacts-destination"),
+		ArtifactStorageOptions: getArtifactStorageOptions(),
+		ArtifactStorageConfig:  viper.GetString("artifact-storage-config"),
+		PresignedURLsEnabled:   viper.GetBool("presigned-urls-enabled"),
+		PresignedURLsExpiry:    viper.GetDuration("presigned-urls-expiry"),
+		DatabaseURI:            viper.GetString("database-uri"),
+		DatabaseReset:          viper.GetBool("database-reset"),
+		DatabasePoolMax:        viper.GetInt("database-pool-max"),
+		DatabaseMigrate:        viper.GetBool("database-migrate"),
+		DatabaseSlowThreshold:  viper.GetDuration("database-slow-threshold"),
+		LiveUpdatesEnabled:     viper.GetBool("live-updates-enabled"),
+		RunLogOutputMax:        viper.GetInt("log-output-max"),
+		RunLogOutputRetain:     viper.GetDuration("log-output-retention"),
+		GCOlderThan:            viper.GetDuration("gc-older-than"),
+		AuditLogEnabled:        viper.GetBool("audit-log-enabled"),
+		AuditLogRetain:         viper.GetDuration("audit-log-retention"),
+		MetricIngest: MetricIngestConfig{
+			Async:         viper.GetBool("metric-ingest-async"),
+			Durability:    viper.GetString("metric-ingest-durability"),
+			Workers:       viper.GetInt("metric-ingest-workers"),
+			QueueSize:     viper.GetInt("metric-ingest-queue-size"),
+			QueueTimeout:  viper.GetDuration("metric-ingest-queue-timeout"),
+			BatchSize:     viper.GetInt("metric-ingest-batch-size"),
+			FlushInterval: viper.GetDuration("metric-ingest-flush-interval"),
+		},
+		Tracing: TracingConfig{
+			Enabled:     viper.GetBool("tracing-enabled"),
+			Endpoint:    viper.GetString("tracing-otlp-endpoint"),
+			Insecure:    viper.GetBool("tracing-otlp-insecure"),
+			SampleRatio: viper.GetFloat64("tracing-sample-ratio"),
+			ServiceName: viper.GetString("tracing-service-name"),
+		},
 	}
 }
 
@@ -80,35 +163,113 @@ func (c *Config) validateConfiguration() error {
 		return eris.Wrap(err, "error parsing 'default-artifact-root' flag")
 	}
 
-	if parsed.User != nil || parsed.RawQuery != "" || parsed.RawFragment != "" {
+	if !isArtifactRootFormatValid(parsed) {
 		return eris.New("incorrect format of 'default-artifact-root' flag")
 	}
 
-	if !slices.Contains([]string{"", "file", "s3", "gs"}, parsed.Scheme) {
-		return eris.New("unsupported schema of 'default-artifact-root' flag")
+	// 2. validate ArtifactsDestination configuration parameter. artifacts served by `mlflow-artifacts` API
+	// have to be stored somewhere, so it is required when DefaultArtifactRoot points to the proxy.
+	if c.ArtifactsDestination == "" && parsed.Scheme == "mlflow-artifacts" {
+		return eris.New("'artifacts-destination' flag is required when 'default-artifact-root' is mlflow-artifacts")
+	}
+	if c.ArtifactsDestination != "" {
+		parsed, err := url.Parse(c.ArtifactsDestination)
+		if err != nil {
+			return eris.Wrap(err, "error parsing 'artifacts-destination' flag")
+		}
+
+		if !isArtifactRootFormatValid(parsed) {
+			return eris.New("incorrect format of 'artifacts-destination' flag")
+		}
+
+		// supported schemes depend on registered artifact storage backends, so here
+		// only the proxy itself is rejected, the rest is checked by the storage factory.
+		if parsed.Scheme == "mlflow-artifacts" {
+			return eris.New("unsupported schema of 'artifacts-destination' flag")
+		}
 	}
 
 	if err := c.Auth.ValidateConfiguration(); err != nil {
 		return eris.Wrap(err, "error validating auth configuration")
 	}
 
+	if err := c.MetricIngest.validateConfiguration(); err != nil {
+		return eris.Wrap(err, "error validating metric ingestion configuration")
+	}
+
+	if err := c.Tracing.validateConfiguration(); err != nil {
+		return eris.Wrap(err, "error validating tracing configuration")
+	}
+
 	return nil
 }
 
+// validateConfiguration validates metric ingestion configuration. It is validated only
+// when asynchronous ingestion is enabled, otherwise metrics are written directly.
+func (c MetricIngestConfig) validateConfiguration() error {
+	if !c.Async {
+		return nil
+	}
+	if c.Durability != MetricIngestDurabilityFlush && c.Durability != MetricIngestDurabilityEnqueue {
+		return eris.Errorf(
+			"unsupported value of 'metric-ingest-durability' flag: '%s', it has to be '%s' or '%s'",
+			c.Durability, MetricIngestDurabilityFlush, MetricIngestDurabilityEnqueue,
+		)
+	}
+	if c.Workers <= 0 || c.QueueSize <= 0 || c.BatchSize <= 0 {
+		return eris.New("'metric-ingest-workers', 'metric-ingest-queue-size' and 'metric-ingest-batch-size' " +
+			"flags have to be positive")
+	}
+	if c.QueueTimeout < 0 || c.FlushInterval <= 0 {
+		return eris.New("'metric-ingest-flush-interval' flag has to be positive and " +
+			"'metric-ingest-queue-timeout' flag can't be negative")
+	}
+	return nil
+}
+
+// validateConfiguration validates tracing configuration, when tracing is enabled.
+func (c TracingConfig) validateConfiguration() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return eris.Errorf("'tracing-sample-ratio' flag has to be between 0 and 1, got: %v", c.SampleRatio)
+	}
+	if c.ServiceName == "" {
+		return eris.New("'tracing-service-name' flag can't be empty")
+	}
+	return nil
+}
+
+// isArtifactRootFormatValid checks that artifact root doesn't have credentials, query or fragment.
+// A user without a password is allowed, because some storages keep the container name
+// in place of the user, like `wasbs://container@account/path`.
+func isArtifactRootFormatValid(parsed *url.URL) bool {
+	if parsed.User != nil {
+		if _, hasPassword := parsed.User.Password(); hasPassword {
+			return false
+		}
+	}
+	return parsed.RawQuery == "" && parsed.RawFragment == ""
+}
+
 // normalizeConfiguration normalizes service configuration parameters.
 func (c *Config) normalizeConfiguration() error {
-	parsed, err := url.Parse(c.DefaultArtifactRoot)
+	defaultArtifactRoot, err := normalizeArtifactRoot(c.DefaultArtifactRoot)
 	if err != nil {
-		return eris.Wrap(err, "error parsing 'default-artifact-root' flag")
+		return eris.Wrap(err, "error normalizing 'default-artifact-root' flag")
 	}
-	switch parsed.Scheme {
-	case "", "file":
-		absoluteArtifactRoot, err := filepath.Abs(path.Join(parsed.Host, parsed.Path))
-		if err != nil {
-			return eris.Wrapf(err, "error getting absolute path for 'default-artifact-root': %s", c.DefaultArtifactRoot)
-		}
-		c.DefaultArtifactRoot = "file://" + absoluteArtifactRoot
+	c.DefaultArtifactRoot = defaultArtifactRoot
+
+	// by default artifacts served by `mlflow-artifacts` API are stored in the default artifact root.
+	if c.ArtifactsDestination == "" {
+		c.ArtifactsDestination = c.DefaultArtifactRoot
+	}
+	artifactsDestination, err := normalizeArtifactRoot(c.ArtifactsDestination)
+	if err != nil {
+		return eris.Wrap(err, "error normalizing 'artifacts-destination' flag")
 	}
+	c.ArtifactsDestination = artifactsDestination
 
 	if err := c.Auth.NormalizeConfiguration(); err != nil {
 		return eris.Wrap(err, "error normalizing auth configuration")
@@ -116,3 +277,20 @@ func (c *Config) normalizeConfiguration() error {
 
 	return nil
 }
+
+// normalizeArtifactRoot converts local artifact root into absolute `file://` URI.
+func normalizeArtifactRoot(artifactRoot string) (string, error) {
+	parsed, err := url.Parse(artifactRoot)
+	if err != nil {
+		return "", eris.Wrapf(err, "error parsing artifact root: %s", artifactRoot)
+	}
+	switch parsed.Scheme {
+	case "", "file":
+		absoluteArtifactRoot, err := filepath.Abs(path.Join(parsed.Host, parsed.Path))
+		if err != nil {
+			return "", eris.Wrapf(err, "error getting absolute path for artifact root: %s", artifactRoot)
+		}
+		return "file://" + absoluteArtifactRoot, nil
+	}
+	return artifactRoot, nil
+}
@@ -24,6 +24,15 @@ func TestConfig_Validate_Ok(t *testing.T) {
 				DefaultArtifactRoot: "s3://bucket_name",
 			},
 		},
+		{
+			name: "DefaultArtifactRootHasAzurePrefix",
+			providedConfig: &Config{
+				DefaultArtifactRoot: "wasbs://container@account.blob.core.windows.net/path",
+			},
+			expectedConfig: &Config{
+				DefaultArtifactRoot: "wasbs://container@account.blob.core.windows.net/path",
+			},
+		},
 		{
 			name: "DefaultArtifactRootHasFilePrefixAndIsRelative",
 			providedConfig: &Config{
@@ -78,6 +87,41 @@ func TestConfig_Validate_Ok(t *testing.T) {
 	}
 }
 
+func TestConfig_Validate_ArtifactsDestination_Ok(t *testing.T) {
+	testData := []struct {
+		name                         string
+		providedConfig               *Config
+		expectedArtifactsDestination string
+	}{
+		{
+			name: "ArtifactsDestinationIsEmpty",
+			providedConfig: &Config{
+				DefaultArtifactRoot: "s3://bucket_name",
+			},
+			expectedArtifactsDestination: "s3://bucket_name",
+		},
+		{
+			name: "ArtifactsDestinationIsRelative",
+			providedConfig: &Config{
+				DefaultArtifactRoot:  "mlflow-artifacts:/",
+				ArtifactsDestination: "path1/path2/path3",
+			},
+			expectedArtifactsDestination: (func() string {
+				path, err := filepath.Abs("path1/path2/path3")
+				require.Nil(t, err)
+				return "file://" + path
+			})(),
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Nil(t, tt.providedConfig.Validate())
+			assert.Equal(t, tt.expectedArtifactsDestination, tt.providedConfig.ArtifactsDestination)
+		})
+	}
+}
+
 func TestConfig_Validate_Error(t *testing.T) {
 	testData := []struct {
 		name   string
@@ -95,12 +139,61 @@ func TestConfig_Validate_Error(t *testing.T) {
 			},
 		},
 		{
-			name: "DefaultArtifactRootHasUnsupportedSchema",
+			name: "DefaultArtifactRootHasCredentials",
 			error: eris.New(
-				"error validating service configuration: unsupported schema of 'default-artifact-root' flag",
+				"error validating service configuration: incorrect format of 'default-artifact-root' flag",
 			),
 			config: &Config{
-				DefaultArtifactRoot: "unsupported://something",
+				DefaultArtifactRoot: "s3://user:password@bucket_name",
+			},
+		},
+		{
+			name: "ArtifactsDestinationIsMissing",
+			error: eris.New(
+				"error validating service configuration: " +
+					"'artifacts-destination' flag is required when 'default-artifact-root' is mlflow-artifacts",
+			),
+			config: &Config{
+				DefaultArtifactRoot: "mlflow-artifacts:/",
+			},
+		},
+		{
+			name: "ArtifactsDestinationHasUnsupportedSchema",
+			error: eris.New(
+				"error validating service configuration: unsupported schema of 'artifacts-destination' flag",
+			),
+			config: &Config{
+				DefaultArtifactRoot:  "s3://bucket_name",
+				ArtifactsDestination: "mlflow-artifacts:/",
+			},
+		},
+		{
+			name: "MetricIngestDurabilityIsUnsupported",
+			error: eris.New(
+				"error validating service configuration: error validating metric ingestion configuration: " +
+					"unsupported value of 'metric-ingest-durability' flag: 'never', it has to be 'flush' or 'enqueue'",
+			),
+			config: &Config{
+				DefaultArtifactRoot: "s3://bucket_name",
+				MetricIngest: MetricIngestConfig{
+					Async:      true,
+					Durability: "never",
+				},
+			},
+		},
+		{
+			name: "TracingSampleRatioIsOutOfRange",
+			error: eris.New(
+				"error validating service configuration: error validating tracing configuration: " +
+					"'tracing-sample-ratio' flag has to be between 0 and 1, got: 1.5",
+			),
+			config: &Config{
+				DefaultArtifactRoot: "s3://bucket_name",
+				Tracing: TracingConfig{
+					Enabled:     true,
+					SampleRatio: 1.5,
+					ServiceName: "fasttrackml",
+				},
 			},
 		},
 	}
@@ -0,0 +1,114 @@
+package dao
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/common/events"
+)
+
+// entityChannels contains tables, changes of which have to be published, and related event channels.
+// Only the tables, which have subscribers, are listed, as every published event costs a round trip.
+var entityChannels = map[string]string{
+	"roles":           events.RoleChannel,
+	"role_namespaces": events.RoleChannel,
+	"experiments":     events.ExperimentChannel,
+	"experiment_tags": events.ExperimentChannel,
+	"dashboards":      events.DashboardChannel,
+}
+
+// EntityEventsPlugin is a gorm plugin, which publishes events about created, updated and deleted entities.
+type EntityEventsPlugin struct {
+	eventListener EventListenerProvider
+}
+
+// NewEntityEventsPlugin creates new instance of gorm plugin, which publishes entity events.
+func NewEntityEventsPlugin(eventListener EventListenerProvider) *EntityEventsPlugin {
+	return &EntityEventsPlugin{
+		eventListener: eventListener,
+	}
+}
+
+// Name returns plugin name.
+func (p EntityEventsPlugin) Name() string {
+	return "fasttrackml:entity_events"
+}
+
+// Initialize registers plugin callbacks.
+func (p EntityEventsPlugin) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Create().After("gorm:after_create").Before(
+		"gorm:commit_or_rollback_transaction",
+	).Register("fasttrackml:publish_created", p.publish(events.EventActionCreated)); err != nil {
+		return eris.Wrap(err, "error registering create callback")
+	}
+	if err := db.Callback().Update().After("gorm:after_update").Before(
+		"gorm:commit_or_rollback_transaction",
+	).Register("fasttrackml:publish_updated", p.publish(events.EventActionUpdated)); err != nil {
+		return eris.Wrap(err, "error registering update callback")
+	}
+	if err := db.Callback().Delete().After("gorm:after_delete").Before(
+		"gorm:commit_or_rollback_transaction",
+	).Register("fasttrackml:publish_deleted", p.publish(events.EventActionDeleted)); err != nil {
+		return eris.Wrap(err, "error registering delete callback")
+	}
+	return nil
+}
+
+// publish returns callback, which publishes event about changed entities.
+func (p EntityEventsPlugin) publish(action events.EventAction) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Error != nil || db.RowsAffected == 0 || db.Statement.Schema == nil {
+			return
+		}
+		channel, ok := entityChannels[db.Statement.Table]
+		if !ok {
+			return
+		}
+
+		data, err := json.Marshal(events.EntityEvent{
+			Action: action,
+			IDs:    getPrimaryKeys(db),
+		})
+		if err != nil {
+			db.AddError(eris.Wrap(err, "error serializing EntityEvent event"))
+			return
+		}
+		if err := p.eventListener.Publish(
+			db.Session(&gorm.Session{NewDB: true}), channel, string(data),
+		); err != nil {
+			db.AddError(eris.Wrapf(err, "error publishing event for %s", db.Statement.Table))
+		}
+	}
+}
+
+// getPrimaryKeys returns primary keys of entities, which current statement is working with.
+func getPrimaryKeys(db *gorm.DB) []string {
+	field := db.Statement.Schema.PrioritizedPrimaryField
+	if field == nil {
+		return nil
+	}
+
+	var ids []string
+	collect := func(value reflect.Value) {
+		if value.Kind() != reflect.Struct {
+			return
+		}
+		if id, isZero := field.ValueOf(db.Statement.Context, value); !isZero {
+			ids = append(ids, fmt.Sprint(reflect.Indirect(reflect.ValueOf(id)).Interface()))
+		}
+	}
+
+	switch value := reflect.Indirect(db.Statement.ReflectValue); value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			collect(reflect.Indirect(value.Index(i)))
+		}
+	case reflect.Struct:
+		collect(value)
+	}
+	return ids
+}
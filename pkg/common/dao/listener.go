@@ -2,118 +2,229 @@ package dao
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/rotisserie/eris"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/common/events"
+)
+
+const (
+	// localQueueSize is a size of in-process event queue.
+	localQueueSize = 1000
+	// maxReconnectDelay is a maximum delay between attempts to restore database connection.
+	maxReconnectDelay = 30 * time.Second
+	// resetPayload is a payload of event, which is sent to subscribers when some events could be missed.
+	resetPayload = `{"action":"` + events.EventActionReset + `"}`
 )
 
 // EventListenerProvider provides an interface to work with database event listener.
 type EventListenerProvider interface {
 	// Listen listens for incoming database events.
 	Listen()
-	// Subscribe subscribe to particular channel.
-	Subscribe(subscriber chan<- string)
-	// GetChannelName returns channel name.
-	GetChannelName() string
+	// Subscribe subscribes to particular channel.
+	Subscribe(channel string, subscriber chan<- string)
+	// Publish publishes event into particular channel. For `postgres`, if db is in scope of
+	// transaction, then event will be delivered to subscribers only when transaction is committed.
+	Publish(db *gorm.DB, channel string, payload string) error
 }
 
-// EventListener represents database event listener.
+// notification represents single event delivered through in-process queue.
+type notification struct {
+	channel string
+	payload string
+}
+
+// EventListener represents database event listener. For `postgres` it relies on LISTEN/NOTIFY,
+// so events published by one instance are delivered to all the instances sharing the same database.
+// For other databases (`sqlite` and `mysql`) events are delivered in-process, so only a single
+// instance is supported there.
 type EventListener struct {
-	mu            sync.Mutex
+	mu            sync.RWMutex
 	ctx           context.Context
-	channel       string
+	db            *gorm.DB
+	channels      []string
+	conn          *sql.Conn
 	connection    *stdlib.Conn
+	queue         chan notification
 	subscriptions map[string][]chan<- string
 }
 
-// NewEventListener creates new database event listener.
-func NewEventListener(ctx context.Context, db *gorm.DB, channel string) (*EventListener, error) {
+// NewEventListener creates new database event listener for requested channels.
+func NewEventListener(ctx context.Context, db *gorm.DB, channels ...string) (*EventListener, error) {
 	eventListener := EventListener{
 		ctx:           ctx,
-		channel:       channel,
+		db:            db,
+		channels:      channels,
 		subscriptions: make(map[string][]chan<- string),
 	}
 
 	switch db.Dialector.Name() {
 	case "postgres":
-		sqlDB, err := db.DB()
-		if err != nil {
-			return nil, eris.Wrap(err, "error getting db instance")
-		}
-		driverConnection, err := sqlDB.Conn(ctx)
-		if err != nil {
-			return nil, eris.Wrap(err, "error getting database connection")
-		}
-
-		if err := driverConnection.Raw(func(driverConn any) error {
-			var ok bool
-			if eventListener.connection, ok = driverConn.(*stdlib.Conn); !ok {
-				return eris.New(
-					"error getting underlying driver connection. driver connection has no type *stdlib.Conn",
-				)
-			}
-			return nil
-		}); err != nil {
-			return nil, eris.Wrap(err, "error getting underlying driver connection")
-		}
-
-		if _, err := eventListener.connection.Conn().Exec(
-			ctx, fmt.Sprintf("listen %s", channel),
-		); err != nil {
-			return nil, eris.Wrapf(err, "error creating listener for %s channel", channel)
+		if err := eventListener.connect(); err != nil {
+			return nil, err
 		}
+	default:
+		eventListener.queue = make(chan notification, localQueueSize)
 	}
 
 	return &eventListener, nil
 }
 
-// NewNamespaceListener creates new database event listener for Namespace entity.
-func NewNamespaceListener(ctx context.Context, db *gorm.DB) (*EventListener, error) {
-	return NewEventListener(ctx, db, "namespace_update_events")
+// NewEventBus creates new database event listener for all the supported channels.
+func NewEventBus(ctx context.Context, db *gorm.DB) (*EventListener, error) {
+	return NewEventListener(ctx, db, events.Channels...)
 }
 
 // Listen listens for incoming database events.
 func (el *EventListener) Listen() {
-	// if listener not nil, then listen for incoming events from database.
-	// if listener is nil, then just return closed channel to do not do anything further.
-	if el.connection != nil {
-		go func() {
-			for {
-				select {
-				case <-el.ctx.Done():
-					log.Debugf("listener finished. exiting.")
-					return
-				default:
-					notification, err := el.connection.Conn().WaitForNotification(el.ctx)
-					if err != nil {
-						log.Errorf("error occurred while listening for the event: %+v", err)
-						return
-					}
-					for _, ch := range el.subscriptions[el.channel] {
-						ch <- notification.Payload
-					}
-				}
-			}
-		}()
+	if el.connection == nil {
+		go el.listenQueue()
+		return
 	}
+	go el.listenDatabase()
 }
 
-// Subscribe subscribe to particular channel.
-func (el *EventListener) Subscribe(subscriber chan<- string) {
+// Subscribe subscribes to particular channel.
+func (el *EventListener) Subscribe(channel string, subscriber chan<- string) {
 	el.mu.Lock()
 	defer el.mu.Unlock()
-	if _, ok := el.subscriptions[el.channel]; !ok {
-		el.subscriptions[el.channel] = []chan<- string{subscriber}
-	} else {
-		el.subscriptions[el.channel] = append(el.subscriptions[el.channel], subscriber)
+	el.subscriptions[channel] = append(el.subscriptions[channel], subscriber)
+}
+
+// Publish publishes event into particular channel.
+func (el *EventListener) Publish(db *gorm.DB, channel string, payload string) error {
+	if el.queue != nil {
+		select {
+		case el.queue <- notification{channel: channel, payload: payload}:
+			return nil
+		case <-el.ctx.Done():
+			return eris.Wrapf(el.ctx.Err(), "error publishing event into %s channel", channel)
+		}
+	}
+
+	if db == nil {
+		db = el.db
+	}
+	if err := db.Exec(`SELECT pg_notify(?, ?)`, channel, payload).Error; err != nil {
+		return eris.Wrap(err, "error triggering 'pg_notify'")
 	}
+	return nil
 }
 
-// GetChannelName returns current channel name.
-func (el *EventListener) GetChannelName() string {
-	return el.channel
+// listenQueue delivers events published in-process.
+func (el *EventListener) listenQueue() {
+	for {
+		select {
+		case <-el.ctx.Done():
+			log.Debugf("listener finished. exiting.")
+			return
+		case notification := <-el.queue:
+			el.dispatch(notification.channel, notification.payload)
+		}
+	}
+}
+
+// listenDatabase delivers events received from database and restores lost connection.
+func (el *EventListener) listenDatabase() {
+	for {
+		notification, err := el.connection.Conn().WaitForNotification(el.ctx)
+		if err != nil {
+			if el.ctx.Err() != nil {
+				log.Debugf("listener finished. exiting.")
+				return
+			}
+			log.Errorf("error occurred while listening for the event: %+v", err)
+			if !el.reconnect() {
+				return
+			}
+			// events sent while connection was lost are gone, so let subscribers know about it.
+			for _, channel := range el.channels {
+				el.dispatch(channel, resetPayload)
+			}
+			continue
+		}
+		el.dispatch(notification.Channel, notification.Payload)
+	}
+}
+
+// dispatch sends event payload to all the subscribers of particular channel.
+func (el *EventListener) dispatch(channel string, payload string) {
+	el.mu.RLock()
+	subscribers := el.subscriptions[channel]
+	el.mu.RUnlock()
+	for _, ch := range subscribers {
+		select {
+		case ch <- payload:
+		case <-el.ctx.Done():
+			return
+		}
+	}
+}
+
+// connect acquires dedicated database connection and starts listening for requested channels.
+func (el *EventListener) connect() error {
+	sqlDB, err := el.db.DB()
+	if err != nil {
+		return eris.Wrap(err, "error getting db instance")
+	}
+	conn, err := sqlDB.Conn(el.ctx)
+	if err != nil {
+		return eris.Wrap(err, "error getting database connection")
+	}
+
+	var connection *stdlib.Conn
+	if err := conn.Raw(func(driverConn any) error {
+		var ok bool
+		if connection, ok = driverConn.(*stdlib.Conn); !ok {
+			return eris.New(
+				"error getting underlying driver connection. driver connection has no type *stdlib.Conn",
+			)
+		}
+		return nil
+	}); err != nil {
+		_ = conn.Close()
+		return eris.Wrap(err, "error getting underlying driver connection")
+	}
+
+	for _, channel := range el.channels {
+		if _, err := connection.Conn().Exec(
+			el.ctx, fmt.Sprintf("listen %s", pgx.Identifier{channel}.Sanitize()),
+		); err != nil {
+			_ = conn.Close()
+			return eris.Wrapf(err, "error creating listener for %s channel", channel)
+		}
+	}
+
+	el.conn, el.connection = conn, connection
+	return nil
+}
+
+// reconnect tries to restore database connection until it succeeds or listener is stopped.
+func (el *EventListener) reconnect() bool {
+	if el.conn != nil {
+		_ = el.conn.Close()
+	}
+	delay := time.Second
+	for {
+		select {
+		case <-el.ctx.Done():
+			return false
+		case <-time.After(delay):
+		}
+		if err := el.connect(); err != nil {
+			log.Errorf("error restoring event listener connection: %+v", err)
+			delay = min(2*delay, maxReconnectDelay)
+			continue
+		}
+		log.Info("event listener connection restored")
+		return true
+	}
 }
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/rotisserie/eris"
+)
+
+// NamespaceRole represents level of access to the namespace.
+type NamespaceRole string
+
+// Supported namespace roles. Each next role includes permissions of the previous one:
+// viewers can only read data, editors can also create and modify data, owners can also delete it.
+const (
+	NamespaceRoleViewer NamespaceRole = "viewer"
+	NamespaceRoleEditor NamespaceRole = "editor"
+	NamespaceRoleOwner  NamespaceRole = "owner"
+)
+
+// namespaceRoleLevels keeps ordering of namespace roles.
+var namespaceRoleLevels = map[NamespaceRole]int{
+	NamespaceRoleViewer: 1,
+	NamespaceRoleEditor: 2,
+	NamespaceRoleOwner:  3,
+}
+
+// IsValid makes check that namespace role is one of the supported roles.
+func (r NamespaceRole) IsValid() bool {
+	_, ok := namespaceRoleLevels[r]
+	return ok
+}
+
+// Includes makes check that current namespace role grants permissions of the required role.
+func (r NamespaceRole) Includes(required NamespaceRole) bool {
+	return r.IsValid() && namespaceRoleLevels[r] >= namespaceRoleLevels[required]
+}
+
+// Max returns the most permissive role out of the current and the given one.
+func (r NamespaceRole) Max(other NamespaceRole) NamespaceRole {
+	if namespaceRoleLevels[other] > namespaceRoleLevels[r] {
+		return other
+	}
+	return r
+}
+
+// ParseNamespaceRole parses user role in `ns:<code>` or `ns:<code>:<role>` format and returns
+// namespace code and namespace role. Role without explicit namespace role grants owner permissions.
+func ParseNamespaceRole(role string) (string, NamespaceRole, error) {
+	code, ok := strings.CutPrefix(role, "ns:")
+	if !ok || code == "" {
+		return "", "", eris.Errorf("role '%s' is not a namespace role", role)
+	}
+	code, namespaceRole, ok := strings.Cut(code, ":")
+	if !ok {
+		return code, NamespaceRoleOwner, nil
+	}
+	if code == "" || !NamespaceRole(namespaceRole).IsValid() {
+		return "", "", eris.Errorf(
+			"invalid namespace role '%s'. Valid values are ['viewer', 'editor', 'owner']", namespaceRole,
+		)
+	}
+	return code, NamespaceRole(namespaceRole), nil
+}
@@ -5,6 +5,36 @@ import "fmt"
 // BasicAuthToken represents object to store auth information related to Basic Auth.
 type BasicAuthToken struct {
 	roles map[string]struct{}
+	// namespaceRoles keeps namespace codes, which user has access to, and level of that access.
+	namespaceRoles map[string]NamespaceRole
+}
+
+// NewBasicAuthToken creates BasicAuthToken from the user roles.
+func NewBasicAuthToken(roles map[string]struct{}) *BasicAuthToken {
+	namespaceRoles := make(map[string]NamespaceRole, len(roles))
+	for role := range roles {
+		code, namespaceRole, err := ParseNamespaceRole(role)
+		if err != nil {
+			continue
+		}
+		namespaceRoles[code] = namespaceRoles[code].Max(namespaceRole)
+	}
+	return &BasicAuthToken{
+		roles:          roles,
+		namespaceRoles: namespaceRoles,
+	}
+}
+
+// NewScopedBasicAuthToken creates BasicAuthToken, which has access only to the given namespaces.
+func NewScopedBasicAuthToken(namespaceRoles map[string]NamespaceRole) *BasicAuthToken {
+	roles := make(map[string]struct{}, len(namespaceRoles))
+	for namespace, namespaceRole := range namespaceRoles {
+		roles[fmt.Sprintf("ns:%s:%s", namespace, namespaceRole)] = struct{}{}
+	}
+	return &BasicAuthToken{
+		roles:          roles,
+		namespaceRoles: namespaceRoles,
+	}
 }
 
 // HasAdminAccess makes check that user has admin permissions to access to the requested resource.
@@ -17,10 +47,16 @@ func (p BasicAuthToken) HasAdminAccess() bool {
 
 // HasUserAccess makes check that user has permission to access to the requested namespace.
 func (p BasicAuthToken) HasUserAccess(namespace string) bool {
-	if _, ok := p.roles[fmt.Sprintf("ns:%s", namespace)]; !ok {
-		return ok
+	_, ok := p.namespaceRoles[namespace]
+	return ok
+}
+
+// HasNamespaceRole makes check that user has at least the required role in the requested namespace.
+func (p BasicAuthToken) HasNamespaceRole(namespace string, required NamespaceRole) bool {
+	if p.HasAdminAccess() {
+		return true
 	}
-	return true
+	return p.namespaceRoles[namespace].Includes(required)
 }
 
 // GetRoles returns User roles assigned to current Auth token.
@@ -56,7 +92,5 @@ func (p UserPermissions) ValidateAuthToken(authToken string) *BasicAuthToken {
 		return nil
 	}
 
-	return &BasicAuthToken{
-		roles: roles,
-	}
+	return NewBasicAuthToken(roles)
 }
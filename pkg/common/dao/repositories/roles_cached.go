@@ -3,7 +3,6 @@ package repositories
 import (
 	"context"
 	"encoding/json"
-	"slices"
 
 	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/rotisserie/eris"
@@ -12,46 +11,53 @@ import (
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common/dao"
+	commonModels "github.com/G-Research/fasttrackml/pkg/common/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common/events"
 )
 
 // RoleRepositoryProvider provides an interface to work with `role` entity.
 type RoleRepositoryProvider interface {
-	// ValidateRolesAccessToNamespace makes validation that requested roles has access to requested namespace.
-	ValidateRolesAccessToNamespace(ctx context.Context, roles []string, namespaceCode string) (bool, error)
+	// GetNamespaceRoleByRoles returns the most permissive namespace role, which requested roles
+	// have in requested namespace. Empty namespace role is returned if roles have no access to namespace.
+	GetNamespaceRoleByRoles(
+		ctx context.Context, roles []string, namespaceCode string,
+	) (commonModels.NamespaceRole, error)
 }
 
 // RoleCachedRepository cached repository to work with `role` entity.
 type RoleCachedRepository struct {
-	db                     *gorm.DB
-	cache                  *lru.Cache[string, []string]
-	namespaceEventListener dao.EventListenerProvider
+	db            *gorm.DB
+	cache         *lru.Cache[string, map[string]commonModels.NamespaceRole]
+	eventListener dao.EventListenerProvider
 }
 
 // NewRoleCachedRepository creates a new instance of cached repository to work with `role` entity.
 func NewRoleCachedRepository(
-	ctx context.Context, db *gorm.DB, namespaceEventListener dao.EventListenerProvider,
+	ctx context.Context, db *gorm.DB, eventListener dao.EventListenerProvider,
 ) (*RoleCachedRepository, error) {
-	cache, err := lru.New[string, []string](1000)
+	cache, err := lru.New[string, map[string]commonModels.NamespaceRole](1000)
 	if err != nil {
 		return nil, eris.Wrap(err, "error creating lru cache for roles entities")
 	}
 
 	repository := RoleCachedRepository{
-		db:                     db,
-		cache:                  cache,
-		namespaceEventListener: namespaceEventListener,
+		db:            db,
+		cache:         cache,
+		eventListener: eventListener,
 	}
 
-	ch := make(chan string)
+	namespaceEvents, roleEvents := make(chan string), make(chan string)
 	go func() {
-		defer close(ch)
 		for {
 			select {
 			case <-ctx.Done():
 				return
-			case data := <-ch:
-				if err := repository.processEvent(data); err != nil {
+			case data := <-namespaceEvents:
+				if err := repository.processNamespaceEvent(data); err != nil {
+					log.Errorf(`error processing incoming event: %s, error: %+v`, data, err)
+				}
+			case data := <-roleEvents:
+				if err := repository.processRoleEvent(data); err != nil {
 					log.Errorf(`error processing incoming event: %s, error: %+v`, data, err)
 				}
 			}
@@ -59,24 +65,21 @@ func NewRoleCachedRepository(
 	}()
 
 	// subscribe to incoming events.
-	namespaceEventListener.Subscribe(ch)
+	eventListener.Subscribe(events.NamespaceChannel, namespaceEvents)
+	eventListener.Subscribe(events.RoleChannel, roleEvents)
 
 	return &repository, nil
 }
 
-// ValidateRolesAccessToNamespace makes validation that requested roles have access to requested namespace.
-func (r RoleCachedRepository) ValidateRolesAccessToNamespace(
+// GetNamespaceRoleByRoles returns the most permissive namespace role, which requested roles
+// have in requested namespace.
+func (r RoleCachedRepository) GetNamespaceRoleByRoles(
 	ctx context.Context, requestedRoles []string, requestedNamespaceCode string,
-) (bool, error) {
+) (commonModels.NamespaceRole, error) {
 	// if namespace already exists in cache, check permissions immediately.
 	namespaceRoles, ok := r.cache.Get(requestedNamespaceCode)
 	if ok {
-		for _, requestedRole := range requestedRoles {
-			if slices.Contains(namespaceRoles, requestedRole) {
-				return true, nil
-			}
-		}
-		return false, nil
+		return getNamespaceRole(namespaceRoles, requestedRoles), nil
 	}
 
 	// otherwise, check database and store result in cache.
@@ -94,38 +97,60 @@ func (r RoleCachedRepository) ValidateRolesAccessToNamespace(
 			&models.Namespace{Code: requestedNamespaceCode},
 		),
 	).Find(&data).Error; err != nil {
-		return false, eris.Wrapf(err, "error getting roles for namespace with code: %s", requestedNamespaceCode)
+		return "", eris.Wrapf(err, "error getting roles for namespace with code: %s", requestedNamespaceCode)
 	}
 
-	namespaceRoles = make([]string, len(data))
-	for i, namespaceRole := range data {
-		namespaceRoles[i] = namespaceRole.Role.Name
+	namespaceRoles = make(map[string]commonModels.NamespaceRole, len(data))
+	for _, namespaceRole := range data {
+		namespaceRoles[namespaceRole.Role.Name] = commonModels.NamespaceRole(namespaceRole.Level)
 	}
 
 	// save into cache.
 	r.cache.Add(requestedNamespaceCode, namespaceRoles)
 
 	// check permissions from a database.
+	return getNamespaceRole(namespaceRoles, requestedRoles), nil
+}
+
+// getNamespaceRole returns the most permissive namespace role out of requested roles.
+func getNamespaceRole(
+	namespaceRoles map[string]commonModels.NamespaceRole, requestedRoles []string,
+) commonModels.NamespaceRole {
+	var result commonModels.NamespaceRole
 	for _, requestedRole := range requestedRoles {
-		if slices.Contains(namespaceRoles, requestedRole) {
-			return true, nil
+		if namespaceRole, ok := namespaceRoles[requestedRole]; ok && namespaceRole.IsValid() {
+			result = result.Max(namespaceRole)
 		}
 	}
-
-	return false, nil
+	return result
 }
 
-// processEvent process incoming event from database.
-func (r RoleCachedRepository) processEvent(data string) error {
+// processNamespaceEvent process incoming namespace event from database.
+func (r RoleCachedRepository) processNamespaceEvent(data string) error {
 	log.Debugf("got incoming namespace event: %s", data)
 	event := events.NamespaceEvent{}
 	if err := json.Unmarshal([]byte(data), &event); err != nil {
 		return eris.Wrap(err, "error unmarshaling incoming database event")
 	}
 	switch event.Action {
-	case events.NamespaceEventActionDeleted:
+	case events.EventActionDeleted:
 		r.cache.Remove(event.Namespace.Code)
+	case events.EventActionReset:
+		r.cache.Purge()
 	}
 	log.Debugf("namespace keys in local cache: %+v", r.cache.Keys())
 	return nil
 }
+
+// processRoleEvent process incoming role event from database.
+func (r RoleCachedRepository) processRoleEvent(data string) error {
+	log.Debugf("got incoming role event: %s", data)
+	event := events.EntityEvent{}
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		return eris.Wrap(err, "error unmarshaling incoming database event")
+	}
+	// cache is grouped by namespace, so any change of roles or
+	// their namespace relations invalidates the whole cache.
+	r.cache.Purge()
+	return nil
+}
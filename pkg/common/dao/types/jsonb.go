@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 
+	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 	"gorm.io/gorm/schema"
@@ -71,6 +72,16 @@ func (JSONB) GormDataType() string {
 
 // GormDBDataType gorm db data type
 func (JSONB) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	switch db.Dialector.Name() {
+	case mysql.Dialector{}.Name():
+		// MySQL can't index JSON and TEXT columns, so indexed values are stored as bounded strings.
+		_, isIndex := field.TagSettings["INDEX"]
+		_, isUnique := field.TagSettings["UNIQUE"]
+		if isIndex || isUnique {
+			return "varchar(768)"
+		}
+		return "longtext"
+	}
 	return "JSONB"
 }
 
@@ -0,0 +1,40 @@
+package events
+
+// EventAction represents Event action.
+type EventAction string
+
+// Supported event actions.
+const (
+	EventActionFetched = "fetched"
+	EventActionCreated = "created"
+	EventActionDeleted = "deleted"
+	EventActionUpdated = "updated"
+	// EventActionReset tells subscribers that some events could be missed,
+	// so everything derived from previous events has to be dropped.
+	EventActionReset = "reset"
+)
+
+// Supported event channels.
+const (
+	NamespaceChannel  = "namespace_update_events"
+	RoleChannel       = "role_update_events"
+	ExperimentChannel = "experiment_update_events"
+	DashboardChannel  = "dashboard_update_events"
+	RunChannel        = "run_update_events"
+)
+
+// Channels contains all the supported event channels.
+var Channels = []string{
+	NamespaceChannel,
+	RoleChannel,
+	ExperimentChannel,
+	DashboardChannel,
+	RunChannel,
+}
+
+// EntityEvent represents database event about changed entities.
+// Empty IDs means that changed entities are unknown, so all of them have to be considered as changed.
+type EntityEvent struct {
+	Action EventAction `json:"action"`
+	IDs    []string    `json:"ids,omitempty"`
+}
@@ -0,0 +1,38 @@
+package events
+
+import "encoding/json"
+
+// RunEventType represents type of the run live update.
+type RunEventType string
+
+// Supported run event types.
+const (
+	RunEventTypeMetrics RunEventType = "metrics"
+	RunEventTypeStatus  RunEventType = "status"
+	RunEventTypeLogs    RunEventType = "logs"
+)
+
+// RunEvent represents live update of the run, like new metric points, status change or log lines.
+// Truncated means that event was too big to be delivered, so the data has to be fetched from API.
+type RunEvent struct {
+	Action      EventAction     `json:"action"`
+	Type        RunEventType    `json:"type,omitempty"`
+	NamespaceID uint            `json:"namespace_id,omitempty"`
+	RunID       string          `json:"run_id,omitempty"`
+	Status      string          `json:"status,omitempty"`
+	EndTime     int64           `json:"end_time,omitempty"`
+	Metrics     []RunMetricData `json:"metrics,omitempty"`
+	Logs        []string        `json:"logs,omitempty"`
+	Truncated   bool            `json:"truncated,omitempty"`
+}
+
+// RunMetricData represents single metric point of RunEvent.
+type RunMetricData struct {
+	Key       string          `json:"key"`
+	Value     float64         `json:"value"`
+	IsNan     bool            `json:"is_nan,omitempty"`
+	Timestamp int64           `json:"timestamp"`
+	Step      int64           `json:"step"`
+	Iter      int64           `json:"iter"`
+	Context   json.RawMessage `json:"context,omitempty"`
+}
@@ -0,0 +1,163 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+)
+
+const (
+	actorContextKey = "actor"
+	// usernameContextKey is a key used by fiber basicauth middleware to store the username.
+	usernameContextKey = "username"
+	// AnonymousActor is an actor of the requests made without authentication.
+	AnonymousActor = "anonymous"
+	// maxAuditEntityIDs is a maximum number of entity IDs recorded for a single request.
+	maxAuditEntityIDs = 1000
+)
+
+// auditEntityKeys is a list of request body fields, which identify affected entities.
+var auditEntityKeys = []string{
+	"id", "ids", "experiment_id", "experiment_ids", "run_id", "run_ids", "run_uuid", "name", "code",
+}
+
+// NewAuditMiddleware creates new middleware, which records every mutating request
+// of Mlflow, Aim and Admin API into the audit log.
+func NewAuditMiddleware(auditLogRepository repositories.AuditLogRepositoryProvider) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		path := ctx.Path()
+		isAdminRequest := AdminPrefixRegexp.MatchString(path)
+		if IsReadOnlyRequest(ctx) || (!isAdminRequest && !MlflowAimPrefixRegexp.MatchString(path)) {
+			return ctx.Next()
+		}
+
+		auditLog := models.AuditLog{
+			Timestamp: time.Now().UnixMilli(),
+			Method:    ctx.Method(),
+			Path:      truncate(strings.Clone(path), 1024),
+		}
+		if !isAdminRequest {
+			if namespace, err := GetNamespaceFromContext(ctx.Context()); err == nil {
+				auditLog.NamespaceID = &namespace.ID
+			}
+		}
+		bodyIDs := getBodyEntityIDs(ctx)
+
+		//nolint:errcheck
+		nextWithRenderedError(ctx)
+
+		// actor and route params are known only after auth middleware and router did their job.
+		auditLog.Actor = truncate(GetActorFromContext(ctx), 256)
+		auditLog.EntityIDs = strings.Join(append(getParamsEntityIDs(ctx), bodyIDs...), ",")
+		auditLog.StatusCode = ctx.Response().StatusCode()
+		if err := auditLogRepository.Create(ctx.Context(), &auditLog); err != nil {
+			log.Errorf("error recording audit log for %s %s: %+v", auditLog.Method, auditLog.Path, err)
+		}
+		return nil
+	}
+}
+
+// GetActorFromContext returns identity of the user, who made the request.
+func GetActorFromContext(ctx *fiber.Ctx) string {
+	if actor, ok := ctx.Locals(actorContextKey).(string); ok && actor != "" {
+		return actor
+	}
+	if username, ok := ctx.Locals(usernameContextKey).(string); ok && username != "" {
+		return username
+	}
+	return AnonymousActor
+}
+
+// setActor stores identity of the user, who made the request.
+func setActor(ctx *fiber.Ctx, actor string) {
+	if actor != "" {
+		ctx.Locals(actorContextKey, actor)
+	}
+}
+
+// getParamsEntityIDs returns entity IDs passed as route params.
+func getParamsEntityIDs(ctx *fiber.Ctx) []string {
+	var ids []string
+	for _, name := range ctx.Route().Params {
+		if value := ctx.Params(name); value != "" {
+			ids = append(ids, strings.Clone(value))
+		}
+	}
+	return ids
+}
+
+// getBodyEntityIDs returns entity IDs passed in JSON or form request body.
+func getBodyEntityIDs(ctx *fiber.Ctx) []string {
+	// streamed bodies (e.g. artifact uploads) are consumed by the route handler.
+	if ctx.Request().IsBodyStream() {
+		return nil
+	}
+
+	var ids []string
+	if bytes.HasPrefix(ctx.Request().Header.ContentType(), []byte(fiber.MIMEApplicationForm)) {
+		for _, key := range auditEntityKeys {
+			if value := ctx.FormValue(key); value != "" {
+				ids = append(ids, strings.Clone(value))
+			}
+		}
+		return ids
+	}
+
+	body := bytes.TrimSpace(ctx.Body())
+	switch {
+	case bytes.HasPrefix(body, []byte("[")):
+		// Aim batch endpoints accept plain list of run IDs.
+		var values []any
+		if err := json.Unmarshal(body, &values); err == nil {
+			ids = appendEntityIDs(ids, values)
+		}
+	case bytes.HasPrefix(body, []byte("{")):
+		var values map[string]any
+		if err := json.Unmarshal(body, &values); err == nil {
+			for _, key := range auditEntityKeys {
+				if value, ok := values[key]; ok {
+					ids = appendEntityIDs(ids, value)
+				}
+			}
+		}
+	}
+	return ids
+}
+
+// appendEntityIDs appends scalar value or list of scalar values to the list of entity IDs.
+func appendEntityIDs(ids []string, value any) []string {
+	switch value := value.(type) {
+	case string:
+		if value != "" && len(ids) < maxAuditEntityIDs {
+			ids = append(ids, value)
+		}
+	case float64:
+		if len(ids) < maxAuditEntityIDs {
+			ids = append(ids, strconv.FormatFloat(value, 'f', -1, 64))
+		}
+	case []any:
+		for _, item := range value {
+			switch item.(type) {
+			case string, float64:
+				ids = appendEntityIDs(ids, item)
+			}
+		}
+	}
+	return ids
+}
+
+// truncate truncates value to the provided length.
+func truncate(value string, length int) string {
+	if len(value) > length {
+		return value[:length]
+	}
+	return value
+}
@@ -2,12 +2,18 @@ package middleware
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/rotisserie/eris"
 	log "github.com/sirupsen/logrus"
 
+	mlflowModels "github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
 )
@@ -19,20 +25,33 @@ const (
 
 // BasicAuthMiddleware represents Basic Auth middleware.
 type BasicAuthMiddleware struct {
-	userPermissions *models.UserPermissions
+	userPermissions    *models.UserPermissions
+	apiTokenRepository repositories.APITokenRepositoryProvider
 }
 
 // NewBasicAuthMiddleware creates new Basic Auth middleware logic.
-func NewBasicAuthMiddleware(userPermissions *models.UserPermissions) fiber.Handler {
+func NewBasicAuthMiddleware(
+	userPermissions *models.UserPermissions, apiTokenRepository repositories.APITokenRepositoryProvider,
+) fiber.Handler {
 	return BasicAuthMiddleware{
-		userPermissions: userPermissions,
+		userPermissions:    userPermissions,
+		apiTokenRepository: apiTokenRepository,
 	}.Handle()
 }
 
 // Handle handles OIDC middleware logic.
 func (m BasicAuthMiddleware) Handle() fiber.Handler {
 	return func(ctx *fiber.Ctx) (err error) {
-		authToken := m.userPermissions.ValidateAuthToken(ctx.Get(fiber.HeaderAuthorization)[6:])
+		authToken, actor, err := m.getAuthToken(ctx)
+		if err != nil {
+			log.Errorf("error validating auth token: %+v", err)
+			return ctx.Status(
+				http.StatusInternalServerError,
+			).JSON(
+				api.NewInternalError("error validating auth token"),
+			)
+		}
+		setActor(ctx, actor)
 		switch {
 		case AdminPrefixRegexp.MatchString(ctx.Path()):
 			return m.handleAdminResourceRequest(ctx, authToken)
@@ -95,9 +114,75 @@ func (m BasicAuthMiddleware) handleAimMlflowResourceRequest(ctx *fiber.Ctx, auth
 			api.NewResourceDoesNotExistError("unable to find namespace with code: %s", namespace.Code),
 		)
 	}
+	if requiredRole := GetRequiredNamespaceRole(ctx); !authToken.HasNamespaceRole(namespace.Code, requiredRole) {
+		return ctx.Status(
+			http.StatusForbidden,
+		).JSON(
+			api.NewPermissionDeniedError(
+				"%s role is required to perform request in namespace with code: %s", requiredRole, namespace.Code,
+			),
+		)
+	}
 	return ctx.Next()
 }
 
+// getAuthToken returns auth token from the `Authorization` header together with the actor, who made
+// the request. Users from configuration file are passed as Basic Auth credentials, API tokens are passed
+// either as a Bearer token or as a password of Basic Auth credentials, so any username could be used in this case.
+func (m BasicAuthMiddleware) getAuthToken(ctx *fiber.Ctx) (*models.BasicAuthToken, string, error) {
+	scheme, credentials, _ := strings.Cut(ctx.Get(fiber.HeaderAuthorization), " ")
+	switch strings.ToLower(scheme) {
+	case "basic":
+		decoded, err := base64.StdEncoding.DecodeString(credentials)
+		if err != nil {
+			return nil, "", nil
+		}
+		username, password, ok := strings.Cut(string(decoded), ":")
+		if authToken := m.userPermissions.ValidateAuthToken(credentials); authToken != nil {
+			return authToken, username, nil
+		}
+		if !ok {
+			return nil, "", nil
+		}
+		return m.validateAPIToken(ctx.Context(), password)
+	case "bearer":
+		return m.validateAPIToken(ctx.Context(), credentials)
+	}
+	return nil, "", nil
+}
+
+// validateAPIToken validates API token against the database and returns auth token with its permissions
+// together with the actor, which identifies the API token.
+func (m BasicAuthMiddleware) validateAPIToken(
+	ctx context.Context, token string,
+) (*models.BasicAuthToken, string, error) {
+	if token == "" {
+		return nil, "", nil
+	}
+	apiToken, err := m.apiTokenRepository.GetByTokenHash(ctx, mlflowModels.HashAPIToken(token))
+	if err != nil {
+		return nil, "", eris.Wrap(err, "error getting api token")
+	}
+	if apiToken == nil || apiToken.IsExpired(time.Now()) {
+		return nil, "", nil
+	}
+
+	// read scope gives viewer permissions, write scope gives full access to the namespace.
+	namespaceRoles := make(map[string]models.NamespaceRole, len(apiToken.Permissions))
+	for _, permission := range apiToken.Permissions {
+		// namespace could be already deleted.
+		if permission.Namespace.Code == "" {
+			continue
+		}
+		namespaceRoles[permission.Namespace.Code] = models.NamespaceRoleViewer
+		if permission.Scope == mlflowModels.APITokenScopeWrite {
+			namespaceRoles[permission.Namespace.Code] = models.NamespaceRoleOwner
+		}
+	}
+	actor := fmt.Sprintf("token:%s (%s)", apiToken.Name, apiToken.ID)
+	return models.NewScopedBasicAuthToken(namespaceRoles), actor, nil
+}
+
 // GetBasicAuthTokenFromContext returns Basic Auth Token from the context.
 func GetBasicAuthTokenFromContext(ctx context.Context) (*models.BasicAuthToken, error) {
 	authToken, ok := ctx.Value(basicAuthTokenContextKey).(*models.BasicAuthToken)
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	streamingReadTimeoutKey = "streaming_read_timeout"
+)
+
+// BodyLimitConfig represents configuration of the body limit middleware.
+type BodyLimitConfig struct {
+	// Limit is the maximum size of the request body, which is read into memory.
+	Limit int
+	// Streaming reports whether the route handler streams the request body on its own.
+	Streaming func(ctx *fiber.Ctx) bool
+	// StreamingReadTimeout is the maximum time to wait for the next part of the streamed request body.
+	StreamingReadTimeout time.Duration
+}
+
+// NewBodyLimitMiddleware creates new middleware, which limits the size of the request bodies. The server streams
+// the bodies, which exceed the limit, so they are read into memory here for all the routes, except the streaming
+// ones. Streaming routes aren't bound by the server read timeout either, as large uploads take longer than that,
+// instead the read deadline is extended by StreamingReadTimeout every time the body is read.
+func NewBodyLimitMiddleware(config BodyLimitConfig) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if config.Streaming != nil && config.Streaming(ctx) {
+			if err := ctx.Context().Conn().SetReadDeadline(
+				time.Now().Add(config.StreamingReadTimeout),
+			); err != nil {
+				return fiber.NewError(fiber.StatusInternalServerError, "error setting read deadline")
+			}
+			ctx.Locals(streamingReadTimeoutKey, config.StreamingReadTimeout)
+			return ctx.Next()
+		}
+
+		if stream := ctx.Request().BodyStream(); stream != nil {
+			body, err := io.ReadAll(io.LimitReader(stream, int64(config.Limit)+1))
+			if err != nil {
+				return fiber.NewError(fiber.StatusBadRequest, "error reading request body")
+			}
+			if len(body) > config.Limit {
+				ctx.Context().SetConnectionClose()
+				return fiber.ErrRequestEntityTooLarge
+			}
+			ctx.Request().SetBody(body)
+		}
+		return ctx.Next()
+	}
+}
+
+// GetRequestBodyReader returns reader of the request body, which doesn't buffer streamed bodies into memory.
+func GetRequestBodyReader(ctx *fiber.Ctx) io.Reader {
+	if stream := ctx.Request().BodyStream(); stream != nil {
+		if timeout, ok := ctx.Locals(streamingReadTimeoutKey).(time.Duration); ok {
+			return &deadlineReader{reader: stream, conn: ctx.Context().Conn(), timeout: timeout}
+		}
+		return stream
+	}
+	return bytes.NewReader(ctx.Body())
+}
+
+// deadlineReader extends read deadline of the connection before every read, so the streamed body fails
+// only, when the client stops sending it, but not when it takes long to send.
+type deadlineReader struct {
+	reader  io.Reader
+	conn    net.Conn
+	timeout time.Duration
+}
+
+// Read implements io.Reader interface.
+func (r *deadlineReader) Read(p []byte) (int, error) {
+	if err := r.conn.SetReadDeadline(time.Now().Add(r.timeout)); err != nil {
+		return 0, err
+	}
+	return r.reader.Read(p)
+}
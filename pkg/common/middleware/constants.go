@@ -1,6 +1,13 @@
 package middleware
 
-import "regexp"
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G-Research/fasttrackml/pkg/common/dao/models"
+)
 
 // regexps to detect requested API.
 var (
@@ -8,3 +15,50 @@ var (
 	ChooserPrefixRegexp   = regexp.MustCompile(`^/chooser|^/$`)
 	MlflowAimPrefixRegexp = regexp.MustCompile(`^/aim/api|^/ajax-api/2.0/mlflow|^/api/2.0/mlflow`)
 )
+
+// ReadOnlyPostRouteRegexp matches `POST` routes of Aim and Mlflow API, which only read data.
+var ReadOnlyPostRouteRegexp = regexp.MustCompile(
+	`^(/ajax-api|/api)/2.0/mlflow/(runs/search|experiments/search|metrics/get-histories|` +
+		`registered-models/get-latest-versions)/?$|` +
+		`^/aim/api/runs/(compare|group|search/metric|search/metric/align|search/(images|texts|audios|distributions|figures)|` +
+		`(images|audios|figures)/get-batch|[^/]+/(metric|images|texts|audios|distributions|figures)/get-batch)/?$`,
+)
+
+// DeletePostRouteRegexp matches `POST` routes of Aim and Mlflow API, which delete data.
+var DeletePostRouteRegexp = regexp.MustCompile(
+	`^(/ajax-api|/api)/2.0/mlflow/(experiments|runs|registered-models|model-versions)/delete/?$|` +
+		`^/aim/api/runs/delete-batch/?$`,
+)
+
+// IsReadOnlyRequest makes check that request doesn't modify any data.
+func IsReadOnlyRequest(ctx *fiber.Ctx) bool {
+	switch ctx.Method() {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		return ReadOnlyPostRouteRegexp.MatchString(ctx.Path())
+	}
+	return false
+}
+
+// IsDeleteRequest makes check that request deletes data.
+func IsDeleteRequest(ctx *fiber.Ctx) bool {
+	switch ctx.Method() {
+	case http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return DeletePostRouteRegexp.MatchString(ctx.Path())
+	}
+	return false
+}
+
+// GetRequiredNamespaceRole returns the minimal namespace role, which is required to perform the request.
+func GetRequiredNamespaceRole(ctx *fiber.Ctx) models.NamespaceRole {
+	switch {
+	case IsReadOnlyRequest(ctx):
+		return models.NamespaceRoleViewer
+	case IsDeleteRequest(ctx):
+		return models.NamespaceRoleOwner
+	}
+	return models.NamespaceRoleEditor
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// nextWithRenderedError calls the next handler and renders its error right away, so the actual status code
+// of the response is known to the middleware afterwards. The original error is returned for reference only,
+// it has been handled already and must not be returned to the upstream handlers.
+func nextWithRenderedError(ctx *fiber.Ctx) error {
+	err := ctx.Next()
+	if err != nil {
+		if err := ctx.App().ErrorHandler(ctx, err); err != nil {
+			_ = ctx.SendStatus(http.StatusInternalServerError)
+		}
+	}
+	return err
+}
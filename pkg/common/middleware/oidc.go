@@ -66,6 +66,7 @@ func (m OIDCMiddleware) handleAdminResourceRequest(ctx *fiber.Ctx) error {
 	}
 
 	log.Debugf("user has roles: %v associated", user.GetRoles())
+	setActor(ctx, user.GetSubject())
 	if !user.IsAdmin() {
 		return ctx.Redirect("/errors/not-found", http.StatusMovedPermanently)
 	}
@@ -86,6 +87,7 @@ func (m OIDCMiddleware) handleChooserResourceRequest(ctx *fiber.Ctx) error {
 		return ctx.Redirect("/login", http.StatusMovedPermanently)
 	}
 	log.Debugf("user has roles: %v associated", user.GetRoles())
+	setActor(ctx, user.GetSubject())
 	ctx.Locals(oidcUserContextKey, user)
 	return ctx.Next()
 }
@@ -107,25 +109,37 @@ func (m OIDCMiddleware) handleAimMlflowResourceRequest(ctx *fiber.Ctx) error {
 		)
 	}
 	log.Debugf("user has roles: %v associated", user.GetRoles())
+	setActor(ctx, user.GetSubject())
 
 	if user.IsAdmin() {
 		return ctx.Next()
 	}
 
-	isValid, err := m.rolesRepository.ValidateRolesAccessToNamespace(ctx.Context(), user.GetRoles(), namespace.Code)
+	namespaceRole, err := m.rolesRepository.GetNamespaceRoleByRoles(
+		ctx.Context(), user.GetRoles(), namespace.Code,
+	)
 	if err != nil {
 		log.Errorf("error validating access to requested namespace with code: %s, %+v", namespace.Code, err)
 		return api.NewInternalError(
 			"error validating access to requested namespace with code: %s", namespace.Code,
 		)
 	}
-	if !isValid {
+	if namespaceRole == "" {
 		return ctx.Status(
 			http.StatusForbidden,
 		).JSON(
 			api.NewResourceDoesNotExistError("unable to find namespace with code: %s", namespace.Code),
 		)
 	}
+	if requiredRole := GetRequiredNamespaceRole(ctx); !namespaceRole.Includes(requiredRole) {
+		return ctx.Status(
+			http.StatusForbidden,
+		).JSON(
+			api.NewPermissionDeniedError(
+				"%s role is required to perform request in namespace with code: %s", requiredRole, namespace.Code,
+			),
+		)
+	}
 	return ctx.Next()
 }
 
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/G-Research/fasttrackml/pkg/common/telemetry"
+)
+
+// NewTelemetryMiddleware creates new middleware, which records count and latency of the requests
+// handled by the router of the provided API. Requests are labeled by the route pattern
+// instead of the actual path, so the number of series doesn't depend on the number of entities.
+func NewTelemetryMiddleware(api string) fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		start := time.Now()
+		//nolint:errcheck
+		nextWithRenderedError(ctx)
+
+		// method is backed by the request buffer, which is reused, so it has to be copied.
+		method, route := strings.Clone(ctx.Method()), ctx.Route().Path
+		telemetry.HTTPRequestsTotal.WithLabelValues(
+			api, method, route, strconv.Itoa(ctx.Response().StatusCode()),
+		).Inc()
+		telemetry.HTTPRequestDuration.WithLabelValues(api, method, route).Observe(time.Since(start).Seconds())
+		return nil
+	}
+}
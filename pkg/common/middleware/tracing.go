@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/G-Research/fasttrackml/pkg/common/tracing"
+)
+
+// NewTracingMiddleware creates new middleware, which starts a span for every request of Mlflow,
+// Aim and Admin API. Incoming W3C trace context is continued, so the requests made by
+// the instrumented clients become a part of their traces.
+func NewTracingMiddleware() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		if !MlflowAimPrefixRegexp.MatchString(ctx.Path()) && !AdminPrefixRegexp.MatchString(ctx.Path()) {
+			return ctx.Next()
+		}
+
+		// method and path are backed by the request buffer, which is reused, but spans are exported later.
+		method, path := strings.Clone(ctx.Method()), strings.Clone(ctx.Path())
+
+		carrier := propagation.HeaderCarrier{}
+		ctx.Request().Header.VisitAll(func(key, value []byte) {
+			carrier.Set(string(key), string(value))
+		})
+		spanCtx, span := tracing.Tracer().Start(
+			otel.GetTextMapPropagator().Extract(ctx.UserContext(), carrier),
+			method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(method),
+				semconv.URLPath(path),
+			),
+		)
+		defer span.End()
+		ctx.SetUserContext(spanCtx)
+		tracing.SetRequestSpan(ctx, span)
+
+		if err := nextWithRenderedError(ctx); err != nil {
+			span.RecordError(err)
+		}
+
+		// route is known only after the router did its job.
+		route, status := ctx.Route().Path, ctx.Response().StatusCode()
+		span.SetName(method + " " + route)
+		span.SetAttributes(semconv.HTTPRoute(route), semconv.HTTPResponseStatusCode(status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,187 @@
+package sampling
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Mode represents algorithm, which is used to downsample metric series.
+type Mode string
+
+// Supported sampling modes.
+const (
+	// ModeLTTB selects points with Largest-Triangle-Three-Buckets algorithm, which keeps visual shape of series.
+	ModeLTTB Mode = "lttb"
+	// ModeMinMax selects points with minimal and maximal values in each bucket of the series.
+	ModeMinMax Mode = "minmax"
+)
+
+// lttbOversampling defines how many candidate points per requested point are selected by the query
+// before LTTB algorithm is applied, so the algorithm still sees local extremes of the series.
+const lttbOversampling = 4
+
+// MinMaxCondition is a query condition, which keeps only points selected by MinMaxColumns.
+const MinMaxCondition = "sampling_min_rank = 1 OR sampling_max_rank = 1"
+
+// IsValid makes check that sampling mode is supported. Empty mode means the default one.
+func (m Mode) IsValid() bool {
+	switch m {
+	case "", ModeLTTB, ModeMinMax:
+		return true
+	}
+	return false
+}
+
+// GetMode returns requested sampling mode or the default one.
+func GetMode(mode string) Mode {
+	if mode == "" {
+		return ModeLTTB
+	}
+	return Mode(mode)
+}
+
+// BucketCount returns number of buckets, which each series has to be split into by the query.
+// Each bucket gives at most 2 points: with the minimal and the maximal value. Bucket boundaries are
+// approximate, so the query may return a few points more, which are dropped by Downsample.
+func BucketCount(mode Mode, maxPoints int) int {
+	if mode == ModeLTTB {
+		maxPoints *= lttbOversampling
+	}
+	return max(1, maxPoints/2)
+}
+
+// MinMaxColumns returns window columns, which rank points inside each bucket of the series by value.
+// bucket is an expression to calculate bucket of the point, e.g. `FLOOR(metrics.iter / interval)`.
+func MinMaxColumns(partitionBy []string, bucket, value, order string) []string {
+	partition := strings.Join(append(partitionBy, bucket), ", ")
+	return []string{
+		fmt.Sprintf(
+			"ROW_NUMBER() OVER (PARTITION BY %s ORDER BY %s, %s) AS sampling_min_rank", partition, value, order,
+		),
+		fmt.Sprintf(
+			"ROW_NUMBER() OVER (PARTITION BY %s ORDER BY %s DESC, %s) AS sampling_max_rank", partition, value, order,
+		),
+	}
+}
+
+// Downsample downsamples series with the algorithm of the provided mode and returns ordered indices
+// of at most threshold selected points.
+func Downsample(mode Mode, x, y []float64, threshold int) []int {
+	if mode == ModeMinMax {
+		return MinMax(y, threshold)
+	}
+	return LTTB(x, y, threshold)
+}
+
+// MinMax downsamples series by splitting it into threshold/2 buckets of the same size and selecting
+// points with the minimal and the maximal values in each bucket. It returns ordered indices of selected
+// points. When threshold is 1, only the point with the maximal value is selected.
+func MinMax(y []float64, threshold int) []int {
+	if threshold >= len(y) || threshold <= 0 {
+		indices := make([]int, len(y))
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices
+	}
+
+	buckets := max(1, threshold/2)
+	indices := make([]int, 0, threshold)
+	for i := 0; i < buckets; i++ {
+		start, end := i*len(y)/buckets, (i+1)*len(y)/buckets
+		minIndex, maxIndex := start, start
+		for j := start; j < end; j++ {
+			if valueOrZero(y[j]) < valueOrZero(y[minIndex]) {
+				minIndex = j
+			}
+			if valueOrZero(y[j]) > valueOrZero(y[maxIndex]) {
+				maxIndex = j
+			}
+		}
+		switch {
+		case threshold == 1:
+			indices = append(indices, maxIndex)
+		case minIndex == maxIndex:
+			indices = append(indices, minIndex)
+		default:
+			indices = append(indices, min(minIndex, maxIndex), max(minIndex, maxIndex))
+		}
+	}
+	return indices
+}
+
+// LTTB downsamples series with Largest-Triangle-Three-Buckets algorithm and returns ordered indices
+// of selected points. The first and the last points of the series are always selected.
+func LTTB(x, y []float64, threshold int) []int {
+	if threshold >= len(x) || threshold <= 0 {
+		indices := make([]int, len(x))
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices
+	}
+	switch threshold {
+	case 1:
+		return []int{0}
+	case 2:
+		return []int{0, len(x) - 1}
+	}
+
+	indices := make([]int, 0, threshold)
+	indices = append(indices, 0)
+
+	// bucket size excludes the first and the last points.
+	bucketSize := float64(len(x)-2) / float64(threshold-2)
+	selected := 0
+	for i := 0; i < threshold-2; i++ {
+		// calculate average point of the next bucket.
+		nextStart := int(math.Floor(float64(i+1)*bucketSize)) + 1
+		nextEnd := min(int(math.Floor(float64(i+2)*bucketSize))+1, len(x))
+		var avgX, avgY float64
+		for j := nextStart; j < nextEnd; j++ {
+			avgX += x[j]
+			avgY += valueOrZero(y[j])
+		}
+		if count := float64(nextEnd - nextStart); count > 0 {
+			avgX /= count
+			avgY /= count
+		}
+
+		// select point of current bucket, which forms the largest triangle
+		// with previously selected point and average point of the next bucket.
+		start := int(math.Floor(float64(i)*bucketSize)) + 1
+		end := int(math.Floor(float64(i+1)*bucketSize)) + 1
+		maxArea, maxIndex := -1.0, start
+		for j := start; j < end; j++ {
+			area := math.Abs(
+				(x[selected]-avgX)*(valueOrZero(y[j])-valueOrZero(y[selected])) -
+					(x[selected]-x[j])*(avgY-valueOrZero(y[selected])),
+			)
+			if area > maxArea {
+				maxArea, maxIndex = area, j
+			}
+		}
+		indices = append(indices, maxIndex)
+		selected = maxIndex
+	}
+
+	return append(indices, len(x)-1)
+}
+
+// valueOrZero replaces NaN and infinite values, so they don't break area calculation.
+func valueOrZero(v float64) float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0
+	}
+	return v
+}
+
+// Pick returns values with the given indices.
+func Pick[T any](values []T, indices []int) []T {
+	result := make([]T, len(indices))
+	for i, index := range indices {
+		result[i] = values[index]
+	}
+	return result
+}
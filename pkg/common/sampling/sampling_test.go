@@ -0,0 +1,133 @@
+package sampling
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLTTB_Ok(t *testing.T) {
+	tests := []struct {
+		name      string
+		x         []float64
+		y         []float64
+		threshold int
+		indices   []int
+	}{
+		{
+			name:      "ThresholdIsGreaterThanSeries",
+			x:         []float64{0, 1, 2},
+			y:         []float64{1, 2, 3},
+			threshold: 5,
+			indices:   []int{0, 1, 2},
+		},
+		{
+			name:      "ThresholdIsTwo",
+			x:         []float64{0, 1, 2, 3},
+			y:         []float64{1, 2, 3, 4},
+			threshold: 2,
+			indices:   []int{0, 3},
+		},
+		{
+			name:      "SpikeIsSelected",
+			x:         []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+			y:         []float64{0, 0, 0, 0, 10, 0, 0, 0, 0, 0},
+			threshold: 3,
+			indices:   []int{0, 4, 9},
+		},
+		{
+			name:      "EachBucketGivesOnePoint",
+			x:         []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+			y:         []float64{0, 5, 0, 0, 0, 0, -5, 0, 0, 0},
+			threshold: 4,
+			indices:   []int{0, 1, 6, 9},
+		},
+		{
+			name:      "NaNValuesAreSupported",
+			x:         []float64{0, 1, 2, 3, 4},
+			y:         []float64{0, math.NaN(), 7, math.NaN(), 0},
+			threshold: 3,
+			indices:   []int{0, 2, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.indices, LTTB(tt.x, tt.y, tt.threshold))
+		})
+	}
+}
+
+func TestMinMax_Ok(t *testing.T) {
+	tests := []struct {
+		name      string
+		y         []float64
+		threshold int
+		indices   []int
+	}{
+		{
+			name:      "ThresholdIsGreaterThanSeries",
+			y:         []float64{1, 2, 3},
+			threshold: 5,
+			indices:   []int{0, 1, 2},
+		},
+		{
+			name:      "ThresholdIsOne",
+			y:         []float64{1, 5, 3, 2},
+			threshold: 1,
+			indices:   []int{1},
+		},
+		{
+			name:      "ThresholdIsOdd",
+			y:         []float64{0, 9, 1, 1, -3, 4, 2, 7, 5, -1},
+			threshold: 5,
+			indices:   []int{1, 4, 7, 9},
+		},
+		{
+			name:      "FlatBucketGivesOnePoint",
+			y:         []float64{1, 1, 1, 1, 0, 5},
+			threshold: 4,
+			indices:   []int{0, 4, 5},
+		},
+		{
+			name:      "NaNValuesAreSupported",
+			y:         []float64{math.NaN(), 3, -1, math.NaN()},
+			threshold: 2,
+			indices:   []int{1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indices := MinMax(tt.y, tt.threshold)
+			assert.Equal(t, tt.indices, indices)
+			assert.LessOrEqual(t, len(indices), max(tt.threshold, 0))
+		})
+	}
+}
+
+func TestDownsample_Ok(t *testing.T) {
+	x, y := []float64{0, 1, 2, 3, 4}, []float64{0, 8, 1, -2, 0}
+	assert.Equal(t, []int{1, 3}, Downsample(ModeMinMax, x, y, 3))
+	assert.Equal(t, []int{0, 1, 4}, Downsample(ModeLTTB, x, y, 3))
+}
+
+func TestBucketCount_Ok(t *testing.T) {
+	assert.Equal(t, 50, BucketCount(ModeMinMax, 100))
+	assert.Equal(t, 200, BucketCount(ModeLTTB, 100))
+	assert.Equal(t, 1, BucketCount(ModeMinMax, 1))
+}
+
+func TestMode_IsValid(t *testing.T) {
+	assert.True(t, Mode("").IsValid())
+	assert.True(t, ModeLTTB.IsValid())
+	assert.True(t, ModeMinMax.IsValid())
+	assert.False(t, Mode("average").IsValid())
+	assert.Equal(t, ModeLTTB, GetMode(""))
+	assert.Equal(t, ModeMinMax, GetMode("minmax"))
+}
+
+func TestPick_Ok(t *testing.T) {
+	assert.Equal(t, []string{"a", "c"}, Pick([]string{"a", "b", "c"}, []int{0, 2}))
+}
@@ -3,33 +3,53 @@ package artifact
 import (
 	"cmp"
 	"context"
+	//nolint:gosec
+	"crypto/md5"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
+	"path"
 	"path/filepath"
 	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/pkg/common/api/request"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
 	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
 )
 
+// proxiedArtifactsRootURI is the root URI of the artifacts served by `mlflow-artifacts` API.
+const proxiedArtifactsRootURI = storage.MlflowArtifactsStorageName + ":/"
+
 // Service provides service layer to work with `artifact` business logic.
 type Service struct {
+	config                 *config.Config
 	runRepository          repositories.RunRepositoryProvider
+	experimentRepository   repositories.ExperimentRepositoryProvider
 	artifactStorageFactory storage.ArtifactStorageFactoryProvider
 }
 
 // NewService creates new Service instance.
 func NewService(
+	config *config.Config,
 	runRepository repositories.RunRepositoryProvider,
+	experimentRepository repositories.ExperimentRepositoryProvider,
 	artifactStorageFactory storage.ArtifactStorageFactoryProvider,
 ) *Service {
 	return &Service{
+		config:                 config,
 		runRepository:          runRepository,
+		experimentRepository:   experimentRepository,
 		artifactStorageFactory: artifactStorageFactory,
 	}
 }
@@ -76,16 +96,9 @@ func (s Service) GetArtifact(
 		return nil, err
 	}
 
-	run, err := s.runRepository.GetByNamespaceIDAndRunID(ctx, namespace.ID, req.GetRunID())
-	if err != nil {
-		return nil, api.NewInternalError("unable to find run '%s': %s", req.GetRunID(), err)
-	}
-	if run == nil {
-		return nil, api.NewResourceDoesNotExistError("unable to find run '%s'", req.GetRunID())
-	}
-	artifactStorage, err := s.artifactStorageFactory.GetStorage(ctx, run.ArtifactURI)
+	run, artifactStorage, err := s.getRunArtifactStorage(ctx, namespace, req.GetRunID())
 	if err != nil {
-		return nil, api.NewInternalError("run with id '%s' has unsupported artifact storage", run.ID)
+		return nil, err
 	}
 
 	artifactReader, err := artifactStorage.Get(
@@ -100,3 +113,363 @@ func (s Service) GetArtifact(
 	}
 	return artifactReader, nil
 }
+
+// GetArtifactPresignedURL returns presigned URL of the artifact requested by `GET /artifacts/get` endpoint.
+// Empty URL means that the artifact has to be streamed with GetArtifact instead, because presigned URLs are
+// disabled or not supported by the artifact storage.
+func (s Service) GetArtifactPresignedURL(
+	ctx context.Context, namespace *models.Namespace, req *request.GetArtifactRequest,
+) (string, error) {
+	if !s.config.PresignedURLsEnabled {
+		return "", nil
+	}
+	if err := ValidateGetArtifactRequest(req); err != nil {
+		return "", err
+	}
+
+	run, artifactStorage, err := s.getRunArtifactStorage(ctx, namespace, req.GetRunID())
+	if err != nil {
+		return "", err
+	}
+	return s.presignGet(ctx, artifactStorage, run.ArtifactURI, req.Path), nil
+}
+
+// ListProxiedArtifacts handles the business logic of `GET /mlflow-artifacts/artifacts` endpoint.
+func (s Service) ListProxiedArtifacts(
+	ctx context.Context, namespace *models.Namespace, req *request.ListProxiedArtifactsRequest,
+) ([]storage.ArtifactObject, error) {
+	if err := ValidateListProxiedArtifactsRequest(req); err != nil {
+		return nil, err
+	}
+
+	// the root of artifacts destination is shared by all the namespaces, so only
+	// the directories of the experiments of the current namespace are listed there.
+	isRoot := path.Clean(req.Path) == "."
+	var artifactStorage storage.ArtifactStorageProvider
+	var err error
+	if isRoot {
+		artifactStorage, err = s.getArtifactsDestinationStorage(ctx)
+	} else {
+		artifactStorage, err = s.getProxiedArtifactStorage(ctx, namespace, req.Path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts, err := artifactStorage.List(ctx, proxiedArtifactsRootURI, req.Path)
+	if err != nil {
+		return nil, api.NewInternalError("error getting artifact list from storage")
+	}
+
+	// staged parts of multipart uploads and the experiments of other namespaces are hidden.
+	if isRoot {
+		artifacts = slices.DeleteFunc(artifacts, func(artifact storage.ArtifactObject) bool {
+			return s.checkProxiedArtifactPath(ctx, namespace, artifact.Path) != nil
+		})
+	}
+
+	// sort artifacts by path
+	slices.SortFunc(artifacts, func(a, b storage.ArtifactObject) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+
+	return artifacts, nil
+}
+
+// GetProxiedArtifactPresignedURL returns presigned URL of the artifact requested by
+// `GET /mlflow-artifacts/artifacts/:path` endpoint. Empty URL means that the artifact
+// has to be streamed with DownloadArtifact instead.
+func (s Service) GetProxiedArtifactPresignedURL(
+	ctx context.Context, namespace *models.Namespace, req *request.DownloadArtifactRequest,
+) (string, error) {
+	if !s.config.PresignedURLsEnabled {
+		return "", nil
+	}
+	if err := ValidateDownloadArtifactRequest(req); err != nil {
+		return "", err
+	}
+
+	artifactStorage, err := s.getProxiedArtifactStorage(ctx, namespace, req.Path)
+	if err != nil {
+		return "", err
+	}
+	return s.presignGet(ctx, artifactStorage, proxiedArtifactsRootURI, req.Path), nil
+}
+
+// DownloadArtifact handles the business logic of `GET /mlflow-artifacts/artifacts/:path` endpoint.
+func (s Service) DownloadArtifact(
+	ctx context.Context, namespace *models.Namespace, req *request.DownloadArtifactRequest,
+) (io.ReadCloser, error) {
+	if err := ValidateDownloadArtifactRequest(req); err != nil {
+		return nil, err
+	}
+
+	artifactStorage, err := s.getProxiedArtifactStorage(ctx, namespace, req.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	artifactReader, err := artifactStorage.Get(ctx, proxiedArtifactsRootURI, req.Path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, api.NewResourceDoesNotExistError("artifact '%s' not found", req.Path)
+		}
+		return nil, api.NewInternalError("error getting artifact '%s': %s", req.Path, err)
+	}
+	return artifactReader, nil
+}
+
+// UploadArtifact handles the business logic of `PUT /mlflow-artifacts/artifacts/:path` endpoint.
+func (s Service) UploadArtifact(
+	ctx context.Context, namespace *models.Namespace, req *request.UploadArtifactRequest, reader io.Reader,
+) error {
+	if err := ValidateUploadArtifactRequest(req); err != nil {
+		return err
+	}
+
+	artifactStorage, err := s.getProxiedArtifactStorage(ctx, namespace, req.Path)
+	if err != nil {
+		return err
+	}
+
+	if err := artifactStorage.Put(ctx, proxiedArtifactsRootURI, req.Path, reader); err != nil {
+		return api.NewInternalError("error uploading artifact '%s': %s", req.Path, err)
+	}
+	return nil
+}
+
+// DeleteArtifact handles the business logic of `DELETE /mlflow-artifacts/artifacts/:path` endpoint.
+func (s Service) DeleteArtifact(
+	ctx context.Context, namespace *models.Namespace, req *request.DeleteArtifactRequest,
+) error {
+	if err := ValidateDeleteArtifactRequest(req); err != nil {
+		return err
+	}
+
+	artifactStorage, err := s.getProxiedArtifactStorage(ctx, namespace, req.Path)
+	if err != nil {
+		return err
+	}
+
+	if err := artifactStorage.Delete(ctx, proxiedArtifactsRootURI, req.Path); err != nil {
+		return api.NewInternalError("error deleting artifact '%s': %s", req.Path, err)
+	}
+	return nil
+}
+
+// CreateMultipartUpload handles the business logic of `POST /mlflow-artifacts/mpu/create/:path` endpoint.
+// Parts are staged in the artifacts destination, so nothing has to be stored at this point. Upload ID is
+// a time-ordered UUID, so staged parts of abandoned uploads can be expired by the garbage collection.
+func (s Service) CreateMultipartUpload(
+	ctx context.Context, namespace *models.Namespace, req *request.CreateMultipartUploadRequest,
+) (string, error) {
+	if err := ValidateCreateMultipartUploadRequest(req); err != nil {
+		return "", err
+	}
+	if err := s.checkProxiedArtifactPath(ctx, namespace, req.ArtifactPath); err != nil {
+		return "", err
+	}
+	uploadID, err := uuid.NewV7()
+	if err != nil {
+		return "", api.NewInternalError("error generating multipart upload id: %s", err)
+	}
+	return uploadID.String(), nil
+}
+
+// UploadMultipartPart handles the business logic of `PUT /mlflow-artifacts/mpu/upload/:path` endpoint.
+// It returns ETag of the uploaded part.
+func (s Service) UploadMultipartPart(
+	ctx context.Context, namespace *models.Namespace, req *request.UploadMultipartPartRequest, reader io.Reader,
+) (string, error) {
+	if err := ValidateUploadMultipartPartRequest(req); err != nil {
+		return "", err
+	}
+
+	artifactStorage, err := s.getProxiedArtifactStorage(ctx, namespace, req.ArtifactPath)
+	if err != nil {
+		return "", err
+	}
+
+	//nolint:gosec
+	hash := md5.New()
+	if err := artifactStorage.Put(
+		ctx,
+		proxiedArtifactsRootURI,
+		getMultipartUploadPartPath(req.UploadID, req.PartNumber),
+		io.TeeReader(reader, hash),
+	); err != nil {
+		return "", api.NewInternalError(
+			"error uploading part %d of multipart upload '%s': %s", req.PartNumber, req.UploadID, err,
+		)
+	}
+	return fmt.Sprintf(`"%s"`, hex.EncodeToString(hash.Sum(nil))), nil
+}
+
+// CompleteMultipartUpload handles the business logic of `POST /mlflow-artifacts/mpu/complete/:path` endpoint.
+// Staged parts are concatenated into the actual artifact and removed afterwards.
+func (s Service) CompleteMultipartUpload(
+	ctx context.Context, namespace *models.Namespace, req *request.CompleteMultipartUploadRequest,
+) error {
+	if err := ValidateCompleteMultipartUploadRequest(req); err != nil {
+		return err
+	}
+
+	artifactStorage, err := s.getProxiedArtifactStorage(ctx, namespace, req.ArtifactPath)
+	if err != nil {
+		return err
+	}
+
+	parts := slices.Clone(req.Parts)
+	slices.SortFunc(parts, func(a, b request.MultipartUploadPartPartialRequest) int {
+		return cmp.Compare(a.PartNumber, b.PartNumber)
+	})
+
+	// stream parts one by one, so we never have the whole artifact in memory.
+	reader, writer := io.Pipe()
+	go func() {
+		for _, part := range parts {
+			partReader, err := artifactStorage.Get(
+				ctx, proxiedArtifactsRootURI, getMultipartUploadPartPath(req.UploadID, part.PartNumber),
+			)
+			if err != nil {
+				writer.CloseWithError(eris.Wrapf(err, "error getting part %d", part.PartNumber))
+				return
+			}
+			_, err = io.Copy(writer, partReader)
+			//nolint:errcheck
+			partReader.Close()
+			if err != nil {
+				writer.CloseWithError(eris.Wrapf(err, "error reading part %d", part.PartNumber))
+				return
+			}
+		}
+		//nolint:errcheck
+		writer.Close()
+	}()
+
+	err = artifactStorage.Put(ctx, proxiedArtifactsRootURI, req.GetDestinationPath(), reader)
+	//nolint:errcheck
+	reader.Close()
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return api.NewInvalidParameterValueError(
+				"multipart upload '%s' is missing some of the provided parts", req.UploadID,
+			)
+		}
+		return api.NewInternalError("error completing multipart upload '%s': %s", req.UploadID, err)
+	}
+
+	if err := artifactStorage.Delete(
+		ctx, proxiedArtifactsRootURI, getMultipartUploadPath(req.UploadID),
+	); err != nil {
+		return api.NewInternalError("error cleaning up multipart upload '%s': %s", req.UploadID, err)
+	}
+	return nil
+}
+
+// AbortMultipartUpload handles the business logic of `POST /mlflow-artifacts/mpu/abort/:path` endpoint.
+func (s Service) AbortMultipartUpload(
+	ctx context.Context, namespace *models.Namespace, req *request.AbortMultipartUploadRequest,
+) error {
+	if err := ValidateAbortMultipartUploadRequest(req); err != nil {
+		return err
+	}
+
+	artifactStorage, err := s.getProxiedArtifactStorage(ctx, namespace, req.ArtifactPath)
+	if err != nil {
+		return err
+	}
+
+	if err := artifactStorage.Delete(
+		ctx, proxiedArtifactsRootURI, getMultipartUploadPath(req.UploadID),
+	); err != nil {
+		return api.NewInternalError("error aborting multipart upload '%s': %s", req.UploadID, err)
+	}
+	return nil
+}
+
+// getRunArtifactStorage returns the run and the storage of its artifacts.
+func (s Service) getRunArtifactStorage(
+	ctx context.Context, namespace *models.Namespace, runID string,
+) (*models.Run, storage.ArtifactStorageProvider, error) {
+	run, err := s.runRepository.GetByNamespaceIDAndRunID(ctx, namespace.ID, runID)
+	if err != nil {
+		return nil, nil, api.NewInternalError("unable to find run '%s': %s", runID, err)
+	}
+	if run == nil {
+		return nil, nil, api.NewResourceDoesNotExistError("unable to find run '%s'", runID)
+	}
+	artifactStorage, err := s.artifactStorageFactory.GetStorage(ctx, run.ArtifactURI)
+	if err != nil {
+		return nil, nil, api.NewInternalError("run with id '%s' has unsupported artifact storage", run.ID)
+	}
+	return run, artifactStorage, nil
+}
+
+// presignGet creates presigned URL of the artifact if the storage supports it. Failures are not fatal,
+// the artifact is streamed through the server instead, so an empty URL is returned.
+func (s Service) presignGet(
+	ctx context.Context, artifactStorage storage.ArtifactStorageProvider, artifactURI, path string,
+) string {
+	presigner, ok := artifactStorage.(storage.ArtifactURLPresigner)
+	if !ok {
+		return ""
+	}
+	url, err := presigner.PresignGet(ctx, artifactURI, path, s.config.PresignedURLsExpiry)
+	if err != nil {
+		if !errors.Is(err, storage.ErrPresignNotSupported) {
+			log.Warnf("error presigning artifact url, falling back to streaming: %s", err)
+		}
+		return ""
+	}
+	return url
+}
+
+// getProxiedArtifactStorage returns storage of the artifacts served by `mlflow-artifacts` API,
+// after checking that the requested path belongs to the namespace.
+func (s Service) getProxiedArtifactStorage(
+	ctx context.Context, namespace *models.Namespace, artifactPath string,
+) (storage.ArtifactStorageProvider, error) {
+	if err := s.checkProxiedArtifactPath(ctx, namespace, artifactPath); err != nil {
+		return nil, err
+	}
+	return s.getArtifactsDestinationStorage(ctx)
+}
+
+// getArtifactsDestinationStorage returns storage of the artifacts destination shared by all the namespaces.
+func (s Service) getArtifactsDestinationStorage(ctx context.Context) (storage.ArtifactStorageProvider, error) {
+	artifactStorage, err := s.artifactStorageFactory.GetStorage(ctx, proxiedArtifactsRootURI)
+	if err != nil {
+		return nil, api.NewInternalError("error getting artifacts destination storage: %s", err)
+	}
+	return artifactStorage, nil
+}
+
+// checkProxiedArtifactPath checks that the artifact path belongs to the experiment of the namespace.
+// Artifacts destination is shared by all the namespaces and the artifacts are stored there
+// as `<experiment id>/<run id>/artifacts/...`, so the first path segment is the experiment id.
+func (s Service) checkProxiedArtifactPath(
+	ctx context.Context, namespace *models.Namespace, artifactPath string,
+) error {
+	experimentID, _, _ := strings.Cut(path.Clean(artifactPath), "/")
+	parsedID, err := strconv.ParseInt(experimentID, 10, 32)
+	if err != nil {
+		return api.NewPermissionDeniedError("artifact path '%s' doesn't belong to any experiment", artifactPath)
+	}
+	if _, err := s.experimentRepository.GetByNamespaceIDAndExperimentID(
+		ctx, namespace.ID, int32(parsedID),
+	); err != nil {
+		return api.NewResourceDoesNotExistError("unable to find experiment '%d': %s", parsedID, err)
+	}
+	return nil
+}
+
+// getMultipartUploadPath returns path where parts of multipart upload are staged.
+func getMultipartUploadPath(uploadID string) string {
+	return path.Join(MultipartUploadsPath, uploadID)
+}
+
+// getMultipartUploadPartPath returns path of the staged part of multipart upload.
+func getMultipartUploadPartPath(uploadID string, partNumber int64) string {
+	return path.Join(getMultipartUploadPath(uploadID), strconv.FormatInt(partNumber, 10))
+}
@@ -5,16 +5,21 @@ import (
 	"context"
 	"errors"
 	"io"
+	"io/fs"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/pkg/common/api/request"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
 	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
 )
 
@@ -55,7 +60,9 @@ func TestService_ListArtifacts_Ok(t *testing.T) {
 	}, nil)
 
 	// call service under testing.
-	service := NewService(&runRepository, &artifactStorageFactory)
+	service := NewService(
+		&config.Config{}, &runRepository, &repositories.MockExperimentRepositoryProvider{}, &artifactStorageFactory,
+	)
 	rootURI, artifacts, err := service.ListArtifacts(
 		context.TODO(),
 		&models.Namespace{
@@ -95,7 +102,9 @@ func TestService_ListArtifacts_Error(t *testing.T) {
 			request: &request.ListArtifactsRequest{},
 			service: func() *Service {
 				return NewService(
+					&config.Config{},
 					&repositories.MockRunRepositoryProvider{},
+					&repositories.MockExperimentRepositoryProvider{},
 					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
@@ -109,7 +118,9 @@ func TestService_ListArtifacts_Error(t *testing.T) {
 			},
 			service: func() *Service {
 				return NewService(
+					&config.Config{},
 					&repositories.MockRunRepositoryProvider{},
+					&repositories.MockExperimentRepositoryProvider{},
 					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
@@ -129,7 +140,9 @@ func TestService_ListArtifacts_Error(t *testing.T) {
 					"id",
 				).Return(nil, errors.New("database error"))
 				return NewService(
+					&config.Config{},
 					&runRepository,
+					&repositories.MockExperimentRepositoryProvider{},
 					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
@@ -164,7 +177,9 @@ func TestService_ListArtifacts_Error(t *testing.T) {
 					ArtifactURI: "/artifact/uri",
 				}, nil)
 				return NewService(
+					&config.Config{},
 					&runRepository,
+					&repositories.MockExperimentRepositoryProvider{},
 					&artifactStorageFactory,
 				)
 			},
@@ -208,7 +223,9 @@ func TestService_GetArtifact_Ok(t *testing.T) {
 	}, nil)
 
 	// call service under testing.
-	service := NewService(&runRepository, &artifactStorageFactory)
+	service := NewService(
+		&config.Config{}, &runRepository, &repositories.MockExperimentRepositoryProvider{}, &artifactStorageFactory,
+	)
 	data, err := service.GetArtifact(
 		context.TODO(),
 		&models.Namespace{
@@ -239,7 +256,9 @@ func TestService_GetArtifact_Error(t *testing.T) {
 			request: &request.GetArtifactRequest{},
 			service: func() *Service {
 				return NewService(
+					&config.Config{},
 					&repositories.MockRunRepositoryProvider{},
+					&repositories.MockExperimentRepositoryProvider{},
 					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
@@ -253,7 +272,9 @@ func TestService_GetArtifact_Error(t *testing.T) {
 			},
 			service: func() *Service {
 				return NewService(
+					&config.Config{},
 					&repositories.MockRunRepositoryProvider{},
+					&repositories.MockExperimentRepositoryProvider{},
 					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
@@ -273,7 +294,9 @@ func TestService_GetArtifact_Error(t *testing.T) {
 					"id",
 				).Return(nil, errors.New("database error"))
 				return NewService(
+					&config.Config{},
 					&runRepository,
+					&repositories.MockExperimentRepositoryProvider{},
 					&storage.MockArtifactStorageFactoryProvider{},
 				)
 			},
@@ -308,7 +331,9 @@ func TestService_GetArtifact_Error(t *testing.T) {
 					ArtifactURI: "/artifact/uri",
 				}, nil)
 				return NewService(
+					&config.Config{},
 					&runRepository,
+					&repositories.MockExperimentRepositoryProvider{},
 					&artifactStorageFactory,
 				)
 			},
@@ -343,7 +368,9 @@ func TestService_GetArtifact_Error(t *testing.T) {
 					ArtifactURI: "/artifact/uri",
 				}, nil)
 				return NewService(
+					&config.Config{},
 					&runRepository,
+					&repositories.MockExperimentRepositoryProvider{},
 					&artifactStorageFactory,
 				)
 			},
@@ -360,3 +387,342 @@ func TestService_GetArtifact_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestService_ListProxiedArtifacts_Ok(t *testing.T) {
+	artifactStorage := storage.MockArtifactStorageProvider{}
+	artifactStorage.On(
+		"List", context.TODO(), "mlflow-artifacts:/", "",
+	).Return(
+		[]storage.ArtifactObject{
+			{
+				Path:  "2",
+				IsDir: true,
+			},
+			{
+				Path:  MultipartUploadsPath,
+				IsDir: true,
+			},
+			{
+				Path:  "1",
+				IsDir: true,
+			},
+			{
+				Path:  "3",
+				IsDir: true,
+			},
+		}, nil,
+	)
+
+	artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
+	artifactStorageFactory.On(
+		"GetStorage", context.TODO(), "mlflow-artifacts:/",
+	).Return(&artifactStorage, nil)
+
+	// init repository mocks.
+	experimentRepository := repositories.MockExperimentRepositoryProvider{}
+	experimentRepository.On(
+		"GetByNamespaceIDAndExperimentID", context.TODO(), uint(1), int32(1),
+	).Return(&models.Experiment{}, nil)
+	experimentRepository.On(
+		"GetByNamespaceIDAndExperimentID", context.TODO(), uint(1), int32(2),
+	).Return(&models.Experiment{}, nil)
+	experimentRepository.On(
+		"GetByNamespaceIDAndExperimentID", context.TODO(), uint(1), int32(3),
+	).Return(nil, errors.New("record not found"))
+
+	// call service under testing.
+	service := NewService(
+		&config.Config{}, &repositories.MockRunRepositoryProvider{}, &experimentRepository, &artifactStorageFactory,
+	)
+	artifacts, err := service.ListProxiedArtifacts(
+		context.TODO(), &models.Namespace{ID: 1}, &request.ListProxiedArtifactsRequest{},
+	)
+
+	require.Nil(t, err)
+	assert.Equal(t, []storage.ArtifactObject{
+		{
+			Path:  "1",
+			IsDir: true,
+		},
+		{
+			Path:  "2",
+			IsDir: true,
+		},
+	}, artifacts)
+}
+
+func TestService_DownloadArtifact_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.DownloadArtifactRequest
+		service func() *Service
+	}{
+		{
+			name:    "EmptyPath",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'path'"),
+			request: &request.DownloadArtifactRequest{},
+			service: func() *Service {
+				return NewService(
+					&config.Config{},
+					&repositories.MockRunRepositoryProvider{},
+					&repositories.MockExperimentRepositoryProvider{},
+					&storage.MockArtifactStorageFactoryProvider{},
+				)
+			},
+		},
+		{
+			name:  "PathOutsideOfExperiment",
+			error: api.NewPermissionDeniedError("artifact path 'path/file.txt' doesn't belong to any experiment"),
+			request: &request.DownloadArtifactRequest{
+				Path: "path/file.txt",
+			},
+			service: func() *Service {
+				return NewService(
+					&config.Config{},
+					&repositories.MockRunRepositoryProvider{},
+					&repositories.MockExperimentRepositoryProvider{},
+					&storage.MockArtifactStorageFactoryProvider{},
+				)
+			},
+		},
+		{
+			name:  "ExperimentOfAnotherNamespace",
+			error: api.NewResourceDoesNotExistError("unable to find experiment '2': record not found"),
+			request: &request.DownloadArtifactRequest{
+				Path: "2/run/artifacts/file.txt",
+			},
+			service: func() *Service {
+				experimentRepository := repositories.MockExperimentRepositoryProvider{}
+				experimentRepository.On(
+					"GetByNamespaceIDAndExperimentID", context.TODO(), uint(1), int32(2),
+				).Return(nil, errors.New("record not found"))
+				return NewService(
+					&config.Config{},
+					&repositories.MockRunRepositoryProvider{},
+					&experimentRepository,
+					&storage.MockArtifactStorageFactoryProvider{},
+				)
+			},
+		},
+		{
+			name:  "ArtifactNotFound",
+			error: api.NewResourceDoesNotExistError("artifact '1/run/artifacts/file.txt' not found"),
+			request: &request.DownloadArtifactRequest{
+				Path: "1/run/artifacts/file.txt",
+			},
+			service: func() *Service {
+				artifactStorage := storage.MockArtifactStorageProvider{}
+				artifactStorage.On(
+					"Get", context.TODO(), "mlflow-artifacts:/", "1/run/artifacts/file.txt",
+				).Return(
+					nil, fs.ErrNotExist,
+				)
+
+				artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
+				artifactStorageFactory.On(
+					"GetStorage", context.TODO(), "mlflow-artifacts:/",
+				).Return(&artifactStorage, nil)
+
+				experimentRepository := repositories.MockExperimentRepositoryProvider{}
+				experimentRepository.On(
+					"GetByNamespaceIDAndExperimentID", context.TODO(), uint(1), int32(1),
+				).Return(&models.Experiment{}, nil)
+				return NewService(
+					&config.Config{},
+					&repositories.MockRunRepositoryProvider{},
+					&experimentRepository,
+					&artifactStorageFactory,
+				)
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.service().DownloadArtifact(context.TODO(), &models.Namespace{ID: 1}, tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestService_UploadMultipartPart_Ok(t *testing.T) {
+	uploadID := uuid.NewString()
+
+	artifactStorage := storage.MockArtifactStorageProvider{}
+	artifactStorage.On(
+		"Put", context.TODO(), "mlflow-artifacts:/", MultipartUploadsPath+"/"+uploadID+"/2", mock.Anything,
+	).Run(func(args mock.Arguments) {
+		_, err := io.ReadAll(args.Get(3).(io.Reader))
+		require.Nil(t, err)
+	}).Return(nil)
+
+	artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
+	artifactStorageFactory.On(
+		"GetStorage", context.TODO(), "mlflow-artifacts:/",
+	).Return(&artifactStorage, nil)
+
+	// init repository mocks.
+	experimentRepository := repositories.MockExperimentRepositoryProvider{}
+	experimentRepository.On(
+		"GetByNamespaceIDAndExperimentID", context.TODO(), uint(1), int32(1),
+	).Return(&models.Experiment{}, nil)
+
+	// call service under testing.
+	service := NewService(
+		&config.Config{}, &repositories.MockRunRepositoryProvider{}, &experimentRepository, &artifactStorageFactory,
+	)
+	etag, err := service.UploadMultipartPart(
+		context.TODO(),
+		&models.Namespace{ID: 1},
+		&request.UploadMultipartPartRequest{
+			ArtifactPath: "1/run/artifacts",
+			UploadID:     uploadID,
+			PartNumber:   2,
+		},
+		strings.NewReader("content"),
+	)
+
+	require.Nil(t, err)
+	assert.Equal(t, `"9a0364b9e99bb480dd25e1f0284c8555"`, etag)
+	artifactStorage.AssertExpectations(t)
+}
+
+func TestService_CompleteMultipartUpload_Ok(t *testing.T) {
+	uploadID := uuid.NewString()
+
+	artifactStorage := storage.MockArtifactStorageProvider{}
+	artifactStorage.On(
+		"Get", context.TODO(), "mlflow-artifacts:/", MultipartUploadsPath+"/"+uploadID+"/1",
+	).Return(io.NopCloser(strings.NewReader("first ")), nil)
+	artifactStorage.On(
+		"Get", context.TODO(), "mlflow-artifacts:/", MultipartUploadsPath+"/"+uploadID+"/2",
+	).Return(io.NopCloser(strings.NewReader("second")), nil)
+	content := new(bytes.Buffer)
+	artifactStorage.On(
+		"Put", context.TODO(), "mlflow-artifacts:/", "1/run/artifacts/file.txt", mock.Anything,
+	).Run(func(args mock.Arguments) {
+		_, err := content.ReadFrom(args.Get(3).(io.Reader))
+		require.Nil(t, err)
+	}).Return(nil)
+	artifactStorage.On(
+		"Delete", context.TODO(), "mlflow-artifacts:/", MultipartUploadsPath+"/"+uploadID,
+	).Return(nil)
+
+	artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
+	artifactStorageFactory.On(
+		"GetStorage", context.TODO(), "mlflow-artifacts:/",
+	).Return(&artifactStorage, nil)
+
+	// init repository mocks.
+	experimentRepository := repositories.MockExperimentRepositoryProvider{}
+	experimentRepository.On(
+		"GetByNamespaceIDAndExperimentID", context.TODO(), uint(1), int32(1),
+	).Return(&models.Experiment{}, nil)
+
+	// call service under testing.
+	service := NewService(
+		&config.Config{}, &repositories.MockRunRepositoryProvider{}, &experimentRepository, &artifactStorageFactory,
+	)
+	err := service.CompleteMultipartUpload(
+		context.TODO(),
+		&models.Namespace{ID: 1},
+		&request.CompleteMultipartUploadRequest{
+			ArtifactPath: "1/run/artifacts",
+			Path:         "/local/path/file.txt",
+			UploadID:     uploadID,
+			Parts: []request.MultipartUploadPartPartialRequest{
+				{PartNumber: 2},
+				{PartNumber: 1},
+			},
+		},
+	)
+
+	require.Nil(t, err)
+	assert.Equal(t, "first second", content.String())
+	artifactStorage.AssertExpectations(t)
+}
+
+// presigningArtifactStorage is a mock of storage supporting presigned URLs.
+type presigningArtifactStorage struct {
+	*storage.MockArtifactStorageProvider
+	*storage.MockArtifactURLPresigner
+}
+
+func TestService_GetArtifactPresignedURL_Ok(t *testing.T) {
+	plainStorage := &storage.MockArtifactStorageProvider{}
+
+	presigner := &storage.MockArtifactURLPresigner{}
+	presigner.On(
+		"PresignGet", context.TODO(), "s3://bucket/artifacts", "model.pkl", 5*time.Minute,
+	).Return("https://bucket.s3.amazonaws.com/artifacts/model.pkl?X-Amz-Signature=abc", nil)
+	presigningStorage := presigningArtifactStorage{plainStorage, presigner}
+
+	failingPresigner := &storage.MockArtifactURLPresigner{}
+	failingPresigner.On(
+		"PresignGet", context.TODO(), "s3://bucket/artifacts", "model.pkl", 5*time.Minute,
+	).Return("", errors.New("no credentials"))
+	failingStorage := presigningArtifactStorage{plainStorage, failingPresigner}
+
+	tests := []struct {
+		name    string
+		enabled bool
+		storage storage.ArtifactStorageProvider
+		url     string
+	}{
+		{
+			name:    "Disabled",
+			enabled: false,
+			storage: presigningStorage,
+			url:     "",
+		},
+		{
+			name:    "Presigned",
+			enabled: true,
+			storage: presigningStorage,
+			url:     "https://bucket.s3.amazonaws.com/artifacts/model.pkl?X-Amz-Signature=abc",
+		},
+		{
+			name:    "NotSupported",
+			enabled: true,
+			storage: plainStorage,
+			url:     "",
+		},
+		{
+			name:    "PresignFailed",
+			enabled: true,
+			storage: failingStorage,
+			url:     "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
+			artifactStorageFactory.On(
+				"GetStorage", context.TODO(), "s3://bucket/artifacts",
+			).Return(tt.storage, nil)
+
+			runRepository := repositories.MockRunRepositoryProvider{}
+			runRepository.On(
+				"GetByNamespaceIDAndRunID", context.TODO(), uint(1), "id",
+			).Return(&models.Run{
+				ID:          "id",
+				ArtifactURI: "s3://bucket/artifacts",
+			}, nil)
+
+			service := NewService(
+				&config.Config{PresignedURLsEnabled: tt.enabled, PresignedURLsExpiry: 5 * time.Minute},
+				&runRepository,
+				&repositories.MockExperimentRepositoryProvider{},
+				&artifactStorageFactory,
+			)
+			url, err := service.GetArtifactPresignedURL(
+				context.TODO(),
+				&models.Namespace{ID: 1},
+				&request.GetArtifactRequest{RunID: "id", Path: "model.pkl"},
+			)
+			require.Nil(t, err)
+			assert.Equal(t, tt.url, url)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+)
+
+// Azure storage names.
+const (
+	AzureBlobStorageName     = "wasbs"
+	AzureDataLakeStorageName = "abfss"
+)
+
+// Azure represents adapter to work with Azure Blob Storage artifacts.
+type Azure struct {
+	client *AzureClient
+}
+
+// Azure storage options.
+const (
+	AzureStorageConnectionStringOption = "azure-storage-connection-string"
+	AzureStorageAccessKeyOption        = "azure-storage-access-key"
+)
+
+func init() {
+	RegisterBackend(Backend{
+		Name:    "azure",
+		Schemes: []string{AzureBlobStorageName, AzureDataLakeStorageName},
+		Options: []Option{
+			{
+				Name:    AzureStorageConnectionStringOption,
+				Usage:   "Azure Blob Storage connection string",
+				EnvVars: []string{"AZURE_STORAGE_CONNECTION_STRING"},
+			},
+			{
+				Name:    AzureStorageAccessKeyOption,
+				Usage:   "Azure Blob Storage account access key",
+				EnvVars: []string{"AZURE_STORAGE_ACCESS_KEY"},
+			},
+		},
+		New: func(ctx context.Context, params BackendParams) (ArtifactStorageProvider, error) {
+			return NewAzure(params.Options)
+		},
+	})
+}
+
+// NewAzure creates new Azure Blob Storage instance.
+func NewAzure(options Options) (*Azure, error) {
+	client, err := NewAzureClient(
+		options.Get(AzureStorageConnectionStringOption), options.Get(AzureStorageAccessKeyOption),
+	)
+	if err != nil {
+		return nil, eris.Wrap(err, "error creating azure storage client")
+	}
+	return &Azure{
+		client: client,
+	}, nil
+}
+
+// ExtractAzureContainerAndPrefix extracts account host, container name and prefix path from provided uri.
+// after processing of uri wasbs://container@account.blob.core.windows.net/2/30357ed2eaac4f2cacdbcd0e06e9e48a/artifacts
+// result will be:
+// - host = account.blob.core.windows.net
+// - container = container
+// - prefix = 2/30357ed2eaac4f2cacdbcd0e06e9e48a/artifacts
+func ExtractAzureContainerAndPrefix(uri string) (string, string, string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", "", "", eris.Wrapf(err, "error parsing provided uri: %s", uri)
+	}
+	if u.User == nil || u.User.Username() == "" {
+		return "", "", "", eris.Errorf("container name is missing in provided uri: %s", uri)
+	}
+	return u.Host, u.User.Username(), strings.TrimLeft(u.Path, "/"), nil
+}
+
+// List implements ArtifactStorageProvider interface.
+func (s Azure) List(ctx context.Context, artifactURI, path string) ([]ArtifactObject, error) {
+	// 1. process input parameters.
+	host, container, rootPrefix, err := ExtractAzureContainerAndPrefix(artifactURI)
+	if err != nil {
+		return nil, eris.Wrap(err, "error extracting container and prefix from provided uri")
+	}
+	prefix := filepath.Join(rootPrefix, path)
+	if prefix != "" {
+		prefix = prefix + "/"
+	}
+
+	// 2. read data from azure storage.
+	blobs, prefixes, err := s.client.ListBlobs(ctx, host, container, prefix, "/")
+	if err != nil {
+		return nil, eris.Wrap(err, "error listing blobs")
+	}
+
+	log.Debugf("got %d directories from Azure storage for container %q and prefix %q", len(prefixes), container, prefix)
+	artifactList := make([]ArtifactObject, 0, len(prefixes)+len(blobs))
+	for _, dir := range prefixes {
+		relPath, err := filepath.Rel(rootPrefix, dir)
+		if err != nil {
+			return nil, eris.Wrapf(err, "error getting relative path for dir: %s", dir)
+		}
+		artifactList = append(artifactList, ArtifactObject{
+			Path:  relPath,
+			Size:  0,
+			IsDir: true,
+		})
+	}
+
+	log.Debugf("got %d objects from Azure storage for container %q and prefix %q", len(blobs), container, prefix)
+	for _, blob := range blobs {
+		relPath, err := filepath.Rel(rootPrefix, blob.Name)
+		if err != nil {
+			return nil, eris.Wrapf(err, "error getting relative path for object: %s", blob.Name)
+		}
+		artifactList = append(artifactList, ArtifactObject{
+			Path:  relPath,
+			Size:  blob.Size,
+			IsDir: false,
+		})
+	}
+
+	return artifactList, nil
+}
+
+// Get returns file content at the storage location.
+func (s Azure) Get(ctx context.Context, artifactURI, path string) (io.ReadCloser, error) {
+	// 1. process input parameters.
+	host, container, prefix, err := ExtractAzureContainerAndPrefix(artifactURI)
+	if err != nil {
+		return nil, eris.Wrap(err, "error extracting container and prefix from provided uri")
+	}
+
+	// 2. get blob from azure storage.
+	reader, err := s.client.GetBlob(ctx, host, container, filepath.Join(prefix, path))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, eris.Wrap(fs.ErrNotExist, "object does not exist")
+		}
+		return nil, eris.Wrap(err, "error getting object")
+	}
+
+	return reader, nil
+}
+
+// Put writes content of the provided io.Reader into the object at the storage location.
+func (s Azure) Put(ctx context.Context, artifactURI, path string, reader io.Reader) error {
+	// 1. process input parameters.
+	host, container, prefix, err := ExtractAzureContainerAndPrefix(artifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error extracting container and prefix from provided uri")
+	}
+
+	// 2. write blob into azure storage.
+	if err := s.client.PutBlob(ctx, host, container, filepath.Join(prefix, path), reader); err != nil {
+		return eris.Wrap(err, "error putting object")
+	}
+
+	return nil
+}
+
+// Delete removes the object or all the objects under the path at the storage location.
+func (s Azure) Delete(ctx context.Context, artifactURI, path string) error {
+	// 1. process input parameters.
+	host, container, prefix, err := ExtractAzureContainerAndPrefix(artifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error extracting container and prefix from provided uri")
+	}
+	name := filepath.Join(prefix, path)
+
+	// 2. delete the object itself.
+	if err := s.client.DeleteBlob(ctx, host, container, name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return eris.Wrapf(err, "error deleting object: %s", name)
+	}
+
+	// 3. delete all the objects under the path.
+	blobs, _, err := s.client.ListBlobs(ctx, host, container, name+"/", "")
+	if err != nil {
+		return eris.Wrap(err, "error listing blobs")
+	}
+	for _, blob := range blobs {
+		if err := s.client.DeleteBlob(ctx, host, container, blob.Name); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return eris.Wrapf(err, "error deleting object: %s", blob.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,256 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
+	"github.com/rotisserie/eris"
+)
+
+const (
+	// azureBlockSize is a size of the block used to upload blobs.
+	azureBlockSize = 4 * 1024 * 1024
+	// azureDefaultEndpointSuffix is an endpoint suffix of the storage accounts in the public Azure cloud.
+	azureDefaultEndpointSuffix = "core.windows.net"
+)
+
+// AzureBlob represents blob returned by the list operation.
+type AzureBlob struct {
+	Name string
+	Size int64
+}
+
+// AzureClient represents a client of Azure Blob Storage. Requests are authorized with the connection string,
+// with the account key (Shared Key) or with Microsoft Entra ID credentials otherwise, which are picked up
+// from the environment, workload identity, managed identity or Azure CLI.
+type AzureClient struct {
+	// client is created from the connection string and is used for all the storage accounts.
+	client *azblob.Client
+	// accountKey and credential are used to create clients of the storage accounts on demand.
+	accountKey string
+	credential azcore.TokenCredential
+	options    *azblob.ClientOptions
+	mutex      sync.Mutex
+	clients    map[string]*azblob.Client
+}
+
+// NewAzureClient creates new Azure Blob Storage client from provided connection string and account key.
+// Both of them are optional, connection string takes precedence over account key.
+func NewAzureClient(connectionString, accessKey string) (*AzureClient, error) {
+	client := AzureClient{
+		accountKey: accessKey,
+		options: &azblob.ClientOptions{
+			ClientOptions: azcore.ClientOptions{
+				Transport: newHTTPClient(),
+			},
+		},
+		clients: map[string]*azblob.Client{},
+	}
+
+	switch {
+	case connectionString != "":
+		connectionClient, err := azblob.NewClientFromConnectionString(connectionString, client.options)
+		if err != nil {
+			return nil, eris.Wrap(err, "error creating azure storage client from connection string")
+		}
+		client.client = connectionClient
+	case accessKey != "":
+		// account name is known only from the artifact URI, so the key is just validated here.
+		if _, err := azblob.NewSharedKeyCredential("", accessKey); err != nil {
+			return nil, eris.Wrap(err, "error decoding azure storage account key")
+		}
+	default:
+		credential, err := azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{
+			ClientOptions: client.options.ClientOptions,
+		})
+		if err != nil {
+			return nil, eris.Wrap(err, "error creating azure credential")
+		}
+		client.credential = credential
+	}
+	return &client, nil
+}
+
+// CreateContainer creates new container in the storage account identified by host.
+func (c *AzureClient) CreateContainer(ctx context.Context, host, container string) error {
+	client, err := c.getClient(host)
+	if err != nil {
+		return err
+	}
+	if _, err := client.CreateContainer(ctx, container, nil); err != nil {
+		return eris.Wrapf(convertAzureError(err), "error creating container: %s", container)
+	}
+	return nil
+}
+
+// DeleteContainer deletes the container together with all its blobs.
+func (c *AzureClient) DeleteContainer(ctx context.Context, host, container string) error {
+	client, err := c.getClient(host)
+	if err != nil {
+		return err
+	}
+	if _, err := client.DeleteContainer(ctx, container, nil); err != nil {
+		return eris.Wrapf(convertAzureError(err), "error deleting container: %s", container)
+	}
+	return nil
+}
+
+// ListBlobs returns blobs and, when delimiter is provided, virtual directories under the prefix.
+func (c *AzureClient) ListBlobs(
+	ctx context.Context, host, containerName, prefix, delimiter string,
+) ([]AzureBlob, []string, error) {
+	client, err := c.getClient(host)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		blobs    []AzureBlob
+		prefixes []string
+	)
+	if delimiter == "" {
+		pager := client.NewListBlobsFlatPager(containerName, &azblob.ListBlobsFlatOptions{Prefix: &prefix})
+		for pager.More() {
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				return nil, nil, eris.Wrapf(
+					convertAzureError(err), "error listing blobs of container: %s", containerName,
+				)
+			}
+			for _, blob := range page.Segment.BlobItems {
+				blobs = append(blobs, newAzureBlob(blob.Name, blob.Properties))
+			}
+		}
+		return blobs, nil, nil
+	}
+
+	pager := client.ServiceClient().NewContainerClient(containerName).NewListBlobsHierarchyPager(
+		delimiter, &container.ListBlobsHierarchyOptions{Prefix: &prefix},
+	)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return nil, nil, eris.Wrapf(convertAzureError(err), "error listing blobs of container: %s", containerName)
+		}
+		for _, blob := range page.Segment.BlobItems {
+			blobs = append(blobs, newAzureBlob(blob.Name, blob.Properties))
+		}
+		for _, blobPrefix := range page.Segment.BlobPrefixes {
+			if blobPrefix.Name != nil {
+				prefixes = append(prefixes, *blobPrefix.Name)
+			}
+		}
+	}
+	return blobs, prefixes, nil
+}
+
+// GetBlob returns content of the blob.
+func (c *AzureClient) GetBlob(ctx context.Context, host, container, name string) (io.ReadCloser, error) {
+	client, err := c.getClient(host)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.DownloadStream(ctx, container, name, nil)
+	if err != nil {
+		return nil, eris.Wrapf(convertAzureError(err), "error getting blob: %s", name)
+	}
+	return resp.Body, nil
+}
+
+// PutBlob writes content of the reader into the blob, the content is uploaded block by block.
+func (c *AzureClient) PutBlob(ctx context.Context, host, container, name string, reader io.Reader) error {
+	client, err := c.getClient(host)
+	if err != nil {
+		return err
+	}
+	if _, err := client.UploadStream(ctx, container, name, reader, &azblob.UploadStreamOptions{
+		BlockSize: azureBlockSize,
+	}); err != nil {
+		return eris.Wrapf(convertAzureError(err), "error putting blob: %s", name)
+	}
+	return nil
+}
+
+// DeleteBlob deletes the blob.
+func (c *AzureClient) DeleteBlob(ctx context.Context, host, container, name string) error {
+	client, err := c.getClient(host)
+	if err != nil {
+		return err
+	}
+	if _, err := client.DeleteBlob(ctx, container, name, nil); err != nil {
+		return eris.Wrapf(convertAzureError(err), "error deleting blob: %s", name)
+	}
+	return nil
+}
+
+// getClient returns client of the storage account identified by host. Data Lake Storage `dfs` hosts
+// are served through the blob endpoint of the same account.
+func (c *AzureClient) getClient(host string) (*azblob.Client, error) {
+	if c.client != nil {
+		return c.client, nil
+	}
+
+	accountName, rest, _ := strings.Cut(host, ".")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if client, ok := c.clients[accountName]; ok {
+		return client, nil
+	}
+
+	// host looks like <account>.<blob|dfs>.<endpoint suffix>.
+	_, endpointSuffix, _ := strings.Cut(rest, ".")
+	if endpointSuffix == "" {
+		endpointSuffix = azureDefaultEndpointSuffix
+	}
+	serviceURL := fmt.Sprintf("https://%s.blob.%s/", accountName, endpointSuffix)
+
+	var (
+		client *azblob.Client
+		err    error
+	)
+	if c.accountKey != "" {
+		credential, credentialErr := azblob.NewSharedKeyCredential(accountName, c.accountKey)
+		if credentialErr != nil {
+			return nil, eris.Wrap(credentialErr, "error creating azure shared key credential")
+		}
+		client, err = azblob.NewClientWithSharedKeyCredential(serviceURL, credential, c.options)
+	} else {
+		client, err = azblob.NewClient(serviceURL, c.credential, c.options)
+	}
+	if err != nil {
+		return nil, eris.Wrapf(err, "error creating azure storage client for account: %s", accountName)
+	}
+	c.clients[accountName] = client
+	return client, nil
+}
+
+// newAzureBlob creates AzureBlob from the listed blob item.
+func newAzureBlob(name *string, properties *container.BlobProperties) AzureBlob {
+	blob := AzureBlob{}
+	if name != nil {
+		blob.Name = *name
+	}
+	if properties != nil && properties.ContentLength != nil {
+		blob.Size = *properties.ContentLength
+	}
+	return blob
+}
+
+// convertAzureError reports not found resources as fs.ErrNotExist and keeps other errors as is.
+func convertAzureError(err error) error {
+	var responseError *azcore.ResponseError
+	if errors.As(err, &responseError) && responseError.StatusCode == http.StatusNotFound {
+		return eris.Wrap(fs.ErrNotExist, responseError.Error())
+	}
+	return err
+}
@@ -0,0 +1,233 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAzureServer is an in-memory implementation of the subset of Azure Blob Storage REST API.
+type fakeAzureServer struct {
+	sync.Mutex
+	blobs  map[string][]byte
+	blocks map[string][]byte
+}
+
+func (s *fakeAzureServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.Lock()
+	defer s.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "SharedKey account:") ||
+		r.Header.Get("X-Ms-Version") == "" || r.Header.Get("X-Ms-Date") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	// path looks like /account/container/name.
+	name := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 3)[2:]
+	query := r.URL.Query()
+	switch {
+	case r.Method == http.MethodGet && query.Get("comp") == "list":
+		s.list(w, query.Get("prefix"), query.Get("delimiter"), query.Get("marker"))
+	case r.Method == http.MethodGet:
+		content, ok := s.blobs[name[0]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		//nolint:errcheck
+		w.Write(content)
+	case r.Method == http.MethodPut && query.Get("comp") == "block":
+		body, _ := io.ReadAll(r.Body)
+		s.blocks[name[0]+query.Get("blockid")] = body
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPut && query.Get("comp") == "blocklist":
+		var blockList struct {
+			Latest []string `xml:"Latest"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := xml.Unmarshal(body, &blockList); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var content []byte
+		for _, blockID := range blockList.Latest {
+			content = append(content, s.blocks[name[0]+blockID]...)
+		}
+		s.blobs[name[0]] = content
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodPut && r.Header.Get("X-Ms-Blob-Type") == "BlockBlob":
+		body, _ := io.ReadAll(r.Body)
+		s.blobs[name[0]] = body
+		w.WriteHeader(http.StatusCreated)
+	case r.Method == http.MethodDelete:
+		if _, ok := s.blobs[name[0]]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		delete(s.blobs, name[0])
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		//nolint:errcheck
+		w.Write([]byte("<Error><Code>UnsupportedOperation</Code><Message>unsupported</Message></Error>"))
+	}
+}
+
+// list lists blobs returning a single item per page to exercise pagination.
+func (s *fakeAzureServer) list(w http.ResponseWriter, prefix, delimiter, marker string) {
+	items := map[string]bool{}
+	for name := range s.blobs {
+		rest, ok := strings.CutPrefix(name, prefix)
+		if !ok {
+			continue
+		}
+		if dir, _, ok := strings.Cut(rest, delimiter); ok && delimiter != "" {
+			items[prefix+dir+delimiter] = true
+			continue
+		}
+		items[name] = false
+	}
+	names := make([]string, 0, len(items))
+	for name := range items {
+		if name > marker {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var response bytes.Buffer
+	response.WriteString("<EnumerationResults><Blobs>")
+	if len(names) > 0 {
+		if items[names[0]] {
+			fmt.Fprintf(&response, "<BlobPrefix><Name>%s</Name></BlobPrefix>", names[0])
+		} else {
+			fmt.Fprintf(
+				&response, "<Blob><Name>%s</Name><Properties><Content-Length>%d</Content-Length></Properties></Blob>",
+				names[0], len(s.blobs[names[0]]),
+			)
+		}
+	}
+	response.WriteString("</Blobs><NextMarker>")
+	if len(names) > 1 {
+		response.WriteString(names[0])
+	}
+	response.WriteString("</NextMarker></EnumerationResults>")
+	//nolint:errcheck
+	w.Write(response.Bytes())
+}
+
+func newTestAzure(t *testing.T) (*Azure, *fakeAzureServer) {
+	server := &fakeAzureServer{blobs: map[string][]byte{}, blocks: map[string][]byte{}}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+
+	storage, err := NewAzure(Options{
+		AzureStorageConnectionStringOption: fmt.Sprintf(
+			"DefaultEndpointsProtocol=http;AccountName=account;AccountKey=%s;BlobEndpoint=%s/account;",
+			base64.StdEncoding.EncodeToString([]byte("key")), httpServer.URL,
+		),
+	})
+	require.Nil(t, err)
+	return storage, server
+}
+
+func TestAzure_Ok(t *testing.T) {
+	storage, server := newTestAzure(t)
+	artifactURI := "wasbs://container@account.blob.core.windows.net/1/run/artifacts"
+
+	// put small and big artifacts, the big one is uploaded block by block.
+	bigContent := bytes.Repeat([]byte("x"), 2*azureBlockSize+1)
+	require.Nil(t, storage.Put(context.Background(), artifactURI, "file.txt", strings.NewReader("content")))
+	require.Nil(t, storage.Put(context.Background(), artifactURI, "model/model.pkl", bytes.NewReader(bigContent)))
+	assert.Len(t, server.blocks, 3)
+
+	// list root and sub directory.
+	artifacts, err := storage.List(context.Background(), artifactURI, "")
+	require.Nil(t, err)
+	assert.Equal(t, []ArtifactObject{
+		{Path: "model", IsDir: true},
+		{Path: "file.txt", Size: 7},
+	}, artifacts)
+	artifacts, err = storage.List(context.Background(), artifactURI, "model")
+	require.Nil(t, err)
+	assert.Equal(t, []ArtifactObject{{Path: "model/model.pkl", Size: int64(len(bigContent))}}, artifacts)
+
+	// get artifacts.
+	reader, err := storage.Get(context.Background(), artifactURI, "model/model.pkl")
+	require.Nil(t, err)
+	content, err := io.ReadAll(reader)
+	require.Nil(t, err)
+	require.Nil(t, reader.Close())
+	assert.Equal(t, bigContent, content)
+
+	// delete all the artifacts.
+	require.Nil(t, storage.Delete(context.Background(), artifactURI, ""))
+	assert.Empty(t, server.blobs)
+}
+
+func TestAzure_Error(t *testing.T) {
+	storage, _ := newTestAzure(t)
+
+	_, err := storage.Get(
+		context.Background(), "wasbs://container@account.blob.core.windows.net/1/run/artifacts", "not-existing",
+	)
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+
+	_, err = storage.List(context.Background(), "wasbs://account.blob.core.windows.net/1/run/artifacts", "")
+	assert.ErrorContains(t, err, "container name is missing in provided uri")
+}
+
+func TestAzureClient_SharedAccessSignature(t *testing.T) {
+	var requestURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// blob names are escaped by the client, so the decoded path is checked.
+		requestURL = r.URL.Path + "?" + r.URL.RawQuery
+		if r.Header.Get("Authorization") != "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		//nolint:errcheck
+		w.Write([]byte("content"))
+	}))
+	defer server.Close()
+
+	client, err := NewAzureClient(
+		fmt.Sprintf("BlobEndpoint=%s;SharedAccessSignature=sv=2021-08-06&sig=abc", server.URL), "",
+	)
+	require.Nil(t, err)
+	reader, err := client.GetBlob(context.Background(), "account.dfs.core.windows.net", "container", "path/file.txt")
+	require.Nil(t, err)
+	require.Nil(t, reader.Close())
+	assert.Equal(t, "/container/path/file.txt?sig=abc&sv=2021-08-06", requestURL)
+}
+
+func TestAzureClient_AccountKey(t *testing.T) {
+	_, err := NewAzureClient("", "not base64")
+	assert.ErrorContains(t, err, "error decoding azure storage account key")
+
+	client, err := NewAzureClient("", base64.StdEncoding.EncodeToString([]byte("key")))
+	require.Nil(t, err)
+
+	// clients are created per account, dfs hosts are served through the blob endpoint.
+	accountClient, err := client.getClient("account.dfs.core.chinacloudapi.cn")
+	require.Nil(t, err)
+	assert.Equal(t, "https://account.blob.core.chinacloudapi.cn/", accountClient.URL())
+	sameClient, err := client.getClient("account.blob.core.chinacloudapi.cn")
+	require.Nil(t, err)
+	assert.Same(t, accountClient, sameClient)
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/rotisserie/eris"
+	"gopkg.in/yaml.v3"
+)
+
+// RootConfig represents options of the storage used for artifact uris under the prefix.
+type RootConfig struct {
+	Prefix  string            `yaml:"prefix"`
+	Options map[string]string `yaml:"options"`
+}
+
+// YamlConfig represents artifact storage configuration in YAML format.
+type YamlConfig struct {
+	Storages []RootConfig `yaml:"storages"`
+}
+
+// matches checks that artifact uri is the prefix itself or is located under the prefix.
+func (c RootConfig) matches(artifactURI string) bool {
+	prefix := strings.TrimSuffix(c.Prefix, "/")
+	return artifactURI == prefix || strings.HasPrefix(artifactURI, prefix+"/")
+}
+
+// LoadConfig loads per artifact root storage configuration from given configuration file.
+func LoadConfig(configFilePath string) ([]RootConfig, error) {
+	//nolint:gosec
+	data, err := os.ReadFile(configFilePath)
+	if err != nil {
+		return nil, eris.Wrap(err, "error reading artifact storage configuration file")
+	}
+
+	switch filepath.Ext(configFilePath) {
+	case ".yaml", ".yml":
+		roots, err := parseConfigFromYaml(data)
+		if err != nil {
+			return nil, eris.Wrap(err, "error parsing artifact storage configuration from yaml")
+		}
+		return roots, nil
+	}
+	return nil, eris.Errorf("unsupported artifact storage configuration file type")
+}
+
+// parseConfigFromYaml parse configuration from ".yaml", ".yml" files and validates it.
+func parseConfigFromYaml(content []byte) ([]RootConfig, error) {
+	config := YamlConfig{}
+	if err := yaml.Unmarshal(content, &config); err != nil {
+		return nil, eris.Wrap(err, "error unmarshaling data from yaml file")
+	}
+
+	envRegex := regexp.MustCompile(`^\$\{(.*)\}$`)
+	for _, root := range config.Storages {
+		parsed, err := url.Parse(root.Prefix)
+		if err != nil {
+			return nil, eris.Wrapf(err, "error parsing storage prefix: %s", root.Prefix)
+		}
+		if parsed.Scheme == "" {
+			return nil, eris.Errorf("storage prefix has to be an absolute uri: %s", root.Prefix)
+		}
+		if _, ok := GetBackend(parsed.Scheme); !ok {
+			return nil, eris.Errorf("unsupported schema of storage prefix: %s", root.Prefix)
+		}
+		for name, value := range root.Options {
+			if !isOptionRegistered(name) {
+				return nil, eris.Errorf("unknown option %q of storage prefix: %s", name, root.Prefix)
+			}
+			// if a value format is ${PARAMETER_FROM_ENV} then try to load it from ENV.
+			if match := envRegex.FindStringSubmatch(value); match != nil {
+				value, ok := os.LookupEnv(match[1])
+				if !ok {
+					return nil, eris.Errorf("error reading option %q from ENV variable: %s", name, match[1])
+				}
+				root.Options[name] = value
+			}
+		}
+	}
+	return config.Storages, nil
+}
@@ -5,14 +5,14 @@ import (
 	"errors"
 	"io"
 	"io/fs"
+	"net/http"
 	"path/filepath"
+	"time"
 
 	"cloud.google.com/go/storage"
 	"github.com/rotisserie/eris"
 	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
-
-	"github.com/G-Research/fasttrackml/pkg/common/config"
 )
 
 // GSStorageName is a Google Storage name.
@@ -25,15 +25,31 @@ type GS struct {
 	client *storage.Client
 }
 
+// GSEndpointURIOption is an option holding Google Storage base endpoint url.
+const GSEndpointURIOption = "gs-endpoint-uri"
+
+func init() {
+	RegisterBackend(Backend{
+		Name:    "gs",
+		Schemes: []string{GSStorageName},
+		Options: []Option{
+			{Name: GSEndpointURIOption, Usage: "Google Storage base endpoint url", Hidden: true},
+		},
+		New: func(ctx context.Context, params BackendParams) (ArtifactStorageProvider, error) {
+			return NewGS(ctx, params.Options)
+		},
+	})
+}
+
 // NewGS creates new Google Storage instance.
-func NewGS(ctx context.Context, config *config.Config) (*GS, error) {
-	var options []option.ClientOption
-	if config.GSEndpointURI != "" {
+func NewGS(ctx context.Context, options Options) (*GS, error) {
+	var clientOptions []option.ClientOption
+	if endpointURI := options.Get(GSEndpointURIOption); endpointURI != "" {
 		// we use option.WithoutAuthentication() in order to make the GCS SDK work with our fake server.
 		// this should be changed if we ever need to use an alternative GCS implementation in a production setting.
-		options = append(options, option.WithEndpoint(config.GSEndpointURI), option.WithoutAuthentication())
+		clientOptions = append(clientOptions, option.WithEndpoint(endpointURI), option.WithoutAuthentication())
 	}
-	client, err := storage.NewClient(ctx, options...)
+	client, err := storage.NewClient(ctx, clientOptions...)
 	if err != nil {
 		return nil, eris.Wrap(err, "error creating GS storage client")
 	}
@@ -112,3 +128,79 @@ func (s GS) Get(ctx context.Context, artifactURI, path string) (io.ReadCloser, e
 
 	return reader, nil
 }
+
+// PresignGet implements ArtifactURLPresigner interface. Signing requires service account
+// credentials, either with a private key or with permission to sign blobs through IAM.
+func (s GS) PresignGet(ctx context.Context, artifactURI, path string, expiry time.Duration) (string, error) {
+	bucketName, prefix, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return "", eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+
+	url, err := s.client.Bucket(bucketName).SignedURL(filepath.Join(prefix, path), &storage.SignedURLOptions{
+		Method:  http.MethodGet,
+		Expires: time.Now().Add(expiry),
+		Scheme:  storage.SigningSchemeV4,
+	})
+	if err != nil {
+		return "", eris.Wrap(err, "error signing object url")
+	}
+	return url, nil
+}
+
+// Put writes content of the provided io.Reader into the object at the storage location.
+func (s GS) Put(ctx context.Context, artifactURI, path string, reader io.Reader) error {
+	// 1. process input parameters.
+	bucketName, prefix, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+
+	// 2. write object into gcp storage.
+	writer := s.client.Bucket(bucketName).Object(filepath.Join(prefix, path)).NewWriter(ctx)
+	if _, err := io.Copy(writer, reader); err != nil {
+		//nolint:errcheck
+		writer.Close()
+		return eris.Wrap(err, "error writing object")
+	}
+	if err := writer.Close(); err != nil {
+		return eris.Wrap(err, "error closing object writer")
+	}
+
+	return nil
+}
+
+// Delete removes the object or all the objects under the path at the storage location.
+func (s GS) Delete(ctx context.Context, artifactURI, path string) error {
+	// 1. process input parameters.
+	bucketName, prefix, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+	name := filepath.Join(prefix, path)
+	bucket := s.client.Bucket(bucketName)
+
+	// 2. delete the object itself.
+	if err := bucket.Object(name).Delete(ctx); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return eris.Wrapf(err, "error deleting object: %s", name)
+	}
+
+	// 3. delete all the objects under the path.
+	it := bucket.Objects(ctx, &storage.Query{
+		Prefix: name + "/",
+	})
+	for {
+		object, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return eris.Wrap(err, "error getting object information")
+		}
+		if err := bucket.Object(object.Name).Delete(ctx); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+			return eris.Wrapf(err, "error deleting object: %s", object.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+)
+
+// HTTP storage names.
+const (
+	HTTPStorageName  = "http"
+	HTTPSStorageName = "https"
+)
+
+// ErrReadOnlyStorage is returned when artifacts are written into, or deleted from, read-only storage.
+var ErrReadOnlyStorage = errors.New("artifact storage is read-only")
+
+// HTTP represents read-only adapter to work with artifacts served by a generic HTTP(S) server.
+// Directories are listed by parsing HTML index pages, like the ones generated by nginx `autoindex`,
+// Apache `mod_autoindex` or `python -m http.server`.
+type HTTP struct {
+	client   *http.Client
+	prefix   string
+	username string
+	password string
+	token    string
+}
+
+// HTTP client timeouts. There is no overall timeout, as downloads of large artifacts are streamed
+// to the clients, but the server has to accept the connection and start responding in time.
+const (
+	httpDialTimeout           = 10 * time.Second
+	httpTLSHandshakeTimeout   = 10 * time.Second
+	httpResponseHeaderTimeout = 30 * time.Second
+)
+
+// HTTP storage options.
+const (
+	HTTPArtifactsUsernameOption = "http-artifacts-username"
+	HTTPArtifactsPasswordOption = "http-artifacts-password"
+	HTTPArtifactsTokenOption    = "http-artifacts-token"
+)
+
+func init() {
+	RegisterBackend(Backend{
+		Name:    "http",
+		Schemes: []string{HTTPStorageName, HTTPSStorageName},
+		Options: []Option{
+			{Name: HTTPArtifactsUsernameOption, Usage: "BasicAuth username of the HTTP(S) artifact server"},
+			{Name: HTTPArtifactsPasswordOption, Usage: "BasicAuth password of the HTTP(S) artifact server"},
+			{Name: HTTPArtifactsTokenOption, Usage: "Bearer token of the HTTP(S) artifact server"},
+		},
+		ReadOnly:     true,
+		RequiresRoot: true,
+		New: func(ctx context.Context, params BackendParams) (ArtifactStorageProvider, error) {
+			return NewHTTP(params.Prefix, params.Options)
+		},
+	})
+}
+
+// NewHTTP creates new HTTP storage instance for the artifacts under provided prefix.
+func NewHTTP(prefix string, options Options) (*HTTP, error) {
+	return &HTTP{
+		client:   newHTTPClient(),
+		prefix:   strings.TrimSuffix(prefix, "/"),
+		username: options.Get(HTTPArtifactsUsernameOption),
+		password: options.Get(HTTPArtifactsPasswordOption),
+		token:    options.Get(HTTPArtifactsTokenOption),
+	}, nil
+}
+
+// newHTTPClient creates HTTP client with the connection timeouts, which is shared by the remote storages.
+func newHTTPClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{Timeout: httpDialTimeout}).DialContext
+	transport.TLSHandshakeTimeout = httpTLSHandshakeTimeout
+	transport.ResponseHeaderTimeout = httpResponseHeaderTimeout
+	return &http.Client{Transport: transport}
+}
+
+// List implements ArtifactStorageProvider interface.
+func (s HTTP) List(ctx context.Context, artifactURI, path string) ([]ArtifactObject, error) {
+	// 1. read index page of the directory.
+	dirURL, err := s.getURL(artifactURI, path)
+	if err != nil {
+		return nil, err
+	}
+	dirURL.Path += "/"
+	resp, err := s.do(ctx, http.MethodGet, dirURL)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return []ArtifactObject{}, nil
+		}
+		return nil, eris.Wrap(err, "error getting directory index")
+	}
+	//nolint:errcheck
+	defer resp.Body.Close()
+	document, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, eris.Wrap(err, "error parsing directory index")
+	}
+
+	// 2. collect direct children of the directory, links to parent directory,
+	// sorting links and external links are skipped.
+	artifactList := []ArtifactObject{}
+	seen := map[string]struct{}{}
+	for _, href := range document.Find("a[href]").Map(func(_ int, selection *goquery.Selection) string {
+		return selection.AttrOr("href", "")
+	}) {
+		linkURL, err := dirURL.Parse(href)
+		if err != nil || linkURL.Host != dirURL.Host || linkURL.RawQuery != "" {
+			continue
+		}
+		name, ok := strings.CutPrefix(linkURL.Path, dirURL.Path)
+		isDir := strings.HasSuffix(name, "/")
+		name = strings.TrimSuffix(name, "/")
+		if !ok || name == "" || strings.Contains(name, "/") {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+
+		object := ArtifactObject{
+			Path:  strings.TrimPrefix(path+"/"+name, "/"),
+			IsDir: isDir,
+		}
+		if !isDir {
+			size, err := s.getSize(ctx, linkURL)
+			if err != nil {
+				return nil, eris.Wrapf(err, "error getting size of object: %s", object.Path)
+			}
+			object.Size = size
+		}
+		artifactList = append(artifactList, object)
+	}
+	log.Debugf("got %d objects from HTTP storage for url %q", len(artifactList), dirURL)
+
+	return artifactList, nil
+}
+
+// Get returns file content at the storage location.
+func (s HTTP) Get(ctx context.Context, artifactURI, path string) (io.ReadCloser, error) {
+	objectURL, err := s.getURL(artifactURI, path)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(ctx, http.MethodGet, objectURL)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, eris.Wrap(fs.ErrNotExist, "object does not exist")
+		}
+		return nil, eris.Wrap(err, "error getting object")
+	}
+	// servers redirect directories to their index pages, which are not objects.
+	if strings.HasSuffix(resp.Request.URL.Path, "/") {
+		//nolint:errcheck,gosec
+		resp.Body.Close()
+		return nil, eris.Wrap(fs.ErrNotExist, "object does not exist")
+	}
+	return resp.Body, nil
+}
+
+// Put implements ArtifactStorageProvider interface. HTTP storage is read-only.
+func (s HTTP) Put(ctx context.Context, artifactURI, path string, reader io.Reader) error {
+	return eris.Wrapf(ErrReadOnlyStorage, "error putting object into: %s", artifactURI)
+}
+
+// Delete implements ArtifactStorageProvider interface. HTTP storage is read-only.
+func (s HTTP) Delete(ctx context.Context, artifactURI, path string) error {
+	return eris.Wrapf(ErrReadOnlyStorage, "error deleting object from: %s", artifactURI)
+}
+
+// getSize returns size of the object from `Content-Length` header, or zero when it is unknown.
+func (s HTTP) getSize(ctx context.Context, objectURL *url.URL) (int64, error) {
+	resp, err := s.do(ctx, http.MethodHead, objectURL)
+	if err != nil {
+		return 0, err
+	}
+	//nolint:errcheck
+	defer resp.Body.Close()
+	return max(resp.ContentLength, 0), nil
+}
+
+// getURL returns url of the object at the storage location.
+func (s HTTP) getURL(artifactURI, objectPath string) (*url.URL, error) {
+	u, err := url.Parse(artifactURI)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error parsing provided uri: %s", artifactURI)
+	}
+	u.Path = path.Join("/", u.Path, objectPath)
+	u.RawPath = ""
+	return u, nil
+}
+
+// do makes authorized request to the server. Not found objects are reported as fs.ErrNotExist,
+// other unsuccessful responses are converted into errors.
+func (s HTTP) do(ctx context.Context, method string, u *url.URL) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return nil, eris.Wrap(err, "error creating request")
+	}
+	// credentials are only sent to the server of the configured artifact root.
+	if s.isUnderPrefix(u) {
+		switch {
+		case s.token != "":
+			req.Header.Set("Authorization", "Bearer "+s.token)
+		case s.username != "":
+			req.SetBasicAuth(s.username, s.password)
+		}
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, eris.Wrap(err, "error making request")
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	//nolint:errcheck,gosec
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, eris.Wrapf(fs.ErrNotExist, "object does not exist: %s", u.Path)
+	}
+	return nil, eris.Errorf("server responded with %d to %s %s", resp.StatusCode, method, u.Path)
+}
+
+// isUnderPrefix checks that url is the prefix of the storage itself or is located under it.
+func (s HTTP) isUnderPrefix(u *url.URL) bool {
+	if s.prefix == "" {
+		return false
+	}
+	return u.String() == s.prefix || strings.HasPrefix(u.String(), s.prefix+"/")
+}
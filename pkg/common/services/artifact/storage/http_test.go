@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHTTP(t *testing.T) (*HTTP, string) {
+	root := t.TempDir()
+	require.Nil(t, os.MkdirAll(filepath.Join(root, "1", "run", "artifacts", "model"), 0o755))
+	require.Nil(t, os.WriteFile(filepath.Join(root, "1", "run", "artifacts", "file.txt"), []byte("content"), 0o600))
+	require.Nil(t, os.WriteFile(
+		filepath.Join(root, "1", "run", "artifacts", "model", "model.pkl"), []byte("model content"), 0o600,
+	))
+
+	fileServer := http.FileServer(http.Dir(root))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fileServer.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	storage, err := NewHTTP(server.URL+"/1/", Options{HTTPArtifactsTokenOption: "token"})
+	require.Nil(t, err)
+	return storage, server.URL + "/1/run/artifacts"
+}
+
+func TestHTTP_Ok(t *testing.T) {
+	storage, artifactURI := newTestHTTP(t)
+
+	artifacts, err := storage.List(context.Background(), artifactURI, "")
+	require.Nil(t, err)
+	assert.Equal(t, []ArtifactObject{
+		{Path: "file.txt", Size: 7},
+		{Path: "model", IsDir: true},
+	}, artifacts)
+
+	artifacts, err = storage.List(context.Background(), artifactURI, "model")
+	require.Nil(t, err)
+	assert.Equal(t, []ArtifactObject{{Path: "model/model.pkl", Size: 13}}, artifacts)
+
+	artifacts, err = storage.List(context.Background(), artifactURI, "not-existing")
+	require.Nil(t, err)
+	assert.Empty(t, artifacts)
+
+	reader, err := storage.Get(context.Background(), artifactURI, "model/model.pkl")
+	require.Nil(t, err)
+	content, err := io.ReadAll(reader)
+	require.Nil(t, err)
+	require.Nil(t, reader.Close())
+	assert.Equal(t, "model content", string(content))
+}
+
+func TestHTTP_Error(t *testing.T) {
+	storage, artifactURI := newTestHTTP(t)
+
+	_, err := storage.Get(context.Background(), artifactURI, "not-existing")
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+
+	_, err = storage.Get(context.Background(), artifactURI, "model")
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+
+	err = storage.Put(context.Background(), artifactURI, "file.txt", strings.NewReader("content"))
+	assert.True(t, errors.Is(err, ErrReadOnlyStorage))
+
+	err = storage.Delete(context.Background(), artifactURI, "")
+	assert.True(t, errors.Is(err, ErrReadOnlyStorage))
+
+	storage.token = "incorrect"
+	_, err = storage.List(context.Background(), artifactURI, "")
+	assert.ErrorContains(t, err, "server responded with 401")
+
+	// credentials are not sent outside of the prefix.
+	storage.token = "token"
+	_, err = storage.List(context.Background(), strings.Replace(artifactURI, "/1/", "/2/", 1), "")
+	assert.ErrorContains(t, err, "server responded with 401")
+}
@@ -23,6 +23,16 @@ const (
 // Local represents local file storage adapter to work with artifacts.
 type Local struct{}
 
+func init() {
+	RegisterBackend(Backend{
+		Name:    "local",
+		Schemes: []string{"", LocalStorageName},
+		New: func(ctx context.Context, params BackendParams) (ArtifactStorageProvider, error) {
+			return NewLocal(params.Config)
+		},
+	})
+}
+
 // NewLocal creates new Local storage instance.
 func NewLocal(config *config.Config) (*Local, error) {
 	return &Local{}, nil
@@ -95,3 +105,46 @@ func (s Local) Get(ctx context.Context, artifactURI, path string) (io.ReadCloser
 
 	return file, nil
 }
+
+// Put writes content of the provided io.Reader into the file at the storage location.
+func (s Local) Put(ctx context.Context, artifactURI, path string, reader io.Reader) error {
+	// 1. trim the `file://` prefix if it exists.
+	artifactURI = strings.TrimPrefix(artifactURI, "file://")
+
+	// 2. process `path` parameter and create parent directories.
+	absPath := filepath.Join(artifactURI, path)
+	if err := os.MkdirAll(filepath.Dir(absPath), os.ModePerm); err != nil {
+		return eris.Wrap(err, "unable to create parent directories")
+	}
+
+	// 3. write the file.
+	// artifactURI and path are validated by the caller
+	// #nosec G304
+	file, err := os.Create(absPath)
+	if err != nil {
+		return eris.Wrap(err, "unable to create file")
+	}
+	if _, err := io.Copy(file, reader); err != nil {
+		//nolint:errcheck
+		file.Close()
+		return eris.Wrap(err, "unable to write file")
+	}
+	if err := file.Close(); err != nil {
+		return eris.Wrap(err, "unable to close file")
+	}
+
+	return nil
+}
+
+// Delete removes the file or the whole directory at the storage location.
+func (s Local) Delete(ctx context.Context, artifactURI, path string) error {
+	// 1. trim the `file://` prefix if it exists.
+	artifactURI = strings.TrimPrefix(artifactURI, "file://")
+
+	// 2. remove the file or the directory.
+	if err := os.RemoveAll(filepath.Join(artifactURI, path)); err != nil {
+		return eris.Wrap(err, "unable to remove path")
+	}
+
+	return nil
+}
@@ -2,9 +2,11 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -132,3 +134,51 @@ func TestLocal_ListArtifacts_Ok(t *testing.T) {
 		})
 	}
 }
+
+func TestLocal_PutArtifact_Ok(t *testing.T) {
+	// setup
+	runArtifactRoot := t.TempDir()
+
+	// invoke
+	storage, err := NewLocal(nil)
+	require.Nil(t, err)
+
+	err = storage.Put(
+		context.Background(), runArtifactRoot, "subdir/file.txt", strings.NewReader("artifact content"),
+	)
+	require.Nil(t, err)
+
+	// verify
+	// #nosec G304
+	content, err := os.ReadFile(filepath.Join(runArtifactRoot, "subdir", "file.txt"))
+	require.Nil(t, err)
+	assert.Equal(t, "artifact content", string(content))
+}
+
+func TestLocal_DeleteArtifact_Ok(t *testing.T) {
+	// setup
+	runArtifactRoot := t.TempDir()
+	err := os.MkdirAll(filepath.Join(runArtifactRoot, "subdir"), os.ModePerm)
+	require.Nil(t, err)
+	err = os.WriteFile(filepath.Join(runArtifactRoot, "subdir", "file.txt"), []byte("content"), fs.ModePerm)
+	require.Nil(t, err)
+	err = os.WriteFile(filepath.Join(runArtifactRoot, "file.txt"), []byte("content"), fs.ModePerm)
+	require.Nil(t, err)
+
+	// invoke
+	storage, err := NewLocal(nil)
+	require.Nil(t, err)
+
+	// verify deletion of single file.
+	require.Nil(t, storage.Delete(context.Background(), runArtifactRoot, "file.txt"))
+	_, err = os.Stat(filepath.Join(runArtifactRoot, "file.txt"))
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+
+	// verify deletion of directory.
+	require.Nil(t, storage.Delete(context.Background(), runArtifactRoot, "subdir"))
+	_, err = os.Stat(filepath.Join(runArtifactRoot, "subdir"))
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+
+	// verify deletion of non-existing artifact.
+	require.Nil(t, storage.Delete(context.Background(), runArtifactRoot, "non-existing"))
+}
@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+// MlflowArtifactsStorageName is a name of storage which proxies artifacts through the tracking server.
+const (
+	MlflowArtifactsStorageName = "mlflow-artifacts"
+)
+
+// MlflowArtifacts represents adapter to work with artifacts proxied through the tracking server.
+// `mlflow-artifacts:/<path>` URIs are resolved against the configured artifacts destination
+// and all the operations are delegated to the storage of that destination.
+type MlflowArtifacts struct {
+	destinationURI string
+	destination    ArtifactStorageProvider
+}
+
+func init() {
+	RegisterBackend(Backend{
+		Name:    "mlflow-artifacts",
+		Schemes: []string{MlflowArtifactsStorageName},
+		New: func(ctx context.Context, params BackendParams) (ArtifactStorageProvider, error) {
+			destination, err := params.Factory.GetStorage(ctx, params.Config.ArtifactsDestination)
+			if err != nil {
+				return nil, eris.Wrap(err, "error initializing artifacts destination storage")
+			}
+			return NewMlflowArtifacts(params.Config, destination)
+		},
+	})
+}
+
+// NewMlflowArtifacts creates new MlflowArtifacts storage instance.
+func NewMlflowArtifacts(config *config.Config, destination ArtifactStorageProvider) (*MlflowArtifacts, error) {
+	return &MlflowArtifacts{
+		destinationURI: config.ArtifactsDestination,
+		destination:    destination,
+	}, nil
+}
+
+// List implements ArtifactStorageProvider interface.
+func (s MlflowArtifacts) List(ctx context.Context, artifactURI, path string) ([]ArtifactObject, error) {
+	resolvedURI, err := s.resolve(artifactURI)
+	if err != nil {
+		return nil, err
+	}
+	return s.destination.List(ctx, resolvedURI, path)
+}
+
+// Get implements ArtifactStorageProvider interface.
+func (s MlflowArtifacts) Get(ctx context.Context, artifactURI, path string) (io.ReadCloser, error) {
+	resolvedURI, err := s.resolve(artifactURI)
+	if err != nil {
+		return nil, err
+	}
+	return s.destination.Get(ctx, resolvedURI, path)
+}
+
+// PresignGet implements ArtifactURLPresigner interface.
+func (s MlflowArtifacts) PresignGet(
+	ctx context.Context, artifactURI, path string, expiry time.Duration,
+) (string, error) {
+	presigner, ok := s.destination.(ArtifactURLPresigner)
+	if !ok {
+		return "", ErrPresignNotSupported
+	}
+	resolvedURI, err := s.resolve(artifactURI)
+	if err != nil {
+		return "", err
+	}
+	return presigner.PresignGet(ctx, resolvedURI, path, expiry)
+}
+
+// Put implements ArtifactStorageProvider interface.
+func (s MlflowArtifacts) Put(ctx context.Context, artifactURI, path string, reader io.Reader) error {
+	resolvedURI, err := s.resolve(artifactURI)
+	if err != nil {
+		return err
+	}
+	return s.destination.Put(ctx, resolvedURI, path, reader)
+}
+
+// Delete implements ArtifactStorageProvider interface.
+func (s MlflowArtifacts) Delete(ctx context.Context, artifactURI, path string) error {
+	resolvedURI, err := s.resolve(artifactURI)
+	if err != nil {
+		return err
+	}
+	return s.destination.Delete(ctx, resolvedURI, path)
+}
+
+// resolve converts `mlflow-artifacts:/<path>` URI into the URI inside artifacts destination.
+func (s MlflowArtifacts) resolve(artifactURI string) (string, error) {
+	u, err := url.Parse(artifactURI)
+	if err != nil {
+		return "", eris.Wrapf(err, "error parsing artifact uri: %s", artifactURI)
+	}
+	if u.Scheme != MlflowArtifactsStorageName {
+		return "", eris.Errorf("unsupported artifact uri: %s", artifactURI)
+	}
+	path := strings.Trim(u.Path, "/")
+	if slices.Contains(strings.Split(path, "/"), "..") {
+		return "", eris.Errorf("artifact uri points outside of artifacts destination: %s", artifactURI)
+	}
+	if path == "" {
+		return s.destinationURI, nil
+	}
+	resolvedURI, err := url.JoinPath(s.destinationURI, path)
+	if err != nil {
+		return "", eris.Wrapf(err, "error resolving artifact uri: %s", artifactURI)
+	}
+	return resolvedURI, nil
+}
@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+func TestMlflowArtifacts_Resolve_Ok(t *testing.T) {
+	tests := []struct {
+		name        string
+		destination string
+		artifactURI string
+		resolvedURI string
+	}{
+		{
+			name:        "RootURI",
+			destination: "s3://bucket/artifacts",
+			artifactURI: "mlflow-artifacts:/",
+			resolvedURI: "s3://bucket/artifacts",
+		},
+		{
+			name:        "NestedURI",
+			destination: "s3://bucket/artifacts",
+			artifactURI: "mlflow-artifacts:/1/run-id/artifacts",
+			resolvedURI: "s3://bucket/artifacts/1/run-id/artifacts",
+		},
+		{
+			name:        "NestedURIWithAuthority",
+			destination: "/tmp/artifacts",
+			artifactURI: "mlflow-artifacts://localhost:5000/1/run-id/artifacts",
+			resolvedURI: "/tmp/artifacts/1/run-id/artifacts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage, err := NewMlflowArtifacts(&config.Config{ArtifactsDestination: tt.destination}, nil)
+			require.Nil(t, err)
+
+			resolvedURI, err := storage.resolve(tt.artifactURI)
+			require.Nil(t, err)
+			assert.Equal(t, tt.resolvedURI, resolvedURI)
+		})
+	}
+}
+
+func TestMlflowArtifacts_Resolve_Error(t *testing.T) {
+	tests := []struct {
+		name        string
+		artifactURI string
+	}{
+		{
+			name:        "UnsupportedScheme",
+			artifactURI: "s3://bucket/path",
+		},
+		{
+			name:        "PathOutsideOfDestination",
+			artifactURI: "mlflow-artifacts:/1/../../secret",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage, err := NewMlflowArtifacts(&config.Config{ArtifactsDestination: "/tmp/artifacts"}, nil)
+			require.Nil(t, err)
+
+			_, err = storage.resolve(tt.artifactURI)
+			assert.NotNil(t, err)
+		})
+	}
+}
@@ -39,6 +39,20 @@ func (_m *MockArtifactStorageFactoryProvider) GetStorage(ctx context.Context, ru
 	return r0, r1
 }
 
+// ValidateArtifactURI provides a mock function with given fields: artifactURI
+func (_m *MockArtifactStorageFactoryProvider) ValidateArtifactURI(artifactURI string) error {
+	ret := _m.Called(artifactURI)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(artifactURI)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewMockArtifactStorageFactoryProvider creates a new instance of MockArtifactStorageFactoryProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockArtifactStorageFactoryProvider(t interface {
@@ -14,6 +14,20 @@ type MockArtifactStorageProvider struct {
 	mock.Mock
 }
 
+// Delete provides a mock function with given fields: ctx, artifactURI, path
+func (_m *MockArtifactStorageProvider) Delete(ctx context.Context, artifactURI string, path string) error {
+	ret := _m.Called(ctx, artifactURI, path)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, artifactURI, path)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: ctx, artifactURI, path
 func (_m *MockArtifactStorageProvider) Get(ctx context.Context, artifactURI string, path string) (io.ReadCloser, error) {
 	ret := _m.Called(ctx, artifactURI, path)
@@ -66,6 +80,20 @@ func (_m *MockArtifactStorageProvider) List(ctx context.Context, artifactURI str
 	return r0, r1
 }
 
+// Put provides a mock function with given fields: ctx, artifactURI, path, reader
+func (_m *MockArtifactStorageProvider) Put(ctx context.Context, artifactURI string, path string, reader io.Reader) error {
+	ret := _m.Called(ctx, artifactURI, path, reader)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, io.Reader) error); ok {
+		r0 = rf(ctx, artifactURI, path, reader)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewMockArtifactStorageProvider creates a new instance of MockArtifactStorageProvider. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockArtifactStorageProvider(t interface {
@@ -0,0 +1,121 @@
+package storage
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"sync"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+// Option represents a configuration flag of artifact storage backend.
+type Option struct {
+	Name   string
+	Usage  string
+	Hidden bool
+	// EnvVars lists additional environment variables the option is read from.
+	EnvVars []string
+}
+
+// Options represents values of artifact storage backend options.
+type Options map[string]string
+
+// Get returns the value of the option or an empty string if the option is not set.
+func (o Options) Get(name string) string {
+	return o[name]
+}
+
+// BackendParams represents parameters passed to the constructor of artifact storage backend.
+type BackendParams struct {
+	// URI is the parsed artifact uri the storage is created for.
+	URI *url.URL
+	// Options holds the global option values merged with the ones configured for the artifact root.
+	Options Options
+	// Prefix is the prefix of the artifact root configuration matching the uri, empty when none matched.
+	Prefix string
+	// Config is the main service configuration.
+	Config *config.Config
+	// Factory allows backends to delegate to other storages.
+	Factory ArtifactStorageFactoryProvider
+}
+
+// Backend represents artifact storage backend.
+type Backend struct {
+	// Name is a human-readable name of the backend used in error messages.
+	Name string
+	// Schemes lists URI schemes handled by the backend.
+	Schemes []string
+	// Options lists configuration flags of the backend.
+	Options []Option
+	// ReadOnly marks backends which can't be used as 'default-artifact-root' or 'artifacts-destination'.
+	ReadOnly bool
+	// RequiresRoot marks backends which only serve artifact uris under the prefixes configured
+	// in the artifact storage configuration file, e.g. the ones sending credentials to arbitrary hosts.
+	RequiresRoot bool
+	// New creates a new storage instance.
+	New func(ctx context.Context, params BackendParams) (ArtifactStorageProvider, error)
+}
+
+var (
+	backendsLock sync.RWMutex
+	backends     []*Backend
+)
+
+// RegisterBackend registers artifact storage backend. It is meant to be called from `init` functions
+// and panics when one of the backend schemes or options has been already registered by another backend.
+func RegisterBackend(backend Backend) {
+	backendsLock.Lock()
+	defer backendsLock.Unlock()
+
+	for _, registered := range backends {
+		for _, scheme := range backend.Schemes {
+			if slices.Contains(registered.Schemes, scheme) {
+				panic("artifact storage scheme is already registered: " + scheme)
+			}
+		}
+		for _, option := range backend.Options {
+			if slices.ContainsFunc(registered.Options, func(o Option) bool { return o.Name == option.Name }) {
+				panic("artifact storage option is already registered: " + option.Name)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(backend.Options))
+	for _, option := range backend.Options {
+		names = append(names, option.Name)
+	}
+	config.RegisterArtifactStorageOptions(names...)
+
+	backends = append(backends, &backend)
+}
+
+// GetBackend returns backend registered for the provided scheme.
+func GetBackend(scheme string) (*Backend, bool) {
+	backendsLock.RLock()
+	defer backendsLock.RUnlock()
+
+	for _, backend := range backends {
+		if slices.Contains(backend.Schemes, scheme) {
+			return backend, true
+		}
+	}
+	return nil, false
+}
+
+// GetOptions returns options of all the registered backends in registration order.
+func GetOptions() []Option {
+	backendsLock.RLock()
+	defer backendsLock.RUnlock()
+
+	var options []Option
+	for _, backend := range backends {
+		options = append(options, backend.Options...)
+	}
+	return options
+}
+
+// isOptionRegistered checks that option has been registered by one of the backends.
+func isOptionRegistered(name string) bool {
+	return slices.ContainsFunc(GetOptions(), func(o Option) bool { return o.Name == name })
+}
@@ -5,16 +5,17 @@ import (
 	"errors"
 	"io"
 	"io/fs"
+	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsConfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/rotisserie/eris"
 	log "github.com/sirupsen/logrus"
-
-	"github.com/G-Research/fasttrackml/pkg/common/config"
 )
 
 // S3StorageName is a s3 storage name.
@@ -22,24 +23,61 @@ const (
 	S3StorageName = "s3"
 )
 
+// s3DeleteObjectsLimit is the maximum number of keys S3 accepts in a single DeleteObjects request.
+const s3DeleteObjectsLimit = 1000
+
 // S3 represents S3 adapter to work with artifacts.
 type S3 struct {
 	client *s3.Client
 }
 
+// S3 storage options.
+const (
+	S3EndpointURIOption     = "s3-endpoint-uri"
+	S3RegionOption          = "s3-region"
+	S3AccessKeyIDOption     = "s3-access-key-id"
+	S3SecretAccessKeyOption = "s3-secret-access-key"
+)
+
+func init() {
+	RegisterBackend(Backend{
+		Name:    "s3",
+		Schemes: []string{S3StorageName},
+		Options: []Option{
+			{Name: S3EndpointURIOption, Usage: "S3 compatible storage base endpoint url"},
+			{Name: S3RegionOption, Usage: "S3 region, by default it is taken from AWS configuration"},
+			{Name: S3AccessKeyIDOption, Usage: "S3 access key id, by default it is taken from AWS configuration"},
+			{Name: S3SecretAccessKeyOption, Usage: "S3 secret access key"},
+		},
+		New: func(ctx context.Context, params BackendParams) (ArtifactStorageProvider, error) {
+			return NewS3(ctx, params.Options)
+		},
+	})
+}
+
 // NewS3 creates new S3 instance.
-func NewS3(ctx context.Context, config *config.Config) (*S3, error) {
+func NewS3(ctx context.Context, options Options) (*S3, error) {
 	var clientOptions []func(o *s3.Options)
-	if config.S3EndpointURI != "" {
+	if endpointURI := options.Get(S3EndpointURIOption); endpointURI != "" {
 		clientOptions = append(clientOptions, func(o *s3.Options) {
 			o.UsePathStyle = true
 		})
 		clientOptions = append(clientOptions, func(o *s3.Options) {
-			o.BaseEndpoint = aws.String(config.S3EndpointURI)
+			o.BaseEndpoint = aws.String(endpointURI)
 		})
 	}
 
-	cfg, err := awsConfig.LoadDefaultConfig(ctx)
+	var configOptions []func(*awsConfig.LoadOptions) error
+	if region := options.Get(S3RegionOption); region != "" {
+		configOptions = append(configOptions, awsConfig.WithRegion(region))
+	}
+	if accessKeyID := options.Get(S3AccessKeyIDOption); accessKeyID != "" {
+		configOptions = append(configOptions, awsConfig.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(accessKeyID, options.Get(S3SecretAccessKeyOption), ""),
+		))
+	}
+
+	cfg, err := awsConfig.LoadDefaultConfig(ctx, configOptions...)
 	if err != nil {
 		return nil, eris.Wrap(err, "error loading configuration for S3 client")
 	}
@@ -133,3 +171,105 @@ func (s S3) Get(ctx context.Context, artifactURI, path string) (io.ReadCloser, e
 
 	return resp.Body, nil
 }
+
+// PresignGet implements ArtifactURLPresigner interface.
+func (s S3) PresignGet(ctx context.Context, artifactURI, path string, expiry time.Duration) (string, error) {
+	bucketName, prefix, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return "", eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+
+	request, err := s3.NewPresignClient(s.client).PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(filepath.Join(prefix, path)),
+	}, s3.WithPresignExpires(expiry))
+	if err != nil {
+		return "", eris.Wrap(err, "error presigning object url")
+	}
+	return request.URL, nil
+}
+
+// Put writes content of the provided io.Reader into the object at the storage location.
+func (s S3) Put(ctx context.Context, artifactURI, path string, reader io.Reader) error {
+	// 1. create s3 request input.
+	bucketName, prefix, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+
+	// 2. S3 client requires a seekable body to calculate payload checksum,
+	// so spool non-seekable streams into a temporary file first.
+	body, ok := reader.(io.ReadSeeker)
+	if !ok {
+		file, err := os.CreateTemp("", "fml-s3-upload-*")
+		if err != nil {
+			return eris.Wrap(err, "error creating temporary file")
+		}
+		defer func() {
+			//nolint:errcheck
+			file.Close()
+			//nolint:errcheck
+			os.Remove(file.Name())
+		}()
+		if _, err := io.Copy(file, reader); err != nil {
+			return eris.Wrap(err, "error writing temporary file")
+		}
+		if _, err := file.Seek(0, io.SeekStart); err != nil {
+			return eris.Wrap(err, "error rewinding temporary file")
+		}
+		body = file
+	}
+
+	// 3. put object into s3 storage.
+	if _, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(filepath.Join(prefix, path)),
+		Body:   body,
+	}); err != nil {
+		return eris.Wrap(err, "error putting object")
+	}
+
+	return nil
+}
+
+// Delete removes the object or all the objects under the path at the storage location.
+func (s S3) Delete(ctx context.Context, artifactURI, path string) error {
+	// 1. create s3 request input.
+	bucketName, prefix, err := ExtractBucketAndPrefix(artifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error extracting bucket and prefix from provided uri")
+	}
+	key := filepath.Join(prefix, path)
+
+	// 2. collect the object itself and all the objects under the path.
+	objects := []types.ObjectIdentifier{{Key: aws.String(key)}}
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucketName),
+		Prefix: aws.String(key + "/"),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return eris.Wrap(err, "error getting s3 page objects")
+		}
+		for _, object := range page.Contents {
+			objects = append(objects, types.ObjectIdentifier{Key: object.Key})
+		}
+	}
+
+	// 3. delete objects from s3 storage.
+	for start := 0; start < len(objects); start += s3DeleteObjectsLimit {
+		end := min(start+s3DeleteObjectsLimit, len(objects))
+		if _, err := s.client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(bucketName),
+			Delete: &types.Delete{
+				Objects: objects[start:end],
+				Quiet:   aws.Bool(true),
+			},
+		}); err != nil {
+			return eris.Wrap(err, "error deleting objects")
+		}
+	}
+
+	return nil
+}
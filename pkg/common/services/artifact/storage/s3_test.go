@@ -0,0 +1,34 @@
+package storage
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestS3_PresignGet_Ok(t *testing.T) {
+	storage, err := NewS3(context.Background(), Options{
+		S3EndpointURIOption:     "http://localhost:9000",
+		S3RegionOption:          "us-east-1",
+		S3AccessKeyIDOption:     "user",
+		S3SecretAccessKeyOption: "password",
+	})
+	require.Nil(t, err)
+
+	presignedURL, err := storage.PresignGet(
+		context.Background(), "s3://bucket/1/run/artifacts", "model/model.pkl", 5*time.Minute,
+	)
+	require.Nil(t, err)
+
+	parsed, err := url.Parse(presignedURL)
+	require.Nil(t, err)
+	assert.Equal(t, "localhost:9000", parsed.Host)
+	assert.Equal(t, "/bucket/1/run/artifacts/model/model.pkl", parsed.Path)
+	assert.Equal(t, "300", parsed.Query().Get("X-Amz-Expires"))
+	assert.Contains(t, parsed.Query().Get("X-Amz-Credential"), "user/")
+	assert.NotEmpty(t, parsed.Query().Get("X-Amz-Signature"))
+}
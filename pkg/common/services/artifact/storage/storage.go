@@ -2,9 +2,11 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/url"
 	"sync"
+	"time"
 
 	"github.com/rotisserie/eris"
 
@@ -39,28 +41,90 @@ type ArtifactStorageProvider interface {
 	Get(ctx context.Context, artifactURI, path string) (io.ReadCloser, error)
 	// List lists all artifact objects under a provided path.
 	List(ctx context.Context, artifactURI, path string) ([]ArtifactObject, error)
+	// Put writes content of the provided io.Reader into specific artifact.
+	Put(ctx context.Context, artifactURI, path string, reader io.Reader) error
+	// Delete deletes specific artifact or all artifact objects under a provided path.
+	Delete(ctx context.Context, artifactURI, path string) error
+}
+
+// ErrPresignNotSupported is returned when presigned URLs can't be created for specific artifact.
+var ErrPresignNotSupported = errors.New("artifact storage doesn't support presigned urls")
+
+// ArtifactURLPresigner provides an interface of storages able to create presigned URLs,
+// so clients can download artifacts directly from the storage.
+type ArtifactURLPresigner interface {
+	// PresignGet returns short-lived URL to download specific artifact.
+	PresignGet(ctx context.Context, artifactURI, path string, expiry time.Duration) (string, error)
 }
 
 // ArtifactStorageFactoryProvider provides an interface provider to work with Artifact Storage.
 type ArtifactStorageFactoryProvider interface {
 	// GetStorage returns Artifact storage based on provided runArtifactPath.
 	GetStorage(ctx context.Context, runArtifactPath string) (ArtifactStorageProvider, error)
+	// ValidateArtifactURI checks that artifacts under provided uri can be served by one of the storages.
+	ValidateArtifactURI(artifactURI string) error
 }
 
 // ArtifactStorageFactory represents Artifact Storage.
 type ArtifactStorageFactory struct {
 	config      *config.Config
+	roots       []RootConfig
 	storageList sync.Map
 }
 
+// storageKey identifies cached storage instance.
+type storageKey struct {
+	backend *Backend
+	prefix  string
+}
+
 // NewArtifactStorageFactory creates new Artifact Storage Factory instance.
 func NewArtifactStorageFactory(config *config.Config) (*ArtifactStorageFactory, error) {
+	if err := validateArtifactRoot("default-artifact-root", config.DefaultArtifactRoot); err != nil {
+		return nil, err
+	}
+	if err := validateArtifactRoot("artifacts-destination", config.ArtifactsDestination); err != nil {
+		return nil, err
+	}
+
+	var roots []RootConfig
+	if config.ArtifactStorageConfig != "" {
+		var err error
+		roots, err = LoadConfig(config.ArtifactStorageConfig)
+		if err != nil {
+			return nil, eris.Wrap(err, "error loading artifact storage configuration")
+		}
+	}
+
 	return &ArtifactStorageFactory{
 		config:      config,
+		roots:       roots,
 		storageList: sync.Map{},
 	}, nil
 }
 
+// validateArtifactRoot checks that artifact root provided by the flag is handled by writable storage.
+func validateArtifactRoot(flag, artifactRoot string) error {
+	u, err := url.Parse(artifactRoot)
+	if err != nil {
+		return eris.Wrapf(err, "error parsing '%s' flag", flag)
+	}
+	if backend, ok := GetBackend(u.Scheme); !ok || backend.ReadOnly {
+		return eris.Errorf("unsupported schema of '%s' flag", flag)
+	}
+	return nil
+}
+
+// ValidateArtifactURI checks that artifacts under provided uri can be served by one of the storages.
+func (s *ArtifactStorageFactory) ValidateArtifactURI(artifactURI string) error {
+	u, err := url.Parse(artifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error parsing artifact root")
+	}
+	_, _, err = s.getBackend(u, artifactURI)
+	return err
+}
+
 // GetStorage returns Artifact storage based on provided runArtifactPath.
 func (s *ArtifactStorageFactory) GetStorage(
 	ctx context.Context,
@@ -71,35 +135,71 @@ func (s *ArtifactStorageFactory) GetStorage(
 		return nil, eris.Wrap(err, "error parsing artifact root")
 	}
 
-	storageName := u.Scheme
-	if storage, ok := s.storageList.Load(storageName); ok {
-		return storage.(ArtifactStorageProvider), nil
+	backend, root, err := s.getBackend(u, runArtifactPath)
+	if err != nil {
+		return nil, err
 	}
 
-	var storage ArtifactStorageProvider
-	switch storageName {
-	case GSStorageName:
-		var err error
-		storage, err = NewGS(ctx, s.config)
-		if err != nil {
-			return nil, eris.Wrap(err, "error initializing gs artifact storage")
-		}
-	case S3StorageName:
-		var err error
-		storage, err = NewS3(ctx, s.config)
-		if err != nil {
-			return nil, eris.Wrap(err, "error initializing s3 artifact storage")
-		}
-	case "", LocalStorageName:
-		var err error
-		storage, err = NewLocal(s.config)
-		if err != nil {
-			return nil, eris.Wrap(err, "error initializing local artifact storage")
+	// storages are shared by all the artifact uris of the backend, unless
+	// the uri matches one of the prefixes having their own options.
+	options := Options{}
+	for name, value := range s.config.ArtifactStorageOptions {
+		options[name] = value
+	}
+	key := storageKey{backend: backend}
+	if root != nil {
+		for name, value := range root.Options {
+			options[name] = value
 		}
-	default:
-		return nil, eris.Errorf("unsupported schema has been provided: %s", u.Scheme)
+		key.prefix = root.Prefix
+	}
+
+	if storage, ok := s.storageList.Load(key); ok {
+		return storage.(ArtifactStorageProvider), nil
+	}
+
+	storage, err := backend.New(ctx, BackendParams{
+		URI:     u,
+		Options: options,
+		Prefix:  key.prefix,
+		Config:  s.config,
+		Factory: s,
+	})
+	if err != nil {
+		return nil, eris.Wrapf(err, "error initializing %s artifact storage", backend.Name)
+	}
+	if s.config.Tracing.Enabled {
+		storage = NewTracedStorage(backend.Name, storage)
+	}
+
+	actual, _ := s.storageList.LoadOrStore(key, storage)
+	return actual.(ArtifactStorageProvider), nil
+}
+
+// getBackend returns backend of the artifact uri and configuration of the artifact root matching it.
+func (s *ArtifactStorageFactory) getBackend(u *url.URL, artifactURI string) (*Backend, *RootConfig, error) {
+	backend, ok := GetBackend(u.Scheme)
+	if !ok {
+		return nil, nil, eris.Errorf("unsupported schema has been provided: %s", u.Scheme)
+	}
+
+	root := s.getRootConfig(artifactURI)
+	if backend.RequiresRoot && root == nil {
+		return nil, nil, eris.Errorf(
+			"%s artifact uri has to match one of the prefixes of artifact storage configuration: %s",
+			backend.Name, artifactURI,
+		)
 	}
+	return backend, root, nil
+}
 
-	s.storageList.Store(storageName, storage)
-	return storage, nil
+// getRootConfig returns configuration of the longest prefix matching the artifact uri.
+func (s *ArtifactStorageFactory) getRootConfig(artifactURI string) *RootConfig {
+	var result *RootConfig
+	for i, root := range s.roots {
+		if root.matches(artifactURI) && (result == nil || len(root.Prefix) > len(result.Prefix)) {
+			result = &s.roots[i]
+		}
+	}
+	return result
 }
@@ -0,0 +1,208 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+// testStorage is a storage of the test backend which remembers its options.
+type testStorage struct {
+	Local
+	options Options
+}
+
+func init() {
+	RegisterBackend(Backend{
+		Name:    "test",
+		Schemes: []string{"test"},
+		Options: []Option{
+			{Name: "test-endpoint"},
+			{Name: "test-token"},
+		},
+		New: func(ctx context.Context, params BackendParams) (ArtifactStorageProvider, error) {
+			return &testStorage{options: params.Options}, nil
+		},
+	})
+}
+
+func writeStorageConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "storages.yaml")
+	require.Nil(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestArtifactStorageFactory_GetStorage_Ok(t *testing.T) {
+	t.Setenv("TEST_STORAGE_TOKEN", "token-from-env")
+	factory, err := NewArtifactStorageFactory(&config.Config{
+		ArtifactStorageOptions: map[string]string{"test-endpoint": "global", "test-token": "global"},
+		ArtifactStorageConfig: writeStorageConfig(t, `
+storages:
+  - prefix: test://bucket1
+    options:
+      test-endpoint: bucket1
+  - prefix: test://bucket1/nested/
+    options:
+      test-token: ${TEST_STORAGE_TOKEN}
+`),
+	})
+	require.Nil(t, err)
+
+	tests := []struct {
+		name        string
+		artifactURI string
+		options     Options
+	}{
+		{
+			name:        "GlobalOptions",
+			artifactURI: "test://bucket2/1/run/artifacts",
+			options:     Options{"test-endpoint": "global", "test-token": "global"},
+		},
+		{
+			name:        "SimilarPrefix",
+			artifactURI: "test://bucket10/1/run/artifacts",
+			options:     Options{"test-endpoint": "global", "test-token": "global"},
+		},
+		{
+			name:        "PrefixOptions",
+			artifactURI: "test://bucket1/1/run/artifacts",
+			options:     Options{"test-endpoint": "bucket1", "test-token": "global"},
+		},
+		{
+			name:        "LongestPrefixOptions",
+			artifactURI: "test://bucket1/nested/1/run/artifacts",
+			options:     Options{"test-endpoint": "global", "test-token": "token-from-env"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage, err := factory.GetStorage(context.Background(), tt.artifactURI)
+			require.Nil(t, err)
+			assert.Equal(t, tt.options, storage.(*testStorage).options)
+
+			// the same storage instance is returned for the same prefix.
+			cached, err := factory.GetStorage(context.Background(), tt.artifactURI+"/model")
+			require.Nil(t, err)
+			assert.Same(t, storage, cached)
+		})
+	}
+
+	storage1, err := factory.GetStorage(context.Background(), "test://bucket1")
+	require.Nil(t, err)
+	storage2, err := factory.GetStorage(context.Background(), "test://bucket2")
+	require.Nil(t, err)
+	assert.NotSame(t, storage1, storage2)
+}
+
+func TestArtifactStorageFactory_GetStorage_Error(t *testing.T) {
+	factory, err := NewArtifactStorageFactory(&config.Config{})
+	require.Nil(t, err)
+
+	_, err = factory.GetStorage(context.Background(), "unsupported://bucket/path")
+	assert.EqualError(t, err, "unsupported schema has been provided: unsupported")
+
+	_, err = factory.GetStorage(context.Background(), "https://artifacts.example.com/path")
+	assert.EqualError(
+		t,
+		err,
+		"http artifact uri has to match one of the prefixes of artifact storage configuration: "+
+			"https://artifacts.example.com/path",
+	)
+}
+
+func TestArtifactStorageFactory_ValidateArtifactURI(t *testing.T) {
+	factory, err := NewArtifactStorageFactory(&config.Config{
+		ArtifactStorageConfig: writeStorageConfig(t, `
+storages:
+  - prefix: https://artifacts.example.com/team
+`),
+	})
+	require.Nil(t, err)
+
+	assert.Nil(t, factory.ValidateArtifactURI("s3://bucket/path"))
+	assert.Nil(t, factory.ValidateArtifactURI("/local/path"))
+	assert.Nil(t, factory.ValidateArtifactURI("https://artifacts.example.com/team/1"))
+	assert.NotNil(t, factory.ValidateArtifactURI("https://artifacts.example.com/other/1"))
+	assert.NotNil(t, factory.ValidateArtifactURI("http://attacker.example.com/team/1"))
+	assert.NotNil(t, factory.ValidateArtifactURI("unsupported://bucket/path"))
+}
+
+func TestNewArtifactStorageFactory_Error(t *testing.T) {
+	tests := []struct {
+		name   string
+		error  string
+		config *config.Config
+	}{
+		{
+			name:  "DefaultArtifactRootHasUnsupportedSchema",
+			error: "unsupported schema of 'default-artifact-root' flag",
+			config: &config.Config{
+				DefaultArtifactRoot: "unsupported://something",
+			},
+		},
+		{
+			name:  "DefaultArtifactRootIsReadOnly",
+			error: "unsupported schema of 'default-artifact-root' flag",
+			config: &config.Config{
+				DefaultArtifactRoot: "https://artifacts.example.com/path",
+			},
+		},
+		{
+			name:  "ArtifactsDestinationHasUnsupportedSchema",
+			error: "unsupported schema of 'artifacts-destination' flag",
+			config: &config.Config{
+				DefaultArtifactRoot:  "s3://bucket_name",
+				ArtifactsDestination: "unsupported://something",
+			},
+		},
+		{
+			name: "StorageConfigHasUnsupportedSchema",
+			error: "error loading artifact storage configuration: error parsing artifact storage configuration " +
+				"from yaml: unsupported schema of storage prefix: unsupported://bucket",
+			config: &config.Config{
+				ArtifactStorageConfig: writeStorageConfig(t, `
+storages:
+  - prefix: unsupported://bucket
+`),
+			},
+		},
+		{
+			name: "StorageConfigHasUnknownOption",
+			error: "error loading artifact storage configuration: error parsing artifact storage configuration " +
+				`from yaml: unknown option "unknown" of storage prefix: test://bucket`,
+			config: &config.Config{
+				ArtifactStorageConfig: writeStorageConfig(t, `
+storages:
+  - prefix: test://bucket
+    options:
+      unknown: value
+`),
+			},
+		},
+		{
+			name: "StorageConfigHasMissingEnv",
+			error: "error loading artifact storage configuration: error parsing artifact storage configuration " +
+				`from yaml: error reading option "test-token" from ENV variable: TEST_STORAGE_MISSING_TOKEN`,
+			config: &config.Config{
+				ArtifactStorageConfig: writeStorageConfig(t, `
+storages:
+  - prefix: test://bucket
+    options:
+      test-token: ${TEST_STORAGE_MISSING_TOKEN}
+`),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewArtifactStorageFactory(tt.config)
+			assert.EqualError(t, err, tt.error)
+		})
+	}
+}
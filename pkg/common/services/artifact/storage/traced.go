@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/G-Research/fasttrackml/pkg/common/tracing"
+)
+
+// TracedStorage records calls of the wrapped storage as spans of the trace from the context.
+type TracedStorage struct {
+	backend string
+	storage ArtifactStorageProvider
+}
+
+// NewTracedStorage creates new TracedStorage instance.
+func NewTracedStorage(backend string, storage ArtifactStorageProvider) *TracedStorage {
+	return &TracedStorage{
+		backend: backend,
+		storage: storage,
+	}
+}
+
+// Get implements ArtifactStorageProvider interface.
+func (s TracedStorage) Get(ctx context.Context, artifactURI, path string) (io.ReadCloser, error) {
+	ctx, span := s.startSpan(ctx, "Get", artifactURI, path)
+	reader, err := s.storage.Get(ctx, artifactURI, path)
+	endSpan(span, err)
+	return reader, err
+}
+
+// List implements ArtifactStorageProvider interface.
+func (s TracedStorage) List(ctx context.Context, artifactURI, path string) ([]ArtifactObject, error) {
+	ctx, span := s.startSpan(ctx, "List", artifactURI, path)
+	objects, err := s.storage.List(ctx, artifactURI, path)
+	endSpan(span, err)
+	return objects, err
+}
+
+// Put implements ArtifactStorageProvider interface.
+func (s TracedStorage) Put(ctx context.Context, artifactURI, path string, reader io.Reader) error {
+	ctx, span := s.startSpan(ctx, "Put", artifactURI, path)
+	err := s.storage.Put(ctx, artifactURI, path, reader)
+	endSpan(span, err)
+	return err
+}
+
+// Delete implements ArtifactStorageProvider interface.
+func (s TracedStorage) Delete(ctx context.Context, artifactURI, path string) error {
+	ctx, span := s.startSpan(ctx, "Delete", artifactURI, path)
+	err := s.storage.Delete(ctx, artifactURI, path)
+	endSpan(span, err)
+	return err
+}
+
+// PresignGet implements ArtifactURLPresigner interface.
+func (s TracedStorage) PresignGet(
+	ctx context.Context, artifactURI, path string, expiry time.Duration,
+) (string, error) {
+	presigner, ok := s.storage.(ArtifactURLPresigner)
+	if !ok {
+		return "", ErrPresignNotSupported
+	}
+	ctx, span := s.startSpan(ctx, "PresignGet", artifactURI, path)
+	url, err := presigner.PresignGet(ctx, artifactURI, path, expiry)
+	endSpan(span, err)
+	return url, err
+}
+
+// startSpan starts span of the storage operation.
+func (s TracedStorage) startSpan(
+	ctx context.Context, operation, artifactURI, path string,
+) (context.Context, trace.Span) {
+	return tracing.StartSpan(
+		ctx,
+		"artifact."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("artifact.backend", s.backend),
+			attribute.String("artifact.uri", artifactURI),
+			attribute.String("artifact.path", path),
+		),
+	)
+}
+
+// endSpan ends span of the storage operation recording its error.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -6,10 +6,19 @@ import (
 	"slices"
 	"strings"
 
+	"github.com/google/uuid"
+
 	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/pkg/common/api/request"
 )
 
+const (
+	// MaxMultipartUploadParts is the maximum number of parts of a single multipart upload.
+	MaxMultipartUploadParts = 10000
+	// MultipartUploadsPath is a path inside artifacts destination where parts of multipart uploads are staged.
+	MultipartUploadsPath = ".mlflow-mpu"
+)
+
 // ValidateListArtifactsRequest validates `GET /mlflow/artifacts/list` request.
 func ValidateListArtifactsRequest(req *request.ListArtifactsRequest) error {
 	if req.RunID == "" && req.RunUUID == "" {
@@ -43,3 +52,99 @@ func validatePath(path string) error {
 	}
 	return nil
 }
+
+// ValidateListProxiedArtifactsRequest validates `GET /mlflow-artifacts/artifacts` request.
+func ValidateListProxiedArtifactsRequest(req *request.ListProxiedArtifactsRequest) error {
+	return validateArtifactPath(req.Path)
+}
+
+// ValidateDownloadArtifactRequest validates `GET /mlflow-artifacts/artifacts/:path` request.
+func ValidateDownloadArtifactRequest(req *request.DownloadArtifactRequest) error {
+	return validateRequiredPath(req.Path)
+}
+
+// ValidateUploadArtifactRequest validates `PUT /mlflow-artifacts/artifacts/:path` request.
+func ValidateUploadArtifactRequest(req *request.UploadArtifactRequest) error {
+	return validateRequiredPath(req.Path)
+}
+
+// ValidateDeleteArtifactRequest validates `DELETE /mlflow-artifacts/artifacts/:path` request.
+func ValidateDeleteArtifactRequest(req *request.DeleteArtifactRequest) error {
+	return validateRequiredPath(req.Path)
+}
+
+// ValidateCreateMultipartUploadRequest validates `POST /mlflow-artifacts/mpu/create/:path` request.
+func ValidateCreateMultipartUploadRequest(req *request.CreateMultipartUploadRequest) error {
+	if req.NumParts < 1 || req.NumParts > MaxMultipartUploadParts {
+		return api.NewInvalidParameterValueError("Invalid value for parameter 'num_parts' supplied.")
+	}
+	if req.Path == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'path'")
+	}
+	return validateRequiredPath(req.GetDestinationPath())
+}
+
+// ValidateUploadMultipartPartRequest validates `PUT /mlflow-artifacts/mpu/upload/:path` request.
+func ValidateUploadMultipartPartRequest(req *request.UploadMultipartPartRequest) error {
+	if err := validateUploadID(req.UploadID); err != nil {
+		return err
+	}
+	if req.PartNumber < 1 || req.PartNumber > MaxMultipartUploadParts {
+		return api.NewInvalidParameterValueError("Invalid value for parameter 'part_number' supplied.")
+	}
+	return validateArtifactPath(req.ArtifactPath)
+}
+
+// ValidateCompleteMultipartUploadRequest validates `POST /mlflow-artifacts/mpu/complete/:path` request.
+func ValidateCompleteMultipartUploadRequest(req *request.CompleteMultipartUploadRequest) error {
+	if err := validateUploadID(req.UploadID); err != nil {
+		return err
+	}
+	if len(req.Parts) == 0 {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'parts'")
+	}
+	for _, part := range req.Parts {
+		if part.PartNumber < 1 || part.PartNumber > MaxMultipartUploadParts {
+			return api.NewInvalidParameterValueError("Invalid value for parameter 'part_number' supplied.")
+		}
+	}
+	if req.Path == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'path'")
+	}
+	return validateRequiredPath(req.GetDestinationPath())
+}
+
+// ValidateAbortMultipartUploadRequest validates `POST /mlflow-artifacts/mpu/abort/:path` request.
+func ValidateAbortMultipartUploadRequest(req *request.AbortMultipartUploadRequest) error {
+	if err := validateUploadID(req.UploadID); err != nil {
+		return err
+	}
+	return validateArtifactPath(req.ArtifactPath)
+}
+
+// validateRequiredPath validates path parameter which can't be empty.
+func validateRequiredPath(path string) error {
+	if path == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'path'")
+	}
+	return validateArtifactPath(path)
+}
+
+// validateArtifactPath validates path of the artifact served by `mlflow-artifacts` API.
+func validateArtifactPath(path string) error {
+	if path == MultipartUploadsPath || strings.HasPrefix(path, MultipartUploadsPath+"/") {
+		return api.NewInvalidParameterValueError("Invalid path")
+	}
+	return validatePath(path)
+}
+
+// validateUploadID validates upload_id parameter of multipart upload.
+func validateUploadID(uploadID string) error {
+	if uploadID == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'upload_id'")
+	}
+	if _, err := uuid.Parse(uploadID); err != nil {
+		return api.NewInvalidParameterValueError("Invalid value for parameter 'upload_id' supplied.")
+	}
+	return nil
+}
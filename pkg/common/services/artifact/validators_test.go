@@ -300,3 +300,118 @@ func TestValidateGetArtifactRequest_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateUploadArtifactRequest_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.UploadArtifactRequest
+	}{
+		{
+			name:    "EmptyPath",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'path'"),
+			request: &request.UploadArtifactRequest{},
+		},
+		{
+			name:  "PathContains2Dots",
+			error: api.NewInvalidParameterValueError("Invalid path"),
+			request: &request.UploadArtifactRequest{
+				Path: "foo/../../bar",
+			},
+		},
+		{
+			name:  "PathPointsToMultipartUploads",
+			error: api.NewInvalidParameterValueError("Invalid path"),
+			request: &request.UploadArtifactRequest{
+				Path: ".mlflow-mpu/upload-id/1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateUploadArtifactRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestValidateCreateMultipartUploadRequest_Ok(t *testing.T) {
+	err := ValidateCreateMultipartUploadRequest(&request.CreateMultipartUploadRequest{
+		ArtifactPath: "1/run-id/artifacts",
+		Path:         "/local/path/model.bin",
+		NumParts:     3,
+	})
+	require.Nil(t, err)
+}
+
+func TestValidateCreateMultipartUploadRequest_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.CreateMultipartUploadRequest
+	}{
+		{
+			name:    "EmptyNumParts",
+			error:   api.NewInvalidParameterValueError("Invalid value for parameter 'num_parts' supplied."),
+			request: &request.CreateMultipartUploadRequest{Path: "model.bin"},
+		},
+		{
+			name:  "TooBigNumParts",
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'num_parts' supplied."),
+			request: &request.CreateMultipartUploadRequest{
+				Path:     "model.bin",
+				NumParts: MaxMultipartUploadParts + 1,
+			},
+		},
+		{
+			name:    "EmptyPath",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'path'"),
+			request: &request.CreateMultipartUploadRequest{NumParts: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateMultipartUploadRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestValidateCompleteMultipartUploadRequest_Error(t *testing.T) {
+	tests := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.CompleteMultipartUploadRequest
+	}{
+		{
+			name:    "EmptyUploadID",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'upload_id'"),
+			request: &request.CompleteMultipartUploadRequest{Path: "model.bin"},
+		},
+		{
+			name:  "IncorrectUploadID",
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'upload_id' supplied."),
+			request: &request.CompleteMultipartUploadRequest{
+				Path:     "model.bin",
+				UploadID: "../../foo",
+			},
+		},
+		{
+			name:  "EmptyParts",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'parts'"),
+			request: &request.CompleteMultipartUploadRequest{
+				Path:     "model.bin",
+				UploadID: "0c7d4f5e-3b0a-4d3c-9d8e-4a1f0b5c6d7e",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCompleteMultipartUploadRequest(tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package gc
+
+import (
+	"context"
+	"errors"
+	"path"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+)
+
+// deleteBatchSize is a number of runs purged in a single transaction.
+const deleteBatchSize = 500
+
+// Options represents options of the garbage collection.
+type Options struct {
+	// OlderThan selects runs and experiments deleted longer than this period ago, zero selects all of them.
+	OlderThan time.Duration
+	// Namespaces is a list of codes of namespaces to collect, empty list means all namespaces.
+	Namespaces []string
+	// UploadsOlderThan selects multipart uploads started longer than this period ago, which were neither
+	// completed nor aborted, so their staged parts are deleted. Zero keeps them.
+	UploadsOlderThan time.Duration
+	// DryRun only reports what would be purged.
+	DryRun bool
+}
+
+// Result represents result of the garbage collection.
+type Result struct {
+	Experiments int
+	Runs        int
+	Failed      int
+	Uploads     int
+}
+
+// Collector permanently purges deleted runs and experiments together with their artifacts.
+type Collector struct {
+	namespaceRepository    repositories.NamespaceRepositoryProvider
+	experimentRepository   repositories.ExperimentRepositoryProvider
+	runRepository          repositories.RunRepositoryProvider
+	artifactStorageFactory storage.ArtifactStorageFactoryProvider
+}
+
+// NewCollector creates new Collector instance.
+func NewCollector(
+	namespaceRepository repositories.NamespaceRepositoryProvider,
+	experimentRepository repositories.ExperimentRepositoryProvider,
+	runRepository repositories.RunRepositoryProvider,
+	artifactStorageFactory storage.ArtifactStorageFactoryProvider,
+) *Collector {
+	return &Collector{
+		namespaceRepository:    namespaceRepository,
+		experimentRepository:   experimentRepository,
+		runRepository:          runRepository,
+		artifactStorageFactory: artifactStorageFactory,
+	}
+}
+
+// Collect purges runs and experiments selected by provided options. Runs, which artifacts
+// couldn't be deleted, are kept together with their experiments, so they are purged next time.
+func (c Collector) Collect(ctx context.Context, options Options) (*Result, error) {
+	namespaces, err := c.getNamespaces(ctx, options.Namespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	var before int64
+	if options.OlderThan > 0 {
+		before = time.Now().Add(-options.OlderThan).UnixMilli()
+	}
+
+	result := Result{}
+	for _, namespace := range namespaces {
+		if err := c.collectNamespace(ctx, &namespace, before, options.DryRun, &result); err != nil {
+			return &result, eris.Wrapf(err, "error collecting namespace with code: %s", namespace.Code)
+		}
+	}
+	if options.UploadsOlderThan > 0 {
+		if err := c.collectUploads(ctx, time.Now().Add(-options.UploadsOlderThan), options.DryRun, &result); err != nil {
+			return &result, eris.Wrap(err, "error collecting abandoned multipart uploads")
+		}
+	}
+	return &result, nil
+}
+
+// collectUploads deletes staged parts of multipart uploads, which were started before provided time.
+// Upload IDs are time-ordered UUIDs, so start time of the upload is taken from its ID.
+func (c Collector) collectUploads(ctx context.Context, before time.Time, dryRun bool, result *Result) error {
+	rootURI := storage.MlflowArtifactsStorageName + ":/"
+	artifactStorage, err := c.artifactStorageFactory.GetStorage(ctx, rootURI)
+	if err != nil {
+		return eris.Wrap(err, "error getting artifact storage")
+	}
+	uploads, err := artifactStorage.List(ctx, rootURI, artifact.MultipartUploadsPath)
+	if err != nil {
+		return eris.Wrap(err, "error listing multipart uploads")
+	}
+
+	for _, upload := range uploads {
+		uploadID, err := uuid.Parse(path.Base(upload.Path))
+		if err != nil || uploadID.Version() != 7 {
+			log.Warnf("unable to get start time of multipart upload %s, upload is kept", upload.Path)
+			continue
+		}
+		if sec, nsec := uploadID.Time().UnixTime(); !time.Unix(sec, nsec).Before(before) {
+			continue
+		}
+		if dryRun {
+			log.Infof("would purge multipart upload %s", uploadID)
+		} else if err := artifactStorage.Delete(ctx, rootURI, upload.Path); err != nil {
+			log.Errorf("error deleting multipart upload %s, upload is kept: %+v", uploadID, err)
+			continue
+		}
+		result.Uploads++
+	}
+	return nil
+}
+
+// collectNamespace purges deleted runs and experiments of the namespace.
+func (c Collector) collectNamespace(
+	ctx context.Context, namespace *models.Namespace, before int64, dryRun bool, result *Result,
+) error {
+	runs, err := c.runRepository.GetDeletedBefore(ctx, namespace.ID, before)
+	if err != nil {
+		return eris.Wrap(err, "error getting deleted runs")
+	}
+	experiments, err := c.experimentRepository.GetDeletedBefore(ctx, namespace.ID, before)
+	if err != nil {
+		return eris.Wrap(err, "error getting deleted experiments")
+	}
+
+	// experiments with runs, which weren't purged, have to be kept.
+	keptExperiments := map[int32]struct{}{}
+	for start := 0; start < len(runs); start += deleteBatchSize {
+		batch := runs[start:min(start+deleteBatchSize, len(runs))]
+		ids := make([]string, 0, len(batch))
+		for _, run := range batch {
+			if dryRun {
+				log.Infof("would purge run %s of namespace %s", run.ID, namespace.Code)
+				ids = append(ids, run.ID)
+				continue
+			}
+			if err := c.deleteArtifacts(ctx, &run); err != nil {
+				log.Errorf("error deleting artifacts of run %s, run is kept: %+v", run.ID, err)
+				keptExperiments[run.ExperimentID] = struct{}{}
+				result.Failed++
+				continue
+			}
+			ids = append(ids, run.ID)
+		}
+		if !dryRun && len(ids) > 0 {
+			if err := c.runRepository.DeleteBatch(ctx, namespace.ID, ids); err != nil {
+				return eris.Wrap(err, "error purging runs")
+			}
+			log.Infof("purged %d runs of namespace %s", len(ids), namespace.Code)
+		}
+		result.Runs += len(ids)
+	}
+
+	ids := make([]*int32, 0, len(experiments))
+	for _, experiment := range experiments {
+		if _, ok := keptExperiments[*experiment.ID]; ok || experiment.IsDefault(namespace) {
+			continue
+		}
+		if dryRun {
+			log.Infof("would purge experiment %d of namespace %s", *experiment.ID, namespace.Code)
+		}
+		ids = append(ids, experiment.ID)
+	}
+	if !dryRun && len(ids) > 0 {
+		if err := c.experimentRepository.DeleteBatch(ctx, ids); err != nil {
+			return eris.Wrap(err, "error purging experiments")
+		}
+		log.Infof("purged %d experiments of namespace %s", len(ids), namespace.Code)
+	}
+	result.Experiments += len(ids)
+	return nil
+}
+
+// deleteArtifacts deletes all the artifacts of the run.
+func (c Collector) deleteArtifacts(ctx context.Context, run *models.Run) error {
+	if run.ArtifactURI == "" {
+		return nil
+	}
+	artifactStorage, err := c.artifactStorageFactory.GetStorage(ctx, run.ArtifactURI)
+	if err != nil {
+		return eris.Wrap(err, "error getting artifact storage")
+	}
+	if err := artifactStorage.Delete(ctx, run.ArtifactURI, ""); err != nil {
+		// artifacts of read-only storage aren't managed by the server, so they are left as is.
+		if errors.Is(err, storage.ErrReadOnlyStorage) {
+			return nil
+		}
+		return eris.Wrap(err, "error deleting artifacts")
+	}
+	return nil
+}
+
+// getNamespaces returns namespaces by their codes, or all namespaces when no codes were provided.
+func (c Collector) getNamespaces(ctx context.Context, codes []string) ([]models.Namespace, error) {
+	if len(codes) == 0 {
+		namespaces, err := c.namespaceRepository.List(ctx)
+		if err != nil {
+			return nil, eris.Wrap(err, "error listing namespaces")
+		}
+		return namespaces, nil
+	}
+	namespaces := make([]models.Namespace, 0, len(codes))
+	for _, code := range codes {
+		namespace, err := c.namespaceRepository.GetByCode(ctx, code)
+		if err != nil {
+			return nil, eris.Wrapf(err, "error getting namespace with code: %s", code)
+		}
+		if namespace == nil {
+			return nil, eris.Errorf("unable to find namespace with code: %s", code)
+		}
+		namespaces = append(namespaces, *namespace)
+	}
+	return namespaces, nil
+}
@@ -0,0 +1,146 @@
+package gc
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+)
+
+func int32Ptr(value int32) *int32 {
+	return &value
+}
+
+func TestCollector_Collect_Ok(t *testing.T) {
+	namespace := models.Namespace{ID: 1, Code: "default", DefaultExperimentID: int32Ptr(0)}
+	namespaceRepository := repositories.MockNamespaceRepositoryProvider{}
+	namespaceRepository.On("List", context.TODO()).Return([]models.Namespace{namespace}, nil)
+
+	runRepository := repositories.MockRunRepositoryProvider{}
+	runRepository.On(
+		"GetDeletedBefore", context.TODO(), uint(1), mock.AnythingOfType("int64"),
+	).Return([]models.Run{
+		{ID: "run1", ExperimentID: 0, ArtifactURI: "/artifacts/0/run1/artifacts"},
+		{ID: "run2", ExperimentID: 1, ArtifactURI: "/artifacts/1/run2/artifacts"},
+		{ID: "run3", ExperimentID: 2, ArtifactURI: "/artifacts/2/run3/artifacts"},
+	}, nil)
+	runRepository.On("DeleteBatch", context.TODO(), uint(1), []string{"run1", "run3"}).Return(nil)
+
+	experimentRepository := repositories.MockExperimentRepositoryProvider{}
+	experimentRepository.On(
+		"GetDeletedBefore", context.TODO(), uint(1), mock.AnythingOfType("int64"),
+	).Return([]models.Experiment{
+		{ID: int32Ptr(0)}, {ID: int32Ptr(1)}, {ID: int32Ptr(2)},
+	}, nil)
+	experimentRepository.On("DeleteBatch", context.TODO(), []*int32{int32Ptr(2)}).Return(nil)
+
+	artifactStorage := storage.MockArtifactStorageProvider{}
+	artifactStorage.On("Delete", context.TODO(), "/artifacts/0/run1/artifacts", "").Return(nil)
+	artifactStorage.On("Delete", context.TODO(), "/artifacts/1/run2/artifacts", "").Return(errors.New("failed"))
+	artifactStorage.On("Delete", context.TODO(), "/artifacts/2/run3/artifacts", "").Return(nil)
+	artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
+	artifactStorageFactory.On("GetStorage", context.TODO(), mock.Anything).Return(&artifactStorage, nil)
+
+	// call collector under testing.
+	result, err := NewCollector(
+		&namespaceRepository, &experimentRepository, &runRepository, &artifactStorageFactory,
+	).Collect(context.TODO(), Options{})
+	require.Nil(t, err)
+	// run2 and its experiment are kept because of artifact failure, default experiment is never purged.
+	assert.Equal(t, &Result{Experiments: 1, Runs: 2, Failed: 1}, result)
+	runRepository.AssertExpectations(t)
+	experimentRepository.AssertExpectations(t)
+	artifactStorage.AssertExpectations(t)
+}
+
+func TestCollector_Collect_DryRun(t *testing.T) {
+	namespace := models.Namespace{ID: 2, Code: "team", DefaultExperimentID: int32Ptr(10)}
+	namespaceRepository := repositories.MockNamespaceRepositoryProvider{}
+	namespaceRepository.On("GetByCode", context.TODO(), "team").Return(&namespace, nil)
+
+	runRepository := repositories.MockRunRepositoryProvider{}
+	runRepository.On(
+		"GetDeletedBefore", context.TODO(), uint(2), mock.AnythingOfType("int64"),
+	).Return([]models.Run{
+		{ID: "run1", ExperimentID: 11, ArtifactURI: "/artifacts/11/run1/artifacts"},
+	}, nil)
+
+	experimentRepository := repositories.MockExperimentRepositoryProvider{}
+	experimentRepository.On(
+		"GetDeletedBefore", context.TODO(), uint(2), mock.AnythingOfType("int64"),
+	).Return([]models.Experiment{{ID: int32Ptr(11)}}, nil)
+
+	artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
+
+	// call collector under testing.
+	result, err := NewCollector(
+		&namespaceRepository, &experimentRepository, &runRepository, &artifactStorageFactory,
+	).Collect(context.TODO(), Options{Namespaces: []string{"team"}, DryRun: true})
+	require.Nil(t, err)
+	assert.Equal(t, &Result{Experiments: 1, Runs: 1}, result)
+	runRepository.AssertNotCalled(t, "DeleteBatch", mock.Anything, mock.Anything, mock.Anything)
+	experimentRepository.AssertNotCalled(t, "DeleteBatch", mock.Anything, mock.Anything)
+	artifactStorageFactory.AssertNotCalled(t, "GetStorage", mock.Anything, mock.Anything)
+}
+
+func TestCollector_Collect_Error(t *testing.T) {
+	namespaceRepository := repositories.MockNamespaceRepositoryProvider{}
+	namespaceRepository.On("GetByCode", context.TODO(), "not-existing").Return(nil, nil)
+
+	// call collector under testing.
+	result, err := NewCollector(
+		&namespaceRepository,
+		&repositories.MockExperimentRepositoryProvider{},
+		&repositories.MockRunRepositoryProvider{},
+		&storage.MockArtifactStorageFactoryProvider{},
+	).Collect(context.TODO(), Options{Namespaces: []string{"not-existing"}})
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "unable to find namespace with code: not-existing")
+}
+
+func TestCollector_Collect_Uploads(t *testing.T) {
+	namespaceRepository := repositories.MockNamespaceRepositoryProvider{}
+	namespaceRepository.On("List", context.TODO()).Return([]models.Namespace{}, nil)
+
+	// upload IDs are time-ordered UUIDs, so the start time of the upload is taken from its ID.
+	newUploadID := func(startedAt time.Time) string {
+		uploadID := uuid.Must(uuid.NewV7())
+		// the first 48 bits of version 7 UUID are unix timestamp in milliseconds.
+		var timestamp [8]byte
+		binary.BigEndian.PutUint64(timestamp[:], uint64(startedAt.UnixMilli()))
+		copy(uploadID[:6], timestamp[2:])
+		return uploadID.String()
+	}
+	abandonedID, activeID := newUploadID(time.Now().Add(-48*time.Hour)), newUploadID(time.Now())
+
+	artifactStorage := storage.MockArtifactStorageProvider{}
+	artifactStorage.On("List", context.TODO(), "mlflow-artifacts:/", ".mlflow-mpu").Return([]storage.ArtifactObject{
+		{Path: ".mlflow-mpu/" + abandonedID, IsDir: true},
+		{Path: ".mlflow-mpu/" + activeID, IsDir: true},
+		{Path: ".mlflow-mpu/not-uuid", IsDir: true},
+	}, nil)
+	artifactStorage.On("Delete", context.TODO(), "mlflow-artifacts:/", ".mlflow-mpu/"+abandonedID).Return(nil)
+	artifactStorageFactory := storage.MockArtifactStorageFactoryProvider{}
+	artifactStorageFactory.On("GetStorage", context.TODO(), "mlflow-artifacts:/").Return(&artifactStorage, nil)
+
+	result, err := NewCollector(
+		&namespaceRepository,
+		&repositories.MockExperimentRepositoryProvider{},
+		&repositories.MockRunRepositoryProvider{},
+		&artifactStorageFactory,
+	).Collect(context.TODO(), Options{UploadsOlderThan: 24 * time.Hour})
+	require.Nil(t, err)
+	assert.Equal(t, &Result{Uploads: 1}, result)
+	artifactStorage.AssertExpectations(t)
+	artifactStorage.AssertNumberOfCalls(t, "Delete", 1)
+}
@@ -0,0 +1,60 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+// DefaultUploadsOlderThan is a period, after which multipart uploads are considered as abandoned.
+const DefaultUploadsOlderThan = 24 * time.Hour
+
+// Job represents garbage collection background job.
+type Job struct {
+	ctx       context.Context
+	config    *config.Config
+	collector *Collector
+}
+
+// NewJob creates a new instance of Job.
+func NewJob(ctx context.Context, config *config.Config, collector *Collector) *Job {
+	return &Job{
+		ctx:       ctx,
+		config:    config,
+		collector: collector,
+	}
+}
+
+// Run runs garbage collection background job. Job is disabled, when retention period is zero.
+func (j Job) Run() {
+	if j.config.GCOlderThan == 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-j.ctx.Done():
+				log.Debug("garbage collection job finished. exiting.")
+				return
+			case <-ticker.C:
+				result, err := j.collector.Collect(j.ctx, Options{
+					OlderThan:        j.config.GCOlderThan,
+					UploadsOlderThan: DefaultUploadsOlderThan,
+				})
+				if err != nil {
+					log.Errorf("error collecting deleted runs and experiments: %+v", err)
+				} else {
+					log.Debugf(
+						"%d deleted runs, %d deleted experiments and %d abandoned uploads were successfully purged",
+						result.Runs, result.Experiments, result.Uploads,
+					)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,285 @@
+package ingest
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/semaphore"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/telemetry"
+)
+
+// insertBatchSize is a number of rows inserted by a single statement, when metrics are flushed.
+const insertBatchSize = 1000
+
+var (
+	// ErrQueueFull is returned, when there was no room for metrics in the queue during the queue timeout.
+	ErrQueueFull = eris.New("metric ingestion queue is full")
+	// ErrStopped is returned, when metrics are logged after the service was stopped.
+	ErrStopped = eris.New("metric ingestion is stopped")
+)
+
+// FlushHandler is called with metrics of the run, once they were written into the database.
+type FlushHandler func(namespaceID uint, runID string, metrics []models.Metric)
+
+// batchRequest represents metrics of a single Enqueue call within the run batch.
+type batchRequest struct {
+	size int
+	done chan error
+}
+
+// runBatch represents metrics of a run buffered since the last flush.
+type runBatch struct {
+	namespaceID uint
+	run         *models.Run
+	metrics     []models.Metric
+	weight      int64
+	requests    []batchRequest
+}
+
+// shard buffers metrics of a subset of runs. Every shard is flushed by its own worker,
+// so metrics of the same run are always written sequentially and keep the logged order.
+type shard struct {
+	mu      sync.Mutex
+	batches map[string]*runBatch
+	full    chan struct{}
+}
+
+// Service provides asynchronous ingestion of logged metrics. Metrics are buffered per run
+// and flushed in big batches, so latest metrics of a run are updated once per flush instead
+// of once per request. Service can be nil, when asynchronous ingestion is disabled,
+// then metrics have to be written directly.
+type Service struct {
+	mu               sync.RWMutex
+	config           config.MetricIngestConfig
+	queue            *semaphore.Weighted
+	shards           []*shard
+	stopped          bool
+	stop             chan struct{}
+	stopOnce         sync.Once
+	workers          sync.WaitGroup
+	metricRepository repositories.MetricRepositoryProvider
+	onFlush          FlushHandler
+}
+
+// NewService creates new Service instance and starts its workers. Workers are stopped,
+// when context is done, or the service is closed. Optional onFlush handler is called after every
+// successful flush, so written metrics can be published.
+func NewService(
+	ctx context.Context,
+	config *config.Config,
+	metricRepository repositories.MetricRepositoryProvider,
+	onFlush FlushHandler,
+) *Service {
+	service := Service{
+		config:           config.MetricIngest,
+		queue:            semaphore.NewWeighted(int64(config.MetricIngest.QueueSize)),
+		shards:           make([]*shard, config.MetricIngest.Workers),
+		stop:             make(chan struct{}),
+		metricRepository: metricRepository,
+		onFlush:          onFlush,
+	}
+	for i := range service.shards {
+		service.shards[i] = &shard{
+			batches: make(map[string]*runBatch),
+			full:    make(chan struct{}, 1),
+		}
+		service.workers.Add(1)
+		go service.work(service.shards[i])
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+			service.Close()
+		case <-service.stop:
+		}
+	}()
+	return &service
+}
+
+// Enqueue buffers metrics of the run. Depending on durability mode, it returns as soon as
+// metrics are buffered or after they were written into the database. When the queue is full,
+// it waits for a room during the queue timeout and then gives up with ErrQueueFull.
+func (s *Service) Enqueue(ctx context.Context, namespaceID uint, run *models.Run, metrics []models.Metric) error {
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	// a single request can't take more than the whole queue, otherwise it would wait forever.
+	weight := min(int64(len(metrics)), int64(s.config.QueueSize))
+	if err := s.acquire(ctx, weight); err != nil {
+		return err
+	}
+
+	var done chan error
+	if s.config.Durability == config.MetricIngestDurabilityFlush {
+		done = make(chan error, 1)
+	}
+	if err := s.add(namespaceID, run, metrics, weight, done); err != nil {
+		s.queue.Release(weight)
+		return err
+	}
+	if done == nil {
+		return nil
+	}
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// acquire takes a room for metrics in the queue. Zero queue timeout means not to wait at all.
+func (s *Service) acquire(ctx context.Context, weight int64) error {
+	if s.config.QueueTimeout == 0 {
+		if !s.queue.TryAcquire(weight) {
+			return ErrQueueFull
+		}
+		return nil
+	}
+	queueCtx, cancel := context.WithTimeout(ctx, s.config.QueueTimeout)
+	defer cancel()
+	if err := s.queue.Acquire(queueCtx, weight); err != nil {
+		return ErrQueueFull
+	}
+	return nil
+}
+
+// add appends metrics to the buffer of the run and wakes up the worker, when the buffer is full.
+func (s *Service) add(
+	namespaceID uint, run *models.Run, metrics []models.Metric, weight int64, done chan error,
+) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.stopped {
+		return ErrStopped
+	}
+
+	shard := s.shards[shardIndex(run.ID, len(s.shards))]
+	shard.mu.Lock()
+	defer shard.mu.Unlock()
+	batch, ok := shard.batches[run.ID]
+	if !ok {
+		batch = &runBatch{namespaceID: namespaceID, run: run}
+		shard.batches[run.ID] = batch
+	}
+	batch.metrics = append(batch.metrics, metrics...)
+	batch.weight += weight
+	batch.requests = append(batch.requests, batchRequest{size: len(metrics), done: done})
+	if len(batch.metrics) >= s.config.BatchSize {
+		select {
+		case shard.full <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Close stops accepting new metrics, flushes all the buffered ones and waits for the workers.
+func (s *Service) Close() {
+	s.stopOnce.Do(func() {
+		s.mu.Lock()
+		s.stopped = true
+		s.mu.Unlock()
+		close(s.stop)
+	})
+	s.workers.Wait()
+}
+
+// work periodically flushes buffered metrics of the shard. Full buffers are flushed immediately.
+func (s *Service) work(shard *shard) {
+	defer s.workers.Done()
+	ticker := time.NewTicker(s.config.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			s.flush(shard, 0)
+			log.Debug("metric ingestion worker finished. exiting.")
+			return
+		case <-ticker.C:
+			s.flush(shard, 0)
+		case <-shard.full:
+			s.flush(shard, s.config.BatchSize)
+		}
+	}
+}
+
+// flush writes buffered batches which have at least minSize metrics.
+func (s *Service) flush(shard *shard, minSize int) {
+	shard.mu.Lock()
+	batches := make([]*runBatch, 0, len(shard.batches))
+	for runID, batch := range shard.batches {
+		if len(batch.metrics) >= minSize {
+			batches = append(batches, batch)
+			delete(shard.batches, runID)
+		}
+	}
+	shard.mu.Unlock()
+
+	for _, batch := range batches {
+		s.write(batch)
+		s.queue.Release(batch.weight)
+	}
+}
+
+// write writes metrics of the batch. When coalesced metrics fail to be written, metrics of every request
+// are written separately, so only the requests, which can't be written, get the error.
+func (s *Service) write(batch *runBatch) {
+	err := s.createMetrics(batch, batch.metrics)
+	if err == nil || len(batch.requests) == 1 {
+		for _, request := range batch.requests {
+			s.complete(batch, request, err)
+		}
+		return
+	}
+
+	offset := 0
+	for _, request := range batch.requests {
+		metrics := batch.metrics[offset : offset+request.size]
+		offset += request.size
+		s.complete(batch, request, s.createMetrics(batch, metrics))
+	}
+}
+
+// createMetrics writes metrics of the run and publishes them, once they were written.
+func (s *Service) createMetrics(batch *runBatch, metrics []models.Metric) error {
+	// metrics are written even when the service is stopping, so buffered metrics aren't lost.
+	if err := s.metricRepository.CreateBatch(context.Background(), batch.run, insertBatchSize, metrics); err != nil {
+		return eris.Wrapf(err, "error flushing %d metrics of run '%s'", len(metrics), batch.run.ID)
+	}
+	if s.onFlush != nil {
+		s.onFlush(batch.namespaceID, batch.run.ID, metrics)
+	}
+	return nil
+}
+
+// complete reports result of the write to the request. Requests, which have already been acknowledged,
+// can't get the error, so their metrics are reported as dropped.
+func (s *Service) complete(batch *runBatch, request batchRequest, err error) {
+	if request.done != nil {
+		request.done <- err
+		return
+	}
+	if err != nil {
+		log.Errorf("error ingesting metrics: %+v", err)
+		telemetry.MetricPointsDroppedTotal.Add(float64(request.size))
+	}
+}
+
+// shardIndex returns index of the shard buffering metrics of the run.
+func shardIndex(runID string, shards int) int {
+	hash := fnv.New32a()
+	//nolint:errcheck
+	hash.Write([]byte(runID))
+	return int(hash.Sum32() % uint32(shards))
+}
@@ -0,0 +1,198 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/telemetry"
+)
+
+func newConfig(durability string) *config.Config {
+	return &config.Config{
+		MetricIngest: config.MetricIngestConfig{
+			Async:         true,
+			Durability:    durability,
+			Workers:       2,
+			QueueSize:     100,
+			QueueTimeout:  0,
+			BatchSize:     100,
+			FlushInterval: time.Hour,
+		},
+	}
+}
+
+func TestService_Enqueue_CoalescesMetricsOfRun(t *testing.T) {
+	run1, run2 := models.Run{ID: "run1"}, models.Run{ID: "run2"}
+	metricRepository := repositories.MockMetricRepositoryProvider{}
+	metricRepository.On("CreateBatch", mock.Anything, &run1, insertBatchSize, []models.Metric{
+		{Key: "loss", Value: 1, Step: 0, RunID: "run1"},
+		{Key: "loss", Value: 2, Step: 1, RunID: "run1"},
+		{Key: "loss", Value: 3, Step: 2, RunID: "run1"},
+	}).Return(nil).Once()
+	metricRepository.On("CreateBatch", mock.Anything, &run2, insertBatchSize, []models.Metric{
+		{Key: "loss", Value: 4, Step: 0, RunID: "run2"},
+	}).Return(nil).Once()
+
+	service := NewService(context.Background(), newConfig(config.MetricIngestDurabilityEnqueue), &metricRepository, nil)
+	require.Nil(t, service.Enqueue(context.Background(), 1, &run1, []models.Metric{
+		{Key: "loss", Value: 1, Step: 0, RunID: "run1"},
+	}))
+	require.Nil(t, service.Enqueue(context.Background(), 1, &run2, []models.Metric{
+		{Key: "loss", Value: 4, Step: 0, RunID: "run2"},
+	}))
+	require.Nil(t, service.Enqueue(context.Background(), 1, &run1, []models.Metric{
+		{Key: "loss", Value: 2, Step: 1, RunID: "run1"},
+		{Key: "loss", Value: 3, Step: 2, RunID: "run1"},
+	}))
+	metricRepository.AssertNotCalled(t, "CreateBatch")
+
+	// buffered metrics are flushed, when the service is closed.
+	service.Close()
+	metricRepository.AssertExpectations(t)
+	assert.Equal(t, ErrStopped, service.Enqueue(context.Background(), 1, &run1, []models.Metric{{Key: "loss"}}))
+}
+
+func TestService_Enqueue_WaitsForFlush(t *testing.T) {
+	run1, run2 := models.Run{ID: "run1"}, models.Run{ID: "run2"}
+	metricRepository := repositories.MockMetricRepositoryProvider{}
+	metricRepository.On("CreateBatch", mock.Anything, &run1, insertBatchSize, mock.Anything).Return(nil)
+	metricRepository.On(
+		"CreateBatch", mock.Anything, &run2, insertBatchSize, mock.Anything,
+	).Return(errors.New("database error"))
+
+	cfg := newConfig(config.MetricIngestDurabilityFlush)
+	cfg.MetricIngest.FlushInterval = 10 * time.Millisecond
+	service := NewService(context.Background(), cfg, &metricRepository, nil)
+	defer service.Close()
+
+	require.Nil(t, service.Enqueue(context.Background(), 1, &run1, []models.Metric{{Key: "loss", RunID: "run1"}}))
+	err := service.Enqueue(context.Background(), 1, &run2, []models.Metric{{Key: "loss", RunID: "run2"}})
+	require.NotNil(t, err)
+	assert.Equal(t, "error flushing 1 metrics of run 'run2': database error", err.Error())
+}
+
+func TestService_Enqueue_FlushesFullBatch(t *testing.T) {
+	run := models.Run{ID: "run1"}
+	metricRepository := repositories.MockMetricRepositoryProvider{}
+	metricRepository.On("CreateBatch", mock.Anything, &run, insertBatchSize, mock.Anything).Return(nil).Once()
+
+	// flush interval is too long, so metrics can be flushed only because the batch is full.
+	cfg := newConfig(config.MetricIngestDurabilityFlush)
+	cfg.MetricIngest.BatchSize = 2
+	service := NewService(context.Background(), cfg, &metricRepository, nil)
+	defer service.Close()
+
+	require.Nil(t, service.Enqueue(context.Background(), 1, &run, []models.Metric{
+		{Key: "loss", Value: 1, RunID: "run1"},
+		{Key: "loss", Value: 2, RunID: "run1"},
+	}))
+	metricRepository.AssertExpectations(t)
+}
+
+func TestService_Enqueue_QueueFull(t *testing.T) {
+	run := models.Run{ID: "run1"}
+	metricRepository := repositories.MockMetricRepositoryProvider{}
+	metricRepository.On("CreateBatch", mock.Anything, &run, insertBatchSize, mock.Anything).Return(nil)
+
+	cfg := newConfig(config.MetricIngestDurabilityEnqueue)
+	cfg.MetricIngest.QueueSize = 2
+	cfg.MetricIngest.QueueTimeout = 10 * time.Millisecond
+	service := NewService(context.Background(), cfg, &metricRepository, nil)
+	defer service.Close()
+
+	require.Nil(t, service.Enqueue(context.Background(), 1, &run, []models.Metric{{Key: "loss"}, {Key: "loss"}}))
+	assert.Equal(t, ErrQueueFull, service.Enqueue(context.Background(), 1, &run, []models.Metric{{Key: "loss"}}))
+
+	// the room in the queue is released, when buffered metrics are flushed.
+	service.flush(service.shards[shardIndex(run.ID, len(service.shards))], 0)
+	require.Nil(t, service.Enqueue(context.Background(), 1, &run, []models.Metric{{Key: "loss"}}))
+}
+
+func TestService_Enqueue_CallsFlushHandler(t *testing.T) {
+	run1, run2 := models.Run{ID: "run1"}, models.Run{ID: "run2"}
+	metricRepository := repositories.MockMetricRepositoryProvider{}
+	metricRepository.On("CreateBatch", mock.Anything, &run1, insertBatchSize, mock.Anything).Return(nil)
+	metricRepository.On(
+		"CreateBatch", mock.Anything, &run2, insertBatchSize, mock.Anything,
+	).Return(errors.New("database error"))
+
+	// handler is called only with the metrics, which were written into the database.
+	var flushed []string
+	service := NewService(
+		context.Background(),
+		newConfig(config.MetricIngestDurabilityEnqueue),
+		&metricRepository,
+		func(namespaceID uint, runID string, metrics []models.Metric) {
+			assert.Equal(t, uint(1), namespaceID)
+			assert.Equal(t, []models.Metric{{Key: "loss", RunID: runID}}, metrics)
+			flushed = append(flushed, runID)
+		},
+	)
+	require.Nil(t, service.Enqueue(context.Background(), 1, &run1, []models.Metric{{Key: "loss", RunID: "run1"}}))
+	require.Nil(t, service.Enqueue(context.Background(), 1, &run2, []models.Metric{{Key: "loss", RunID: "run2"}}))
+	assert.Empty(t, flushed)
+
+	service.Close()
+	assert.Equal(t, []string{"run1"}, flushed)
+}
+
+func TestService_Enqueue_WritesRequestsSeparatelyOnError(t *testing.T) {
+	run := models.Run{ID: "run1"}
+	metricRepository := repositories.MockMetricRepositoryProvider{}
+	metricRepository.On("CreateBatch", mock.Anything, &run, insertBatchSize, mock.MatchedBy(
+		func(metrics []models.Metric) bool { return len(metrics) == 3 },
+	)).Return(errors.New("database error")).Once()
+	metricRepository.On("CreateBatch", mock.Anything, &run, insertBatchSize, []models.Metric{
+		{Key: "loss", RunID: "run1"},
+	}).Return(nil).Once()
+	metricRepository.On("CreateBatch", mock.Anything, &run, insertBatchSize, []models.Metric{
+		{Key: "bad", RunID: "run1"},
+		{Key: "bad", RunID: "run1"},
+	}).Return(errors.New("database error")).Once()
+
+	// both requests are coalesced, as the batch is full only with metrics of both of them.
+	cfg := newConfig(config.MetricIngestDurabilityFlush)
+	cfg.MetricIngest.BatchSize = 3
+	service := NewService(context.Background(), cfg, &metricRepository, nil)
+	defer service.Close()
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- service.Enqueue(context.Background(), 1, &run, []models.Metric{{Key: "loss", RunID: "run1"}})
+	}()
+	err := service.Enqueue(context.Background(), 1, &run, []models.Metric{
+		{Key: "bad", RunID: "run1"},
+		{Key: "bad", RunID: "run1"},
+	})
+	require.NotNil(t, err)
+	assert.Equal(t, "error flushing 2 metrics of run 'run1': database error", err.Error())
+	assert.Nil(t, <-errs)
+	metricRepository.AssertExpectations(t)
+}
+
+func TestService_Enqueue_CountsDroppedMetrics(t *testing.T) {
+	run := models.Run{ID: "run1"}
+	metricRepository := repositories.MockMetricRepositoryProvider{}
+	metricRepository.On(
+		"CreateBatch", mock.Anything, &run, insertBatchSize, mock.Anything,
+	).Return(errors.New("database error"))
+
+	service := NewService(
+		context.Background(), newConfig(config.MetricIngestDurabilityEnqueue), &metricRepository, nil,
+	)
+	dropped := testutil.ToFloat64(telemetry.MetricPointsDroppedTotal)
+	require.Nil(t, service.Enqueue(context.Background(), 1, &run, []models.Metric{{Key: "loss"}, {Key: "loss"}}))
+
+	service.Close()
+	assert.Equal(t, dropped+2, testutil.ToFloat64(telemetry.MetricPointsDroppedTotal))
+}
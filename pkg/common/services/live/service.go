@@ -0,0 +1,231 @@
+package live
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/dao"
+	"github.com/G-Research/fasttrackml/pkg/common/events"
+)
+
+const (
+	// maxPayloadSize is a maximum size of published event, `postgres` doesn't allow NOTIFY payloads
+	// longer than 8000 bytes, so bigger events are split or truncated.
+	maxPayloadSize = 7900
+	// queueSize is a size of the queue of events received from the event bus.
+	queueSize = 1000
+	// subscriptionQueueSize is a size of the queue of every subscription.
+	subscriptionQueueSize = 100
+)
+
+// Subscription represents subscription to live updates of the runs.
+type Subscription struct {
+	namespaceID uint
+	runIDs      []string
+	events      chan events.RunEvent
+	closed      atomic.Bool
+	closeOnce   sync.Once
+}
+
+// Events returns channel with live updates. Channel is closed when subscriber was too slow
+// to receive the updates, so some of them were dropped.
+func (s *Subscription) Events() <-chan events.RunEvent {
+	return s.events
+}
+
+// matches makes check that subscription is interested in provided event.
+func (s *Subscription) matches(event events.RunEvent) bool {
+	if event.Action == events.EventActionReset {
+		return true
+	}
+	if event.NamespaceID != s.namespaceID {
+		return false
+	}
+	return len(s.runIDs) == 0 || slices.Contains(s.runIDs, event.RunID)
+}
+
+// close closes subscription channel.
+func (s *Subscription) close() {
+	s.closeOnce.Do(func() {
+		s.closed.Store(true)
+		close(s.events)
+	})
+}
+
+// Service provides live updates of the runs. Updates are delivered through the event bus,
+// so they are received by subscribers of all the instances sharing the same database.
+// Service can be nil, when live updates are disabled, then nothing is published.
+type Service struct {
+	mu            sync.RWMutex
+	ctx           context.Context
+	queue         chan string
+	eventListener dao.EventListenerProvider
+	subscriptions map[*Subscription]struct{}
+}
+
+// NewService creates new Service instance.
+func NewService(ctx context.Context, eventListener dao.EventListenerProvider) *Service {
+	service := Service{
+		ctx:           ctx,
+		queue:         make(chan string, queueSize),
+		eventListener: eventListener,
+		subscriptions: make(map[*Subscription]struct{}),
+	}
+	eventListener.Subscribe(events.RunChannel, service.queue)
+	go service.listen()
+	return &service
+}
+
+// Subscribe subscribes to live updates of the runs of the namespace. Empty list of runs means all of them.
+func (s *Service) Subscribe(namespaceID uint, runIDs []string) *Subscription {
+	subscription := Subscription{
+		namespaceID: namespaceID,
+		runIDs:      runIDs,
+		events:      make(chan events.RunEvent, subscriptionQueueSize),
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscriptions[&subscription] = struct{}{}
+	return &subscription
+}
+
+// Unsubscribe cancels the subscription.
+func (s *Service) Unsubscribe(subscription *Subscription) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.subscriptions, subscription)
+	subscription.close()
+}
+
+// PublishMetrics publishes new metric points of the run.
+func (s *Service) PublishMetrics(namespaceID uint, runID string, metrics []models.Metric) {
+	if s == nil || len(metrics) == 0 {
+		return
+	}
+	data := make([]events.RunMetricData, len(metrics))
+	for i, metric := range metrics {
+		data[i] = events.RunMetricData{
+			Key:       metric.Key,
+			Value:     metric.Value,
+			IsNan:     metric.IsNan,
+			Timestamp: metric.Timestamp,
+			Step:      metric.Step,
+			Iter:      metric.Iter,
+		}
+		if len(metric.Context.Json) > 0 {
+			data[i].Context = json.RawMessage(metric.Context.Json)
+		}
+	}
+	s.publish(events.RunEvent{
+		Action:      events.EventActionCreated,
+		Type:        events.RunEventTypeMetrics,
+		NamespaceID: namespaceID,
+		RunID:       runID,
+		Metrics:     data,
+	})
+}
+
+// PublishStatus publishes current status of the run.
+func (s *Service) PublishStatus(namespaceID uint, run *models.Run) {
+	if s == nil {
+		return
+	}
+	s.publish(events.RunEvent{
+		Action:      events.EventActionUpdated,
+		Type:        events.RunEventTypeStatus,
+		NamespaceID: namespaceID,
+		RunID:       run.ID,
+		Status:      string(run.Status),
+		EndTime:     run.EndTime.Int64,
+	})
+}
+
+// PublishLogs publishes new log lines of the run.
+func (s *Service) PublishLogs(namespaceID uint, runID string, logs ...string) {
+	if s == nil || len(logs) == 0 {
+		return
+	}
+	s.publish(events.RunEvent{
+		Action:      events.EventActionCreated,
+		Type:        events.RunEventTypeLogs,
+		NamespaceID: namespaceID,
+		RunID:       runID,
+		Logs:        logs,
+	})
+}
+
+// publish publishes event into the event bus. Live updates are the best effort,
+// so errors are only logged and never fail the request, which changed the run.
+func (s *Service) publish(event events.RunEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		log.Errorf("error serializing run event: %+v", err)
+		return
+	}
+	if len(data) > maxPayloadSize {
+		switch {
+		case len(event.Metrics) > 1:
+			first, second := event, event
+			first.Metrics, second.Metrics = event.Metrics[:len(event.Metrics)/2], event.Metrics[len(event.Metrics)/2:]
+			s.publish(first)
+			s.publish(second)
+			return
+		case len(event.Logs) > 1:
+			first, second := event, event
+			first.Logs, second.Logs = event.Logs[:len(event.Logs)/2], event.Logs[len(event.Logs)/2:]
+			s.publish(first)
+			s.publish(second)
+			return
+		default:
+			event.Metrics, event.Logs, event.Truncated = nil, nil, true
+			if data, err = json.Marshal(event); err != nil {
+				log.Errorf("error serializing run event: %+v", err)
+				return
+			}
+		}
+	}
+	if err := s.eventListener.Publish(nil, events.RunChannel, string(data)); err != nil {
+		log.Errorf("error publishing run event: %+v", err)
+	}
+}
+
+// listen delivers events received from the event bus to the subscribers.
+func (s *Service) listen() {
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case payload := <-s.queue:
+			var event events.RunEvent
+			if err := json.Unmarshal([]byte(payload), &event); err != nil {
+				log.Errorf("error unmarshaling run event: %+v", err)
+				continue
+			}
+			s.dispatch(event)
+		}
+	}
+}
+
+// dispatch sends event to all the interested subscribers. Subscribers, which can't keep up
+// with the updates, are closed, so they can reconnect and fetch current state of the runs.
+func (s *Service) dispatch(event events.RunEvent) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for subscription := range s.subscriptions {
+		if subscription.closed.Load() || !subscription.matches(event) {
+			continue
+		}
+		select {
+		case subscription.events <- event:
+		default:
+			log.Warnf("live updates subscriber is too slow, closing subscription")
+			subscription.close()
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package live
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"math"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/events"
+)
+
+// testEventBus is a simple in-process implementation of dao.EventListenerProvider.
+type testEventBus struct {
+	mu          sync.Mutex
+	payloads    []string
+	subscribers []chan<- string
+}
+
+func (b *testEventBus) Listen() {}
+
+func (b *testEventBus) Subscribe(_ string, subscriber chan<- string) {
+	b.subscribers = append(b.subscribers, subscriber)
+}
+
+func (b *testEventBus) Publish(_ *gorm.DB, _ string, payload string) error {
+	b.mu.Lock()
+	b.payloads = append(b.payloads, payload)
+	b.mu.Unlock()
+	for _, subscriber := range b.subscribers {
+		subscriber <- payload
+	}
+	return nil
+}
+
+func receive(t *testing.T, subscription *Subscription) events.RunEvent {
+	select {
+	case event, ok := <-subscription.Events():
+		require.True(t, ok)
+		return event
+	case <-time.After(time.Second):
+		require.FailNow(t, "event was not received")
+	}
+	return events.RunEvent{}
+}
+
+func TestService_Ok(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventBus := testEventBus{}
+	service := NewService(ctx, &eventBus)
+	allRuns := service.Subscribe(1, nil)
+	singleRun := service.Subscribe(1, []string{"run2"})
+	otherNamespace := service.Subscribe(2, nil)
+
+	service.PublishMetrics(1, "run1", []models.Metric{
+		{Key: "loss", Value: 0.5, Timestamp: 1, Step: 2, Iter: 3, Context: models.Context{Json: []byte(`{"a":"b"}`)}},
+	})
+	service.PublishStatus(1, &models.Run{
+		ID: "run2", Status: models.StatusFinished, EndTime: sql.NullInt64{Int64: 123, Valid: true},
+	})
+	service.PublishLogs(1, "run2", "line")
+
+	event := receive(t, allRuns)
+	assert.Equal(t, events.RunEventTypeMetrics, event.Type)
+	assert.Equal(t, "run1", event.RunID)
+	assert.Equal(t, []events.RunMetricData{
+		{Key: "loss", Value: 0.5, Timestamp: 1, Step: 2, Iter: 3, Context: json.RawMessage(`{"a":"b"}`)},
+	}, event.Metrics)
+
+	event = receive(t, allRuns)
+	assert.Equal(t, events.RunEventTypeStatus, event.Type)
+	assert.Equal(t, string(models.StatusFinished), event.Status)
+	assert.Equal(t, int64(123), event.EndTime)
+
+	event = receive(t, singleRun)
+	assert.Equal(t, events.RunEventTypeStatus, event.Type)
+	assert.Equal(t, "run2", event.RunID)
+	event = receive(t, singleRun)
+	assert.Equal(t, events.RunEventTypeLogs, event.Type)
+	assert.Equal(t, []string{"line"}, event.Logs)
+
+	// reset event is delivered to everyone.
+	require.Nil(t, eventBus.Publish(nil, events.RunChannel, `{"action":"reset"}`))
+	event = receive(t, otherNamespace)
+	assert.Equal(t, events.EventAction(events.EventActionReset), event.Action)
+	event = receive(t, singleRun)
+	assert.Equal(t, events.EventAction(events.EventActionReset), event.Action)
+
+	service.Unsubscribe(singleRun)
+	_, ok := <-singleRun.Events()
+	assert.False(t, ok)
+}
+
+func TestService_PublishLargeEvents_Ok(t *testing.T) {
+	eventBus := testEventBus{}
+	service := &Service{eventListener: &eventBus}
+
+	metrics := make([]models.Metric, 500)
+	for i := range metrics {
+		metrics[i] = models.Metric{Key: "loss", Value: math.Pi, Step: int64(i), Iter: int64(i + 1)}
+	}
+	service.PublishMetrics(1, "run", metrics)
+	service.PublishLogs(1, "run", strings.Repeat("x", maxPayloadSize))
+
+	count := 0
+	for i, payload := range eventBus.payloads {
+		assert.LessOrEqual(t, len(payload), maxPayloadSize)
+		var event events.RunEvent
+		require.Nil(t, json.Unmarshal([]byte(payload), &event))
+		if i == len(eventBus.payloads)-1 {
+			assert.True(t, event.Truncated)
+			assert.Empty(t, event.Logs)
+			continue
+		}
+		count += len(event.Metrics)
+	}
+	assert.Greater(t, len(eventBus.payloads), 2)
+	assert.Equal(t, len(metrics), count)
+}
+
+func TestService_Disabled_Ok(t *testing.T) {
+	var service *Service
+	service.PublishMetrics(1, "run", []models.Metric{{Key: "loss"}})
+	service.PublishStatus(1, &models.Run{ID: "run"})
+	service.PublishLogs(1, "run", "line")
+}
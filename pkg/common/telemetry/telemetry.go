@@ -0,0 +1,104 @@
+package telemetry
+
+import (
+	"database/sql"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace is a common prefix of all the exported metrics.
+const namespace = "fasttrackml"
+
+// Namespace cache lookup results.
+const (
+	CacheResultHit  = "hit"
+	CacheResultMiss = "miss"
+)
+
+// registry holds process wide collectors. Collectors, which depend on a server instance,
+// are registered separately by NewHandler.
+var registry = prometheus.NewRegistry()
+
+var (
+	// HTTPRequestsTotal counts handled requests per API, method, route and status code.
+	HTTPRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Number of handled HTTP requests.",
+	}, []string{"api", "method", "route", "status"})
+	// HTTPRequestDuration observes latency of handled requests per API, method and route.
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of handled HTTP requests.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"api", "method", "route"})
+	// DatabaseSlowQueriesTotal counts queries, which took longer than the slow query threshold.
+	DatabaseSlowQueriesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "database",
+		Name:      "slow_queries_total",
+		Help:      "Number of queries slower than the configured slow query threshold.",
+	})
+	// MetricPointsIngestedTotal counts metric points written into the database.
+	MetricPointsIngestedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "metrics",
+		Name:      "points_ingested_total",
+		Help:      "Number of metric points written into the database.",
+	})
+	// MetricPointsDroppedTotal counts metric points, which were acknowledged, but failed to be written.
+	MetricPointsDroppedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "metrics",
+		Name:      "points_dropped_total",
+		Help:      "Number of acknowledged metric points, which failed to be written into the database.",
+	})
+	// NamespaceCacheRequestsTotal counts namespace cache lookups per result (hit or miss).
+	NamespaceCacheRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "namespace_cache",
+		Name:      "requests_total",
+		Help:      "Number of namespace cache lookups.",
+	}, []string{"result"})
+	// ArtifactBytesServedTotal counts bytes of artifacts sent to the clients.
+	ArtifactBytesServedTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "artifacts",
+		Name:      "bytes_served_total",
+		Help:      "Number of artifact bytes sent to the clients.",
+	})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestsTotal,
+		HTTPRequestDuration,
+		DatabaseSlowQueriesTotal,
+		MetricPointsIngestedTotal,
+		MetricPointsDroppedTotal,
+		NamespaceCacheRequestsTotal,
+		ArtifactBytesServedTotal,
+	)
+}
+
+// NewHandler creates new handler, which exposes collected metrics and stats of the provided
+// database connection pools in Prometheus format. Pools are distinguished by `db_name` label.
+func NewHandler(pools map[string]*sql.DB) fiber.Handler {
+	dbRegistry := prometheus.NewRegistry()
+	for name, pool := range pools {
+		dbRegistry.MustRegister(collectors.NewDBStatsCollector(pool, name))
+	}
+	return adaptor.HTTPHandler(promhttp.HandlerFor(
+		prometheus.Gatherers{registry, dbRegistry},
+		promhttp.HandlerOpts{},
+	))
+}
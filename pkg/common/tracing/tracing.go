@@ -0,0 +1,91 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/version"
+)
+
+// tracerName is a name of the tracer used to create all the spans.
+const tracerName = "github.com/G-Research/fasttrackml"
+
+// requestSpanKey is a key of the request span in fiber locals.
+type requestSpanKey struct{}
+
+// NewTracerProvider creates new tracer provider exporting spans to the configured OTLP endpoint
+// and registers it globally together with W3C trace context propagator. Provider has to be
+// shut down to export the buffered spans.
+func NewTracerProvider(ctx context.Context, config *config.Config) (*sdktrace.TracerProvider, error) {
+	options := []otlptracehttp.Option{}
+	if config.Tracing.Endpoint != "" {
+		options = append(options, otlptracehttp.WithEndpoint(config.Tracing.Endpoint))
+	}
+	if config.Tracing.Insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	exporter, err := otlptracehttp.New(ctx, options...)
+	if err != nil {
+		return nil, eris.Wrap(err, "error creating otlp trace exporter")
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(config.Tracing.ServiceName),
+		semconv.ServiceVersion(version.Version),
+	))
+	if err != nil {
+		return nil, eris.Wrap(err, "error creating trace resource")
+	}
+
+	// sampling decision of the incoming trace context is respected, so traces of
+	// the training pipelines are complete regardless of the configured ratio.
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.Tracing.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{},
+	))
+	return provider, nil
+}
+
+// Tracer returns tracer used to create all the spans.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// StartSpan starts new span as a child of the span from the context. Controllers pass
+// fasthttp request context to the services, so the span of the request is looked up in it too.
+// Only requests are traced, so without a span in the context the context is returned as is
+// together with a no-op span.
+func StartSpan(
+	ctx context.Context, name string, options ...trace.SpanStartOption,
+) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		span, ok := ctx.Value(requestSpanKey{}).(trace.Span)
+		if !ok {
+			return ctx, trace.SpanFromContext(ctx)
+		}
+		ctx = trace.ContextWithSpan(ctx, span)
+	}
+	return Tracer().Start(ctx, name, options...)
+}
+
+// SetRequestSpan stores span of the request, so it becomes a parent of the spans started
+// with the request context.
+func SetRequestSpan(ctx *fiber.Ctx, span trace.Span) {
+	ctx.Locals(requestSpanKey{}, span)
+}
@@ -0,0 +1,339 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+)
+
+const (
+	// aimRepositoryDir is a name of the directory, which contains Aim repository.
+	aimRepositoryDir = ".aim"
+	// aimRepositoryMetaFile is a name of the SQLite database of Aim repository, which keeps
+	// experiments, runs and tags.
+	aimRepositoryMetaFile = "run_metadata.sqlite"
+	// aimDefaultExperimentName is a name of the experiment, which Aim assigns runs to by default.
+	aimDefaultExperimentName = "default"
+	// aimRunIDLength is a length of the run id, Aim run hashes are shorter, so they are padded.
+	aimRunIDLength = 32
+)
+
+// aimExperiment represents row of `experiment` table of Aim repository.
+type aimExperiment struct {
+	ID          int64
+	Name        string
+	Description sql.NullString
+	IsArchived  sql.NullBool
+	CreatedAt   sql.NullTime
+	UpdatedAt   sql.NullTime
+}
+
+// aimRun represents row of `run` table of Aim repository.
+type aimRun struct {
+	ID           int64
+	Hash         string
+	Name         sql.NullString
+	Description  sql.NullString
+	IsArchived   sql.NullBool
+	CreatedAt    sql.NullTime
+	UpdatedAt    sql.NullTime
+	FinalizedAt  sql.NullTime
+	ExperimentID sql.NullInt64
+}
+
+// aimTag represents row of `tag` table of Aim repository.
+type aimTag struct {
+	ID          int64
+	UUID        string
+	Name        string
+	Color       sql.NullString
+	Description sql.NullString
+	IsArchived  sql.NullBool
+}
+
+// aimRunTag represents row of `run_tag` table of Aim repository.
+type aimRunTag struct {
+	RunID int64
+	TagID int64
+}
+
+// AimRepositorySummary contains the number of experiments, runs and tags found in Aim repository.
+type AimRepositorySummary struct {
+	Experiments int
+	Runs        int
+	Tags        int
+}
+
+// AimRepositoryImporter imports experiments, runs and tags from Aim repository (`.aim` directory).
+// They are kept by Aim in `run_metadata.sqlite`, which is loaded into a standalone SQLite database
+// first and then copied into destination namespace by Importer. Params and metric series are kept by
+// Aim in RocksDB databases with its own encoding, so they aren't imported.
+type AimRepositoryImporter struct {
+	db            *gorm.DB
+	root          string
+	namespaceCode string
+}
+
+// NewAimRepositoryImporter initializes an AimRepositoryImporter.
+func NewAimRepositoryImporter(
+	db *gorm.DB, root string, options ...func(importer *AimRepositoryImporter),
+) *AimRepositoryImporter {
+	importer := AimRepositoryImporter{
+		db:            db,
+		root:          root,
+		namespaceCode: models.DefaultNamespaceCode,
+	}
+	for _, o := range options {
+		o(&importer)
+	}
+	return &importer
+}
+
+// WithAimRepositoryNamespace sets destination Namespace.
+func WithAimRepositoryNamespace(namespace string) func(importer *AimRepositoryImporter) {
+	return func(i *AimRepositoryImporter) {
+		if namespace != "" {
+			i.namespaceCode = namespace
+		}
+	}
+}
+
+// Import reads Aim repository and imports its content.
+func (i *AimRepositoryImporter) Import(ctx context.Context) (*AimRepositorySummary, error) {
+	path, err := findAimRepositoryMetaFile(i.root)
+	if err != nil {
+		return nil, err
+	}
+	// Aim repository is opened read-only, so it is left as is, even if Aim is still running.
+	sourceDB, err := gorm.Open(
+		sqlite.Open(fmt.Sprintf("file:%s?mode=ro", path)), &gorm.Config{Logger: logger.Discard},
+	)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error opening aim repository %s", i.root)
+	}
+	if db, err := sourceDB.DB(); err == nil {
+		//nolint:errcheck
+		defer db.Close()
+	}
+
+	var summary *AimRepositorySummary
+	if err := importStaging(ctx, i.db, i.namespaceCode, func(db *gorm.DB, namespaceID uint) error {
+		summary, err = i.load(sourceDB.WithContext(ctx), db, namespaceID)
+		return err
+	}, nil); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// load reads experiments, runs and tags of Aim repository into staging database.
+func (i *AimRepositoryImporter) load(sourceDB, db *gorm.DB, namespaceID uint) (*AimRepositorySummary, error) {
+	// descriptions of experiments have been added by later Aim versions.
+	experimentColumns := "id, name, is_archived, created_at, updated_at"
+	if sourceDB.Migrator().HasColumn("experiment", "description") {
+		experimentColumns += ", description"
+	}
+	var experiments []aimExperiment
+	if err := sourceDB.Table("experiment").Select(experimentColumns).Order("id").Scan(&experiments).Error; err != nil {
+		return nil, eris.Wrapf(err, "error reading experiments of aim repository %s", i.root)
+	}
+	var runs []aimRun
+	if err := sourceDB.Table("run").Select(
+		"id, hash, name, description, is_archived, created_at, updated_at, finalized_at, experiment_id",
+	).Order("id").Scan(&runs).Error; err != nil {
+		return nil, eris.Wrapf(err, "error reading runs of aim repository %s", i.root)
+	}
+	var tags []aimTag
+	if err := sourceDB.Table("tag").Select(
+		"id, uuid, name, color, description, is_archived",
+	).Order("id").Scan(&tags).Error; err != nil {
+		return nil, eris.Wrapf(err, "error reading tags of aim repository %s", i.root)
+	}
+	var runTags []aimRunTag
+	if err := sourceDB.Table("run_tag").Select("run_id, tag_id").Scan(&runTags).Error; err != nil {
+		return nil, eris.Wrapf(err, "error reading run tags of aim repository %s", i.root)
+	}
+
+	summary := AimRepositorySummary{}
+	// experiment identifiers of Aim are assigned anew, except the default experiment, which becomes
+	// the default experiment of the namespace together with runs without experiment.
+	experimentIDs := map[int64]int32{}
+	nextID := int32(1)
+	hasDefaultExperiment := false
+	for _, aimExperiment := range experiments {
+		experiment := Experiment{
+			ID:             common.GetPointer(nextID),
+			Name:           aimExperiment.Name,
+			LifecycleStage: newAimLifecycleStage(aimExperiment.IsArchived),
+			CreationTime:   newAimTimestamp(aimExperiment.CreatedAt),
+			LastUpdateTime: newAimTimestamp(aimExperiment.UpdatedAt),
+			NamespaceID:    namespaceID,
+		}
+		if aimExperiment.Name == aimDefaultExperimentName && !hasDefaultExperiment {
+			experiment.ID, experiment.Name = common.GetPointer(DefaultExperimentID), "Default"
+			hasDefaultExperiment = true
+		} else {
+			nextID++
+		}
+		if err := db.Create(&experiment).Error; err != nil {
+			return nil, eris.Wrapf(err, "error creating experiment %s", aimExperiment.Name)
+		}
+		if aimExperiment.Description.String != "" {
+			if err := db.Create(&ExperimentTag{
+				Key:          common.DescriptionTagKey,
+				Value:        aimExperiment.Description.String,
+				ExperimentID: *experiment.ID,
+			}).Error; err != nil {
+				return nil, eris.Wrapf(err, "error creating description of experiment %s", aimExperiment.Name)
+			}
+		}
+		experimentIDs[aimExperiment.ID] = *experiment.ID
+		summary.Experiments++
+	}
+
+	runIDs := make(map[int64]string, len(runs))
+	for _, aimRun := range runs {
+		experimentID, ok := experimentIDs[aimRun.ExperimentID.Int64]
+		if !aimRun.ExperimentID.Valid || !ok {
+			experimentID = DefaultExperimentID
+			if !hasDefaultExperiment {
+				if err := db.Create(&Experiment{
+					ID:             common.GetPointer(DefaultExperimentID),
+					Name:           "Default",
+					LifecycleStage: LifecycleStageActive,
+					NamespaceID:    namespaceID,
+				}).Error; err != nil {
+					return nil, eris.Wrap(err, "error creating default experiment")
+				}
+				hasDefaultExperiment = true
+				summary.Experiments++
+			}
+		}
+		run := Run{
+			ID:             newAimRunID(aimRun.Hash),
+			Name:           aimRun.Name.String,
+			SourceType:     "UNKNOWN",
+			Status:         StatusRunning,
+			StartTime:      newAimTimestamp(aimRun.CreatedAt),
+			EndTime:        newAimTimestamp(aimRun.FinalizedAt),
+			LifecycleStage: newAimLifecycleStage(aimRun.IsArchived),
+			ExperimentID:   experimentID,
+		}
+		if aimRun.FinalizedAt.Valid {
+			run.Status = StatusFinished
+		}
+		if run.LifecycleStage == LifecycleStageDeleted {
+			run.DeletedTime = newAimTimestamp(aimRun.UpdatedAt)
+		}
+		if err := db.Create(&run).Error; err != nil {
+			return nil, eris.Wrapf(err, "error creating run %s", aimRun.Hash)
+		}
+		if aimRun.Description.String != "" {
+			if err := db.Create(&Tag{
+				Key: common.DescriptionTagKey, Value: aimRun.Description.String, RunID: run.ID,
+			}).Error; err != nil {
+				return nil, eris.Wrapf(err, "error creating description of run %s", aimRun.Hash)
+			}
+		}
+		runIDs[aimRun.ID] = run.ID
+		summary.Runs++
+	}
+
+	tagIDs := make(map[int64]uuid.UUID, len(tags))
+	for _, aimTag := range tags {
+		// tags are identified by their uuid, so repeated imports don't duplicate them.
+		id, err := uuid.Parse(aimTag.UUID)
+		if err != nil {
+			return nil, eris.Wrapf(err, "error parsing uuid of tag %s", aimTag.Name)
+		}
+		if err := db.Create(&SharedTag{
+			ID:          id,
+			IsArchived:  aimTag.IsArchived.Bool,
+			Name:        aimTag.Name,
+			Color:       aimTag.Color.String,
+			Description: aimTag.Description.String,
+			NamespaceID: namespaceID,
+		}).Error; err != nil {
+			return nil, eris.Wrapf(err, "error creating tag %s", aimTag.Name)
+		}
+		tagIDs[aimTag.ID] = id
+		summary.Tags++
+	}
+	for _, runTag := range runTags {
+		runID, ok := runIDs[runTag.RunID]
+		if !ok {
+			continue
+		}
+		tagID, ok := tagIDs[runTag.TagID]
+		if !ok {
+			continue
+		}
+		if err := db.Table("run_shared_tags").Create(map[string]any{
+			"run_id":        runID,
+			"shared_tag_id": tagID,
+		}).Error; err != nil {
+			return nil, eris.Wrapf(err, "error creating tag of run %s", runID)
+		}
+	}
+	log.Infof(
+		"Loaded %d experiments, %d runs and %d tags from aim repository %s",
+		summary.Experiments, summary.Runs, summary.Tags, i.root,
+	)
+	return &summary, nil
+}
+
+// findAimRepositoryMetaFile returns path of `run_metadata.sqlite` of Aim repository, which could
+// be provided either as `.aim` directory or as directory, where Aim repository has been initialized.
+func findAimRepositoryMetaFile(root string) (string, error) {
+	for _, path := range []string{
+		filepath.Join(root, aimRepositoryMetaFile),
+		filepath.Join(root, aimRepositoryDir, aimRepositoryMetaFile),
+	} {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return "", eris.Wrapf(err, "error reading aim repository %s", root)
+		}
+	}
+	return "", eris.Errorf("no aim repository found in %s", root)
+}
+
+// newAimRunID converts hash of Aim run into run id. Run ids are referenced by uuid columns of tags,
+// so shorter Aim hashes are padded with zeros.
+func newAimRunID(hash string) string {
+	if len(hash) < aimRunIDLength {
+		return hash + strings.Repeat("0", aimRunIDLength-len(hash))
+	}
+	return hash
+}
+
+// newAimLifecycleStage converts archived flag of Aim into lifecycle stage.
+func newAimLifecycleStage(isArchived sql.NullBool) LifecycleStage {
+	if isArchived.Bool {
+		return LifecycleStageDeleted
+	}
+	return LifecycleStageActive
+}
+
+// newAimTimestamp converts time of Aim into milliseconds.
+func newAimTimestamp(value sql.NullTime) sql.NullInt64 {
+	if !value.Valid {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: value.Time.UnixMilli(), Valid: true}
+}
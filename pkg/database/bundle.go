@@ -0,0 +1,660 @@
+package database
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/version"
+)
+
+// Bundle format details.
+const (
+	BundleFormat        = "fasttrackml-namespace-bundle"
+	BundleFormatVersion = 1
+	bundleManifestName  = "manifest.json"
+	bundleDatabaseName  = "database.db"
+	bundleArtifactsDir  = "artifacts"
+)
+
+// bundleTables contains tables, which are exported into the bundle.
+var bundleTables = []string{
+	"apps",
+	"dashboards",
+	"experiments",
+	"experiment_tags",
+	"runs",
+	"tags",
+	"params",
+	"contexts",
+	"metrics",
+	"latest_metrics",
+	"logs",
+	"artifacts",
+	"datasets",
+	"inputs",
+	"input_tags",
+	"shared_tags",
+	"run_shared_tags",
+	"registered_models",
+	"registered_model_tags",
+	"registered_model_aliases",
+	"model_versions",
+	"model_version_tags",
+}
+
+// BundleManifest describes the content of namespace bundle.
+type BundleManifest struct {
+	Format             string           `json:"format"`
+	FormatVersion      int              `json:"format_version"`
+	FastTrackMLVersion string           `json:"fasttrackml_version"`
+	SchemaVersion      string           `json:"schema_version"`
+	CreatedAt          time.Time        `json:"created_at"`
+	Namespace          BundleNamespace  `json:"namespace"`
+	Database           string           `json:"database"`
+	Tables             map[string]int64 `json:"tables"`
+	Artifacts          []BundleArtifact `json:"artifacts,omitempty"`
+}
+
+// BundleNamespace describes exported namespace.
+type BundleNamespace struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+// BundleArtifact describes exported run artifact.
+type BundleArtifact struct {
+	RunID string `json:"run_id"`
+	Path  string `json:"path"`
+	Size  int64  `json:"size"`
+}
+
+// Validate validates that bundle manifest is supported.
+func (m BundleManifest) Validate() error {
+	if m.Format != BundleFormat {
+		return eris.Errorf("unsupported bundle format: %s", m.Format)
+	}
+	if m.FormatVersion < 1 || m.FormatVersion > BundleFormatVersion {
+		return eris.Errorf("unsupported bundle format version: %d", m.FormatVersion)
+	}
+	if m.Namespace.Code == "" {
+		return eris.New("bundle namespace code is empty")
+	}
+	return nil
+}
+
+// Exporter exports single namespace into a portable `tar.zst` bundle. The bundle contains
+// `manifest.json`, a SQLite database with namespace data and, optionally, run artifacts.
+type Exporter struct {
+	db                     *gorm.DB
+	namespaceCode          string
+	artifactStorageFactory storage.ArtifactStorageFactoryProvider
+}
+
+// NewExporter initializes an Exporter.
+func NewExporter(db *gorm.DB, namespaceCode string, options ...func(exporter *Exporter)) *Exporter {
+	exporter := Exporter{
+		db:            db,
+		namespaceCode: namespaceCode,
+	}
+	for _, o := range options {
+		o(&exporter)
+	}
+	return &exporter
+}
+
+// WithExportArtifacts enables export of run artifacts.
+func WithExportArtifacts(factory storage.ArtifactStorageFactoryProvider) func(exporter *Exporter) {
+	return func(e *Exporter) {
+		e.artifactStorageFactory = factory
+	}
+}
+
+// Export writes namespace bundle into the writer.
+func (e *Exporter) Export(ctx context.Context, writer io.Writer) (*BundleManifest, error) {
+	var namespace Namespace
+	if err := e.db.WithContext(ctx).Where("code = ?", e.namespaceCode).First(&namespace).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, eris.Wrapf(err, "namespace %s not found", e.namespaceCode)
+		}
+		return nil, eris.Wrapf(err, "error getting namespace %s", e.namespaceCode)
+	}
+
+	dir, err := os.MkdirTemp("", "fml-export-*")
+	if err != nil {
+		return nil, eris.Wrap(err, "error creating temporary directory")
+	}
+	//nolint:errcheck
+	defer os.RemoveAll(dir)
+
+	// copy namespace data into standalone database, which will be a part of the bundle.
+	databasePath := filepath.Join(dir, bundleDatabaseName)
+	bundleDB, err := openBundleDatabase(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	//nolint:errcheck
+	defer bundleDB.Close()
+
+	if err := bundleDB.GormDB().Create(&Namespace{
+		ID:                  namespace.ID,
+		Code:                namespace.Code,
+		Description:         namespace.Description,
+		DefaultExperimentID: namespace.DefaultExperimentID,
+	}).Error; err != nil {
+		return nil, eris.Wrap(err, "error creating bundle namespace")
+	}
+	importer := NewImporter(
+		e.db.WithContext(ctx),
+		bundleDB.GormDB().WithContext(ctx),
+		WithSourceNamespace(namespace.Code),
+		WithDestinationNamespace(namespace.Code),
+	)
+	if err := importer.Import(); err != nil {
+		return nil, eris.Wrap(err, "error copying namespace data")
+	}
+	if err := updateDefaultExperiment(
+		bundleDB.GormDB(), importer, &namespace, namespace.DefaultExperimentID,
+	); err != nil {
+		return nil, eris.Wrap(err, "error updating bundle namespace")
+	}
+
+	manifest := BundleManifest{
+		Format:             BundleFormat,
+		FormatVersion:      BundleFormatVersion,
+		FastTrackMLVersion: version.Version,
+		SchemaVersion:      currentVersion(),
+		CreatedAt:          time.Now().UTC(),
+		Namespace: BundleNamespace{
+			Code:        namespace.Code,
+			Description: namespace.Description,
+		},
+		Database: bundleDatabaseName,
+		Tables:   make(map[string]int64, len(bundleTables)),
+	}
+	for _, table := range bundleTables {
+		var count int64
+		if err := bundleDB.GormDB().Table(table).Count(&count).Error; err != nil {
+			return nil, eris.Wrapf(err, "error counting %s rows", table)
+		}
+		manifest.Tables[table] = count
+	}
+
+	var runs []Run
+	if e.artifactStorageFactory != nil {
+		if err := bundleDB.GormDB().Select("run_uuid", "artifact_uri").Find(&runs).Error; err != nil {
+			return nil, eris.Wrap(err, "error getting runs")
+		}
+		for _, run := range runs {
+			artifacts, err := listArtifacts(ctx, e.artifactStorageFactory, run, "")
+			if err != nil {
+				return nil, eris.Wrapf(err, "error listing artifacts of run %s", run.ID)
+			}
+			manifest.Artifacts = append(manifest.Artifacts, artifacts...)
+		}
+	}
+
+	if err := bundleDB.Close(); err != nil {
+		return nil, eris.Wrap(err, "error closing bundle database")
+	}
+
+	if err := e.writeArchive(ctx, writer, &manifest, databasePath, runs); err != nil {
+		return nil, eris.Wrap(err, "error writing bundle")
+	}
+	return &manifest, nil
+}
+
+// listArtifacts recursively lists artifacts of the run.
+func listArtifacts(
+	ctx context.Context, factory storage.ArtifactStorageFactoryProvider, run Run, dir string,
+) ([]BundleArtifact, error) {
+	artifactStorage, err := factory.GetStorage(ctx, run.ArtifactURI)
+	if err != nil {
+		return nil, eris.Wrap(err, "error getting artifact storage")
+	}
+	objects, err := artifactStorage.List(ctx, run.ArtifactURI, dir)
+	if err != nil {
+		return nil, eris.Wrapf(err, "error listing artifacts under %q", dir)
+	}
+
+	var artifacts []BundleArtifact
+	for _, object := range objects {
+		if object.IsDirectory() {
+			nested, err := listArtifacts(ctx, factory, run, object.GetPath())
+			if err != nil {
+				return nil, err
+			}
+			artifacts = append(artifacts, nested...)
+			continue
+		}
+		artifacts = append(artifacts, BundleArtifact{
+			RunID: run.ID,
+			Path:  object.GetPath(),
+			Size:  object.GetSize(),
+		})
+	}
+	return artifacts, nil
+}
+
+// writeArchive writes manifest, database and artifacts into compressed archive.
+func (e *Exporter) writeArchive(
+	ctx context.Context, writer io.Writer, manifest *BundleManifest, databasePath string, runs []Run,
+) error {
+	zstdWriter, err := zstd.NewWriter(writer)
+	if err != nil {
+		return eris.Wrap(err, "error creating zstd writer")
+	}
+	tarWriter := tar.NewWriter(zstdWriter)
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return eris.Wrap(err, "error serializing manifest")
+	}
+	if err := writeArchiveEntry(tarWriter, bundleManifestName, int64(len(data)), bytes.NewReader(data)); err != nil {
+		return err
+	}
+
+	databaseFile, err := os.Open(databasePath)
+	if err != nil {
+		return eris.Wrap(err, "error opening bundle database")
+	}
+	//nolint:errcheck
+	defer databaseFile.Close()
+	info, err := databaseFile.Stat()
+	if err != nil {
+		return eris.Wrap(err, "error getting bundle database info")
+	}
+	if err := writeArchiveEntry(tarWriter, bundleDatabaseName, info.Size(), databaseFile); err != nil {
+		return err
+	}
+
+	artifactURIs := make(map[string]string, len(runs))
+	for _, run := range runs {
+		artifactURIs[run.ID] = run.ArtifactURI
+	}
+	for _, artifact := range manifest.Artifacts {
+		artifactURI := artifactURIs[artifact.RunID]
+		artifactStorage, err := e.artifactStorageFactory.GetStorage(ctx, artifactURI)
+		if err != nil {
+			return eris.Wrap(err, "error getting artifact storage")
+		}
+		reader, err := artifactStorage.Get(ctx, artifactURI, artifact.Path)
+		if err != nil {
+			return eris.Wrapf(err, "error getting artifact %s of run %s", artifact.Path, artifact.RunID)
+		}
+		err = writeArchiveEntry(
+			tarWriter, path.Join(bundleArtifactsDir, artifact.RunID, artifact.Path), artifact.Size, reader,
+		)
+		//nolint:errcheck,gosec
+		reader.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return eris.Wrap(err, "error closing tar writer")
+	}
+	if err := zstdWriter.Close(); err != nil {
+		return eris.Wrap(err, "error closing zstd writer")
+	}
+	return nil
+}
+
+// BundleImporter imports namespace bundle, created by Exporter.
+type BundleImporter struct {
+	db                     *gorm.DB
+	namespaceCode          string
+	artifactRoot           string
+	artifactStorageFactory storage.ArtifactStorageFactoryProvider
+}
+
+// NewBundleImporter initializes a BundleImporter.
+func NewBundleImporter(db *gorm.DB, options ...func(importer *BundleImporter)) *BundleImporter {
+	importer := BundleImporter{
+		db: db,
+	}
+	for _, o := range options {
+		o(&importer)
+	}
+	return &importer
+}
+
+// WithBundleNamespace sets destination Namespace. By default, namespace code from the bundle is used.
+func WithBundleNamespace(namespace string) func(importer *BundleImporter) {
+	return func(i *BundleImporter) {
+		i.namespaceCode = namespace
+	}
+}
+
+// WithImportArtifacts enables import of run artifacts into the provided artifact root.
+func WithImportArtifacts(
+	factory storage.ArtifactStorageFactoryProvider, artifactRoot string,
+) func(importer *BundleImporter) {
+	return func(i *BundleImporter) {
+		i.artifactStorageFactory = factory
+		i.artifactRoot = artifactRoot
+	}
+}
+
+// Import reads namespace bundle from the reader and imports its content.
+func (i *BundleImporter) Import(ctx context.Context, reader io.Reader) (*BundleManifest, error) {
+	zstdReader, err := zstd.NewReader(reader)
+	if err != nil {
+		return nil, eris.Wrap(err, "error creating zstd reader")
+	}
+	defer zstdReader.Close()
+	tarReader := tar.NewReader(zstdReader)
+
+	// bundle always starts with manifest followed by database.
+	var manifest BundleManifest
+	if err := readArchiveEntry(tarReader, bundleManifestName, func(r io.Reader) error {
+		return json.NewDecoder(r).Decode(&manifest)
+	}); err != nil {
+		return nil, err
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, eris.Wrap(err, "error validating bundle manifest")
+	}
+
+	dir, err := os.MkdirTemp("", "fml-import-*")
+	if err != nil {
+		return nil, eris.Wrap(err, "error creating temporary directory")
+	}
+	//nolint:errcheck
+	defer os.RemoveAll(dir)
+
+	databasePath := filepath.Join(dir, bundleDatabaseName)
+	if err := readArchiveEntry(tarReader, manifest.Database, func(r io.Reader) error {
+		file, err := os.Create(databasePath)
+		if err != nil {
+			return eris.Wrap(err, "error creating bundle database file")
+		}
+		//nolint:errcheck
+		defer file.Close()
+		//nolint:gosec
+		if _, err := io.Copy(file, r); err != nil {
+			return eris.Wrap(err, "error extracting bundle database")
+		}
+		return file.Close()
+	}); err != nil {
+		return nil, err
+	}
+
+	bundleDB, err := openBundleDatabase(databasePath)
+	if err != nil {
+		return nil, err
+	}
+	//nolint:errcheck
+	defer bundleDB.Close()
+
+	namespace, created, err := i.getOrCreateNamespace(ctx, &manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	importer := NewImporter(
+		bundleDB.GormDB().WithContext(ctx),
+		i.db.WithContext(ctx),
+		WithSourceNamespace(manifest.Namespace.Code),
+		WithDestinationNamespace(namespace.Code),
+	)
+	if err := importer.Import(); err != nil {
+		return nil, eris.Wrap(err, "error importing bundle database")
+	}
+	// newly created namespace has to get default experiment from the bundle.
+	if created {
+		var bundleNamespace Namespace
+		if err := bundleDB.GormDB().First(&bundleNamespace).Error; err != nil {
+			return nil, eris.Wrap(err, "error getting bundle namespace")
+		}
+		if err := updateDefaultExperiment(
+			i.db.WithContext(ctx), importer, namespace, bundleNamespace.DefaultExperimentID,
+		); err != nil {
+			return nil, eris.Wrap(err, "error updating namespace")
+		}
+	}
+
+	if i.artifactStorageFactory == nil || len(manifest.Artifacts) == 0 {
+		return &manifest, nil
+	}
+
+	artifactURIs, err := relocateArtifacts(ctx, i.db, bundleDB.GormDB(), importer, i.artifactRoot)
+	if err != nil {
+		return nil, eris.Wrap(err, "error relocating artifacts")
+	}
+	if err := i.importArtifacts(ctx, tarReader, artifactURIs, importer.existingRunIDs); err != nil {
+		return nil, eris.Wrap(err, "error importing artifacts")
+	}
+	return &manifest, nil
+}
+
+// getOrCreateNamespace returns destination namespace and creates it, if it doesn't exist yet.
+func (i *BundleImporter) getOrCreateNamespace(
+	ctx context.Context, manifest *BundleManifest,
+) (*Namespace, bool, error) {
+	code := i.namespaceCode
+	if code == "" {
+		code = manifest.Namespace.Code
+	}
+	return getOrCreateNamespace(ctx, i.db, code, manifest.Namespace.Description)
+}
+
+// relocateArtifacts points imported experiments and runs to the artifact root of current installation.
+// Experiments and runs, which already existed, keep their location. It returns new artifact URIs
+// of the runs, which have been inserted by the import.
+func relocateArtifacts(
+	ctx context.Context, db, sourceDB *gorm.DB, importer *Importer, artifactRoot string,
+) (map[string]string, error) {
+	var runs []Run
+	if err := sourceDB.WithContext(ctx).Select("run_uuid", "experiment_id").Find(&runs).Error; err != nil {
+		return nil, eris.Wrap(err, "error getting source runs")
+	}
+
+	experiments := make(map[int32]experimentInfo, len(importer.experimentInfos))
+	for _, info := range importer.experimentInfos {
+		experiments[info.sourceID] = info
+		if !info.created {
+			continue
+		}
+		location, err := url.JoinPath(artifactRoot, fmt.Sprintf("%d", info.destID))
+		if err != nil {
+			return nil, eris.Wrap(err, "error creating experiment artifact location")
+		}
+		if err := db.WithContext(ctx).Model(&Experiment{}).Where(
+			"experiment_id = ?", info.destID,
+		).Update(
+			"artifact_location", location,
+		).Error; err != nil {
+			return nil, eris.Wrap(err, "error updating experiment artifact location")
+		}
+	}
+
+	artifactURIs := make(map[string]string, len(runs))
+	for _, run := range runs {
+		// artifacts of already existing runs are neither moved nor overwritten.
+		if _, ok := importer.existingRunIDs[run.ID]; ok {
+			continue
+		}
+		info, ok := experiments[run.ExperimentID]
+		if !ok {
+			return nil, eris.Errorf("experiment %d of run %s has not been imported", run.ExperimentID, run.ID)
+		}
+		artifactURI, err := url.JoinPath(artifactRoot, fmt.Sprintf("%d", info.destID), run.ID, "artifacts")
+		if err != nil {
+			return nil, eris.Wrap(err, "error creating run artifact uri")
+		}
+		// `artifact_uri` is create-only field of the Run model, so table is updated directly.
+		if err := db.WithContext(ctx).Table("runs").Where(
+			"run_uuid = ?", run.ID,
+		).Update(
+			"artifact_uri", artifactURI,
+		).Error; err != nil {
+			return nil, eris.Wrap(err, "error updating run artifact uri")
+		}
+		artifactURIs[run.ID] = artifactURI
+	}
+	return artifactURIs, nil
+}
+
+// importArtifacts uploads the rest of archive entries as run artifacts. Entries of the runs,
+// which already existed, are skipped.
+func (i *BundleImporter) importArtifacts(
+	ctx context.Context, tarReader *tar.Reader, artifactURIs map[string]string, existingRunIDs map[string]struct{},
+) error {
+	count := 0
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return eris.Wrap(err, "error reading bundle")
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		runID, artifactPath, err := parseArtifactEntryName(header.Name)
+		if err != nil {
+			return err
+		}
+		if _, ok := existingRunIDs[runID]; ok {
+			continue
+		}
+		artifactURI, ok := artifactURIs[runID]
+		if !ok {
+			return eris.Errorf("artifact %s belongs to unknown run %s", artifactPath, runID)
+		}
+		artifactStorage, err := i.artifactStorageFactory.GetStorage(ctx, artifactURI)
+		if err != nil {
+			return eris.Wrap(err, "error getting artifact storage")
+		}
+		if err := artifactStorage.Put(ctx, artifactURI, artifactPath, tarReader); err != nil {
+			return eris.Wrapf(err, "error putting artifact %s of run %s", artifactPath, runID)
+		}
+		count++
+	}
+	log.Infof("Importing artifacts - found %d records", count)
+	return nil
+}
+
+// parseArtifactEntryName splits archive entry name into run ID and artifact path.
+func parseArtifactEntryName(name string) (string, string, error) {
+	parts := strings.SplitN(name, "/", 3)
+	if len(parts) != 3 || parts[0] != bundleArtifactsDir || parts[1] == "" || parts[2] == "" {
+		return "", "", eris.Errorf("unexpected bundle entry: %s", name)
+	}
+	if path.IsAbs(parts[2]) || path.Clean(parts[2]) != parts[2] || strings.HasPrefix(parts[2], "..") {
+		return "", "", eris.Errorf("unsafe artifact path in bundle entry: %s", name)
+	}
+	return parts[1], parts[2], nil
+}
+
+// getOrCreateNamespace returns namespace by code and creates it, if it doesn't exist yet.
+// The second returned value shows that namespace has been created.
+func getOrCreateNamespace(ctx context.Context, db *gorm.DB, code, description string) (*Namespace, bool, error) {
+	var namespace Namespace
+	if err := db.WithContext(ctx).Where("code = ?", code).First(&namespace).Error; err == nil {
+		return &namespace, false, nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, eris.Wrapf(err, "error getting namespace %s", code)
+	}
+
+	// default experiment will be assigned as soon as experiments are imported.
+	namespace = Namespace{
+		Code:                code,
+		Description:         description,
+		DefaultExperimentID: common.GetPointer(DefaultExperimentID),
+	}
+	if err := db.WithContext(ctx).Create(&namespace).Error; err != nil {
+		return nil, false, eris.Wrapf(err, "error creating namespace %s", code)
+	}
+	log.Infof("Created namespace %s", code)
+	return &namespace, true, nil
+}
+
+// openBundleDatabase opens and migrates bundle database.
+func openBundleDatabase(databasePath string) (DBProvider, error) {
+	db, err := NewDBProvider(
+		fmt.Sprintf("sqlite://%s?_journal=DELETE", databasePath),
+		time.Second*1,
+		1,
+	)
+	if err != nil {
+		return nil, eris.Wrap(err, "error opening bundle database")
+	}
+	if err := CheckAndMigrateDB(true, db.GormDB()); err != nil {
+		//nolint:errcheck,gosec
+		db.Close()
+		return nil, eris.Wrap(err, "error migrating bundle database")
+	}
+	return db, nil
+}
+
+// writeArchiveEntry writes single file into archive.
+func writeArchiveEntry(tarWriter *tar.Writer, name string, size int64, reader io.Reader) error {
+	if err := tarWriter.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     size,
+		Mode:     0o644,
+		ModTime:  time.Now(),
+	}); err != nil {
+		return eris.Wrapf(err, "error writing %s header", name)
+	}
+	if _, err := io.CopyN(tarWriter, reader, size); err != nil {
+		return eris.Wrapf(err, "error writing %s", name)
+	}
+	return nil
+}
+
+// readArchiveEntry reads next archive entry, which is expected to have provided name.
+func readArchiveEntry(tarReader *tar.Reader, name string, read func(r io.Reader) error) error {
+	header, err := tarReader.Next()
+	if err != nil {
+		return eris.Wrapf(err, "error reading %s from bundle", name)
+	}
+	if header.Name != name {
+		return eris.Errorf("unexpected bundle entry %s, expected %s", header.Name, name)
+	}
+	return read(tarReader)
+}
+
+// updateDefaultExperiment points namespace default experiment to the imported one.
+func updateDefaultExperiment(db *gorm.DB, importer *Importer, namespace *Namespace, sourceID *int32) error {
+	if sourceID == nil {
+		return nil
+	}
+	for _, info := range importer.experimentInfos {
+		if info.sourceID == *sourceID {
+			if err := db.Model(&Namespace{}).Where(
+				"id = ?", namespace.ID,
+			).Update(
+				"default_experiment_id", info.destID,
+			).Error; err != nil {
+				return eris.Wrap(err, "error updating namespace default experiment")
+			}
+			namespace.DefaultExperimentID = common.GetPointer(info.destID)
+			return nil
+		}
+	}
+	return nil
+}
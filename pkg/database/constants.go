@@ -5,10 +5,12 @@ const (
 	SQLiteSchemaName     = "sqlite"
 	PostgresSchemaName   = "postgres"
 	PostgresQLSchemaName = "postgresql"
+	MySQLSchemaName      = "mysql"
 )
 
 // list of supported dialects.
 const (
 	SQLiteDialectorName   = "sqlite"
 	PostgresDialectorName = "postgres"
+	MySQLDialectorName    = "mysql"
 )
@@ -34,6 +34,15 @@ func NewDBProvider(
 		if err != nil {
 			return nil, eris.Wrap(err, "error creating postgres provider")
 		}
+	case MySQLSchemaName:
+		db, err = NewMySQLDBInstance(
+			*dsnURL,
+			slowThreshold,
+			poolMax,
+		)
+		if err != nil {
+			return nil, eris.Wrap(err, "error creating mysql provider")
+		}
 	default:
 		return nil, eris.New("unsupported database type")
 	}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"database/sql"
 	"io"
 
 	"gorm.io/gorm"
@@ -12,6 +13,7 @@ type DBProvider interface {
 	Dsn() string
 	Close() error
 	Reset() error
+	Pools() map[string]*sql.DB
 }
 
 // DB is a global gorm.DB reference
@@ -22,6 +24,7 @@ type DBInstance struct {
 	*gorm.DB
 	dsn     string
 	closers []io.Closer
+	pools   map[string]*sql.DB
 }
 
 // Close invokes the closers.
@@ -40,6 +43,11 @@ func (db *DBInstance) Dsn() string {
 	return db.dsn
 }
 
+// Pools returns underlying connection pools by their names.
+func (db *DBInstance) Pools() map[string]*sql.DB {
+	return db.pools
+}
+
 // GormDB returns the gorm DB.
 func (db *DBInstance) GormDB() *gorm.DB {
 	return db.DB
@@ -0,0 +1,665 @@
+package database
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+)
+
+const (
+	// fileStoreMetaFile is a name of the file, which describes experiment or run in MLflow file store.
+	fileStoreMetaFile = "meta.yaml"
+	// fileStoreTrashDir is a directory, which contains deleted experiments of MLflow file store.
+	fileStoreTrashDir = ".trash"
+	// fileStoreBatchSize is a number of metrics inserted at once.
+	fileStoreBatchSize = 1000
+)
+
+// fileStoreRunStatuses maps MLflow RunStatus enum values to run statuses.
+var fileStoreRunStatuses = map[int]Status{
+	1: StatusRunning,
+	2: StatusScheduled,
+	3: StatusFinished,
+	4: StatusFailed,
+	5: StatusKilled,
+}
+
+// fileStoreSourceTypes maps MLflow SourceType enum values to run source types.
+var fileStoreSourceTypes = map[int]string{
+	1:    "NOTEBOOK",
+	2:    "JOB",
+	3:    "PROJECT",
+	4:    "LOCAL",
+	1000: "UNKNOWN",
+}
+
+// fileStoreExperiment represents experiment `meta.yaml` of MLflow file store.
+type fileStoreExperiment struct {
+	ID               string `yaml:"experiment_id"`
+	Name             string `yaml:"name"`
+	ArtifactLocation string `yaml:"artifact_location"`
+	LifecycleStage   string `yaml:"lifecycle_stage"`
+	CreationTime     *int64 `yaml:"creation_time"`
+	LastUpdateTime   *int64 `yaml:"last_update_time"`
+}
+
+// fileStoreRun represents run `meta.yaml` of MLflow file store.
+type fileStoreRun struct {
+	ID             string `yaml:"run_uuid"`
+	Name           string `yaml:"run_name"`
+	ArtifactURI    string `yaml:"artifact_uri"`
+	EntryPointName string `yaml:"entry_point_name"`
+	LifecycleStage string `yaml:"lifecycle_stage"`
+	SourceName     string `yaml:"source_name"`
+	SourceType     any    `yaml:"source_type"`
+	SourceVersion  string `yaml:"source_version"`
+	Status         any    `yaml:"status"`
+	UserID         string `yaml:"user_id"`
+	StartTime      *int64 `yaml:"start_time"`
+	EndTime        *int64 `yaml:"end_time"`
+	DeletedTime    *int64 `yaml:"deleted_time"`
+}
+
+// FileStoreSummary contains the number of experiments and runs found in MLflow file store.
+type FileStoreSummary struct {
+	Experiments int
+	Runs        int
+}
+
+// FileStoreImporter imports experiments and runs from MLflow file store (`mlruns` directory).
+// File store content is loaded into a standalone SQLite database first, which is then copied into
+// destination namespace by Importer.
+type FileStoreImporter struct {
+	db                     *gorm.DB
+	root                   string
+	namespaceCode          string
+	artifactRoot           string
+	artifactStorageFactory storage.ArtifactStorageFactoryProvider
+}
+
+// NewFileStoreImporter initializes a FileStoreImporter.
+func NewFileStoreImporter(db *gorm.DB, root string, options ...func(importer *FileStoreImporter)) *FileStoreImporter {
+	importer := FileStoreImporter{
+		db:            db,
+		root:          root,
+		namespaceCode: models.DefaultNamespaceCode,
+	}
+	for _, o := range options {
+		o(&importer)
+	}
+	return &importer
+}
+
+// WithFileStoreNamespace sets destination Namespace.
+func WithFileStoreNamespace(namespace string) func(importer *FileStoreImporter) {
+	return func(i *FileStoreImporter) {
+		if namespace != "" {
+			i.namespaceCode = namespace
+		}
+	}
+}
+
+// WithFileStoreArtifacts enables copying of run artifacts into the provided artifact root.
+func WithFileStoreArtifacts(
+	factory storage.ArtifactStorageFactoryProvider, artifactRoot string,
+) func(importer *FileStoreImporter) {
+	return func(i *FileStoreImporter) {
+		i.artifactStorageFactory = factory
+		i.artifactRoot = artifactRoot
+	}
+}
+
+// Import reads MLflow file store and imports its content.
+func (i *FileStoreImporter) Import(ctx context.Context) (*FileStoreSummary, error) {
+	var (
+		summary      *FileStoreSummary
+		artifactURIs map[string]string
+	)
+	if err := importStaging(ctx, i.db, i.namespaceCode, func(db *gorm.DB, namespaceID uint) error {
+		var err error
+		summary, artifactURIs, err = i.load(ctx, db, namespaceID)
+		return err
+	}, func(stagingDB *gorm.DB, importer *Importer) error {
+		if i.artifactStorageFactory == nil {
+			return nil
+		}
+		destinationURIs, err := relocateArtifacts(ctx, i.db, stagingDB, importer, i.artifactRoot)
+		if err != nil {
+			return eris.Wrap(err, "error relocating artifacts")
+		}
+		for runID, sourceURI := range artifactURIs {
+			destinationURI, ok := destinationURIs[runID]
+			if !ok {
+				continue
+			}
+			if err := i.copyArtifacts(ctx, runID, sourceURI, destinationURI); err != nil {
+				return eris.Wrapf(err, "error copying artifacts of run %s", runID)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// load reads experiments and runs of the file store into staging database. It returns artifact URIs
+// of the runs, where artifacts can be read from.
+func (i *FileStoreImporter) load(
+	ctx context.Context, db *gorm.DB, namespaceID uint,
+) (*FileStoreSummary, map[string]string, error) {
+	experimentDirs, err := findFileStoreEntries(i.root)
+	if err != nil {
+		return nil, nil, eris.Wrapf(err, "error reading file store %s", i.root)
+	}
+	trashDirs, err := findFileStoreEntries(filepath.Join(i.root, fileStoreTrashDir))
+	if err != nil {
+		return nil, nil, eris.Wrapf(err, "error reading file store %s", i.root)
+	}
+	experimentDirs = append(experimentDirs, trashDirs...)
+	if len(experimentDirs) == 0 {
+		return nil, nil, eris.Errorf("no experiments found in file store %s", i.root)
+	}
+
+	summary := FileStoreSummary{}
+	artifactURIs := map[string]string{}
+	nextID := int32(1)
+	for _, experimentDir := range experimentDirs {
+		var meta fileStoreExperiment
+		if err := readFileStoreMeta(experimentDir, &meta); err != nil {
+			return nil, nil, err
+		}
+		// experiment identifiers of the file store don't have to fit int32, so new ones are assigned,
+		// except the default experiment, which keeps its identifier.
+		id := nextID
+		if meta.ID == fmt.Sprintf("%d", DefaultExperimentID) {
+			id = DefaultExperimentID
+		} else {
+			nextID++
+		}
+		experiment := Experiment{
+			ID:               common.GetPointer(id),
+			Name:             meta.Name,
+			ArtifactLocation: meta.ArtifactLocation,
+			LifecycleStage:   LifecycleStage(meta.LifecycleStage),
+			CreationTime:     newNullInt64(meta.CreationTime),
+			LastUpdateTime:   newNullInt64(meta.LastUpdateTime),
+			NamespaceID:      namespaceID,
+		}
+		if experiment.LifecycleStage == "" {
+			experiment.LifecycleStage = LifecycleStageActive
+		}
+		if err := db.Create(&experiment).Error; err != nil {
+			return nil, nil, eris.Wrapf(err, "error creating experiment %s", meta.Name)
+		}
+
+		tags, err := readFileStoreValues(filepath.Join(experimentDir, "tags"))
+		if err != nil {
+			return nil, nil, eris.Wrapf(err, "error reading tags of experiment %s", meta.Name)
+		}
+		for key, value := range tags {
+			if err := db.Create(&ExperimentTag{Key: key, Value: value, ExperimentID: id}).Error; err != nil {
+				return nil, nil, eris.Wrapf(err, "error creating tag of experiment %s", meta.Name)
+			}
+		}
+
+		runDirs, err := findFileStoreEntries(experimentDir)
+		if err != nil {
+			return nil, nil, eris.Wrapf(err, "error reading runs of experiment %s", meta.Name)
+		}
+		for _, runDir := range runDirs {
+			run, err := i.loadRun(ctx, db, runDir, id)
+			if err != nil {
+				return nil, nil, eris.Wrapf(err, "error loading run %s", runDir)
+			}
+			artifactURIs[run.ID] = fileStoreArtifactURI(run.ArtifactURI, filepath.Join(runDir, "artifacts"))
+			summary.Runs++
+		}
+		summary.Experiments++
+		log.Infof("Loaded experiment %s with %d runs", meta.Name, len(runDirs))
+	}
+	return &summary, artifactURIs, nil
+}
+
+// loadRun reads single run with its params, tags and metrics into staging database.
+func (i *FileStoreImporter) loadRun(ctx context.Context, db *gorm.DB, runDir string, experimentID int32) (*Run, error) {
+	var meta fileStoreRun
+	if err := readFileStoreMeta(runDir, &meta); err != nil {
+		return nil, err
+	}
+	if meta.ID == "" {
+		meta.ID = filepath.Base(runDir)
+	}
+
+	tags, err := readFileStoreValues(filepath.Join(runDir, "tags"))
+	if err != nil {
+		return nil, eris.Wrap(err, "error reading tags")
+	}
+	params, err := readFileStoreValues(filepath.Join(runDir, "params"))
+	if err != nil {
+		return nil, eris.Wrap(err, "error reading params")
+	}
+
+	run := Run{
+		ID:             meta.ID,
+		Name:           meta.Name,
+		SourceType:     "UNKNOWN",
+		SourceName:     meta.SourceName,
+		EntryPointName: meta.EntryPointName,
+		UserID:         meta.UserID,
+		Status:         StatusRunning,
+		StartTime:      newNullInt64(meta.StartTime),
+		EndTime:        newNullInt64(meta.EndTime),
+		SourceVersion:  meta.SourceVersion,
+		LifecycleStage: LifecycleStage(meta.LifecycleStage),
+		ArtifactURI:    meta.ArtifactURI,
+		ExperimentID:   experimentID,
+		DeletedTime:    newNullInt64(meta.DeletedTime),
+	}
+	// runs created by older MLflow versions keep their names only in tags.
+	if run.Name == "" {
+		run.Name = tags["mlflow.runName"]
+	}
+	if run.LifecycleStage == "" {
+		run.LifecycleStage = LifecycleStageActive
+	}
+	if sourceType, ok := fileStoreEnumValue(meta.SourceType, fileStoreSourceTypes); ok {
+		run.SourceType = sourceType
+	}
+	if status, ok := fileStoreEnumValue(meta.Status, fileStoreRunStatuses); ok {
+		run.Status = status
+	}
+	if err := db.Create(&run).Error; err != nil {
+		return nil, eris.Wrap(err, "error creating run")
+	}
+
+	for key, value := range tags {
+		if err := db.Create(&Tag{Key: key, Value: value, RunID: run.ID}).Error; err != nil {
+			return nil, eris.Wrapf(err, "error creating tag %s", key)
+		}
+	}
+	for key, value := range params {
+		if err := db.Create(&Param{Key: key, ValueStr: common.GetPointer(value), RunID: run.ID}).Error; err != nil {
+			return nil, eris.Wrapf(err, "error creating param %s", key)
+		}
+	}
+
+	metricsDir := filepath.Join(runDir, "metrics")
+	metricFiles, err := listFileStoreValues(metricsDir)
+	if err != nil {
+		return nil, eris.Wrap(err, "error reading metrics")
+	}
+	if len(metricFiles) > 0 {
+		defaultContext := Context{Json: models.DefaultContext.Json}
+		if err := db.FirstOrCreate(&defaultContext, defaultContext).Error; err != nil {
+			return nil, eris.Wrap(err, "error creating default context")
+		}
+		for _, key := range metricFiles {
+			if err := loadFileStoreMetric(
+				ctx, db, filepath.Join(metricsDir, filepath.FromSlash(key)), key, run.ID, defaultContext.ID,
+			); err != nil {
+				return nil, eris.Wrapf(err, "error loading metric %s", key)
+			}
+		}
+	}
+	return &run, nil
+}
+
+// copyArtifacts copies run artifacts from the source to the destination artifact URI.
+func (i *FileStoreImporter) copyArtifacts(ctx context.Context, runID, sourceURI, destinationURI string) error {
+	artifacts, err := listArtifacts(ctx, i.artifactStorageFactory, Run{ID: runID, ArtifactURI: sourceURI}, "")
+	if err != nil {
+		return err
+	}
+	if len(artifacts) == 0 {
+		return nil
+	}
+	sourceStorage, err := i.artifactStorageFactory.GetStorage(ctx, sourceURI)
+	if err != nil {
+		return eris.Wrap(err, "error getting source artifact storage")
+	}
+	destinationStorage, err := i.artifactStorageFactory.GetStorage(ctx, destinationURI)
+	if err != nil {
+		return eris.Wrap(err, "error getting destination artifact storage")
+	}
+	for _, artifact := range artifacts {
+		reader, err := sourceStorage.Get(ctx, sourceURI, artifact.Path)
+		if err != nil {
+			return eris.Wrapf(err, "error getting artifact %s", artifact.Path)
+		}
+		err = destinationStorage.Put(ctx, destinationURI, artifact.Path, reader)
+		//nolint:errcheck,gosec
+		reader.Close()
+		if err != nil {
+			return eris.Wrapf(err, "error putting artifact %s", artifact.Path)
+		}
+	}
+	log.Debugf("Copied %d artifacts of run %s", len(artifacts), runID)
+	return nil
+}
+
+// loadFileStoreMetric reads metric history file, where every line is `<timestamp> <value> [<step>]`.
+func loadFileStoreMetric(ctx context.Context, db *gorm.DB, path, key, runID string, contextID uint) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return eris.Wrap(err, "error opening metric file")
+	}
+	//nolint:errcheck
+	defer file.Close()
+
+	var latestMetric *LatestMetric
+	var summary LatestMetric
+	metrics := make([]Metric, 0, fileStoreBatchSize)
+	flush := func() error {
+		if len(metrics) == 0 {
+			return nil
+		}
+		if err := db.WithContext(ctx).Clauses(
+			clause.OnConflict{DoNothing: true},
+		).Create(&metrics).Error; err != nil {
+			return eris.Wrap(err, "error creating metrics")
+		}
+		metrics = metrics[:0]
+		return nil
+	}
+
+	iter := int64(0)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		metric, err := parseFileStoreMetric(line)
+		if err != nil {
+			return err
+		}
+		iter++
+		metric.Key, metric.RunID, metric.ContextID, metric.Iter = key, runID, contextID, iter
+		metrics = append(metrics, metric)
+		if !metric.IsNan {
+			if summary.MinValue == nil || metric.Value < *summary.MinValue {
+				summary.MinValue = &metric.Value
+			}
+			if summary.MaxValue == nil || metric.Value > *summary.MaxValue {
+				summary.MaxValue = &metric.Value
+			}
+			summary.SumValue += metric.Value
+			summary.ValueCount++
+		}
+		if latestMetric == nil ||
+			metric.Step > latestMetric.Step ||
+			(metric.Step == latestMetric.Step && metric.Timestamp > latestMetric.Timestamp) ||
+			(metric.Step == latestMetric.Step && metric.Timestamp == latestMetric.Timestamp &&
+				metric.Value > latestMetric.Value) {
+			latestMetric = &LatestMetric{
+				Key:       metric.Key,
+				Value:     metric.Value,
+				Timestamp: metric.Timestamp,
+				Step:      metric.Step,
+				IsNan:     metric.IsNan,
+				RunID:     metric.RunID,
+				ContextID: metric.ContextID,
+			}
+		}
+		if len(metrics) == fileStoreBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return eris.Wrap(err, "error reading metric file")
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	if latestMetric != nil {
+		latestMetric.LastIter = iter
+		latestMetric.MinValue, latestMetric.MaxValue = summary.MinValue, summary.MaxValue
+		latestMetric.SumValue, latestMetric.ValueCount = summary.SumValue, summary.ValueCount
+		if err := db.WithContext(ctx).Create(latestMetric).Error; err != nil {
+			return eris.Wrap(err, "error creating latest metric")
+		}
+	}
+	return nil
+}
+
+// parseFileStoreMetric parses single line of metric history file.
+func parseFileStoreMetric(line string) (Metric, error) {
+	fields := strings.Fields(line)
+	if len(fields) < 2 || len(fields) > 3 {
+		return Metric{}, eris.Errorf("unexpected metric line: %q", line)
+	}
+	timestamp, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return Metric{}, eris.Wrapf(err, "error parsing metric timestamp: %q", line)
+	}
+	value, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return Metric{}, eris.Wrapf(err, "error parsing metric value: %q", line)
+	}
+	metric := Metric{Timestamp: timestamp, Value: value}
+	switch {
+	case math.IsNaN(value):
+		metric.Value, metric.IsNan = 0, true
+	case math.IsInf(value, 1):
+		metric.Value = math.MaxFloat64
+	case math.IsInf(value, -1):
+		metric.Value = -math.MaxFloat64
+	}
+	if len(fields) == 3 {
+		if metric.Step, err = strconv.ParseInt(fields[2], 10, 64); err != nil {
+			return Metric{}, eris.Wrapf(err, "error parsing metric step: %q", line)
+		}
+	}
+	return metric, nil
+}
+
+// findFileStoreEntries returns sorted subdirectories of dir, which contain `meta.yaml`.
+func findFileStoreEntries(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var dirs []string
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		if _, err := os.Stat(filepath.Join(path, fileStoreMetaFile)); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		dirs = append(dirs, path)
+	}
+	sort.Strings(dirs)
+	return dirs, nil
+}
+
+// readFileStoreMeta parses `meta.yaml` of the directory.
+func readFileStoreMeta(dir string, meta any) error {
+	data, err := os.ReadFile(filepath.Join(dir, fileStoreMetaFile))
+	if err != nil {
+		return eris.Wrapf(err, "error reading %s", dir)
+	}
+	if err := yaml.Unmarshal(data, meta); err != nil {
+		return eris.Wrapf(err, "error parsing %s", filepath.Join(dir, fileStoreMetaFile))
+	}
+	return nil
+}
+
+// listFileStoreValues recursively lists files of the directory. Keys containing `/` are stored
+// by MLflow in nested directories, so relative slash-separated paths are returned.
+func listFileStoreValues(dir string) ([]string, error) {
+	var keys []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		key, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, filepath.ToSlash(key))
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// readFileStoreValues reads key/value pairs (tags or params), where every file contains a single value.
+func readFileStoreValues(dir string) (map[string]string, error) {
+	keys, err := listFileStoreValues(dir)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(keys))
+	for _, key := range keys {
+		file, err := os.Open(filepath.Join(dir, filepath.FromSlash(key)))
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(file)
+		//nolint:errcheck,gosec
+		file.Close()
+		if err != nil {
+			return nil, err
+		}
+		values[key] = string(data)
+	}
+	return values, nil
+}
+
+// fileStoreEnumValue converts enum stored as a number or as a name.
+func fileStoreEnumValue[T ~string](value any, values map[int]T) (T, bool) {
+	switch v := value.(type) {
+	case int:
+		result, ok := values[v]
+		return result, ok
+	case string:
+		for _, result := range values {
+			if string(result) == v {
+				return result, true
+			}
+		}
+	}
+	return "", false
+}
+
+// fileStoreArtifactURI returns location of run artifacts. Local artifact URI points to the location
+// where file store has been created, so when it doesn't exist anymore, run directory is used instead.
+func fileStoreArtifactURI(artifactURI, runArtifactsDir string) string {
+	u, err := url.Parse(artifactURI)
+	if err != nil || (u.Scheme != "" && u.Scheme != "file") {
+		return artifactURI
+	}
+	if _, err := os.Stat(u.Path); err == nil {
+		return u.Path
+	}
+	return runArtifactsDir
+}
+
+// importStaging loads the source by load into a standalone SQLite database and copies it into
+// destination namespace by Importer. Staging database is still open when imported is called,
+// so the data, which isn't kept in the database, like artifacts, can be copied there.
+func importStaging(
+	ctx context.Context,
+	db *gorm.DB,
+	namespaceCode string,
+	load func(db *gorm.DB, namespaceID uint) error,
+	imported func(stagingDB *gorm.DB, importer *Importer) error,
+) error {
+	dir, err := os.MkdirTemp("", "fml-staging-*")
+	if err != nil {
+		return eris.Wrap(err, "error creating temporary directory")
+	}
+	//nolint:errcheck
+	defer os.RemoveAll(dir)
+
+	stagingDB, err := openBundleDatabase(filepath.Join(dir, bundleDatabaseName))
+	if err != nil {
+		return err
+	}
+	//nolint:errcheck
+	defer stagingDB.Close()
+
+	stagingNamespace := Namespace{
+		ID:                  1,
+		Code:                namespaceCode,
+		DefaultExperimentID: common.GetPointer(DefaultExperimentID),
+	}
+	if err := stagingDB.GormDB().Create(&stagingNamespace).Error; err != nil {
+		return eris.Wrap(err, "error creating staging namespace")
+	}
+
+	if err := load(stagingDB.GormDB().WithContext(ctx), stagingNamespace.ID); err != nil {
+		return err
+	}
+
+	namespace, created, err := getOrCreateNamespace(ctx, db, namespaceCode, "")
+	if err != nil {
+		return err
+	}
+	importer := NewImporter(
+		stagingDB.GormDB().WithContext(ctx),
+		db.WithContext(ctx),
+		WithSourceNamespace(stagingNamespace.Code),
+		WithDestinationNamespace(namespace.Code),
+	)
+	if err := importer.Import(); err != nil {
+		return eris.Wrap(err, "error importing staging database")
+	}
+	if created {
+		if err := updateDefaultExperiment(
+			db.WithContext(ctx), importer, namespace, stagingNamespace.DefaultExperimentID,
+		); err != nil {
+			return eris.Wrap(err, "error updating namespace")
+		}
+	}
+	if imported == nil {
+		return nil
+	}
+	return imported(stagingDB.GormDB(), importer)
+}
+
+// newNullInt64 converts optional value into sql.NullInt64.
+func newNullInt64(value *int64) sql.NullInt64 {
+	if value == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: *value, Valid: true}
+}
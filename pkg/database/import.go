@@ -3,6 +3,7 @@ package database
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"reflect"
 	"regexp"
 	"slices"
@@ -19,15 +20,33 @@ import (
 type experimentInfo struct {
 	destID   int32
 	sourceID int32
+	created  bool
 }
 
 var uuidRegexp = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
 
+// uniqueKey describes unique columns of the table with uuid primary key. Rows, which already exist
+// in destination under another id, are reused and the columns referencing them are updated.
+type uniqueKey struct {
+	reference string
+	columns   []string
+}
+
+var uniqueKeys = map[string]uniqueKey{
+	"datasets":          {reference: "dataset_id", columns: []string{"name", "digest", "experiment_id"}},
+	"inputs":            {reference: "input_id", columns: []string{"dataset_id", "run_uuid"}},
+	"registered_models": {reference: "registered_model_id", columns: []string{"name", "namespace_id"}},
+	"model_versions":    {reference: "model_version_id", columns: []string{"registered_model_id", "version"}},
+}
+
 // Importer will handle the transport of data from source to destination db.
 type Importer struct {
 	sourceDB                 *gorm.DB
 	destinationDB            *gorm.DB
 	experimentInfos          []experimentInfo
+	contextInfos             map[uint]uint
+	idInfos                  map[string]map[uuid.UUID]uuid.UUID
+	existingRunIDs           map[string]struct{}
 	sourceNamespace          *Namespace
 	destinationNamespace     *Namespace
 	sourceNamespaceName      *string
@@ -40,6 +59,9 @@ func NewImporter(input, output *gorm.DB, options ...func(importer *Importer)) *I
 		destinationDB:   output,
 		sourceDB:        input,
 		experimentInfos: []experimentInfo{},
+		contextInfos:    map[uint]uint{},
+		idInfos:         map[string]map[uuid.UUID]uuid.UUID{},
+		existingRunIDs:  map[string]struct{}{},
 	}
 	for _, o := range options {
 		o(&importer)
@@ -89,8 +111,18 @@ func (s *Importer) Import() error {
 		"contexts",
 		"metrics",
 		"latest_metrics",
+		"logs",
+		"artifacts",
+		"datasets",
+		"inputs",
+		"input_tags",
 		"shared_tags",
 		"run_shared_tags",
+		"registered_models",
+		"registered_model_tags",
+		"registered_model_aliases",
+		"model_versions",
+		"model_version_tags",
 	}
 	for _, table := range tables {
 		if err := s.importTable(table); err != nil {
@@ -141,18 +173,19 @@ func (s *Importer) importExperiments() error {
 			if s.destinationNamespace != nil {
 				newItem.NamespaceID = s.destinationNamespace.ID
 			}
-			// keep default experiment ID, but otherwise draw new one
-			if *scannedItem.ID == int32(0) {
+			// keep default experiment ID, unless experiment goes to another namespace, otherwise draw new one
+			if *scannedItem.ID == int32(0) && s.destinationNamespace == nil {
 				newItem.ID = scannedItem.ID
 			}
-			if err := destTX.Where(
-				Experiment{Name: scannedItem.Name},
+			result := destTX.Where(
+				Experiment{Name: scannedItem.Name, NamespaceID: newItem.NamespaceID},
 			).FirstOrCreate(
 				&newItem,
-			).Error; err != nil {
+			)
+			if err := result.Error; err != nil {
 				return eris.Wrap(err, "error creating destination row")
 			}
-			s.saveExperimentInfo(scannedItem, newItem)
+			s.saveExperimentInfo(scannedItem, newItem, result.RowsAffected > 0)
 			count++
 		}
 		log.Infof("Importing experiments - found %d records", count)
@@ -164,6 +197,65 @@ func (s *Importer) importExperiments() error {
 	return nil
 }
 
+// importContexts copies the contents of the contexts table from sourceDB to destinationDB,
+// while reusing already existing contexts and recording the new ID.
+func (s *Importer) importContexts() error {
+	// Start transaction in the destDB
+	err := s.destinationDB.Transaction(func(destTX *gorm.DB) error {
+		// Query data from the source database
+		rows, err := EntityLimitedByNamespace(
+			"contexts",
+			s.sourceDB.Model(Context{}).Distinct("contexts.*"),
+			s.sourceNamespace,
+		).Rows()
+		if err != nil {
+			return eris.Wrap(err, "error creating Rows instance from source")
+		}
+		if err := rows.Err(); err != nil {
+			return eris.Wrap(err, "error getting query result")
+		}
+		//nolint:errcheck
+		defer rows.Close()
+
+		count := 0
+		for rows.Next() {
+			var scannedItem Context
+			if err := s.sourceDB.ScanRows(rows, &scannedItem); err != nil {
+				return eris.Wrap(err, "error creating Rows instance from source")
+			}
+			newItem := Context{
+				Json: scannedItem.Json,
+			}
+			if err := destTX.Clauses(
+				clause.OnConflict{
+					Columns:   []clause.Column{{Name: "json"}},
+					UpdateAll: true,
+				},
+			).Create(
+				&newItem,
+			).Error; err != nil {
+				return eris.Wrap(err, "error creating destination row")
+			}
+			// MySQL doesn't return id of the upserted row, when it already exists.
+			if destTX.Dialector.Name() == MySQLDialectorName {
+				var storedItem Context
+				if err := destTX.Where("json = ?", newItem.Json).First(&storedItem).Error; err != nil {
+					return eris.Wrap(err, "error getting destination row")
+				}
+				newItem.ID = storedItem.ID
+			}
+			s.contextInfos[scannedItem.ID] = newItem.ID
+			count++
+		}
+		log.Infof("Importing contexts - found %d records", count)
+		return nil
+	})
+	if err != nil {
+		return eris.Wrap(err, "error copying contexts table")
+	}
+	return nil
+}
+
 // importTable copies the contents of one table (model) from sourceDB
 // while updating the experiment_id to destinationDB.
 func (s *Importer) importTable(table string) error {
@@ -173,6 +265,11 @@ func (s *Importer) importTable(table string) error {
 		if err := s.importExperiments(); err != nil {
 			return eris.Wrap(err, "error importing table experiments")
 		}
+	// handle a special case for contexts.
+	case "contexts":
+		if err := s.importContexts(); err != nil {
+			return eris.Wrap(err, "error importing table contexts")
+		}
 	default:
 		// Start transaction in the destinationDB
 		err := s.destinationDB.Transaction(func(destTX *gorm.DB) error {
@@ -193,22 +290,46 @@ func (s *Importer) importTable(table string) error {
 			//nolint:errcheck
 			defer rows.Close()
 
+			// MySQL can't build ON DUPLICATE KEY UPDATE clause for a table without the model,
+			// so rows are inserted ignoring duplicates instead.
+			conflictClauses := []clause.Expression{clause.OnConflict{DoNothing: true}, clause.Returning{}}
+			if destTX.Dialector.Name() == MySQLDialectorName {
+				conflictClauses = []clause.Expression{clause.Insert{Modifier: "IGNORE"}}
+			}
+
 			count := 0
 			for rows.Next() {
 				var item map[string]any
 				if err = s.sourceDB.Debug().ScanRows(rows, &item); err != nil {
 					return eris.Wrap(err, "error scanning source row")
 				}
+				// logs have auto incremented ID, which could clash with already existing rows. So logs can't be
+				// told apart from already imported ones and logs of the runs, which already existed, are skipped.
+				if table == "logs" {
+					if _, ok := s.existingRunIDs[getStringValue(item["run_uuid"])]; ok {
+						continue
+					}
+					delete(item, "id")
+				}
 				item, err = s.translateFields(item)
 				if err != nil {
 					return eris.Wrap(err, "error translating fields")
 				}
 				item = ApplyNamespaceRestriction(table, item, s.destinationNamespace)
-				if err := destTX.Table(table).Clauses(
-					clause.OnConflict{DoNothing: true}, clause.Returning{},
-				).Create(&item).Error; err != nil {
+				// returned values overwrite the fields of the item, so keep the source ones.
+				sourceItem := maps.Clone(item)
+				result := destTX.Table(table).Clauses(
+					conflictClauses...,
+				).Create(&item)
+				if err := result.Error; err != nil {
 					return eris.Wrap(err, "error creating destination row")
 				}
+				if table == "runs" && result.RowsAffected == 0 {
+					s.existingRunIDs[getStringValue(sourceItem["run_uuid"])] = struct{}{}
+				}
+				if err := s.saveIDInfo(destTX, table, sourceItem); err != nil {
+					return eris.Wrap(err, "error getting destination row")
+				}
 				count++
 			}
 			log.Infof("Importing %s - found %d records", table, count)
@@ -223,13 +344,40 @@ func (s *Importer) importTable(table string) error {
 }
 
 // saveExperimentInfo maps source and destination experiment for later id mapping.
-func (s *Importer) saveExperimentInfo(source, dest Experiment) {
+func (s *Importer) saveExperimentInfo(source, dest Experiment, created bool) {
 	s.experimentInfos = append(s.experimentInfos, experimentInfo{
 		destID:   *dest.ID,
 		sourceID: *source.ID,
+		created:  created,
 	})
 }
 
+// saveIDInfo maps source and destination id of the row with unique key for later id mapping,
+// as the row could already exist in destination under another id.
+func (s *Importer) saveIDInfo(destTX *gorm.DB, table string, item map[string]any) error {
+	key, ok := uniqueKeys[table]
+	if !ok {
+		return nil
+	}
+	sourceID, ok := item["id"].(uuid.UUID)
+	if !ok {
+		return eris.Errorf("unable to assert %s as uuid: %v", "id", item["id"])
+	}
+	query := destTX.Table(table).Select("id")
+	for _, column := range key.columns {
+		query = query.Where(fmt.Sprintf("%s = ?", column), item[column])
+	}
+	var destID uuid.UUID
+	if err := query.Row().Scan(&destID); err != nil {
+		return eris.Wrapf(err, "error getting %s id", table)
+	}
+	if _, ok := s.idInfos[key.reference]; !ok {
+		s.idInfos[key.reference] = map[uuid.UUID]uuid.UUID{}
+	}
+	s.idInfos[key.reference][sourceID] = destID
+	return nil
+}
+
 // translateFields alters row before creation as needed (especially, replacing old experiment_id with new).
 func (s *Importer) translateFields(item map[string]any) (map[string]any, error) {
 	// boolean fields are numeric when coming from sqlite
@@ -261,12 +409,28 @@ func (s *Importer) translateFields(item map[string]any) (map[string]any, error)
 			}
 		}
 	}
+	// items with context_id need to reference the new ID
+	if contextID, ok := item["context_id"]; ok {
+		var id uint
+		switch v := contextID.(type) {
+		case int32:
+			id = uint(v)
+		case int64:
+			id = uint(v)
+		default:
+			return nil, eris.Errorf("unable to assert %s as uint: %d", "context_id", contextID)
+		}
+		if destID, ok := s.contextInfos[id]; ok {
+			item["context_id"] = destID
+		}
+	}
 	// items with string uuid need to translate to UUID native type
-	uuidFields := []string{"id", "app_id"}
+	uuidFields := []string{
+		"id", "app_id", "dataset_id", "input_id", "registered_model_id", "model_version_id",
+	}
 	for _, field := range uuidFields {
 		if srcUUID, ok := item[field]; ok {
-			// when uuid, this field will be pointer to interface{} and requires some reflection
-			stringUUID := fmt.Sprintf("%v", reflect.Indirect(reflect.ValueOf(srcUUID)))
+			stringUUID := getStringValue(srcUUID)
 			if uuidRegexp.MatchString(stringUUID) {
 				binID, err := uuid.Parse(stringUUID)
 				if err != nil {
@@ -276,12 +440,31 @@ func (s *Importer) translateFields(item map[string]any) (map[string]any, error)
 			}
 		}
 	}
+	// items referencing rows with unique key need to reference the new ID
+	for field, idInfos := range s.idInfos {
+		if srcID, ok := item[field].(uuid.UUID); ok {
+			if destID, ok := idInfos[srcID]; ok {
+				item[field] = destID
+			}
+		}
+	}
 	return item, nil
 }
 
+// getStringValue returns string representation of the scanned value.
+func getStringValue(value any) string {
+	// when uuid, the value will be pointer to interface{} and requires some reflection
+	return fmt.Sprintf("%v", reflect.Indirect(reflect.ValueOf(value)))
+}
+
 // updateNamespaceDefaultExperiment updates the default_experiment_id for all namespaces
-// when its related experiment received a new id.
+// when its related experiment received a new id. Namespaces are imported only when
+// destination Namespace has not been provided, so otherwise there is nothing to update.
 func (s *Importer) updateNamespaceDefaultExperiment() error {
+	if s.destinationNamespace != nil {
+		return nil
+	}
+
 	// Start transaction in the destinationDB
 	err := s.destinationDB.Transaction(func(destTX *gorm.DB) error {
 		// Get namespaces
@@ -316,7 +499,7 @@ func (s *Importer) updateNamespaceDefaultExperiment() error {
 // ApplyNamespaceRestriction overwrite Namespace if it is needed.
 func ApplyNamespaceRestriction(table string, item map[string]any, namespace *Namespace) map[string]any {
 	if namespace != nil {
-		if slices.Contains([]string{"apps", "experiments"}, table) {
+		if slices.Contains([]string{"apps", "experiments", "shared_tags", "registered_models"}, table) {
 			item["namespace_id"] = namespace.ID
 		}
 	}
@@ -343,7 +526,7 @@ func EntityLimitedByNamespace(table string, db *gorm.DB, namespace *Namespace) *
 			).Where(
 				"experiments.namespace_id = ?", namespace.ID,
 			)
-		case "tags", "params", "metrics", "latest_metrics":
+		case "tags", "params", "metrics", "latest_metrics", "logs", "artifacts", "inputs":
 			return db.Joins(
 				fmt.Sprintf("LEFT JOIN runs ON runs.run_uuid = %s.run_uuid", table),
 			).Joins(
@@ -351,8 +534,38 @@ func EntityLimitedByNamespace(table string, db *gorm.DB, namespace *Namespace) *
 			).Where(
 				"experiments.namespace_id = ?", namespace.ID,
 			)
-		case "apps", "experiments":
+		case "apps", "experiments", "registered_models":
 			return db.Where(fmt.Sprintf("%s.namespace_id = ?", table), namespace.ID)
+		case "datasets":
+			return db.Joins(
+				"LEFT JOIN experiments ON experiments.experiment_id = datasets.experiment_id",
+			).Where(
+				"experiments.namespace_id = ?", namespace.ID,
+			)
+		case "input_tags":
+			return db.Joins(
+				"LEFT JOIN inputs ON inputs.id = input_tags.input_id",
+			).Joins(
+				"LEFT JOIN runs ON runs.run_uuid = inputs.run_uuid",
+			).Joins(
+				"LEFT JOIN experiments ON experiments.experiment_id = runs.experiment_id",
+			).Where(
+				"experiments.namespace_id = ?", namespace.ID,
+			)
+		case "registered_model_tags", "registered_model_aliases", "model_versions":
+			return db.Joins(
+				fmt.Sprintf("LEFT JOIN registered_models ON registered_models.id = %s.registered_model_id", table),
+			).Where(
+				"registered_models.namespace_id = ?", namespace.ID,
+			)
+		case "model_version_tags":
+			return db.Joins(
+				"LEFT JOIN model_versions ON model_versions.id = model_version_tags.model_version_id",
+			).Joins(
+				"LEFT JOIN registered_models ON registered_models.id = model_versions.registered_model_id",
+			).Where(
+				"registered_models.namespace_id = ?", namespace.ID,
+			)
 		case "dashboards":
 			return db.Joins(
 				"LEFT JOIN apps ON apps.id = dashboards.app_id",
@@ -9,8 +9,15 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+
+	"github.com/G-Research/fasttrackml/pkg/common/telemetry"
+	"github.com/G-Research/fasttrackml/pkg/common/tracing"
 )
 
 const (
@@ -62,12 +69,18 @@ func (l *loggerAdaptor) Trace(
 	fc func() (sql string, rowsAffected int64),
 	err error,
 ) {
+	// slow queries are counted regardless of the log level.
+	elapsed := time.Since(begin)
+	if l.Config.SlowThreshold != 0 && elapsed > l.Config.SlowThreshold {
+		telemetry.DatabaseSlowQueriesTotal.Inc()
+	}
+	l.traceSpan(ctx, begin, fc, err)
+
 	if l.Logger.GetLevel() <= logrus.FatalLevel {
 		return
 	}
 
 	// This logic is similar to the default logger in gorm.io/gorm/logger.
-	elapsed := time.Since(begin)
 	switch {
 	case err != nil &&
 		l.Logger.IsLevelEnabled(logrus.ErrorLevel) &&
@@ -82,6 +95,33 @@ func (l *loggerAdaptor) Trace(
 	}
 }
 
+// traceSpan records the statement as a span of the trace from the context. Statements of
+// background jobs don't have a trace, so they are skipped. SQL is rendered only when the span
+// is sampled, as it is quite expensive.
+func (l *loggerAdaptor) traceSpan(
+	ctx context.Context,
+	begin time.Time,
+	fc func() (sql string, rowsAffected int64),
+	err error,
+) {
+	if ctx == nil {
+		return
+	}
+	_, span := tracing.StartSpan(
+		ctx, "gorm.query", trace.WithSpanKind(trace.SpanKindClient), trace.WithTimestamp(begin),
+	)
+	if !span.IsRecording() {
+		return
+	}
+	sql, rows := fc()
+	span.SetAttributes(semconv.DBQueryText(sql), attribute.Int64("db.rows_affected", rows))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
 // getLoggerEntry gets a logger entry with context and caller information added.
 func (l *loggerAdaptor) getLoggerEntry(ctx context.Context) *logrus.Entry {
 	e := l.Logger.WithContext(ctx)
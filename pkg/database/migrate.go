@@ -150,6 +150,17 @@ func CheckAndMigrateDB(migrate bool, db *gorm.DB) error {
 				&SchemaVersion{},
 				&Log{},
 				&Artifact{},
+				&RegisteredModel{},
+				&RegisteredModelTag{},
+				&RegisteredModelAlias{},
+				&ModelVersion{},
+				&ModelVersionTag{},
+				&Dataset{},
+				&Input{},
+				&InputTag{},
+				&APIToken{},
+				&APITokenPermission{},
+				&AuditLog{},
 			); err != nil {
 				return fmt.Errorf("error initializing database: %w", err)
 			}
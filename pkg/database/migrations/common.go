@@ -3,6 +3,7 @@ package migrations
 import (
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // RunWithoutForeignKeyIfNeeded disables foreign keys if needed for the migration
@@ -15,3 +16,24 @@ func RunWithoutForeignKeyIfNeeded(db *gorm.DB, fn func() error) error {
 	}
 	return fn()
 }
+
+// DropMySQLForeignKeys drops foreign keys of the table, which reference the given table.
+// Existing MLFlow MySQL databases have foreign key constraints with generated names (e.g. `params_ibfk_1`).
+func DropMySQLForeignKeys(tx *gorm.DB, table, referencedTable string) error {
+	var constraints []string
+	if err := tx.Raw(
+		"SELECT constraint_name FROM information_schema.referential_constraints"+
+			" WHERE constraint_schema = DATABASE() AND table_name = ? AND referenced_table_name = ?",
+		table, referencedTable,
+	).Scan(&constraints).Error; err != nil {
+		return err
+	}
+	for _, constraint := range constraints {
+		if err := tx.Exec(
+			"ALTER TABLE ? DROP FOREIGN KEY ?", clause.Table{Name: table}, clause.Column{Name: constraint},
+		).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package v_0001
 
 import (
+	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 )
 
@@ -20,18 +21,14 @@ func Migrate(db *gorm.DB) error {
 				return err
 			}
 		}
-		if err := tx.Exec(
+		queries := []string{
 			"UPDATE runs" +
 				"  SET row_num = rows.row_num" +
 				"  FROM (" +
 				"    SELECT run_uuid, ROW_NUMBER() OVER (ORDER BY start_time, run_uuid DESC) - 1 AS row_num" +
 				"    FROM runs" +
 				"  ) AS rows" +
-				"  WHERE runs.run_uuid = rows.run_uuid").
-			Error; err != nil {
-			return err
-		}
-		if err := tx.Exec(
+				"  WHERE runs.run_uuid = rows.run_uuid",
 			"UPDATE metrics" +
 				"  SET iter = iters.iter" +
 				"  FROM (" +
@@ -41,11 +38,7 @@ func Migrate(db *gorm.DB) error {
 				"  ) AS iters" +
 				"  WHERE" +
 				"    (metrics.run_uuid, metrics.key, metrics.timestamp, metrics.step, metrics.value) =" +
-				"    (iters.run_uuid, iters.key, iters.timestamp, iters.step, iters.value)").
-			Error; err != nil {
-			return err
-		}
-		if err := tx.Exec(
+				"    (iters.run_uuid, iters.key, iters.timestamp, iters.step, iters.value)",
 			"UPDATE latest_metrics" +
 				"  SET last_iter = metrics.last_iter" +
 				"  FROM (" +
@@ -55,9 +48,40 @@ func Migrate(db *gorm.DB) error {
 				"  ) AS metrics" +
 				"  WHERE" +
 				"    (latest_metrics.run_uuid, latest_metrics.key) =" +
-				"    (metrics.run_uuid, metrics.key)").
-			Error; err != nil {
-			return err
+				"    (metrics.run_uuid, metrics.key)",
+		}
+		if tx.Dialector.Name() == (mysql.Dialector{}).Name() {
+			// MySQL doesn't support UPDATE ... FROM, `key` and `rows` are reserved words there.
+			queries = []string{
+				"UPDATE runs" +
+					"  JOIN (" +
+					"    SELECT run_uuid, ROW_NUMBER() OVER (ORDER BY start_time, run_uuid DESC) - 1 AS new_row_num" +
+					"    FROM runs" +
+					"  ) AS numbered_runs ON runs.run_uuid = numbered_runs.run_uuid" +
+					"  SET runs.row_num = numbered_runs.new_row_num",
+				"UPDATE metrics" +
+					"  JOIN (" +
+					"    SELECT ROW_NUMBER() OVER (PARTITION BY run_uuid, \"key\" ORDER BY timestamp, step, value) - 1" +
+					"      AS new_iter, run_uuid, \"key\", timestamp, step, value" +
+					"    FROM metrics" +
+					"  ) AS iters ON" +
+					"    (metrics.run_uuid, metrics.\"key\", metrics.timestamp, metrics.step, metrics.value) =" +
+					"    (iters.run_uuid, iters.\"key\", iters.timestamp, iters.step, iters.value)" +
+					"  SET metrics.iter = iters.new_iter",
+				"UPDATE latest_metrics" +
+					"  JOIN (" +
+					"    SELECT run_uuid, \"key\", MAX(iter) AS last_iter" +
+					"    FROM metrics" +
+					"    GROUP BY run_uuid, \"key\"" +
+					"  ) AS iters ON" +
+					"    (latest_metrics.run_uuid, latest_metrics.\"key\") = (iters.run_uuid, iters.\"key\")" +
+					"  SET latest_metrics.last_iter = iters.last_iter",
+			}
+		}
+		for _, query := range queries {
+			if err := tx.Exec(query).Error; err != nil {
+				return err
+			}
 		}
 		if err := tx.AutoMigrate(&SchemaVersion{}); err != nil {
 			return err
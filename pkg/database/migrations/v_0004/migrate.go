@@ -3,9 +3,12 @@ package v_0004
 import (
 	"fmt"
 
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/database/migrations"
 )
 
 const Version = "1ce8669664d2"
@@ -32,6 +35,11 @@ func Migrate(db *gorm.DB) error {
 						return err
 					}
 				}
+			case mysql.Dialector{}.Name():
+				// Existing MLFlow MySQL databases have foreign key constraints with generated names.
+				if err := migrations.DropMySQLForeignKeys(tx, tx.NamingStrategy.TableName(constraint), "runs"); err != nil {
+					return err
+				}
 			default:
 				return fmt.Errorf("unsupported database dialect %s", tx.Dialector.Name())
 			}
@@ -3,6 +3,7 @@ package v_0005
 import (
 	"fmt"
 
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -42,6 +43,11 @@ func Migrate(db *gorm.DB) error {
 							return err
 						}
 					}
+				case mysql.Dialector{}.Name():
+					// Existing MLFlow MySQL databases have foreign key constraints with generated names.
+					if err := migrations.DropMySQLForeignKeys(tx, e.Table, "experiments"); err != nil {
+						return err
+					}
 				default:
 					return fmt.Errorf("unsupported database dialect %s", tx.Dialector.Name())
 				}
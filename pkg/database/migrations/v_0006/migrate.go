@@ -6,6 +6,7 @@ import (
 
 	"github.com/rotisserie/eris"
 	log "github.com/sirupsen/logrus"
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -40,6 +41,10 @@ func Migrate(db *gorm.DB) error {
 				if err := postgresMigrate(tx); err != nil {
 					return err
 				}
+			case mysql.Dialector{}.Name():
+				if err := mysqlMigrate(tx); err != nil {
+					return err
+				}
 			}
 			if err := tx.Migrator().AlterColumn(&Experiment{}, "Name"); err != nil {
 				return err
@@ -87,6 +92,29 @@ func postgresMigrate(tx *gorm.DB) error {
 	return nil
 }
 
+func mysqlMigrate(tx *gorm.DB) error {
+	defaultNamespace, err := createDefaultNamespace(tx)
+	if err != nil {
+		return err
+	}
+	// namespace_id has to match `namespaces.id` type, which is unsigned on MySQL.
+	if err := tx.Exec(
+		fmt.Sprintf(`
+			ALTER TABLE experiments ADD COLUMN namespace_id BIGINT UNSIGNED NOT NULL DEFAULT %d,
+			ADD CONSTRAINT fk_namespaces_experiments FOREIGN KEY (namespace_id) REFERENCES namespaces(id)`,
+			defaultNamespace.ID,
+		),
+	).Error; err != nil {
+		return eris.Wrap(err, "error adding namespace_id column for experiments")
+	}
+
+	if err := tx.Exec(`ALTER TABLE experiments ALTER COLUMN namespace_id DROP DEFAULT`).Error; err != nil {
+		return eris.Wrapf(err, "error dropping default value for `namespace_id` field in `experiments` table")
+	}
+
+	return nil
+}
+
 // createDefaultNamespace creates the default namespace if it doesn't exist.
 func createDefaultNamespace(db *gorm.DB) (*Namespace, error) {
 	defaultNamespace := Namespace{
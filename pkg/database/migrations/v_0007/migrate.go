@@ -3,6 +3,7 @@ package v_0007
 import (
 	"fmt"
 
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -23,6 +24,14 @@ func Migrate(db *gorm.DB) error {
 					return err
 				}
 			}
+		case mysql.Dialector{}.Name():
+			// MySQL names the unique index of MLFlow experiments after the column
+			index := "name"
+			if tx.Migrator().HasIndex("experiments", index) {
+				if err := tx.Migrator().DropIndex("experiments", index); err != nil {
+					return err
+				}
+			}
 		default:
 			return fmt.Errorf("unsupported database dialect %s", tx.Dialector.Name())
 		}
@@ -8,6 +8,7 @@ import (
 	"github.com/rotisserie/eris"
 	log "github.com/sirupsen/logrus"
 	"gorm.io/datatypes"
+	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -29,6 +30,10 @@ func Migrate(db *gorm.DB) error {
 				if err := postgresMigrate(tx); err != nil {
 					return err
 				}
+			case mysql.Dialector{}.Name():
+				if err := mysqlMigrate(tx); err != nil {
+					return err
+				}
 			}
 			// Update the schema version
 			return tx.Model(&SchemaVersion{}).
@@ -140,6 +145,46 @@ func postgresMigrate(tx *gorm.DB) error {
 	return nil
 }
 
+func mysqlMigrate(tx *gorm.DB) error {
+	// MySQL can't index JSON columns, so contexts are stored as strings.
+	tableOptions, _ := tx.Get("gorm:table_options")
+	if err := tx.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS contexts (
+                        id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+                        json VARCHAR(768) NOT NULL,
+                        PRIMARY KEY (id),
+                        CONSTRAINT uni_contexts_json UNIQUE (json),
+                        INDEX idx_contexts_json (json)
+                ) %v`, tableOptions)).Error; err != nil {
+		return eris.Wrap(err, "error creating contexts table")
+	}
+	defaultMetricContext, err := createDefaultMetricContext(tx)
+	if err != nil {
+		return eris.Wrap(err, "error creating default metric context")
+	}
+
+	tablesKeyCols := map[string][]string{
+		"metrics":        {`"key"`, "value", "timestamp", "run_uuid", "step", "is_nan", "context_id"},
+		"latest_metrics": {`"key"`, "run_uuid", "context_id"},
+	}
+	for table, pkCols := range tablesKeyCols {
+		sql := fmt.Sprintf(`ALTER TABLE %s
+                        DROP PRIMARY KEY,
+                        ADD COLUMN context_id BIGINT UNSIGNED NOT NULL DEFAULT %d,
+                        ADD CONSTRAINT fk_%s_contexts FOREIGN KEY (context_id) REFERENCES contexts(id),
+                        ADD PRIMARY KEY (%s)`,
+			table, defaultMetricContext.ID, table, strings.Join(pkCols, ","))
+		if err := tx.Exec(sql).Error; err != nil {
+			return eris.Wrapf(err, "error adding context_id column for %s", table)
+		}
+
+		sql = fmt.Sprintf("ALTER TABLE %s ALTER COLUMN context_id DROP DEFAULT", table)
+		if err := tx.Exec(sql).Error; err != nil {
+			return eris.Wrapf(err, "error dropping default value for %s", table)
+		}
+	}
+	return nil
+}
+
 func backupName(name string) string {
 	return fmt.Sprintf("%s_old", name)
 }
@@ -0,0 +1,30 @@
+package v_0022
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/database/migrations"
+)
+
+const Version = "20261017110716"
+
+func Migrate(db *gorm.DB) error {
+	return migrations.RunWithoutForeignKeyIfNeeded(db, func() error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			for _, field := range []string{"Type", "Text", "Data"} {
+				if err := tx.Migrator().AddColumn(&Artifact{}, field); err != nil {
+					return err
+				}
+			}
+			if err := tx.Migrator().CreateIndex(&Artifact{}, "Type"); err != nil {
+				return err
+			}
+
+			// Update the schema version
+			return tx.Model(&SchemaVersion{}).
+				Where("1 = 1").
+				Update("Version", Version).
+				Error
+		})
+	})
+}
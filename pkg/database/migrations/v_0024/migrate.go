@@ -0,0 +1,59 @@
+package v_0024
+
+import (
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/database/migrations"
+)
+
+const Version = "20261018011203"
+
+func Migrate(db *gorm.DB) error {
+	return migrations.RunWithoutForeignKeyIfNeeded(db, func() error {
+		return db.Transaction(func(tx *gorm.DB) error {
+			for _, field := range []string{"MinValue", "MaxValue", "SumValue", "ValueCount"} {
+				if err := tx.Migrator().AddColumn(&LatestMetric{}, field); err != nil {
+					return err
+				}
+			}
+			query := "UPDATE latest_metrics" +
+				"  SET min_value = summaries.min_value, max_value = summaries.max_value," +
+				"    sum_value = summaries.sum_value, value_count = summaries.value_count" +
+				"  FROM (" +
+				"    SELECT run_uuid, key, context_id, MIN(value) AS min_value, MAX(value) AS max_value," +
+				"      SUM(value) AS sum_value, COUNT(*) AS value_count" +
+				"    FROM metrics" +
+				"    WHERE is_nan = ?" +
+				"    GROUP BY run_uuid, key, context_id" +
+				"  ) AS summaries" +
+				"  WHERE" +
+				"    (latest_metrics.run_uuid, latest_metrics.key, latest_metrics.context_id) =" +
+				"    (summaries.run_uuid, summaries.key, summaries.context_id)"
+			if tx.Dialector.Name() == (mysql.Dialector{}).Name() {
+				// MySQL doesn't support UPDATE ... FROM and `key` is a reserved word there.
+				query = "UPDATE latest_metrics" +
+					"  JOIN (" +
+					"    SELECT run_uuid, \"key\", context_id, MIN(value) AS min_value, MAX(value) AS max_value," +
+					"      SUM(value) AS sum_value, COUNT(*) AS value_count" +
+					"    FROM metrics" +
+					"    WHERE is_nan = ?" +
+					"    GROUP BY run_uuid, \"key\", context_id" +
+					"  ) AS summaries ON" +
+					"    (latest_metrics.run_uuid, latest_metrics.\"key\", latest_metrics.context_id) =" +
+					"    (summaries.run_uuid, summaries.\"key\", summaries.context_id)" +
+					"  SET latest_metrics.min_value = summaries.min_value, latest_metrics.max_value = summaries.max_value," +
+					"    latest_metrics.sum_value = summaries.sum_value, latest_metrics.value_count = summaries.value_count"
+			}
+			if err := tx.Exec(query, false).Error; err != nil {
+				return err
+			}
+
+			// Update the schema version
+			return tx.Model(&SchemaVersion{}).
+				Where("1 = 1").
+				Update("Version", Version).
+				Error
+		})
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 
@@ -119,6 +120,15 @@ func (rn RowNum) GormDataType() string {
 
 func (rn RowNum) GormValue(ctx context.Context, db *gorm.DB) clause.Expr {
 	if rn == 0 {
+		switch db.Dialector.Name() {
+		case mysql.Dialector{}.Name():
+			// MySQL doesn't allow to select from the table being inserted into,
+			// unless the subquery result is materialized into a derived table.
+			return clause.Expr{
+				SQL: "(SELECT next_row_num FROM " +
+					"(SELECT COALESCE(MAX(row_num), -1) + 1 AS next_row_num FROM runs) AS max_row_num)",
+			}
+		}
 		return clause.Expr{
 			SQL: "(SELECT COALESCE(MAX(row_num), -1) FROM runs) + 1",
 		}
@@ -174,15 +184,19 @@ type Metric struct {
 }
 
 type LatestMetric struct {
-	Key       string  `gorm:"type:varchar(250);not null;primaryKey"`
-	Value     float64 `gorm:"type:double precision;not null"`
-	Timestamp int64
-	Step      int64  `gorm:"not null"`
-	IsNan     bool   `gorm:"not null"`
-	RunID     string `gorm:"column:run_uuid;not null;primaryKey;index"`
-	LastIter  int64
-	ContextID uint `gorm:"not null;primaryKey"`
-	Context   Context
+	Key        string  `gorm:"type:varchar(250);not null;primaryKey"`
+	Value      float64 `gorm:"type:double precision;not null"`
+	Timestamp  int64
+	Step       int64  `gorm:"not null"`
+	IsNan      bool   `gorm:"not null"`
+	RunID      string `gorm:"column:run_uuid;not null;primaryKey;index"`
+	LastIter   int64
+	ContextID  uint `gorm:"not null;primaryKey"`
+	Context    Context
+	MinValue   *float64 `gorm:"type:double precision"`
+	MaxValue   *float64 `gorm:"type:double precision"`
+	SumValue   float64  `gorm:"type:double precision;not null;default:0"`
+	ValueCount int64    `gorm:"not null;default:0"`
 }
 
 type Log struct {
@@ -308,6 +322,7 @@ type RoleNamespace struct {
 	RoleID      uuid.UUID `gorm:"not null;index:,unique,composite:relation"`
 	Namespace   Namespace `gorm:"constraint:OnDelete:CASCADE"`
 	NamespaceID uint      `gorm:"not null;index:,unique,composite:relation"`
+	Level       string    `gorm:"type:varchar(8);not null;default:owner"`
 }
 
 type Artifact struct {
@@ -323,4 +338,110 @@ type Artifact struct {
 	Format  string
 	Caption string
 	BlobURI string
+	Type    string `gorm:"type:varchar(16);not null;default:images;index"`
+	Text    string
+	Data    types.JSONB
+}
+
+type RegisteredModel struct {
+	ID             uuid.UUID              `gorm:"type:uuid;primaryKey"`
+	Name           string                 `gorm:"type:varchar(256);not null;index:,unique,composite:name"`
+	Description    string                 `gorm:"type:varchar(5000)"`
+	CreationTime   int64                  `gorm:"type:bigint;not null"`
+	LastUpdateTime int64                  `gorm:"type:bigint;not null"`
+	NamespaceID    uint                   `gorm:"not null;index:,unique,composite:name"`
+	Namespace      Namespace              `gorm:"constraint:OnDelete:CASCADE"`
+	Tags           []RegisteredModelTag   `gorm:"constraint:OnDelete:CASCADE"`
+	Aliases        []RegisteredModelAlias `gorm:"constraint:OnDelete:CASCADE"`
+	Versions       []ModelVersion         `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+type RegisteredModelTag struct {
+	Key               string    `gorm:"type:varchar(250);not null;primaryKey"`
+	Value             string    `gorm:"type:varchar(5000)"`
+	RegisteredModelID uuid.UUID `gorm:"type:uuid;not null;primaryKey"`
+}
+
+type RegisteredModelAlias struct {
+	Alias             string    `gorm:"type:varchar(256);not null;primaryKey"`
+	Version           int64     `gorm:"not null"`
+	RegisteredModelID uuid.UUID `gorm:"type:uuid;not null;primaryKey"`
+}
+
+//nolint:lll
+type ModelVersion struct {
+	ID                uuid.UUID         `gorm:"type:uuid;primaryKey"`
+	Version           int64             `gorm:"not null;index:,unique,composite:version"`
+	RegisteredModelID uuid.UUID         `gorm:"type:uuid;not null;index:,unique,composite:version"`
+	CreationTime      int64             `gorm:"type:bigint;not null"`
+	LastUpdateTime    int64             `gorm:"type:bigint;not null"`
+	Description       string            `gorm:"type:varchar(5000)"`
+	UserID            string            `gorm:"type:varchar(256)"`
+	CurrentStage      string            `gorm:"type:varchar(20);check:current_stage IN ('None', 'Staging', 'Production', 'Archived', 'Deleted_Internal')"`
+	Source            string            `gorm:"type:varchar(500)"`
+	RunID             string            `gorm:"column:run_uuid;type:varchar(32);index"`
+	RunLink           string            `gorm:"type:varchar(500)"`
+	Status            string            `gorm:"type:varchar(20)"`
+	StatusMessage     string            `gorm:"type:varchar(500)"`
+	Tags              []ModelVersionTag `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+type ModelVersionTag struct {
+	Key            string    `gorm:"type:varchar(250);not null;primaryKey"`
+	Value          string    `gorm:"type:varchar(5000)"`
+	ModelVersionID uuid.UUID `gorm:"type:uuid;not null;primaryKey"`
+}
+
+type Dataset struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Name         string     `gorm:"type:varchar(500);not null;index:,unique,composite:dataset"`
+	Digest       string     `gorm:"type:varchar(36);not null;index:,unique,composite:dataset"`
+	SourceType   string     `gorm:"type:varchar(36);not null"`
+	Source       string     `gorm:"type:text;not null"`
+	Schema       string     `gorm:"type:text"`
+	Profile      string     `gorm:"type:text"`
+	ExperimentID int32      `gorm:"not null;index:,unique,composite:dataset"`
+	Experiment   Experiment `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+type Input struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	DatasetID uuid.UUID  `gorm:"type:uuid;not null;index:,unique,composite:input"`
+	Dataset   Dataset    `gorm:"constraint:OnDelete:CASCADE"`
+	RunID     string     `gorm:"column:run_uuid;type:varchar(32);not null;index:,unique,composite:input"`
+	Run       Run        `gorm:"constraint:OnDelete:CASCADE"`
+	Tags      []InputTag `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+type InputTag struct {
+	Key     string    `gorm:"type:varchar(255);not null;primaryKey"`
+	Value   string    `gorm:"type:varchar(500);not null"`
+	InputID uuid.UUID `gorm:"type:uuid;not null;primaryKey"`
+}
+
+type APIToken struct {
+	Base
+	Name        string               `gorm:"type:varchar(256);not null"`
+	Description string               `gorm:"type:varchar(500)"`
+	TokenHash   string               `gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt   *time.Time           `gorm:"index"`
+	Permissions []APITokenPermission `gorm:"constraint:OnDelete:CASCADE"`
+}
+
+type APITokenPermission struct {
+	APITokenID  uuid.UUID `gorm:"type:uuid;not null;primaryKey"`
+	NamespaceID uint      `gorm:"not null;primaryKey"`
+	Namespace   Namespace `gorm:"constraint:OnDelete:CASCADE"`
+	Scope       string    `gorm:"type:varchar(5);not null;check:scope IN ('read', 'write')"`
+}
+
+type AuditLog struct {
+	ID          uint   `gorm:"primaryKey;autoIncrement"`
+	Timestamp   int64  `gorm:"not null;index"`
+	NamespaceID *uint  `gorm:"index"`
+	Actor       string `gorm:"type:varchar(256);not null;index"`
+	Method      string `gorm:"type:varchar(16);not null"`
+	Path        string `gorm:"type:varchar(1024);not null"`
+	EntityIDs   string `gorm:"column:entity_ids;type:text"`
+	StatusCode  int    `gorm:"not null"`
 }
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"database/sql"
 	"net/url"
 	"time"
 
@@ -46,6 +47,7 @@ func NewPostgresDBInstance(
 	sqlDB.SetConnMaxIdleTime(time.Minute)
 	sqlDB.SetMaxIdleConns(poolMax)
 	sqlDB.SetMaxOpenConns(poolMax)
+	db.pools = map[string]*sql.DB{"default": sqlDB}
 
 	return &db, nil
 }
@@ -0,0 +1,40 @@
+package database
+
+import (
+	"gorm.io/gorm"
+)
+
+// LockRuns locks the runs table in scope of the transaction, so runs.row_num could be calculated safely.
+func LockRuns(tx *gorm.DB) error {
+	switch tx.Dialector.Name() {
+	case PostgresDialectorName:
+		return tx.Exec("LOCK TABLE runs").Error
+	case MySQLDialectorName:
+		// LOCK TABLES implicitly commits the current transaction on MySQL, so the rows are locked instead.
+		return tx.Exec("SELECT MAX(row_num) FROM runs FOR UPDATE").Error
+	}
+	return nil
+}
+
+// RenumberRunsQuery returns the query, which updates runs.row_num with the correct ordinal
+// starting from the row number given twice as the query parameters.
+func RenumberRunsQuery(tx *gorm.DB) string {
+	switch tx.Dialector.Name() {
+	case MySQLDialectorName:
+		return `UPDATE runs
+		        JOIN (
+		          SELECT run_uuid, ROW_NUMBER() OVER (ORDER BY start_time) + ? - 1 as new_row_num
+		          FROM runs
+		          WHERE runs.row_num >= ?
+		        ) as numbered_runs ON runs.run_uuid = numbered_runs.run_uuid
+		        SET runs.row_num = numbered_runs.new_row_num`
+	}
+	return `UPDATE runs
+	        SET row_num = rows.new_row_num
+	        FROM (
+	          SELECT run_uuid, ROW_NUMBER() OVER (ORDER BY start_time) + ? - 1 as new_row_num
+	          FROM runs
+	          WHERE runs.row_num >= ?
+	        ) as rows
+	        WHERE runs.run_uuid = rows.run_uuid`
+}
@@ -93,6 +93,7 @@ func NewSqliteDBInstance(
 	}
 	db.closers = append(db.closers, replicaDB)
 	replicaDB.SetMaxOpenConns(poolMax)
+	db.pools = map[string]*sql.DB{"source": sourceDB, "replica": replicaDB}
 	replicaConn = sqlite.Dialector{
 		Conn: replicaDB,
 	}
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/recover"
 	"github.com/rotisserie/eris"
 	log "github.com/sirupsen/logrus"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 
 	aimAPI "github.com/G-Research/fasttrackml/pkg/api/aim"
 	aimController "github.com/G-Research/fasttrackml/pkg/api/aim/controller"
@@ -40,10 +42,17 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/common/middleware"
 	artifactService "github.com/G-Research/fasttrackml/pkg/common/services/artifact"
 	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/common/services/gc"
+	"github.com/G-Research/fasttrackml/pkg/common/services/ingest"
+	"github.com/G-Research/fasttrackml/pkg/common/services/live"
+	"github.com/G-Research/fasttrackml/pkg/common/telemetry"
+	"github.com/G-Research/fasttrackml/pkg/common/tracing"
 	"github.com/G-Research/fasttrackml/pkg/database"
 	adminUI "github.com/G-Research/fasttrackml/pkg/ui/admin"
 	adminUIController "github.com/G-Research/fasttrackml/pkg/ui/admin/controller"
+	adminUIAuditService "github.com/G-Research/fasttrackml/pkg/ui/admin/service/audit"
 	adminUINamespaceService "github.com/G-Research/fasttrackml/pkg/ui/admin/service/namespace"
+	adminUITokenService "github.com/G-Research/fasttrackml/pkg/ui/admin/service/token"
 	aimUI "github.com/G-Research/fasttrackml/pkg/ui/aim"
 	"github.com/G-Research/fasttrackml/pkg/ui/chooser"
 	chooserController "github.com/G-Research/fasttrackml/pkg/ui/chooser/controller"
@@ -125,6 +134,12 @@ func createDBProvider(ctx context.Context, config *config.Config) (database.DBPr
 	return db, nil
 }
 
+// bodyLimit is the maximum size of the request body, which is read into memory.
+const bodyLimit = 16 * 1024 * 1024
+
+// streamingReadTimeout is the maximum time to wait for the next part of the streamed request body.
+const streamingReadTimeout = 60 * time.Second
+
 // createApp creates a new fiber app with base configuration.
 //
 //nolint:contextcheck
@@ -135,7 +150,8 @@ func createApp(
 	artifactStorageFactory storage.ArtifactStorageFactoryProvider,
 ) (*fiber.App, error) {
 	app := fiber.New(fiber.Config{
-		BodyLimit:             16 * 1024 * 1024,
+		BodyLimit:             bodyLimit,
+		StreamRequestBody:     true,
 		ReadBufferSize:        16384,
 		ReadTimeout:           5 * time.Second,
 		WriteTimeout:          600 * time.Second,
@@ -149,7 +165,10 @@ func createApp(
 				return aimAPI.ErrorHandler(c, err)
 			case strings.HasPrefix(p, "/api/2.0/mlflow/") ||
 				strings.HasPrefix(p, "/ajax-api/2.0/mlflow/") ||
-				strings.HasPrefix(p, "/mlflow/ajax-api/2.0/mlflow/"):
+				strings.HasPrefix(p, "/mlflow/ajax-api/2.0/mlflow/") ||
+				strings.HasPrefix(p, "/api/2.0/mlflow-artifacts/") ||
+				strings.HasPrefix(p, "/ajax-api/2.0/mlflow-artifacts/") ||
+				strings.HasPrefix(p, "/mlflow/ajax-api/2.0/mlflow-artifacts/"):
 				return mlflowService.ErrorHandler(c, err)
 
 			default:
@@ -158,36 +177,113 @@ func createApp(
 		},
 	})
 
+	// buffered metrics have to be flushed before the database connection is closed.
+	var ingestService *ingest.Service
+
+	// spans are buffered by the tracer provider, so it has to be shut down to export them.
+	var tracerProvider *sdktrace.TracerProvider
+	if config.Tracing.Enabled {
+		provider, err := tracing.NewTracerProvider(ctx, config)
+		if err != nil {
+			return nil, eris.Wrap(err, "error creating tracer provider")
+		}
+		tracerProvider = provider
+	}
+
+	// Prometheus metrics can be exposed on a separate address, so they aren't reachable by API clients.
+	metricsHandler := telemetry.NewHandler(db.Pools())
+	var metricsApp *fiber.App
+
 	app.Hooks().OnShutdown(func() error {
+		if metricsApp != nil {
+			if err := metricsApp.Shutdown(); err != nil {
+				log.Errorf("error shutting down metrics server: %+v", err)
+			}
+		}
+		if ingestService != nil {
+			log.Info("Flushing buffered metrics")
+			ingestService.Close()
+		}
+		if tracerProvider != nil {
+			log.Info("Exporting buffered spans")
+			if err := tracerProvider.Shutdown(context.Background()); err != nil {
+				log.Errorf("error shutting down tracer provider: %+v", err)
+			}
+		}
 		log.Info("Shutting down database connection")
 		return db.Close()
 	})
 
+	// request bodies, which exceed the body limit, are streamed, so the limit is enforced by the middleware.
+	app.Use(middleware.NewBodyLimitMiddleware(middleware.BodyLimitConfig{
+		Limit: bodyLimit,
+		Streaming: func(c *fiber.Ctx) bool {
+			// artifact uploads are streamed into the artifact storage,
+			// bulk metrics are decoded straight from the Arrow IPC stream.
+			return (strings.Contains(c.Path(), "/mlflow-artifacts/") && c.Method() == http.MethodPut) ||
+				(strings.HasSuffix(c.Path(), "/mlflow/metrics/log-bulk") && c.Method() == http.MethodPost)
+		},
+		StreamingReadTimeout: streamingReadTimeout,
+	}))
+
 	if config.DevMode {
 		log.Info("Development mode - enabling CORS")
 		app.Use(cors.New())
 	}
 
-	// create namespace notification listener.
-	namespaceEventListener, err := dao.NewNamespaceListener(ctx, db.GormDB())
+	if config.Tracing.Enabled {
+		log.Info("Tracing - enabling OpenTelemetry tracing")
+		app.Use(middleware.NewTracingMiddleware())
+	}
+
+	// create event bus to keep local caches of all the instances consistent.
+	eventListener, err := dao.NewEventBus(ctx, db.GormDB())
 	if err != nil {
-		return nil, eris.Wrap(err, "error creating namespace notification listener")
+		return nil, eris.Wrap(err, "error creating event bus")
+	}
+	if err := db.GormDB().Use(dao.NewEntityEventsPlugin(eventListener)); err != nil {
+		return nil, eris.Wrap(err, "error registering entity events plugin")
 	}
 
 	namespaceCachedRepository, err := mlflowRepositories.NewNamespaceCachedRepository(
-		ctx, mlflowRepositories.NewNamespaceRepository(db.GormDB()), namespaceEventListener,
+		ctx, mlflowRepositories.NewNamespaceRepository(db.GormDB()), eventListener,
 	)
 	if err != nil {
 		return nil, eris.Wrap(err, "error creating namespace repository")
 	}
 	rolesCachedRepository, err := repositories.NewRoleCachedRepository(
-		ctx, db.GormDB(), namespaceEventListener,
+		ctx, db.GormDB(), eventListener,
 	)
 	if err != nil {
 		return nil, eris.Wrap(err, "error creating roles repository")
 	}
+	experimentCachedRepository, err := mlflowRepositories.NewExperimentCachedRepository(
+		ctx, mlflowRepositories.NewExperimentRepository(db.GormDB()), eventListener,
+	)
+	if err != nil {
+		return nil, eris.Wrap(err, "error creating experiment repository")
+	}
+	dashboardCachedRepository, err := aimRepositories.NewDashboardCachedRepository(
+		ctx, aimRepositories.NewDashboardRepository(db.GormDB()), eventListener,
+	)
+	if err != nil {
+		return nil, eris.Wrap(err, "error creating dashboard repository")
+	}
+
+	// live updates of the runs are delivered through the same event bus.
+	var liveService *live.Service
+	if config.LiveUpdatesEnabled {
+		liveService = live.NewService(ctx, eventListener)
+	}
+
+	// buffered metrics are published to the live updates only once they were flushed.
+	if config.MetricIngest.Async {
+		ingestService = ingest.NewService(
+			ctx, config, mlflowRepositories.NewMetricRepository(db.GormDB()), liveService.PublishMetrics,
+		)
+	}
 
-	namespaceEventListener.Listen()
+	eventListener.Listen()
 
 	// attach global middlewares.
 	if config.Auth.AuthUsername != "" && config.Auth.AuthPassword != "" {
@@ -204,7 +300,9 @@ func createApp(
 		Next: func(c *fiber.Ctx) bool {
 			// This is a little brittle, maybe there is a better way?
 			// Do not compress metric histories as urllib3 did not support file-like compressed reads until 2.0.0a1
-			return strings.HasSuffix(c.Path(), "/metrics/get-histories")
+			// Do not compress live updates stream as compression buffers the events.
+			return strings.HasSuffix(c.Path(), "/metrics/get-histories") ||
+				strings.HasSuffix(strings.TrimSuffix(c.Path(), "/"), "/runs/live")
 		},
 	}))
 
@@ -220,6 +318,15 @@ func createApp(
 	app.Get("/version", func(c *fiber.Ctx) error {
 		return c.SendString(version.Version)
 	})
+	if config.MetricsListenAddress == "" {
+		app.Get("/metrics", metricsHandler)
+	}
+
+	// record mutating requests into the audit log. Middleware is attached before auth middlewares,
+	// so rejected requests are recorded as well.
+	if config.AuditLogEnabled {
+		app.Use(middleware.NewAuditMiddleware(mlflowRepositories.NewAuditLogRepository(db.GormDB())))
+	}
 
 	// based on Auth configuration, attach global OIDC or Basic Auth middleware.
 	switch {
@@ -256,14 +363,18 @@ func createApp(
 		})
 		app.Use(middleware.NewOIDCMiddleware(oidcClient, rolesCachedRepository))
 	case config.Auth.IsAuthTypeUser():
-		app.Use(middleware.NewBasicAuthMiddleware(config.Auth.AuthParsedUserPermissions))
+		app.Use(middleware.NewBasicAuthMiddleware(
+			config.Auth.AuthParsedUserPermissions, mlflowRepositories.NewAPITokenRepository(db.GormDB()),
+		))
 	}
 
 	app.Use(compress.New(compress.Config{
 		Next: func(c *fiber.Ctx) bool {
 			// This is a little brittle, maybe there is a better way?
 			// Do not compress metric histories as urllib3 did not support file-like compressed reads until 2.0.0a1
-			return strings.HasSuffix(c.Path(), "/metrics/get-histories")
+			// Do not compress live updates stream as compression buffers the events.
+			return strings.HasSuffix(c.Path(), "/metrics/get-histories") ||
+				strings.HasSuffix(strings.TrimSuffix(c.Path(), "/"), "/runs/live")
 		},
 	}))
 
@@ -286,7 +397,9 @@ func createApp(
 				aimRepositories.NewArtifactRepository(db.GormDB()),
 			),
 			artifactService.NewService(
+				config,
 				mlflowRepositories.NewRunRepository(db.GormDB()),
+				experimentCachedRepository,
 				artifactStorageFactory,
 			),
 			aimProjectService.NewService(
@@ -299,14 +412,17 @@ func createApp(
 				config.LiveUpdatesEnabled,
 			),
 			aimDashboardService.NewService(
-				aimRepositories.NewDashboardRepository(db.GormDB()),
+				dashboardCachedRepository,
 				aimRepositories.NewAppRepository(db.GormDB()),
 			),
 			aimExperimentService.NewService(
 				aimRepositories.NewTagRepository(db.GormDB()),
 				aimRepositories.NewExperimentRepository(db.GormDB()),
 			),
+			liveService,
 		),
+	).AddGlobalMiddleware(
+		middleware.NewTelemetryMiddleware("aim"),
 	).Init(app)
 
 	// init `mlflow` api and ui routes.
@@ -318,25 +434,36 @@ func createApp(
 				mlflowRepositories.NewRunRepository(db.GormDB()),
 				mlflowRepositories.NewParamRepository(db.GormDB()),
 				mlflowRepositories.NewMetricRepository(db.GormDB()),
-				mlflowRepositories.NewExperimentRepository(db.GormDB()),
+				experimentCachedRepository,
 				mlflowRepositories.NewLogRepository(db.GormDB(), config.RunLogOutputMax),
 				mlflowRepositories.NewArtifactRepository(db.GormDB()),
+				mlflowRepositories.NewInputRepository(db.GormDB()),
+				liveService,
+				ingestService,
+			),
+			mlflowModelService.NewService(
+				mlflowRepositories.NewModelVersionRepository(db.GormDB()),
+				mlflowRepositories.NewRegisteredModelRepository(db.GormDB()),
 			),
-			mlflowModelService.NewService(),
 			mlflowMetricService.NewService(
 				mlflowRepositories.NewRunRepository(db.GormDB()),
 				mlflowRepositories.NewMetricRepository(db.GormDB()),
 			),
 			artifactService.NewService(
+				config,
 				mlflowRepositories.NewRunRepository(db.GormDB()),
+				experimentCachedRepository,
 				artifactStorageFactory,
 			),
 			mlflowExperimentService.NewService(
 				config,
 				mlflowRepositories.NewTagRepository(db.GormDB()),
 				mlflowRepositories.NewExperimentRepository(db.GormDB()),
+				artifactStorageFactory,
 			),
 		),
+	).AddGlobalMiddleware(
+		middleware.NewTelemetryMiddleware("mlflow"),
 	).Init(app)
 
 	// run a log cleaner background job.
@@ -346,6 +473,25 @@ func createApp(
 		mlflowRepositories.NewLogRepository(db.GormDB(), config.RunLogOutputMax),
 	).Run()
 
+	// run a garbage collection background job.
+	gc.NewJob(
+		ctx,
+		config,
+		gc.NewCollector(
+			namespaceCachedRepository,
+			mlflowRepositories.NewExperimentRepository(db.GormDB()),
+			mlflowRepositories.NewRunRepository(db.GormDB()),
+			artifactStorageFactory,
+		),
+	).Run()
+
+	// run an audit log cleaner background job.
+	adminUIAuditService.NewCleaner(
+		ctx,
+		config,
+		mlflowRepositories.NewAuditLogRepository(db.GormDB()),
+	).Run()
+
 	mlflowUI.AddRoutes(app)
 	aimUI.AddRoutes(app)
 
@@ -357,6 +503,14 @@ func createApp(
 				namespaceCachedRepository,
 				mlflowRepositories.NewExperimentRepository(db.GormDB()),
 			),
+			adminUITokenService.NewService(
+				mlflowRepositories.NewAPITokenRepository(db.GormDB()),
+				namespaceCachedRepository,
+			),
+			adminUIAuditService.NewService(
+				mlflowRepositories.NewAuditLogRepository(db.GormDB()),
+				namespaceCachedRepository,
+			),
 		),
 	).Init(app); err != nil {
 		return nil, eris.Wrap(err, "error initializing admin routes")
@@ -381,5 +535,20 @@ func createApp(
 		return nil, eris.Wrap(err, "error initializing chooser routes")
 	}
 
+	if config.MetricsListenAddress != "" {
+		listener, err := net.Listen("tcp", config.MetricsListenAddress)
+		if err != nil {
+			return nil, eris.Wrapf(err, "error listening on metrics address %s", config.MetricsListenAddress)
+		}
+		metricsApp = fiber.New(fiber.Config{DisableStartupMessage: true})
+		metricsApp.Get("/metrics", metricsHandler)
+		go func() {
+			log.Infof("Exposing metrics on %s", config.MetricsListenAddress)
+			if err := metricsApp.Listener(listener); err != nil {
+				log.Errorf("error serving metrics: %+v", err)
+			}
+		}()
+	}
+
 	return app, nil
 }
@@ -0,0 +1,81 @@
+package controller
+
+import (
+	"github.com/gofiber/fiber/v2"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/response"
+	"github.com/G-Research/fasttrackml/pkg/ui/common"
+)
+
+// GetAuditLogs renders the list view of the latest audit log records.
+func (c Controller) GetAuditLogs(ctx *fiber.Ctx) error {
+	var req request.AuditLogs
+	if err := ctx.QueryParser(&req); err != nil {
+		return ctx.Render("audit/index", fiber.Map{
+			"Status":  StatusError,
+			"Message": common.ErrorMessageForUI("audit log filter", err.Error()),
+		})
+	}
+	auditLogs, err := c.auditService.ListAuditLogs(ctx.Context(), &req)
+	if err != nil {
+		return ctx.Render("audit/index", fiber.Map{
+			"Status":  StatusError,
+			"Message": common.ErrorMessageForUI("audit log filter", err.Error()),
+			"Filter":  req,
+		})
+	}
+	namespaceCodes, err := c.auditService.GetNamespaceCodes(ctx.Context())
+	if err != nil {
+		return ctx.Render("audit/index", fiber.Map{
+			"Status":  StatusError,
+			"Message": common.ErrorMessageForUI("namespace", err.Error()),
+			"Filter":  req,
+		})
+	}
+	return ctx.Render("audit/index", fiber.Map{
+		"AuditLogs": response.NewListAuditLogsResponse(auditLogs, namespaceCodes).AuditLogs,
+		"Filter":    req,
+	})
+}
+
+// ListAuditLogs handles `GET /admin/api/audit-logs` endpoint.
+func (c Controller) ListAuditLogs(ctx *fiber.Ctx) error {
+	var req request.AuditLogs
+	if err := ctx.QueryParser(&req); err != nil {
+		return handleAPIError(ctx, api.NewBadRequestError("Unable to decode query parameters: %s", err))
+	}
+	log.Debugf("listAuditLogs request: %#v", req)
+
+	auditLogs, err := c.auditService.ListAuditLogs(ctx.Context(), &req)
+	if err != nil {
+		return handleAPIError(ctx, err)
+	}
+	namespaceCodes, err := c.auditService.GetNamespaceCodes(ctx.Context())
+	if err != nil {
+		return handleAPIError(ctx, err)
+	}
+	return ctx.JSON(response.NewListAuditLogsResponse(auditLogs, namespaceCodes))
+}
+
+// ExportAuditLogs handles `GET /admin/api/audit-logs/export` endpoint.
+func (c Controller) ExportAuditLogs(ctx *fiber.Ctx) error {
+	var req request.AuditLogs
+	if err := ctx.QueryParser(&req); err != nil {
+		return handleAPIError(ctx, api.NewBadRequestError("Unable to decode query parameters: %s", err))
+	}
+	log.Debugf("exportAuditLogs request: %#v", req)
+
+	namespaceCodes, err := c.auditService.GetNamespaceCodes(ctx.Context())
+	if err != nil {
+		return handleAPIError(ctx, err)
+	}
+	rows, next, err := c.auditService.ExportAuditLogs(ctx.Context(), &req)
+	if err != nil {
+		return handleAPIError(ctx, err)
+	}
+	response.NewExportAuditLogsResponse(ctx, rows, next, namespaceCodes)
+	return nil
+}
@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/response"
+	"github.com/G-Research/fasttrackml/pkg/ui/common"
+)
+
+// GetAPITokens renders the list view of API tokens.
+func (c Controller) GetAPITokens(ctx *fiber.Ctx) error {
+	tokens, err := c.tokenService.ListAPITokens(ctx.Context())
+	if err != nil {
+		return ctx.Render("tokens/index", fiber.Map{
+			"Status":  StatusError,
+			"Message": common.ErrorMessageForUI("api token", err.Error()),
+		})
+	}
+	return ctx.Render("tokens/index", fiber.Map{
+		"Tokens": response.NewListAPITokensResponse(tokens).Tokens,
+	})
+}
+
+// NewAPIToken renders the create view for an API token.
+func (c Controller) NewAPIToken(ctx *fiber.Ctx) error {
+	namespaces, err := c.namespaceService.ListNamespaces(ctx.Context())
+	if err != nil {
+		return ctx.Render("tokens/create", fiber.Map{
+			"Status":  StatusError,
+			"Message": common.ErrorMessageForUI("namespace", err.Error()),
+		})
+	}
+	return ctx.Render("tokens/create", fiber.Map{
+		"Namespaces": namespaces,
+	})
+}
+
+// ListAPITokens handles `GET /admin/api/tokens` endpoint.
+func (c Controller) ListAPITokens(ctx *fiber.Ctx) error {
+	tokens, err := c.tokenService.ListAPITokens(ctx.Context())
+	if err != nil {
+		return handleAPIError(ctx, err)
+	}
+	return ctx.JSON(response.NewListAPITokensResponse(tokens))
+}
+
+// CreateAPIToken handles `POST /admin/api/tokens` endpoint.
+func (c Controller) CreateAPIToken(ctx *fiber.Ctx) error {
+	var req request.APIToken
+	if err := ctx.BodyParser(&req); err != nil {
+		return handleAPIError(ctx, api.NewBadRequestError("Unable to decode request body: %s", err))
+	}
+	log.Debugf("createAPIToken request: name: %s, permissions: %v", req.Name, req.Permissions)
+
+	token, plainToken, err := c.tokenService.CreateAPIToken(ctx.Context(), &req)
+	if err != nil {
+		return handleAPIError(ctx, err)
+	}
+	return ctx.JSON(response.CreateAPITokenResponse{
+		Token: response.NewAPITokenResponse(token, plainToken),
+	})
+}
+
+// DeleteAPIToken handles `DELETE /admin/api/tokens/:id` endpoint.
+func (c Controller) DeleteAPIToken(ctx *fiber.Ctx) error {
+	id, err := uuid.Parse(ctx.Params("id"))
+	if err != nil {
+		return handleAPIError(ctx, api.NewBadRequestError("unable to parse id"))
+	}
+	if err := c.tokenService.DeleteAPIToken(ctx.Context(), id); err != nil {
+		return handleAPIError(ctx, err)
+	}
+	return ctx.JSON(fiber.Map{})
+}
+
+// handleAPIError renders error of the JSON API in the same format as Aim and Mlflow API do.
+func handleAPIError(ctx *fiber.Ctx, err error) error {
+	var e *api.ErrorResponse
+	if !errors.As(err, &e) {
+		e = api.NewInternalError(err.Error())
+	}
+	return ctx.Status(e.StatusCode).JSON(e)
+}
@@ -0,0 +1,7 @@
+function auditLogIndex() {
+  redirectTo('/admin/audit-logs/');
+}
+
+function exportAuditLogs() {
+  redirectTo('/admin/api/audit-logs/export' + window.location.search);
+}
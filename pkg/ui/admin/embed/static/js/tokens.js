@@ -0,0 +1,63 @@
+function handleCreateAPIToken() {
+  $("#createAPITokenForm").on("submit", function(event) {
+    event.preventDefault(); // Prevent the default form submission
+
+    const request = {
+      name: $("#name").val(),
+      description: $("#description").val(),
+      permissions: [],
+    };
+    const expiresAt = $("#expires_at").val();
+    if (expiresAt) {
+      request["expires_at"] = new Date(expiresAt).toISOString();
+    }
+    $(".token-scope").each(function() {
+      const scope = $(this).val();
+      if (scope) {
+        request.permissions.push({namespace: $(this).data("namespace"), scope: scope});
+      }
+    });
+
+    // Perform a POST request using jQuery's $.ajax
+    $.ajax({
+      url: "/admin/api/tokens",
+      type: "POST",
+      contentType: "application/json",
+      data: JSON.stringify(request),
+    }).done(function(data) {
+      $("#createAPITokenForm").hide();
+      $("#message").hide();
+      $("#token-value").text(data["token"]["token"]);
+      $("#token-created").show();
+    }).fail(handleAPITokenError);
+  });
+}
+
+function createAPIToken() {
+  redirectTo('/admin/tokens/new');
+}
+
+function apiTokenIndex() {
+  redirectTo('/admin/tokens/');
+}
+
+function revokeAPIToken(id) {
+  if (confirm("Are you sure?") != true ){
+    return
+  }
+  // Perform a DELETE request using jQuery's $.ajax
+  $.ajax({
+    url: `/admin/api/tokens/${id}`,
+    type: "DELETE",
+    contentType: "application/json",
+  }).done(function() {
+    redirectTo('/admin/tokens/'
+        + `?message=${encodeURIComponent("Successfully revoked API token.")}`
+        + `&status=success`);
+  }).fail(handleAPITokenError);
+}
+
+function handleAPITokenError(jqxhr) {
+  const data = jqxhr.responseJSON || {};
+  showErrorMessage(data["message"] || "Unable to process request.");
+}
@@ -0,0 +1,10 @@
+package request
+
+// AuditLogs represents the data to filter audit log records.
+type AuditLogs struct {
+	Namespace string `query:"namespace"`
+	Actor     string `query:"actor"`
+	Since     int64  `query:"since"`
+	Until     int64  `query:"until"`
+	Limit     int    `query:"limit"`
+}
@@ -0,0 +1,17 @@
+package request
+
+import "time"
+
+// APITokenPermission represents the data to grant API token access to a Namespace.
+type APITokenPermission struct {
+	Namespace string `json:"namespace"`
+	Scope     string `json:"scope"`
+}
+
+// APIToken represents the data to create an API token.
+type APIToken struct {
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	ExpiresAt   *time.Time           `json:"expires_at"`
+	Permissions []APITokenPermission `json:"permissions"`
+}
@@ -0,0 +1,102 @@
+package response
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/rotisserie/eris"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+)
+
+// AuditLog represents the data for viewing an audit log record.
+type AuditLog struct {
+	ID         uint      `json:"id"`
+	Timestamp  time.Time `json:"timestamp"`
+	Namespace  string    `json:"namespace"`
+	Actor      string    `json:"actor"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	EntityIDs  []string  `json:"entity_ids"`
+	StatusCode int       `json:"status_code"`
+}
+
+// NewAuditLogResponse creates new AuditLog object. Namespace is shown by its code,
+// when the namespace was deleted, its ID is shown instead.
+func NewAuditLogResponse(auditLog *models.AuditLog, namespaceCodes map[uint]string) *AuditLog {
+	resp := AuditLog{
+		ID:         auditLog.ID,
+		Timestamp:  time.UnixMilli(auditLog.Timestamp).UTC(),
+		Actor:      auditLog.Actor,
+		Method:     auditLog.Method,
+		Path:       auditLog.Path,
+		EntityIDs:  []string{},
+		StatusCode: auditLog.StatusCode,
+	}
+	if auditLog.NamespaceID != nil {
+		resp.Namespace = namespaceCodes[*auditLog.NamespaceID]
+		if resp.Namespace == "" {
+			resp.Namespace = strconv.FormatUint(uint64(*auditLog.NamespaceID), 10)
+		}
+	}
+	if auditLog.EntityIDs != "" {
+		resp.EntityIDs = strings.Split(auditLog.EntityIDs, ",")
+	}
+	return &resp
+}
+
+// ListAuditLogsResponse represents the response for `GET /admin/api/audit-logs` endpoint.
+type ListAuditLogsResponse struct {
+	AuditLogs []*AuditLog `json:"audit_logs"`
+}
+
+// NewListAuditLogsResponse creates new ListAuditLogsResponse object.
+func NewListAuditLogsResponse(auditLogs []models.AuditLog, namespaceCodes map[uint]string) *ListAuditLogsResponse {
+	resp := ListAuditLogsResponse{
+		AuditLogs: make([]*AuditLog, len(auditLogs)),
+	}
+	for i := range auditLogs {
+		resp.AuditLogs[i] = NewAuditLogResponse(&auditLogs[i], namespaceCodes)
+	}
+	return &resp
+}
+
+// NewExportAuditLogsResponse creates new response for `GET /admin/api/audit-logs/export` endpoint.
+// Audit log records are streamed as JSON lines.
+func NewExportAuditLogsResponse(
+	ctx *fiber.Ctx,
+	rows *sql.Rows,
+	next func(*sql.Rows) (*models.AuditLog, error),
+	namespaceCodes map[uint]string,
+) {
+	ctx.Set(fiber.HeaderContentType, "application/x-ndjson")
+	ctx.Set(fiber.HeaderContentDisposition, `attachment; filename="audit-logs.jsonl"`)
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		//nolint:errcheck
+		defer rows.Close()
+
+		start := time.Now()
+		if err := func() error {
+			encoder := json.NewEncoder(w)
+			for rows.Next() {
+				auditLog, err := next(rows)
+				if err != nil {
+					return eris.Wrap(err, "error getting next result")
+				}
+				if err := encoder.Encode(NewAuditLogResponse(auditLog, namespaceCodes)); err != nil {
+					return eris.Wrap(err, "error encoding audit log")
+				}
+			}
+			return w.Flush()
+		}(); err != nil {
+			log.Errorf("error encountered in %s %s: error streaming audit logs: %s", ctx.Method(), ctx.Path(), err)
+		}
+		log.Infof("body - %s %s %s", time.Since(start), ctx.Method(), ctx.Path())
+	})
+}
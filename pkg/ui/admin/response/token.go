@@ -0,0 +1,72 @@
+package response
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+)
+
+// APITokenPermission represents the data for viewing API token access to a Namespace.
+type APITokenPermission struct {
+	Namespace string `json:"namespace"`
+	Scope     string `json:"scope"`
+}
+
+// APIToken represents the data for viewing an API token.
+// Token itself is returned only once, right after the API token was created.
+type APIToken struct {
+	ID          uuid.UUID            `json:"id"`
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Token       string               `json:"token,omitempty"`
+	ExpiresAt   *time.Time           `json:"expires_at"`
+	CreatedAt   time.Time            `json:"created_at"`
+	Permissions []APITokenPermission `json:"permissions"`
+}
+
+// NewAPITokenResponse creates new APIToken object.
+func NewAPITokenResponse(token *models.APIToken, plainToken string) *APIToken {
+	resp := APIToken{
+		ID:          token.ID,
+		Name:        token.Name,
+		Description: token.Description,
+		Token:       plainToken,
+		ExpiresAt:   token.ExpiresAt,
+		CreatedAt:   token.CreatedAt,
+		Permissions: make([]APITokenPermission, 0, len(token.Permissions)),
+	}
+	for _, permission := range token.Permissions {
+		// namespace could be already deleted.
+		if permission.Namespace.Code == "" {
+			continue
+		}
+		resp.Permissions = append(resp.Permissions, APITokenPermission{
+			Namespace: permission.Namespace.Code,
+			Scope:     string(permission.Scope),
+		})
+	}
+	return &resp
+}
+
+// CreateAPITokenResponse represents the response for `POST /admin/api/tokens` endpoint.
+type CreateAPITokenResponse struct {
+	Token *APIToken `json:"token"`
+}
+
+// ListAPITokensResponse represents the response for `GET /admin/api/tokens` endpoint.
+type ListAPITokensResponse struct {
+	Tokens []*APIToken `json:"tokens"`
+}
+
+// NewListAPITokensResponse creates new ListAPITokensResponse object.
+func NewListAPITokensResponse(tokens []models.APIToken) *ListAPITokensResponse {
+	resp := ListAPITokensResponse{
+		Tokens: make([]*APIToken, len(tokens)),
+	}
+	for i := range tokens {
+		resp.Tokens[i] = NewAPITokenResponse(&tokens[i], "")
+	}
+	return &resp
+}
@@ -48,9 +48,17 @@ func (r *Router) Init(router fiber.Router) error {
 
 	// specific routes
 	namespaces := app.Group("namespaces")
+	tokens := app.Group("tokens")
+	tokensAPI := app.Group("api/tokens")
+	auditLogs := app.Group("audit-logs")
+	auditLogsAPI := app.Group("api/audit-logs")
 	// apply global middlewares.
 	for _, globalMiddleware := range r.globalMiddlewares {
 		namespaces.Use(globalMiddleware)
+		tokens.Use(globalMiddleware)
+		tokensAPI.Use(globalMiddleware)
+		auditLogs.Use(globalMiddleware)
+		auditLogsAPI.Use(globalMiddleware)
 	}
 	namespaces.Get("/", r.controller.GetNamespaces)
 	namespaces.Post("/", r.controller.CreateNamespace)
@@ -59,6 +67,18 @@ func (r *Router) Init(router fiber.Router) error {
 	namespaces.Put("/:id<int>/", r.controller.UpdateNamespace)
 	namespaces.Delete("/:id<int>/", r.controller.DeleteNamespace)
 
+	tokens.Get("/", r.controller.GetAPITokens)
+	tokens.Get("/new", r.controller.NewAPIToken)
+
+	tokensAPI.Get("/", r.controller.ListAPITokens)
+	tokensAPI.Post("/", r.controller.CreateAPIToken)
+	tokensAPI.Delete("/:id<guid>/", r.controller.DeleteAPIToken)
+
+	auditLogs.Get("/", r.controller.GetAuditLogs)
+
+	auditLogsAPI.Get("/", r.controller.ListAuditLogs)
+	auditLogsAPI.Get("/export", r.controller.ExportAuditLogs)
+
 	// default route
 	app.Use("/", etag.New(), filesystem.New(filesystem.Config{
 		Root: http.FS(sub),
@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+)
+
+// Cleaner represents audit log cleaner, which deletes records older than retention period.
+type Cleaner struct {
+	ctx                context.Context
+	config             *config.Config
+	auditLogRepository repositories.AuditLogRepositoryProvider
+}
+
+// NewCleaner creates a new instance of Cleaner.
+func NewCleaner(
+	ctx context.Context,
+	config *config.Config,
+	auditLogRepository repositories.AuditLogRepositoryProvider,
+) *Cleaner {
+	return &Cleaner{
+		ctx:                ctx,
+		config:             config,
+		auditLogRepository: auditLogRepository,
+	}
+}
+
+// Run runs audit log cleaner background job. Zero retention period keeps records forever.
+func (c Cleaner) Run() {
+	if c.config.AuditLogRetain == 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(10 * time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.ctx.Done():
+				log.Debug("audit log cleaner finished. exiting.")
+				return
+			case <-ticker.C:
+				numberOfDeleted, err := c.auditLogRepository.CleanExpired(c.ctx, c.config.AuditLogRetain)
+				if err != nil {
+					log.Errorf("error cleaning expired audit logs: %+v", err)
+				} else {
+					log.Debugf("%d expired audit logs were successfully cleaned", numberOfDeleted)
+				}
+			}
+		}
+	}()
+}
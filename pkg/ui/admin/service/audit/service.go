@@ -0,0 +1,102 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+)
+
+// list of limits of audit log records returned at once.
+const (
+	DefaultListAuditLogsLimit = 100
+	MaxListAuditLogsLimit     = 1000
+)
+
+// Service provides service layer to work with `audit log` business logic.
+type Service struct {
+	auditLogRepository  repositories.AuditLogRepositoryProvider
+	namespaceRepository repositories.NamespaceRepositoryProvider
+}
+
+// NewService creates new Service instance.
+func NewService(
+	auditLogRepository repositories.AuditLogRepositoryProvider,
+	namespaceRepository repositories.NamespaceRepositoryProvider,
+) *Service {
+	return &Service{
+		auditLogRepository:  auditLogRepository,
+		namespaceRepository: namespaceRepository,
+	}
+}
+
+// ListAuditLogs returns the latest audit log records matching the request.
+func (s Service) ListAuditLogs(ctx context.Context, req *request.AuditLogs) ([]models.AuditLog, error) {
+	filter, err := s.getFilter(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if filter.Limit == 0 {
+		filter.Limit = DefaultListAuditLogsLimit
+	}
+	auditLogs, err := s.auditLogRepository.List(ctx, *filter)
+	if err != nil {
+		return nil, api.NewInternalError("error listing audit logs: %s", err)
+	}
+	return auditLogs, nil
+}
+
+// ExportAuditLogs returns cursor over all the audit log records matching the request.
+func (s Service) ExportAuditLogs(
+	ctx context.Context, req *request.AuditLogs,
+) (*sql.Rows, func(*sql.Rows) (*models.AuditLog, error), error) {
+	filter, err := s.getFilter(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows, next, err := s.auditLogRepository.GetRows(ctx, *filter)
+	if err != nil {
+		return nil, nil, api.NewInternalError("error exporting audit logs: %s", err)
+	}
+	return rows, next, nil
+}
+
+// GetNamespaceCodes returns codes of existing namespaces by their IDs.
+func (s Service) GetNamespaceCodes(ctx context.Context) (map[uint]string, error) {
+	namespaces, err := s.namespaceRepository.List(ctx)
+	if err != nil {
+		return nil, api.NewInternalError("error listing namespaces: %s", err)
+	}
+	codes := make(map[uint]string, len(namespaces))
+	for _, namespace := range namespaces {
+		codes[namespace.ID] = namespace.Code
+	}
+	return codes, nil
+}
+
+// getFilter validates the request and converts it into the repository filter.
+func (s Service) getFilter(ctx context.Context, req *request.AuditLogs) (*repositories.AuditLogsFilter, error) {
+	if err := ValidateAuditLogsRequest(req); err != nil {
+		return nil, err
+	}
+	filter := repositories.AuditLogsFilter{
+		Actor: req.Actor,
+		Since: req.Since,
+		Until: req.Until,
+		Limit: req.Limit,
+	}
+	if req.Namespace != "" {
+		namespace, err := s.namespaceRepository.GetByCode(ctx, req.Namespace)
+		if err != nil {
+			return nil, api.NewInternalError("error getting namespace with code '%s': %s", req.Namespace, err)
+		}
+		if namespace == nil {
+			return nil, api.NewResourceDoesNotExistError("unable to find namespace with code: %s", req.Namespace)
+		}
+		filter.NamespaceID = &namespace.ID
+	}
+	return &filter, nil
+}
@@ -0,0 +1,96 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+)
+
+func TestService_ListAuditLogs_Ok(t *testing.T) {
+	// init repository mocks.
+	namespaceRepository := repositories.MockNamespaceRepositoryProvider{}
+	namespaceRepository.On(
+		"GetByCode", context.TODO(), "default",
+	).Return(&models.Namespace{ID: 1, Code: "default"}, nil)
+
+	auditLogs := []models.AuditLog{{ID: 1, NamespaceID: common.GetPointer[uint](1), Actor: "user"}}
+	auditLogRepository := repositories.MockAuditLogRepositoryProvider{}
+	auditLogRepository.On(
+		"List", context.TODO(), repositories.AuditLogsFilter{
+			NamespaceID: common.GetPointer[uint](1),
+			Actor:       "user",
+			Since:       10,
+			Limit:       DefaultListAuditLogsLimit,
+		},
+	).Return(auditLogs, nil)
+
+	// call service under testing.
+	service := NewService(&auditLogRepository, &namespaceRepository)
+	result, err := service.ListAuditLogs(context.TODO(), &request.AuditLogs{
+		Namespace: "default",
+		Actor:     "user",
+		Since:     10,
+	})
+
+	// compare results.
+	require.Nil(t, err)
+	assert.Equal(t, auditLogs, result)
+}
+
+func TestService_ListAuditLogs_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.AuditLogs
+		service func() *Service
+	}{
+		{
+			name:    "IncorrectLimit",
+			error:   api.NewInvalidParameterValueError("'limit' has to be between 0 and 1000"),
+			request: &request.AuditLogs{Limit: 1001},
+			service: func() *Service {
+				return NewService(
+					&repositories.MockAuditLogRepositoryProvider{}, &repositories.MockNamespaceRepositoryProvider{},
+				)
+			},
+		},
+		{
+			name:    "NotFoundNamespace",
+			error:   api.NewResourceDoesNotExistError("unable to find namespace with code: default"),
+			request: &request.AuditLogs{Namespace: "default"},
+			service: func() *Service {
+				namespaceRepository := repositories.MockNamespaceRepositoryProvider{}
+				namespaceRepository.On("GetByCode", context.TODO(), "default").Return(nil, nil)
+				return NewService(&repositories.MockAuditLogRepositoryProvider{}, &namespaceRepository)
+			},
+		},
+		{
+			name:    "DatabaseError",
+			error:   api.NewInternalError("error listing audit logs: database error"),
+			request: &request.AuditLogs{},
+			service: func() *Service {
+				auditLogRepository := repositories.MockAuditLogRepositoryProvider{}
+				auditLogRepository.On(
+					"List", context.TODO(), repositories.AuditLogsFilter{Limit: DefaultListAuditLogsLimit},
+				).Return(nil, errors.New("database error"))
+				return NewService(&auditLogRepository, &repositories.MockNamespaceRepositoryProvider{})
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.service().ListAuditLogs(context.TODO(), tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
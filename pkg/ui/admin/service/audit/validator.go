@@ -0,0 +1,23 @@
+package audit
+
+import (
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+)
+
+// ValidateAuditLogsRequest validates request to list or export audit log records.
+func ValidateAuditLogsRequest(req *request.AuditLogs) error {
+	if req.Since < 0 {
+		return api.NewInvalidParameterValueError("'since' has to be a non-negative timestamp")
+	}
+	if req.Until < 0 {
+		return api.NewInvalidParameterValueError("'until' has to be a non-negative timestamp")
+	}
+	if req.Since > 0 && req.Until > 0 && req.Since >= req.Until {
+		return api.NewInvalidParameterValueError("'since' has to be less than 'until'")
+	}
+	if req.Limit < 0 || req.Limit > MaxListAuditLogsLimit {
+		return api.NewInvalidParameterValueError("'limit' has to be between 0 and %d", MaxListAuditLogsLimit)
+	}
+	return nil
+}
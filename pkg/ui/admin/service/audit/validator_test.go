@@ -0,0 +1,55 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+)
+
+func TestValidateAuditLogsRequest_Ok(t *testing.T) {
+	require.Nil(t, ValidateAuditLogsRequest(&request.AuditLogs{
+		Namespace: "default",
+		Since:     1,
+		Until:     2,
+		Limit:     MaxListAuditLogsLimit,
+	}))
+}
+
+func TestValidateAuditLogsRequest_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.AuditLogs
+	}{
+		{
+			name:    "NegativeSince",
+			error:   api.NewInvalidParameterValueError("'since' has to be a non-negative timestamp"),
+			request: &request.AuditLogs{Since: -1},
+		},
+		{
+			name:    "NegativeUntil",
+			error:   api.NewInvalidParameterValueError("'until' has to be a non-negative timestamp"),
+			request: &request.AuditLogs{Until: -1},
+		},
+		{
+			name:    "IncorrectPeriod",
+			error:   api.NewInvalidParameterValueError("'since' has to be less than 'until'"),
+			request: &request.AuditLogs{Since: 2, Until: 2},
+		},
+		{
+			name:    "IncorrectLimit",
+			error:   api.NewInvalidParameterValueError("'limit' has to be between 0 and 1000"),
+			request: &request.AuditLogs{Limit: -1},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.error, ValidateAuditLogsRequest(tt.request))
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package token
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+)
+
+// tokenPrefix helps to recognize FastTrackML API tokens, e.g. by secret scanners.
+const tokenPrefix = "fml_"
+
+// Service provides service layer to work with `api token` business logic.
+type Service struct {
+	apiTokenRepository  repositories.APITokenRepositoryProvider
+	namespaceRepository repositories.NamespaceRepositoryProvider
+}
+
+// NewService creates new Service instance.
+func NewService(
+	apiTokenRepository repositories.APITokenRepositoryProvider,
+	namespaceRepository repositories.NamespaceRepositoryProvider,
+) *Service {
+	return &Service{
+		apiTokenRepository:  apiTokenRepository,
+		namespaceRepository: namespaceRepository,
+	}
+}
+
+// ListAPITokens returns all API tokens.
+func (s Service) ListAPITokens(ctx context.Context) ([]models.APIToken, error) {
+	tokens, err := s.apiTokenRepository.List(ctx)
+	if err != nil {
+		return nil, api.NewInternalError("error listing api tokens: %s", err)
+	}
+	return tokens, nil
+}
+
+// CreateAPIToken creates new API token and returns it together with the plain token value.
+// Only hash of the token is stored, so the plain value can't be retrieved later.
+func (s Service) CreateAPIToken(ctx context.Context, req *request.APIToken) (*models.APIToken, string, error) {
+	if err := ValidateCreateAPITokenRequest(req, time.Now()); err != nil {
+		return nil, "", err
+	}
+
+	token := models.APIToken{
+		Name:        req.Name,
+		Description: req.Description,
+		ExpiresAt:   req.ExpiresAt,
+		Permissions: make([]models.APITokenPermission, len(req.Permissions)),
+	}
+	for i, permission := range req.Permissions {
+		namespace, err := s.namespaceRepository.GetByCode(ctx, permission.Namespace)
+		if err != nil {
+			return nil, "", api.NewInternalError("error getting namespace with code '%s': %s", permission.Namespace, err)
+		}
+		if namespace == nil {
+			return nil, "", api.NewResourceDoesNotExistError(
+				"unable to find namespace with code: %s", permission.Namespace,
+			)
+		}
+		token.Permissions[i] = models.APITokenPermission{
+			NamespaceID: namespace.ID,
+			Namespace:   *namespace,
+			Scope:       models.APITokenScope(permission.Scope),
+		}
+	}
+
+	plainToken, err := generateToken()
+	if err != nil {
+		return nil, "", api.NewInternalError("error generating api token: %s", err)
+	}
+	token.TokenHash = models.HashAPIToken(plainToken)
+	if err := s.apiTokenRepository.Create(ctx, &token); err != nil {
+		return nil, "", api.NewInternalError("error creating api token: %s", err)
+	}
+	return &token, plainToken, nil
+}
+
+// DeleteAPIToken revokes existing API token.
+func (s Service) DeleteAPIToken(ctx context.Context, id uuid.UUID) error {
+	token, err := s.apiTokenRepository.GetByID(ctx, id)
+	if err != nil {
+		return api.NewInternalError("error getting api token with id '%s': %s", id, err)
+	}
+	if token == nil {
+		return api.NewResourceDoesNotExistError("unable to find api token with id: %s", id)
+	}
+	if err := s.apiTokenRepository.Delete(ctx, token); err != nil {
+		return api.NewInternalError("error deleting api token with id '%s': %s", id, err)
+	}
+	return nil
+}
+
+// generateToken generates new random API token.
+func generateToken() (string, error) {
+	data := make([]byte, 32)
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+	return tokenPrefix + hex.EncodeToString(data), nil
+}
@@ -0,0 +1,143 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+)
+
+func TestService_CreateAPIToken_Ok(t *testing.T) {
+	// init repository mocks.
+	namespaceRepository := repositories.MockNamespaceRepositoryProvider{}
+	namespaceRepository.On(
+		"GetByCode", context.TODO(), "default",
+	).Return(&models.Namespace{ID: 1, Code: "default"}, nil)
+
+	apiTokenRepository := repositories.MockAPITokenRepositoryProvider{}
+	apiTokenRepository.On(
+		"Create",
+		context.TODO(),
+		mock.MatchedBy(func(token *models.APIToken) bool {
+			assert.Equal(t, "ci", token.Name)
+			assert.Equal(t, "description", token.Description)
+			assert.Len(t, token.TokenHash, 64)
+			assert.Equal(t, []models.APITokenPermission{
+				{
+					NamespaceID: 1,
+					Namespace:   models.Namespace{ID: 1, Code: "default"},
+					Scope:       models.APITokenScopeWrite,
+				},
+			}, token.Permissions)
+			return true
+		}),
+	).Return(nil)
+
+	// call service under testing.
+	service := NewService(&apiTokenRepository, &namespaceRepository)
+	token, plainToken, err := service.CreateAPIToken(context.TODO(), &request.APIToken{
+		Name:        "ci",
+		Description: "description",
+		Permissions: []request.APITokenPermission{{Namespace: "default", Scope: "write"}},
+	})
+
+	// compare results.
+	require.Nil(t, err)
+	assert.Regexp(t, `^fml_[0-9a-f]{64}$`, plainToken)
+	assert.Equal(t, models.HashAPIToken(plainToken), token.TokenHash)
+}
+
+func TestService_CreateAPIToken_Error(t *testing.T) {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.APIToken
+		service func() *Service
+	}{
+		{
+			name:    "EmptyName",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'name'"),
+			request: &request.APIToken{},
+			service: func() *Service {
+				return NewService(
+					&repositories.MockAPITokenRepositoryProvider{}, &repositories.MockNamespaceRepositoryProvider{},
+				)
+			},
+		},
+		{
+			name:  "NotFoundNamespace",
+			error: api.NewResourceDoesNotExistError("unable to find namespace with code: default"),
+			request: &request.APIToken{
+				Name:        "ci",
+				Permissions: []request.APITokenPermission{{Namespace: "default", Scope: "read"}},
+			},
+			service: func() *Service {
+				namespaceRepository := repositories.MockNamespaceRepositoryProvider{}
+				namespaceRepository.On("GetByCode", context.TODO(), "default").Return(nil, nil)
+				return NewService(&repositories.MockAPITokenRepositoryProvider{}, &namespaceRepository)
+			},
+		},
+		{
+			name:  "DatabaseError",
+			error: api.NewInternalError("error creating api token: database error"),
+			request: &request.APIToken{
+				Name:        "ci",
+				Permissions: []request.APITokenPermission{{Namespace: "default", Scope: "read"}},
+			},
+			service: func() *Service {
+				namespaceRepository := repositories.MockNamespaceRepositoryProvider{}
+				namespaceRepository.On(
+					"GetByCode", context.TODO(), "default",
+				).Return(&models.Namespace{ID: 1, Code: "default"}, nil)
+				apiTokenRepository := repositories.MockAPITokenRepositoryProvider{}
+				apiTokenRepository.On(
+					"Create", context.TODO(), mock.AnythingOfType("*models.APIToken"),
+				).Return(errors.New("database error"))
+				return NewService(&apiTokenRepository, &namespaceRepository)
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := tt.service().CreateAPIToken(context.TODO(), tt.request)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
+
+func TestService_DeleteAPIToken_Ok(t *testing.T) {
+	id := uuid.New()
+	token := &models.APIToken{Base: models.Base{ID: id}}
+
+	// init repository mocks.
+	apiTokenRepository := repositories.MockAPITokenRepositoryProvider{}
+	apiTokenRepository.On("GetByID", context.TODO(), id).Return(token, nil)
+	apiTokenRepository.On("Delete", context.TODO(), token).Return(nil)
+
+	// call service under testing.
+	service := NewService(&apiTokenRepository, &repositories.MockNamespaceRepositoryProvider{})
+	require.Nil(t, service.DeleteAPIToken(context.TODO(), id))
+}
+
+func TestService_DeleteAPIToken_Error(t *testing.T) {
+	id := uuid.New()
+
+	// init repository mocks.
+	apiTokenRepository := repositories.MockAPITokenRepositoryProvider{}
+	apiTokenRepository.On("GetByID", context.TODO(), id).Return(nil, nil)
+
+	// call service under testing.
+	service := NewService(&apiTokenRepository, &repositories.MockNamespaceRepositoryProvider{})
+	err := service.DeleteAPIToken(context.TODO(), id)
+	assert.Equal(t, api.NewResourceDoesNotExistError("unable to find api token with id: %s", id), err)
+}
@@ -0,0 +1,51 @@
+package token
+
+import (
+	"time"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+)
+
+// maxNameLength is the maximum length of the API token name.
+const maxNameLength = 256
+
+// ValidateCreateAPITokenRequest validates request to create an API token.
+func ValidateCreateAPITokenRequest(req *request.APIToken, now time.Time) error {
+	if req.Name == "" {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'name'")
+	}
+	if len(req.Name) > maxNameLength {
+		return api.NewInvalidParameterValueError("'name' exceeds the maximum length of %d characters", maxNameLength)
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return api.NewInvalidParameterValueError("'expires_at' has to be in the future")
+	}
+	if len(req.Permissions) == 0 {
+		return api.NewInvalidParameterValueError("Missing value for required parameter 'permissions'")
+	}
+
+	namespaces := make(map[string]struct{}, len(req.Permissions))
+	for _, permission := range req.Permissions {
+		if permission.Namespace == "" {
+			return api.NewInvalidParameterValueError("Missing value for required parameter 'permissions.namespace'")
+		}
+		if _, ok := namespaces[permission.Namespace]; ok {
+			return api.NewInvalidParameterValueError(
+				"duplicate permission for namespace '%s'", permission.Namespace,
+			)
+		}
+		namespaces[permission.Namespace] = struct{}{}
+
+		switch models.APITokenScope(permission.Scope) {
+		case models.APITokenScopeRead, models.APITokenScopeWrite:
+		default:
+			return api.NewInvalidParameterValueError(
+				"invalid scope '%s' for namespace '%s'. Valid values are ['read', 'write']",
+				permission.Scope, permission.Namespace,
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package token
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+)
+
+func TestValidateCreateAPITokenRequest_Ok(t *testing.T) {
+	now := time.Now()
+	expiresAt := now.Add(time.Hour)
+	err := ValidateCreateAPITokenRequest(&request.APIToken{
+		Name:      "ci",
+		ExpiresAt: &expiresAt,
+		Permissions: []request.APITokenPermission{
+			{Namespace: "default", Scope: "read"},
+			{Namespace: "namespace1", Scope: "write"},
+		},
+	}, now)
+	require.Nil(t, err)
+}
+
+func TestValidateCreateAPITokenRequest_Error(t *testing.T) {
+	now := time.Now()
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request *request.APIToken
+	}{
+		{
+			name:    "EmptyName",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'name'"),
+			request: &request.APIToken{},
+		},
+		{
+			name:  "TooLongName",
+			error: api.NewInvalidParameterValueError("'name' exceeds the maximum length of 256 characters"),
+			request: &request.APIToken{
+				Name: strings.Repeat("a", 257),
+			},
+		},
+		{
+			name:  "ExpiresAtInThePast",
+			error: api.NewInvalidParameterValueError("'expires_at' has to be in the future"),
+			request: &request.APIToken{
+				Name:      "ci",
+				ExpiresAt: &now,
+			},
+		},
+		{
+			name:  "EmptyPermissions",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'permissions'"),
+			request: &request.APIToken{
+				Name: "ci",
+			},
+		},
+		{
+			name:  "EmptyNamespace",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'permissions.namespace'"),
+			request: &request.APIToken{
+				Name:        "ci",
+				Permissions: []request.APITokenPermission{{Scope: "read"}},
+			},
+		},
+		{
+			name:  "DuplicateNamespace",
+			error: api.NewInvalidParameterValueError("duplicate permission for namespace 'default'"),
+			request: &request.APIToken{
+				Name: "ci",
+				Permissions: []request.APITokenPermission{
+					{Namespace: "default", Scope: "read"},
+					{Namespace: "default", Scope: "write"},
+				},
+			},
+		},
+		{
+			name: "InvalidScope",
+			error: api.NewInvalidParameterValueError(
+				"invalid scope 'admin' for namespace 'default'. Valid values are ['read', 'write']",
+			),
+			request: &request.APIToken{
+				Name:        "ci",
+				Permissions: []request.APITokenPermission{{Namespace: "default", Scope: "admin"}},
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCreateAPITokenRequest(tt.request, now)
+			assert.Equal(t, tt.error, err)
+		})
+	}
+}
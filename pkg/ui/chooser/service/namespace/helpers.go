@@ -1,9 +1,8 @@
 package namespace
 
 import (
-	"fmt"
-
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	commonModels "github.com/G-Research/fasttrackml/pkg/common/dao/models"
 )
 
 // FilterNamespacesByAuthTokenUserRoles filter namespaces by provided roles from Auth token.
@@ -11,9 +10,10 @@ func FilterNamespacesByAuthTokenUserRoles(
 	roles map[string]struct{},
 	namespaces []models.Namespace,
 ) []models.Namespace {
+	authToken := commonModels.NewBasicAuthToken(roles)
 	var filteredPermissions []models.Namespace
 	for _, namespace := range namespaces {
-		if _, ok := roles[fmt.Sprintf("ns:%s", namespace.Code)]; ok {
+		if authToken.HasUserAccess(namespace.Code) {
 			filteredPermissions = append(filteredPermissions, namespace)
 		}
 	}
@@ -128,3 +128,15 @@ def test_log_image(client, server, run):
             )
             == None
         )
+
+
+def test_log_text(client, server, run):
+    # test logging some texts
+    for i in range(10):
+        assert client.log_text(run.info.run_id, "predictions", f"prediction {i}", i) == None
+
+
+def test_log_distribution(client, server, run):
+    # test logging some distributions
+    for i in range(10):
+        assert client.log_distribution(run.info.run_id, "activations", [1, 2, 3], 3, [0, 1], i) == None
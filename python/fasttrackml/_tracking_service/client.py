@@ -165,3 +165,24 @@ class FasttrackmlTrackingServiceClient(TrackingServiceClient):
         self.custom_store.log_image(
             run_id, name, filename, artifact_path, caption, index, width, height, format, step, iter
         )
+
+    def log_text(
+        self,
+        run_id: str,
+        name: str,
+        text: str,
+        step: int,
+        index: int,
+    ):
+        self.custom_store.log_text(run_id, name, text, step, index)
+
+    def log_distribution(
+        self,
+        run_id: str,
+        name: str,
+        weights: Sequence[float],
+        bin_count: int,
+        range: Sequence[float],
+        step: int,
+    ):
+        self.custom_store.log_distribution(run_id, name, weights, bin_count, range, step)
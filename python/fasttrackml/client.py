@@ -516,3 +516,69 @@ class FasttrackmlClient(MlflowClient):
         return self._tracking_client.log_image(
             run_id, name, filename, artifact_path, caption, index, width, height, format, step, iter
         )
+
+    def log_text(
+        self,
+        run_id: str,
+        name: str,
+        text: str,
+        step: int = 0,
+        index: int = 0,
+    ) -> None:
+        """
+        Log a text for the provided run which will be viewable in the Texts explorer.
+
+        Args:
+            run_id: String ID of the run
+            name: String the name for this sequence of texts
+            text: The text to log
+            step: The text step
+            index: The text index within the step
+
+        .. code-block:: python
+            :caption: Example
+
+            from fasttrackml import FasttrackmlClient
+
+            client = FasttrackmlClient()
+            run = client.create_run("0")
+            for step in range(10):
+                client.log_text(run.info.run_id, "predictions", f"prediction {step}", step)
+            client.set_terminated(run.info.run_id)
+        """
+        self._tracking_client.log_text(run_id, name, text, step, index)
+
+    def log_distribution(
+        self,
+        run_id: str,
+        name: str,
+        weights: Sequence[float],
+        bin_count: int,
+        range: Sequence[float],
+        step: int = 0,
+    ) -> None:
+        """
+        Log a distribution (histogram) for the provided run which will be viewable in the Distributions explorer.
+
+        Args:
+            run_id: String ID of the run
+            name: String the name for this sequence of distributions
+            weights: The weights of the histogram bins
+            bin_count: The number of histogram bins
+            range: The low and the high ends of the histogram
+            step: The distribution step
+
+        .. code-block:: python
+            :caption: Example
+
+            import numpy as np
+            from fasttrackml import FasttrackmlClient
+
+            client = FasttrackmlClient()
+            run = client.create_run("0")
+            for step in range(10):
+                weights, edges = np.histogram(np.random.normal(size=1000), bins=64)
+                client.log_distribution(run.info.run_id, "activations", weights, 64, [edges[0], edges[-1]], step)
+            client.set_terminated(run.info.run_id)
+        """
+        self._tracking_client.log_distribution(run_id, name, weights, bin_count, range, step)
@@ -93,6 +93,13 @@ class CustomRestStore(RestStore):
         return result
 
     def log_batch(self, run_id, metrics=[], params=[], tags=[]):
+        # metrics-only batches go through the faster bulk endpoint, anything else
+        # stays a single log-batch call so that it is applied atomically.
+        if metrics and not params and not tags:
+            result = self._log_metrics_bulk([(run_id, metric) for metric in metrics])
+            if result.status_code != 404:
+                return self._check_result(result)
+
         metrics_list, params_list, tags_list = [], [], []
         for metric in metrics:
             metrics_list.append(
@@ -116,7 +123,7 @@ class CustomRestStore(RestStore):
             params_list.append(param_partial)
 
         for tag in tags:
-            tag_list.append(
+            tags_list.append(
                 {
                     "key": tag.key,
                     "value": tag.value,
@@ -131,7 +138,46 @@ class CustomRestStore(RestStore):
                 "json": {"run_id": run_id, "metrics": metrics_list, "params": params_list, "tags": tags_list},
             }
         )
+        return self._check_result(result)
+
+    def log_metrics_bulk(self, run_metrics):
+        """Log metrics of many runs at once, `run_metrics` is a list of (run_id, metric) tuples."""
+        return self._check_result(self._log_metrics_bulk(run_metrics))
+
+    def _log_metrics_bulk(self, run_metrics):
+        contexts = []
+        for _, metric in run_metrics:
+            context = getattr(metric, "context", None)
+            try:
+                contexts.append(json.dumps(context) if context else None)
+            except Exception as e:
+                raise MlflowException(f"Failed to serialize object in context: {context}: {str(e)}")
+        table = pa.table(
+            {
+                "run_id": pa.array([run_id for run_id, _ in run_metrics], type=pa.string()),
+                "key": pa.array([metric.key for _, metric in run_metrics], type=pa.string()),
+                "value": pa.array([metric.value for _, metric in run_metrics], type=pa.float64()),
+                "timestamp": pa.array([metric.timestamp for _, metric in run_metrics], type=pa.int64()),
+                "step": pa.array([metric.step or 0 for _, metric in run_metrics], type=pa.int64()),
+                "context": pa.array(contexts, type=pa.string()),
+            }
+        )
+        sink = pa.BufferOutputStream()
+        with pa.ipc.new_stream(sink, table.schema) as writer:
+            writer.write_table(table)
+
+        return http_request(
+            **{
+                "host_creds": self.get_host_creds(),
+                "endpoint": "/api/2.0/mlflow/metrics/log-bulk",
+                "method": "POST",
+                "data": sink.getvalue().to_pybytes(),
+                "extra_headers": {"Content-Type": "application/vnd.apache.arrow.stream"},
+            }
+        )
 
+    @staticmethod
+    def _check_result(result):
         if result.status_code != 200:
             result = result.json()
         if "error_code" in result:
@@ -320,3 +366,63 @@ class CustomRestStore(RestStore):
                 error_code=result["error_code"],
             )
         return result
+
+    def log_text(
+        self,
+        run_id: str,
+        name: str,
+        text: str,
+        step: int,
+        index: int,
+    ):
+        return self._log_sequence_artifact(
+            {
+                "run_id": run_id,
+                "name": name,
+                "type": "texts",
+                "text": text,
+                "step": step,
+                "index": index,
+            }
+        )
+
+    def log_distribution(
+        self,
+        run_id: str,
+        name: str,
+        weights: Sequence[float],
+        bin_count: int,
+        range: Sequence[float],
+        step: int,
+    ):
+        return self._log_sequence_artifact(
+            {
+                "run_id": run_id,
+                "name": name,
+                "type": "distributions",
+                "distribution": {
+                    "weights": [float(weight) for weight in weights],
+                    "bin_count": bin_count,
+                    "range": [float(value) for value in range],
+                },
+                "step": step,
+            }
+        )
+
+    def _log_sequence_artifact(self, request_body: dict):
+        result = http_request(
+            **{
+                "host_creds": self.get_host_creds(),
+                "endpoint": "/api/2.0/mlflow/runs/log-artifact",
+                "method": "POST",
+                "json": request_body,
+            }
+        )
+        if result.status_code != 201:
+            result = result.json()
+        if "error_code" in result:
+            raise MlflowException(
+                message=result["message"],
+                error_code=result["error_code"],
+            )
+        return result
@@ -0,0 +1,223 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	mlflowRequest "github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	mlflowResponse "github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/middleware"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/response"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type AuditLogTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestAuditLogTestSuite(t *testing.T) {
+	testSuite := new(AuditLogTestSuite)
+	testSuite.Config = config.Config{
+		AuditLogEnabled: true,
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *AuditLogTestSuite) Test_Ok() {
+	runs, err := s.RunFixtures.CreateExampleRuns(context.Background(), s.DefaultExperiment, 2)
+	s.Require().Nil(err)
+
+	// mutating requests have to be recorded.
+	createExperimentResp := mlflowResponse.CreateExperimentResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			mlflowRequest.CreateExperimentRequest{Name: "audit"},
+		).WithResponse(
+			&createExperimentResp,
+		).DoRequest(
+			"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsCreateRoute,
+		),
+	)
+	deleteBatchResp := fiber.Map{}
+	s.Require().Nil(
+		s.AIMClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			[]string{runs[0].ID, runs[1].ID},
+		).WithResponse(
+			&deleteBatchResp,
+		).DoRequest(
+			"/runs/delete-batch",
+		),
+	)
+	errorResp := api.ErrorResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			mlflowRequest.DeleteExperimentRequest{ID: "999"},
+		).WithResponse(
+			&errorResp,
+		).DoRequest(
+			"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsDeleteRoute,
+		),
+	)
+	s.Equal(http.StatusNotFound, errorResp.StatusCode)
+	createTokenResp := response.CreateAPITokenResponse{}
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.APIToken{
+				Name:        "ci",
+				Permissions: []request.APITokenPermission{{Namespace: "default", Scope: "read"}},
+			},
+		).WithResponse(
+			&createTokenResp,
+		).DoRequest("/api/tokens"),
+	)
+
+	// read-only requests are not recorded.
+	searchRunsResp := mlflowResponse.SearchRunsResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			mlflowRequest.SearchRunsRequest{ExperimentIDs: []string{createExperimentResp.ID}},
+		).WithResponse(
+			&searchRunsResp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute,
+		),
+	)
+
+	listResp := response.ListAuditLogsResponse{}
+	s.Require().Nil(s.AdminClient().WithResponse(&listResp).DoRequest("/api/audit-logs"))
+	s.Require().Equal(4, len(listResp.AuditLogs))
+	for _, auditLog := range listResp.AuditLogs {
+		s.Equal(middleware.AnonymousActor, auditLog.Actor)
+		s.Equal(http.MethodPost, auditLog.Method)
+		s.False(auditLog.Timestamp.IsZero())
+	}
+	s.Equal("", listResp.AuditLogs[0].Namespace)
+	s.Equal("/admin/api/tokens", listResp.AuditLogs[0].Path)
+	s.Equal([]string{"ci"}, listResp.AuditLogs[0].EntityIDs)
+	s.Equal(http.StatusOK, listResp.AuditLogs[0].StatusCode)
+
+	s.Equal(models.DefaultNamespaceCode, listResp.AuditLogs[1].Namespace)
+	s.Equal("/api/2.0/mlflow/experiments/delete", listResp.AuditLogs[1].Path)
+	s.Equal([]string{"999"}, listResp.AuditLogs[1].EntityIDs)
+	s.Equal(http.StatusNotFound, listResp.AuditLogs[1].StatusCode)
+
+	s.Equal(models.DefaultNamespaceCode, listResp.AuditLogs[2].Namespace)
+	s.Equal("/aim/api/runs/delete-batch", listResp.AuditLogs[2].Path)
+	s.Equal([]string{runs[0].ID, runs[1].ID}, listResp.AuditLogs[2].EntityIDs)
+	s.Equal(http.StatusOK, listResp.AuditLogs[2].StatusCode)
+
+	s.Equal(models.DefaultNamespaceCode, listResp.AuditLogs[3].Namespace)
+	s.Equal("/api/2.0/mlflow/experiments/create", listResp.AuditLogs[3].Path)
+	s.Equal([]string{"audit"}, listResp.AuditLogs[3].EntityIDs)
+
+	// filter by namespace and limit.
+	listResp = response.ListAuditLogsResponse{}
+	s.Require().Nil(
+		s.AdminClient().WithQuery(
+			request.AuditLogs{Namespace: models.DefaultNamespaceCode, Limit: 2},
+		).WithResponse(
+			&listResp,
+		).DoRequest("/api/audit-logs"),
+	)
+	s.Require().Equal(2, len(listResp.AuditLogs))
+	s.Equal("/api/2.0/mlflow/experiments/delete", listResp.AuditLogs[0].Path)
+	s.Equal("/aim/api/runs/delete-batch", listResp.AuditLogs[1].Path)
+
+	// export records as JSON lines.
+	exportResp := new(bytes.Buffer)
+	s.Require().Nil(
+		s.AdminClient().WithQuery(
+			request.AuditLogs{Namespace: models.DefaultNamespaceCode},
+		).WithResponseType(
+			helpers.ResponseTypeBuffer,
+		).WithResponse(
+			exportResp,
+		).DoRequest("/api/audit-logs/export"),
+	)
+	var exported []response.AuditLog
+	scanner := bufio.NewScanner(exportResp)
+	for scanner.Scan() {
+		var auditLog response.AuditLog
+		s.Require().Nil(json.Unmarshal(scanner.Bytes(), &auditLog))
+		exported = append(exported, auditLog)
+	}
+	s.Require().Equal(3, len(exported))
+	s.Equal(*listResp.AuditLogs[0], exported[0])
+	s.Equal(*listResp.AuditLogs[1], exported[1])
+	s.Equal("/api/2.0/mlflow/experiments/create", exported[2].Path)
+
+	// render the list view.
+	var page goquery.Document
+	s.Require().Nil(
+		s.AdminClient().WithQuery(
+			request.AuditLogs{Actor: middleware.AnonymousActor},
+		).WithResponseType(
+			helpers.ResponseTypeHTML,
+		).WithResponse(
+			&page,
+		).DoRequest("/audit-logs/"),
+	)
+	s.Equal(4, page.Find("#auditLogs tbody tr").Length())
+	s.Empty(page.Find(".error-message").Text())
+}
+
+func (s *AuditLogTestSuite) Test_Error() {
+	tests := []struct {
+		name    string
+		request request.AuditLogs
+		error   *api.ErrorResponse
+	}{
+		{
+			name:    "NotFoundNamespace",
+			request: request.AuditLogs{Namespace: "not-existing"},
+			error:   api.NewResourceDoesNotExistError("unable to find namespace with code: not-existing"),
+		},
+		{
+			name:    "IncorrectLimit",
+			request: request.AuditLogs{Limit: 1001},
+			error:   api.NewInvalidParameterValueError("'limit' has to be between 0 and 1000"),
+		},
+		{
+			name:    "IncorrectPeriod",
+			request: request.AuditLogs{Since: 2, Until: 1},
+			error:   api.NewInvalidParameterValueError("'since' has to be less than 'until'"),
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.AdminClient().WithQuery(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest("/api/audit-logs"),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+			s.Equal(tt.error.StatusCode, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package token
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	"github.com/G-Research/fasttrackml/pkg/ui/admin/response"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type APITokenTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestAPITokenTestSuite(t *testing.T) {
+	suite.Run(t, new(APITokenTestSuite))
+}
+
+func (s *APITokenTestSuite) Test_Ok() {
+	_, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  2,
+		Code:                "namespace1",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+
+	// create new token.
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	createResp := response.CreateAPITokenResponse{}
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.APIToken{
+				Name:        "ci",
+				Description: "token for ci",
+				ExpiresAt:   &expiresAt,
+				Permissions: []request.APITokenPermission{
+					{Namespace: "default", Scope: "read"},
+					{Namespace: "namespace1", Scope: "write"},
+				},
+			},
+		).WithResponse(
+			&createResp,
+		).DoRequest("/api/tokens"),
+	)
+	s.Require().NotNil(createResp.Token)
+	s.Regexp(`^fml_[0-9a-f]{64}$`, createResp.Token.Token)
+	s.Equal("ci", createResp.Token.Name)
+	s.Equal("token for ci", createResp.Token.Description)
+	s.True(expiresAt.Equal(*createResp.Token.ExpiresAt))
+	s.ElementsMatch([]response.APITokenPermission{
+		{Namespace: "default", Scope: "read"},
+		{Namespace: "namespace1", Scope: "write"},
+	}, createResp.Token.Permissions)
+
+	// only hash of the token has to be stored.
+	listResp := response.ListAPITokensResponse{}
+	s.Require().Nil(s.AdminClient().WithResponse(&listResp).DoRequest("/api/tokens"))
+	s.Require().Equal(1, len(listResp.Tokens))
+	s.Equal(createResp.Token.ID, listResp.Tokens[0].ID)
+	s.Empty(listResp.Tokens[0].Token)
+
+	// revoke the token.
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodDelete,
+		).DoRequest("/api/tokens/%s", createResp.Token.ID),
+	)
+	listResp = response.ListAPITokensResponse{}
+	s.Require().Nil(s.AdminClient().WithResponse(&listResp).DoRequest("/api/tokens"))
+	s.Empty(listResp.Tokens)
+}
+
+func (s *APITokenTestSuite) Test_Error() {
+	past := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name    string
+		request request.APIToken
+		error   *api.ErrorResponse
+	}{
+		{
+			name: "EmptyName",
+			request: request.APIToken{
+				Permissions: []request.APITokenPermission{{Namespace: "default", Scope: "read"}},
+			},
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'name'"),
+		},
+		{
+			name: "ExpiredToken",
+			request: request.APIToken{
+				Name:        "ci",
+				ExpiresAt:   &past,
+				Permissions: []request.APITokenPermission{{Namespace: "default", Scope: "read"}},
+			},
+			error: api.NewInvalidParameterValueError("'expires_at' has to be in the future"),
+		},
+		{
+			name: "InvalidScope",
+			request: request.APIToken{
+				Name:        "ci",
+				Permissions: []request.APITokenPermission{{Namespace: "default", Scope: "admin"}},
+			},
+			error: api.NewInvalidParameterValueError(
+				"invalid scope 'admin' for namespace 'default'. Valid values are ['read', 'write']",
+			),
+		},
+		{
+			name: "NotFoundNamespace",
+			request: request.APIToken{
+				Name:        "ci",
+				Permissions: []request.APITokenPermission{{Namespace: "not-existing", Scope: "read"}},
+			},
+			error: api.NewResourceDoesNotExistError("unable to find namespace with code: not-existing"),
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			client := s.AdminClient()
+			s.Require().Nil(
+				client.WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest("/api/tokens"),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+			s.Equal(tt.error.StatusCode, client.GetStatusCode())
+		})
+	}
+
+	resp := api.ErrorResponse{}
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodDelete,
+		).WithResponse(
+			&resp,
+		).DoRequest("/api/tokens/%s", "2b1d6dca-0cf1-4a4d-9d4c-6d3c2f8c5a41"),
+	)
+	s.Equal(
+		"RESOURCE_DOES_NOT_EXIST: unable to find api token with id: 2b1d6dca-0cf1-4a4d-9d4c-6d3c2f8c5a41",
+		resp.Error(),
+	)
+}
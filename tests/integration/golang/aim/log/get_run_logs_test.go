@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 
 	"github.com/G-Research/fasttrackml/pkg/api/aim/encoding"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
 	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
 )
 
@@ -52,3 +54,157 @@ func (s *GetRunLogsTestSuite) Test_Ok() {
 		s.Require().Equal(fmt.Sprintf("value_%d", i), value)
 	}
 }
+
+func (s *GetRunLogsTestSuite) Test_Filters() {
+	run, err := s.RunFixtures.CreateExampleRun(context.Background(), s.DefaultExperiment)
+	s.Require().Nil(err)
+	otherRun, err := s.RunFixtures.CreateExampleRun(context.Background(), s.DefaultExperiment)
+	s.Require().Nil(err)
+
+	for i, value := range []string{"epoch 1", "epoch 2", "epoch 3", "epoch 4"} {
+		_, err := s.LogFixtures.CreateLog(context.Background(), &models.Log{
+			RunID: run.ID, Value: value, Timestamp: int64(100 + i),
+		})
+		s.Require().Nil(err)
+	}
+	_, err = s.LogFixtures.CreateLog(context.Background(), &models.Log{
+		RunID: otherRun.ID, Value: "other run", Timestamp: 100,
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name     string
+		query    map[any]any
+		expected map[string]any
+	}{
+		{
+			name:     "GetLogsSince",
+			query:    map[any]any{"since": 102},
+			expected: map[string]any{"3": "epoch 3", "4": "epoch 4"},
+		},
+		{
+			name:     "GetLogsSinceWithLimit",
+			query:    map[any]any{"since": 101, "limit": 1},
+			expected: map[string]any{"2": "epoch 2"},
+		},
+		{
+			name:     "GetLogsTail",
+			query:    map[any]any{"tail": 2},
+			expected: map[string]any{"3": "epoch 3", "4": "epoch 4"},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			s.Equal(tt.expected, s.getRunLogs(run.ID, tt.query))
+		})
+	}
+
+	s.Run("FollowLogs", func() {
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			_, err := s.LogFixtures.CreateLog(context.Background(), &models.Log{
+				RunID: run.ID, Value: "epoch 5", Timestamp: 104,
+			})
+			s.Nil(err)
+			time.Sleep(1500 * time.Millisecond)
+			run.Status = models.StatusFinished
+			s.Nil(s.RunFixtures.UpdateRun(context.Background(), run))
+		}()
+		s.Equal(
+			map[string]any{"4": "epoch 4", "5": "epoch 5"},
+			s.getRunLogs(run.ID, map[any]any{"tail": 1, "follow": true}),
+		)
+	})
+}
+
+func (s *GetRunLogsTestSuite) Test_Pages() {
+	run, err := s.RunFixtures.CreateExampleRun(context.Background(), s.DefaultExperiment)
+	s.Require().Nil(err)
+
+	// all the lines are logged in the same second, so `since` can't be used to read the next page.
+	for i := 1; i <= 25; i++ {
+		_, err := s.LogFixtures.CreateLog(context.Background(), &models.Log{
+			RunID: run.ID, Value: fmt.Sprintf("line %d", i), Timestamp: 100,
+		})
+		s.Require().Nil(err)
+	}
+
+	var afterID string
+	for _, page := range [][]int{{1, 10}, {11, 20}, {21, 25}} {
+		query := map[any]any{"since": 100, "limit": 10}
+		if afterID != "" {
+			query["after_id"] = afterID
+		}
+		resp := new(bytes.Buffer)
+		client := s.AIMClient().WithQuery(
+			query,
+		).WithResponseType(
+			helpers.ResponseTypeBuffer,
+		).WithResponse(
+			resp,
+		)
+		s.Require().Nil(client.DoRequest("/runs/%s/logs", run.ID))
+		decodedData, err := encoding.NewDecoder(resp).Decode()
+		s.Require().Nil(err)
+
+		expected := map[string]any{}
+		for i := page[0]; i <= page[1]; i++ {
+			expected[fmt.Sprintf("%d", i)] = fmt.Sprintf("line %d", i)
+		}
+		s.Equal(expected, decodedData)
+		afterID = client.GetResponseHeader("X-Last-Log-ID")
+	}
+	// the last page isn't full, so there is nothing to continue with.
+	s.Empty(afterID)
+}
+
+func (s *GetRunLogsTestSuite) Test_Error() {
+	tests := []struct {
+		name    string
+		query   map[any]any
+		message string
+	}{
+		{
+			name:    "GetLogsOfNotExistingRun",
+			message: "run 'not-existing-id' not found",
+		},
+		{
+			name:    "GetLogsWithLimitAndTail",
+			query:   map[any]any{"limit": 1, "tail": 1},
+			message: "Only one of parameters 'limit' and 'tail' can be supplied.",
+		},
+		{
+			name:    "GetLogsWithNegativeTail",
+			query:   map[any]any{"tail": -1},
+			message: "Invalid value for parameter 'tail' supplied.",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp api.ErrorResponse
+			client := s.AIMClient().WithResponse(&resp)
+			if tt.query != nil {
+				client = client.WithQuery(tt.query)
+			}
+			s.Require().Nil(client.DoRequest("/runs/%s/logs", "not-existing-id"))
+			s.Equal(http.StatusBadRequest, resp.StatusCode)
+			s.Equal(tt.message, resp.Message)
+		})
+	}
+}
+
+func (s *GetRunLogsTestSuite) getRunLogs(runID string, query map[any]any) map[string]any {
+	resp := new(bytes.Buffer)
+	s.Require().Nil(
+		s.AIMClient().WithQuery(
+			query,
+		).WithResponseType(
+			helpers.ResponseTypeBuffer,
+		).WithResponse(
+			resp,
+		).DoRequest("/runs/%s/logs", runID),
+	)
+	decodedData, err := encoding.NewDecoder(resp).Decode()
+	s.Require().Nil(err)
+	return decodedData
+}
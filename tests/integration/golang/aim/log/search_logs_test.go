@@ -0,0 +1,145 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SearchLogsTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSearchLogsTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchLogsTestSuite))
+}
+
+func (s *SearchLogsTestSuite) Test_Ok() {
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           uuid.New().String(),
+		NamespaceID:    s.DefaultNamespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	run, err := s.RunFixtures.CreateExampleRun(context.Background(), experiment)
+	s.Require().Nil(err)
+	otherRun, err := s.RunFixtures.CreateExampleRun(context.Background(), s.DefaultExperiment)
+	s.Require().Nil(err)
+
+	for i, value := range []string{"epoch 1", "epoch 2", "CUDA out of memory", "epoch 3"} {
+		_, err := s.LogFixtures.CreateLog(context.Background(), &models.Log{
+			RunID: run.ID, Value: value, Timestamp: int64(100 + i),
+		})
+		s.Require().Nil(err)
+	}
+	_, err = s.LogFixtures.CreateLog(context.Background(), &models.Log{
+		RunID: otherRun.ID, Value: "CUDA out of memory", Timestamp: 100,
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name     string
+		query    map[any]any
+		expected []response.SearchLogsResponse
+	}{
+		{
+			name:  "SearchExperimentLogsBySubstring",
+			query: map[any]any{"q": "CUDA out", "experiment_id": *experiment.ID},
+			expected: []response.SearchLogsResponse{
+				{
+					RunID:          run.ID,
+					RunName:        run.Name,
+					ExperimentID:   fmt.Sprintf("%d", *experiment.ID),
+					ExperimentName: experiment.Name,
+					Timestamp:      102,
+					Value:          "CUDA out of memory",
+				},
+			},
+		},
+		{
+			name:  "SearchRunLogsByRegex",
+			query: map[any]any{"q": "^epoch [23]$", "regex": true, "run_id": run.ID, "limit": 1},
+			expected: []response.SearchLogsResponse{
+				{
+					RunID:          run.ID,
+					RunName:        run.Name,
+					ExperimentID:   fmt.Sprintf("%d", *experiment.ID),
+					ExperimentName: experiment.Name,
+					Timestamp:      101,
+					Value:          "epoch 2",
+				},
+			},
+		},
+		{
+			name:     "SearchRunLogsSince",
+			query:    map[any]any{"q": "epoch 1", "run_id": run.ID, "since": 101},
+			expected: []response.SearchLogsResponse{},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp []response.SearchLogsResponse
+			s.Require().Nil(
+				s.AIMClient().WithQuery(
+					tt.query,
+				).WithResponse(
+					&resp,
+				).DoRequest("/runs/logs/search"),
+			)
+			s.Equal(tt.expected, resp)
+		})
+	}
+}
+
+func (s *SearchLogsTestSuite) Test_Error() {
+	tests := []struct {
+		name    string
+		query   map[any]any
+		message string
+	}{
+		{
+			name:    "SearchLogsWithoutQuery",
+			query:   map[any]any{"run_id": "id"},
+			message: "Missing value for required parameter 'q'.",
+		},
+		{
+			name:    "SearchLogsWithoutRunOrExperiment",
+			query:   map[any]any{"q": "error"},
+			message: "One of parameters 'run_id' and 'experiment_id' has to be supplied.",
+		},
+		{
+			name:  "SearchLogsWithInvalidRegex",
+			query: map[any]any{"q": "(error", "regex": true, "run_id": "id"},
+			message: "Invalid regular expression supplied in parameter 'q': error parsing regexp: " +
+				"missing closing ): `(error`",
+		},
+		{
+			name:    "SearchLogsWithTooBigLimit",
+			query:   map[any]any{"q": "error", "run_id": "id", "limit": 1001},
+			message: "Invalid value for parameter 'limit' supplied. Value has to be between 1 and 1000.",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp api.ErrorResponse
+			s.Require().Nil(
+				s.AIMClient().WithQuery(
+					tt.query,
+				).WithResponse(
+					&resp,
+				).DoRequest("/runs/logs/search"),
+			)
+			s.Equal(http.StatusBadRequest, resp.StatusCode)
+			s.Equal(tt.message, resp.Message)
+		})
+	}
+}
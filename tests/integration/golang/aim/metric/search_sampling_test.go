@@ -0,0 +1,205 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/aim/encoding"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SearchMetricsSamplingTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSearchMetricsSamplingTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchMetricsSamplingTestSuite))
+}
+
+func (s *SearchMetricsSamplingTestSuite) Test_Ok() {
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           uuid.New().String(),
+		LifecycleStage: models.LifecycleStageActive,
+		NamespaceID:    s.DefaultNamespace.ID,
+	})
+	s.Require().Nil(err)
+
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             "id1",
+		Name:           "TestRun1",
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ExperimentID:   *experiment.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	// create saw-like series of 200 points with a single spike.
+	for i := 0; i < 200; i++ {
+		value := float64(i % 10)
+		if i == 137 {
+			value = 100
+		}
+		_, err = s.MetricFixtures.CreateMetric(context.Background(), &models.Metric{
+			Key:       "TestMetric",
+			Value:     value,
+			Timestamp: int64(123456789 + i),
+			Step:      int64(i),
+			RunID:     run.ID,
+			Iter:      int64(i),
+		})
+		s.Require().Nil(err)
+	}
+	_, err = s.MetricFixtures.CreateLatestMetric(context.Background(), &models.LatestMetric{
+		Key:       "TestMetric",
+		Value:     9,
+		Timestamp: 123456789 + 199,
+		Step:      199,
+		RunID:     run.ID,
+		LastIter:  199,
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name           string
+		request        request.SearchMetricsRequest
+		verifyResponse func(values, iters []float64)
+	}{
+		{
+			name: "SearchMetricWithMinMaxSampling",
+			request: request.SearchMetricsRequest{
+				Metrics: []request.MetricTuple{
+					{
+						Key:     "TestMetric",
+						Context: fiber.Map{},
+					},
+				},
+				MaxPoints: 20,
+				Sampling:  "minmax",
+			},
+			verifyResponse: func(values, iters []float64) {
+				s.Equal(20, len(values))
+				s.Equal(20, len(iters))
+				s.Contains(values, float64(100))
+				s.Contains(values, float64(0))
+				s.Contains(values, float64(9))
+			},
+		},
+		{
+			name: "SearchMetricWithLTTBSampling",
+			request: request.SearchMetricsRequest{
+				Metrics: []request.MetricTuple{
+					{
+						Key:     "TestMetric",
+						Context: fiber.Map{},
+					},
+				},
+				MaxPoints: 20,
+			},
+			verifyResponse: func(values, iters []float64) {
+				s.Equal(20, len(values))
+				s.Equal(20, len(iters))
+				s.Equal(float64(0), iters[0])
+				s.Equal(float64(199), iters[len(iters)-1])
+				s.Contains(values, float64(100))
+			},
+		},
+		{
+			name: "SearchMetricWithMaxPointsGreaterThanSeries",
+			request: request.SearchMetricsRequest{
+				Metrics: []request.MetricTuple{
+					{
+						Key:     "TestMetric",
+						Context: fiber.Map{},
+					},
+				},
+				MaxPoints: 1000,
+				Sampling:  "minmax",
+			},
+			verifyResponse: func(values, iters []float64) {
+				s.Equal(200, len(values))
+				s.Equal(200, len(iters))
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := new(bytes.Buffer)
+			s.Require().Nil(
+				s.AIMClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponseType(
+					helpers.ResponseTypeBuffer,
+				).WithResponse(
+					resp,
+				).DoRequest("/runs/search/metric"),
+			)
+
+			decodedData, err := encoding.NewDecoder(resp).Decode()
+			s.Require().Nil(err)
+
+			values, ok := decodedData[run.ID+".traces.0.values.blob"].([]float64)
+			s.Require().True(ok)
+			iters, ok := decodedData[run.ID+".traces.0.iters.blob"].([]float64)
+			s.Require().True(ok)
+			tt.verifyResponse(values, iters)
+		})
+	}
+}
+
+func (s *SearchMetricsSamplingTestSuite) Test_Error() {
+	tests := []struct {
+		name    string
+		request request.SearchMetricsRequest
+		error   *api.ErrorResponse
+	}{
+		{
+			name: "SearchMetricWithIncorrectMaxPoints",
+			request: request.SearchMetricsRequest{
+				MaxPoints: -1,
+			},
+			error: &api.ErrorResponse{
+				Message:    "Invalid value for parameter 'max_points' supplied.",
+				StatusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "SearchMetricWithUnsupportedSampling",
+			request: request.SearchMetricsRequest{
+				MaxPoints: 10,
+				Sampling:  "average",
+			},
+			error: &api.ErrorResponse{
+				Message:    "Invalid value 'average' for parameter 'sampling'. Valid values are ['lttb', 'minmax']",
+				StatusCode: http.StatusBadRequest,
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp api.ErrorResponse
+			s.Require().Nil(
+				s.AIMClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest("/runs/search/metric"),
+			)
+			s.Equal(tt.error.Message, resp.Message)
+			s.Equal(tt.error.StatusCode, resp.StatusCode)
+		})
+	}
+}
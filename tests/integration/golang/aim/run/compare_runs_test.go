@@ -0,0 +1,353 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/aim/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type CompareRunsTestSuite struct {
+	helpers.BaseTestSuite
+	runs []*models.Run
+}
+
+func TestCompareRunsTestSuite(t *testing.T) {
+	suite.Run(t, new(CompareRunsTestSuite))
+}
+
+func (s *CompareRunsTestSuite) SetupTest() {
+	s.BaseTestSuite.SetupTest()
+
+	// create two runs with partially matching params, tags and metrics.
+	s.runs = make([]*models.Run, 2)
+	for i, name := range []string{"run1", "run2"} {
+		run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             name,
+			Name:           name,
+			Status:         models.StatusRunning,
+			SourceType:     "JOB",
+			ExperimentID:   *s.DefaultExperiment.ID,
+			LifecycleStage: models.LifecycleStageActive,
+		})
+		s.Require().Nil(err)
+		s.runs[i] = run
+	}
+
+	params := []models.Param{
+		{Key: "batch", ValueStr: common.GetPointer("32"), RunID: "run1"},
+		{Key: "batch", ValueStr: common.GetPointer("32"), RunID: "run2"},
+		{Key: "lr", ValueStr: common.GetPointer("0.1"), RunID: "run1"},
+		{Key: "lr", ValueStr: common.GetPointer("0.2"), RunID: "run2"},
+		{Key: "optimizer", ValueStr: common.GetPointer("adam"), RunID: "run2"},
+	}
+	for _, param := range params {
+		_, err := s.ParamFixtures.CreateParam(context.Background(), &param)
+		s.Require().Nil(err)
+	}
+
+	_, err := s.TagFixtures.CreateTag(context.Background(), &models.Tag{Key: "team", Value: "a", RunID: "run1"})
+	s.Require().Nil(err)
+
+	metrics := []models.Metric{
+		{Key: "loss", Value: 1.0, Step: 0, Iter: 1, RunID: "run1"},
+		{Key: "loss", Value: 0.5, Step: 1, Iter: 2, RunID: "run1"},
+		{Key: "loss", Value: 2.0, Step: 0, Iter: 1, RunID: "run2"},
+		{Key: "loss", Value: 0.5, Step: 2, Iter: 2, RunID: "run2"},
+		{Key: "accuracy", Value: 0.9, Step: 0, Iter: 1, RunID: "run1"},
+	}
+	for _, metric := range metrics {
+		_, err := s.MetricFixtures.CreateMetric(context.Background(), &metric)
+		s.Require().Nil(err)
+	}
+	latestMetrics := []models.LatestMetric{
+		{
+			Key: "loss", Value: 0.5, Step: 1, LastIter: 2, RunID: "run1",
+			LatestMetricSummary: models.LatestMetricSummary{
+				MinValue: common.GetPointer(0.5), MaxValue: common.GetPointer(1.0), SumValue: 1.5, ValueCount: 2,
+			},
+		},
+		{
+			Key: "loss", Value: 0.5, Step: 2, LastIter: 2, RunID: "run2",
+			LatestMetricSummary: models.LatestMetricSummary{
+				MinValue: common.GetPointer(0.5), MaxValue: common.GetPointer(2.0), SumValue: 2.5, ValueCount: 2,
+			},
+		},
+		{
+			Key: "accuracy", Value: 0.9, Step: 0, LastIter: 1, RunID: "run1",
+			LatestMetricSummary: models.LatestMetricSummary{
+				MinValue: common.GetPointer(0.9), MaxValue: common.GetPointer(0.9), SumValue: 0.9, ValueCount: 1,
+			},
+		},
+	}
+	for _, metric := range latestMetrics {
+		_, err := s.MetricFixtures.CreateLatestMetric(context.Background(), &metric)
+		s.Require().Nil(err)
+	}
+}
+
+func (s *CompareRunsTestSuite) Test_Ok() {
+	runs := []response.CompareRunsRunPartial{
+		{
+			ID:   "run1",
+			Name: "run1",
+			Experiment: response.GetRunInfoExperimentPartial{
+				ID:   "0",
+				Name: s.DefaultExperiment.Name,
+			},
+		},
+		{
+			ID:   "run2",
+			Name: "run2",
+			Experiment: response.GetRunInfoExperimentPartial{
+				ID:   "0",
+				Name: s.DefaultExperiment.Name,
+			},
+		},
+	}
+	params := []response.CompareRunsValuesPartial{
+		{Key: "batch", Values: []any{"32", "32"}},
+		{Key: "lr", Values: []any{"0.1", "0.2"}, Different: true},
+		{Key: "optimizer", Values: []any{nil, "adam"}, Different: true},
+	}
+	tags := []response.CompareRunsValuesPartial{
+		{Key: "team", Values: []any{"a", nil}, Different: true},
+	}
+
+	tests := []struct {
+		name     string
+		request  request.CompareRunsRequest
+		response response.CompareRunsResponse
+	}{
+		{
+			name: "CompareWithoutHistory",
+			request: request.CompareRunsRequest{
+				RunIDs: []string{"run1", "run2"},
+			},
+			response: response.CompareRunsResponse{
+				Runs:   runs,
+				Params: params,
+				Tags:   tags,
+				Metrics: []response.CompareRunsMetricPartial{
+					{
+						Name:    "loss",
+						Context: json.RawMessage(`{}`),
+						Latest:  []*float64{common.GetPointer(0.5), common.GetPointer(0.5)},
+						Min:     []*float64{common.GetPointer(0.5), common.GetPointer(0.5)},
+						Max:     []*float64{common.GetPointer(1.0), common.GetPointer(2.0)},
+					},
+				},
+			},
+		},
+		{
+			name: "CompareWithHistoryInReversedOrder",
+			request: request.CompareRunsRequest{
+				RunIDs:  []string{"run2", "run1"},
+				Metrics: []string{"loss"},
+				History: true,
+			},
+			response: response.CompareRunsResponse{
+				Runs: []response.CompareRunsRunPartial{runs[1], runs[0]},
+				Params: []response.CompareRunsValuesPartial{
+					{Key: "batch", Values: []any{"32", "32"}},
+					{Key: "lr", Values: []any{"0.2", "0.1"}, Different: true},
+					{Key: "optimizer", Values: []any{"adam", nil}, Different: true},
+				},
+				Tags: []response.CompareRunsValuesPartial{
+					{Key: "team", Values: []any{nil, "a"}, Different: true},
+				},
+				Metrics: []response.CompareRunsMetricPartial{
+					{
+						Name:    "loss",
+						Context: json.RawMessage(`{}`),
+						Latest:  []*float64{common.GetPointer(0.5), common.GetPointer(0.5)},
+						Min:     []*float64{common.GetPointer(0.5), common.GetPointer(0.5)},
+						Max:     []*float64{common.GetPointer(2.0), common.GetPointer(1.0)},
+						History: &response.CompareRunsMetricHistoryPartial{
+							Steps: []int64{0, 1, 2},
+							Values: [][]*float64{
+								{common.GetPointer(2.0), nil, common.GetPointer(0.5)},
+								{common.GetPointer(1.0), common.GetPointer(0.5), nil},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "CompareWithUnknownMetric",
+			request: request.CompareRunsRequest{
+				RunIDs:  []string{"run1", "run2"},
+				Metrics: []string{"accuracy"},
+				History: true,
+			},
+			response: response.CompareRunsResponse{
+				Runs:    runs,
+				Params:  params,
+				Tags:    tags,
+				Metrics: []response.CompareRunsMetricPartial{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp response.CompareRunsResponse
+			s.Require().Nil(
+				s.AIMClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"/runs/compare",
+				),
+			)
+			s.Equal(tt.response, resp)
+		})
+	}
+}
+
+func (s *CompareRunsTestSuite) Test_MaxPoints() {
+	// both runs log the same long history of a new metric.
+	for _, runID := range []string{"run1", "run2"} {
+		for i := 0; i < 200; i++ {
+			_, err := s.MetricFixtures.CreateMetric(context.Background(), &models.Metric{
+				Key: "f1", Value: float64(i % 7), Step: int64(i), Iter: int64(i), RunID: runID,
+			})
+			s.Require().Nil(err)
+		}
+		_, err := s.MetricFixtures.CreateLatestMetric(context.Background(), &models.LatestMetric{
+			Key: "f1", Value: float64(199 % 7), Step: 199, LastIter: 199, RunID: runID,
+		})
+		s.Require().Nil(err)
+	}
+
+	tests := []struct {
+		name     string
+		sampling string
+		check    func(steps int)
+	}{
+		{
+			name:     "CompareWithLTTBSampling",
+			sampling: "lttb",
+			check: func(steps int) {
+				s.Equal(10, steps)
+			},
+		},
+		{
+			name:     "CompareWithMinMaxSampling",
+			sampling: "minmax",
+			check: func(steps int) {
+				s.LessOrEqual(steps, 10)
+				s.Greater(steps, 1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp response.CompareRunsResponse
+			s.Require().Nil(
+				s.AIMClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					request.CompareRunsRequest{
+						RunIDs:    []string{"run1", "run2"},
+						Metrics:   []string{"f1"},
+						History:   true,
+						MaxPoints: 10,
+						Sampling:  tt.sampling,
+					},
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"/runs/compare",
+				),
+			)
+			s.Require().Len(resp.Metrics, 1)
+			history := resp.Metrics[0].History
+			s.Require().NotNil(history)
+			tt.check(len(history.Steps))
+			s.Equal(history.Values[0], history.Values[1])
+			s.Equal(int64(0), history.Steps[0])
+		})
+	}
+}
+
+func (s *CompareRunsTestSuite) Test_Error() {
+	tests := []struct {
+		name    string
+		request request.CompareRunsRequest
+		error   *api.ErrorResponse
+	}{
+		{
+			name: "CompareSingleRun",
+			request: request.CompareRunsRequest{
+				RunIDs: []string{"run1"},
+			},
+			error: &api.ErrorResponse{
+				Message:    "Invalid value for parameter 'run_ids' supplied. Between 2 and 100 runs can be compared.",
+				StatusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "CompareDuplicatedRuns",
+			request: request.CompareRunsRequest{
+				RunIDs: []string{"run1", "run1"},
+			},
+			error: &api.ErrorResponse{
+				Message:    "Invalid value for parameter 'run_ids' supplied. Run ids have to be unique and non-empty.",
+				StatusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "CompareWithTooManyPoints",
+			request: request.CompareRunsRequest{
+				RunIDs:    []string{"run1", "run2"},
+				History:   true,
+				MaxPoints: 1001,
+			},
+			error: &api.ErrorResponse{
+				Message:    "Invalid value for parameter 'max_points' supplied. It has to be between 0 and 1000.",
+				StatusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name: "CompareNonexistentRun",
+			request: request.CompareRunsRequest{
+				RunIDs: []string{"run1", "not-found-id"},
+			},
+			error: &api.ErrorResponse{
+				Message:    "run 'not-found-id' not found",
+				StatusCode: http.StatusBadRequest,
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp api.ErrorResponse
+			s.Require().Nil(
+				s.AIMClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"/runs/compare",
+				),
+			)
+			s.Equal(tt.error.Message, resp.Message)
+			s.Equal(tt.error.StatusCode, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/aim/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GroupRunsTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestGroupRunsTestSuite(t *testing.T) {
+	suite.Run(t, new(GroupRunsTestSuite))
+}
+
+func (s *GroupRunsTestSuite) SetupTest() {
+	s.BaseTestSuite.SetupTest()
+
+	for _, id := range []string{"run1", "run2", "run3", "run4"} {
+		_, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             id,
+			Name:           id,
+			Status:         models.StatusRunning,
+			SourceType:     "JOB",
+			ExperimentID:   *s.DefaultExperiment.ID,
+			LifecycleStage: models.LifecycleStageActive,
+		})
+		s.Require().Nil(err)
+	}
+
+	params := []models.Param{
+		{Key: "lr", ValueStr: common.GetPointer("0.1"), RunID: "run1"},
+		{Key: "lr", ValueStr: common.GetPointer("0.1"), RunID: "run2"},
+		{Key: "lr", ValueStr: common.GetPointer("0.2"), RunID: "run3"},
+	}
+	for _, param := range params {
+		_, err := s.ParamFixtures.CreateParam(context.Background(), &param)
+		s.Require().Nil(err)
+	}
+	for _, runID := range []string{"run1", "run3"} {
+		_, err := s.TagFixtures.CreateTag(context.Background(), &models.Tag{Key: "team", Value: "a", RunID: runID})
+		s.Require().Nil(err)
+	}
+
+	latestMetrics := []models.LatestMetric{
+		{
+			Key: "loss", Value: 0.5, Step: 1, LastIter: 2, RunID: "run1",
+			LatestMetricSummary: models.LatestMetricSummary{
+				MinValue: common.GetPointer(0.5), MaxValue: common.GetPointer(1.0), SumValue: 1.5, ValueCount: 2,
+			},
+		},
+		{
+			Key: "loss", Value: 0.3, Step: 1, LastIter: 2, RunID: "run2",
+			LatestMetricSummary: models.LatestMetricSummary{
+				MinValue: common.GetPointer(0.3), MaxValue: common.GetPointer(0.9), SumValue: 1.2, ValueCount: 2,
+			},
+		},
+		{
+			Key: "loss", Value: 0.75, Step: 0, LastIter: 1, RunID: "run3",
+			LatestMetricSummary: models.LatestMetricSummary{
+				MinValue: common.GetPointer(0.75), MaxValue: common.GetPointer(0.75), SumValue: 0.75, ValueCount: 1,
+			},
+		},
+		{
+			Key: "accuracy", Value: 0.9, Step: 0, LastIter: 1, RunID: "run3",
+			LatestMetricSummary: models.LatestMetricSummary{
+				MinValue: common.GetPointer(0.9), MaxValue: common.GetPointer(0.9), SumValue: 0.9, ValueCount: 1,
+			},
+		},
+	}
+	for _, metric := range latestMetrics {
+		_, err := s.MetricFixtures.CreateLatestMetric(context.Background(), &metric)
+		s.Require().Nil(err)
+	}
+}
+
+func (s *GroupRunsTestSuite) Test_Ok() {
+	tests := []struct {
+		name     string
+		request  request.GroupRunsRequest
+		response response.GroupRunsResponse
+	}{
+		{
+			name: "GroupByParam",
+			request: request.GroupRunsRequest{
+				GroupBy:       "params.lr",
+				ExperimentIDs: []int32{*s.DefaultExperiment.ID},
+			},
+			response: response.GroupRunsResponse{
+				GroupBy: "params.lr",
+				Groups: []response.GroupRunsGroupPartial{
+					{
+						Value:    "0.1",
+						RunCount: 2,
+						Metrics: []response.GroupRunsMetricPartial{
+							{
+								Name:       "loss",
+								Context:    json.RawMessage(`{}`),
+								Min:        common.GetPointer(0.3),
+								Max:        common.GetPointer(1.0),
+								Mean:       common.GetPointer((1.5 + 1.2) / 4),
+								LatestMean: common.GetPointer((0.5 + 0.3) / 2),
+								RunCount:   2,
+							},
+						},
+					},
+					{
+						Value:    nil,
+						RunCount: 1,
+						Metrics:  []response.GroupRunsMetricPartial{},
+					},
+					{
+						Value:    "0.2",
+						RunCount: 1,
+						Metrics: []response.GroupRunsMetricPartial{
+							{
+								Name:       "accuracy",
+								Context:    json.RawMessage(`{}`),
+								Min:        common.GetPointer(0.9),
+								Max:        common.GetPointer(0.9),
+								Mean:       common.GetPointer(0.9),
+								LatestMean: common.GetPointer(0.9),
+								RunCount:   1,
+							},
+							{
+								Name:       "loss",
+								Context:    json.RawMessage(`{}`),
+								Min:        common.GetPointer(0.75),
+								Max:        common.GetPointer(0.75),
+								Mean:       common.GetPointer(0.75),
+								LatestMean: common.GetPointer(0.75),
+								RunCount:   1,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "GroupByTagWithMetrics",
+			request: request.GroupRunsRequest{
+				GroupBy: "tags.team",
+				Metrics: []string{"loss"},
+			},
+			response: response.GroupRunsResponse{
+				GroupBy: "tags.team",
+				Groups: []response.GroupRunsGroupPartial{
+					{
+						Value:    nil,
+						RunCount: 2,
+						Metrics: []response.GroupRunsMetricPartial{
+							{
+								Name:       "loss",
+								Context:    json.RawMessage(`{}`),
+								Min:        common.GetPointer(0.3),
+								Max:        common.GetPointer(0.9),
+								Mean:       common.GetPointer(1.2 / 2),
+								LatestMean: common.GetPointer(0.3),
+								RunCount:   1,
+							},
+						},
+					},
+					{
+						Value:    "a",
+						RunCount: 2,
+						Metrics: []response.GroupRunsMetricPartial{
+							{
+								Name:       "loss",
+								Context:    json.RawMessage(`{}`),
+								Min:        common.GetPointer(0.5),
+								Max:        common.GetPointer(1.0),
+								Mean:       common.GetPointer((1.5 + 0.75) / 3),
+								LatestMean: common.GetPointer((0.5 + 0.75) / 2),
+								RunCount:   2,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp response.GroupRunsResponse
+			s.Require().Nil(
+				s.AIMClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"/runs/group",
+				),
+			)
+			s.Equal(tt.response, resp)
+		})
+	}
+}
+
+func (s *GroupRunsTestSuite) Test_Error() {
+	tests := []struct {
+		name    string
+		request request.GroupRunsRequest
+		error   *api.ErrorResponse
+	}{
+		{
+			name:    "GroupByWithoutEntity",
+			request: request.GroupRunsRequest{GroupBy: "lr"},
+			error: &api.ErrorResponse{
+				Message:    "Invalid value for parameter 'group_by' supplied. It has to be 'params.<key>' or 'tags.<key>'.",
+				StatusCode: http.StatusBadRequest,
+			},
+		},
+		{
+			name:    "GroupByMetric",
+			request: request.GroupRunsRequest{GroupBy: "metrics.loss"},
+			error: &api.ErrorResponse{
+				Message:    "Invalid value for parameter 'group_by' supplied. It has to be 'params.<key>' or 'tags.<key>'.",
+				StatusCode: http.StatusBadRequest,
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			var resp api.ErrorResponse
+			s.Require().Nil(
+				s.AIMClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"/runs/group",
+				),
+			)
+			s.Equal(tt.error.Message, resp.Message)
+			s.Equal(tt.error.StatusCode, resp.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package run
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/aim/encoding"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	mlflowRequest "github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SearchSequencesTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSearchSequencesTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchSequencesTestSuite))
+}
+
+func (s *SearchSequencesTestSuite) Test_Ok() {
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:             uuid.New().String(),
+		LifecycleStage:   models.LifecycleStageActive,
+		NamespaceID:      s.DefaultNamespace.ID,
+		ArtifactLocation: "s3://my-bucket",
+	})
+	s.Require().Nil(err)
+	run, err := s.RunFixtures.CreateExampleRun(context.Background(), experiment)
+	s.Require().Nil(err)
+
+	// log sequences through MLflow API.
+	requests := []mlflowRequest.LogArtifactRequest{
+		{RunID: run.ID, Name: "samples", Type: "texts", Text: "first", Step: 0, Index: 0},
+		{RunID: run.ID, Name: "samples", Type: "texts", Text: "second", Step: 0, Index: 1},
+		{RunID: run.ID, Name: "samples", Type: "texts", Text: "third", Step: 1, Index: 0},
+		{RunID: run.ID, Name: "speech", Type: "audios", BlobURI: "path/speech.wav", Format: "wav"},
+		{RunID: run.ID, Name: "chart", Type: "figures", BlobURI: "path/chart.json"},
+		{
+			RunID: run.ID,
+			Name:  "weights",
+			Type:  "distributions",
+			Distribution: &mlflowRequest.DistributionPartialRequest{
+				Weights:  []float64{1, 2, 3},
+				BinCount: 3,
+				Range:    []float64{-1, 1},
+			},
+		},
+		// image with the same name must not be a part of other sequences.
+		{RunID: run.ID, Name: "samples", BlobURI: "path/image.png", Format: "png"},
+	}
+	for _, req := range requests {
+		s.Require().Nil(
+			s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				req,
+			).DoRequest(
+				"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogArtifactRoute,
+			),
+		)
+	}
+
+	tests := []struct {
+		name     string
+		sequence string
+		query    string
+		expected map[string]any
+	}{
+		{
+			name:     "SearchTexts",
+			sequence: "texts",
+			query:    `texts.name == "samples"`,
+			expected: map[string]any{
+				"traces.0.name":            "samples",
+				"traces.0.values.0.0.data": "first",
+				"traces.0.values.0.1.data": "second",
+				"traces.0.values.1.0.data": "third",
+				"params.texts_per_step":    int64(1),
+			},
+		},
+		{
+			name:     "SearchAudios",
+			sequence: "audios",
+			query:    `audios.name == "speech"`,
+			expected: map[string]any{
+				"traces.0.name":                "speech",
+				"traces.0.values.0.0.blob_uri": "path/speech.wav",
+				"traces.0.values.0.0.format":   "wav",
+			},
+		},
+		{
+			name:     "SearchFigures",
+			sequence: "figures",
+			query:    `figures.name == "chart"`,
+			expected: map[string]any{
+				"traces.0.name":                "chart",
+				"traces.0.values.0.0.blob_uri": "path/chart.json",
+			},
+		},
+		{
+			name:     "SearchDistributions",
+			sequence: "distributions",
+			query:    `distributions.name == "weights"`,
+			expected: map[string]any{
+				"traces.0.name":                  "weights",
+				"traces.0.values.0.0.bin_count":  int64(3),
+				"traces.0.values.0.0.range.0":    float64(-1),
+				"traces.0.values.0.0.range.1":    float64(1),
+				"traces.0.values.0.0.data.shape": int64(3),
+				"traces.0.values.0.0.data.dtype": "float64",
+				"traces.0.values.0.0.data.blob":  []float64{1, 2, 3},
+				"traces.0.values.0.0.data.type":  "numpy",
+				"traces.0.values.0.0.blob_uri":   nil,
+				"traces.0.values.0.0.caption":    nil,
+				"traces.0.values.0.0.format":     nil,
+				"traces.0.values.0.0.index":      int64(0),
+				"traces.0.values.0.0.step":       int64(0),
+				"traces.0.values.0.0.iter":       int64(1),
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := new(bytes.Buffer)
+			s.Require().Nil(
+				s.AIMClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					request.SearchArtifactsRequest{Query: tt.query},
+				).WithResponseType(
+					helpers.ResponseTypeBuffer,
+				).WithResponse(
+					resp,
+				).DoRequest("/runs/search/%s", tt.sequence),
+			)
+
+			decodedData, err := encoding.NewDecoder(resp).Decode()
+			s.Require().Nil(err)
+			for key, value := range tt.expected {
+				key = fmt.Sprintf("%s.%s", run.ID, key)
+				if value == nil {
+					s.NotContains(decodedData, key)
+				} else {
+					s.Equal(value, decodedData[key], key)
+				}
+			}
+		})
+	}
+
+	// texts of the single run.
+	resp := new(bytes.Buffer)
+	s.Require().Nil(
+		s.AIMClient().WithMethod(
+			http.MethodPost,
+		).WithQuery(map[any]any{
+			"record_range": "1:2",
+		}).WithRequest(
+			[]request.GetRunArtifactsTraceRequest{{Name: "samples", Context: map[string]string{}}},
+		).WithResponseType(
+			helpers.ResponseTypeBuffer,
+		).WithResponse(
+			resp,
+		).DoRequest("/runs/%s/texts/get-batch", run.ID),
+	)
+	decodedData, err := encoding.NewDecoder(resp).Decode()
+	s.Require().Nil(err)
+	s.Equal("samples", decodedData["name"])
+	s.Equal("third", decodedData["values.0.0.data"])
+	s.Equal(int64(1), decodedData["iters.0"])
+	s.NotContains(decodedData, "values.1.0.data")
+
+	// project params contain names of the logged sequences.
+	var params map[string]map[string]any
+	s.Require().Nil(
+		s.AIMClient().WithQuery(map[any]any{
+			"experiments":    *experiment.ID,
+			"exclude_params": true,
+		}).WithResponse(
+			&params,
+		).DoRequest("/projects/params"),
+	)
+	s.Equal(map[string]any{"samples": []any{}}, params["images"])
+	s.Equal(map[string]any{"samples": []any{}}, params["texts"])
+	s.Equal(map[string]any{"speech": []any{}}, params["audios"])
+	s.Equal(map[string]any{"weights": []any{}}, params["distributions"])
+	s.Equal(map[string]any{"chart": []any{}}, params["figures"])
+}
+
+func (s *SearchSequencesTestSuite) Test_Error() {
+	var resp api.ErrorResponse
+	s.Require().Nil(
+		s.AIMClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			[]request.GetRunArtifactsTraceRequest{{Name: "samples"}},
+		).WithResponse(
+			&resp,
+		).DoRequest("/runs/%s/texts/get-batch", "not_existing-id"),
+	)
+	s.Equal("Not Found", resp.Message)
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+
+	resp = api.ErrorResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			mlflowRequest.LogArtifactRequest{RunID: "id", Type: "videos"},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogArtifactRoute,
+		),
+	)
+	s.Equal(api.ErrorCodeInvalidParameterValue, string(resp.ErrorCode))
+	s.Equal("Invalid value for parameter 'type' supplied", resp.Message)
+}
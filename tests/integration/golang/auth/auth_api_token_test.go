@@ -0,0 +1,269 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/zeebo/assert"
+	"gopkg.in/yaml.v3"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	mlflowResponse "github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/config/auth"
+	adminRequest "github.com/G-Research/fasttrackml/pkg/ui/admin/request"
+	adminResponse "github.com/G-Research/fasttrackml/pkg/ui/admin/response"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type APITokenAuthTestSuite struct {
+	helpers.BaseTestSuite
+	adminAuthHeader map[string]string
+}
+
+func TestAPITokenAuthTestSuite(t *testing.T) {
+	// create users configuration firstly.
+	data, err := yaml.Marshal(auth.YamlConfig{
+		Users: []auth.YamlUserConfig{
+			{
+				Name: "admin",
+				Roles: []string{
+					"admin",
+				},
+				Password: "adminpassword",
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	configPath := fmt.Sprintf("%s/users-config.yaml", t.TempDir())
+	// #nosec G304
+	f, err := os.Create(configPath)
+	assert.Nil(t, err)
+	_, err = f.Write(data)
+	assert.Nil(t, err)
+	assert.Nil(t, f.Close())
+
+	// run test suite with newly created configuration.
+	testSuite := new(APITokenAuthTestSuite)
+	testSuite.Config = config.Config{
+		Auth: auth.Config{
+			AuthUsersConfig: configPath,
+		},
+	}
+	testSuite.adminAuthHeader = map[string]string{
+		"Content-Type": "application/json",
+		"Authorization": fmt.Sprintf(
+			"Basic %s", base64.StdEncoding.EncodeToString([]byte("admin:adminpassword")),
+		),
+	}
+	assert.Nil(t, testSuite.Config.Validate())
+	suite.Run(t, testSuite)
+}
+
+func (s *APITokenAuthTestSuite) createAPIToken(req adminRequest.APIToken) *adminResponse.APIToken {
+	resp := adminResponse.CreateAPITokenResponse{}
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodPost,
+		).WithHeaders(
+			s.adminAuthHeader,
+		).WithRequest(
+			req,
+		).WithResponse(
+			&resp,
+		).DoRequest("/api/tokens"),
+	)
+	s.Require().NotNil(resp.Token)
+	return resp.Token
+}
+
+func (s *APITokenAuthTestSuite) TestAPITokenAuth_Ok() {
+	namespace1, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  2,
+		Code:                "namespace1",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+
+	readToken := s.createAPIToken(adminRequest.APIToken{
+		Name:        "read",
+		Permissions: []adminRequest.APITokenPermission{{Namespace: namespace1.Code, Scope: "read"}},
+	})
+	writeToken := s.createAPIToken(adminRequest.APIToken{
+		Name:        "write",
+		Permissions: []adminRequest.APITokenPermission{{Namespace: namespace1.Code, Scope: "write"}},
+	})
+
+	// check that read token could be used to read resources, passed either as Bearer or Basic Auth password.
+	for _, header := range []string{
+		fmt.Sprintf("Bearer %s", readToken.Token),
+		fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte("ci:"+readToken.Token))),
+	} {
+		searchResponse := mlflowResponse.SearchExperimentsResponse{}
+		s.Require().Nil(
+			s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithNamespace(
+				namespace1.Code,
+			).WithHeaders(map[string]string{
+				"Content-Type":  "application/json",
+				"Authorization": header,
+			}).WithRequest(
+				request.SearchExperimentsRequest{},
+			).WithResponse(
+				&searchResponse,
+			).DoRequest(
+				"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsSearchRoute,
+			),
+		)
+	}
+
+	// check that read token can't be used to modify resources.
+	errorResponse := api.ErrorResponse{}
+	client := s.MlflowClient()
+	s.Require().Nil(
+		client.WithMethod(
+			http.MethodPost,
+		).WithNamespace(
+			namespace1.Code,
+		).WithHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": fmt.Sprintf("Bearer %s", readToken.Token),
+		}).WithRequest(
+			request.CreateExperimentRequest{Name: "experiment"},
+		).WithResponse(
+			&errorResponse,
+		).DoRequest(
+			"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsCreateRoute,
+		),
+	)
+	s.Equal(http.StatusForbidden, client.GetStatusCode())
+	s.Equal(
+		"PERMISSION_DENIED: editor role is required to perform request in namespace with code: namespace1", errorResponse.Error(),
+	)
+
+	// check that write token can modify resources.
+	createResponse := mlflowResponse.CreateExperimentResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithNamespace(
+			namespace1.Code,
+		).WithHeaders(map[string]string{
+			"Content-Type":  "application/json",
+			"Authorization": fmt.Sprintf("Bearer %s", writeToken.Token),
+		}).WithRequest(
+			request.CreateExperimentRequest{Name: "experiment"},
+		).WithResponse(
+			&createResponse,
+		).DoRequest(
+			"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsCreateRoute,
+		),
+	)
+	s.NotEmpty(createResponse.ID)
+}
+
+func (s *APITokenAuthTestSuite) TestAPITokenAuth_Error() {
+	namespace1, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  2,
+		Code:                "namespace1",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+	namespace2, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  3,
+		Code:                "namespace2",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+
+	// token without access to namespace2.
+	token := s.createAPIToken(adminRequest.APIToken{
+		Name:        "write",
+		Permissions: []adminRequest.APITokenPermission{{Namespace: namespace1.Code, Scope: "write"}},
+	})
+
+	// expired token.
+	_, err = s.APITokenFixtures.CreateAPIToken(context.Background(), "fml_expired", &models.APIToken{
+		Name:      "expired",
+		ExpiresAt: common.GetPointer(time.Now().Add(-time.Hour)),
+		Permissions: []models.APITokenPermission{
+			{NamespaceID: namespace1.ID, Scope: models.APITokenScopeWrite},
+		},
+	})
+	s.Require().Nil(err)
+
+	// revoked token.
+	revokedToken := s.createAPIToken(adminRequest.APIToken{
+		Name:        "revoked",
+		Permissions: []adminRequest.APITokenPermission{{Namespace: namespace1.Code, Scope: "write"}},
+	})
+	s.Require().Nil(
+		s.AdminClient().WithMethod(
+			http.MethodDelete,
+		).WithHeaders(
+			s.adminAuthHeader,
+		).DoRequest("/api/tokens/%s", revokedToken.ID),
+	)
+
+	tests := []struct {
+		name      string
+		token     string
+		namespace string
+	}{
+		{
+			name:      "NamespaceNotInPermissions",
+			token:     token.Token,
+			namespace: namespace2.Code,
+		},
+		{
+			name:      "ExpiredToken",
+			token:     "fml_expired",
+			namespace: namespace1.Code,
+		},
+		{
+			name:      "RevokedToken",
+			token:     revokedToken.Token,
+			namespace: namespace1.Code,
+		},
+		{
+			name:      "UnknownToken",
+			token:     "fml_unknown",
+			namespace: namespace1.Code,
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			errorResponse := api.ErrorResponse{}
+			client := s.MlflowClient()
+			s.Require().Nil(
+				client.WithNamespace(
+					tt.namespace,
+				).WithHeaders(map[string]string{
+					"Content-Type":  "application/json",
+					"Authorization": fmt.Sprintf("Bearer %s", tt.token),
+				}).WithResponse(
+					&errorResponse,
+				).DoRequest(
+					"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsSearchRoute,
+				),
+			)
+			s.Equal(http.StatusNotFound, client.GetStatusCode())
+			s.Equal(
+				fmt.Sprintf("RESOURCE_DOES_NOT_EXIST: unable to find namespace with code: %s", tt.namespace),
+				errorResponse.Error(),
+			)
+		})
+	}
+}
@@ -0,0 +1,297 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/oauth2-proxy/mockoidc"
+	"github.com/stretchr/testify/suite"
+	"github.com/zeebo/assert"
+	"gopkg.in/yaml.v3"
+
+	aimRequest "github.com/G-Research/fasttrackml/pkg/api/aim/api/request"
+	aimResponse "github.com/G-Research/fasttrackml/pkg/api/aim/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	mlflowResponse "github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/config/auth"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers/oidc"
+)
+
+// namespaceRolesTestCase describes expected permissions of namespace role.
+type namespaceRolesTestCase struct {
+	name      string
+	withAuth  func(client *helpers.HttpClient) *helpers.HttpClient
+	canEdit   bool
+	canDelete bool
+}
+
+// checkNamespaceRoles makes check that each namespace role can perform only permitted requests.
+func checkNamespaceRoles(s *helpers.BaseTestSuite, namespace *models.Namespace, tests []namespaceRolesTestCase) {
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// every role is able to read data.
+			searchResponse := mlflowResponse.SearchExperimentsResponse{}
+			client := tt.withAuth(s.MlflowClient())
+			s.Require().Nil(
+				client.WithMethod(
+					http.MethodPost,
+				).WithNamespace(
+					namespace.Code,
+				).WithRequest(
+					request.SearchExperimentsRequest{},
+				).WithResponse(
+					&searchResponse,
+				).DoRequest(
+					"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsSearchRoute,
+				),
+			)
+			s.Equal(http.StatusOK, client.GetStatusCode())
+
+			// check permissions to create data.
+			experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+				Name:           fmt.Sprintf("%s-experiment", tt.name),
+				NamespaceID:    namespace.ID,
+				LifecycleStage: models.LifecycleStageActive,
+			})
+			s.Require().Nil(err)
+
+			// every role is able to compare runs.
+			runs, err := s.RunFixtures.CreateExampleRuns(context.Background(), experiment, 2)
+			s.Require().Nil(err)
+			compareResponse := aimResponse.CompareRunsResponse{}
+			client = tt.withAuth(s.AIMClient())
+			s.Require().Nil(
+				client.WithMethod(
+					http.MethodPost,
+				).WithNamespace(
+					namespace.Code,
+				).WithRequest(
+					aimRequest.CompareRunsRequest{RunIDs: []string{runs[0].ID, runs[1].ID}},
+				).WithResponse(
+					&compareResponse,
+				).DoRequest(
+					"/runs/compare/",
+				),
+			)
+			s.Equal(http.StatusOK, client.GetStatusCode())
+
+			// every role is able to group runs.
+			groupResponse := aimResponse.GroupRunsResponse{}
+			client = tt.withAuth(s.AIMClient())
+			s.Require().Nil(
+				client.WithMethod(
+					http.MethodPost,
+				).WithNamespace(
+					namespace.Code,
+				).WithRequest(
+					aimRequest.GroupRunsRequest{GroupBy: "params.key", ExperimentIDs: []int32{*experiment.ID}},
+				).WithResponse(
+					&groupResponse,
+				).DoRequest(
+					"/runs/group/",
+				),
+			)
+			s.Equal(http.StatusOK, client.GetStatusCode())
+
+			errorResponse := api.ErrorResponse{}
+			client = tt.withAuth(s.MlflowClient())
+			s.Require().Nil(
+				client.WithMethod(
+					http.MethodPost,
+				).WithNamespace(
+					namespace.Code,
+				).WithRequest(
+					request.CreateExperimentRequest{Name: fmt.Sprintf("%s-new-experiment", tt.name)},
+				).WithResponse(
+					&errorResponse,
+				).DoRequest(
+					"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsCreateRoute,
+				),
+			)
+			if tt.canEdit {
+				s.Equal(http.StatusOK, client.GetStatusCode())
+			} else {
+				s.Equal(http.StatusForbidden, client.GetStatusCode())
+				s.Equal(
+					fmt.Sprintf(
+						"PERMISSION_DENIED: editor role is required to perform request in namespace with code: %s",
+						namespace.Code,
+					),
+					errorResponse.Error(),
+				)
+			}
+
+			// check permissions to delete data.
+			errorResponse = api.ErrorResponse{}
+			client = tt.withAuth(s.MlflowClient())
+			s.Require().Nil(
+				client.WithMethod(
+					http.MethodPost,
+				).WithNamespace(
+					namespace.Code,
+				).WithRequest(
+					request.DeleteExperimentRequest{ID: fmt.Sprintf("%d", *experiment.ID)},
+				).WithResponse(
+					&errorResponse,
+				).DoRequest(
+					"%s%s", mlflow.ExperimentsRoutePrefix, mlflow.ExperimentsDeleteRoute,
+				),
+			)
+			if tt.canDelete {
+				s.Equal(http.StatusOK, client.GetStatusCode())
+			} else {
+				s.Equal(http.StatusForbidden, client.GetStatusCode())
+				s.Equal(
+					fmt.Sprintf(
+						"PERMISSION_DENIED: owner role is required to perform request in namespace with code: %s",
+						namespace.Code,
+					),
+					errorResponse.Error(),
+				)
+			}
+		})
+	}
+}
+
+type BasicAuthNamespaceRolesTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestBasicAuthNamespaceRolesTestSuite(t *testing.T) {
+	// create users configuration firstly.
+	data, err := yaml.Marshal(auth.YamlConfig{
+		Users: []auth.YamlUserConfig{
+			{
+				Name:     "viewer",
+				Roles:    []string{"ns:namespace1:viewer"},
+				Password: "viewerpassword",
+			},
+			{
+				Name:     "editor",
+				Roles:    []string{"ns:namespace1:editor"},
+				Password: "editorpassword",
+			},
+			{
+				Name:     "owner",
+				Roles:    []string{"ns:namespace1:owner"},
+				Password: "ownerpassword",
+			},
+			{
+				Name:     "legacy",
+				Roles:    []string{"ns:namespace1"},
+				Password: "legacypassword",
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	configPath := fmt.Sprintf("%s/users-config.yaml", t.TempDir())
+	assert.Nil(t, os.WriteFile(configPath, data, 0o600))
+
+	// run test suite with newly created configuration.
+	testSuite := new(BasicAuthNamespaceRolesTestSuite)
+	testSuite.Config = config.Config{
+		Auth: auth.Config{
+			AuthUsersConfig: configPath,
+		},
+	}
+	assert.Nil(t, testSuite.Config.Validate())
+	suite.Run(t, testSuite)
+}
+
+func (s *BasicAuthNamespaceRolesTestSuite) TestNamespaceRoles_Ok() {
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  2,
+		Code:                "namespace1",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+
+	withBasicAuth := func(user string) func(client *helpers.HttpClient) *helpers.HttpClient {
+		return func(client *helpers.HttpClient) *helpers.HttpClient {
+			return client.WithHeaders(map[string]string{
+				"Content-Type": "application/json",
+				"Authorization": fmt.Sprintf(
+					"Basic %s", base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%spassword", user, user))),
+				),
+			})
+		}
+	}
+	checkNamespaceRoles(&s.BaseTestSuite, namespace, []namespaceRolesTestCase{
+		{name: "Viewer", withAuth: withBasicAuth("viewer")},
+		{name: "Editor", withAuth: withBasicAuth("editor"), canEdit: true},
+		{name: "Owner", withAuth: withBasicAuth("owner"), canEdit: true, canDelete: true},
+		{name: "RoleWithoutLevel", withAuth: withBasicAuth("legacy"), canEdit: true, canDelete: true},
+	})
+}
+
+type OIDCNamespaceRolesTestSuite struct {
+	helpers.BaseTestSuite
+	oidcMockServer *oidc.MockServer
+}
+
+func TestOIDCNamespaceRolesTestSuite(t *testing.T) {
+	// create and run OIDC mock server.
+	oidcMockServer, err := oidc.NewMockServer()
+	assert.Nil(t, err)
+
+	// create a service configuration with OIDC enabled option.
+	testSuite := new(OIDCNamespaceRolesTestSuite)
+	cfg := config.Config{
+		Auth: auth.Config{
+			AuthOIDCScopes:           []string{"openid"},
+			AuthOIDCAdminRole:        "admin",
+			AuthOIDCClientID:         oidcMockServer.ClientID(),
+			AuthOIDCClaimRoles:       "groups",
+			AuthOIDCClientSecret:     oidcMockServer.ClientSecret(),
+			AuthOIDCProviderEndpoint: oidcMockServer.Address(),
+		},
+	}
+	assert.Nil(t, cfg.Validate())
+	testSuite.Config = cfg
+	testSuite.oidcMockServer = oidcMockServer
+	suite.Run(t, testSuite)
+}
+
+func (s *OIDCNamespaceRolesTestSuite) TestNamespaceRoles_Ok() {
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		ID:                  2,
+		Code:                "namespace1",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+
+	withOIDCGroup := func(group, level string) func(client *helpers.HttpClient) *helpers.HttpClient {
+		role := models.Role{Name: group}
+		s.Require().Nil(s.RolesFixtures.CreateRole(context.Background(), &role))
+		s.Require().Nil(
+			s.RolesFixtures.AttachNamespaceToRoleWithLevel(context.Background(), &role, namespace, level),
+		)
+		token, err := s.oidcMockServer.Login(
+			context.Background(),
+			&mockoidc.MockUser{
+				Email:  "test.user@example.com",
+				Groups: []string{group},
+			}, []string{"openid", "groups"},
+		)
+		s.Require().Nil(err)
+		return func(client *helpers.HttpClient) *helpers.HttpClient {
+			return client.WithCookie("access_token", token)
+		}
+	}
+	checkNamespaceRoles(&s.BaseTestSuite, namespace, []namespaceRolesTestCase{
+		{name: "Viewer", withAuth: withOIDCGroup("viewers", "viewer")},
+		{name: "Editor", withAuth: withOIDCGroup("editors", "editor"), canEdit: true},
+		{name: "Owner", withAuth: withOIDCGroup("owners", "owner"), canEdit: true, canDelete: true},
+	})
+}
@@ -14,6 +14,7 @@ import (
 	aimRequest "github.com/G-Research/fasttrackml/pkg/api/aim/api/request"
 	aimResponse "github.com/G-Research/fasttrackml/pkg/api/aim/api/response"
 	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
 	"github.com/G-Research/fasttrackml/pkg/database"
 	"github.com/G-Research/fasttrackml/pkg/server"
 	"github.com/G-Research/fasttrackml/tests/integration/golang/fixtures"
@@ -60,8 +61,10 @@ func (s *AimTestSuite) SetupSuite() {
 		DatabaseSlowThreshold: 1 * time.Second,
 		DatabaseMigrate:       true,
 		DefaultArtifactRoot:   s.T().TempDir(),
-		S3EndpointURI:         helpers.GetS3EndpointUri(),
-		GSEndpointURI:         helpers.GetGSEndpointUri(),
+		ArtifactStorageOptions: map[string]string{
+			storage.S3EndpointURIOption: helpers.GetS3EndpointUri(),
+			storage.GSEndpointURIOption: helpers.GetGSEndpointUri(),
+		},
 	})
 	s.Nil(err)
 	s.server = srv
@@ -16,6 +16,7 @@ import (
 	mlflowRequest "github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
 	mlflowResponse "github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
 	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
 	"github.com/G-Research/fasttrackml/pkg/database"
 	"github.com/G-Research/fasttrackml/pkg/server"
 	"github.com/G-Research/fasttrackml/tests/integration/golang/fixtures"
@@ -62,8 +63,10 @@ func (s *MLflowTestSuite) SetupSuite() {
 		DatabaseSlowThreshold: 1 * time.Second,
 		DatabaseMigrate:       true,
 		DefaultArtifactRoot:   s.T().TempDir(),
-		S3EndpointURI:         helpers.GetS3EndpointUri(),
-		GSEndpointURI:         helpers.GetGSEndpointUri(),
+		ArtifactStorageOptions: map[string]string{
+			storage.S3EndpointURIOption: helpers.GetS3EndpointUri(),
+			storage.GSEndpointURIOption: helpers.GetGSEndpointUri(),
+		},
 	})
 	s.Require().Nil(err)
 	s.server = srv
@@ -0,0 +1,158 @@
+package database
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/database"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+const (
+	aimRepositoryRunID         = "1b2c3d4e5f6a7b8c9d0e1f2a00000000"
+	aimRepositoryArchivedRunID = "0a1b2c3d4e5f6a7b8c9d0e1f00000000"
+	aimRepositoryTagID         = "8d5f1c9e-2b7a-4c3d-9e8f-1a2b3c4d5e6f"
+)
+
+type AimRepositoryTestSuite struct {
+	suite.Suite
+	root string
+	db   *gorm.DB
+}
+
+func TestAimRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(AimRepositoryTestSuite))
+}
+
+func (s *AimRepositoryTestSuite) SetupTest() {
+	dsn, err := helpers.GenerateDatabaseURI(s.T(), "sqlite")
+	s.Require().Nil(err)
+	db, err := database.NewDBProvider(dsn, 1*time.Second, 20)
+	s.Require().Nil(err)
+	s.Require().Nil(database.CheckAndMigrateDB(true, db.GormDB()))
+	s.Require().Nil(database.CreateDefaultNamespace(db.GormDB()))
+	s.Require().Nil(database.CreateDefaultExperiment(db.GormDB(), "s3://fasttrackml"))
+	s.db = db.GormDB()
+
+	// Aim repository is initialized in the project directory, which is provided to the import.
+	s.root = s.T().TempDir()
+	s.Require().Nil(os.Mkdir(filepath.Join(s.root, ".aim"), 0o755))
+	aimDB, err := gorm.Open(sqlite.Open(filepath.Join(s.root, ".aim", "run_metadata.sqlite")), &gorm.Config{})
+	s.Require().Nil(err)
+	for _, statement := range []string{
+		`CREATE TABLE experiment (
+			id INTEGER PRIMARY KEY, uuid VARCHAR(36), name TEXT, is_archived BOOLEAN,
+			created_at DATETIME, updated_at DATETIME, description TEXT
+		)`,
+		`CREATE TABLE run (
+			id INTEGER PRIMARY KEY, hash VARCHAR NOT NULL, name TEXT, description TEXT, is_archived BOOLEAN,
+			created_at DATETIME, updated_at DATETIME, finalized_at DATETIME, experiment_id INTEGER
+		)`,
+		`CREATE TABLE tag (
+			id INTEGER PRIMARY KEY, uuid VARCHAR(36), name TEXT, color TEXT, description TEXT, is_archived BOOLEAN,
+			created_at DATETIME, updated_at DATETIME
+		)`,
+		`CREATE TABLE run_tag (run_id INTEGER, tag_id INTEGER)`,
+		`INSERT INTO experiment VALUES
+			(1, 'f0a1b2c3-d4e5-4f6a-8b9c-0d1e2f3a4b5c', 'default', 0,
+				'2023-11-14 22:13:20.000000', '2023-11-14 22:13:20.000000', NULL),
+			(2, 'a0b1c2d3-e4f5-4a6b-8c9d-0e1f2a3b4c5d', 'my-experiment', 0,
+				'2023-11-14 22:13:20.001000', '2023-11-14 22:13:20.002000', 'experiment description')`,
+		`INSERT INTO run VALUES
+			(1, '1b2c3d4e5f6a7b8c9d0e1f2a', 'my-run', 'run description', 0,
+				'2023-11-14 22:13:20.000000', '2023-11-14 22:13:30.000000', '2023-11-14 22:13:30.000000', 2),
+			(2, '0a1b2c3d4e5f6a7b8c9d0e1f', 'archived-run', NULL, 1,
+				'2020-09-13 12:26:40.000000', '2020-09-13 12:26:50.000000', NULL, NULL)`,
+		`INSERT INTO tag VALUES
+			(1, '` + aimRepositoryTagID + `', 'baseline', '#ff0000', 'tag description', 0, NULL, NULL)`,
+		`INSERT INTO run_tag VALUES (1, 1)`,
+	} {
+		s.Require().Nil(aimDB.Exec(statement).Error)
+	}
+	sqlDB, err := aimDB.DB()
+	s.Require().Nil(err)
+	s.Require().Nil(sqlDB.Close())
+}
+
+func (s *AimRepositoryTestSuite) Test_Ok() {
+	importer := database.NewAimRepositoryImporter(s.db, s.root, database.WithAimRepositoryNamespace("aim"))
+	summary, err := importer.Import(context.Background())
+	s.Require().Nil(err)
+	s.Equal(&database.AimRepositorySummary{Experiments: 2, Runs: 2, Tags: 1}, summary)
+
+	var namespace models.Namespace
+	s.Require().Nil(s.db.Where("code = ?", "aim").First(&namespace).Error)
+
+	// the default experiment of Aim becomes the default experiment of the namespace.
+	var experiments []models.Experiment
+	s.Require().Nil(s.db.Where("namespace_id = ?", namespace.ID).Order("name").Find(&experiments).Error)
+	s.Require().Len(experiments, 2)
+	s.Equal("Default", experiments[0].Name)
+	s.Equal(*experiments[0].ID, *namespace.DefaultExperimentID)
+	s.Equal("my-experiment", experiments[1].Name)
+	s.Equal(models.LifecycleStageActive, experiments[1].LifecycleStage)
+	s.Equal(int64(1700000000001), experiments[1].CreationTime.Int64)
+	s.Equal(int64(1700000000002), experiments[1].LastUpdateTime.Int64)
+
+	var experimentTag models.ExperimentTag
+	s.Require().Nil(s.db.Where("experiment_id = ?", *experiments[1].ID).First(&experimentTag).Error)
+	s.Equal("mlflow.note.content", experimentTag.Key)
+	s.Equal("experiment description", experimentTag.Value)
+
+	var run models.Run
+	s.Require().Nil(s.db.Where("run_uuid = ?", aimRepositoryRunID).First(&run).Error)
+	s.Equal("my-run", run.Name)
+	s.Equal(*experiments[1].ID, run.ExperimentID)
+	s.Equal(models.StatusFinished, run.Status)
+	s.Equal(models.LifecycleStageActive, run.LifecycleStage)
+	s.Equal(int64(1700000000000), run.StartTime.Int64)
+	s.Equal(int64(1700000010000), run.EndTime.Int64)
+
+	var runTag models.Tag
+	s.Require().Nil(s.db.Where("run_uuid = ?", aimRepositoryRunID).First(&runTag).Error)
+	s.Equal("mlflow.note.content", runTag.Key)
+	s.Equal("run description", runTag.Value)
+
+	// archived run without experiment is imported as deleted run of the default experiment.
+	var archivedRun models.Run
+	s.Require().Nil(s.db.Where("run_uuid = ?", aimRepositoryArchivedRunID).First(&archivedRun).Error)
+	s.Equal("archived-run", archivedRun.Name)
+	s.Equal(*experiments[0].ID, archivedRun.ExperimentID)
+	s.Equal(models.StatusRunning, archivedRun.Status)
+	s.Equal(models.LifecycleStageDeleted, archivedRun.LifecycleStage)
+	s.Equal(int64(1600000010000), archivedRun.DeletedTime.Int64)
+	s.False(archivedRun.EndTime.Valid)
+
+	var sharedTag database.SharedTag
+	s.Require().Nil(s.db.Preload("Runs").Where("id = ?", aimRepositoryTagID).First(&sharedTag).Error)
+	s.Equal("baseline", sharedTag.Name)
+	s.Equal("#ff0000", sharedTag.Color)
+	s.Equal("tag description", sharedTag.Description)
+	s.Equal(namespace.ID, sharedTag.NamespaceID)
+	s.Require().Len(sharedTag.Runs, 1)
+	s.Equal(aimRepositoryRunID, sharedTag.Runs[0].ID)
+
+	// repeated import doesn't duplicate data.
+	_, err = importer.Import(context.Background())
+	s.Require().Nil(err)
+	var count int64
+	s.Require().Nil(s.db.Model(&models.Experiment{}).Where("namespace_id = ?", namespace.ID).Count(&count).Error)
+	s.Equal(int64(2), count)
+	s.Require().Nil(s.db.Model(&database.SharedTag{}).Where("namespace_id = ?", namespace.ID).Count(&count).Error)
+	s.Equal(int64(1), count)
+	s.Require().Nil(s.db.Table("run_shared_tags").Count(&count).Error)
+	s.Equal(int64(1), count)
+}
+
+func (s *AimRepositoryTestSuite) Test_Error() {
+	_, err := database.NewAimRepositoryImporter(s.db, s.T().TempDir()).Import(context.Background())
+	s.ErrorContains(err, "no aim repository found in")
+}
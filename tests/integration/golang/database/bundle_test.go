@@ -0,0 +1,338 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/database"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/fixtures"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type BundleTestSuite struct {
+	suite.Suite
+	sourceDB *gorm.DB
+	run      *models.Run
+}
+
+func TestBundleTestSuite(t *testing.T) {
+	suite.Run(t, new(BundleTestSuite))
+}
+
+func (s *BundleTestSuite) SetupTest() {
+	s.sourceDB = s.createDB()
+
+	namespaceFixtures, err := fixtures.NewNamespaceFixtures(s.sourceDB)
+	s.Require().Nil(err)
+	experimentFixtures, err := fixtures.NewExperimentFixtures(s.sourceDB)
+	s.Require().Nil(err)
+	runFixtures, err := fixtures.NewRunFixtures(s.sourceDB)
+	s.Require().Nil(err)
+	metricFixtures, err := fixtures.NewMetricFixtures(s.sourceDB)
+	s.Require().Nil(err)
+	logFixtures, err := fixtures.NewLogFixtures(s.sourceDB)
+	s.Require().Nil(err)
+	dashboardFixtures, err := fixtures.NewDashboardFixtures(s.sourceDB)
+	s.Require().Nil(err)
+	inputFixtures, err := fixtures.NewInputFixtures(s.sourceDB)
+	s.Require().Nil(err)
+	artifactFixtures, err := fixtures.NewArtifactFixtures(s.sourceDB)
+	s.Require().Nil(err)
+	registeredModelFixtures, err := fixtures.NewRegisteredModelFixtures(s.sourceDB)
+	s.Require().Nil(err)
+
+	// data of the default namespace must not be a part of the bundle.
+	experiment, err := experimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           uuid.NewString(),
+		NamespaceID:    1,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	_, err = runFixtures.CreateExampleRuns(context.Background(), experiment, 3)
+	s.Require().Nil(err)
+
+	namespace, err := namespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		Code:                "source",
+		Description:         "source namespace",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+	experiment, err = experimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           "Default",
+		NamespaceID:    namespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	namespace.DefaultExperimentID = experiment.ID
+	_, err = namespaceFixtures.UpdateNamespace(context.Background(), namespace)
+	s.Require().Nil(err)
+
+	_, err = runFixtures.CreateExampleRuns(context.Background(), experiment, 1)
+	s.Require().Nil(err)
+
+	// run with artifacts.
+	artifactURI := s.T().TempDir()
+	s.Require().Nil(os.MkdirAll(filepath.Join(artifactURI, "model"), 0o755))
+	s.Require().Nil(os.WriteFile(filepath.Join(artifactURI, "file.txt"), []byte("content"), 0o600))
+	s.Require().Nil(os.WriteFile(filepath.Join(artifactURI, "model", "model.pkl"), []byte("model content"), 0o600))
+	s.run, err = runFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.NewString(), "-", ""),
+		Name:           "run with artifacts",
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ExperimentID:   *experiment.ID,
+		ArtifactURI:    artifactURI,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	// metric with custom context.
+	_, err = metricFixtures.CreateMetric(context.Background(), &models.Metric{
+		Key:       "loss",
+		Value:     0.5,
+		Timestamp: 1234567890,
+		RunID:     s.run.ID,
+		Step:      1,
+		Iter:      1,
+		Context:   models.Context{Json: types.JSONB(`{"subset":"train"}`)},
+	})
+	s.Require().Nil(err)
+
+	_, err = logFixtures.CreateLog(context.Background(), &models.Log{
+		RunID:     s.run.ID,
+		Value:     "log line",
+		Timestamp: 1234567890,
+	})
+	s.Require().Nil(err)
+
+	_, err = dashboardFixtures.CreateDashboard(context.Background(), &database.Dashboard{
+		App: database.App{
+			Type:        "mpi",
+			State:       database.AppState{},
+			NamespaceID: namespace.ID,
+		},
+		Name: uuid.NewString(),
+	})
+	s.Require().Nil(err)
+
+	dataset, err := inputFixtures.CreateDataset(context.Background(), &models.Dataset{
+		Name:         "dataset",
+		Digest:       "digest",
+		SourceType:   "source-type",
+		Source:       "source",
+		ExperimentID: *experiment.ID,
+	})
+	s.Require().Nil(err)
+	_, err = inputFixtures.CreateInput(context.Background(), &models.Input{
+		DatasetID: dataset.ID,
+		RunID:     s.run.ID,
+		Tags:      []models.InputTag{{Key: "context", Value: "training"}},
+	})
+	s.Require().Nil(err)
+
+	_, err = artifactFixtures.CreateArtifact(context.Background(), &models.Artifact{
+		ID:      uuid.New(),
+		Name:    "image",
+		RunID:   s.run.ID,
+		BlobURI: "path/filename.png",
+		Format:  "png",
+	})
+	s.Require().Nil(err)
+
+	registeredModel, err := registeredModelFixtures.CreateRegisteredModel(context.Background(), &models.RegisteredModel{
+		Name:        "model",
+		NamespaceID: namespace.ID,
+		Tags:        []models.RegisteredModelTag{{Key: "key", Value: "value"}},
+		Aliases:     []models.RegisteredModelAlias{{Alias: "champion", Version: 1}},
+	})
+	s.Require().Nil(err)
+	_, err = registeredModelFixtures.CreateModelVersion(context.Background(), &models.ModelVersion{
+		Version:           1,
+		RegisteredModelID: registeredModel.ID,
+		CurrentStage:      models.ModelVersionStageNone,
+		Status:            models.ModelVersionStatusReady,
+		RunID:             s.run.ID,
+		Tags:              []models.ModelVersionTag{{Key: "key", Value: "value"}},
+	})
+	s.Require().Nil(err)
+}
+
+func (s *BundleTestSuite) Test_Ok() {
+	artifactStorageFactory, err := storage.NewArtifactStorageFactory(&config.Config{})
+	s.Require().Nil(err)
+
+	// export `source` namespace.
+	var bundle bytes.Buffer
+	manifest, err := database.NewExporter(
+		s.sourceDB, "source", database.WithExportArtifacts(artifactStorageFactory),
+	).Export(context.Background(), &bundle)
+	s.Require().Nil(err)
+	s.Equal(database.BundleFormat, manifest.Format)
+	s.Equal("source", manifest.Namespace.Code)
+	s.Equal("source namespace", manifest.Namespace.Description)
+	s.Equal(int64(1), manifest.Tables["experiments"])
+	s.Equal(int64(2), manifest.Tables["runs"])
+	s.Equal(int64(5), manifest.Tables["metrics"])
+	s.Equal(int64(1), manifest.Tables["logs"])
+	s.Equal(int64(1), manifest.Tables["dashboards"])
+	s.Equal(int64(1), manifest.Tables["artifacts"])
+	s.Equal(int64(1), manifest.Tables["datasets"])
+	s.Equal(int64(1), manifest.Tables["inputs"])
+	s.Equal(int64(1), manifest.Tables["input_tags"])
+	s.Equal(int64(1), manifest.Tables["registered_models"])
+	s.Equal(int64(1), manifest.Tables["registered_model_tags"])
+	s.Equal(int64(1), manifest.Tables["registered_model_aliases"])
+	s.Equal(int64(1), manifest.Tables["model_versions"])
+	s.Equal(int64(1), manifest.Tables["model_version_tags"])
+	s.ElementsMatch([]database.BundleArtifact{
+		{RunID: s.run.ID, Path: "file.txt", Size: 7},
+		{RunID: s.run.ID, Path: "model/model.pkl", Size: 13},
+	}, manifest.Artifacts)
+
+	// destination database already has some data, so identifiers will be different.
+	destinationDB := s.createDB()
+	experimentFixtures, err := fixtures.NewExperimentFixtures(destinationDB)
+	s.Require().Nil(err)
+	for i := 0; i < 3; i++ {
+		_, err = experimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+			Name:           uuid.NewString(),
+			NamespaceID:    1,
+			LifecycleStage: models.LifecycleStageActive,
+		})
+		s.Require().Nil(err)
+	}
+	s.Require().Nil(destinationDB.Create(&models.Context{Json: types.JSONB(`{"subset":"test"}`)}).Error)
+
+	// import bundle into `target` namespace.
+	artifactRoot := s.T().TempDir()
+	importer := database.NewBundleImporter(
+		destinationDB,
+		database.WithBundleNamespace("target"),
+		database.WithImportArtifacts(artifactStorageFactory, artifactRoot),
+	)
+	_, err = importer.Import(context.Background(), bytes.NewReader(bundle.Bytes()))
+	s.Require().Nil(err)
+
+	var namespace models.Namespace
+	s.Require().Nil(destinationDB.Where("code = ?", "target").First(&namespace).Error)
+	s.Equal("source namespace", namespace.Description)
+
+	var experiments []models.Experiment
+	s.Require().Nil(destinationDB.Where("namespace_id = ?", namespace.ID).Find(&experiments).Error)
+	s.Require().Len(experiments, 1)
+	s.Equal(*experiments[0].ID, *namespace.DefaultExperimentID)
+	s.Equal(filepath.Join(artifactRoot, "4"), experiments[0].ArtifactLocation)
+
+	var runs []models.Run
+	s.Require().Nil(destinationDB.Where("experiment_id = ?", *experiments[0].ID).Find(&runs).Error)
+	s.Len(runs, 2)
+
+	var run models.Run
+	s.Require().Nil(destinationDB.Where("run_uuid = ?", s.run.ID).First(&run).Error)
+	s.Equal(filepath.Join(artifactRoot, "4", s.run.ID, "artifacts"), run.ArtifactURI)
+
+	content, err := os.ReadFile(filepath.Join(run.ArtifactURI, "file.txt"))
+	s.Require().Nil(err)
+	s.Equal("content", string(content))
+	content, err = os.ReadFile(filepath.Join(run.ArtifactURI, "model", "model.pkl"))
+	s.Require().Nil(err)
+	s.Equal("model content", string(content))
+
+	var metric models.Metric
+	s.Require().Nil(destinationDB.Preload("Context").Where(
+		"run_uuid = ? AND key = ?", s.run.ID, "loss",
+	).First(&metric).Error)
+	s.JSONEq(`{"subset":"train"}`, string(metric.Context.Json))
+
+	var count int64
+	s.Require().Nil(destinationDB.Model(&models.Log{}).Where("run_uuid = ?", s.run.ID).Count(&count).Error)
+	s.Equal(int64(1), count)
+	s.Require().Nil(destinationDB.Model(&database.App{}).Where("namespace_id = ?", namespace.ID).Count(&count).Error)
+	s.Equal(int64(1), count)
+	s.Require().Nil(destinationDB.Model(&models.Artifact{}).Where("run_uuid = ?", s.run.ID).Count(&count).Error)
+	s.Equal(int64(1), count)
+
+	inputFixtures, err := fixtures.NewInputFixtures(destinationDB)
+	s.Require().Nil(err)
+	inputs, err := inputFixtures.GetByRunID(context.Background(), s.run.ID)
+	s.Require().Nil(err)
+	s.Require().Len(inputs, 1)
+	s.Equal(*experiments[0].ID, inputs[0].Dataset.ExperimentID)
+	s.Len(inputs[0].Tags, 1)
+
+	registeredModelFixtures, err := fixtures.NewRegisteredModelFixtures(destinationDB)
+	s.Require().Nil(err)
+	registeredModel, err := registeredModelFixtures.GetRegisteredModelByNamespaceIDAndName(
+		context.Background(), namespace.ID, "model",
+	)
+	s.Require().Nil(err)
+	s.Len(registeredModel.Tags, 1)
+	s.Len(registeredModel.Aliases, 1)
+	versions, err := registeredModelFixtures.GetModelVersionsByRegisteredModelID(
+		context.Background(), registeredModel.ID,
+	)
+	s.Require().Nil(err)
+	s.Require().Len(versions, 1)
+	s.Len(versions[0].Tags, 1)
+
+	// second import of the same bundle doesn't duplicate data and doesn't touch artifacts of existing runs.
+	movedArtifactURI := s.T().TempDir()
+	s.Require().Nil(destinationDB.Table("runs").Where(
+		"run_uuid = ?", s.run.ID,
+	).Update(
+		"artifact_uri", movedArtifactURI,
+	).Error)
+	s.Require().Nil(os.WriteFile(filepath.Join(run.ArtifactURI, "file.txt"), []byte("changed"), 0o600))
+	_, err = importer.Import(context.Background(), bytes.NewReader(bundle.Bytes()))
+	s.Require().Nil(err)
+	s.Require().Nil(destinationDB.Model(&models.Experiment{}).Where(
+		"namespace_id = ?", namespace.ID,
+	).Count(&count).Error)
+	s.Equal(int64(1), count)
+	s.Require().Nil(destinationDB.Model(&models.Metric{}).Where("run_uuid = ?", s.run.ID).Count(&count).Error)
+	s.Equal(int64(1), count)
+	s.Require().Nil(destinationDB.Model(&models.Log{}).Where("run_uuid = ?", s.run.ID).Count(&count).Error)
+	s.Equal(int64(1), count)
+	s.Require().Nil(destinationDB.Model(&models.ModelVersion{}).Count(&count).Error)
+	s.Equal(int64(1), count)
+	s.Require().Nil(destinationDB.Where("run_uuid = ?", s.run.ID).First(&run).Error)
+	s.Equal(movedArtifactURI, run.ArtifactURI)
+	content, err = os.ReadFile(filepath.Join(artifactRoot, "4", s.run.ID, "artifacts", "file.txt"))
+	s.Require().Nil(err)
+	s.Equal("changed", string(content))
+}
+
+func (s *BundleTestSuite) Test_Error() {
+	_, err := database.NewExporter(s.sourceDB, "unknown").Export(context.Background(), &bytes.Buffer{})
+	s.ErrorContains(err, "namespace unknown not found")
+
+	_, err = database.NewBundleImporter(s.sourceDB).Import(
+		context.Background(), bytes.NewReader([]byte("not a bundle")),
+	)
+	s.Error(err)
+}
+
+func (s *BundleTestSuite) createDB() *gorm.DB {
+	dsn, err := helpers.GenerateDatabaseURI(s.T(), "sqlite")
+	s.Require().Nil(err)
+	db, err := database.NewDBProvider(dsn, 1*time.Second, 20)
+	s.Require().Nil(err)
+	s.Require().Nil(database.CheckAndMigrateDB(true, db.GormDB()))
+	s.Require().Nil(database.CreateDefaultNamespace(db.GormDB()))
+	s.Require().Nil(database.CreateDefaultExperiment(db.GormDB(), "s3://fasttrackml"))
+	return db.GormDB()
+}
@@ -0,0 +1,340 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	aimRepositories "github.com/G-Research/fasttrackml/pkg/api/aim/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/dao"
+	commonModels "github.com/G-Research/fasttrackml/pkg/common/dao/models"
+	commonRepositories "github.com/G-Research/fasttrackml/pkg/common/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/events"
+	"github.com/G-Research/fasttrackml/pkg/database"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type EventBusTestSuite struct {
+	suite.Suite
+	db       *gorm.DB
+	ctx      context.Context
+	cancel   context.CancelFunc
+	eventBus *dao.EventListener
+}
+
+func TestEventBusTestSuite(t *testing.T) {
+	suite.Run(t, new(EventBusTestSuite))
+}
+
+func (s *EventBusTestSuite) SetupTest() {
+	dsn, err := helpers.GenerateDatabaseURI(s.T(), helpers.GetDatabaseBackend())
+	s.Require().Nil(err)
+	db, err := database.NewDBProvider(
+		dsn,
+		1*time.Second,
+		20,
+	)
+	s.Require().Nil(err)
+	s.Require().Nil(database.CheckAndMigrateDB(true, db.GormDB()))
+	s.Require().Nil(database.CreateDefaultNamespace(db.GormDB()))
+	s.db = db.GormDB()
+
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.eventBus, err = dao.NewEventBus(s.ctx, s.db)
+	s.Require().Nil(err)
+	s.Require().Nil(s.db.Use(dao.NewEntityEventsPlugin(s.eventBus)))
+}
+
+func (s *EventBusTestSuite) TearDownTest() {
+	s.cancel()
+}
+
+func (s *EventBusTestSuite) Test_NamespaceEvents() {
+	namespaceEvents := make(chan string, 10)
+	s.eventBus.Subscribe(events.NamespaceChannel, namespaceEvents)
+	s.eventBus.Listen()
+
+	namespaceRepository, err := repositories.NewNamespaceCachedRepository(
+		s.ctx, repositories.NewNamespaceRepository(s.db), s.eventBus,
+	)
+	s.Require().Nil(err)
+
+	namespace := models.Namespace{Code: "test", DefaultExperimentID: common.GetPointer(int32(0))}
+	s.Require().Nil(namespaceRepository.Create(s.ctx, &namespace))
+	event := s.receiveNamespaceEvent(namespaceEvents)
+	s.Equal(events.EventAction(events.EventActionCreated), event.Action)
+	s.Equal(namespace.ID, event.Namespace.ID)
+
+	namespace.Description = "updated"
+	s.Require().Nil(namespaceRepository.Update(s.ctx, &namespace))
+	event = s.receiveNamespaceEvent(namespaceEvents)
+	s.Equal(events.EventAction(events.EventActionUpdated), event.Action)
+	s.Equal("updated", event.Namespace.Description)
+
+	s.Require().Nil(namespaceRepository.Delete(s.ctx, &namespace))
+	event = s.receiveNamespaceEvent(namespaceEvents)
+	s.Equal(events.EventAction(events.EventActionDeleted), event.Action)
+	s.Equal("test", event.Namespace.Code)
+}
+
+func (s *EventBusTestSuite) Test_EntityEvents() {
+	tests := []struct {
+		name    string
+		channel string
+		// create creates new entity and returns its id, rename and delete change it.
+		create func() string
+		rename func(id string) error
+		delete func(id string) error
+	}{
+		{
+			name:    "Roles",
+			channel: events.RoleChannel,
+			create: func() string {
+				role := models.Role{Name: "ns:test"}
+				s.Require().Nil(s.db.Create(&role).Error)
+				return fmt.Sprint(role.ID)
+			},
+			rename: func(id string) error {
+				return s.db.Model(&models.Role{}).Where("id = ?", id).Update("Name", "ns:updated").Error
+			},
+			delete: func(id string) error {
+				return s.db.Where("id = ?", id).Delete(&models.Role{}).Error
+			},
+		},
+		{
+			name:    "Experiments",
+			channel: events.ExperimentChannel,
+			create: func() string {
+				experiment := models.Experiment{
+					Name:           "Test Experiment",
+					NamespaceID:    1,
+					LifecycleStage: models.LifecycleStageActive,
+				}
+				s.Require().Nil(s.db.Create(&experiment).Error)
+				return fmt.Sprint(*experiment.ID)
+			},
+			rename: func(id string) error {
+				return s.db.Model(&models.Experiment{}).Where("experiment_id = ?", id).Update("Name", "Updated").Error
+			},
+			delete: func(id string) error {
+				return s.db.Where("experiment_id = ?", id).Delete(&models.Experiment{}).Error
+			},
+		},
+		{
+			name:    "Dashboards",
+			channel: events.DashboardChannel,
+			create: func() string {
+				app := database.App{Type: "metrics", NamespaceID: 1}
+				s.Require().Nil(s.db.Create(&app).Error)
+				dashboard := database.Dashboard{Name: "Test Dashboard", AppID: &app.ID}
+				s.Require().Nil(s.db.Omit("App").Create(&dashboard).Error)
+				return dashboard.ID.String()
+			},
+			rename: func(id string) error {
+				return s.db.Model(&database.Dashboard{}).Where("id = ?", id).Update("Name", "Updated").Error
+			},
+			delete: func(id string) error {
+				return s.db.Where("id = ?", id).Delete(&database.Dashboard{}).Error
+			},
+		},
+	}
+
+	channels := make(map[string]chan string, len(tests))
+	for _, tt := range tests {
+		channels[tt.channel] = make(chan string, 10)
+		s.eventBus.Subscribe(tt.channel, channels[tt.channel])
+	}
+	s.eventBus.Listen()
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			ch := channels[tt.channel]
+
+			id := tt.create()
+			s.Equal(
+				events.EntityEvent{Action: events.EventActionCreated, IDs: []string{id}},
+				s.receiveEntityEvent(ch),
+			)
+
+			// entities changed by the condition are unknown, so ids are empty.
+			s.Require().Nil(tt.rename(id))
+			s.Equal(events.EntityEvent{Action: events.EventActionUpdated}, s.receiveEntityEvent(ch))
+
+			s.Require().Nil(tt.delete(id))
+			s.Equal(events.EntityEvent{Action: events.EventActionDeleted}, s.receiveEntityEvent(ch))
+
+			// every change is published only into the channel of its table.
+			for channel, other := range channels {
+				s.Empty(other, "unexpected event in %s channel", channel)
+			}
+		})
+	}
+}
+
+func (s *EventBusTestSuite) Test_NamespaceCache() {
+	// two cached repositories sharing the same event bus act like two replicas.
+	replica1, err := repositories.NewNamespaceCachedRepository(
+		s.ctx, repositories.NewNamespaceRepository(s.db), s.eventBus,
+	)
+	s.Require().Nil(err)
+	replica2, err := repositories.NewNamespaceCachedRepository(
+		s.ctx, repositories.NewNamespaceRepository(s.db), s.eventBus,
+	)
+	s.Require().Nil(err)
+	s.eventBus.Listen()
+
+	namespace, err := replica2.GetByCode(s.ctx, models.DefaultNamespaceCode)
+	s.Require().Nil(err)
+	s.Equal("Default namespace", namespace.Description)
+
+	namespace.Description = "Updated namespace"
+	s.Require().Nil(replica1.Update(s.ctx, namespace))
+	s.Eventually(func() bool {
+		namespace, err := replica2.GetByCode(s.ctx, models.DefaultNamespaceCode)
+		return err == nil && namespace.Description == "Updated namespace"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	s.Require().Nil(replica1.Delete(s.ctx, namespace))
+	s.Eventually(func() bool {
+		namespace, err := replica2.GetByCode(s.ctx, models.DefaultNamespaceCode)
+		return err == nil && namespace == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func (s *EventBusTestSuite) Test_RoleCache() {
+	roleRepository, err := commonRepositories.NewRoleCachedRepository(s.ctx, s.db, s.eventBus)
+	s.Require().Nil(err)
+	s.eventBus.Listen()
+
+	level, err := roleRepository.GetNamespaceRoleByRoles(s.ctx, []string{"ns:default"}, models.DefaultNamespaceCode)
+	s.Require().Nil(err)
+	s.Equal(commonModels.NamespaceRole(""), level)
+
+	role := models.Role{Name: "ns:default"}
+	s.Require().Nil(s.db.Create(&role).Error)
+	s.Require().Nil(s.db.Create(&models.RoleNamespace{
+		RoleID:      role.ID,
+		NamespaceID: 1,
+		Level:       string(commonModels.NamespaceRoleViewer),
+	}).Error)
+	s.Eventually(func() bool {
+		level, err := roleRepository.GetNamespaceRoleByRoles(
+			s.ctx, []string{"ns:default"}, models.DefaultNamespaceCode,
+		)
+		return err == nil && level == commonModels.NamespaceRoleViewer
+	}, 5*time.Second, 10*time.Millisecond)
+
+	s.Require().Nil(s.db.Model(
+		&models.RoleNamespace{},
+	).Where(
+		"role_id = ?", role.ID,
+	).Update(
+		"Level", string(commonModels.NamespaceRoleOwner),
+	).Error)
+	s.Eventually(func() bool {
+		level, err := roleRepository.GetNamespaceRoleByRoles(
+			s.ctx, []string{"ns:default"}, models.DefaultNamespaceCode,
+		)
+		return err == nil && level == commonModels.NamespaceRoleOwner
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func (s *EventBusTestSuite) Test_ExperimentCache() {
+	experimentRepository, err := repositories.NewExperimentCachedRepository(
+		s.ctx, repositories.NewExperimentRepository(s.db), s.eventBus,
+	)
+	s.Require().Nil(err)
+	s.eventBus.Listen()
+
+	experiment := models.Experiment{
+		Name:           "Test Experiment",
+		NamespaceID:    1,
+		LifecycleStage: models.LifecycleStageActive,
+	}
+	s.Require().Nil(s.db.Create(&experiment).Error)
+	cached, err := experimentRepository.GetByNamespaceIDAndExperimentID(s.ctx, 1, *experiment.ID)
+	s.Require().Nil(err)
+	s.Equal("Test Experiment", cached.Name)
+
+	// experiment is changed bypassing the cached repository, like another replica would do.
+	s.Require().Nil(s.db.Model(&experiment).Update("Name", "Updated Experiment").Error)
+	s.Eventually(func() bool {
+		cached, err := experimentRepository.GetByNamespaceIDAndExperimentID(s.ctx, 1, *experiment.ID)
+		return err == nil && cached.Name == "Updated Experiment"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	s.Require().Nil(s.db.Create(&models.ExperimentTag{
+		ExperimentID: *experiment.ID, Key: "key", Value: "value",
+	}).Error)
+	s.Eventually(func() bool {
+		cached, err := experimentRepository.GetByNamespaceIDAndExperimentID(s.ctx, 1, *experiment.ID)
+		return err == nil && len(cached.Tags) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	s.Require().Nil(s.db.Delete(&experiment).Error)
+	s.Eventually(func() bool {
+		_, err := experimentRepository.GetByNamespaceIDAndExperimentID(s.ctx, 1, *experiment.ID)
+		return err != nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func (s *EventBusTestSuite) Test_DashboardCache() {
+	dashboardRepository, err := aimRepositories.NewDashboardCachedRepository(
+		s.ctx, aimRepositories.NewDashboardRepository(s.db), s.eventBus,
+	)
+	s.Require().Nil(err)
+	s.eventBus.Listen()
+
+	app := database.App{Type: "metrics", NamespaceID: 1}
+	s.Require().Nil(s.db.Create(&app).Error)
+	dashboard := database.Dashboard{Name: "Test Dashboard", AppID: &app.ID}
+	s.Require().Nil(s.db.Omit("App").Create(&dashboard).Error)
+	cached, err := dashboardRepository.GetByNamespaceIDAndDashboardID(s.ctx, 1, dashboard.ID.String())
+	s.Require().Nil(err)
+	s.Equal("Test Dashboard", cached.Name)
+
+	// dashboard is changed bypassing the cached repository, like another replica would do.
+	s.Require().Nil(s.db.Model(&dashboard).Update("Name", "Updated Dashboard").Error)
+	s.Eventually(func() bool {
+		cached, err := dashboardRepository.GetByNamespaceIDAndDashboardID(s.ctx, 1, dashboard.ID.String())
+		return err == nil && cached.Name == "Updated Dashboard"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	s.Require().Nil(s.db.Model(&dashboard).Update("IsArchived", true).Error)
+	s.Eventually(func() bool {
+		cached, err := dashboardRepository.GetByNamespaceIDAndDashboardID(s.ctx, 1, dashboard.ID.String())
+		return err == nil && cached == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func (s *EventBusTestSuite) receiveEntityEvent(ch <-chan string) events.EntityEvent {
+	select {
+	case data := <-ch:
+		var event events.EntityEvent
+		s.Require().Nil(json.Unmarshal([]byte(data), &event))
+		return event
+	case <-time.After(5 * time.Second):
+		s.FailNow("event has not been received")
+	}
+	return events.EntityEvent{}
+}
+
+func (s *EventBusTestSuite) receiveNamespaceEvent(ch <-chan string) events.NamespaceEvent {
+	select {
+	case data := <-ch:
+		var event events.NamespaceEvent
+		s.Require().Nil(json.Unmarshal([]byte(data), &event))
+		return event
+	case <-time.After(5 * time.Second):
+		s.FailNow("event has not been received")
+	}
+	return events.NamespaceEvent{}
+}
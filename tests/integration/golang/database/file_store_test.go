@@ -0,0 +1,226 @@
+package database
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/database"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+const (
+	fileStoreRunID        = "5f3a0c1a7b6e4d2f9a8b7c6d5e4f3a2b"
+	fileStoreDeletedRunID = "0b1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e"
+)
+
+type FileStoreTestSuite struct {
+	suite.Suite
+	root string
+	db   *gorm.DB
+}
+
+func TestFileStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(FileStoreTestSuite))
+}
+
+func (s *FileStoreTestSuite) SetupTest() {
+	dsn, err := helpers.GenerateDatabaseURI(s.T(), "sqlite")
+	s.Require().Nil(err)
+	db, err := database.NewDBProvider(dsn, 1*time.Second, 20)
+	s.Require().Nil(err)
+	s.Require().Nil(database.CheckAndMigrateDB(true, db.GormDB()))
+	s.Require().Nil(database.CreateDefaultNamespace(db.GormDB()))
+	s.Require().Nil(database.CreateDefaultExperiment(db.GormDB(), "s3://fasttrackml"))
+	s.db = db.GormDB()
+
+	// file store is created by MLflow in a different location, so artifact URIs don't exist anymore.
+	s.root = s.T().TempDir()
+	s.writeFile("0/meta.yaml", `artifact_location: file:///home/user/mlruns/0
+creation_time: 1700000000000
+experiment_id: '0'
+last_update_time: 1700000000000
+lifecycle_stage: active
+name: Default
+`)
+	s.writeFile("123456789012345678/meta.yaml", `artifact_location: file:///home/user/mlruns/123456789012345678
+creation_time: 1700000000001
+experiment_id: '123456789012345678'
+last_update_time: 1700000000002
+lifecycle_stage: active
+name: my-experiment
+`)
+	s.writeFile("123456789012345678/tags/mlflow.note.content", "experiment description")
+	s.writeFile("123456789012345678/"+fileStoreRunID+"/meta.yaml", `artifact_uri: `+
+		`file:///home/user/mlruns/123456789012345678/`+fileStoreRunID+`/artifacts
+end_time: 1700000010000
+entry_point_name: ''
+experiment_id: '123456789012345678'
+lifecycle_stage: active
+run_id: `+fileStoreRunID+`
+run_name: my-run
+run_uuid: `+fileStoreRunID+`
+source_name: ''
+source_type: 4
+source_version: ''
+start_time: 1700000000000
+status: 3
+tags: []
+user_id: user
+`)
+	s.writeFile("123456789012345678/"+fileStoreRunID+"/params/lr", "0.01")
+	s.writeFile("123456789012345678/"+fileStoreRunID+"/params/optimizer/name", "adam")
+	s.writeFile("123456789012345678/"+fileStoreRunID+"/tags/mlflow.runName", "my-run")
+	s.writeFile("123456789012345678/"+fileStoreRunID+"/metrics/loss", `1700000000100 0.9 0
+1700000000200 0.5 1
+1700000000300 nan 2
+1700000000400 0.1 3
+`)
+	s.writeFile("123456789012345678/"+fileStoreRunID+"/metrics/train/accuracy", `1700000000100 0.5 0
+1700000000200 inf 1
+`)
+	s.writeFile("123456789012345678/"+fileStoreRunID+"/artifacts/model/model.pkl", "model content")
+
+	// deleted experiment with the run created by older MLflow version.
+	s.writeFile(".trash/7/meta.yaml", `artifact_location: ./mlruns/7
+experiment_id: '7'
+lifecycle_stage: deleted
+name: deleted-experiment
+`)
+	s.writeFile(".trash/7/"+fileStoreDeletedRunID+"/meta.yaml", `artifact_uri: `+
+		`./mlruns/7/`+fileStoreDeletedRunID+`/artifacts
+end_time: null
+entry_point_name: ''
+experiment_id: '7'
+lifecycle_stage: deleted
+run_uuid: `+fileStoreDeletedRunID+`
+source_name: ''
+source_type: 4
+source_version: ''
+start_time: 1600000000000
+status: 1
+user_id: user
+`)
+	s.writeFile(".trash/7/"+fileStoreDeletedRunID+"/tags/mlflow.runName", "old-run")
+	s.writeFile(".trash/7/"+fileStoreDeletedRunID+"/metrics/loss", "1600000000100 0.7\n")
+}
+
+func (s *FileStoreTestSuite) Test_Ok() {
+	artifactStorageFactory, err := storage.NewArtifactStorageFactory(&config.Config{})
+	s.Require().Nil(err)
+	artifactRoot := s.T().TempDir()
+
+	importer := database.NewFileStoreImporter(
+		s.db,
+		s.root,
+		database.WithFileStoreNamespace("mlruns"),
+		database.WithFileStoreArtifacts(artifactStorageFactory, artifactRoot),
+	)
+	summary, err := importer.Import(context.Background())
+	s.Require().Nil(err)
+	s.Equal(&database.FileStoreSummary{Experiments: 3, Runs: 2}, summary)
+
+	var namespace models.Namespace
+	s.Require().Nil(s.db.Where("code = ?", "mlruns").First(&namespace).Error)
+
+	var experiments []models.Experiment
+	s.Require().Nil(s.db.Where("namespace_id = ?", namespace.ID).Order("name").Find(&experiments).Error)
+	s.Require().Len(experiments, 3)
+	s.Equal("Default", experiments[0].Name)
+	s.Equal(*experiments[0].ID, *namespace.DefaultExperimentID)
+	s.Equal("deleted-experiment", experiments[1].Name)
+	s.Equal(models.LifecycleStageDeleted, experiments[1].LifecycleStage)
+	s.Equal("my-experiment", experiments[2].Name)
+	s.Equal(int64(1700000000001), experiments[2].CreationTime.Int64)
+
+	var tag models.ExperimentTag
+	s.Require().Nil(s.db.Where("experiment_id = ?", *experiments[2].ID).First(&tag).Error)
+	s.Equal("mlflow.note.content", tag.Key)
+	s.Equal("experiment description", tag.Value)
+
+	var run models.Run
+	s.Require().Nil(s.db.Where("run_uuid = ?", fileStoreRunID).First(&run).Error)
+	s.Equal("my-run", run.Name)
+	s.Equal(*experiments[2].ID, run.ExperimentID)
+	s.Equal(models.StatusFinished, run.Status)
+	s.Equal("LOCAL", run.SourceType)
+	s.Equal("user", run.UserID)
+	s.Equal(int64(1700000000000), run.StartTime.Int64)
+	s.Equal(int64(1700000010000), run.EndTime.Int64)
+
+	var params []models.Param
+	s.Require().Nil(s.db.Where("run_uuid = ?", fileStoreRunID).Order("key").Find(&params).Error)
+	s.Require().Len(params, 2)
+	s.Equal("lr", params[0].Key)
+	s.Equal("0.01", *params[0].ValueStr)
+	s.Equal("optimizer/name", params[1].Key)
+	s.Equal("adam", *params[1].ValueStr)
+
+	var metrics []models.Metric
+	s.Require().Nil(s.db.Where(
+		"run_uuid = ? AND key = ?", fileStoreRunID, "loss",
+	).Order("iter").Find(&metrics).Error)
+	s.Require().Len(metrics, 4)
+	s.Equal(0.9, metrics[0].Value)
+	s.Equal(int64(1), metrics[0].Iter)
+	s.True(metrics[2].IsNan)
+	s.Equal(int64(3), metrics[3].Step)
+
+	var latestMetric models.LatestMetric
+	s.Require().Nil(s.db.Where(
+		"run_uuid = ? AND key = ?", fileStoreRunID, "train/accuracy",
+	).First(&latestMetric).Error)
+	s.Equal(math.MaxFloat64, latestMetric.Value)
+	s.Equal(int64(1), latestMetric.Step)
+	s.Equal(int64(2), latestMetric.LastIter)
+
+	// deleted run has its name in tags and no step in metric history.
+	var deletedRun models.Run
+	s.Require().Nil(s.db.Where("run_uuid = ?", fileStoreDeletedRunID).First(&deletedRun).Error)
+	s.Equal("old-run", deletedRun.Name)
+	s.Equal(models.LifecycleStageDeleted, deletedRun.LifecycleStage)
+	s.Equal(models.StatusRunning, deletedRun.Status)
+	s.False(deletedRun.EndTime.Valid)
+	s.Require().Nil(s.db.Where("run_uuid = ?", fileStoreDeletedRunID).Find(&metrics).Error)
+	s.Require().Len(metrics, 1)
+	s.Equal(int64(0), metrics[0].Step)
+
+	// artifacts are copied from the file store into the artifact root.
+	s.Equal(filepath.Join(artifactRoot, "2", fileStoreRunID, "artifacts"), run.ArtifactURI)
+	content, err := os.ReadFile(filepath.Join(run.ArtifactURI, "model", "model.pkl"))
+	s.Require().Nil(err)
+	s.Equal("model content", string(content))
+
+	// repeated import doesn't duplicate data.
+	_, err = importer.Import(context.Background())
+	s.Require().Nil(err)
+	var count int64
+	s.Require().Nil(s.db.Model(&models.Experiment{}).Where("namespace_id = ?", namespace.ID).Count(&count).Error)
+	s.Equal(int64(3), count)
+	s.Require().Nil(s.db.Model(&models.Metric{}).Where("run_uuid = ?", fileStoreRunID).Count(&count).Error)
+	s.Equal(int64(6), count)
+}
+
+func (s *FileStoreTestSuite) Test_Error() {
+	_, err := database.NewFileStoreImporter(s.db, s.T().TempDir()).Import(context.Background())
+	s.ErrorContains(err, "no experiments found in file store")
+
+	s.writeFile("123456789012345678/"+fileStoreRunID+"/metrics/broken", "1700000000100 not-a-number 0\n")
+	_, err = database.NewFileStoreImporter(s.db, s.root).Import(context.Background())
+	s.ErrorContains(err, "error parsing metric value")
+}
+
+func (s *FileStoreTestSuite) writeFile(name, content string) {
+	path := filepath.Join(s.root, filepath.FromSlash(name))
+	s.Require().Nil(os.MkdirAll(filepath.Dir(path), 0o755))
+	s.Require().Nil(os.WriteFile(path, []byte(content), 0o600))
+}
@@ -0,0 +1,175 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/repositories"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/pkg/common/services/gc"
+	"github.com/G-Research/fasttrackml/pkg/database"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/fixtures"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GCTestSuite struct {
+	suite.Suite
+	db               *gorm.DB
+	collector        *gc.Collector
+	oldRun           *models.Run
+	recentRun        *models.Run
+	activeRun        *models.Run
+	orphanRun        *models.Run
+	oldExperiment    *models.Experiment
+	recentExperiment *models.Experiment
+	runFixtures      *fixtures.RunFixtures
+}
+
+func TestGCTestSuite(t *testing.T) {
+	suite.Run(t, new(GCTestSuite))
+}
+
+func (s *GCTestSuite) SetupTest() {
+	dsn, err := helpers.GenerateDatabaseURI(s.T(), "sqlite")
+	s.Require().Nil(err)
+	db, err := database.NewDBProvider(dsn, 1*time.Second, 20)
+	s.Require().Nil(err)
+	s.Require().Nil(database.CheckAndMigrateDB(true, db.GormDB()))
+	s.Require().Nil(database.CreateDefaultNamespace(db.GormDB()))
+	s.Require().Nil(database.CreateDefaultExperiment(db.GormDB(), "s3://fasttrackml"))
+	s.db = db.GormDB()
+
+	artifactStorageFactory, err := storage.NewArtifactStorageFactory(&config.Config{})
+	s.Require().Nil(err)
+	s.collector = gc.NewCollector(
+		repositories.NewNamespaceRepository(s.db),
+		repositories.NewExperimentRepository(s.db),
+		repositories.NewRunRepository(s.db),
+		artifactStorageFactory,
+	)
+
+	experimentFixtures, err := fixtures.NewExperimentFixtures(s.db)
+	s.Require().Nil(err)
+	s.runFixtures, err = fixtures.NewRunFixtures(s.db)
+	s.Require().Nil(err)
+	logFixtures, err := fixtures.NewLogFixtures(s.db)
+	s.Require().Nil(err)
+
+	old := sql.NullInt64{Int64: time.Now().Add(-48 * time.Hour).UnixMilli(), Valid: true}
+	recent := sql.NullInt64{Int64: time.Now().UnixMilli(), Valid: true}
+
+	experiment, err := experimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           uuid.NewString(),
+		NamespaceID:    1,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	s.oldRun = s.createRun(*experiment.ID, models.LifecycleStageDeleted, old)
+	s.recentRun = s.createRun(*experiment.ID, models.LifecycleStageDeleted, recent)
+	s.activeRun = s.createRun(*experiment.ID, models.LifecycleStageActive, sql.NullInt64{})
+	_, err = logFixtures.CreateLog(context.Background(), &models.Log{
+		RunID:     s.oldRun.ID,
+		Value:     "log line",
+		Timestamp: 1234567890,
+	})
+	s.Require().Nil(err)
+
+	// runs of deleted experiments are purged together with the experiment.
+	s.oldExperiment, err = experimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           uuid.NewString(),
+		NamespaceID:    1,
+		LifecycleStage: models.LifecycleStageDeleted,
+		LastUpdateTime: old,
+	})
+	s.Require().Nil(err)
+	s.orphanRun = s.createRun(*s.oldExperiment.ID, models.LifecycleStageActive, sql.NullInt64{})
+
+	s.recentExperiment, err = experimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           uuid.NewString(),
+		NamespaceID:    1,
+		LifecycleStage: models.LifecycleStageDeleted,
+		LastUpdateTime: recent,
+	})
+	s.Require().Nil(err)
+}
+
+func (s *GCTestSuite) Test_Ok() {
+	// dry run doesn't change anything.
+	result, err := s.collector.Collect(context.Background(), gc.Options{OlderThan: 24 * time.Hour, DryRun: true})
+	s.Require().Nil(err)
+	s.Equal(&gc.Result{Experiments: 1, Runs: 2}, result)
+	s.assertRuns(s.oldRun.ID, s.recentRun.ID, s.activeRun.ID, s.orphanRun.ID)
+	s.DirExists(s.oldRun.ArtifactURI)
+
+	result, err = s.collector.Collect(context.Background(), gc.Options{OlderThan: 24 * time.Hour})
+	s.Require().Nil(err)
+	s.Equal(&gc.Result{Experiments: 1, Runs: 2}, result)
+	s.assertRuns(s.recentRun.ID, s.activeRun.ID)
+	s.NoDirExists(s.oldRun.ArtifactURI)
+	s.NoDirExists(s.orphanRun.ArtifactURI)
+	s.DirExists(s.recentRun.ArtifactURI)
+
+	var logs int64
+	s.Require().Nil(s.db.Model(&models.Log{}).Where("run_uuid = ?", s.oldRun.ID).Count(&logs).Error)
+	s.Equal(int64(0), logs)
+
+	var experiments []models.Experiment
+	s.Require().Nil(s.db.Where(
+		"experiment_id IN ?", []int32{*s.oldExperiment.ID, *s.recentExperiment.ID},
+	).Find(&experiments).Error)
+	s.Require().Len(experiments, 1)
+	s.Equal(*s.recentExperiment.ID, *experiments[0].ID)
+
+	// zero period purges everything deleted, but never the default experiment.
+	s.Require().Nil(s.db.Model(&models.Experiment{}).Where(
+		"experiment_id = ?", models.DefaultExperimentID,
+	).Update("lifecycle_stage", models.LifecycleStageDeleted).Error)
+	result, err = s.collector.Collect(context.Background(), gc.Options{Namespaces: []string{"default"}})
+	s.Require().Nil(err)
+	s.Equal(&gc.Result{Experiments: 1, Runs: 1}, result)
+	s.assertRuns(s.activeRun.ID)
+}
+
+func (s *GCTestSuite) Test_Error() {
+	_, err := s.collector.Collect(context.Background(), gc.Options{Namespaces: []string{"not-existing"}})
+	s.EqualError(err, "unable to find namespace with code: not-existing")
+}
+
+func (s *GCTestSuite) createRun(
+	experimentID int32, lifecycleStage models.LifecycleStage, deletedTime sql.NullInt64,
+) *models.Run {
+	artifactURI := s.T().TempDir()
+	s.Require().Nil(os.WriteFile(artifactURI+"/file.txt", []byte("content"), 0o600))
+	run, err := s.runFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.NewString(), "-", ""),
+		Name:           uuid.NewString(),
+		Status:         models.StatusFinished,
+		SourceType:     "JOB",
+		ExperimentID:   experimentID,
+		ArtifactURI:    artifactURI,
+		LifecycleStage: lifecycleStage,
+		DeletedTime:    deletedTime,
+	})
+	s.Require().Nil(err)
+	return run
+}
+
+func (s *GCTestSuite) assertRuns(ids ...string) {
+	var runs []models.Run
+	s.Require().Nil(s.db.Find(&runs).Error)
+	actual := make([]string, len(runs))
+	for i, run := range runs {
+		actual[i] = run.ID
+	}
+	s.ElementsMatch(ids, actual)
+}
@@ -29,6 +29,12 @@ type rowCounts struct {
 	apps                     int
 	sharedTags               int
 	runSharedTags            int
+	logs                     int
+	artifacts                int
+	datasets                 int
+	inputs                   int
+	registeredModels         int
+	modelVersions            int
 }
 
 type ImportTestSuite struct {
@@ -115,6 +121,7 @@ func (s *ImportTestSuite) populateDB(db *gorm.DB) {
 	runs, err := runFixtures.CreateExampleRuns(context.Background(), experiment, 5)
 	s.Require().Nil(err)
 	s.runs = runs
+	s.populateRunData(db, 1, experiment, runs[0])
 
 	// experiment 2
 	experiment, err = experimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
@@ -139,6 +146,7 @@ func (s *ImportTestSuite) populateDB(db *gorm.DB) {
 	runs, err = runFixtures.CreateExampleRuns(context.Background(), experiment, 5)
 	s.Require().Nil(err)
 	s.runs = runs
+	s.populateRunData(db, namespace.ID, experiment, runs[0])
 
 	dashboardFixtures, err := fixtures.NewDashboardFixtures(db)
 	s.Require().Nil(err)
@@ -188,13 +196,81 @@ func (s *ImportTestSuite) populateDB(db *gorm.DB) {
 	s.Require().Nil(sharedTagsFixtures.Associate(context.Background(), sharedTag2.ID.String(), runs[0].ID))
 }
 
+// populateRunData creates logs, dataset, aim artifact and registered model for the run.
+func (s *ImportTestSuite) populateRunData(db *gorm.DB, namespaceID uint, experiment *models.Experiment, run *models.Run) {
+	inputFixtures, err := fixtures.NewInputFixtures(db)
+	s.Require().Nil(err)
+
+	artifactFixtures, err := fixtures.NewArtifactFixtures(db)
+	s.Require().Nil(err)
+
+	registeredModelFixtures, err := fixtures.NewRegisteredModelFixtures(db)
+	s.Require().Nil(err)
+
+	logFixtures, err := fixtures.NewLogFixtures(db)
+	s.Require().Nil(err)
+
+	// the same line logged twice at once has to be imported twice as well.
+	for i := 0; i < 2; i++ {
+		_, err = logFixtures.CreateLog(context.Background(), &models.Log{
+			RunID:     run.ID,
+			Value:     "log line",
+			Timestamp: 1234567890,
+		})
+		s.Require().Nil(err)
+	}
+
+	dataset, err := inputFixtures.CreateDataset(context.Background(), &models.Dataset{
+		Name:         "dataset",
+		Digest:       "digest",
+		SourceType:   "source-type",
+		Source:       "source",
+		ExperimentID: *experiment.ID,
+	})
+	s.Require().Nil(err)
+
+	_, err = inputFixtures.CreateInput(context.Background(), &models.Input{
+		DatasetID: dataset.ID,
+		RunID:     run.ID,
+		Tags:      []models.InputTag{{Key: "context", Value: "training"}},
+	})
+	s.Require().Nil(err)
+
+	_, err = artifactFixtures.CreateArtifact(context.Background(), &models.Artifact{
+		ID:      uuid.New(),
+		Name:    "image",
+		RunID:   run.ID,
+		BlobURI: "path/filename.png",
+		Format:  "png",
+	})
+	s.Require().Nil(err)
+
+	registeredModel, err := registeredModelFixtures.CreateRegisteredModel(context.Background(), &models.RegisteredModel{
+		Name:        "model",
+		NamespaceID: namespaceID,
+		Tags:        []models.RegisteredModelTag{{Key: "key", Value: "value"}},
+		Aliases:     []models.RegisteredModelAlias{{Alias: "champion", Version: 1}},
+	})
+	s.Require().Nil(err)
+
+	_, err = registeredModelFixtures.CreateModelVersion(context.Background(), &models.ModelVersion{
+		Version:           1,
+		RegisteredModelID: registeredModel.ID,
+		CurrentStage:      models.ModelVersionStageNone,
+		Status:            models.ModelVersionStatusReady,
+		RunID:             run.ID,
+		Tags:              []models.ModelVersionTag{{Key: "key", Value: "value"}},
+	})
+	s.Require().Nil(err)
+}
+
 func (s *ImportTestSuite) TearDownSubTest() {
 	s.Require().Nil(s.inputRunFixtures.TruncateTables())
 	s.Require().Nil(s.outputRunFixtures.TruncateTables())
 }
 
 func (s *ImportTestSuite) TestGlobalScopeImport_Ok() {
-	backends := []string{"sqlite", "sqlcipher", "postgres"}
+	backends := []string{"sqlite", "sqlcipher", "postgres", "mysql"}
 	for _, inputBackend := range backends {
 		for _, outputBackend := range backends {
 			s.inputBackend = inputBackend
@@ -214,6 +290,12 @@ func (s *ImportTestSuite) TestGlobalScopeImport_Ok() {
 					apps:                     3,
 					sharedTags:               2,
 					runSharedTags:            2,
+					logs:                     4,
+					artifacts:                2,
+					datasets:                 2,
+					inputs:                   2,
+					registeredModels:         2,
+					modelVersions:            2,
 				})
 
 				// initially, dest DB is empty
@@ -237,6 +319,12 @@ func (s *ImportTestSuite) TestGlobalScopeImport_Ok() {
 					apps:                     3,
 					sharedTags:               2,
 					runSharedTags:            2,
+					logs:                     4,
+					artifacts:                2,
+					datasets:                 2,
+					inputs:                   2,
+					registeredModels:         2,
+					modelVersions:            2,
 				})
 
 				// invoke the Importer.Import method a 2nd time
@@ -256,6 +344,12 @@ func (s *ImportTestSuite) TestGlobalScopeImport_Ok() {
 					apps:                     3,
 					sharedTags:               2,
 					runSharedTags:            2,
+					logs:                     4,
+					artifacts:                2,
+					datasets:                 2,
+					inputs:                   2,
+					registeredModels:         2,
+					modelVersions:            2,
 				})
 
 				// confirm row-for-row equality
@@ -271,6 +365,15 @@ func (s *ImportTestSuite) TestGlobalScopeImport_Ok() {
 					"latest_metrics",
 					"shared_tags",
 					"run_shared_tags",
+					"artifacts",
+					"datasets",
+					"inputs",
+					"input_tags",
+					"registered_models",
+					"registered_model_tags",
+					"registered_model_aliases",
+					"model_versions",
+					"model_version_tags",
 				} {
 					s.validateTable(s.inputDB, s.outputDB, table)
 				}
@@ -280,7 +383,7 @@ func (s *ImportTestSuite) TestGlobalScopeImport_Ok() {
 }
 
 func (s *ImportTestSuite) TestNamespaceScopeImport_Ok() {
-	backends := []string{"sqlite", "sqlcipher", "postgres"}
+	backends := []string{"sqlite", "sqlcipher", "postgres", "mysql"}
 	for _, inputBackend := range backends {
 		for _, outputBackend := range backends {
 			s.inputBackend = inputBackend
@@ -295,6 +398,14 @@ func (s *ImportTestSuite) TestNamespaceScopeImport_Ok() {
 				})
 				s.Require().Nil(err)
 
+				// destination namespace already has registered model with the same name, which has to be reused.
+				registeredModelFixtures, err := fixtures.NewRegisteredModelFixtures(s.outputDB)
+				s.Require().Nil(err)
+				registeredModel, err := registeredModelFixtures.CreateRegisteredModel(
+					context.Background(), &models.RegisteredModel{Name: "model", NamespaceID: namespace.ID},
+				)
+				s.Require().Nil(err)
+
 				// source DB should have expected
 				s.validateRowCounts(s.inputDB, rowCounts{
 					namespaces:               2,
@@ -309,10 +420,16 @@ func (s *ImportTestSuite) TestNamespaceScopeImport_Ok() {
 					apps:                     3,
 					sharedTags:               2,
 					runSharedTags:            2,
+					logs:                     4,
+					artifacts:                2,
+					datasets:                 2,
+					inputs:                   2,
+					registeredModels:         2,
+					modelVersions:            2,
 				})
 
 				// initially, dest DB is empty
-				s.validateRowCounts(s.outputDB, rowCounts{namespaces: 2, experiments: 1})
+				s.validateRowCounts(s.outputDB, rowCounts{namespaces: 2, experiments: 1, registeredModels: 1})
 
 				// invoke the Importer.Import() method
 				importer := database.NewImporter(
@@ -337,6 +454,12 @@ func (s *ImportTestSuite) TestNamespaceScopeImport_Ok() {
 					apps:                     1,
 					sharedTags:               1,
 					runSharedTags:            1,
+					logs:                     2,
+					artifacts:                1,
+					datasets:                 1,
+					inputs:                   1,
+					registeredModels:         1,
+					modelVersions:            1,
 				})
 
 				// invoke the Importer.Import method a 2nd time
@@ -356,7 +479,27 @@ func (s *ImportTestSuite) TestNamespaceScopeImport_Ok() {
 					apps:                     1,
 					sharedTags:               1,
 					runSharedTags:            1,
+					logs:                     2,
+					artifacts:                1,
+					datasets:                 1,
+					inputs:                   1,
+					registeredModels:         1,
+					modelVersions:            1,
 				})
+
+				// imported model version and tags have to belong to already existing registered model.
+				versions, err := registeredModelFixtures.GetModelVersionsByRegisteredModelID(
+					context.Background(), registeredModel.ID,
+				)
+				s.Require().Nil(err)
+				s.Require().Len(versions, 1)
+				s.Len(versions[0].Tags, 1)
+				registeredModel, err = registeredModelFixtures.GetRegisteredModelByNamespaceIDAndName(
+					context.Background(), namespace.ID, "model",
+				)
+				s.Require().Nil(err)
+				s.Len(registeredModel.Tags, 1)
+				s.Len(registeredModel.Aliases, 1)
 			})
 		}
 	}
@@ -402,6 +545,24 @@ func (s *ImportTestSuite) validateRowCounts(db *gorm.DB, counts rowCounts) {
 
 	s.Require().Nil(db.Model(&database.RunSharedTag{}).Count(&countVal).Error)
 	s.Equal(counts.runSharedTags, int(countVal), "Run shared tag count incorrect")
+
+	s.Require().Nil(db.Model(&models.Log{}).Count(&countVal).Error)
+	s.Equal(counts.logs, int(countVal), "Logs count incorrect")
+
+	s.Require().Nil(db.Model(&models.Artifact{}).Count(&countVal).Error)
+	s.Equal(counts.artifacts, int(countVal), "Artifacts count incorrect")
+
+	s.Require().Nil(db.Model(&models.Dataset{}).Count(&countVal).Error)
+	s.Equal(counts.datasets, int(countVal), "Datasets count incorrect")
+
+	s.Require().Nil(db.Model(&models.Input{}).Count(&countVal).Error)
+	s.Equal(counts.inputs, int(countVal), "Inputs count incorrect")
+
+	s.Require().Nil(db.Model(&models.RegisteredModel{}).Count(&countVal).Error)
+	s.Equal(counts.registeredModels, int(countVal), "Registered models count incorrect")
+
+	s.Require().Nil(db.Model(&models.ModelVersion{}).Count(&countVal).Error)
+	s.Equal(counts.modelVersions, int(countVal), "Model versions count incorrect")
 }
 
 // validateTable will scan source and dest table and confirm they are identical
@@ -437,7 +598,7 @@ func (s *ImportTestSuite) validateTable(source, dest *gorm.DB, table string) {
 					if v, ok := v.(int64); ok {
 						row[k] = int32(v)
 					}
-				case "id", "app_id":
+				case "id", "app_id", "dataset_id", "input_id", "registered_model_id", "model_version_id":
 					switch v := v.(type) {
 					case *interface{}:
 						switch s := (*v).(type) {
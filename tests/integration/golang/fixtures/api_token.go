@@ -0,0 +1,33 @@
+package fixtures
+
+import (
+	"context"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+)
+
+// APITokenFixtures represents data fixtures object.
+type APITokenFixtures struct {
+	baseFixtures
+}
+
+// NewAPITokenFixtures creates new instance of APITokenFixtures.
+func NewAPITokenFixtures(db *gorm.DB) (*APITokenFixtures, error) {
+	return &APITokenFixtures{
+		baseFixtures: baseFixtures{db: db},
+	}, nil
+}
+
+// CreateAPIToken creates new test API token with hash of provided plain token and its permissions.
+func (f APITokenFixtures) CreateAPIToken(
+	ctx context.Context, token string, apiToken *models.APIToken,
+) (*models.APIToken, error) {
+	apiToken.TokenHash = models.HashAPIToken(token)
+	if err := f.baseFixtures.db.WithContext(ctx).Omit("Permissions.Namespace").Create(apiToken).Error; err != nil {
+		return nil, eris.Wrap(err, "error creating test api token")
+	}
+	return apiToken, nil
+}
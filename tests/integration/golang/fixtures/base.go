@@ -25,6 +25,9 @@ func (f baseFixtures) TruncateTables() error {
 		aimModels.App{},
 		aimModels.SharedTag{},
 		mlflowModels.Artifact{},
+		mlflowModels.InputTag{},
+		mlflowModels.Input{},
+		mlflowModels.Dataset{},
 		mlflowModels.Tag{},
 		mlflowModels.Param{},
 		mlflowModels.LatestMetric{},
@@ -32,6 +35,14 @@ func (f baseFixtures) TruncateTables() error {
 		mlflowModels.Context{},
 		mlflowModels.Log{},
 		mlflowModels.Run{},
+		mlflowModels.ModelVersionTag{},
+		mlflowModels.RegisteredModelAlias{},
+		mlflowModels.ModelVersion{},
+		mlflowModels.RegisteredModelTag{},
+		mlflowModels.RegisteredModel{},
+		mlflowModels.APITokenPermission{},
+		mlflowModels.APIToken{},
+		mlflowModels.AuditLog{},
 		mlflowModels.ExperimentTag{},
 		mlflowModels.Experiment{},
 		mlflowModels.Namespace{},
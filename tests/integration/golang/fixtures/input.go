@@ -0,0 +1,53 @@
+package fixtures
+
+import (
+	"context"
+
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+)
+
+// InputFixtures represents data fixtures object.
+type InputFixtures struct {
+	baseFixtures
+}
+
+// NewInputFixtures creates new instance of InputFixtures.
+func NewInputFixtures(db *gorm.DB) (*InputFixtures, error) {
+	return &InputFixtures{
+		baseFixtures: baseFixtures{db: db},
+	}, nil
+}
+
+// CreateDataset creates new test Dataset.
+func (f InputFixtures) CreateDataset(ctx context.Context, dataset *models.Dataset) (*models.Dataset, error) {
+	if err := f.baseFixtures.db.WithContext(ctx).Create(dataset).Error; err != nil {
+		return nil, eris.Wrap(err, "error creating test dataset")
+	}
+	return dataset, nil
+}
+
+// CreateInput creates new test Input together with its tags.
+func (f InputFixtures) CreateInput(ctx context.Context, input *models.Input) (*models.Input, error) {
+	if err := f.baseFixtures.db.WithContext(ctx).Omit("Dataset").Create(input).Error; err != nil {
+		return nil, eris.Wrap(err, "error creating test input")
+	}
+	return input, nil
+}
+
+// GetByRunID returns input list with datasets and tags by requested Run ID.
+func (f InputFixtures) GetByRunID(ctx context.Context, runID string) ([]models.Input, error) {
+	var inputs []models.Input
+	if err := f.db.WithContext(ctx).Preload(
+		"Dataset",
+	).Preload(
+		"Tags",
+	).Where(
+		models.Input{RunID: runID},
+	).Find(&inputs).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting inputs by run id: %s", runID)
+	}
+	return inputs, nil
+}
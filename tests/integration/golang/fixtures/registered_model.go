@@ -0,0 +1,77 @@
+package fixtures
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/rotisserie/eris"
+	"gorm.io/gorm"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+)
+
+// RegisteredModelFixtures represents data fixtures object.
+type RegisteredModelFixtures struct {
+	baseFixtures
+}
+
+// NewRegisteredModelFixtures creates new instance of RegisteredModelFixtures.
+func NewRegisteredModelFixtures(db *gorm.DB) (*RegisteredModelFixtures, error) {
+	return &RegisteredModelFixtures{
+		baseFixtures: baseFixtures{db: db},
+	}, nil
+}
+
+// CreateRegisteredModel creates new test RegisteredModel.
+func (f RegisteredModelFixtures) CreateRegisteredModel(
+	ctx context.Context, registeredModel *models.RegisteredModel,
+) (*models.RegisteredModel, error) {
+	if err := f.db.WithContext(ctx).Omit("Namespace").Create(registeredModel).Error; err != nil {
+		return nil, eris.Wrap(err, "error creating test registered model")
+	}
+	return registeredModel, nil
+}
+
+// CreateModelVersion creates new test ModelVersion.
+func (f RegisteredModelFixtures) CreateModelVersion(
+	ctx context.Context, version *models.ModelVersion,
+) (*models.ModelVersion, error) {
+	if err := f.db.WithContext(ctx).Omit("RegisteredModel").Create(version).Error; err != nil {
+		return nil, eris.Wrap(err, "error creating test model version")
+	}
+	return version, nil
+}
+
+// GetRegisteredModelByNamespaceIDAndName returns RegisteredModel by Namespace ID and name.
+func (f RegisteredModelFixtures) GetRegisteredModelByNamespaceIDAndName(
+	ctx context.Context, namespaceID uint, name string,
+) (*models.RegisteredModel, error) {
+	var registeredModel models.RegisteredModel
+	if err := f.db.WithContext(ctx).Preload(
+		"Tags",
+	).Preload(
+		"Aliases",
+	).Where(
+		"namespace_id = ? AND name = ?", namespaceID, name,
+	).First(&registeredModel).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting registered model by name: %s", name)
+	}
+	return &registeredModel, nil
+}
+
+// GetModelVersionsByRegisteredModelID returns all ModelVersions, including deleted, of RegisteredModel.
+func (f RegisteredModelFixtures) GetModelVersionsByRegisteredModelID(
+	ctx context.Context, registeredModelID uuid.UUID,
+) ([]models.ModelVersion, error) {
+	var versions []models.ModelVersion
+	if err := f.db.WithContext(ctx).Preload(
+		"Tags",
+	).Where(
+		"registered_model_id = ?", registeredModelID,
+	).Order(
+		"version",
+	).Find(&versions).Error; err != nil {
+		return nil, eris.Wrapf(err, "error getting model versions by registered model id: %s", registeredModelID)
+	}
+	return versions, nil
+}
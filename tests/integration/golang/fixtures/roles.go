@@ -41,3 +41,17 @@ func (f RoleFixtures) AttachNamespaceToRole(
 	}
 	return nil
 }
+
+// AttachNamespaceToRoleWithLevel attaches a Role to provided Namespace with the given namespace role level.
+func (f RoleFixtures) AttachNamespaceToRoleWithLevel(
+	ctx context.Context, role *models.Role, namespace *models.Namespace, level string,
+) error {
+	if err := f.db.WithContext(ctx).Create(&models.RoleNamespace{
+		RoleID:      role.ID,
+		NamespaceID: namespace.ID,
+		Level:       level,
+	}).Error; err != nil {
+		return eris.Wrap(err, "error attaching namespace to role ")
+	}
+	return nil
+}
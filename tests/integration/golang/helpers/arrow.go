@@ -3,6 +3,7 @@ package helpers
 import (
 	"bytes"
 
+	"github.com/apache/arrow/go/v14/arrow"
 	"github.com/apache/arrow/go/v14/arrow/array"
 	"github.com/apache/arrow/go/v14/arrow/ipc"
 	"github.com/apache/arrow/go/v14/arrow/memory"
@@ -48,3 +49,51 @@ func DecodeArrowMetrics(buf *bytes.Buffer) ([]models.Metric, error) {
 
 	return metrics, nil
 }
+
+// EncodeArrowMetrics encodes metrics into Arrow IPC stream accepted by `POST /metrics/log-bulk`.
+// NaN values are encoded as nulls.
+func EncodeArrowMetrics(metrics []models.Metric) ([]byte, error) {
+	pool := memory.NewGoAllocator()
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "run_id", Type: arrow.BinaryTypes.String},
+			{Name: "key", Type: arrow.BinaryTypes.String},
+			{Name: "value", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+			{Name: "timestamp", Type: arrow.PrimitiveTypes.Int64},
+			{Name: "step", Type: arrow.PrimitiveTypes.Int64},
+			{Name: "context", Type: arrow.BinaryTypes.String, Nullable: true},
+		},
+		nil,
+	)
+
+	builder := array.NewRecordBuilder(pool, schema)
+	defer builder.Release()
+	for _, metric := range metrics {
+		builder.Field(0).(*array.StringBuilder).Append(metric.RunID)
+		builder.Field(1).(*array.StringBuilder).Append(metric.Key)
+		if metric.IsNan {
+			builder.Field(2).(*array.Float64Builder).AppendNull()
+		} else {
+			builder.Field(2).(*array.Float64Builder).Append(metric.Value)
+		}
+		builder.Field(3).(*array.Int64Builder).Append(metric.Timestamp)
+		builder.Field(4).(*array.Int64Builder).Append(metric.Step)
+		if metric.Context.Json == nil {
+			builder.Field(5).(*array.StringBuilder).AppendNull()
+		} else {
+			builder.Field(5).(*array.StringBuilder).Append(string(metric.Context.Json))
+		}
+	}
+	record := builder.NewRecord()
+	defer record.Release()
+
+	var buf bytes.Buffer
+	writer := ipc.NewWriter(&buf, ipc.WithAllocator(pool), ipc.WithSchema(schema))
+	if err := writer.Write(record); err != nil {
+		return nil, eris.Wrap(err, "error writing arrow record")
+	}
+	if err := writer.Close(); err != nil {
+		return nil, eris.Wrap(err, "error closing arrow writer")
+	}
+	return buf.Bytes(), nil
+}
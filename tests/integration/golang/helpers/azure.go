@@ -0,0 +1,60 @@
+package helpers
+
+import (
+	"context"
+
+	"github.com/rotisserie/eris"
+
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+)
+
+// AzureHost is a host of Azure storage account used in artifact URIs of the tests.
+const AzureHost = "devstoreaccount1.blob.core.windows.net"
+
+type AzureTestSuite struct {
+	BaseTestSuite
+	Client         *storage.AzureClient
+	testContainers []string
+}
+
+// NewAzureTestSuite creates a new instance of AzureTestSuite.
+func NewAzureTestSuite(testContainers ...string) AzureTestSuite {
+	return AzureTestSuite{
+		testContainers: testContainers,
+	}
+}
+
+func (s *AzureTestSuite) SetupSuite() {
+	s.BaseTestSuite.SetupSuite()
+
+	client, err := storage.NewAzureClient(GetAzureStorageConnectionString(), "")
+	s.Require().Nil(err)
+	s.Client = client
+
+	s.AddSetupHook(func() {
+		s.Require().Nil(s.CreateTestContainers())
+	})
+	s.AddTearDownHook(func() {
+		s.Require().Nil(s.DeleteTestContainers())
+	})
+}
+
+// CreateTestContainers creates the test containers.
+func (s *AzureTestSuite) CreateTestContainers() error {
+	for _, container := range s.testContainers {
+		if err := s.Client.CreateContainer(context.Background(), AzureHost, container); err != nil {
+			return eris.Wrapf(err, "failed to create container %q", container)
+		}
+	}
+	return nil
+}
+
+// DeleteTestContainers deletes the test containers together with their blobs.
+func (s *AzureTestSuite) DeleteTestContainers() error {
+	for _, container := range s.testContainers {
+		if err := s.Client.DeleteContainer(context.Background(), AzureHost, container); err != nil {
+			return eris.Wrapf(err, "failed to delete container %q", container)
+		}
+	}
+	return nil
+}
@@ -42,6 +42,7 @@ type HttpClient struct {
 	response     any
 	responseType ResponseType
 	statusCode   int
+	header       http.Header
 }
 
 // NewClient creates a new preconfigured HTTP client.
@@ -58,11 +59,21 @@ func NewClient(server server.Server, basePath string) *HttpClient {
 	}
 }
 
+// NewServerClient creates a new HTTP client for the server endpoints, like `/health` or `/metrics`
+func NewServerClient(server server.Server) *HttpClient {
+	return NewClient(server, "")
+}
+
 // NewMlflowApiClient creates a new HTTP client for the mlflow api
 func NewMlflowApiClient(server server.Server) *HttpClient {
 	return NewClient(server, "/api/2.0/mlflow")
 }
 
+// NewMlflowArtifactsApiClient creates a new HTTP client for the mlflow-artifacts api
+func NewMlflowArtifactsApiClient(server server.Server) *HttpClient {
+	return NewClient(server, "/api/2.0/mlflow-artifacts")
+}
+
 // NewAimApiClient creates a new HTTP client for the aim api
 func NewAimApiClient(server server.Server) *HttpClient {
 	return NewClient(server, "/aim/api")
@@ -131,12 +142,20 @@ func (c *HttpClient) GetStatusCode() int {
 	return c.statusCode
 }
 
+// GetResponseHeader returns the header of the response.
+func (c *HttpClient) GetResponseHeader(name string) string {
+	return c.header.Get(name)
+}
+
 // DoRequest do actual HTTP request based on provided parameters.
 // nolint:gocyclo
 func (c *HttpClient) DoRequest(uri string, values ...any) error {
 	// 1. check if request object were provided. if provided then marshal it.
+	// raw request body could be provided as a slice of bytes.
 	var requestBody io.Reader
-	if c.request != nil {
+	if data, ok := c.request.([]byte); ok {
+		requestBody = bytes.NewReader(data)
+	} else if c.request != nil {
 		data, err := json.Marshal(c.request)
 		if err != nil {
 			return eris.Wrap(err, "error marshaling request object")
@@ -216,6 +235,7 @@ func (c *HttpClient) DoRequest(uri string, values ...any) error {
 	defer resp.Body.Close()
 
 	c.statusCode = resp.StatusCode
+	c.header = resp.Header
 
 	// 9. read and check response data.
 	if c.response != nil {
@@ -8,8 +8,11 @@ import (
 	"testing"
 	"time"
 
+	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/rotisserie/eris"
+
+	"github.com/G-Research/fasttrackml/pkg/database"
 )
 
 func GenerateDatabaseURI(t *testing.T, backend string) (string, error) {
@@ -22,6 +25,9 @@ func GenerateDatabaseURI(t *testing.T, backend string) (string, error) {
 		// temporary directory name contains some not allowed character to be in database name. filter it.
 		tmpDir := fmt.Sprintf("db_%d", time.Now().UnixMicro())
 		return getPostgresDatabase(t, GetPostgresUri(), strings.ToLower(tmpDir))
+	case "mysql":
+		tmpDir := fmt.Sprintf("db_%d", time.Now().UnixMicro())
+		return getMySQLDatabase(t, GetMySQLUri(), tmpDir)
 	default:
 		return "", fmt.Errorf("unknown backend: %s", backend)
 	}
@@ -55,3 +61,37 @@ func getPostgresDatabase(t *testing.T, dsn string, name string) (string, error)
 	uri.Path = name
 	return uri.String(), nil
 }
+
+func getMySQLDatabase(t *testing.T, dsn string, name string) (string, error) {
+	uri, err := url.Parse(dsn)
+	if err != nil {
+		return "", eris.Wrapf(err, "failed to parse dsn %q", dsn)
+	}
+
+	config, err := database.NewMySQLConfig(*uri)
+	if err != nil {
+		return "", eris.Wrapf(err, "failed to convert dsn %q", dsn)
+	}
+
+	db, err := sql.Open("mysql", config.FormatDSN())
+	if err != nil {
+		return "", eris.Wrapf(err, "failed to open database %q", dsn)
+	}
+
+	_, err = db.Exec("CREATE DATABASE " + name + " CHARACTER SET utf8mb4 COLLATE utf8mb4_bin")
+	if err != nil {
+		return "", eris.Wrapf(err, "failed to create database %q on %q", name, dsn)
+	}
+
+	t.Cleanup(func() {
+		// nolint:errcheck
+		defer db.Close()
+		_, err := db.Exec("DROP DATABASE " + name)
+		if err != nil {
+			t.Errorf("failed to drop database %q on %q: %v", name, dsn, err)
+		}
+	})
+
+	uri.Path = name
+	return uri.String(), nil
+}
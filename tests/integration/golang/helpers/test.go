@@ -11,6 +11,7 @@ import (
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
 	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
 	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
 	"github.com/G-Research/fasttrackml/pkg/database"
 	"github.com/G-Research/fasttrackml/pkg/server"
 	"github.com/G-Research/fasttrackml/tests/integration/golang/fixtures"
@@ -25,8 +26,10 @@ type BaseTestSuite struct {
 	server                      server.Server
 	setupHooks                  []func()
 	tearDownHooks               []func()
+	ServerClient                func() *HttpClient
 	AIMClient                   func() *HttpClient
 	MlflowClient                func() *HttpClient
+	MlflowArtifactsClient       func() *HttpClient
 	AdminClient                 func() *HttpClient
 	ChooserClient               func() *HttpClient
 	AppFixtures                 *fixtures.AppFixtures
@@ -42,6 +45,9 @@ type BaseTestSuite struct {
 	ProjectFixtures             *fixtures.ProjectFixtures
 	DashboardFixtures           *fixtures.DashboardFixtures
 	ExperimentFixtures          *fixtures.ExperimentFixtures
+	RegisteredModelFixtures     *fixtures.RegisteredModelFixtures
+	InputFixtures               *fixtures.InputFixtures
+	APITokenFixtures            *fixtures.APITokenFixtures
 	DefaultExperiment           *models.Experiment
 	NamespaceFixtures           *fixtures.NamespaceFixtures
 	DefaultNamespace            *models.Namespace
@@ -139,6 +145,18 @@ func (s *BaseTestSuite) initFixtures() {
 	logFixtures, err := fixtures.NewLogFixtures(db)
 	s.Require().Nil(err)
 	s.LogFixtures = logFixtures
+
+	registeredModelFixtures, err := fixtures.NewRegisteredModelFixtures(db)
+	s.Require().Nil(err)
+	s.RegisteredModelFixtures = registeredModelFixtures
+
+	inputFixtures, err := fixtures.NewInputFixtures(db)
+	s.Require().Nil(err)
+	s.InputFixtures = inputFixtures
+
+	apiTokenFixtures, err := fixtures.NewAPITokenFixtures(db)
+	s.Require().Nil(err)
+	s.APITokenFixtures = apiTokenFixtures
 }
 
 func (s *BaseTestSuite) closeDB() {
@@ -152,22 +170,34 @@ func (s *BaseTestSuite) startServer() {
 		DatabaseSlowThreshold: 1 * time.Second,
 		DatabaseMigrate:       true,
 		DefaultArtifactRoot:   s.T().TempDir(),
-		S3EndpointURI:         GetS3EndpointUri(),
-		GSEndpointURI:         GetGSEndpointUri(),
-		RunLogOutputMax:       MaxLogRows,
+		ArtifactStorageOptions: map[string]string{
+			storage.S3EndpointURIOption:                GetS3EndpointUri(),
+			storage.GSEndpointURIOption:                GetGSEndpointUri(),
+			storage.AzureStorageConnectionStringOption: GetAzureStorageConnectionString(),
+		},
+		RunLogOutputMax: MaxLogRows,
 	}
 	s.Require().Nil(mergo.Merge(&cfg, s.Config))
+	if cfg.ArtifactsDestination == "" {
+		cfg.ArtifactsDestination = cfg.DefaultArtifactRoot
+	}
 
 	srv, err := server.NewServer(context.Background(), &cfg)
 	s.Require().Nil(err)
 	s.server = srv
 
+	s.ServerClient = func() *HttpClient {
+		return NewServerClient(s.server)
+	}
 	s.AIMClient = func() *HttpClient {
 		return NewAimApiClient(s.server)
 	}
 	s.MlflowClient = func() *HttpClient {
 		return NewMlflowApiClient(s.server)
 	}
+	s.MlflowArtifactsClient = func() *HttpClient {
+		return NewMlflowArtifactsApiClient(s.server)
+	}
 	s.AdminClient = func() *HttpClient {
 		return NewAdminApiClient(s.server)
 	}
@@ -0,0 +1,141 @@
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/api/request"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GetArtifactAzureTestSuite struct {
+	helpers.AzureTestSuite
+}
+
+func TestGetArtifactAzureTestSuite(t *testing.T) {
+	suite.Run(t, &GetArtifactAzureTestSuite{
+		helpers.NewAzureTestSuite("container1"),
+	})
+}
+
+func (s *GetArtifactAzureTestSuite) Test_Ok() {
+	// create test experiment
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:             "Test Experiment In Container container1",
+		NamespaceID:      s.DefaultNamespace.ID,
+		LifecycleStage:   models.LifecycleStageActive,
+		ArtifactLocation: fmt.Sprintf("wasbs://container1@%s/1", helpers.AzureHost),
+	})
+	s.Require().Nil(err)
+
+	// create test run
+	runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             runID,
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ExperimentID:   *experiment.ID,
+		ArtifactURI:    fmt.Sprintf("%s/%s/artifacts", experiment.ArtifactLocation, runID),
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	// upload artifact objects to Azure
+	s.Require().Nil(s.Client.PutBlob(
+		context.Background(),
+		helpers.AzureHost,
+		"container1",
+		fmt.Sprintf("1/%s/artifacts/artifact.file", runID),
+		strings.NewReader("content"),
+	))
+	s.Require().Nil(s.Client.PutBlob(
+		context.Background(),
+		helpers.AzureHost,
+		"container1",
+		fmt.Sprintf("1/%s/artifacts/artifact.subdir/artifact.file", runID),
+		strings.NewReader("subdir-object-content"),
+	))
+
+	tests := []struct {
+		name    string
+		path    string
+		content string
+	}{
+		{
+			name:    "RootObject",
+			path:    "artifact.file",
+			content: "content",
+		},
+		{
+			name:    "SubdirObject",
+			path:    "artifact.subdir/artifact.file",
+			content: "subdir-object-content",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := new(bytes.Buffer)
+			s.Require().Nil(s.MlflowClient().WithQuery(
+				request.GetArtifactRequest{
+					RunID: run.ID,
+					Path:  tt.path,
+				},
+			).WithResponseType(
+				helpers.ResponseTypeBuffer,
+			).WithResponse(
+				resp,
+			).DoRequest(
+				"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsGetRoute,
+			))
+			s.Equal(tt.content, resp.String())
+		})
+	}
+}
+
+func (s *GetArtifactAzureTestSuite) Test_Error() {
+	// create test experiment
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:             "Test Experiment In Container container1",
+		NamespaceID:      s.DefaultNamespace.ID,
+		LifecycleStage:   models.LifecycleStageActive,
+		ArtifactLocation: fmt.Sprintf("wasbs://container1@%s/1", helpers.AzureHost),
+	})
+	s.Require().Nil(err)
+
+	// create test run
+	runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             runID,
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ExperimentID:   *experiment.ID,
+		ArtifactURI:    fmt.Sprintf("%s/%s/artifacts", experiment.ArtifactLocation, runID),
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	resp := api.ErrorResponse{}
+	s.Require().Nil(s.MlflowClient().WithQuery(
+		request.GetArtifactRequest{
+			RunID: run.ID,
+			Path:  "non-existent-file",
+		},
+	).WithResponse(
+		&resp,
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsGetRoute,
+	))
+	s.Equal(api.NewResourceDoesNotExistError(
+		"error getting artifact object for URI: wasbs:/container1@%s/1/%s/artifacts/non-existent-file",
+		helpers.AzureHost, runID,
+	).Error(), resp.Error())
+}
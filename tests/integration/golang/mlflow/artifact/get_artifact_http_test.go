@@ -0,0 +1,130 @@
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/api/request"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/pkg/common/services/artifact/storage"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GetArtifactHTTPTestSuite struct {
+	helpers.BaseTestSuite
+	root   string
+	server *httptest.Server
+}
+
+func TestGetArtifactHTTPTestSuite(t *testing.T) {
+	// serve artifacts with a static file server protected by BasicAuth.
+	root := t.TempDir()
+	fileServer := http.FileServer(http.Dir(root))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if username, password, ok := r.BasicAuth(); !ok || username != "user" || password != "password" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fileServer.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	testSuite := &GetArtifactHTTPTestSuite{root: root, server: server}
+	testSuite.Config = config.Config{
+		ArtifactStorageOptions: map[string]string{
+			storage.HTTPArtifactsUsernameOption: "user",
+			storage.HTTPArtifactsPasswordOption: "password",
+		},
+		ArtifactStorageConfig: writeHTTPStorageConfig(t, server.URL),
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *GetArtifactHTTPTestSuite) Test_Ok() {
+	runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	artifactsDir := filepath.Join(s.root, "1", runID, "artifacts")
+	s.Require().Nil(os.MkdirAll(filepath.Join(artifactsDir, "artifact.subdir"), 0o755))
+	s.Require().Nil(os.WriteFile(filepath.Join(artifactsDir, "artifact.file"), []byte("content"), 0o600))
+	s.Require().Nil(os.WriteFile(
+		filepath.Join(artifactsDir, "artifact.subdir", "artifact.file"), []byte("subdir-object-content"), 0o600,
+	))
+
+	// create test experiment and run.
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:             "Test Experiment On HTTP Server",
+		NamespaceID:      s.DefaultNamespace.ID,
+		LifecycleStage:   models.LifecycleStageActive,
+		ArtifactLocation: s.server.URL + "/1",
+	})
+	s.Require().Nil(err)
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             runID,
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ExperimentID:   *experiment.ID,
+		ArtifactURI:    experiment.ArtifactLocation + "/" + runID + "/artifacts",
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name    string
+		path    string
+		content string
+	}{
+		{
+			name:    "RootObject",
+			path:    "artifact.file",
+			content: "content",
+		},
+		{
+			name:    "SubdirObject",
+			path:    "artifact.subdir/artifact.file",
+			content: "subdir-object-content",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := new(bytes.Buffer)
+			s.Require().Nil(s.MlflowClient().WithQuery(
+				request.GetArtifactRequest{
+					RunID: run.ID,
+					Path:  tt.path,
+				},
+			).WithResponseType(
+				helpers.ResponseTypeBuffer,
+			).WithResponse(
+				resp,
+			).DoRequest(
+				"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsGetRoute,
+			))
+			s.Equal(tt.content, resp.String())
+		})
+	}
+
+	// directories are not objects.
+	resp := api.ErrorResponse{}
+	s.Require().Nil(s.MlflowClient().WithQuery(
+		request.GetArtifactRequest{
+			RunID: run.ID,
+			Path:  "artifact.subdir",
+		},
+	).WithResponse(
+		&resp,
+	).DoRequest(
+		"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsGetRoute,
+	))
+	s.Equal(http.StatusNotFound, resp.StatusCode)
+}
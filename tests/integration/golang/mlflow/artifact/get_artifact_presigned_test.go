@@ -0,0 +1,141 @@
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api/request"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GetArtifactPresignedS3TestSuite struct {
+	helpers.S3TestSuite
+}
+
+func TestGetArtifactPresignedS3TestSuite(t *testing.T) {
+	testSuite := &GetArtifactPresignedS3TestSuite{
+		helpers.NewS3TestSuite("bucket1"),
+	}
+	testSuite.Config = config.Config{
+		PresignedURLsEnabled: true,
+		PresignedURLsExpiry:  time.Minute,
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *GetArtifactPresignedS3TestSuite) Test_Ok() {
+	// create test experiment and run.
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:             "Test Experiment In Bucket bucket1",
+		NamespaceID:      s.DefaultNamespace.ID,
+		LifecycleStage:   models.LifecycleStageActive,
+		ArtifactLocation: "s3://bucket1/1",
+	})
+	s.Require().Nil(err)
+	runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             runID,
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ExperimentID:   *experiment.ID,
+		ArtifactURI:    fmt.Sprintf("%s/%s/artifacts", experiment.ArtifactLocation, runID),
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	// upload artifact object to S3.
+	_, err = s.Client.PutObject(context.Background(), &s3.PutObjectInput{
+		Key:    aws.String(fmt.Sprintf("1/%s/artifacts/artifact.file", runID)),
+		Body:   strings.NewReader("content"),
+		Bucket: aws.String("bucket1"),
+	})
+	s.Require().Nil(err)
+
+	// the server redirects to presigned url instead of streaming the artifact.
+	client := s.MlflowClient().WithQuery(request.GetArtifactRequest{
+		RunID: run.ID,
+		Path:  "artifact.file",
+	})
+	s.Require().Nil(client.DoRequest("%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsGetRoute))
+	s.Equal(http.StatusTemporaryRedirect, client.GetStatusCode())
+
+	location := client.GetResponseHeader("Location")
+	s.Contains(location, "X-Amz-Signature=")
+	//nolint:gosec,noctx
+	resp, err := http.Get(location)
+	s.Require().Nil(err)
+	//nolint:errcheck
+	defer resp.Body.Close()
+	content, err := io.ReadAll(resp.Body)
+	s.Require().Nil(err)
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Equal("content", string(content))
+}
+
+type GetArtifactPresignedLocalTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestGetArtifactPresignedLocalTestSuite(t *testing.T) {
+	testSuite := new(GetArtifactPresignedLocalTestSuite)
+	testSuite.Config = config.Config{
+		PresignedURLsEnabled: true,
+		PresignedURLsExpiry:  time.Minute,
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *GetArtifactPresignedLocalTestSuite) Test_Ok() {
+	// create test experiment and run.
+	experimentArtifactDir := s.T().TempDir()
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:             "Test Experiment In Local Directory",
+		NamespaceID:      s.DefaultNamespace.ID,
+		LifecycleStage:   models.LifecycleStageActive,
+		ArtifactLocation: experimentArtifactDir,
+	})
+	s.Require().Nil(err)
+	runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	runArtifactDir := filepath.Join(experimentArtifactDir, runID, "artifacts")
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             runID,
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ExperimentID:   *experiment.ID,
+		ArtifactURI:    runArtifactDir,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	s.Require().Nil(os.MkdirAll(runArtifactDir, 0o755))
+	s.Require().Nil(os.WriteFile(filepath.Join(runArtifactDir, "artifact.file"), []byte("content"), 0o600))
+
+	// local storage can't presign urls, so the artifact is streamed by the server.
+	resp := new(bytes.Buffer)
+	client := s.MlflowClient().WithQuery(request.GetArtifactRequest{
+		RunID: run.ID,
+		Path:  "artifact.file",
+	}).WithResponseType(
+		helpers.ResponseTypeBuffer,
+	).WithResponse(
+		resp,
+	)
+	s.Require().Nil(client.DoRequest("%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsGetRoute))
+	s.Equal(http.StatusOK, client.GetStatusCode())
+	s.Equal("content", resp.String())
+}
@@ -0,0 +1,100 @@
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api/request"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GetArtifactStorageConfigTestSuite struct {
+	helpers.BaseTestSuite
+	servers map[string]*httptest.Server
+}
+
+func TestGetArtifactStorageConfigTestSuite(t *testing.T) {
+	// each artifact server accepts its own token only.
+	testSuite := &GetArtifactStorageConfigTestSuite{servers: map[string]*httptest.Server{}}
+	var storages strings.Builder
+	storages.WriteString("storages:\n")
+	for _, token := range []string{"token1", "token2"} {
+		server := newTokenArtifactServer(t, token)
+		testSuite.servers[token] = server
+		fmt.Fprintf(&storages, "  - prefix: %s\n    options:\n      http-artifacts-token: %s\n", server.URL, token)
+	}
+
+	configPath := filepath.Join(t.TempDir(), "storages.yaml")
+	require.Nil(t, os.WriteFile(configPath, []byte(storages.String()), 0o600))
+	testSuite.Config = config.Config{
+		ArtifactStorageConfig: configPath,
+	}
+	suite.Run(t, testSuite)
+}
+
+// newTokenArtifactServer serves the same content for any artifact path when the request has the token.
+func newTokenArtifactServer(t *testing.T, token string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+token {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		//nolint:errcheck
+		w.Write([]byte("content of " + token))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (s *GetArtifactStorageConfigTestSuite) Test_Ok() {
+	for token, server := range s.servers {
+		s.Run(token, func() {
+			experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+				Name:             "Test Experiment On Server With " + token,
+				NamespaceID:      s.DefaultNamespace.ID,
+				LifecycleStage:   models.LifecycleStageActive,
+				ArtifactLocation: server.URL + "/1",
+			})
+			s.Require().Nil(err)
+			runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+			run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+				ID:             runID,
+				Status:         models.StatusRunning,
+				SourceType:     "JOB",
+				ExperimentID:   *experiment.ID,
+				ArtifactURI:    experiment.ArtifactLocation + "/" + runID + "/artifacts",
+				LifecycleStage: models.LifecycleStageActive,
+			})
+			s.Require().Nil(err)
+
+			resp := new(bytes.Buffer)
+			s.Require().Nil(s.MlflowClient().WithQuery(
+				request.GetArtifactRequest{
+					RunID: run.ID,
+					Path:  "artifact.file",
+				},
+			).WithResponseType(
+				helpers.ResponseTypeBuffer,
+			).WithResponse(
+				resp,
+			).DoRequest(
+				"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsGetRoute,
+			))
+			s.Equal("content of "+token, resp.String())
+		})
+	}
+}
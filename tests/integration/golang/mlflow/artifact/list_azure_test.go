@@ -0,0 +1,154 @@
+package artifact
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api/request"
+	"github.com/G-Research/fasttrackml/pkg/common/api/response"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type ListArtifactAzureTestSuite struct {
+	helpers.AzureTestSuite
+}
+
+func TestListArtifactAzureTestSuite(t *testing.T) {
+	suite.Run(t, &ListArtifactAzureTestSuite{
+		helpers.NewAzureTestSuite("container1", "container2"),
+	})
+}
+
+func (s *ListArtifactAzureTestSuite) Test_Ok() {
+	tests := []struct {
+		name      string
+		scheme    string
+		container string
+	}{
+		{
+			name:      "TestWithBlobStorage",
+			scheme:    "wasbs",
+			container: "container1",
+		},
+		{
+			name:      "TestWithDataLakeStorage",
+			scheme:    "abfss",
+			container: "container2",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			// 1. create test experiment.
+			experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+				Name:             fmt.Sprintf("Test Experiment In Container %s", tt.container),
+				NamespaceID:      s.DefaultNamespace.ID,
+				LifecycleStage:   models.LifecycleStageActive,
+				ArtifactLocation: fmt.Sprintf("%s://%s@%s/1", tt.scheme, tt.container, helpers.AzureHost),
+			})
+			s.Require().Nil(err)
+
+			// 2. create test run.
+			runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+			run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+				ID:             runID,
+				Status:         models.StatusRunning,
+				SourceType:     "JOB",
+				ExperimentID:   *experiment.ID,
+				ArtifactURI:    fmt.Sprintf("%s/%s/artifacts", experiment.ArtifactLocation, runID),
+				LifecycleStage: models.LifecycleStageActive,
+			})
+			s.Require().Nil(err)
+
+			// 3. upload artifact objects to Azure.
+			s.Require().Nil(s.Client.PutBlob(
+				context.Background(),
+				helpers.AzureHost,
+				tt.container,
+				fmt.Sprintf("1/%s/artifacts/artifact.file1", runID),
+				strings.NewReader("contentX"),
+			))
+			s.Require().Nil(s.Client.PutBlob(
+				context.Background(),
+				helpers.AzureHost,
+				tt.container,
+				fmt.Sprintf("1/%s/artifacts/artifact.dir/artifact.file2", runID),
+				strings.NewReader("contentXX"),
+			))
+
+			// 4. make actual API call for root dir.
+			rootDirResp := response.ListArtifactsResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithQuery(
+					request.ListArtifactsRequest{
+						RunID: run.ID,
+					},
+				).WithResponse(
+					&rootDirResp,
+				).DoRequest(
+					"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsListRoute,
+				),
+			)
+			s.Equal(run.ArtifactURI, rootDirResp.RootURI)
+			s.Equal([]response.FilePartialResponse{
+				{
+					Path:     "artifact.dir",
+					IsDir:    true,
+					FileSize: 0,
+				},
+				{
+					Path:     "artifact.file1",
+					IsDir:    false,
+					FileSize: 8,
+				},
+			}, rootDirResp.Files)
+
+			// 5. make actual API call for sub dir.
+			subDirResp := response.ListArtifactsResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithQuery(
+					request.ListArtifactsRequest{
+						RunID: run.ID,
+						Path:  "artifact.dir",
+					},
+				).WithResponse(
+					&subDirResp,
+				).DoRequest(
+					"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsListRoute,
+				),
+			)
+			s.Equal(run.ArtifactURI, subDirResp.RootURI)
+			s.Equal([]response.FilePartialResponse{
+				{
+					Path:     "artifact.dir/artifact.file2",
+					IsDir:    false,
+					FileSize: 9,
+				},
+			}, subDirResp.Files)
+
+			// 6. make actual API call for non-existing dir.
+			nonExistingDirResp := response.ListArtifactsResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithQuery(
+					request.ListArtifactsRequest{
+						RunID: run.ID,
+						Path:  "non-existing-dir",
+					},
+				).WithResponse(
+					&nonExistingDirResp,
+				).DoRequest(
+					"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsListRoute,
+				),
+			)
+			s.Equal(run.ArtifactURI, nonExistingDirResp.RootURI)
+			s.Equal(0, len(nonExistingDirResp.Files))
+		})
+	}
+}
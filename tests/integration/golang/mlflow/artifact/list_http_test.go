@@ -0,0 +1,126 @@
+package artifact
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api/request"
+	"github.com/G-Research/fasttrackml/pkg/common/api/response"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type ListArtifactHTTPTestSuite struct {
+	helpers.BaseTestSuite
+	root   string
+	server *httptest.Server
+}
+
+func TestListArtifactHTTPTestSuite(t *testing.T) {
+	// serve artifacts with a static file server.
+	root := t.TempDir()
+	server := httptest.NewServer(http.FileServer(http.Dir(root)))
+	t.Cleanup(server.Close)
+
+	testSuite := &ListArtifactHTTPTestSuite{root: root, server: server}
+	testSuite.Config = config.Config{
+		ArtifactStorageConfig: writeHTTPStorageConfig(t, server.URL),
+	}
+	suite.Run(t, testSuite)
+}
+
+// writeHTTPStorageConfig writes artifact storage configuration allowing the artifacts of the server.
+func writeHTTPStorageConfig(t *testing.T, serverURL string) string {
+	configPath := filepath.Join(t.TempDir(), "storages.yaml")
+	require.Nil(t, os.WriteFile(configPath, []byte("storages:\n  - prefix: "+serverURL+"\n"), 0o600))
+	return configPath
+}
+
+func (s *ListArtifactHTTPTestSuite) Test_Ok() {
+	// 1. create artifacts served by the file server.
+	runID := strings.ReplaceAll(uuid.New().String(), "-", "")
+	artifactsDir := filepath.Join(s.root, "1", runID, "artifacts")
+	s.Require().Nil(os.MkdirAll(filepath.Join(artifactsDir, "artifact.dir"), 0o755))
+	s.Require().Nil(os.WriteFile(filepath.Join(artifactsDir, "artifact.file1"), []byte("contentX"), 0o600))
+	s.Require().Nil(os.WriteFile(
+		filepath.Join(artifactsDir, "artifact.dir", "artifact.file2"), []byte("contentXX"), 0o600,
+	))
+
+	// 2. create test experiment and run.
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:             "Test Experiment On HTTP Server",
+		NamespaceID:      s.DefaultNamespace.ID,
+		LifecycleStage:   models.LifecycleStageActive,
+		ArtifactLocation: s.server.URL + "/1",
+	})
+	s.Require().Nil(err)
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             runID,
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		ExperimentID:   *experiment.ID,
+		ArtifactURI:    experiment.ArtifactLocation + "/" + runID + "/artifacts",
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	// 3. make actual API call for root dir.
+	rootDirResp := response.ListArtifactsResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(
+			request.ListArtifactsRequest{
+				RunID: run.ID,
+			},
+		).WithResponse(
+			&rootDirResp,
+		).DoRequest(
+			"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsListRoute,
+		),
+	)
+	s.Equal(run.ArtifactURI, rootDirResp.RootURI)
+	s.Equal([]response.FilePartialResponse{
+		{
+			Path:     "artifact.dir",
+			IsDir:    true,
+			FileSize: 0,
+		},
+		{
+			Path:     "artifact.file1",
+			IsDir:    false,
+			FileSize: 8,
+		},
+	}, rootDirResp.Files)
+
+	// 4. make actual API call for sub dir.
+	subDirResp := response.ListArtifactsResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(
+			request.ListArtifactsRequest{
+				RunID: run.ID,
+				Path:  "artifact.dir",
+			},
+		).WithResponse(
+			&subDirResp,
+		).DoRequest(
+			"%s%s", mlflow.ArtifactsRoutePrefix, mlflow.ArtifactsListRoute,
+		),
+	)
+	s.Equal([]response.FilePartialResponse{
+		{
+			Path:     "artifact.dir/artifact.file2",
+			IsDir:    false,
+			FileSize: 9,
+		},
+	}, subDirResp.Files)
+}
@@ -0,0 +1,406 @@
+package artifact
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/api/request"
+	"github.com/G-Research/fasttrackml/pkg/common/api/response"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type MlflowArtifactsLocalTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestMlflowArtifactsLocalTestSuite(t *testing.T) {
+	testSuite := new(MlflowArtifactsLocalTestSuite)
+	testSuite.Config = config.Config{
+		ArtifactsDestination: t.TempDir(),
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *MlflowArtifactsLocalTestSuite) Test_Ok() {
+	// 1. upload artifacts.
+	for artifactPath, content := range map[string]string{
+		"0/run-1/artifacts/artifact.file1":                "contentX",
+		"0/run-1/artifacts/artifact.dir/artifact.file2":   "contentXX",
+		"0/run-1/artifacts/artifact.dir/artifact.file%20": "contentXXX",
+	} {
+		resp := map[string]any{}
+		s.Require().Nil(s.MlflowArtifactsClient().WithMethod(
+			http.MethodPut,
+		).WithRequest(
+			[]byte(content),
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s/%s", mlflow.MlflowArtifactsRoutePrefix, artifactPath,
+		))
+	}
+
+	// 2. check that artifacts were stored in the artifacts destination.
+	// #nosec G304
+	content, err := os.ReadFile(
+		filepath.Join(s.Config.ArtifactsDestination, "0", "run-1", "artifacts", "artifact.dir", "artifact.file "),
+	)
+	s.Require().Nil(err)
+	s.Equal("contentXXX", string(content))
+
+	// 3. list artifacts.
+	listResp := response.ListProxiedArtifactsResponse{}
+	s.Require().Nil(s.MlflowArtifactsClient().WithQuery(
+		request.ListProxiedArtifactsRequest{Path: "0/run-1/artifacts"},
+	).WithResponse(
+		&listResp,
+	).DoRequest(
+		mlflow.MlflowArtifactsRoutePrefix,
+	))
+	s.Equal([]response.FilePartialResponse{
+		{Path: "artifact.dir", IsDir: true},
+		{Path: "artifact.file1", FileSize: 8},
+	}, listResp.Files)
+
+	// 4. download artifact.
+	downloadResp := new(bytes.Buffer)
+	s.Require().Nil(s.MlflowArtifactsClient().WithResponseType(
+		helpers.ResponseTypeBuffer,
+	).WithResponse(
+		downloadResp,
+	).DoRequest(
+		"%s/%s", mlflow.MlflowArtifactsRoutePrefix, "0/run-1/artifacts/artifact.dir/artifact.file2",
+	))
+	s.Equal("contentXX", downloadResp.String())
+
+	// 5. delete artifact directory.
+	resp := map[string]any{}
+	s.Require().Nil(s.MlflowArtifactsClient().WithMethod(
+		http.MethodDelete,
+	).WithResponse(
+		&resp,
+	).DoRequest(
+		"%s/%s", mlflow.MlflowArtifactsRoutePrefix, "0/run-1/artifacts/artifact.dir",
+	))
+
+	listResp = response.ListProxiedArtifactsResponse{}
+	s.Require().Nil(s.MlflowArtifactsClient().WithQuery(
+		request.ListProxiedArtifactsRequest{Path: "0/run-1/artifacts"},
+	).WithResponse(
+		&listResp,
+	).DoRequest(
+		mlflow.MlflowArtifactsRoutePrefix,
+	))
+	s.Equal([]response.FilePartialResponse{
+		{Path: "artifact.file1", FileSize: 8},
+	}, listResp.Files)
+}
+
+func (s *MlflowArtifactsLocalTestSuite) Test_MultipartUpload_Ok() {
+	// 1. create multipart upload.
+	createResp := response.CreateMultipartUploadResponse{}
+	s.Require().Nil(s.MlflowArtifactsClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.CreateMultipartUploadRequest{
+			Path:     "/local/path/model.bin",
+			NumParts: 2,
+		},
+	).WithResponse(
+		&createResp,
+	).DoRequest(
+		"%s%s", mlflow.MlflowArtifactsMultipartUploadRoutePrefix, "/create/0/run-2/artifacts",
+	))
+	s.NotEmpty(createResp.UploadID)
+	s.Require().Len(createResp.Credentials, 2)
+
+	// 2. upload parts in reverse order to the provided urls.
+	parts := make([]request.MultipartUploadPartPartialRequest, len(createResp.Credentials))
+	for i := len(createResp.Credentials) - 1; i >= 0; i-- {
+		credential := createResp.Credentials[i]
+		partURL, err := url.Parse(credential.URL)
+		s.Require().Nil(err)
+		s.Equal("/api/2.0/mlflow-artifacts/mpu/upload/0/run-2/artifacts", partURL.Path)
+		s.Equal(createResp.UploadID, partURL.Query().Get("upload_id"))
+
+		resp := map[string]any{}
+		s.Require().Nil(s.MlflowArtifactsClient().WithMethod(
+			http.MethodPut,
+		).WithQuery(
+			request.UploadMultipartPartRequest{
+				UploadID:   createResp.UploadID,
+				PartNumber: credential.PartNumber,
+			},
+		).WithRequest(
+			[]byte(fmt.Sprintf("part%d;", credential.PartNumber)),
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.MlflowArtifactsMultipartUploadRoutePrefix, "/upload/0/run-2/artifacts",
+		))
+		parts[i] = request.MultipartUploadPartPartialRequest{PartNumber: credential.PartNumber}
+	}
+
+	// 3. staged parts should not be visible.
+	listResp := response.ListProxiedArtifactsResponse{}
+	s.Require().Nil(s.MlflowArtifactsClient().WithResponse(
+		&listResp,
+	).DoRequest(
+		mlflow.MlflowArtifactsRoutePrefix,
+	))
+	for _, file := range listResp.Files {
+		s.NotEqual(".mlflow-mpu", file.Path)
+	}
+
+	// 4. complete multipart upload.
+	resp := map[string]any{}
+	s.Require().Nil(s.MlflowArtifactsClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.CompleteMultipartUploadRequest{
+			Path:     "/local/path/model.bin",
+			UploadID: createResp.UploadID,
+			Parts:    parts,
+		},
+	).WithResponse(
+		&resp,
+	).DoRequest(
+		"%s%s", mlflow.MlflowArtifactsMultipartUploadRoutePrefix, "/complete/0/run-2/artifacts",
+	))
+
+	// 5. check the result.
+	downloadResp := new(bytes.Buffer)
+	s.Require().Nil(s.MlflowArtifactsClient().WithResponseType(
+		helpers.ResponseTypeBuffer,
+	).WithResponse(
+		downloadResp,
+	).DoRequest(
+		"%s/%s", mlflow.MlflowArtifactsRoutePrefix, "0/run-2/artifacts/model.bin",
+	))
+	s.Equal("part1;part2;", downloadResp.String())
+
+	_, err := os.Stat(filepath.Join(s.Config.ArtifactsDestination, ".mlflow-mpu", createResp.UploadID))
+	s.True(os.IsNotExist(err))
+}
+
+func (s *MlflowArtifactsLocalTestSuite) Test_UploadLargeArtifact_Ok() {
+	// artifact is larger than the body limit of the other routes.
+	content := bytes.Repeat([]byte("0123456789abcdef"), 20*1024*1024/16)
+
+	// 1. upload artifact.
+	resp := map[string]any{}
+	client := s.MlflowArtifactsClient()
+	s.Require().Nil(client.WithMethod(
+		http.MethodPut,
+	).WithRequest(
+		content,
+	).WithResponse(
+		&resp,
+	).DoRequest(
+		"%s/%s", mlflow.MlflowArtifactsRoutePrefix, "0/run-3/artifacts/large.bin",
+	))
+	s.Equal(http.StatusOK, client.GetStatusCode())
+
+	// 2. upload the same content as a part of multipart upload.
+	createResp := response.CreateMultipartUploadResponse{}
+	s.Require().Nil(s.MlflowArtifactsClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.CreateMultipartUploadRequest{
+			Path:     "/local/path/large-mpu.bin",
+			NumParts: 1,
+		},
+	).WithResponse(
+		&createResp,
+	).DoRequest(
+		"%s%s", mlflow.MlflowArtifactsMultipartUploadRoutePrefix, "/create/0/run-3/artifacts",
+	))
+	s.Require().Len(createResp.Credentials, 1)
+	s.Require().Nil(s.MlflowArtifactsClient().WithMethod(
+		http.MethodPut,
+	).WithQuery(
+		request.UploadMultipartPartRequest{
+			UploadID:   createResp.UploadID,
+			PartNumber: createResp.Credentials[0].PartNumber,
+		},
+	).WithRequest(
+		content,
+	).WithResponse(
+		&resp,
+	).DoRequest(
+		"%s%s", mlflow.MlflowArtifactsMultipartUploadRoutePrefix, "/upload/0/run-3/artifacts",
+	))
+	s.Require().Nil(s.MlflowArtifactsClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.CompleteMultipartUploadRequest{
+			Path:     "/local/path/large-mpu.bin",
+			UploadID: createResp.UploadID,
+			Parts: []request.MultipartUploadPartPartialRequest{
+				{PartNumber: createResp.Credentials[0].PartNumber},
+			},
+		},
+	).WithResponse(
+		&resp,
+	).DoRequest(
+		"%s%s", mlflow.MlflowArtifactsMultipartUploadRoutePrefix, "/complete/0/run-3/artifacts",
+	))
+
+	// 3. check that both artifacts were stored as a whole.
+	for _, name := range []string{"large.bin", "large-mpu.bin"} {
+		// #nosec G304
+		stored, err := os.ReadFile(
+			filepath.Join(s.Config.ArtifactsDestination, "0", "run-3", "artifacts", name),
+		)
+		s.Require().Nil(err)
+		s.Equal(len(content), len(stored))
+		s.True(bytes.Equal(content, stored))
+	}
+
+	// 4. body limit is still applied to the other routes.
+	errorResp := api.ErrorResponse{}
+	client = s.MlflowArtifactsClient()
+	s.Require().Nil(client.WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		content,
+	).WithResponse(
+		&errorResp,
+	).DoRequest(
+		"%s%s", mlflow.MlflowArtifactsMultipartUploadRoutePrefix, "/create/0/run-3/artifacts",
+	))
+	s.Equal(http.StatusBadRequest, client.GetStatusCode())
+	s.Equal(api.ErrorCodeBadRequest, string(errorResp.ErrorCode))
+}
+
+func (s *MlflowArtifactsLocalTestSuite) Test_NamespaceIsolation_Ok() {
+	// 1. create experiment in another namespace and upload its artifact.
+	namespace, err := s.NamespaceFixtures.CreateNamespace(context.Background(), &models.Namespace{
+		Code:                "other",
+		DefaultExperimentID: common.GetPointer(models.DefaultExperimentID),
+	})
+	s.Require().Nil(err)
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           "Other Experiment",
+		NamespaceID:    namespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	artifactPath := fmt.Sprintf("%d/run-4/artifacts/artifact.file", *experiment.ID)
+
+	resp := map[string]any{}
+	s.Require().Nil(s.MlflowArtifactsClient().WithMethod(
+		http.MethodPut,
+	).WithNamespace(
+		namespace.Code,
+	).WithRequest(
+		[]byte("content"),
+	).WithResponse(
+		&resp,
+	).DoRequest(
+		"%s/%s", mlflow.MlflowArtifactsRoutePrefix, artifactPath,
+	))
+
+	// 2. artifact is not visible in the default namespace.
+	listResp := response.ListProxiedArtifactsResponse{}
+	s.Require().Nil(s.MlflowArtifactsClient().WithResponse(
+		&listResp,
+	).DoRequest(
+		mlflow.MlflowArtifactsRoutePrefix,
+	))
+	for _, file := range listResp.Files {
+		s.NotEqual(fmt.Sprint(*experiment.ID), file.Path)
+	}
+
+	for _, method := range []string{http.MethodGet, http.MethodPut, http.MethodDelete} {
+		errorResp := api.ErrorResponse{}
+		s.Require().Nil(s.MlflowArtifactsClient().WithMethod(
+			method,
+		).WithResponse(
+			&errorResp,
+		).DoRequest(
+			"%s/%s", mlflow.MlflowArtifactsRoutePrefix, artifactPath,
+		))
+		s.Equal(api.ErrorCodeResourceDoesNotExist, string(errorResp.ErrorCode))
+	}
+
+	// 3. artifact is still there for its own namespace.
+	downloadResp := new(bytes.Buffer)
+	s.Require().Nil(s.MlflowArtifactsClient().WithNamespace(
+		namespace.Code,
+	).WithResponseType(
+		helpers.ResponseTypeBuffer,
+	).WithResponse(
+		downloadResp,
+	).DoRequest(
+		"%s/%s", mlflow.MlflowArtifactsRoutePrefix, artifactPath,
+	))
+	s.Equal("content", downloadResp.String())
+}
+
+func (s *MlflowArtifactsLocalTestSuite) Test_Error() {
+	tests := []struct {
+		name   string
+		error  *api.ErrorResponse
+		method string
+		path   string
+	}{
+		{
+			name:   "DownloadNonExistingArtifact",
+			error:  api.NewResourceDoesNotExistError("artifact '0/non-existing.file' not found"),
+			method: http.MethodGet,
+			path:   "0/non-existing.file",
+		},
+		{
+			name:   "DownloadArtifactOutsideOfExperiment",
+			error:  api.NewPermissionDeniedError("artifact path 'artifact.file' doesn't belong to any experiment"),
+			method: http.MethodGet,
+			path:   "artifact.file",
+		},
+		{
+			name:   "UploadArtifactOfNonExistingExperiment",
+			error:  api.NewResourceDoesNotExistError("unable to find experiment '123'"),
+			method: http.MethodPut,
+			path:   "123/run-id/artifacts/artifact.file",
+		},
+		{
+			name:   "UploadArtifactOutsideOfDestination",
+			error:  api.NewInvalidParameterValueError("Invalid path"),
+			method: http.MethodPut,
+			path:   "foo/..%2F..%2Fbar",
+		},
+		{
+			name:   "DeleteMultipartUploads",
+			error:  api.NewInvalidParameterValueError("Invalid path"),
+			method: http.MethodDelete,
+			path:   ".mlflow-mpu",
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(s.MlflowArtifactsClient().WithMethod(
+				tt.method,
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s/%s", mlflow.MlflowArtifactsRoutePrefix, tt.path,
+			))
+			s.Contains(resp.Error(), tt.error.Error())
+		})
+	}
+}
@@ -77,6 +77,17 @@ func (s *CreateExperimentTestSuite) Test_Error() {
 				ArtifactLocation: "incorrect-protocol,:/incorrect-location",
 			},
 		},
+		{
+			name: "NotConfiguredHTTPArtifactLocationProperty",
+			error: api.NewInvalidParameterValueError(
+				`Invalid value for parameter 'artifact_location': http artifact uri has to match one of the ` +
+					`prefixes of artifact storage configuration: https://artifacts.example.com/location`,
+			),
+			request: &request.CreateExperimentRequest{
+				Name:             "name",
+				ArtifactLocation: "https://artifacts.example.com/location",
+			},
+		},
 	}
 
 	for _, tt := range testData {
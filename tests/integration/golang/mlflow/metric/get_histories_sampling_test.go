@@ -0,0 +1,229 @@
+package metric
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type GetHistoriesSamplingTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestGetHistoriesSamplingTestSuite(t *testing.T) {
+	suite.Run(t, new(GetHistoriesSamplingTestSuite))
+}
+
+func (s *GetHistoriesSamplingTestSuite) Test_Ok() {
+	experiment, err := s.ExperimentFixtures.CreateExperiment(context.Background(), &models.Experiment{
+		Name:           "Test Experiment",
+		NamespaceID:    s.DefaultNamespace.ID,
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             "run1",
+		Name:           "chill-run",
+		Status:         models.StatusRunning,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		ExperimentID:   *experiment.ID,
+	})
+	s.Require().Nil(err)
+
+	// create saw-like series of 200 points with a single spike.
+	for i := 0; i < 200; i++ {
+		value := float64(i % 10)
+		if i == 137 {
+			value = 100
+		}
+		_, err = s.MetricFixtures.CreateMetric(context.Background(), &models.Metric{
+			Key:       "key1",
+			Value:     value,
+			Timestamp: int64(1234567890 + i),
+			RunID:     run.ID,
+			Step:      int64(i),
+			Iter:      int64(i),
+		})
+		s.Require().Nil(err)
+	}
+	_, err = s.MetricFixtures.CreateLatestMetric(context.Background(), &models.LatestMetric{
+		Key:       "key1",
+		Value:     9,
+		Timestamp: 1234567890 + 199,
+		RunID:     run.ID,
+		Step:      199,
+		LastIter:  199,
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name           string
+		request        *request.GetMetricHistoriesRequest
+		verifyResponse func([]models.Metric)
+	}{
+		{
+			name: "WithoutSampling",
+			request: &request.GetMetricHistoriesRequest{
+				RunIDs: []string{run.ID},
+			},
+			verifyResponse: func(metrics []models.Metric) {
+				s.Equal(200, len(metrics))
+			},
+		},
+		{
+			name: "WithMinMaxSampling",
+			request: &request.GetMetricHistoriesRequest{
+				RunIDs:    []string{run.ID},
+				MaxPoints: 20,
+				Sampling:  "minmax",
+			},
+			verifyResponse: func(metrics []models.Metric) {
+				s.Equal(20, len(metrics))
+				values := make([]float64, len(metrics))
+				for i, metric := range metrics {
+					values[i] = metric.Value
+					if i > 0 {
+						s.Less(metrics[i-1].Step, metric.Step)
+					}
+				}
+				s.Contains(values, float64(100))
+				s.Contains(values, float64(0))
+				s.Contains(values, float64(9))
+			},
+		},
+		{
+			name: "WithMinMaxSamplingAndSinglePoint",
+			request: &request.GetMetricHistoriesRequest{
+				RunIDs:    []string{run.ID},
+				MaxPoints: 1,
+				Sampling:  "minmax",
+			},
+			verifyResponse: func(metrics []models.Metric) {
+				s.Require().Equal(1, len(metrics))
+				s.Equal(float64(100), metrics[0].Value)
+			},
+		},
+		{
+			name: "WithMinMaxSamplingAndOddMaxPoints",
+			request: &request.GetMetricHistoriesRequest{
+				RunIDs:    []string{run.ID},
+				MaxPoints: 7,
+				Sampling:  "minmax",
+			},
+			verifyResponse: func(metrics []models.Metric) {
+				s.LessOrEqual(len(metrics), 7)
+				values := make([]float64, len(metrics))
+				for i, metric := range metrics {
+					values[i] = metric.Value
+				}
+				s.Contains(values, float64(100))
+			},
+		},
+		{
+			name: "WithLTTBSampling",
+			request: &request.GetMetricHistoriesRequest{
+				RunIDs:    []string{run.ID},
+				MaxPoints: 20,
+			},
+			verifyResponse: func(metrics []models.Metric) {
+				s.Equal(20, len(metrics))
+				s.Equal(int64(0), metrics[0].Step)
+				s.Equal(int64(199), metrics[len(metrics)-1].Step)
+				values := make([]float64, len(metrics))
+				for i, metric := range metrics {
+					values[i] = metric.Value
+				}
+				s.Contains(values, float64(100))
+			},
+		},
+		{
+			name: "WithMaxPointsGreaterThanSeries",
+			request: &request.GetMetricHistoriesRequest{
+				RunIDs:    []string{run.ID},
+				MaxPoints: 1000,
+				Sampling:  "minmax",
+			},
+			verifyResponse: func(metrics []models.Metric) {
+				s.Equal(200, len(metrics))
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := new(bytes.Buffer)
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponseType(
+					helpers.ResponseTypeBuffer,
+				).WithResponse(
+					resp,
+				).DoRequest(
+					"%s%s", mlflow.MetricsRoutePrefix, mlflow.MetricsGetHistoriesRoute,
+				),
+			)
+
+			metrics, err := helpers.DecodeArrowMetrics(resp)
+			s.Require().Nil(err)
+			tt.verifyResponse(metrics)
+		})
+	}
+}
+
+func (s *GetHistoriesSamplingTestSuite) Test_Error() {
+	tests := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request request.GetMetricHistoriesRequest
+	}{
+		{
+			name: "IncorrectMaxPoints",
+			request: request.GetMetricHistoriesRequest{
+				RunIDs:    []string{"id"},
+				MaxPoints: -1,
+			},
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'max_points' supplied."),
+		},
+		{
+			name: "UnsupportedSampling",
+			request: request.GetMetricHistoriesRequest{
+				RunIDs:    []string{"id"},
+				MaxPoints: 10,
+				Sampling:  "average",
+			},
+			error: api.NewInvalidParameterValueError(
+				"Invalid value 'average' for parameter 'sampling'. Valid values are ['lttb', 'minmax']",
+			),
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.MetricsRoutePrefix, mlflow.MetricsGetHistoriesRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package metric
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/pkg/common/dao/types"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type LogMetricBulkTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestLogMetricBulkTestSuite(t *testing.T) {
+	suite.Run(t, new(LogMetricBulkTestSuite))
+}
+
+func (s *LogMetricBulkTestSuite) SetupTest() {
+	s.BaseTestSuite.SetupTest()
+
+	for _, id := range []string{"run1", "run2"} {
+		_, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             id,
+			Name:           id,
+			Status:         models.StatusRunning,
+			SourceType:     "JOB",
+			ExperimentID:   *s.DefaultExperiment.ID,
+			LifecycleStage: models.LifecycleStageActive,
+		})
+		s.Require().Nil(err)
+	}
+}
+
+func (s *LogMetricBulkTestSuite) Test_Ok() {
+	body, err := helpers.EncodeArrowMetrics([]models.Metric{
+		{RunID: "run1", Key: "loss", Value: 1.5, Timestamp: 1687325991, Step: 0},
+		{RunID: "run2", Key: "loss", Value: 2.5, Timestamp: 1687325991, Step: 0},
+		{RunID: "run1", Key: "loss", IsNan: true, Timestamp: 1687325992, Step: 1},
+		{RunID: "run1", Key: "loss", Value: 0.5, Timestamp: 1687325993, Step: 2},
+		{
+			RunID: "run2", Key: "accuracy", Value: 0.75, Timestamp: 1687325992, Step: 1,
+			Context: models.Context{Json: types.JSONB(`{"subset":"train"}`)},
+		},
+	})
+	s.Require().Nil(err)
+
+	resp := map[string]any{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			body,
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.MetricsRoutePrefix, mlflow.MetricsLogBulkRoute,
+		),
+	)
+	s.Empty(resp)
+
+	metrics, err := s.MetricFixtures.GetMetricsByRunID(context.Background(), "run1")
+	s.Require().Nil(err)
+	s.Len(metrics, 3)
+	latestMetric, err := s.MetricFixtures.GetLatestMetricByRunID(context.Background(), "run1")
+	s.Require().Nil(err)
+	s.Equal("loss", latestMetric.Key)
+	s.Equal(0.5, latestMetric.Value)
+	s.Equal(int64(2), latestMetric.Step)
+	s.Equal(int64(3), latestMetric.LastIter)
+
+	metrics, err = s.MetricFixtures.GetMetricsByRunID(context.Background(), "run2")
+	s.Require().Nil(err)
+	s.Len(metrics, 2)
+	metrics, err = s.MetricFixtures.GetMetricsByContext(context.Background(), map[string]string{"subset": "train"})
+	s.Require().Nil(err)
+	s.Require().Len(metrics, 1)
+	s.Equal("run2", metrics[0].RunID)
+	s.Equal("accuracy", metrics[0].Key)
+	s.Equal(0.75, metrics[0].Value)
+}
+
+func (s *LogMetricBulkTestSuite) Test_Error() {
+	validBody, err := helpers.EncodeArrowMetrics([]models.Metric{
+		{RunID: "run1", Key: "loss", Value: 1.5, Timestamp: 1687325991},
+		{RunID: "unknown", Key: "loss", Value: 2.5, Timestamp: 1687325991},
+	})
+	s.Require().Nil(err)
+	emptyKeyBody, err := helpers.EncodeArrowMetrics([]models.Metric{
+		{RunID: "run1", Value: 1.5, Timestamp: 1687325991},
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name  string
+		body  []byte
+		error *api.ErrorResponse
+	}{
+		{
+			name:  "NotArrowStream",
+			body:  []byte(`{"metrics": []}`),
+			error: api.NewBadRequestError("Unable to decode request body: error creating Arrow reader"),
+		},
+		{
+			name:  "EmptyKey",
+			body:  emptyKeyBody,
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'metrics' supplied"),
+		},
+		{
+			name:  "NotFoundRun",
+			body:  validBody,
+			error: api.NewResourceDoesNotExistError("Run 'unknown' not found"),
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.body,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.MetricsRoutePrefix, mlflow.MetricsLogBulkRoute,
+				),
+			)
+			s.Equal(tt.error.ErrorCode, resp.ErrorCode)
+			s.Contains(resp.Message, tt.error.Message)
+		})
+	}
+
+	// nothing is written, when any of the runs doesn't exist.
+	metrics, err := s.MetricFixtures.GetMetricsByRunID(context.Background(), "run1")
+	s.Require().Nil(err)
+	s.Empty(metrics)
+}
@@ -0,0 +1,201 @@
+package model
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type CreateModelVersionTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestCreateModelVersionTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateModelVersionTestSuite))
+}
+
+func (s *CreateModelVersionTestSuite) Test_Ok() {
+	registeredModel, err := s.RegisteredModelFixtures.CreateRegisteredModel(
+		context.Background(), &models.RegisteredModel{
+			Name:        "TestModel",
+			NamespaceID: s.DefaultNamespace.ID,
+		},
+	)
+	s.Require().Nil(err)
+
+	// create two versions, delete the last one and create one more. version numbers should never be reused.
+	for i := 0; i < 2; i++ {
+		resp := response.ModelVersionResponse{}
+		s.Require().Nil(
+			s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				request.CreateModelVersionRequest{
+					Name:        "TestModel",
+					Source:      "s3://bucket/model",
+					RunID:       "run-id",
+					Description: "description",
+					Tags: []request.ModelVersionTagPartialRequest{
+						{
+							Key:   "key",
+							Value: "value",
+						},
+					},
+				},
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s%s", mlflow.ModelVersionsRoutePrefix, mlflow.ModelVersionsCreateRoute,
+			),
+		)
+		s.Equal("TestModel", resp.ModelVersion.Name)
+		s.Equal(string(models.ModelVersionStageNone), resp.ModelVersion.CurrentStage)
+		s.Equal(string(models.ModelVersionStatusReady), resp.ModelVersion.Status)
+		s.Equal("s3://bucket/model", resp.ModelVersion.Source)
+		s.Equal("run-id", resp.ModelVersion.RunID)
+		s.Equal("description", resp.ModelVersion.Description)
+		s.Equal([]response.ModelVersionTagPartialResponse{
+			{
+				Key:   "key",
+				Value: "value",
+			},
+		}, resp.ModelVersion.Tags)
+	}
+
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodDelete,
+		).WithRequest(
+			request.DeleteModelVersionRequest{
+				Name:    "TestModel",
+				Version: "2",
+			},
+		).WithResponse(
+			&struct{}{},
+		).DoRequest(
+			"%s%s", mlflow.ModelVersionsRoutePrefix, mlflow.ModelVersionsDeleteRoute,
+		),
+	)
+
+	resp := response.ModelVersionResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.CreateModelVersionRequest{
+				Name:   "TestModel",
+				Source: "s3://bucket/model",
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.ModelVersionsRoutePrefix, mlflow.ModelVersionsCreateRoute,
+		),
+	)
+	s.Equal("3", resp.ModelVersion.Version)
+
+	versions, err := s.RegisteredModelFixtures.GetModelVersionsByRegisteredModelID(
+		context.Background(), registeredModel.ID,
+	)
+	s.Require().Nil(err)
+	s.Require().Len(versions, 3)
+	s.Equal(models.ModelVersionStageDeletedInternal, versions[1].CurrentStage)
+	s.Empty(versions[1].Tags)
+}
+
+func (s *CreateModelVersionTestSuite) Test_Concurrent() {
+	registeredModel, err := s.RegisteredModelFixtures.CreateRegisteredModel(
+		context.Background(), &models.RegisteredModel{
+			Name:        "ConcurrentModel",
+			NamespaceID: s.DefaultNamespace.ID,
+		},
+	)
+	s.Require().Nil(err)
+
+	// versions created at the same time should still get unique version numbers.
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				request.CreateModelVersionRequest{
+					Name:   "ConcurrentModel",
+					Source: "s3://bucket/model",
+				},
+			).WithResponse(
+				&response.ModelVersionResponse{},
+			).DoRequest(
+				"%s%s", mlflow.ModelVersionsRoutePrefix, mlflow.ModelVersionsCreateRoute,
+			)
+		}(i)
+	}
+	wg.Wait()
+	for _, err := range errs {
+		s.Require().Nil(err)
+	}
+
+	versions, err := s.RegisteredModelFixtures.GetModelVersionsByRegisteredModelID(
+		context.Background(), registeredModel.ID,
+	)
+	s.Require().Nil(err)
+	s.Require().Len(versions, len(errs))
+	for i, version := range versions {
+		s.Equal(int64(i+1), version.Version)
+	}
+}
+
+func (s *CreateModelVersionTestSuite) Test_Error() {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request request.CreateModelVersionRequest
+	}{
+		{
+			name:  "EmptySourceProperty",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'source'"),
+			request: request.CreateModelVersionRequest{
+				Name: "TestModel",
+			},
+		},
+		{
+			name:  "RegisteredModelNotFound",
+			error: api.NewResourceDoesNotExistError("Registered Model with name=UnknownModel not found"),
+			request: request.CreateModelVersionRequest{
+				Name:   "UnknownModel",
+				Source: "s3://bucket/model",
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.ModelVersionsRoutePrefix, mlflow.ModelVersionsCreateRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
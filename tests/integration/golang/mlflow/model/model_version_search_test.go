@@ -0,0 +1,111 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SearchModelVersionsTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSearchModelVersionsTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchModelVersionsTestSuite))
+}
+
+func (s *SearchModelVersionsTestSuite) Test_Ok() {
+	// 1. prepare database with test data.
+	for _, name := range []string{"ModelA", "ModelB"} {
+		registeredModel, err := s.RegisteredModelFixtures.CreateRegisteredModel(
+			context.Background(), &models.RegisteredModel{
+				Name:        name,
+				NamespaceID: s.DefaultNamespace.ID,
+			},
+		)
+		s.Require().Nil(err)
+		for _, version := range []models.ModelVersion{
+			{Version: 1, RunID: "run-1", CurrentStage: models.ModelVersionStageNone},
+			{Version: 2, RunID: "run-2", CurrentStage: models.ModelVersionStageNone},
+			{Version: 3, RunID: "run-3", CurrentStage: models.ModelVersionStageDeletedInternal},
+		} {
+			_, err = s.RegisteredModelFixtures.CreateModelVersion(context.Background(), &models.ModelVersion{
+				Version:           version.Version,
+				RunID:             version.RunID,
+				Source:            "s3://bucket/" + name,
+				CurrentStage:      version.CurrentStage,
+				Status:            models.ModelVersionStatusReady,
+				RegisteredModelID: registeredModel.ID,
+			})
+			s.Require().Nil(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		request  request.SearchModelVersionsRequest
+		expected []string
+	}{
+		{
+			name:     "NoFilter",
+			request:  request.SearchModelVersionsRequest{},
+			expected: []string{"ModelA/2", "ModelA/1", "ModelB/2", "ModelB/1"},
+		},
+		{
+			name: "FilterByName",
+			request: request.SearchModelVersionsRequest{
+				Filter: "name = 'ModelB'",
+			},
+			expected: []string{"ModelB/2", "ModelB/1"},
+		},
+		{
+			name: "FilterByRunIDs",
+			request: request.SearchModelVersionsRequest{
+				Filter: "run_id IN ('run-1', 'run-3')",
+			},
+			expected: []string{"ModelA/1", "ModelB/1"},
+		},
+		{
+			name: "FilterByVersionNumber",
+			request: request.SearchModelVersionsRequest{
+				Filter: "version_number > 1",
+			},
+			expected: []string{"ModelA/2", "ModelB/2"},
+		},
+		{
+			name: "OrderByVersionNumber",
+			request: request.SearchModelVersionsRequest{
+				Filter:  "source_path LIKE '%ModelA'",
+				OrderBy: []string{"version_number ASC"},
+			},
+			expected: []string{"ModelA/1", "ModelA/2"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := response.SearchModelVersionsResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithQuery(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.ModelVersionsRoutePrefix, mlflow.ModelVersionsSearchRoute,
+				),
+			)
+			versions := make([]string, 0, len(resp.ModelVersions))
+			for _, version := range resp.ModelVersions {
+				versions = append(versions, version.Name+"/"+version.Version)
+			}
+			s.Equal(tt.expected, versions)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package model
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type TransitionModelVersionStageTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestTransitionModelVersionStageTestSuite(t *testing.T) {
+	suite.Run(t, new(TransitionModelVersionStageTestSuite))
+}
+
+func (s *TransitionModelVersionStageTestSuite) Test_Ok() {
+	registeredModel, err := s.RegisteredModelFixtures.CreateRegisteredModel(
+		context.Background(), &models.RegisteredModel{
+			Name:        "TestModel",
+			NamespaceID: s.DefaultNamespace.ID,
+		},
+	)
+	s.Require().Nil(err)
+	for _, version := range []models.ModelVersion{
+		{Version: 1, CurrentStage: models.ModelVersionStageProduction},
+		{Version: 2, CurrentStage: models.ModelVersionStageNone},
+	} {
+		_, err = s.RegisteredModelFixtures.CreateModelVersion(context.Background(), &models.ModelVersion{
+			Version:           version.Version,
+			Source:            "s3://bucket/model",
+			CurrentStage:      version.CurrentStage,
+			Status:            models.ModelVersionStatusReady,
+			RegisteredModelID: registeredModel.ID,
+		})
+		s.Require().Nil(err)
+	}
+
+	resp := response.ModelVersionResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.TransitionModelVersionStageRequest{
+				Name:                    "TestModel",
+				Version:                 "2",
+				Stage:                   "production",
+				ArchiveExistingVersions: true,
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.ModelVersionsRoutePrefix, mlflow.ModelVersionsTransitionStageRoute,
+		),
+	)
+	s.Equal(string(models.ModelVersionStageProduction), resp.ModelVersion.CurrentStage)
+
+	latest := response.GetLatestVersionsResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.GetLatestVersionsRequest{
+				Name: "TestModel",
+			},
+		).WithResponse(
+			&latest,
+		).DoRequest(
+			"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsGetLatestVersionsRoute,
+		),
+	)
+	stages := map[string]string{}
+	for _, version := range latest.ModelVersions {
+		stages[version.Version] = version.CurrentStage
+	}
+	s.Equal(map[string]string{
+		"1": string(models.ModelVersionStageArchived),
+		"2": string(models.ModelVersionStageProduction),
+	}, stages)
+}
+
+func (s *TransitionModelVersionStageTestSuite) Test_Error() {
+	registeredModel, err := s.RegisteredModelFixtures.CreateRegisteredModel(
+		context.Background(), &models.RegisteredModel{
+			Name:        "TestModel",
+			NamespaceID: s.DefaultNamespace.ID,
+		},
+	)
+	s.Require().Nil(err)
+	_, err = s.RegisteredModelFixtures.CreateModelVersion(context.Background(), &models.ModelVersion{
+		Version:           1,
+		Source:            "s3://bucket/model",
+		CurrentStage:      models.ModelVersionStageNone,
+		Status:            models.ModelVersionStatusReady,
+		RegisteredModelID: registeredModel.ID,
+	})
+	s.Require().Nil(err)
+
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request request.TransitionModelVersionStageRequest
+	}{
+		{
+			name:  "IncorrectStage",
+			error: api.NewInvalidParameterValueError("Invalid Model Version stage: unknown"),
+			request: request.TransitionModelVersionStageRequest{
+				Name:    "TestModel",
+				Version: "1",
+				Stage:   "unknown",
+			},
+		},
+		{
+			name:  "ModelVersionNotFound",
+			error: api.NewResourceDoesNotExistError("Model Version (name=TestModel, version=2) not found"),
+			request: request.TransitionModelVersionStageRequest{
+				Name:    "TestModel",
+				Version: "2",
+				Stage:   "Staging",
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.ModelVersionsRoutePrefix, mlflow.ModelVersionsTransitionStageRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package model
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type RegisteredModelAliasTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestRegisteredModelAliasTestSuite(t *testing.T) {
+	suite.Run(t, new(RegisteredModelAliasTestSuite))
+}
+
+func (s *RegisteredModelAliasTestSuite) Test_Ok() {
+	registeredModel, err := s.RegisteredModelFixtures.CreateRegisteredModel(
+		context.Background(), &models.RegisteredModel{
+			Name:        "TestModel",
+			NamespaceID: s.DefaultNamespace.ID,
+		},
+	)
+	s.Require().Nil(err)
+	for _, version := range []int64{1, 2} {
+		_, err = s.RegisteredModelFixtures.CreateModelVersion(context.Background(), &models.ModelVersion{
+			Version:           version,
+			Source:            "s3://bucket/model",
+			CurrentStage:      models.ModelVersionStageNone,
+			Status:            models.ModelVersionStatusReady,
+			RegisteredModelID: registeredModel.ID,
+		})
+		s.Require().Nil(err)
+	}
+
+	// 1. set alias and check that it points to the requested version.
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.SetRegisteredModelAliasRequest{
+				Name:    "TestModel",
+				Alias:   "champion",
+				Version: "1",
+			},
+		).WithResponse(
+			&struct{}{},
+		).DoRequest(
+			"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsAliasRoute,
+		),
+	)
+	resp := response.ModelVersionResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(
+			request.GetModelVersionByAliasRequest{
+				Name:  "TestModel",
+				Alias: "champion",
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsAliasRoute,
+		),
+	)
+	s.Equal("1", resp.ModelVersion.Version)
+	s.Equal([]string{"champion"}, resp.ModelVersion.Aliases)
+
+	// 2. move alias to another version.
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.SetRegisteredModelAliasRequest{
+				Name:    "TestModel",
+				Alias:   "champion",
+				Version: "2",
+			},
+		).WithResponse(
+			&struct{}{},
+		).DoRequest(
+			"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsAliasRoute,
+		),
+	)
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(
+			request.GetModelVersionByAliasRequest{
+				Name:  "TestModel",
+				Alias: "champion",
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsAliasRoute,
+		),
+	)
+	s.Equal("2", resp.ModelVersion.Version)
+
+	// 3. delete alias.
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodDelete,
+		).WithRequest(
+			request.DeleteRegisteredModelAliasRequest{
+				Name:  "TestModel",
+				Alias: "champion",
+			},
+		).WithResponse(
+			&struct{}{},
+		).DoRequest(
+			"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsAliasRoute,
+		),
+	)
+	registeredModel, err = s.RegisteredModelFixtures.GetRegisteredModelByNamespaceIDAndName(
+		context.Background(), s.DefaultNamespace.ID, "TestModel",
+	)
+	s.Require().Nil(err)
+	s.Empty(registeredModel.Aliases)
+}
+
+func (s *RegisteredModelAliasTestSuite) Test_Error() {
+	_, err := s.RegisteredModelFixtures.CreateRegisteredModel(context.Background(), &models.RegisteredModel{
+		Name:        "TestModel",
+		NamespaceID: s.DefaultNamespace.ID,
+	})
+	s.Require().Nil(err)
+
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request request.SetRegisteredModelAliasRequest
+	}{
+		{
+			name: "ReservedAliasName",
+			error: api.NewInvalidParameterValueError(
+				"Invalid alias name: 'latest'. Aliases cannot be 'latest' or have the format 'v<number>'",
+			),
+			request: request.SetRegisteredModelAliasRequest{
+				Name:    "TestModel",
+				Alias:   "latest",
+				Version: "1",
+			},
+		},
+		{
+			name:  "ModelVersionNotFound",
+			error: api.NewResourceDoesNotExistError("Model Version (name=TestModel, version=1) not found"),
+			request: request.SetRegisteredModelAliasRequest{
+				Name:    "TestModel",
+				Alias:   "champion",
+				Version: "1",
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsAliasRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
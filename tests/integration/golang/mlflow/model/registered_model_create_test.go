@@ -0,0 +1,111 @@
+package model
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type CreateRegisteredModelTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestCreateRegisteredModelTestSuite(t *testing.T) {
+	suite.Run(t, new(CreateRegisteredModelTestSuite))
+}
+
+func (s *CreateRegisteredModelTestSuite) Test_Ok() {
+	req := request.CreateRegisteredModelRequest{
+		Name:        "TestModel",
+		Description: "description",
+		Tags: []request.RegisteredModelTagPartialRequest{
+			{
+				Key:   "key",
+				Value: "value",
+			},
+		},
+	}
+	resp := response.RegisteredModelResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			req,
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsCreateRoute,
+		),
+	)
+	s.Equal("TestModel", resp.RegisteredModel.Name)
+	s.Equal("description", resp.RegisteredModel.Description)
+	s.Equal([]response.RegisteredModelTagPartialResponse{
+		{
+			Key:   "key",
+			Value: "value",
+		},
+	}, resp.RegisteredModel.Tags)
+	s.NotEmpty(resp.RegisteredModel.CreationTimestamp)
+	s.Equal(resp.RegisteredModel.CreationTimestamp, resp.RegisteredModel.LastUpdatedTimestamp)
+
+	registeredModel, err := s.RegisteredModelFixtures.GetRegisteredModelByNamespaceIDAndName(
+		context.Background(), s.DefaultNamespace.ID, "TestModel",
+	)
+	s.Require().Nil(err)
+	s.Equal("description", registeredModel.Description)
+	s.Equal(1, len(registeredModel.Tags))
+}
+
+func (s *CreateRegisteredModelTestSuite) Test_Error() {
+	_, err := s.RegisteredModelFixtures.CreateRegisteredModel(context.Background(), &models.RegisteredModel{
+		Name:        "ExistingModel",
+		NamespaceID: s.DefaultNamespace.ID,
+	})
+	s.Require().Nil(err)
+
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request request.CreateRegisteredModelRequest
+	}{
+		{
+			name:    "EmptyNameProperty",
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'name'"),
+			request: request.CreateRegisteredModelRequest{},
+		},
+		{
+			name:  "RegisteredModelAlreadyExists",
+			error: api.NewResourceAlreadyExistsError("Registered Model (name=ExistingModel) already exists"),
+			request: request.CreateRegisteredModelRequest{
+				Name: "ExistingModel",
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsCreateRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
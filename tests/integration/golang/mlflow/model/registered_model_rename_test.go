@@ -0,0 +1,134 @@
+package model
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type RenameRegisteredModelTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestRenameRegisteredModelTestSuite(t *testing.T) {
+	suite.Run(t, new(RenameRegisteredModelTestSuite))
+}
+
+func (s *RenameRegisteredModelTestSuite) Test_Ok() {
+	registeredModel, err := s.RegisteredModelFixtures.CreateRegisteredModel(
+		context.Background(), &models.RegisteredModel{
+			Name:        "TestModel",
+			NamespaceID: s.DefaultNamespace.ID,
+		},
+	)
+	s.Require().Nil(err)
+	_, err = s.RegisteredModelFixtures.CreateModelVersion(context.Background(), &models.ModelVersion{
+		Version:           1,
+		Source:            "s3://bucket/model",
+		CurrentStage:      models.ModelVersionStageNone,
+		Status:            models.ModelVersionStatusReady,
+		RegisteredModelID: registeredModel.ID,
+	})
+	s.Require().Nil(err)
+
+	resp := response.RegisteredModelResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.RenameRegisteredModelRequest{
+				Name:    "TestModel",
+				NewName: "RenamedModel",
+			},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsRenameRoute,
+		),
+	)
+	s.Equal("RenamedModel", resp.RegisteredModel.Name)
+
+	// versions follow the renamed model.
+	versionResp := response.ModelVersionResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithQuery(
+			request.GetModelVersionRequest{
+				Name:    "RenamedModel",
+				Version: "1",
+			},
+		).WithResponse(
+			&versionResp,
+		).DoRequest(
+			"%s%s", mlflow.ModelVersionsRoutePrefix, mlflow.ModelVersionsGetRoute,
+		),
+	)
+	s.Equal("RenamedModel", versionResp.ModelVersion.Name)
+	s.Equal("1", versionResp.ModelVersion.Version)
+}
+
+func (s *RenameRegisteredModelTestSuite) Test_Error() {
+	for _, name := range []string{"TestModel", "ExistingModel"} {
+		_, err := s.RegisteredModelFixtures.CreateRegisteredModel(context.Background(), &models.RegisteredModel{
+			Name:        name,
+			NamespaceID: s.DefaultNamespace.ID,
+		})
+		s.Require().Nil(err)
+	}
+
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request request.RenameRegisteredModelRequest
+	}{
+		{
+			name:  "EmptyNewNameProperty",
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'new_name'"),
+			request: request.RenameRegisteredModelRequest{
+				Name: "TestModel",
+			},
+		},
+		{
+			name:  "RegisteredModelNotFound",
+			error: api.NewResourceDoesNotExistError("Registered Model with name=UnknownModel not found"),
+			request: request.RenameRegisteredModelRequest{
+				Name:    "UnknownModel",
+				NewName: "RenamedModel",
+			},
+		},
+		{
+			name:  "NewNameAlreadyExists",
+			error: api.NewResourceAlreadyExistsError("Registered Model (name=ExistingModel) already exists"),
+			request: request.RenameRegisteredModelRequest{
+				Name:    "TestModel",
+				NewName: "ExistingModel",
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsRenameRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
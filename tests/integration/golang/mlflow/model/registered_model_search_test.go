@@ -0,0 +1,149 @@
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SearchRegisteredModelsTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSearchRegisteredModelsTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchRegisteredModelsTestSuite))
+}
+
+func (s *SearchRegisteredModelsTestSuite) Test_Ok() {
+	// 1. prepare database with test data.
+	registeredModels := []struct {
+		name string
+		team string
+	}{
+		{name: "ModelA", team: "research"},
+		{name: "ModelB", team: "platform"},
+		{name: "OtherModel", team: "research"},
+	}
+	for i, registeredModel := range registeredModels {
+		_, err := s.RegisteredModelFixtures.CreateRegisteredModel(context.Background(), &models.RegisteredModel{
+			Name:           registeredModel.name,
+			CreationTime:   int64(i),
+			LastUpdateTime: int64(i),
+			NamespaceID:    s.DefaultNamespace.ID,
+			Tags: []models.RegisteredModelTag{
+				{
+					Key:   "team",
+					Value: registeredModel.team,
+				},
+			},
+		})
+		s.Require().Nil(err)
+	}
+
+	tests := []struct {
+		name     string
+		request  request.SearchRegisteredModelsRequest
+		expected []string
+	}{
+		{
+			name:     "NoFilter",
+			request:  request.SearchRegisteredModelsRequest{},
+			expected: []string{"ModelA", "ModelB", "OtherModel"},
+		},
+		{
+			name: "FilterByNameLike",
+			request: request.SearchRegisteredModelsRequest{
+				Filter: "name LIKE 'Model%'",
+			},
+			expected: []string{"ModelA", "ModelB"},
+		},
+		{
+			name: "FilterByTag",
+			request: request.SearchRegisteredModelsRequest{
+				Filter: "tags.team = 'research'",
+			},
+			expected: []string{"ModelA", "OtherModel"},
+		},
+		{
+			name: "OrderByCreationTimestampDesc",
+			request: request.SearchRegisteredModelsRequest{
+				OrderBy: []string{"creation_timestamp DESC"},
+			},
+			expected: []string{"OtherModel", "ModelB", "ModelA"},
+		},
+		{
+			name: "MaxResults",
+			request: request.SearchRegisteredModelsRequest{
+				MaxResults: 2,
+			},
+			expected: []string{"ModelA", "ModelB"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := response.SearchRegisteredModelsResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithQuery(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsSearchRoute,
+				),
+			)
+			names := make([]string, 0, len(resp.RegisteredModels))
+			for _, registeredModel := range resp.RegisteredModels {
+				names = append(names, registeredModel.Name)
+			}
+			s.Equal(tt.expected, names)
+		})
+	}
+}
+
+func (s *SearchRegisteredModelsTestSuite) Test_Error() {
+	testData := []struct {
+		name    string
+		error   *api.ErrorResponse
+		request request.SearchRegisteredModelsRequest
+	}{
+		{
+			name:  "InvalidMaxResults",
+			error: api.NewInvalidParameterValueError("Invalid value for parameter 'max_results' supplied."),
+			request: request.SearchRegisteredModelsRequest{
+				MaxResults: 1001,
+			},
+		},
+		{
+			name:  "InvalidFilterAttribute",
+			error: api.NewInvalidParameterValueError("invalid attribute 'source'. Valid values are ['name']"),
+			request: request.SearchRegisteredModelsRequest{
+				Filter: "source = 'value'",
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithQuery(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RegisteredModelsRoutePrefix, mlflow.RegisteredModelsSearchRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
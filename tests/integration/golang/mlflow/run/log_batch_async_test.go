@@ -0,0 +1,112 @@
+package run
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type LogBatchAsyncTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestLogBatchAsyncFlushTestSuite(t *testing.T) {
+	testSuite := new(LogBatchAsyncTestSuite)
+	testSuite.Config = newMetricIngestConfig(config.MetricIngestDurabilityFlush)
+	suite.Run(t, testSuite)
+}
+
+func TestLogBatchAsyncEnqueueTestSuite(t *testing.T) {
+	testSuite := new(LogBatchAsyncTestSuite)
+	testSuite.Config = newMetricIngestConfig(config.MetricIngestDurabilityEnqueue)
+	suite.Run(t, testSuite)
+}
+
+func newMetricIngestConfig(durability string) config.Config {
+	return config.Config{
+		MetricIngest: config.MetricIngestConfig{
+			Async:         true,
+			Durability:    durability,
+			Workers:       2,
+			QueueSize:     1000,
+			QueueTimeout:  time.Second,
+			BatchSize:     100,
+			FlushInterval: 50 * time.Millisecond,
+		},
+	}
+}
+
+func (s *LogBatchAsyncTestSuite) Test_Ok() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+
+	// log metrics by several concurrent clients, so their requests are coalesced.
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			req := request.LogBatchRequest{RunID: run.ID}
+			for step := int64(i * 10); step < int64(i*10+10); step++ {
+				req.Metrics = append(req.Metrics, request.MetricPartialRequest{
+					Key:       "loss",
+					Value:     float64(step),
+					Timestamp: 1687325991 + step,
+					Step:      step,
+				})
+			}
+			resp := map[string]any{}
+			errs <- s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				req,
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+			)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		s.Require().Nil(err)
+	}
+
+	// with `enqueue` durability metrics are written after the response, so wait for them.
+	s.Eventually(func() bool {
+		latestMetric, err := s.MetricFixtures.GetLatestMetricByRunID(context.Background(), run.ID)
+		return err == nil && latestMetric.LastIter == 50
+	}, 5*time.Second, 10*time.Millisecond)
+
+	metrics, err := s.MetricFixtures.GetMetricsByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Len(metrics, 50)
+	latestMetric, err := s.MetricFixtures.GetLatestMetricByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Equal(int64(49), latestMetric.Step)
+	s.Equal(49.0, latestMetric.Value)
+	s.Equal(int64(50), latestMetric.LastIter)
+	s.Equal(int64(50), latestMetric.ValueCount)
+	s.Equal(0.0, *latestMetric.MinValue)
+	s.Equal(49.0, *latestMetric.MaxValue)
+}
@@ -0,0 +1,197 @@
+package run
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type LogInputsTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestLogInputsTestSuite(t *testing.T) {
+	suite.Run(t, new(LogInputsTestSuite))
+}
+
+func (s *LogInputsTestSuite) Test_Ok() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+
+	req := request.LogInputsRequest{
+		RunID: run.ID,
+		Datasets: []request.DatasetInputPartialRequest{
+			{
+				Tags: []request.InputTagPartialRequest{{
+					Key:   "mlflow.data.context",
+					Value: "training",
+				}},
+				Dataset: request.DatasetPartialRequest{
+					Name:       "train",
+					Digest:     "abc123",
+					SourceType: "local",
+					Source:     `{"uri": "/data/train.csv"}`,
+					Schema:     `{"mlflow_colspec": []}`,
+					Profile:    `{"num_rows": 10}`,
+				},
+			},
+			{
+				Dataset: request.DatasetPartialRequest{
+					Name:       "test",
+					Digest:     "def456",
+					SourceType: "local",
+					Source:     `{"uri": "/data/test.csv"}`,
+				},
+			},
+		},
+	}
+	// log the same inputs twice to make sure that datasets and inputs are not duplicated.
+	for i := 0; i < 2; i++ {
+		resp := map[string]any{}
+		s.Require().Nil(
+			s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				req,
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogInputsRoute,
+			),
+		)
+		s.Empty(resp)
+	}
+
+	inputs, err := s.InputFixtures.GetByRunID(context.Background(), run.ID)
+	s.Require().Nil(err)
+	s.Equal(2, len(inputs))
+
+	resp := response.GetRunResponse{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodGet,
+		).WithQuery(
+			request.GetRunRequest{RunID: run.ID},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsGetRoute,
+		),
+	)
+	s.ElementsMatch([]response.DatasetInputPartialResponse{
+		{
+			Tags: []response.InputTagPartialResponse{{
+				Key:   "mlflow.data.context",
+				Value: "training",
+			}},
+			Dataset: response.DatasetPartialResponse{
+				Name:       "train",
+				Digest:     "abc123",
+				SourceType: "local",
+				Source:     `{"uri": "/data/train.csv"}`,
+				Schema:     `{"mlflow_colspec": []}`,
+				Profile:    `{"num_rows": 10}`,
+			},
+		},
+		{
+			Dataset: response.DatasetPartialResponse{
+				Name:       "test",
+				Digest:     "def456",
+				SourceType: "local",
+				Source:     `{"uri": "/data/test.csv"}`,
+			},
+		},
+	}, resp.Run.Inputs.DatasetInputs)
+}
+
+func (s *LogInputsTestSuite) Test_Error() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name    string
+		request request.LogInputsRequest
+		error   *api.ErrorResponse
+	}{
+		{
+			name:    "MissingRunID",
+			request: request.LogInputsRequest{},
+			error:   api.NewInvalidParameterValueError("Missing value for required parameter 'run_id'"),
+		},
+		{
+			name: "MissingDatasetName",
+			request: request.LogInputsRequest{
+				RunID: run.ID,
+				Datasets: []request.DatasetInputPartialRequest{{
+					Dataset: request.DatasetPartialRequest{
+						Digest:     "abc123",
+						SourceType: "local",
+						Source:     "source",
+					},
+				}},
+			},
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'dataset.name'"),
+		},
+		{
+			name: "MissingDatasetSource",
+			request: request.LogInputsRequest{
+				RunID: run.ID,
+				Datasets: []request.DatasetInputPartialRequest{{
+					Dataset: request.DatasetPartialRequest{
+						Name:       "train",
+						Digest:     "abc123",
+						SourceType: "local",
+					},
+				}},
+			},
+			error: api.NewInvalidParameterValueError("Missing value for required parameter 'dataset.source'"),
+		},
+		{
+			name: "NotFoundRun",
+			request: request.LogInputsRequest{
+				RunID: "not-existing-run",
+			},
+			error: api.NewResourceDoesNotExistError("Run 'not-existing-run' not found"),
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(
+				s.MlflowClient().WithMethod(
+					http.MethodPost,
+				).WithRequest(
+					tt.request,
+				).WithResponse(
+					&resp,
+				).DoRequest(
+					"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogInputsRoute,
+				),
+			)
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package run
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/common"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SearchFilterTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSearchFilterTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchFilterTestSuite))
+}
+
+func (s *SearchFilterTestSuite) createRun(id string, startTime int64, metric float64, tags map[string]string) {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:         id,
+		Name:       id,
+		Status:     models.StatusRunning,
+		SourceType: "JOB",
+		StartTime: sql.NullInt64{
+			Int64: startTime,
+			Valid: true,
+		},
+		ExperimentID:   *s.DefaultExperiment.ID,
+		ArtifactURI:    "artifact_uri",
+		LifecycleStage: models.LifecycleStageActive,
+	})
+	s.Require().Nil(err)
+	_, err = s.MetricFixtures.CreateLatestMetric(context.Background(), &models.LatestMetric{
+		Key:       "accuracy",
+		Value:     metric,
+		Timestamp: 1234567890,
+		Step:      1,
+		RunID:     run.ID,
+	})
+	s.Require().Nil(err)
+	for key, value := range tags {
+		_, err = s.TagFixtures.CreateTag(context.Background(), &models.Tag{
+			Key:   key,
+			Value: value,
+			RunID: run.ID,
+		})
+		s.Require().Nil(err)
+	}
+}
+
+func (s *SearchFilterTestSuite) Test_Ok() {
+	s.createRun("id1", 100, 0.95, map[string]string{"stage": "dev"})
+	s.createRun("id2", 200, 0.5, map[string]string{"stage": "prod"})
+	s.createRun("id3", 300, 0.7, nil)
+	_, err := s.ParamFixtures.CreateParam(context.Background(), &models.Param{
+		Key:      "lr",
+		ValueStr: common.GetPointer("0.1"),
+		RunID:    "id1",
+	})
+	s.Require().Nil(err)
+	_, err = s.ParamFixtures.CreateParam(context.Background(), &models.Param{
+		Key:      "batch_size",
+		ValueStr: common.GetPointer("32"),
+		ValueInt: common.GetPointer(int64(32)),
+		RunID:    "id2",
+	})
+	s.Require().Nil(err)
+	dataset, err := s.InputFixtures.CreateDataset(context.Background(), &models.Dataset{
+		Name:         "train",
+		Digest:       "abc123",
+		SourceType:   "local",
+		Source:       "source",
+		ExperimentID: *s.DefaultExperiment.ID,
+	})
+	s.Require().Nil(err)
+	_, err = s.InputFixtures.CreateInput(context.Background(), &models.Input{
+		DatasetID: dataset.ID,
+		RunID:     "id1",
+		Tags: []models.InputTag{{
+			Key:   "mlflow.data.context",
+			Value: "training",
+		}},
+	})
+	s.Require().Nil(err)
+	_, err = s.InputFixtures.CreateInput(context.Background(), &models.Input{
+		DatasetID: dataset.ID,
+		RunID:     "id3",
+	})
+	s.Require().Nil(err)
+
+	tests := []struct {
+		name   string
+		filter string
+		runIDs []string
+	}{
+		{
+			name:   "OrExpression",
+			filter: `metrics.accuracy > 0.9 OR tags.stage = 'prod'`,
+			runIDs: []string{"id1", "id2"},
+		},
+		{
+			name:   "OrExpressionWithLowercaseKeyword",
+			filter: `metrics.accuracy > 0.9 or tags.stage = 'prod'`,
+			runIDs: []string{"id1", "id2"},
+		},
+		{
+			name:   "AndTakesPrecedenceOverOr",
+			filter: `metrics.accuracy > 0.9 OR tags.stage = 'prod' AND attributes.start_time > 250`,
+			runIDs: []string{"id1"},
+		},
+		{
+			name:   "ParenthesesChangePrecedence",
+			filter: `(metrics.accuracy > 0.9 OR tags.stage = 'prod') AND attributes.start_time > 150`,
+			runIDs: []string{"id2"},
+		},
+		{
+			name:   "NotExpression",
+			filter: `NOT tags.stage = 'prod'`,
+			runIDs: []string{"id1", "id3"},
+		},
+		{
+			name:   "NotExpressionWithParentheses",
+			filter: `NOT (metrics.accuracy < 0.6 OR metrics.accuracy > 0.9)`,
+			runIDs: []string{"id3"},
+		},
+		{
+			name:   "TagIsNull",
+			filter: `tags.stage IS NULL`,
+			runIDs: []string{"id3"},
+		},
+		{
+			name:   "TagIsNotNull",
+			filter: `tags.stage IS NOT NULL AND tags.stage != 'dev'`,
+			runIDs: []string{"id2"},
+		},
+		{
+			name:   "ParamIsNull",
+			filter: `params.lr IS NULL`,
+			runIDs: []string{"id2", "id3"},
+		},
+		{
+			name:   "ParamNumericComparison",
+			filter: `params.batch_size >= 16`,
+			runIDs: []string{"id2"},
+		},
+		{
+			name:   "QuotedKeys",
+			filter: "tags.`stage` = 'dev' OR tags.\"stage\" = 'prod'",
+			runIDs: []string{"id1", "id2"},
+		},
+		{
+			name:   "RunIDInList",
+			filter: `attributes.run_id IN ('id1', 'id3') AND NOT attributes.run_id = 'id1'`,
+			runIDs: []string{"id3"},
+		},
+		{
+			name:   "DatasetName",
+			filter: `datasets.name = 'train'`,
+			runIDs: []string{"id1", "id3"},
+		},
+		{
+			name:   "DatasetDigestInList",
+			filter: `datasets.digest IN ('abc123', 'def456') AND tags.stage = 'dev'`,
+			runIDs: []string{"id1"},
+		},
+		{
+			name:   "DatasetContext",
+			filter: `datasets.context ILIKE 'TRAIN%'`,
+			runIDs: []string{"id1"},
+		},
+		{
+			name:   "NotDatasetName",
+			filter: `NOT datasets.name LIKE 'tr%'`,
+			runIDs: []string{"id2"},
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := response.SearchRunsResponse{}
+			s.Require().Nil(s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				request.SearchRunsRequest{
+					Filter:        tt.filter,
+					ExperimentIDs: []string{fmt.Sprintf("%d", *s.DefaultExperiment.ID)},
+				},
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute,
+			))
+
+			runIDs := make([]string, len(resp.Runs))
+			for i, run := range resp.Runs {
+				runIDs[i] = run.Info.ID
+			}
+			s.ElementsMatch(tt.runIDs, runIDs)
+		})
+	}
+}
+
+func (s *SearchFilterTestSuite) Test_Error() {
+	tests := []struct {
+		name   string
+		filter string
+		error  *api.ErrorResponse
+	}{
+		{
+			name:   "UnbalancedParentheses",
+			filter: `(metrics.accuracy > 0.9 OR tags.stage = 'prod'`,
+			error: api.NewInvalidParameterValueError(
+				`syntax error at position 46 in filter "(metrics.accuracy > 0.9 OR tags.stage = 'prod'": ` +
+					`expected ')', got 'end of filter'`,
+			),
+		},
+		{
+			name:   "MissingValue",
+			filter: `metrics.accuracy > OR tags.stage = 'prod'`,
+			error: api.NewInvalidParameterValueError(
+				`syntax error at position 19 in filter "metrics.accuracy > OR tags.stage = 'prod'": ` +
+					`expected value, got 'OR'`,
+			),
+		},
+		{
+			name:   "UnterminatedString",
+			filter: `tags.stage = 'prod`,
+			error: api.NewInvalidParameterValueError(
+				`syntax error at position 13 in filter "tags.stage = 'prod": unterminated quoted string`,
+			),
+		},
+		{
+			name:   "IsNullOnMetric",
+			filter: `metrics.accuracy IS NULL`,
+			error:  api.NewInvalidParameterValueError("invalid metric comparison operator 'IS NULL'"),
+		},
+		{
+			name:   "InvalidDatasetKey",
+			filter: `datasets.size = '1'`,
+			error: api.NewInvalidParameterValueError(
+				"invalid dataset attribute key 'size'. Valid values are ['name', 'digest', 'context']",
+			),
+		},
+		{
+			name:   "InvalidDatasetOperator",
+			filter: `datasets.name > 'train'`,
+			error:  api.NewInvalidParameterValueError("invalid dataset comparison operator '>'"),
+		},
+	}
+
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				request.SearchRunsRequest{
+					Filter:        tt.filter,
+					ExperimentIDs: []string{fmt.Sprintf("%d", *s.DefaultExperiment.ID)},
+				},
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute,
+			))
+			s.Equal(tt.error.Error(), resp.Error())
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package run
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/response"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/api"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type SearchMetricAggregationTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestSearchMetricAggregationTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchMetricAggregationTestSuite))
+}
+
+func (s *SearchMetricAggregationTestSuite) SetupTest() {
+	s.BaseTestSuite.SetupTest()
+
+	// every run logs `val_loss` in two batches, so the summary is updated with the existing values.
+	for i, batches := range map[string][][]any{
+		"id1": {{1.0, 0.2}, {0.6, "NaN"}},
+		"id2": {{0.8, 0.5}, {0.4}},
+		"id3": {{2.0}, {1.5}},
+	} {
+		run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+			ID:             i,
+			Name:           i,
+			Status:         models.StatusRunning,
+			SourceType:     "JOB",
+			StartTime:      sql.NullInt64{Int64: 100, Valid: true},
+			ExperimentID:   *s.DefaultExperiment.ID,
+			ArtifactURI:    "artifact_uri",
+			LifecycleStage: models.LifecycleStageActive,
+		})
+		s.Require().Nil(err)
+
+		step := int64(0)
+		for _, values := range batches {
+			req := request.LogBatchRequest{RunID: run.ID}
+			for _, value := range values {
+				req.Metrics = append(req.Metrics, request.MetricPartialRequest{
+					Key:       "val_loss",
+					Value:     value,
+					Timestamp: 1687325991 + step,
+					Step:      step,
+				})
+				step++
+			}
+			resp := map[string]any{}
+			s.Require().Nil(s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				req,
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+			))
+		}
+	}
+}
+
+func (s *SearchMetricAggregationTestSuite) Test_Summary() {
+	metric, err := s.MetricFixtures.GetLatestMetricByRunID(context.Background(), "id1")
+	s.Require().Nil(err)
+	s.True(metric.IsNan)
+	s.Equal(0.2, *metric.MinValue)
+	s.Equal(1.0, *metric.MaxValue)
+	s.InDelta(1.8, metric.SumValue, 1e-9)
+	s.Equal(int64(3), metric.ValueCount)
+
+	// metrics, which already exist, are skipped, so they don't change the summary.
+	resp := map[string]any{}
+	s.Require().Nil(s.MlflowClient().WithMethod(
+		http.MethodPost,
+	).WithRequest(
+		request.LogBatchRequest{
+			RunID: "id1",
+			Metrics: []request.MetricPartialRequest{
+				{Key: "val_loss", Value: 1.0, Timestamp: 1687325991, Step: 0},
+				{Key: "val_loss", Value: 0.2, Timestamp: 1687325992, Step: 1},
+			},
+		},
+	).WithResponse(
+		&resp,
+	).DoRequest(
+		"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogBatchRoute,
+	))
+	metric, err = s.MetricFixtures.GetLatestMetricByRunID(context.Background(), "id1")
+	s.Require().Nil(err)
+	s.Equal(0.2, *metric.MinValue)
+	s.Equal(1.0, *metric.MaxValue)
+	s.InDelta(1.8, metric.SumValue, 1e-9)
+	s.Equal(int64(3), metric.ValueCount)
+}
+
+func (s *SearchMetricAggregationTestSuite) Test_Error() {
+	tests := []struct {
+		name    string
+		filter  string
+		orderBy []string
+		error   string
+	}{
+		{
+			name:   "UnknownFunction",
+			filter: `median(metrics.val_loss) < 1`,
+			error:  "invalid metric function 'median'",
+		},
+		{
+			name:   "FunctionOfParam",
+			filter: `min(params.lr) < 1`,
+			error:  "function 'min' can be applied only to metrics",
+		},
+		{
+			name:    "AtStepWithoutStep",
+			orderBy: []string{"at_step(metrics.val_loss)"},
+			error:   "function 'at_step' takes exactly one step argument",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := api.ErrorResponse{}
+			s.Require().Nil(s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				request.SearchRunsRequest{
+					Filter:        tt.filter,
+					OrderBy:       tt.orderBy,
+					ExperimentIDs: []string{fmt.Sprintf("%d", *s.DefaultExperiment.ID)},
+				},
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute,
+			))
+			s.Equal(api.ErrorCodeInvalidParameterValue, string(resp.ErrorCode))
+			s.Contains(resp.Message, tt.error)
+		})
+	}
+}
+
+func (s *SearchMetricAggregationTestSuite) Test_Ok() {
+	tests := []struct {
+		name    string
+		filter  string
+		orderBy []string
+		runIDs  []string
+	}{
+		{
+			name:   "FilterByMin",
+			filter: `min(metrics.val_loss) < 0.3`,
+			runIDs: []string{"id1"},
+		},
+		{
+			name:   "FilterByMax",
+			filter: `max(metrics.val_loss) <= 0.8`,
+			runIDs: []string{"id2"},
+		},
+		{
+			name:   "FilterByMeanSkipsNaN",
+			filter: `mean(metrics.val_loss) < 0.59`,
+			runIDs: []string{"id2"},
+		},
+		{
+			name:   "FilterByValueAtStep",
+			filter: `at_step(metrics.val_loss, 1) >= 0.5`,
+			runIDs: []string{"id2", "id3"},
+		},
+		{
+			name:   "FilterByLastSkipsNaN",
+			filter: `LAST(metrics.val_loss) < 0.5`,
+			runIDs: []string{"id2"},
+		},
+		{
+			name:   "KeyWithSuffixIsNotAggregation",
+			filter: "metrics.val_loss.min < 10",
+			runIDs: []string{},
+		},
+		{
+			name:    "OrderByMin",
+			orderBy: []string{"min(metrics.val_loss) ASC"},
+			runIDs:  []string{"id1", "id2", "id3"},
+		},
+		{
+			name:    "OrderByMeanDesc",
+			orderBy: []string{"mean(metrics.`val_loss`) DESC"},
+			runIDs:  []string{"id3", "id1", "id2"},
+		},
+		{
+			name:    "OrderByValueAtStep",
+			filter:  `max(metrics.val_loss) < 1.5`,
+			orderBy: []string{"at_step(metrics.val_loss, 0)"},
+			runIDs:  []string{"id2", "id1"},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.name, func() {
+			resp := response.SearchRunsResponse{}
+			s.Require().Nil(s.MlflowClient().WithMethod(
+				http.MethodPost,
+			).WithRequest(
+				request.SearchRunsRequest{
+					Filter:        tt.filter,
+					OrderBy:       tt.orderBy,
+					ExperimentIDs: []string{fmt.Sprintf("%d", *s.DefaultExperiment.ID)},
+				},
+			).WithResponse(
+				&resp,
+			).DoRequest(
+				"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsSearchRoute,
+			))
+
+			runIDs := make([]string, len(resp.Runs))
+			for i, run := range resp.Runs {
+				runIDs[i] = run.Info.ID
+			}
+			if tt.orderBy != nil {
+				s.Equal(tt.runIDs, runIDs)
+			} else {
+				s.ElementsMatch(tt.runIDs, runIDs)
+			}
+		})
+	}
+}
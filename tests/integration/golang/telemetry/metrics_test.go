@@ -0,0 +1,125 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/api/request"
+	"github.com/G-Research/fasttrackml/pkg/api/mlflow/dao/models"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+type MetricsTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestMetricsTestSuite(t *testing.T) {
+	suite.Run(t, new(MetricsTestSuite))
+}
+
+func (s *MetricsTestSuite) Test_Ok() {
+	run, err := s.RunFixtures.CreateRun(context.Background(), &models.Run{
+		ID:             strings.ReplaceAll(uuid.New().String(), "-", ""),
+		ExperimentID:   *s.DefaultExperiment.ID,
+		SourceType:     "JOB",
+		LifecycleStage: models.LifecycleStageActive,
+		Status:         models.StatusRunning,
+	})
+	s.Require().Nil(err)
+
+	resp := map[string]any{}
+	s.Require().Nil(
+		s.MlflowClient().WithMethod(
+			http.MethodPost,
+		).WithRequest(
+			request.LogMetricRequest{RunID: run.ID, Key: "loss", Value: 0.5, Timestamp: 1687325991},
+		).WithResponse(
+			&resp,
+		).DoRequest(
+			"%s%s", mlflow.RunsRoutePrefix, mlflow.RunsLogMetricRoute,
+		),
+	)
+	s.Require().Nil(
+		s.AIMClient().WithResponse(
+			&resp,
+		).DoRequest(
+			"/runs/%s/info", run.ID,
+		),
+	)
+
+	metrics := bytes.Buffer{}
+	client := s.ServerClient()
+	s.Require().Nil(
+		client.WithResponseType(
+			helpers.ResponseTypeBuffer,
+		).WithResponse(
+			&metrics,
+		).DoRequest(
+			"/metrics",
+		),
+	)
+	s.Equal(http.StatusOK, client.GetStatusCode())
+
+	// requests are labeled by route patterns, not by actual paths.
+	s.Contains(
+		metrics.String(),
+		`fasttrackml_http_requests_total{api="mlflow",method="POST",route="/api/2.0/mlflow/runs/log-metric",status="200"}`,
+	)
+	s.Contains(
+		metrics.String(),
+		`fasttrackml_http_requests_total{api="aim",method="GET",route="/aim/api/runs/:id/info/",status="200"}`,
+	)
+	s.Contains(metrics.String(), `fasttrackml_http_request_duration_seconds_bucket{api="mlflow"`)
+	s.Contains(metrics.String(), "fasttrackml_metrics_points_ingested_total")
+	s.Contains(metrics.String(), `fasttrackml_namespace_cache_requests_total{result="hit"}`)
+	s.Contains(metrics.String(), "fasttrackml_database_slow_queries_total")
+	s.Contains(metrics.String(), "fasttrackml_artifacts_bytes_served_total")
+	s.Contains(metrics.String(), "go_sql_max_open_connections{db_name=")
+}
+
+type MetricsListenAddressTestSuite struct {
+	helpers.BaseTestSuite
+}
+
+func TestMetricsListenAddressTestSuite(t *testing.T) {
+	testSuite := new(MetricsListenAddressTestSuite)
+	testSuite.Config = config.Config{
+		MetricsListenAddress: "localhost:15090",
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *MetricsListenAddressTestSuite) Test_Ok() {
+	resp, err := http.Get(fmt.Sprintf("http://%s/metrics", s.Config.MetricsListenAddress))
+	s.Require().Nil(err)
+	//nolint:errcheck
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	s.Require().Nil(err)
+	s.Equal(http.StatusOK, resp.StatusCode)
+	s.Contains(string(body), "fasttrackml_http_requests_total")
+
+	// metrics aren't exposed on the main listen address.
+	metrics := bytes.Buffer{}
+	client := s.ServerClient()
+	s.Require().Nil(
+		client.WithResponseType(
+			helpers.ResponseTypeBuffer,
+		).WithResponse(
+			&metrics,
+		).DoRequest(
+			"/metrics",
+		),
+	)
+	s.NotContains(metrics.String(), "fasttrackml_http_requests_total")
+}
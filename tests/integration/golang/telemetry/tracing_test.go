@@ -0,0 +1,136 @@
+package telemetry
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/G-Research/fasttrackml/pkg/api/aim/api/request"
+	"github.com/G-Research/fasttrackml/pkg/common/config"
+	"github.com/G-Research/fasttrackml/tests/integration/golang/helpers"
+)
+
+const (
+	traceID      = "4bf92f3577b34da6a3ce929d0e0e4736"
+	parentSpanID = "00f067aa0ba902b7"
+)
+
+// collector is a fake OTLP/HTTP collector, which keeps all the received spans.
+type collector struct {
+	sync.Mutex
+	spans []*tracepb.Span
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	req := coltracepb.ExportTraceServiceRequest{}
+	if err := proto.Unmarshal(body, &req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	for _, resourceSpans := range req.ResourceSpans {
+		for _, scopeSpans := range resourceSpans.ScopeSpans {
+			c.spans = append(c.spans, scopeSpans.Spans...)
+		}
+	}
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.WriteHeader(http.StatusOK)
+}
+
+// getSpans returns received spans by their names.
+func (c *collector) getSpans() map[string]*tracepb.Span {
+	c.Lock()
+	defer c.Unlock()
+	spans := map[string]*tracepb.Span{}
+	for _, span := range c.spans {
+		spans[span.Name] = span
+	}
+	return spans
+}
+
+type TracingTestSuite struct {
+	helpers.BaseTestSuite
+	collector *collector
+}
+
+func TestTracingTestSuite(t *testing.T) {
+	// export spans right away instead of the default 5 seconds delay.
+	t.Setenv("OTEL_BSP_SCHEDULE_DELAY", "10")
+
+	testSuite := &TracingTestSuite{collector: &collector{}}
+	server := httptest.NewServer(testSuite.collector)
+	defer server.Close()
+
+	testSuite.Config = config.Config{
+		Tracing: config.TracingConfig{
+			Enabled:     true,
+			Endpoint:    strings.TrimPrefix(server.URL, "http://"),
+			Insecure:    true,
+			SampleRatio: 1,
+			ServiceName: "fasttrackml",
+		},
+	}
+	suite.Run(t, testSuite)
+}
+
+func (s *TracingTestSuite) Test_Ok() {
+	resp := new(bytes.Buffer)
+	client := s.AIMClient()
+	s.Require().Nil(
+		client.WithHeaders(
+			map[string]string{"traceparent": "00-" + traceID + "-" + parentSpanID + "-01"},
+		).WithResponseType(
+			helpers.ResponseTypeBuffer,
+		).WithQuery(
+			request.SearchRunsRequest{Query: "run.name == 'test'"},
+		).WithResponse(
+			resp,
+		).DoRequest(
+			"/runs/search/run",
+		),
+	)
+	s.Equal(http.StatusOK, client.GetStatusCode())
+
+	var spans map[string]*tracepb.Span
+	s.Require().Eventually(func() bool {
+		spans = s.collector.getSpans()
+		return spans["GET /aim/api/runs/search/run/"] != nil &&
+			spans["aim.RunService.SearchRuns"] != nil &&
+			spans["query.QueryParser.Parse"] != nil &&
+			spans["gorm.query"] != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// request span continues the incoming trace, other spans are nested into it.
+	requestSpan := spans["GET /aim/api/runs/search/run/"]
+	s.Equal(traceID, hex.EncodeToString(requestSpan.TraceId))
+	s.Equal(parentSpanID, hex.EncodeToString(requestSpan.ParentSpanId))
+	s.Equal(tracepb.Span_SPAN_KIND_SERVER, requestSpan.Kind)
+
+	serviceSpan := spans["aim.RunService.SearchRuns"]
+	s.Equal(traceID, hex.EncodeToString(serviceSpan.TraceId))
+	s.Equal(requestSpan.SpanId, serviceSpan.ParentSpanId)
+
+	parseSpan := spans["query.QueryParser.Parse"]
+	s.Equal(serviceSpan.SpanId, parseSpan.ParentSpanId)
+
+	querySpan := spans["gorm.query"]
+	s.Equal(traceID, hex.EncodeToString(querySpan.TraceId))
+	s.Equal(serviceSpan.SpanId, querySpan.ParentSpanId)
+}